@@ -15,6 +15,7 @@ package cassandraaccessor
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -25,6 +26,15 @@ import (
 type CassandraAccessor struct {
 	client   cc.CassandraClusterInterface
 	keyspaceMetadata cc.KeyspaceMetadataInterface
+	keyspace string
+}
+
+// TokenRange is a range (Start, End] of partition key tokens, which is how
+// Cassandra assigns ranges of the token ring to nodes. A nil Start or End
+// leaves that side of the range unbounded.
+type TokenRange struct {
+	Start *int64
+	End   *int64
 }
 
 var GetOrCreateClient = cc.GetOrCreateCassandraClusterClient
@@ -55,6 +65,7 @@ func NewCassandraAccessor(sourceProfile profiles.SourceProfile) (*CassandraAcces
 	accessor := &CassandraAccessor{
 		client:   client,
 		keyspaceMetadata: keyspaceMD,
+		keyspace: cfg.Keyspace,
 	}
 
 	return accessor, keyspaceMD, nil
@@ -65,3 +76,111 @@ func (acc *CassandraAccessor) Close() {
 		acc.client.Close()
 	}
 }
+
+// SplitTokenRanges splits the token ring of the Murmur3Partitioner, which
+// spans all int64 values, into n contiguous token ranges of equal size.
+func SplitTokenRanges(n int) []TokenRange {
+	if n < 1 {
+		n = 1
+	}
+	// Compute in uint64, where the smallest token math.MinInt64 is 1 << 63,
+	// to avoid overflow.
+	const minToken = uint64(1) << 63
+	step := math.MaxUint64 / uint64(n)
+	ranges := make([]TokenRange, 0, n)
+	var start *int64
+	for i := 1; i < n; i++ {
+		end := int64(minToken + uint64(i)*step)
+		ranges = append(ranges, TokenRange{Start: start, End: &end})
+		start = &end
+	}
+	return append(ranges, TokenRange{Start: start})
+}
+
+// CountRows returns an estimate of the number of rows in a table of the
+// keyspace, which is used to report progress. Counting the rows would scan
+// the whole table, so it reads system.size_estimates instead. The estimates
+// cover the token ranges of the node that runs the query and are scaled up to
+// the whole token ring. They count partitions, so tables with several rows
+// per partition have more rows than estimated, and they are 0 until the node
+// first computes them.
+func (acc *CassandraAccessor) CountRows(table string) (int64, error) {
+	iter := acc.client.Query("SELECT range_start, range_end, partitions_count FROM system.size_estimates WHERE keyspace_name = ? AND table_name = ?", acc.keyspace, table)
+	var partitions int64
+	var span float64 // Part of the token ring covered by the estimates.
+	for {
+		row := make(map[string]interface{})
+		if !iter.MapScan(row) {
+			break
+		}
+		start, err1 := strconv.ParseInt(fmt.Sprint(row["range_start"]), 10, 64)
+		end, err2 := strconv.ParseInt(fmt.Sprint(row["range_end"]), 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		count, _ := row["partitions_count"].(int64)
+		partitions += count
+		// The subtraction wraps around for the range that wraps around the ring.
+		span += float64(uint64(end)-uint64(start)) / math.Exp2(64)
+	}
+	if err := iter.Close(); err != nil {
+		return 0, fmt.Errorf("failed to estimate the rows of table '%s': %w", table, err)
+	}
+	if span == 0 {
+		return 0, nil
+	}
+	return int64(float64(partitions) / span), nil
+}
+
+// ScanTokenRange reads the given columns of the rows of a table whose
+// partition key token falls in tr, and calls f with each row keyed by column
+// name. partitionKeys are the names of the partition key columns of the table.
+func (acc *CassandraAccessor) ScanTokenRange(table string, cols []string, partitionKeys []string, tr TokenRange, f func(row map[string]interface{})) error {
+	stmt, args := tokenRangeQuery(acc.keyspace, table, cols, partitionKeys, tr)
+	iter := acc.client.Query(stmt, args...)
+	for {
+		row := make(map[string]interface{})
+		if !iter.MapScan(row) {
+			break
+		}
+		f(row)
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("failed to read table '%s': %w", table, err)
+	}
+	return nil
+}
+
+// tokenRangeQuery returns the CQL statement and its arguments that select
+// the rows of a table in token range tr.
+func tokenRangeQuery(keyspace, table string, cols []string, partitionKeys []string, tr TokenRange) (string, []interface{}) {
+	quotedCols := make([]string, len(cols))
+	for i, c := range cols {
+		quotedCols[i] = quoteIdentifier(c)
+	}
+	quotedKeys := make([]string, len(partitionKeys))
+	for i, k := range partitionKeys {
+		quotedKeys[i] = quoteIdentifier(k)
+	}
+	stmt := fmt.Sprintf("SELECT %s FROM %s.%s", strings.Join(quotedCols, ", "), quoteIdentifier(keyspace), quoteIdentifier(table))
+	token := fmt.Sprintf("token(%s)", strings.Join(quotedKeys, ", "))
+	var conds []string
+	var args []interface{}
+	if tr.Start != nil {
+		conds = append(conds, token+" > ?")
+		args = append(args, *tr.Start)
+	}
+	if tr.End != nil {
+		conds = append(conds, token+" <= ?")
+		args = append(args, *tr.End)
+	}
+	if len(conds) > 0 {
+		stmt += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return stmt, args
+}
+
+// quoteIdentifier quotes a CQL identifier so that it keeps its case.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -121,4 +121,90 @@ func TestCassandraAccessor_Close(t *testing.T) {
 			accessor.Close()
 		})
 	})
-}
\ No newline at end of file
+}
+func TestSplitTokenRanges(t *testing.T) {
+	assert.Equal(t, []TokenRange{{}}, SplitTokenRanges(1))
+
+	ranges := SplitTokenRanges(4)
+	assert.Equal(t, 4, len(ranges))
+	assert.Nil(t, ranges[0].Start)
+	assert.Nil(t, ranges[3].End)
+	for i := 1; i < len(ranges); i++ {
+		assert.Equal(t, *ranges[i-1].End, *ranges[i].Start)
+	}
+	// Each range spans (2^64 - 1) / 4 tokens.
+	assert.Equal(t, int64(-1<<62-1), *ranges[0].End)
+	assert.Equal(t, int64(-2), *ranges[1].End)
+	assert.Equal(t, int64(1<<62-3), *ranges[2].End)
+}
+
+func TestTokenRangeQuery(t *testing.T) {
+	start, end := int64(-10), int64(20)
+	stmt, args := tokenRangeQuery("ks", "Orders", []string{"id", "Item"}, []string{"id"}, TokenRange{})
+	assert.Equal(t, `SELECT "id", "Item" FROM "ks"."Orders"`, stmt)
+	assert.Nil(t, args)
+
+	stmt, args = tokenRangeQuery("ks", "orders", []string{"a", "b", "c"}, []string{"a", "b"}, TokenRange{Start: &start, End: &end})
+	assert.Equal(t, `SELECT "a", "b", "c" FROM "ks"."orders" WHERE token("a", "b") > ? AND token("a", "b") <= ?`, stmt)
+	assert.Equal(t, []interface{}{int64(-10), int64(20)}, args)
+
+	stmt, args = tokenRangeQuery("ks", "orders", []string{"a"}, []string{"a"}, TokenRange{End: &end})
+	assert.Equal(t, `SELECT "a" FROM "ks"."orders" WHERE token("a") <= ?`, stmt)
+	assert.Equal(t, []interface{}{int64(20)}, args)
+}
+
+func TestCassandraAccessor_ScanTokenRange(t *testing.T) {
+	start := int64(0)
+	mockClient := new(cc.MockCassandraCluster)
+	mockClient.On("Query", `SELECT "id", "name" FROM "ks"."users" WHERE token("id") > ?`, []interface{}{int64(0)}).Return(&cc.MockRowIterator{
+		Rows: []map[string]interface{}{{"id": 1, "name": "a"}, {"id": 2, "name": "b"}},
+	}).Once()
+	accessor := &CassandraAccessor{client: mockClient, keyspace: "ks"}
+
+	var rows []map[string]interface{}
+	err := accessor.ScanTokenRange("users", []string{"id", "name"}, []string{"id"}, TokenRange{Start: &start}, func(row map[string]interface{}) {
+		rows = append(rows, row)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"id": 1, "name": "a"}, {"id": 2, "name": "b"}}, rows)
+	mockClient.AssertExpectations(t)
+
+	mockClient.On("Query", `SELECT "id" FROM "ks"."users"`, []interface{}(nil)).Return(&cc.MockRowIterator{Err: errors.New("timeout")}).Once()
+	err = accessor.ScanTokenRange("users", []string{"id"}, []string{"id"}, TokenRange{}, func(row map[string]interface{}) {})
+	assert.EqualError(t, err, "failed to read table 'users': timeout")
+}
+
+func TestCassandraAccessor_CountRows(t *testing.T) {
+	q := "SELECT range_start, range_end, partitions_count FROM system.size_estimates WHERE keyspace_name = ? AND table_name = ?"
+	mockClient := new(cc.MockCassandraCluster)
+	// The node holds a quarter of the token ring, in two ranges.
+	mockClient.On("Query", q, []interface{}{"ks", "users"}).Return(&cc.MockRowIterator{
+		Rows: []map[string]interface{}{
+			{"range_start": "-9223372036854775808", "range_end": "-6917529027641081856", "partitions_count": int64(30)},
+			{"range_start": "4611686018427387904", "range_end": "6917529027641081856", "partitions_count": int64(12)},
+		},
+	}).Once()
+	// The range that wraps around the ring.
+	mockClient.On("Query", q, []interface{}{"ks", "orders"}).Return(&cc.MockRowIterator{
+		Rows: []map[string]interface{}{
+			{"range_start": "4611686018427387904", "range_end": "-4611686018427387904", "partitions_count": int64(50)},
+		},
+	}).Once()
+	mockClient.On("Query", q, []interface{}{"ks", "new_table"}).Return(&cc.MockRowIterator{}).Once()
+	mockClient.On("Query", q, []interface{}{"ks", "broken"}).Return(&cc.MockRowIterator{Err: errors.New("timeout")}).Once()
+	accessor := &CassandraAccessor{client: mockClient, keyspace: "ks"}
+
+	count, err := accessor.CountRows("users")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(168), count)
+	count, err = accessor.CountRows("orders")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), count)
+	count, err = accessor.CountRows("new_table")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	_, err = accessor.CountRows("broken")
+	assert.EqualError(t, err, "failed to estimate the rows of table 'broken': timeout")
+	mockClient.AssertExpectations(t)
+}
@@ -15,20 +15,34 @@ package cassandraclient
 
 import (
 	"fmt"
+	"reflect"
+
 	"github.com/gocql/gocql"
 )
 
 type GocqlSessionInterface interface {
 	KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMetadata, error)
+	Query(stmt string, values ...interface{}) RowIterator
 	Close()
 }
 
+// RowIterator iterates over the rows returned by a CQL query, fetching
+// further pages from the cluster as needed.
+type RowIterator interface {
+	// MapScan fills m with the next row, keyed by column name. It returns
+	// false when there are no more rows or an error occurred.
+	MapScan(m map[string]interface{}) bool
+	// Close releases the iterator and returns any error hit while reading.
+	Close() error
+}
+
 type KeyspaceMetadataInterface interface {
 	Tables() map[string]*gocql.TableMetadata
 }
 
 type CassandraClusterInterface interface {
 	KeyspaceMetadata(keyspace string) (KeyspaceMetadataInterface, error)
+	Query(stmt string, values ...interface{}) RowIterator
 	Close() 
 }
 
@@ -51,6 +65,46 @@ func (gs *GocqlSessionImpl) KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMe
 	return ks, nil
 }
 
+func (gs *GocqlSessionImpl) Query(stmt string, values ...interface{}) RowIterator {
+	return &gocqlRowIterator{iter: gs.session.Query(stmt, values...).Iter()}
+}
+
+// gocqlRowIterator implements RowIterator on top of gocql.Iter. Unlike
+// gocql.Iter.MapScan, which returns the zero value of a column's type for
+// NULL, it returns nil for NULL values.
+type gocqlRowIterator struct {
+	iter *gocql.Iter
+}
+
+func (it *gocqlRowIterator) MapScan(m map[string]interface{}) bool {
+	rowData, err := it.iter.RowData()
+	if err != nil {
+		return false
+	}
+	// rowData.Values holds a pointer to a value of each column's Go type.
+	// Scanning into a pointer to such a pointer leaves it nil for NULL.
+	dests := make([]interface{}, len(rowData.Values))
+	for i, v := range rowData.Values {
+		dests[i] = reflect.New(reflect.TypeOf(v)).Interface()
+	}
+	if !it.iter.Scan(dests...) {
+		return false
+	}
+	for i, col := range rowData.Columns {
+		p := reflect.ValueOf(dests[i]).Elem()
+		if p.IsNil() {
+			m[col] = nil
+		} else {
+			m[col] = p.Elem().Interface()
+		}
+	}
+	return true
+}
+
+func (it *gocqlRowIterator) Close() error {
+	return it.iter.Close()
+}
+
 func (gs *GocqlSessionImpl) Close() {
 	if gs.session != nil {
 		gs.session.Close()
@@ -80,6 +134,10 @@ func (c *CassandraClusterImpl) KeyspaceMetadata(keyspace string) (KeyspaceMetada
 	return &CassandraKeyspaceMetadataImpl{keyspaceMetadata: ks}, nil
 }
 
+func (c *CassandraClusterImpl) Query(stmt string, values ...interface{}) RowIterator {
+	return c.session.Query(stmt, values...)
+}
+
 func (c *CassandraClusterImpl) Close() {
 	c.session.Close()
 }
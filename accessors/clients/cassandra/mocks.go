@@ -30,6 +30,11 @@ func (m *MockGocqlSession) KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMet
 	return nil, args.Error(1)
 }
 
+func (m *MockGocqlSession) Query(stmt string, values ...interface{}) RowIterator {
+	args := m.Called(stmt, values)
+	return args.Get(0).(RowIterator)
+}
+
 func (m *MockGocqlSession) Close() {
 	m.Called()
 }
@@ -59,6 +64,32 @@ func (m *MockCassandraCluster) KeyspaceMetadata(keyspace string) (KeyspaceMetada
 	return nil, args.Error(1)
 }
 
+func (m *MockCassandraCluster) Query(stmt string, values ...interface{}) RowIterator {
+	args := m.Called(stmt, values)
+	return args.Get(0).(RowIterator)
+}
+
 func (m *MockCassandraCluster) Close() {
 	m.Called()
 }
+
+// MockRowIterator returns Rows in order, followed by Err from Close.
+type MockRowIterator struct {
+	Rows []map[string]interface{}
+	Err  error
+}
+
+func (m *MockRowIterator) MapScan(row map[string]interface{}) bool {
+	if len(m.Rows) == 0 {
+		return false
+	}
+	for k, v := range m.Rows[0] {
+		row[k] = v
+	}
+	m.Rows = m.Rows[1:]
+	return true
+}
+
+func (m *MockRowIterator) Close() error {
+	return m.Err
+}
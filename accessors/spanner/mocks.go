@@ -34,6 +34,7 @@ type SpannerAccessorMock struct {
 	CreateChangeStreamMock          func(ctx context.Context, changeStreamName, dbURI string) error
 	CreateDatabaseMock              func(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string) error
 	UpdateDatabaseMock              func(ctx context.Context, dbURI string, conv *internal.Conv, driver string) error
+	UpdateDatabaseDdlInBatchesMock  func(ctx context.Context, dbURI string, statements []string, batchSize int) error
 	CreateOrUpdateDatabaseMock      func(ctx context.Context, dbURI, driver string, conv *internal.Conv, migrationType string, tablesExistingOnSpanner []string) error
 	VerifyDbMock                    func(ctx context.Context, dbURI string, conv *internal.Conv, tablesExistingOnSpanner []string) (dbExists bool, err error)
 	VerifyCreateTableDDLMock        func(ctx context.Context, dbURI string, conv *internal.Conv, tableId string, driver string) error
@@ -83,6 +84,9 @@ func (sam *SpannerAccessorMock) CreateDatabase(ctx context.Context, dbURI string
 func (sam *SpannerAccessorMock) UpdateDatabase(ctx context.Context, dbURI string, conv *internal.Conv, driver string) error {
 	return sam.UpdateDatabaseMock(ctx, dbURI, conv, driver)
 }
+func (sam *SpannerAccessorMock) UpdateDatabaseDdlInBatches(ctx context.Context, dbURI string, statements []string, batchSize int) error {
+	return sam.UpdateDatabaseDdlInBatchesMock(ctx, dbURI, statements, batchSize)
+}
 func (sam *SpannerAccessorMock) CreateOrUpdateDatabase(ctx context.Context, dbURI, driver string, conv *internal.Conv, migrationType string, tablesExistingOnSpanner []string) error {
 	return sam.CreateOrUpdateDatabaseMock(ctx, dbURI, driver, conv, migrationType, tablesExistingOnSpanner)
 }
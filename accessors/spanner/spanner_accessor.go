@@ -68,6 +68,8 @@ type SpannerAccessor interface {
 	CreateDatabase(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string) error
 	// Update Database using conv
 	UpdateDatabase(ctx context.Context, dbURI string, conv *internal.Conv, driver string) error
+	// Run DDL statements against an existing database, in batches of at most batchSize statements.
+	UpdateDatabaseDdlInBatches(ctx context.Context, dbURI string, statements []string, batchSize int) error
 	// Updates an existing Spanner database or create a new one if one does not exist using Conv
 	CreateOrUpdateDatabase(ctx context.Context, dbURI, driver string, conv *internal.Conv, migrationType string, tablesExistingOnSpanner []string) error
 	// Check whether the db exists and if it does, verify if the schema is what we currently support.
@@ -276,7 +278,7 @@ func (sp *SpannerAccessorImpl) CreateChangeStream(ctx context.Context, changeStr
 	op, err := sp.AdminClient.UpdateDatabaseDdl(ctx, &databasepb.UpdateDatabaseDdlRequest{
 		Database: dbURI,
 		// TODO: create change stream for only the tables present in Spanner.
-		Statements: []string{fmt.Sprintf("CREATE CHANGE STREAM %s FOR ALL OPTIONS (value_capture_type = 'NEW_ROW', retention_period = '7d')", changeStreamName)},
+		Statements: []string{ddl.ChangeStream{Name: changeStreamName, WatchAll: true, ValueCaptureType: "NEW_ROW", RetentionPeriod: "7d"}.PrintChangeStream(nil, ddl.Config{})},
 	})
 	if err != nil {
 		return fmt.Errorf("cannot submit request create change stream request: %v", err)
@@ -307,8 +309,18 @@ func (sp *SpannerAccessorImpl) CreateDatabase(ctx context.Context, dbURI string,
 	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
 		req.DatabaseDialect = adminpb.DatabaseDialect_POSTGRESQL
 	} else {
-		req.ExtraStatements = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
-
+		protoBundle := conv.ProtoBundle()
+		if stmts := ddl.GetProtoBundleDDL(ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect}, protoBundle); len(stmts) > 0 {
+			descriptors, err := protoBundle.FileDescriptorSet()
+			if err != nil {
+				return fmt.Errorf("can't build proto descriptors: %w", err)
+			}
+			req.ProtoDescriptors = descriptors
+			req.ExtraStatements = stmts
+		}
+		req.ExtraStatements = append(req.ExtraStatements, ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)...)
+		req.ExtraStatements = append(req.ExtraStatements, ddl.GetViewsDDL(ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect}, conv.ReadyViews())...)
+		req.ExtraStatements = append(req.ExtraStatements, ddl.GetChangeStreamsDDL(ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect}, conv.SpSchema, conv.SpChangeStreams)...)
 	}
 
 	op, err := sp.AdminClient.CreateDatabase(ctx, req)
@@ -371,13 +383,25 @@ func (sp *SpannerAccessorImpl) UpdateDatabase(ctx context.Context, dbURI string,
 	// Spanner DDL doesn't accept them), and protects table and col names
 	// using backticks (to avoid any issues with Spanner reserved words).
 	// Foreign Keys are set to false since we create them post data migration.
-	schema := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	protoBundle := conv.ProtoBundle()
+	schema := ddl.GetProtoBundleDDL(ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect}, protoBundle)
+	var descriptors []byte
+	if len(schema) > 0 {
+		var err error
+		if descriptors, err = protoBundle.FileDescriptorSet(); err != nil {
+			return fmt.Errorf("can't build proto descriptors: %w", err)
+		}
+	}
+	schema = append(schema, ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)...)
+	schema = append(schema, ddl.GetViewsDDL(ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect}, conv.ReadyViews())...)
+	schema = append(schema, ddl.GetChangeStreamsDDL(ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect}, conv.SpSchema, conv.SpChangeStreams)...)
 	if len(schema) == 0 {
 		return nil
 	}
 	req := &adminpb.UpdateDatabaseDdlRequest{
-		Database:   dbURI,
-		Statements: schema,
+		Database:         dbURI,
+		Statements:       schema,
+		ProtoDescriptors: descriptors,
 	}
 	// Update queries for postgres as target db return response after more
 	// than 1 min for large schemas, therefore, timeout is specified as 5 minutes
@@ -393,6 +417,37 @@ func (sp *SpannerAccessorImpl) UpdateDatabase(ctx context.Context, dbURI string,
 	return nil
 }
 
+// UpdateDatabaseDdlInBatches runs the statements against an existing
+// database in order, sending at most batchSize statements per
+// UpdateDatabaseDdl request and waiting for each batch to complete before the
+// next one. Spanner applies the statements of a batch one by one, so on
+// failure the statements before the failing one, including those of earlier
+// batches, remain applied.
+func (sp *SpannerAccessorImpl) UpdateDatabaseDdlInBatches(ctx context.Context, dbURI string, statements []string, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = len(statements)
+	}
+	for start := 0; start < len(statements); start += batchSize {
+		end := start + batchSize
+		if end > len(statements) {
+			end = len(statements)
+		}
+		logger.Log.Info(fmt.Sprintf("Applying statements %d to %d of %d", start+1, end, len(statements)))
+		req := &adminpb.UpdateDatabaseDdlRequest{
+			Database:   dbURI,
+			Statements: statements[start:end],
+		}
+		op, err := sp.AdminClient.UpdateDatabaseDdl(ctx, req)
+		if err != nil {
+			return fmt.Errorf("can't build UpdateDatabaseDdlRequest: %w", parse.AnalyzeError(err, dbURI))
+		}
+		if err := op.Wait(ctx); err != nil {
+			return fmt.Errorf("UpdateDatabaseDdl call failed for statements %d to %d: %w", start+1, end, parse.AnalyzeError(err, dbURI))
+		}
+	}
+	return nil
+}
+
 // CreatesOrUpdatesDatabase updates an existing Spanner database or creates a new one if one does not exist.
 func (sp *SpannerAccessorImpl) CreateOrUpdateDatabase(ctx context.Context, dbURI, driver string, conv *internal.Conv, migrationType string, tablesExistingOnSpanner []string) error {
 	dbExists, err := sp.VerifyDb(ctx, dbURI, conv, tablesExistingOnSpanner)
@@ -1050,6 +1050,56 @@ func TestSpannerAccessorImpl_UpdateDatabase(t *testing.T) {
 	}
 }
 
+func TestSpannerAccessorImpl_UpdateDatabaseDdlInBatches(t *testing.T) {
+	statements := []string{"DROP INDEX a", "DROP TABLE b", "ALTER TABLE c ADD COLUMN d INT64", "CREATE INDEX e ON c (d)", "CREATE INDEX f ON c (d)"}
+	testCases := []struct {
+		name            string
+		batchSize       int
+		failOnBatch     int
+		expectedBatches [][]string
+		expectError     bool
+	}{
+		{
+			name:            "Batches of two statements",
+			batchSize:       2,
+			expectedBatches: [][]string{statements[0:2], statements[2:4], statements[4:5]},
+		},
+		{
+			name:            "Single batch",
+			batchSize:       0,
+			expectedBatches: [][]string{statements},
+		},
+		{
+			name:            "Stops at the failing batch",
+			batchSize:       2,
+			failOnBatch:     2,
+			expectedBatches: [][]string{statements[0:2], statements[2:4]},
+			expectError:     true,
+		},
+	}
+	ctx := context.Background()
+	for _, tc := range testCases {
+		var batches [][]string
+		acm := spanneradmin.AdminClientMock{
+			UpdateDatabaseDdlMock: func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+				batches = append(batches, req.Statements)
+				return &spanneradmin.UpdateDatabaseDdlOperationMock{
+					WaitMock: func(ctx context.Context, opts ...gax.CallOption) error {
+						if len(batches) == tc.failOnBatch {
+							return fmt.Errorf("error")
+						}
+						return nil
+					},
+				}, nil
+			},
+		}
+		spA := SpannerAccessorImpl{AdminClient: &acm}
+		err := spA.UpdateDatabaseDdlInBatches(ctx, "projects/project-id/instances/instance-id/databases/database-id", statements, tc.batchSize)
+		assert.Equal(t, tc.expectError, err != nil, tc.name)
+		assert.Equal(t, tc.expectedBatches, batches, tc.name)
+	}
+}
+
 func TestSpannerAccessorImpl_UpdateDDLForeignKey(t *testing.T) {
 	schemaWithStatements := map[string]ddl.CreateTable{
 		"table_id": {
@@ -17,20 +17,26 @@ package assessment
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
-	"cloud.google.com/go/vertexai/genai"
 	assessment "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors"
+	projectAnalyzer "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/project_analyzer"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/llm"
+	common "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/oracle"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/sqlserver"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/task"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"go.uber.org/zap"
-	"google.golang.org/api/option"
 )
 
 type assessmentCollectors struct {
@@ -38,6 +44,9 @@ type assessmentCollectors struct {
 	infoSchemaCollector        *assessment.InfoSchemaCollector
 	appAssessmentCollector     assessment.AppCodeAssessor
 	performanceSchemaCollector *assessment.PerformanceSchemaCollector
+	staticQueryCollector       *assessment.StaticQueryCollector
+	sourceSpecificComparison   common.SourceSpecificComparison
+	queryTranslator            common.QueryTranslator
 }
 
 type assessmentTaskInput struct {
@@ -107,7 +116,21 @@ func PerformAssessment(conv *internal.Conv, sourceProfile profiles.SourceProfile
 		}
 	}
 
-	combinedQueries := combineAndDeduplicateQueries(c.performanceSchemaCollector.Queries, output.AppCodeAssessment)
+	costAssessment, err := performCostAssessment(c, output.SchemaAssessment, assessmentConfig)
+	if err != nil {
+		logger.Log.Error("could not complete cost assessment", zap.Error(err))
+	}
+	output.CostAssessment = costAssessment
+
+	var performanceSchemaQueries []utils.QueryAssessmentInfo
+	if c.performanceSchemaCollector != nil {
+		performanceSchemaQueries = c.performanceSchemaCollector.Queries
+	}
+	var staticQueries []projectAnalyzer.ExtractedQuery
+	if c.staticQueryCollector != nil {
+		staticQueries = c.staticQueryCollector.Queries
+	}
+	combinedQueries := combineAndDeduplicateQueries(performanceSchemaQueries, output.AppCodeAssessment, staticQueries)
 	logger.Log.Info("Combined deduplicated queries", zap.Int("count", len(combinedQueries)))
 	translatedQueries, err := performQueryAssessment(ctx, c, combinedQueries, projectId, assessmentConfig, conv)
 	output.QueryAssessment = utils.QueryAssessmentOutput{QueryTranslationResult: &translatedQueries}
@@ -120,12 +143,12 @@ func PerformAssessment(conv *internal.Conv, sourceProfile profiles.SourceProfile
 }
 
 type AIClientService struct {
-	NewClientFunc        func(ctx context.Context, projectID, location string, opts ...option.ClientOption) (*genai.Client, error)
-	TranslateQueriesFunc func(ctx context.Context, queries []utils.QueryTranslationInput, aiClient *genai.Client, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error)
+	NewProviderFunc      func(ctx context.Context, cfg llm.Config) (llm.Provider, error)
+	TranslateQueriesFunc func(ctx context.Context, queries []utils.QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error)
 }
 
 var aiClientService = &AIClientService{
-	NewClientFunc:        genai.NewClient,
+	NewProviderFunc:      llm.NewProvider,
 	TranslateQueriesFunc: utils.TranslateQueriesToSpanner,
 }
 
@@ -143,11 +166,22 @@ func performQueryAssessment(ctx context.Context, collectors assessmentCollectors
 			conv.DatabaseOptions),
 		"\n")
 
+	ruleTranslations := 0
 	for _, query := range queries {
-		if query.AssessmentSource == "performance_schema" {
+		if needsTranslation(query) {
+			if translatedQuery, ok := translateWithRules(collectors.queryTranslator, query); ok {
+				translatedQuery.SpannerTablesAffected, translatedQuery.TranslationError = fetchSpannerTableNames(conv, translatedQuery.SourceTablesAffected)
+				translationResult = append(translationResult, translatedQuery)
+				ruleTranslations++
+				continue
+			}
 			performanceSchemaQueries = append(performanceSchemaQueries, utils.QueryTranslationInput{
-				Query: query.NormalizedQuery,
-				Count: query.ExecutionCount,
+				Query:                   query.NormalizedQuery,
+				Count:                   query.ExecutionCount,
+				TotalLatencyMs:          query.TotalLatencyMs,
+				AssessmentSource:        query.AssessmentSource,
+				SnippetId:               query.SnippetId,
+				NumberOfQueryOccurances: query.NumberOfQueryOccurances,
 			})
 		} else {
 			query.SpannerTablesAffected, query.TranslationError = fetchSpannerTableNames(conv, query.SourceTablesAffected)
@@ -155,11 +189,16 @@ func performQueryAssessment(ctx context.Context, collectors assessmentCollectors
 			translationResult = append(translationResult, query)
 		}
 	}
-	aiClient, err := aiClientService.NewClientFunc(ctx, projectId, assessmentConfig["location"])
+	if len(performanceSchemaQueries) == 0 && ruleTranslations > 0 {
+		logger.Log.Info("query assessment completed successfully.", zap.Int("rule_translations", ruleTranslations))
+		return translationResult, nil
+	}
+	llmProvider, err := aiClientService.NewProviderFunc(ctx, llm.ConfigFromAssessmentProfile(assessmentConfig, projectId))
 	if err != nil {
-		return translationResult, fmt.Errorf("Error creating ai client")
+		return translationResult, fmt.Errorf("Error creating ai client: %v", err)
 	}
-	translatedQueries, err := aiClientService.TranslateQueriesFunc(ctx, performanceSchemaQueries, aiClient, mysqlSchema, spannerSchema)
+	defer llmProvider.Close()
+	translatedQueries, err := aiClientService.TranslateQueriesFunc(ctx, performanceSchemaQueries, llmProvider, mysqlSchema, spannerSchema)
 	if translatedQueries != nil {
 		for _, translatedQuery := range translatedQueries {
 			translatedQuery.SpannerTablesAffected, translatedQuery.TranslationError = fetchSpannerTableNames(conv, translatedQuery.SourceTablesAffected)
@@ -173,6 +212,39 @@ func performQueryAssessment(ctx context.Context, collectors assessmentCollectors
 	return translationResult, nil
 }
 
+// translateWithRules translates a query with the rules of the source, without
+// the LLM, keeping the details of its assessment. It returns false when there
+// are no rules for the source or they can't translate the query.
+func translateWithRules(translator common.QueryTranslator, query utils.QueryTranslationResult) (utils.QueryTranslationResult, bool) {
+	if translator == nil {
+		return query, false
+	}
+	result, err := translator.TranslateQuery(query.NormalizedQuery)
+	if err != nil {
+		logger.Log.Debug("query not translated by rules", zap.String("query", query.NormalizedQuery), zap.Error(err))
+		return query, false
+	}
+	result.OriginalQuery = query.OriginalQuery
+	result.NormalizedQuery = query.NormalizedQuery
+	result.AssessmentSource = query.AssessmentSource
+	result.ExecutionCount = query.ExecutionCount
+	result.TotalLatencyMs = query.TotalLatencyMs
+	result.SnippetId = query.SnippetId
+	result.NumberOfQueryOccurances = query.NumberOfQueryOccurances
+	return result, true
+}
+
+// needsTranslation tells if a query still has to be translated by the LLM,
+// i.e. it comes from the performance schema or the static analysis of the
+// code and the app code assessment didn't translate it already.
+func needsTranslation(query utils.QueryTranslationResult) bool {
+	sources := strings.Split(query.AssessmentSource, ", ")
+	if slices.Contains(sources, "app_code") {
+		return false
+	}
+	return slices.Contains(sources, "performance_schema") || slices.Contains(sources, "static_analysis")
+}
+
 func fetchSpannerTableNames(conv *internal.Conv, tableNames []string) ([]string, string) {
 	spannerTableNames := make([]string, 0, len(tableNames))
 	for _, tableName := range tableNames {
@@ -203,6 +275,8 @@ func initializeCollectors(conv *internal.Conv, sourceProfile profiles.SourceProf
 		return c, err
 	}
 	c.infoSchemaCollector = &infoSchemaCollector
+	c.sourceSpecificComparison = getSourceSpecificComparison(sourceProfile.Driver)
+	c.queryTranslator = getQueryTranslator(sourceProfile.Driver, conv)
 
 	//Initialize App Assessment Collector
 	language, exists := assessmentConfig["language"]
@@ -211,6 +285,13 @@ func initializeCollectors(conv *internal.Conv, sourceProfile profiles.SourceProf
 
 	codeDirectory, exists := assessmentConfig["codeDirectory"]
 	if exists {
+		staticQueryCollector, err := assessment.GetStaticQueryCollector(ctx, codeDirectory)
+		if err != nil {
+			logger.Log.Warn("failed to initialize static query collector", zap.Error(err))
+		} else {
+			c.staticQueryCollector = &staticQueryCollector
+		}
+
 		logger.Log.Info("initializing app collector")
 		mysqlSchema := utils.GetDDL(conv.SrcSchema)
 		spannerSchema := strings.Join(
@@ -224,8 +305,13 @@ func initializeCollectors(conv *internal.Conv, sourceProfile profiles.SourceProf
 		logger.Log.Debug("mysqlSchema", zap.String("schema", mysqlSchema))
 		logger.Log.Debug("spannerSchema", zap.String("schema", spannerSchema))
 
+		llmProvider, err := aiClientService.NewProviderFunc(ctx, llm.ConfigFromAssessmentProfile(assessmentConfig, projectId))
+		if err != nil {
+			logger.Log.Error("error creating llm provider")
+			return c, err
+		}
 		summarizer, err := assessment.NewMigrationCodeSummarizer(
-			ctx, nil, projectId, assessmentConfig["location"], mysqlSchema, spannerSchema, codeDirectory, language, sourceFramework, targetFramework)
+			ctx, llmProvider, mysqlSchema, spannerSchema, codeDirectory, language, sourceFramework, targetFramework)
 		if err != nil {
 			logger.Log.Error("error initiating migration summarizer")
 			return c, err
@@ -247,12 +333,39 @@ func initializeCollectors(conv *internal.Conv, sourceProfile profiles.SourceProf
 		logger.Log.Info("initialized performance schema collector")
 	}
 
-	return c, err
+	return c, nil
+}
+
+// getSourceSpecificComparison returns the comparison of source and Spanner
+// types for a driver.
+func getSourceSpecificComparison(driver string) common.SourceSpecificComparison {
+	switch driver {
+	case constants.POSTGRES:
+		return postgres.SourceSpecificComparisonImpl{}
+	case constants.SQLSERVER:
+		return sqlserver.SourceSpecificComparisonImpl{}
+	case constants.ORACLE:
+		return oracle.SourceSpecificComparisonImpl{}
+	default:
+		return mysql.SourceSpecificComparisonImpl{}
+	}
+}
+
+// getQueryTranslator returns the rules translating the queries of a driver to
+// Spanner, nil when there are none and the LLM translates all the queries.
+func getQueryTranslator(driver string, conv *internal.Conv) common.QueryTranslator {
+	switch driver {
+	case constants.MYSQL, constants.MYSQLDUMP:
+		return mysql.QueryTranslatorImpl{Conv: conv}
+	default:
+		return nil
+	}
 }
 
 func combineAndDeduplicateQueries(
 	performanceSchemaQueries []utils.QueryAssessmentInfo,
 	appCodeQueries *utils.AppCodeAssessmentOutput,
+	staticQueries []projectAnalyzer.ExtractedQuery,
 ) []utils.QueryTranslationResult {
 	queryMap := make(map[string]utils.QueryTranslationResult)
 
@@ -264,6 +377,7 @@ func combineAndDeduplicateQueries(
 			NormalizedQuery:  key,
 			AssessmentSource: "performance_schema",
 			ExecutionCount:   q.Count,
+			TotalLatencyMs:   q.TotalLatencyMs,
 		}
 	}
 
@@ -278,6 +392,7 @@ func combineAndDeduplicateQueries(
 			if existingQuery, ok := queryMap[key]; ok && existingQuery.AssessmentSource == "performance_schema" {
 				q.AssessmentSource = "app_code, performance_schema"
 				q.ExecutionCount = existingQuery.ExecutionCount
+				q.TotalLatencyMs = existingQuery.TotalLatencyMs
 				queryMap[key] = q
 			} else {
 				queryMap[key] = q
@@ -285,6 +400,32 @@ func combineAndDeduplicateQueries(
 		}
 
 	}
+
+	// Process and merge queries from the static analysis of the code. The
+	// locations of the occurrences of a query are its snippet, unless the app
+	// code assessment found it in a snippet already.
+	for _, sq := range staticQueries {
+		key := utils.NormalizeQuery(sq.Query)
+		location := fmt.Sprintf("%s:%d", sq.FilePath, sq.Line)
+		q, ok := queryMap[key]
+		if !ok {
+			q = utils.QueryTranslationResult{
+				OriginalQuery:    sq.Query,
+				NormalizedQuery:  key,
+				AssessmentSource: "static_analysis",
+			}
+		} else if !strings.Contains(q.AssessmentSource, "static_analysis") {
+			q.AssessmentSource += ", static_analysis"
+		}
+		if q.SnippetId == "" {
+			q.SnippetId = location
+		} else if !strings.Contains(q.AssessmentSource, "app_code") {
+			q.SnippetId += ", " + location
+		}
+		q.NumberOfQueryOccurances++
+		queryMap[key] = q
+	}
+
 	// Convert map back to slice.
 	var combinedQueries []utils.QueryTranslationResult
 	for _, q := range queryMap {
@@ -300,6 +441,10 @@ func performSchemaAssessment(ctx context.Context, collectors assessmentCollector
 	srcTableDefs, spTableDefs := collectors.infoSchemaCollector.ListTables()
 	srcColDefs, spColDefs := collectors.infoSchemaCollector.ListColumnDefinitions()
 	srcIndexes, spIndexes := collectors.infoSchemaCollector.ListIndexes()
+	sourceSpecificComparison := collectors.sourceSpecificComparison
+	if sourceSpecificComparison == nil {
+		sourceSpecificComparison = mysql.SourceSpecificComparisonImpl{}
+	}
 
 	tableAssessments := []utils.TableAssessment{}
 	for tableId, srcTableDef := range srcTableDefs {
@@ -315,7 +460,7 @@ func performSchemaAssessment(ctx context.Context, collectors assessmentCollector
 				//Column not of current table
 				continue
 			}
-			isTypeCompatible := mysql.SourceSpecificComparisonImpl{}.IsDataTypeCodeCompatible(srcColumn, spColumn) // Make generic when more sources added
+			isTypeCompatible := sourceSpecificComparison.IsDataTypeCodeCompatible(srcColumn, spColumn)
 			sizeIncreaseInBytes := getSpColSizeBytes(spColumn) - srcColumn.MaxColumnSize
 			colAssessment := utils.ColumnAssessment{SourceColDef: &srcColumn, SpannerColDef: &spColumn, CompatibleDataType: isTypeCompatible, SizeIncreaseInBytes: int(sizeIncreaseInBytes)}
 			columnAssessments = append(columnAssessments, colAssessment)
@@ -360,6 +505,51 @@ func performSchemaAssessment(ctx context.Context, collectors assessmentCollector
 	return schemaOut, nil
 }
 
+// performCostAssessment estimates the size of the tables on Spanner and the
+// cost of the instance serving them, with the pricing of the pricingFile of
+// the assessment profile or the default one. The instance is sized for the
+// average query rates multiplied by the peakToAverageRatio of the profile.
+func performCostAssessment(collectors assessmentCollectors, schemaAssessment *utils.SchemaAssessmentOutput, assessmentConfig map[string]string) (utils.CostAssessmentOutput, error) {
+	if schemaAssessment == nil || collectors.infoSchemaCollector == nil {
+		return utils.CostAssessmentOutput{}, fmt.Errorf("cost assessment requires the schema assessment")
+	}
+	logger.Log.Info("starting cost assessment...")
+	pricing, err := utils.LoadSpannerPricing(assessmentConfig["pricingFile"])
+	if err != nil {
+		return utils.CostAssessmentOutput{}, err
+	}
+	peakToAverageRatio, err := utils.ParsePeakToAverageRatio(assessmentConfig["peakToAverageRatio"])
+	if err != nil {
+		return utils.CostAssessmentOutput{}, err
+	}
+
+	tableSizes := collectors.infoSchemaCollector.ListTableSizes()
+	var tables []utils.TableCostAssessment
+	for _, tableAssessment := range schemaAssessment.TableAssessmentOutput {
+		size, ok := tableSizes[tableAssessment.SourceTableDef.Id]
+		if !ok {
+			continue
+		}
+		tables = append(tables, utils.TableCostAssessment{
+			TableName:    tableAssessment.SourceTableDef.Name,
+			RowCount:     size.RowCount,
+			SourceBytes:  size.DataBytes + size.IndexBytes,
+			SpannerBytes: utils.EstimateSpannerTableBytes(tableAssessment, size),
+		})
+	}
+
+	var readQps, writeQps float64
+	var windowSeconds int64
+	if collectors.performanceSchemaCollector != nil {
+		windowSeconds = collectors.performanceSchemaCollector.StatisticsWindowSeconds
+		readQps, writeQps = utils.QueryRates(collectors.performanceSchemaCollector.Queries, windowSeconds)
+	}
+	costAssessment := utils.EstimateCost(tables, readQps, writeQps, peakToAverageRatio, pricing)
+	costAssessment.StatisticsWindowSeconds = windowSeconds
+	logger.Log.Info("cost assessment completed successfully.")
+	return costAssessment, nil
+}
+
 func performAppAssessment(ctx context.Context, collectors assessmentCollectors) (*utils.AppCodeAssessmentOutput, error) {
 
 	if collectors.appAssessmentCollector == nil {
@@ -7,8 +7,13 @@ import (
 	"sort"
 	"testing"
 
-	"cloud.google.com/go/vertexai/genai"
 	assessment "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors"
+	projectAnalyzer "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/project_analyzer"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/llm"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/oracle"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/sqlserver"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -16,7 +21,6 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"google.golang.org/api/option"
 )
 
 // MockAppCodeAssessor is a mock for the AppCodeAssessor interface.
@@ -32,6 +36,19 @@ func (m *MockAppCodeAssessor) AnalyzeProject(ctx context.Context) (*utils.CodeAs
 	return args.Get(0).(*utils.CodeAssessment), args.Get(1).([]utils.QueryTranslationResult), args.Error(2)
 }
 
+// fakeQueryTranslator translates the queries it has a translation for.
+type fakeQueryTranslator struct {
+	translations map[string]string
+}
+
+func (f fakeQueryTranslator) TranslateQuery(query string) (utils.QueryTranslationResult, error) {
+	spannerQuery, ok := f.translations[query]
+	if !ok {
+		return utils.QueryTranslationResult{}, errors.New("not supported")
+	}
+	return utils.QueryTranslationResult{OriginalQuery: query, SpannerQuery: spannerQuery, TranslationSource: utils.TranslationSourceRules}, nil
+}
+
 func TestCombineAndDeduplicateQueries(t *testing.T) {
 	// Helper function to find a specific result in a slice.
 	findResult := func(results []utils.QueryTranslationResult, normalizedQuery string) (utils.QueryTranslationResult, bool) {
@@ -47,6 +64,7 @@ func TestCombineAndDeduplicateQueries(t *testing.T) {
 		result := combineAndDeduplicateQueries(
 			[]utils.QueryAssessmentInfo{},
 			&utils.AppCodeAssessmentOutput{QueryTranslationResult: &[]utils.QueryTranslationResult{}},
+			nil,
 		)
 		assert.Empty(t, result)
 	})
@@ -56,7 +74,7 @@ func TestCombineAndDeduplicateQueries(t *testing.T) {
 			{Query: "SELECT * FROM users WHERE id = ?", Count: 100},
 			{Query: "SELECT * FROM products WHERE id = ?", Count: 50},
 		}
-		result := combineAndDeduplicateQueries(perfQueries, nil)
+		result := combineAndDeduplicateQueries(perfQueries, nil, nil)
 		assert.Len(t, result, 2)
 
 		q1, ok1 := findResult(result, "SELECT * FROM users WHERE id = ?")
@@ -75,7 +93,7 @@ func TestCombineAndDeduplicateQueries(t *testing.T) {
 			{NormalizedQuery: "SELECT * FROM users WHERE id = ?", OriginalQuery: "SELECT * FROM users WHERE id = 1", AssessmentSource: "app_code"},
 			{NormalizedQuery: "INSERT INTO orders VALUES (?)", OriginalQuery: "INSERT INTO orders VALUES (1)", AssessmentSource: "app_code"},
 		}
-		result := combineAndDeduplicateQueries([]utils.QueryAssessmentInfo{}, &utils.AppCodeAssessmentOutput{QueryTranslationResult: &appQueries})
+		result := combineAndDeduplicateQueries([]utils.QueryAssessmentInfo{}, &utils.AppCodeAssessmentOutput{QueryTranslationResult: &appQueries}, nil)
 		assert.Len(t, result, 2)
 
 		q1, ok1 := findResult(result, "SELECT * FROM users WHERE id = ?")
@@ -95,7 +113,7 @@ func TestCombineAndDeduplicateQueries(t *testing.T) {
 		appQueries := []utils.QueryTranslationResult{
 			{NormalizedQuery: "SELECT * FROM products WHERE id = ?", OriginalQuery: "SELECT * FROM products", AssessmentSource: "app_code"},
 		}
-		result := combineAndDeduplicateQueries(perfQueries, &utils.AppCodeAssessmentOutput{QueryTranslationResult: &appQueries})
+		result := combineAndDeduplicateQueries(perfQueries, &utils.AppCodeAssessmentOutput{QueryTranslationResult: &appQueries}, nil)
 		assert.Len(t, result, 2)
 
 		q1, ok1 := findResult(result, "SELECT * FROM users WHERE id = ?")
@@ -116,7 +134,7 @@ func TestCombineAndDeduplicateQueries(t *testing.T) {
 			{NormalizedQuery: "SELECT * FROM users WHERE id = ?", OriginalQuery: "SELECT * FROM users WHERE id = 1", AssessmentSource: "app_code"}, // Common query
 			{NormalizedQuery: "INSERT INTO products values(?)", OriginalQuery: "INSERT INTO products values(1)", AssessmentSource: "app_code"},     // Unique to app code
 		}
-		result := combineAndDeduplicateQueries(perfQueries, &utils.AppCodeAssessmentOutput{QueryTranslationResult: &appQueries})
+		result := combineAndDeduplicateQueries(perfQueries, &utils.AppCodeAssessmentOutput{QueryTranslationResult: &appQueries}, nil)
 		assert.Len(t, result, 3)
 
 		// Check the deduplicated query
@@ -139,6 +157,40 @@ func TestCombineAndDeduplicateQueries(t *testing.T) {
 		assert.Equal(t, "INSERT INTO products values(1)", appQuery.OriginalQuery)
 		assert.Equal(t, "app_code", appQuery.AssessmentSource)
 	})
+
+	t.Run("static analysis queries are merged by normalized query", func(t *testing.T) {
+		perfQueries := []utils.QueryAssessmentInfo{
+			{Query: "SELECT * FROM users WHERE id = ?", Count: 100},
+		}
+		appQueries := []utils.QueryTranslationResult{
+			{NormalizedQuery: "INSERT INTO products VALUES (?)", OriginalQuery: "INSERT INTO products VALUES (1)", AssessmentSource: "app_code", SnippetId: "snippet_1"},
+		}
+		staticQueries := []projectAnalyzer.ExtractedQuery{
+			{Query: "SELECT * FROM users WHERE id = 1", FilePath: "dao/user.go", Line: 10},
+			{Query: "INSERT INTO products VALUES (2)", FilePath: "dao/product.go", Line: 5},
+			{Query: "DELETE FROM orders WHERE id = ?", FilePath: "dao/order.go", Line: 7},
+			{Query: "DELETE FROM orders WHERE id = ?", FilePath: "dao/order.go", Line: 21},
+		}
+		result := combineAndDeduplicateQueries(perfQueries, &utils.AppCodeAssessmentOutput{QueryTranslationResult: &appQueries}, staticQueries)
+		assert.Len(t, result, 3)
+
+		perfQuery, ok := findResult(result, "SELECT * FROM users WHERE id = ?")
+		assert.True(t, ok)
+		assert.Equal(t, "performance_schema, static_analysis", perfQuery.AssessmentSource)
+		assert.Equal(t, 100, perfQuery.ExecutionCount)
+		assert.Equal(t, "dao/user.go:10", perfQuery.SnippetId)
+
+		appQuery, ok := findResult(result, "INSERT INTO products VALUES (?)")
+		assert.True(t, ok)
+		assert.Equal(t, "app_code, static_analysis", appQuery.AssessmentSource)
+		assert.Equal(t, "snippet_1", appQuery.SnippetId)
+
+		staticQuery, ok := findResult(result, "DELETE FROM orders WHERE id = ?")
+		assert.True(t, ok)
+		assert.Equal(t, "static_analysis", staticQuery.AssessmentSource)
+		assert.Equal(t, "dao/order.go:7, dao/order.go:21", staticQuery.SnippetId)
+		assert.Equal(t, 2, staticQuery.NumberOfQueryOccurances)
+	})
 }
 
 func TestPerformQueryAssessment(t *testing.T) {
@@ -154,11 +206,11 @@ func TestPerformQueryAssessment(t *testing.T) {
 	collectors := assessmentCollectors{}
 
 	t.Run("success with a mix of queries", func(t *testing.T) {
-		aiClientService.NewClientFunc = func(ctx context.Context, projectID, location string, opts ...option.ClientOption) (*genai.Client, error) {
-			return &genai.Client{}, nil
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
+			return &llm.FakeProvider{}, nil
 		}
 
-		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, aiClient *genai.Client, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
+		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
 			assert.Len(t, queries, 1) // Only one performance schema query should be passed.
 			assert.Equal(t, "SELECT * FROM users WHERE id = ?", queries[0].Query)
 			return []utils.QueryTranslationResult{
@@ -185,8 +237,105 @@ func TestPerformQueryAssessment(t *testing.T) {
 		assert.Equal(t, "SELECT * FROM `users` WHERE id = ?", result[1].SpannerQuery)
 	})
 
-	t.Run("genai.NewClient returns an error", func(t *testing.T) {
-		aiClientService.NewClientFunc = func(ctx context.Context, projectID, location string, opts ...option.ClientOption) (*genai.Client, error) {
+	t.Run("static analysis queries are translated", func(t *testing.T) {
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
+			return &llm.FakeProvider{}, nil
+		}
+		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
+			assert.Len(t, queries, 2)
+			assert.Equal(t, utils.QueryTranslationInput{Query: "DELETE FROM orders WHERE id = ?", AssessmentSource: "static_analysis", SnippetId: "dao/order.go:7", NumberOfQueryOccurances: 1}, queries[0])
+			assert.Equal(t, "performance_schema, static_analysis", queries[1].AssessmentSource)
+			return []utils.QueryTranslationResult{
+				{OriginalQuery: queries[0].Query, AssessmentSource: queries[0].AssessmentSource},
+				{OriginalQuery: queries[1].Query, AssessmentSource: queries[1].AssessmentSource},
+			}, nil
+		}
+
+		queries := []utils.QueryTranslationResult{
+			{OriginalQuery: "DELETE FROM orders WHERE id = ?", NormalizedQuery: "DELETE FROM orders WHERE id = ?", AssessmentSource: "static_analysis", SnippetId: "dao/order.go:7", NumberOfQueryOccurances: 1},
+			{OriginalQuery: "SELECT * FROM users", NormalizedQuery: "SELECT * FROM users", AssessmentSource: "performance_schema, static_analysis"},
+			{OriginalQuery: "INSERT INTO products", NormalizedQuery: "INSERT INTO products", AssessmentSource: "app_code, static_analysis"},
+		}
+
+		result, err := performQueryAssessment(ctx, collectors, queries, projectId, assessmentConfig, conv)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, "INSERT INTO products", result[0].OriginalQuery)
+		assert.Equal(t, "static_analysis", result[1].AssessmentSource)
+	})
+
+	t.Run("rules translate before the llm", func(t *testing.T) {
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
+			return &llm.FakeProvider{}, nil
+		}
+		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
+			assert.Len(t, queries, 1)
+			assert.Equal(t, "SELECT GROUP_CONCAT(name) FROM users", queries[0].Query)
+			return []utils.QueryTranslationResult{{OriginalQuery: queries[0].Query, TranslationSource: utils.TranslationSourceLLM}}, nil
+		}
+		rulesCollectors := assessmentCollectors{queryTranslator: fakeQueryTranslator{translations: map[string]string{
+			"SELECT * FROM users LIMIT 10, 5": "SELECT * FROM `users` LIMIT 5 OFFSET 10",
+		}}}
+
+		queries := []utils.QueryTranslationResult{
+			{OriginalQuery: "SELECT * FROM users LIMIT 10, 5", NormalizedQuery: "SELECT * FROM users LIMIT 10, 5", AssessmentSource: "performance_schema", ExecutionCount: 7, TotalLatencyMs: 3.5},
+			{OriginalQuery: "SELECT GROUP_CONCAT(name) FROM users", NormalizedQuery: "SELECT GROUP_CONCAT(name) FROM users", AssessmentSource: "performance_schema"},
+		}
+
+		result, err := performQueryAssessment(ctx, rulesCollectors, queries, projectId, assessmentConfig, conv)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "SELECT * FROM `users` LIMIT 5 OFFSET 10", result[0].SpannerQuery)
+		assert.Equal(t, utils.TranslationSourceRules, result[0].TranslationSource)
+		assert.Equal(t, "performance_schema", result[0].AssessmentSource)
+		assert.Equal(t, 7, result[0].ExecutionCount)
+		assert.Equal(t, 3.5, result[0].TotalLatencyMs)
+		assert.Equal(t, utils.TranslationSourceLLM, result[1].TranslationSource)
+	})
+
+	t.Run("no llm when the rules translate all the queries", func(t *testing.T) {
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
+			return nil, errors.New("the llm must not be used")
+		}
+		rulesCollectors := assessmentCollectors{queryTranslator: fakeQueryTranslator{translations: map[string]string{
+			"SELECT * FROM users": "SELECT * FROM `users`",
+		}}}
+
+		queries := []utils.QueryTranslationResult{
+			{OriginalQuery: "SELECT * FROM users", NormalizedQuery: "SELECT * FROM users", AssessmentSource: "static_analysis"},
+		}
+
+		result, err := performQueryAssessment(ctx, rulesCollectors, queries, projectId, assessmentConfig, conv)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "SELECT * FROM `users`", result[0].SpannerQuery)
+	})
+
+	t.Run("llm provider from the assessment profile", func(t *testing.T) {
+		var gotCfg llm.Config
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
+			gotCfg = cfg
+			return &llm.FakeProvider{}, nil
+		}
+		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
+			assert.IsType(t, &llm.FakeProvider{}, llmProvider)
+			return nil, nil
+		}
+
+		config := map[string]string{"llmProvider": "openai", "llmEndpoint": "http://localhost:8000/v1", "llmModel": "llama3"}
+		_, err := performQueryAssessment(ctx, collectors, []utils.QueryTranslationResult{}, projectId, config, conv)
+
+		assert.NoError(t, err)
+		assert.Equal(t, llm.OpenAI, gotCfg.Provider)
+		assert.Equal(t, "http://localhost:8000/v1", gotCfg.Endpoint)
+		assert.Equal(t, "llama3", gotCfg.ProModel)
+	})
+
+	t.Run("llm.NewProvider returns an error", func(t *testing.T) {
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
 			return nil, errors.New("client creation error")
 		}
 
@@ -199,11 +348,11 @@ func TestPerformQueryAssessment(t *testing.T) {
 	})
 
 	t.Run("TranslateQueriesToSpanner returns an error", func(t *testing.T) {
-		aiClientService.NewClientFunc = func(ctx context.Context, projectID, location string, opts ...option.ClientOption) (*genai.Client, error) {
-			return &genai.Client{}, nil
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
+			return &llm.FakeProvider{}, nil
 		}
 
-		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, aiClient *genai.Client, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
+		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
 			return nil, errors.New("translation failed")
 		}
 
@@ -219,11 +368,11 @@ func TestPerformQueryAssessment(t *testing.T) {
 	})
 
 	t.Run("input queries is empty", func(t *testing.T) {
-		aiClientService.NewClientFunc = func(ctx context.Context, projectID, location string, opts ...option.ClientOption) (*genai.Client, error) {
-			return &genai.Client{}, nil
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
+			return &llm.FakeProvider{}, nil
 		}
 
-		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, aiClient *genai.Client, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
+		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
 			assert.Empty(t, queries)
 			return nil, fmt.Errorf("no performance schema queries to translate")
 		}
@@ -236,11 +385,11 @@ func TestPerformQueryAssessment(t *testing.T) {
 	})
 
 	t.Run("only non-performance_schema queries", func(t *testing.T) {
-		aiClientService.NewClientFunc = func(ctx context.Context, projectID, location string, opts ...option.ClientOption) (*genai.Client, error) {
-			return &genai.Client{}, nil
+		aiClientService.NewProviderFunc = func(ctx context.Context, cfg llm.Config) (llm.Provider, error) {
+			return &llm.FakeProvider{}, nil
 		}
 
-		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, aiClient *genai.Client, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
+		aiClientService.TranslateQueriesFunc = func(ctx context.Context, queries []utils.QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]utils.QueryTranslationResult, error) {
 			assert.Empty(t, queries)
 			return nil, fmt.Errorf("no performance schema queries to translate")
 		}
@@ -382,7 +531,7 @@ func TestPerformSchemaAssessment(t *testing.T) {
 		functions := []utils.FunctionAssessmentInfo{{Name: "my_func", Definition: "RETURN 1;"}}
 		views := []utils.ViewAssessmentInfo{{Name: "my_view", Definition: "SELECT * FROM table1"}}
 
-		infoSchemaCollector, err := assessment.BuildInfoSchemaCollector(tables, indexes, triggers, storedProcedures, functions, views, conv)
+		infoSchemaCollector, err := assessment.BuildInfoSchemaCollector(tables, indexes, triggers, storedProcedures, functions, views, nil, conv)
 		assert.NoError(t, err)
 
 		collectors := assessmentCollectors{
@@ -425,6 +574,57 @@ func TestPerformSchemaAssessment(t *testing.T) {
 	})
 }
 
+func TestPerformCostAssessment(t *testing.T) {
+	ctx := context.Background()
+	conv := generateSampleConv()
+	tables := map[string]utils.TableAssessmentInfo{
+		"t1": {
+			Name: "table1",
+			ColumnAssessmentInfos: map[string]utils.ColumnAssessmentInfo[any]{
+				"c1": {MaxColumnSize: 8},
+				"c2": {MaxColumnSize: 20},
+			},
+		},
+	}
+	tableSizes := []utils.TableSizeAssessmentInfo{{Name: "table1", RowCount: 1000, DataBytes: 100000, IndexBytes: 20000}}
+	infoSchemaCollector, err := assessment.BuildInfoSchemaCollector(tables, nil, nil, nil, nil, nil, tableSizes, conv)
+	assert.NoError(t, err)
+	collectors := assessmentCollectors{
+		infoSchemaCollector: &infoSchemaCollector,
+		performanceSchemaCollector: &assessment.PerformanceSchemaCollector{
+			Queries:                 []utils.QueryAssessmentInfo{{Query: "SELECT * FROM table1", Count: 7200}},
+			StatisticsWindowSeconds: 3600,
+		},
+	}
+	schemaAssessment, err := performSchemaAssessment(ctx, collectors)
+	assert.NoError(t, err)
+
+	result, err := performCostAssessment(collectors, schemaAssessment, map[string]string{})
+
+	assert.NoError(t, err)
+	// Each row grows by 8 bytes for the INT64 column and 1 byte for the table.
+	assert.Equal(t, []utils.TableCostAssessment{
+		{TableName: "table1", RowCount: 1000, SourceBytes: 120000, SpannerBytes: 129000},
+	}, result.Tables)
+	assert.Equal(t, 2.0, result.ReadQps)
+	assert.NotEmpty(t, result.RegionConfigs)
+	assert.Equal(t, 100, result.RegionConfigs[0].ProcessingUnits)
+	assert.Equal(t, int64(3600), result.StatisticsWindowSeconds)
+	assert.Equal(t, 1.0, result.PeakToAverageRatio)
+
+	result, err = performCostAssessment(collectors, schemaAssessment, map[string]string{"peakToAverageRatio": "4"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, result.ReadQps)
+	assert.Equal(t, 4.0, result.PeakToAverageRatio)
+	_, err = performCostAssessment(collectors, schemaAssessment, map[string]string{"peakToAverageRatio": "0"})
+	assert.Error(t, err)
+
+	_, err = performCostAssessment(collectors, schemaAssessment, map[string]string{"pricingFile": "missing.json"})
+	assert.Error(t, err)
+	_, err = performCostAssessment(collectors, nil, map[string]string{})
+	assert.Error(t, err)
+}
+
 func generateSampleConv() *internal.Conv {
 	conv := &internal.Conv{
 		SrcSchema: map[string]schema.Table{
@@ -593,6 +793,20 @@ func TestIsCharsetCompatible(t *testing.T) {
 	}
 }
 
+func TestGetQueryTranslator(t *testing.T) {
+	conv := internal.MakeConv()
+	assert.Equal(t, mysql.QueryTranslatorImpl{Conv: conv}, getQueryTranslator(constants.MYSQL, conv))
+	assert.Equal(t, mysql.QueryTranslatorImpl{Conv: conv}, getQueryTranslator(constants.MYSQLDUMP, conv))
+	assert.Nil(t, getQueryTranslator(constants.POSTGRES, conv))
+}
+
+func TestGetSourceSpecificComparison(t *testing.T) {
+	assert.IsType(t, mysql.SourceSpecificComparisonImpl{}, getSourceSpecificComparison(constants.MYSQL))
+	assert.IsType(t, postgres.SourceSpecificComparisonImpl{}, getSourceSpecificComparison(constants.POSTGRES))
+	assert.IsType(t, sqlserver.SourceSpecificComparisonImpl{}, getSourceSpecificComparison(constants.SQLSERVER))
+	assert.IsType(t, oracle.SourceSpecificComparisonImpl{}, getSourceSpecificComparison(constants.ORACLE))
+}
+
 func TestTableSizeDiffBytes(t *testing.T) {
 	// Note: This test reflects the current dummy implementation.
 	// It should be updated when the function logic is implemented.
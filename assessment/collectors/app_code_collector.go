@@ -21,6 +21,7 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -28,10 +29,10 @@ import (
 	"strings"
 	"sync"
 
-	"cloud.google.com/go/vertexai/genai"
 	assessment "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/embeddings"
 	parser "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/parser"
 	dependencyAnalyzer "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/project_analyzer"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/llm"
 	utils "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/task"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
@@ -47,22 +48,6 @@ var daoMigrationPromptTemplate string
 //go:embed prompts/non-dao-migration-prompt.txt
 var nonDAOMigrationPromptTemplate string
 
-// generativeModel defines an interface for interacting with a generative AI model.
-type generativeModel interface {
-	GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error)
-	SetResponseMIMEType(string)
-}
-
-// genaiModelWrapper wraps the actual genai.GenerativeModel to implement the generativeModel interface.
-type genaiModelWrapper struct {
-	*genai.GenerativeModel
-}
-
-// SetResponseMIMEType sets the response MIME type for the underlying model.
-func (w *genaiModelWrapper) SetResponseMIMEType(mimeType string) {
-	w.GenerativeModel.ResponseMIMEType = mimeType
-}
-
 // AppCodeAssessor defines the interface for any component that can analyze application code.
 type AppCodeAssessor interface {
 	AnalyzeProject(ctx context.Context) (*utils.CodeAssessment, []utils.QueryTranslationResult, error)
@@ -70,11 +55,9 @@ type AppCodeAssessor interface {
 
 // MigrationCodeSummarizer holds the LLM models and configurations for code migration assessment.
 type MigrationCodeSummarizer struct {
-	gcpProjectID               string
-	gcpLocation                string
-	aiClient                   *genai.Client
-	geminiProModel             generativeModel
-	geminiFlashModel           generativeModel
+	llmProvider                llm.Provider
+	geminiProModel             llm.GenerativeModel
+	geminiFlashModel           llm.GenerativeModel
 	codeSampleDatabase         *assessment.MysqlConceptDb
 	querySampleDatabase        *assessment.MysqlConceptDb
 	sourceDatabaseFramework    string
@@ -137,12 +120,13 @@ var SupportedFrameworkCombinations = map[FrameworkPair]bool{
 	// Add more allowed combinations here
 }
 
-// NewMigrationCodeSummarizer initializes a new MigrationCodeSummarizer.
+// NewMigrationCodeSummarizer initializes a new MigrationCodeSummarizer, which
+// uses the models of llmProvider.
 // ToDo:Add Unit Tests
 func NewMigrationCodeSummarizer(
 	ctx context.Context,
-	googleGenerativeAIAPIKey *string,
-	projectID, location, sourceSchema, targetSchema, projectPath, language, sourceFramework, targetFramework string,
+	llmProvider llm.Provider,
+	sourceSchema, targetSchema, projectPath, language, sourceFramework, targetFramework string,
 ) (*MigrationCodeSummarizer, error) {
 
 	if language == "" {
@@ -172,31 +156,26 @@ func NewMigrationCodeSummarizer(
 		return nil, fmt.Errorf("source-target framework '%s'-'%s' combination not supported. Supported frameworks are: %v", sourceFramework, targetFramework, SupportedFrameworkCombinations)
 	}
 
-	if googleGenerativeAIAPIKey != nil {
-		os.Setenv("GOOGLE_API_KEY", *googleGenerativeAIAPIKey)
-	}
-
-	client, err := genai.NewClient(ctx, projectID, location)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Vertex AI client: %w", err)
-	}
-
-	codeSampleDB, err := assessment.NewMysqlToSpannerCodeDb(projectID, location, strings.ToLower(sourceFramework)+"_"+strings.ToLower(targetFramework))
-	if err != nil {
+	// The sample databases only enrich the prompts, so the providers without
+	// an embedding model go without them.
+	codeSampleDB, err := assessment.NewMysqlToSpannerCodeDb(ctx, llmProvider, strings.ToLower(sourceFramework)+"_"+strings.ToLower(targetFramework))
+	if errors.Is(err, llm.ErrNoEmbeddingModel) {
+		logger.Log.Warn("no embedding model, the code samples will not be used")
+	} else if err != nil {
 		return nil, fmt.Errorf("failed to load code sample DB: %w", err)
 	}
 
-	querySampleDB, err := assessment.NewMysqlToSpannerQueryDb(projectID, location)
-	if err != nil {
+	querySampleDB, err := assessment.NewMysqlToSpannerQueryDb(ctx, llmProvider)
+	if errors.Is(err, llm.ErrNoEmbeddingModel) {
+		logger.Log.Warn("no embedding model, the MySQL query samples will not be used")
+	} else if err != nil {
 		return nil, fmt.Errorf("failed to load MySQL query sample DB: %w", err)
 	}
 
 	summarizer := &MigrationCodeSummarizer{
-		gcpProjectID:               projectID,
-		gcpLocation:                location,
-		aiClient:                   client,
-		geminiProModel:             &genaiModelWrapper{client.GenerativeModel(utils.GEMINI_PRO_MODEL)},
-		geminiFlashModel:           &genaiModelWrapper{client.GenerativeModel(utils.GEMINI_FLASH_MODEL)},
+		llmProvider:                llmProvider,
+		geminiProModel:             llmProvider.GenerativeModel(llm.ProModel),
+		geminiFlashModel:           llmProvider.GenerativeModel(llm.FlashModel),
 		codeSampleDatabase:         codeSampleDB,
 		projectDependencyAnalyzer:  projectDependencyAnalyzer,
 		sourceDatabaseSchema:       sourceSchema,
@@ -228,21 +207,16 @@ func (m *MigrationCodeSummarizer) InvokeCodeConversion(
 	prompt = strings.ReplaceAll(prompt, "{{NEW_SCHEMA}}", newSchema)
 
 	retryClient := utils.DefaultLLMRetryClient{}
-	response, err := retryClient.GenerateContentWithRetry(ctx, m.geminiFlashModel.(*genaiModelWrapper).GenerativeModel, genai.Text(prompt), 5, logger.Log)
+	response, err := retryClient.GenerateContentWithRetry(ctx, m.geminiFlashModel, prompt, 5, logger.Log)
 	if err != nil {
 		return "", err
 	}
 	logger.Log.Debug("LLM Token Usage (Initial Conversion): ",
-		zap.Int32("Prompt Tokens", response.UsageMetadata.PromptTokenCount),
-		zap.Int32("Candidate Tokens", response.UsageMetadata.CandidatesTokenCount),
-		zap.Int32("Total Tokens", response.UsageMetadata.TotalTokenCount))
+		zap.Int32("Prompt Tokens", response.PromptTokens),
+		zap.Int32("Candidate Tokens", response.CandidateTokens),
+		zap.Int32("Total Tokens", response.TotalTokens))
 
-	var llmResponse string
-	if response.Candidates != nil && len(response.Candidates) > 0 && len(response.Candidates[0].Content.Parts) > 0 {
-		if part, ok := response.Candidates[0].Content.Parts[0].(genai.Text); ok {
-			llmResponse = string(part)
-		}
-	}
+	llmResponse := response.Text
 
 	llmResponse = m.parseJSONWithRetries(m.geminiFlashModel, prompt, llmResponse, identifier)
 
@@ -260,7 +234,7 @@ func (m *MigrationCodeSummarizer) InvokeCodeConversion(
 
 		for i, question := range questionOutput.Questions {
 			// Search in code samples database
-			relevantRecords := m.codeSampleDatabase.Search([]string{question}, m.gcpProjectID, m.gcpLocation, 0.25, 2)
+			relevantRecords := m.codeSampleDatabase.Search(ctx, []string{question}, 0.25, 2)
 			if len(relevantRecords) > 0 {
 				answersPresent = true
 				for _, record := range relevantRecords {
@@ -273,7 +247,7 @@ func (m *MigrationCodeSummarizer) InvokeCodeConversion(
 			}
 
 			// Search in MySQL query samples database
-			queryRecords := m.querySampleDatabase.Search([]string{question}, m.gcpProjectID, m.gcpLocation, 0.25, 2)
+			queryRecords := m.querySampleDatabase.Search(ctx, []string{question}, 0.25, 2)
 			if len(queryRecords) > 0 {
 				answersPresent = true
 				for _, record := range queryRecords {
@@ -292,21 +266,17 @@ func (m *MigrationCodeSummarizer) InvokeCodeConversion(
 		}
 	}
 
-	finalResponse, err := retryClient.GenerateContentWithRetry(ctx, m.geminiProModel.(*genaiModelWrapper).GenerativeModel, genai.Text(finalPrompt), 5, logger.Log)
+	finalResponse, err := retryClient.GenerateContentWithRetry(ctx, m.geminiProModel, finalPrompt, 5, logger.Log)
 	if err != nil {
 		logger.Log.Error("Error generating final content:", zap.Error(err))
 		return "", err
 	}
 	logger.Log.Debug("LLM Token Usage (Final Conversion): ",
-		zap.Int32("Prompt Tokens", finalResponse.UsageMetadata.PromptTokenCount),
-		zap.Int32("Candidate Tokens", finalResponse.UsageMetadata.CandidatesTokenCount),
-		zap.Int32("Total Tokens", finalResponse.UsageMetadata.TotalTokenCount))
+		zap.Int32("Prompt Tokens", finalResponse.PromptTokens),
+		zap.Int32("Candidate Tokens", finalResponse.CandidateTokens),
+		zap.Int32("Total Tokens", finalResponse.TotalTokens))
 
-	if len(finalResponse.Candidates) > 0 && len(finalResponse.Candidates[0].Content.Parts) > 0 {
-		if part, ok := finalResponse.Candidates[0].Content.Parts[0].(genai.Text); ok {
-			llmResponse = string(part)
-		}
-	}
+	llmResponse = finalResponse.Text
 
 	logger.Log.Debug("Final LLM Response: ", zap.String("response", llmResponse))
 
@@ -333,7 +303,7 @@ func formatQuestionsAndSearchResults(questions []string, codeSearchResults [][]s
 	return formattedString
 }
 
-func (m *MigrationCodeSummarizer) parseJSONWithRetries(model generativeModel, originalPrompt string, originalResponse string, identifier string) string {
+func (m *MigrationCodeSummarizer) parseJSONWithRetries(model llm.GenerativeModel, originalPrompt string, originalResponse string, identifier string) string {
 	jsonFixPromptTemplate := `
         You are a JSON parser expert tasked with fixing parsing errors in JSON string. Golang's json.Unmarshal library is
         being used for parsing the json string. The following JSON string is currently failing with error message: %s.
@@ -372,19 +342,16 @@ func (m *MigrationCodeSummarizer) parseJSONWithRetries(model generativeModel, or
 		logger.Log.Debug("JSON Parsing Retry Prompt: ", zap.String("prompt", newPrompt))
 
 		retryClient := utils.DefaultLLMRetryClient{}
-		resp, err := retryClient.GenerateContentWithRetry(context.Background(), model.(*genaiModelWrapper).GenerativeModel, genai.Text(newPrompt), 5, logger.Log)
+		resp, err := retryClient.GenerateContentWithRetry(context.Background(), model, newPrompt, 5, logger.Log)
 		if err != nil {
 			logger.Log.Warn("Failed to get response from LLM for JSON parsing retry: ", zap.Error(err))
+			continue
 		}
 		logger.Log.Debug("LLM Token Usage (JSON Parsing Retry): ",
-			zap.Int32("Prompt Tokens", resp.UsageMetadata.PromptTokenCount),
-			zap.Int32("Candidate Tokens", resp.UsageMetadata.CandidatesTokenCount),
-			zap.Int32("Total Tokens", resp.UsageMetadata.TotalTokenCount))
-		if len(resp.Candidates) > 0 && len(resp.Candidates[0].Content.Parts) > 0 {
-			if part, ok := resp.Candidates[0].Content.Parts[0].(genai.Text); ok {
-				originalResponse = string(part)
-			}
-		}
+			zap.Int32("Prompt Tokens", resp.PromptTokens),
+			zap.Int32("Candidate Tokens", resp.CandidateTokens),
+			zap.Int32("Total Tokens", resp.TotalTokens))
+		originalResponse = resp.Text
 	}
 	logger.Log.Warn("Failed to parse JSON after multiple retries for identifier: ", zap.String("identifier", identifier), zap.String("originalResponse", originalResponse))
 	return ""
@@ -450,21 +417,17 @@ func (m *MigrationCodeSummarizer) AnalyzeFile(ctx context.Context, projectPath,
 		logger.Log.Debug("Analyzing Non-DAO File: ", zap.String("filepath", filepath))
 		prompt := m.getPromptForNonDAOClass(content, filepath, &methodChanges)
 		retryClient := utils.DefaultLLMRetryClient{}
-		response, err := retryClient.GenerateContentWithRetry(ctx, m.geminiFlashModel.(*genaiModelWrapper).GenerativeModel, genai.Text(prompt), 5, logger.Log)
+		response, err := retryClient.GenerateContentWithRetry(ctx, m.geminiFlashModel, prompt, 5, logger.Log)
 
 		if err != nil {
 			return &FileAnalysisResponse{codeAssessment, extractedMethodSignatures, projectPath, filepath, queryResults}
 		}
 		logger.Log.Debug("LLM Token Usage (Non-DAO Analysis): ",
-			zap.Int32("Prompt Tokens", response.UsageMetadata.PromptTokenCount),
-			zap.Int32("Candidate Tokens", response.UsageMetadata.CandidatesTokenCount),
-			zap.Int32("Total Tokens", response.UsageMetadata.TotalTokenCount))
+			zap.Int32("Prompt Tokens", response.PromptTokens),
+			zap.Int32("Candidate Tokens", response.CandidateTokens),
+			zap.Int32("Total Tokens", response.TotalTokens))
 
-		if response.Candidates != nil && len(response.Candidates) > 0 && len(response.Candidates[0].Content.Parts) > 0 {
-			if part, ok := response.Candidates[0].Content.Parts[0].(genai.Text); ok {
-				llmResponse = string(part)
-			}
-		}
+		llmResponse = response.Text
 
 		llmResponse = m.parseJSONWithRetries(m.geminiFlashModel, prompt, llmResponse, "analyze-non-dao-class-"+filepath)
 		isDataAccessObject = false
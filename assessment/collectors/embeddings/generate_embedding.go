@@ -22,12 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 
-	aiplatform "cloud.google.com/go/aiplatform/apiv1"
-	"cloud.google.com/go/aiplatform/apiv1/aiplatformpb"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
-	"github.com/googleapis/gax-go/v2"
-	"google.golang.org/api/option"
-	"google.golang.org/protobuf/types/known/structpb"
 )
 
 //go:embed go_concept_examples.json
@@ -55,13 +50,13 @@ type MySqlMigrationConcept struct {
 	Embedding []float32 `json:"embedding,omitempty"`
 }
 
-// PredictionClientInterface allows mocking
-type PredictionClientInterface interface {
-	Predict(context.Context, *aiplatformpb.PredictRequest, ...gax.CallOption) (*aiplatformpb.PredictResponse, error)
-	Close() error
+// Embedder computes the embedding vectors of texts. It is implemented by the
+// llm providers and allows mocking.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
 }
 
-func createCodeSampleEmbeddings(ctx context.Context, client PredictionClientInterface, project, location, model, sourceTargetFramework string) ([]MySqlMigrationConcept, error) {
+func createCodeSampleEmbeddings(ctx context.Context, embedder Embedder, sourceTargetFramework string) ([]MySqlMigrationConcept, error) {
 	var data []byte
 	switch sourceTargetFramework {
 	case "go-sql-driver/mysql_go-sql-spanner":
@@ -80,64 +75,32 @@ func createCodeSampleEmbeddings(ctx context.Context, client PredictionClientInte
 	if err := json.Unmarshal(data, &concepts); err != nil {
 		return nil, err
 	}
-	return attachEmbeddings(ctx, client, project, location, model, concepts)
+	return attachEmbeddings(ctx, embedder, concepts)
 }
 
-func createQuerySampleEmbeddings(ctx context.Context, client PredictionClientInterface, project, location, model string) ([]MySqlMigrationConcept, error) {
+func createQuerySampleEmbeddings(ctx context.Context, embedder Embedder) ([]MySqlMigrationConcept, error) {
 	var queryExamples []MySqlMigrationConcept
 	if err := json.Unmarshal(utils.QueryTranslationExamples, &queryExamples); err != nil {
 		return nil, fmt.Errorf("failed to parse MySQL query examples JSON: %w", err)
 	}
-	return attachEmbeddings(ctx, client, project, location, model, queryExamples)
+	return attachEmbeddings(ctx, embedder, queryExamples)
 }
 
-func attachEmbeddings(ctx context.Context, client PredictionClientInterface, project, location, model string, concepts []MySqlMigrationConcept) ([]MySqlMigrationConcept, error) {
-	var instances []*structpb.Value
-	for _, c := range concepts {
-		instances = append(instances, structpb.NewStructValue(&structpb.Struct{
-			Fields: map[string]*structpb.Value{
-				"content":   structpb.NewStringValue(c.Example),
-				"task_type": structpb.NewStringValue("SEMANTIC_SIMILARITY"),
-			},
-		}))
+func attachEmbeddings(ctx context.Context, embedder Embedder, concepts []MySqlMigrationConcept) ([]MySqlMigrationConcept, error) {
+	examples := make([]string, len(concepts))
+	for i, c := range concepts {
+		examples[i] = c.Example
 	}
 
-	req := &aiplatformpb.PredictRequest{
-		Endpoint:  fmt.Sprintf("projects/%s/locations/%s/publishers/google/models/%s", project, location, model),
-		Instances: instances,
-	}
-
-	resp, err := client.Predict(ctx, req)
+	embeddings, err := embedder.Embed(ctx, examples)
 	if err != nil {
 		return nil, err
 	}
 
-	for i, prediction := range resp.Predictions {
-		values := prediction.GetStructValue().GetFields()["embeddings"].GetStructValue().GetFields()["values"].GetListValue().GetValues()
-		if values == nil {
-			continue
+	for i, embedding := range embeddings {
+		if i < len(concepts) {
+			concepts[i].Embedding = embedding
 		}
-		embedding := make([]float32, len(values))
-		for j, v := range values {
-			if v == nil {
-				continue
-			}
-			embedding[j] = float32(v.GetNumberValue())
-		}
-		concepts[i].Embedding = embedding
 	}
 	return concepts, nil
 }
-
-// Helper to create a new Vertex AI Prediction client and return context, client, and model
-func newAIPredictionClient(location string) (context.Context, PredictionClientInterface, string, error) {
-	ctx := context.Background()
-	apiEndpoint := fmt.Sprintf("%s-aiplatform.googleapis.com:443", location)
-	model := "gemini-embedding-001"
-
-	client, err := aiplatform.NewPredictionClient(ctx, option.WithEndpoint(apiEndpoint))
-	if err != nil {
-		return nil, nil, "", err
-	}
-	return ctx, client, model, nil
-}
@@ -21,51 +21,31 @@ import (
 	"errors"
 	"testing"
 
-	"cloud.google.com/go/aiplatform/apiv1/aiplatformpb"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
-	"github.com/googleapis/gax-go/v2"
 	"github.com/stretchr/testify/assert"
-	"google.golang.org/protobuf/types/known/structpb"
 )
 
-// fakeClient satisfies PredictionClientInterface
+// fakeClient satisfies Embedder
 type fakeClient struct {
-	predictResp *aiplatformpb.PredictResponse
+	embeddings  [][]float32
 	predictErr  error
 	closeCalled bool
 }
 
-func (f *fakeClient) Predict(ctx context.Context, req *aiplatformpb.PredictRequest, _ ...gax.CallOption) (*aiplatformpb.PredictResponse, error) {
+func (f *fakeClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
 	if f.predictErr != nil {
 		return nil, f.predictErr
 	}
-	if f.predictResp != nil {
-		return f.predictResp, nil
+	if f.embeddings != nil {
+		return f.embeddings, nil
 	}
 
 	// Default response with embedding [0.1, 0.2, 0.3]
-	listVal := &structpb.ListValue{
-		Values: []*structpb.Value{
-			structpb.NewNumberValue(0.1),
-			structpb.NewNumberValue(0.2),
-			structpb.NewNumberValue(0.3),
-		},
+	embeddings := make([][]float32, len(texts))
+	for i := range texts {
+		embeddings[i] = []float32{0.1, 0.2, 0.3}
 	}
-	structVal := &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"embeddings": structpb.NewStructValue(&structpb.Struct{
-				Fields: map[string]*structpb.Value{
-					"values": structpb.NewListValue(listVal),
-				},
-			}),
-		},
-	}
-
-	return &aiplatformpb.PredictResponse{
-		Predictions: []*structpb.Value{
-			structpb.NewStructValue(structVal),
-		},
-	}, nil
+	return embeddings, nil
 }
 
 func (f *fakeClient) Close() error {
@@ -88,7 +68,7 @@ func TestCreateCodeSampleEmbeddings(t *testing.T) {
 	]`)
 
 	client := &fakeClient{}
-	concepts, err := createCodeSampleEmbeddings(ctx, client, "test-proj", "us-central1", "mock-model", "go-sql-driver/mysql_go-sql-spanner")
+	concepts, err := createCodeSampleEmbeddings(ctx, client, "go-sql-driver/mysql_go-sql-spanner")
 
 	assert.NoError(t, err)
 	assert.Len(t, concepts, 1)
@@ -110,7 +90,7 @@ func TestCreateCodeSampleEmbeddingsJava(t *testing.T) {
 	]`)
 
 	client := &fakeClient{}
-	concepts, err := createCodeSampleEmbeddings(ctx, client, "test-proj", "us-central1", "mock-model", "jdbc_jdbc")
+	concepts, err := createCodeSampleEmbeddings(ctx, client, "jdbc_jdbc")
 
 	assert.NoError(t, err)
 	assert.Len(t, concepts, 1)
@@ -121,7 +101,7 @@ func TestCreateCodeSampleEmbeddings_UnsupportedLanguage(t *testing.T) {
 	ctx := context.Background()
 	client := &fakeClient{}
 
-	concepts, err := createCodeSampleEmbeddings(ctx, client, "test-proj", "us-central1", "mock-model", "python")
+	concepts, err := createCodeSampleEmbeddings(ctx, client, "python")
 
 	assert.Nil(t, concepts)
 	assert.Error(t, err)
@@ -131,7 +111,7 @@ func TestCreateCodeSampleEmbeddings_PredictError(t *testing.T) {
 	ctx := context.Background()
 	client := &fakeClient{predictErr: errors.New("predict failure")}
 
-	_, err := createCodeSampleEmbeddings(ctx, client, "test-proj", "us-central1", "mock-model", "go-sql-driver/mysql_go-sql-spanner")
+	_, err := createCodeSampleEmbeddings(ctx, client, "go-sql-driver/mysql_go-sql-spanner")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "predict failure")
 }
@@ -144,7 +124,7 @@ func TestCreateCodeSampleEmbeddings_InvalidJSON(t *testing.T) {
 	defer func() { goMysqlMigrationConcept = oldGoConcept }()
 
 	client := &fakeClient{}
-	_, err := createCodeSampleEmbeddings(ctx, client, "test-proj", "us-central1", "mock-model", "go-sql-driver/mysql_go-sql-spanner")
+	_, err := createCodeSampleEmbeddings(ctx, client, "go-sql-driver/mysql_go-sql-spanner")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid character")
 }
@@ -171,7 +151,7 @@ func TestCreateQueryExampleEmbeddingsWithClient(t *testing.T) {
 	]`)
 	ctx := context.Background()
 	client := &fakeClient{}
-	concepts, err := createQuerySampleEmbeddings(ctx, client, "test-proj", "us-central1", "mock-model")
+	concepts, err := createQuerySampleEmbeddings(ctx, client)
 	assert.NoError(t, err)
 	assert.Len(t, concepts, 1)
 	assert.Equal(t, "1", concepts[0].ID)
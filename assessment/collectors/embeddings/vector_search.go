@@ -19,55 +19,38 @@ package assessment
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"log"
 	"math"
 	"sort"
 
-	aiplatform "cloud.google.com/go/aiplatform/apiv1"
-	"cloud.google.com/go/aiplatform/apiv1/aiplatformpb"
-	"google.golang.org/api/option"
-	"google.golang.org/protobuf/types/known/structpb"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"go.uber.org/zap"
 )
 
-const EmbeddingModel = "text-embedding-preview-0815"
-
 type MysqlConceptDb struct {
-	data map[string]MySqlMigrationConcept
+	data     map[string]MySqlMigrationConcept
+	embedder Embedder
 }
 
-func NewMysqlToSpannerCodeDb(projectId, location, sourceTargetFramework string) (*MysqlConceptDb, error) {
-	ctx, client, model, err := newAIPredictionClient(location)
-	if err != nil {
-		return nil, err
-	}
-	defer client.Close()
-
-	mysqlMigrationConcepts, err := createCodeSampleEmbeddings(ctx, client, projectId, location, model, sourceTargetFramework)
+func NewMysqlToSpannerCodeDb(ctx context.Context, embedder Embedder, sourceTargetFramework string) (*MysqlConceptDb, error) {
+	mysqlMigrationConcepts, err := createCodeSampleEmbeddings(ctx, embedder, sourceTargetFramework)
 	if err != nil {
 		return nil, err
 	}
 
-	db := &MysqlConceptDb{data: make(map[string]MySqlMigrationConcept)}
+	db := &MysqlConceptDb{data: make(map[string]MySqlMigrationConcept), embedder: embedder}
 	for _, concept := range mysqlMigrationConcepts {
 		db.data[concept.ID] = concept
 	}
 	return db, nil
 }
 
-func NewMysqlToSpannerQueryDb(projectId, location string) (*MysqlConceptDb, error) {
-	ctx, client, model, err := newAIPredictionClient(location)
+func NewMysqlToSpannerQueryDb(ctx context.Context, embedder Embedder) (*MysqlConceptDb, error) {
+	mysqlQueryExamples, err := createQuerySampleEmbeddings(ctx, embedder)
 	if err != nil {
 		return nil, err
 	}
-	defer client.Close()
 
-	mysqlQueryExamples, err := createQuerySampleEmbeddings(ctx, client, projectId, location, model)
-	if err != nil {
-		return nil, err
-	}
-
-	db := &MysqlConceptDb{data: make(map[string]MySqlMigrationConcept)}
+	db := &MysqlConceptDb{data: make(map[string]MySqlMigrationConcept), embedder: embedder}
 	for _, concept := range mysqlQueryExamples {
 		db.data[concept.ID] = concept
 	}
@@ -75,6 +58,9 @@ func NewMysqlToSpannerQueryDb(projectId, location string) (*MysqlConceptDb, erro
 }
 
 func cosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
 	var dotProduct, normA, normB float32
 	for i := range a {
 		dotProduct += a[i] * b[i]
@@ -87,58 +73,14 @@ func cosineSimilarity(a, b []float32) float32 {
 	return dotProduct / (float32(math.Sqrt(float64(normA))) * float32(math.Sqrt(float64(normB))))
 }
 
-// We make embedTextsFunc a variable so it can be overridden in tests.
-var embedTextsFunc = embedTexts
-
-func embedTexts(project, location string, texts []string) ([][]float32, error) {
-	ctx := context.Background()
-	client, err := aiplatform.NewPredictionClient(ctx, option.WithEndpoint(location+"-aiplatform.googleapis.com:443"))
-	if err != nil {
-		return nil, err
-	}
-	defer client.Close()
-
-	endpoint := fmt.Sprintf("projects/%s/locations/%s/publishers/google/models/%s", project, location, EmbeddingModel)
-
-	instances := make([]*structpb.Value, len(texts))
-	for i, text := range texts {
-		instances[i] = structpb.NewStructValue(&structpb.Struct{
-			Fields: map[string]*structpb.Value{
-				"content":   structpb.NewStringValue(text),
-				"task_type": structpb.NewStringValue("SEMANTIC_SIMILARITY"),
-			},
-		})
-	}
-
-	req := &aiplatformpb.PredictRequest{
-		Endpoint:  endpoint,
-		Instances: instances,
-	}
-
-	resp, err := client.Predict(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	var embeddings [][]float32
-	for _, prediction := range resp.Predictions {
-		values := prediction.GetStructValue().Fields["embeddings"].GetStructValue().Fields["values"].GetListValue().Values
-		vector := make([]float32, len(values))
-		for j, value := range values {
-			vector[j] = float32(value.GetNumberValue())
-		}
-		embeddings = append(embeddings, vector)
-	}
-	return embeddings, nil
-}
-
-func (db *MysqlConceptDb) Search(searchTerms []string, project, location string, distance float32, topK int) map[string]map[string]interface{} {
-	if len(searchTerms) == 0 {
+func (db *MysqlConceptDb) Search(ctx context.Context, searchTerms []string, distance float32, topK int) map[string]map[string]interface{} {
+	if db == nil || len(searchTerms) == 0 {
 		return nil
 	}
-	searchEmbeddings, err := embedTextsFunc(project, location, searchTerms)
+	searchEmbeddings, err := db.embedder.Embed(ctx, searchTerms)
 	if err != nil {
-		log.Fatalf("Failed to get embeddings: %v", err)
+		logger.Log.Warn("Failed to get embeddings", zap.Error(err))
+		return nil
 	}
 
 	targetSimilarity := 1 - distance
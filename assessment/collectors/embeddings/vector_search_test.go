@@ -17,13 +17,23 @@
 package assessment
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
+func init() {
+	logger.Log = zap.NewNop()
+}
+
 // fakeEmbedder returns dummy embeddings with fixed values.
-func fakeEmbedder(project, location string, texts []string) ([][]float32, error) {
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
 	// Each embedding is a vector of length 3 with arbitrary fixed values.
 	embeddings := make([][]float32, len(texts))
 	for i := range texts {
@@ -38,15 +48,12 @@ func TestCosineSimilarity(t *testing.T) {
 	c := []float32{1, 0, 0}
 	assert.Equal(t, float32(0), cosineSimilarity(a, b), "Orthogonal vectors should have 0 similarity")
 	assert.Equal(t, float32(1), cosineSimilarity(a, c), "Same vectors should have similarity 1")
+	assert.Equal(t, float32(0), cosineSimilarity(a, []float32{1, 0}), "Vectors of different models should have 0 similarity")
 }
 func TestSearch(t *testing.T) {
-	// Override the embedTextsFunc to avoid real API calls
-	original := embedTextsFunc
-	embedTextsFunc = fakeEmbedder
-	defer func() { embedTextsFunc = original }()
-
-	// Setup a db with one concept with embedding vector {1,0,0}
+	// Setup a db with one concept with embedding vector {1,0,0}, with a fake embedder to avoid real API calls
 	db := &MysqlConceptDb{
+		embedder: fakeEmbedder{},
 		data: map[string]MySqlMigrationConcept{
 			"1": {
 				ID:      "1",
@@ -74,7 +81,7 @@ func TestSearch(t *testing.T) {
 		},
 	}
 
-	results := db.Search([]string{"test"}, "test-project", "us-central1", 0.1, 5)
+	results := db.Search(context.Background(), []string{"test"}, 0.1, 5)
 	assert.NotNil(t, results)
 	assert.Contains(t, results, "1")
 
@@ -94,6 +101,29 @@ func TestSearch(t *testing.T) {
 
 func TestSearch_NoTerms(t *testing.T) {
 	db := &MysqlConceptDb{data: make(map[string]MySqlMigrationConcept)}
-	results := db.Search([]string{}, "test-project", "us-central1", 0.1, 5)
+	results := db.Search(context.Background(), []string{}, 0.1, 5)
+	assert.Nil(t, results)
+}
+
+func TestSearch_NilDb(t *testing.T) {
+	var db *MysqlConceptDb
+	assert.Nil(t, db.Search(context.Background(), []string{"test"}, 0.1, 5))
+}
+
+func TestSearch_EmbedError(t *testing.T) {
+	db := &MysqlConceptDb{
+		embedder: &fakeClient{predictErr: errors.New("embedding failure")},
+		data:     map[string]MySqlMigrationConcept{"1": {ID: "1", Embedding: []float32{1, 0, 0}}},
+	}
+	results := db.Search(context.Background(), []string{"test"}, 0.1, 5)
 	assert.Nil(t, results)
 }
+
+func TestNewMysqlToSpannerQueryDb(t *testing.T) {
+	db, err := NewMysqlToSpannerQueryDb(context.Background(), fakeEmbedder{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, db.data)
+
+	_, err = NewMysqlToSpannerQueryDb(context.Background(), &fakeClient{predictErr: errors.New("embedding failure")})
+	assert.ErrorContains(t, err, "embedding failure")
+}
@@ -23,6 +23,9 @@ import (
 	collectorCommon "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/common"
 	common "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/oracle"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/sqlserver"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -39,6 +42,7 @@ type InfoSchemaCollector struct {
 	storedProcedures []utils.StoredProcedureAssessmentInfo
 	functions        []utils.FunctionAssessmentInfo
 	views            []utils.ViewAssessmentInfo
+	tableSizes       []utils.TableSizeAssessmentInfo
 	conv             *internal.Conv
 }
 
@@ -59,6 +63,7 @@ func BuildInfoSchemaCollector(tables map[string]utils.TableAssessmentInfo,
 	storedProcedures []utils.StoredProcedureAssessmentInfo,
 	functions []utils.FunctionAssessmentInfo,
 	views []utils.ViewAssessmentInfo,
+	tableSizes []utils.TableSizeAssessmentInfo,
 	conv *internal.Conv) (InfoSchemaCollector, error) {
 	return InfoSchemaCollector{
 		tables:           tables,
@@ -67,6 +72,7 @@ func BuildInfoSchemaCollector(tables map[string]utils.TableAssessmentInfo,
 		storedProcedures: storedProcedures,
 		functions:        functions,
 		views:            views,
+		tableSizes:       tableSizes,
 		conv:             conv,
 	}, nil
 }
@@ -114,6 +120,10 @@ func GetInfoSchemaCollector(conv *internal.Conv, sourceProfile profiles.SourcePr
 	if err != nil {
 		errString = errString + fmt.Sprintf("\nError while scanning views: %v", err)
 	}
+	tableSizes, err := infoSchema.GetTableSizeInfo()
+	if err != nil {
+		errString = errString + fmt.Sprintf("\nError while scanning table sizes: %v", err)
+	}
 	err = nil
 	if errString != "" {
 		err = fmt.Errorf(errString, "")
@@ -126,6 +136,7 @@ func GetInfoSchemaCollector(conv *internal.Conv, sourceProfile profiles.SourcePr
 		storedProcedures: sps,
 		functions:        functions,
 		views:            views,
+		tableSizes:       tableSizes,
 	}, err
 }
 
@@ -152,6 +163,22 @@ func getInfoSchema(db *sql.DB, sourceProfile profiles.SourceProfile) (common.Inf
 			Db:     db,
 			DbName: sourceProfile.Conn.Mysql.Db,
 		}, nil
+	case constants.POSTGRES:
+		return postgres.InfoSchemaImpl{
+			Db:     db,
+			DbName: sourceProfile.Conn.Pg.Db,
+		}, nil
+	case constants.SQLSERVER:
+		return sqlserver.InfoSchemaImpl{
+			Db:     db,
+			DbName: sourceProfile.Conn.SqlServer.Db,
+		}, nil
+	case constants.ORACLE:
+		// Objects are read from the schema of the user, like in sources/oracle.
+		return oracle.InfoSchemaImpl{
+			Db:     db,
+			DbName: strings.ToUpper(sourceProfile.Conn.Oracle.User),
+		}, nil
 	default:
 		return nil, fmt.Errorf("driver %s not supported", driver)
 	}
@@ -273,7 +300,7 @@ func (c InfoSchemaCollector) ListIndexes() (map[string]utils.SrcIndexDetails, ma
 				TableId:   c.indexes[i].TableId,
 				IsUnique:  c.indexes[i].IndexDef.Unique,
 				TableName: c.conv.SpSchema[c.indexes[i].TableId].Name,
-				Ddl:       getSpannerIndex(c.indexes[i].IndexDef.Id, c.conv.SpSchema[c.indexes[i].TableId]).PrintCreateIndex(c.conv.SpSchema[c.indexes[i].TableId], ddl.Config{}),
+				Ddl:       getSpannerIndex(c.indexes[i].IndexDef.Id, c.conv.SpSchema[c.indexes[i].TableId]).PrintCreateIndex(c.conv.SpSchema, c.conv.SpSchema[c.indexes[i].TableId], ddl.Config{}),
 			}
 		}
 	}
@@ -323,17 +350,35 @@ func (c InfoSchemaCollector) ListViews() map[string]utils.ViewAssessment {
 	viewAssessmentOutput := make(map[string]utils.ViewAssessment)
 	for _, view := range c.views {
 		viewId := internal.GenerateViewId()
+		viewType := "NON-MATERIALIZED"
+		if view.IsMaterialized {
+			viewType = "MATERIALIZED"
+		}
 		viewAssessmentOutput[viewId] = utils.ViewAssessment{
 			Id:            viewId,
 			SrcName:       view.Name,
 			SrcDefinition: view.Definition,
-			SrcViewType:   "NON-MATERIALIZED", // Views are always non-materialized in MySQL
+			SrcViewType:   viewType,
 			SpName:        internal.GetSpannerValidName(c.conv, view.Name),
 		}
 	}
 	return viewAssessmentOutput
 }
 
+// ListTableSizes returns the sizes of the source tables, by table id. The
+// tables the source has no statistics of are missing.
+func (c InfoSchemaCollector) ListTableSizes() map[string]utils.TableSizeAssessmentInfo {
+	tableSizes := make(map[string]utils.TableSizeAssessmentInfo)
+	for _, tableSize := range c.tableSizes {
+		tableId, err := internal.GetTableIdFromSrcName(c.conv.SrcSchema, tableSize.Name)
+		if err != nil {
+			continue
+		}
+		tableSizes[tableId] = tableSize
+	}
+	return tableSizes
+}
+
 func (c InfoSchemaCollector) ListColumnDefinitions() (map[string]utils.SrcColumnDetails, map[string]utils.SpColumnDetails) {
 	srcColumnDetails := make(map[string]utils.SrcColumnDetails)
 	spColumnDetails := make(map[string]utils.SpColumnDetails)
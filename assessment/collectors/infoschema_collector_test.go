@@ -9,6 +9,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	sources "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/oracle"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/sqlserver"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -45,6 +48,9 @@ func TestBuildInforSchemaCollector(t *testing.T) {
 		sampleViews := []utils.ViewAssessmentInfo{
 			{Name: "view1"},
 		}
+		sampleTableSizes := []utils.TableSizeAssessmentInfo{
+			{Name: "table1", RowCount: 10},
+		}
 		sampleConv := &internal.Conv{}
 
 		// 2. Act: Call the function under test.
@@ -55,6 +61,7 @@ func TestBuildInforSchemaCollector(t *testing.T) {
 			sampleSps,
 			sampleFuncs,
 			sampleViews,
+			sampleTableSizes,
 			sampleConv,
 		)
 
@@ -69,6 +76,7 @@ func TestBuildInforSchemaCollector(t *testing.T) {
 		assert.Equal(t, sampleSps, collector.storedProcedures)
 		assert.Equal(t, sampleFuncs, collector.functions)
 		assert.Equal(t, sampleViews, collector.views)
+		assert.Equal(t, sampleTableSizes, collector.tableSizes)
 		assert.Same(t, sampleConv, collector.conv, "The 'conv' field should be the same instance as the input")
 	})
 }
@@ -93,6 +101,27 @@ func TestGetInfoSchema(t *testing.T) {
 	assert.NotNil(t, infoSchemaMySQL)
 	assert.IsType(t, mysql.InfoSchemaImpl{}, infoSchemaMySQL)
 
+	infoSchemaPostgres, err := getInfoSchema(db, profiles.SourceProfile{
+		Driver: constants.POSTGRES,
+		Conn:   profiles.SourceProfileConnection{Pg: profiles.SourceProfileConnectionPostgreSQL{Db: "test_db"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, postgres.InfoSchemaImpl{Db: db, DbName: "test_db"}, infoSchemaPostgres)
+
+	infoSchemaSqlServer, err := getInfoSchema(db, profiles.SourceProfile{
+		Driver: constants.SQLSERVER,
+		Conn:   profiles.SourceProfileConnection{SqlServer: profiles.SourceProfileConnectionSqlServer{Db: "test_db"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, sqlserver.InfoSchemaImpl{Db: db, DbName: "test_db"}, infoSchemaSqlServer)
+
+	infoSchemaOracle, err := getInfoSchema(db, profiles.SourceProfile{
+		Driver: constants.ORACLE,
+		Conn:   profiles.SourceProfileConnection{Oracle: profiles.SourceProfileConnectionOracle{User: "hr", Db: "XE"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, oracle.InfoSchemaImpl{Db: db, DbName: "HR"}, infoSchemaOracle)
+
 	sourceProfileUnsupported := profiles.SourceProfile{
 		Driver: "unsupported",
 	}
@@ -292,6 +321,33 @@ func TestInfoSchemaCollector_ListViews(t *testing.T) {
 	}
 }
 
+func TestInfoSchemaCollector_ListMaterializedViews(t *testing.T) {
+	collector := InfoSchemaCollector{
+		conv: &internal.Conv{UsedNames: make(map[string]bool)},
+		views: []utils.ViewAssessmentInfo{
+			{Name: "order_totals", Definition: "SELECT sum(total) FROM orders", IsMaterialized: true},
+		},
+	}
+	views := collector.ListViews()
+	assert.Len(t, views, 1)
+	for _, view := range views {
+		assert.Equal(t, "MATERIALIZED", view.SrcViewType)
+	}
+}
+
+func TestInfoSchemaCollector_ListTableSizes(t *testing.T) {
+	collector := InfoSchemaCollector{
+		conv: &internal.Conv{SrcSchema: map[string]schema.Table{"t1": {Name: "orders", Id: "t1"}}},
+		tableSizes: []utils.TableSizeAssessmentInfo{
+			{Name: "orders", RowCount: 1000, DataBytes: 163840},
+			{Name: "not_converted", RowCount: 10},
+		},
+	}
+	assert.Equal(t, map[string]utils.TableSizeAssessmentInfo{
+		"t1": {Name: "orders", RowCount: 1000, DataBytes: 163840},
+	}, collector.ListTableSizes())
+}
+
 func TestInfoSchemaCollector_ListStoredProcedures(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -988,6 +1044,14 @@ func (m *MockInfoSchema) GetViewInfo() ([]utils.ViewAssessmentInfo, error) {
 	return args.Get(0).([]utils.ViewAssessmentInfo), args.Error(1)
 }
 
+func (m *MockInfoSchema) GetTableSizeInfo() ([]utils.TableSizeAssessmentInfo, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]utils.TableSizeAssessmentInfo), args.Error(1)
+}
+
 type MockConnectionConfigProvider struct {
 	mock.Mock
 }
@@ -1047,6 +1111,7 @@ func TestCreateInfoSchemaCollector(t *testing.T) {
 	expectedSps := []utils.StoredProcedureAssessmentInfo{{Name: "sp1"}}
 	expectedFuncs := []utils.FunctionAssessmentInfo{{Name: "func1"}}
 	expectedViews := []utils.ViewAssessmentInfo{{Name: "view1"}}
+	expectedTableSizes := []utils.TableSizeAssessmentInfo{{Name: tableName, RowCount: 10}}
 
 	t.Run("All InfoSchema methods mocked successfully", func(t *testing.T) {
 		mockCfgProvider := new(MockConnectionConfigProvider)
@@ -1062,6 +1127,7 @@ func TestCreateInfoSchemaCollector(t *testing.T) {
 		mockIS.On("GetStoredProcedureInfo").Return(expectedSps, nil).Once()
 		mockIS.On("GetFunctionInfo").Return(expectedFuncs, nil).Once()
 		mockIS.On("GetViewInfo").Return(expectedViews, nil).Once()
+		mockIS.On("GetTableSizeInfo").Return(expectedTableSizes, nil).Once()
 
 		collector, err := GetInfoSchemaCollector(mockConv, sourceProfile, mockDbConnector, mockCfgProvider, func(db *sql.DB, sp profiles.SourceProfile) (sources.InfoSchema, error) {
 			return mockIS, nil
@@ -1075,6 +1141,7 @@ func TestCreateInfoSchemaCollector(t *testing.T) {
 		assert.Equal(t, expectedSps, collector.storedProcedures)
 		assert.Equal(t, expectedFuncs, collector.functions)
 		assert.Equal(t, expectedViews, collector.views)
+		assert.Equal(t, expectedTableSizes, collector.tableSizes)
 		assert.Equal(t, mockConv, collector.conv)
 
 		mockCfgProvider.AssertExpectations(t)
@@ -1160,6 +1227,7 @@ func TestCreateInfoSchemaCollector(t *testing.T) {
 		mockIS.On("GetStoredProcedureInfo").Return(nil, errors.New("get stored procedure error")).Once()
 		mockIS.On("GetFunctionInfo").Return(nil, errors.New("some func error")).Once()
 		mockIS.On("GetViewInfo").Return(nil, errors.New("get view error")).Once()
+		mockIS.On("GetTableSizeInfo").Return(nil, errors.New("get table size error")).Once()
 
 		collector, errResult := GetInfoSchemaCollector(mockConv, sourceProfile, mockDbConnector, mockCfgProvider, func(db *sql.DB, sp profiles.SourceProfile) (sources.InfoSchema, error) {
 			return mockIS, nil
@@ -1173,6 +1241,7 @@ func TestCreateInfoSchemaCollector(t *testing.T) {
 		assert.Contains(t, fullErrorMsg, "Error while scanning functions: some func error")
 		assert.Contains(t, fullErrorMsg, "Error while scanning stored procedures: get stored procedure error")
 		assert.Contains(t, fullErrorMsg, "Error while scanning views: get view error")
+		assert.Contains(t, fullErrorMsg, "Error while scanning table sizes: get table size error")
 
 		assert.Nil(t, collector.tables)
 		assert.Nil(t, collector.indexes)
@@ -1180,6 +1249,7 @@ func TestCreateInfoSchemaCollector(t *testing.T) {
 		assert.Nil(t, collector.storedProcedures)
 		assert.Nil(t, collector.functions)
 		assert.Nil(t, collector.views)
+		assert.Nil(t, collector.tableSizes)
 
 		mockCfgProvider.AssertExpectations(t)
 		mockDbConnector.AssertExpectations(t)
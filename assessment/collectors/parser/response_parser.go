@@ -196,6 +196,7 @@ func ParseDaoFileChanges(fileAnalyzerResponse string, projectPath, filePath stri
 				DatabasesReferenced:     ParseStringArrayInterface(qc["databases_referenced"]),
 				SelectForUpdate:         ParseAnyToBool(qc["select_for_update"]),
 				QueryType:               GetQueryType(ParseAnyToString(qc["normalized_query"])),
+				TranslationSource:       TranslationSourceLLM,
 			}
 			queryResults = append(queryResults, queryResult)
 		}
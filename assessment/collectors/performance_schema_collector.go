@@ -22,6 +22,8 @@ import (
 	collectorCommon "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/common"
 	sourcesCommon "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/sqlserver"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
@@ -32,6 +34,9 @@ import (
 // PerformanceSchemaCollector collects performance schema data from source databases
 type PerformanceSchemaCollector struct {
 	Queries []utils.QueryAssessmentInfo
+	// StatisticsWindowSeconds is how long the execution counts of the queries
+	// were gathered for, 0 if unknown.
+	StatisticsWindowSeconds int64
 }
 
 // IsEmpty checks if the collector has any data
@@ -68,11 +73,17 @@ func GetPerformanceSchemaCollector(sourceProfile profiles.SourceProfile, dbConne
 		return PerformanceSchemaCollector{}, fmt.Errorf("failed to get all queries: %w", err)
 	}
 
+	statisticsWindowSeconds, err := performanceSchema.GetStatisticsWindowSeconds()
+	if err != nil {
+		logger.Log.Warn("failed to get the statistics window, the query rates won't be estimated", zap.Error(err))
+	}
+
 	logger.Log.Info("performance schema collector initialized successfully",
 		zap.Int("query_count", len(queries)))
 
 	return PerformanceSchemaCollector{
-		Queries: queries,
+		Queries:                 queries,
+		StatisticsWindowSeconds: statisticsWindowSeconds,
 	}, nil
 }
 
@@ -93,6 +104,16 @@ func (d DefaultPerformanceSchemaProvider) getPerformanceSchema(db *sql.DB, sourc
 			Db:     db,
 			DbName: sourceProfile.Conn.Mysql.Db,
 		}, nil
+	case constants.POSTGRES:
+		return postgres.PerformanceSchemaImpl{
+			Db:     db,
+			DbName: sourceProfile.Conn.Pg.Db,
+		}, nil
+	case constants.SQLSERVER:
+		return sqlserver.PerformanceSchemaImpl{
+			Db:     db,
+			DbName: sourceProfile.Conn.SqlServer.Db,
+		}, nil
 	default:
 		return nil, fmt.Errorf("driver %s not supported for performance schema", driver)
 	}
@@ -21,6 +21,8 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	sourcesCommon "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/sqlserver"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
@@ -46,6 +48,11 @@ func (m *MockPerformanceSchema) GetAllQueryAssessments() ([]utils.QueryAssessmen
 	return args.Get(0).([]utils.QueryAssessmentInfo), args.Error(1)
 }
 
+func (m *MockPerformanceSchema) GetStatisticsWindowSeconds() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockPerformanceSchemaProvider struct {
 	mock.Mock
 }
@@ -84,6 +91,30 @@ func TestDefaultPerformanceSchemaProvider_getPerformanceSchema(t *testing.T) {
 	assert.Equal(t, db, mysqlPS.Db)
 	assert.Equal(t, "test_mysql_db", mysqlPS.DbName)
 
+	sourceProfilePostgres := profiles.SourceProfile{
+		Driver: constants.POSTGRES,
+		Conn: profiles.SourceProfileConnection{
+			Pg: profiles.SourceProfileConnectionPostgreSQL{
+				Db: "test_pg_db",
+			},
+		},
+	}
+	psPostgres, err := provider.getPerformanceSchema(db, sourceProfilePostgres)
+	assert.NoError(t, err)
+	assert.Equal(t, postgres.PerformanceSchemaImpl{Db: db, DbName: "test_pg_db"}, psPostgres)
+
+	sourceProfileSqlServer := profiles.SourceProfile{
+		Driver: constants.SQLSERVER,
+		Conn: profiles.SourceProfileConnection{
+			SqlServer: profiles.SourceProfileConnectionSqlServer{
+				Db: "test_sqlserver_db",
+			},
+		},
+	}
+	psSqlServer, err := provider.getPerformanceSchema(db, sourceProfileSqlServer)
+	assert.NoError(t, err)
+	assert.Equal(t, sqlserver.PerformanceSchemaImpl{Db: db, DbName: "test_sqlserver_db"}, psSqlServer)
+
 	sourceProfileUnsupported := profiles.SourceProfile{
 		Driver: "unsupported_db",
 	}
@@ -150,6 +181,7 @@ func TestGetPerformanceSchemaCollector_Success(t *testing.T) {
 	mockDbConnector.On("Connect", sourceProfile.Driver, "mock_conn_string").Return(dummyDb, nil).Once()
 	mockPSProvider.On("getPerformanceSchema", dummyDb, sourceProfile).Return(mockPS, nil).Once()
 	mockPS.On("GetAllQueryAssessments").Return(expectedQueries, nil).Once()
+	mockPS.On("GetStatisticsWindowSeconds").Return(int64(3600), nil).Once()
 
 	collector, err := GetPerformanceSchemaCollector(sourceProfile, mockDbConnector, mockCfgProvider, mockPSProvider)
 
@@ -157,6 +189,7 @@ func TestGetPerformanceSchemaCollector_Success(t *testing.T) {
 	assert.NotNil(t, collector)
 	assert.False(t, collector.IsEmpty())
 	assert.Equal(t, expectedQueries, collector.Queries)
+	assert.Equal(t, int64(3600), collector.StatisticsWindowSeconds)
 
 	mockCfgProvider.AssertExpectations(t)
 	mockDbConnector.AssertExpectations(t)
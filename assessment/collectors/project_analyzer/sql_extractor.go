@@ -0,0 +1,481 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package assessment
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/java"
+	"go.uber.org/zap"
+)
+
+// ExtractedQuery is a SQL query found in the application code.
+type ExtractedQuery struct {
+	Query    string
+	FilePath string
+	Line     int
+}
+
+// Maximum number of constants followed to resolve the value of a query.
+const maxQueryResolutionDepth = 10
+
+var (
+	sqlStatementRegex     = regexp.MustCompile(`(?is)^\s*\(?\s*(SELECT|INSERT|UPDATE|DELETE|REPLACE|WITH|CALL|MERGE|UPSERT)\s`)
+	myBatisParameterRegex = regexp.MustCompile(`[#$]\{[^}]*\}`)
+	whitespaceRegex       = regexp.MustCompile(`\s+`)
+)
+
+// goQueryMethods maps the methods of database/sql and sqlx which take a
+// query to the index of the query in their arguments.
+var goQueryMethods = map[string]int{
+	"Exec":                0,
+	"Query":               0,
+	"QueryRow":            0,
+	"Prepare":             0,
+	"ExecContext":         1,
+	"QueryContext":        1,
+	"QueryRowContext":     1,
+	"PrepareContext":      1,
+	"Queryx":              0,
+	"QueryRowx":           0,
+	"MustExec":            0,
+	"Preparex":            0,
+	"PrepareNamed":        0,
+	"NamedExec":           0,
+	"NamedQuery":          0,
+	"Get":                 1,
+	"Select":              1,
+	"QueryxContext":       1,
+	"QueryRowxContext":    1,
+	"MustExecContext":     1,
+	"PreparexContext":     1,
+	"PrepareNamedContext": 1,
+	"NamedExecContext":    1,
+	"NamedQueryContext":   1,
+	"GetContext":          2,
+	"SelectContext":       2,
+}
+
+// javaQueryMethods are the methods of JDBC, JPA and Spring's JdbcTemplate
+// which take a query as their first argument.
+var javaQueryMethods = map[string]bool{
+	"prepareStatement":   true,
+	"prepareCall":        true,
+	"executeQuery":       true,
+	"executeUpdate":      true,
+	"executeLargeUpdate": true,
+	"execute":            true,
+	"addBatch":           true,
+	"createQuery":        true,
+	"createNativeQuery":  true,
+	"query":              true,
+	"queryForObject":     true,
+	"queryForList":       true,
+	"queryForMap":        true,
+	"queryForRowSet":     true,
+	"queryForStream":     true,
+	"update":             true,
+	"batchUpdate":        true,
+}
+
+// javaQueryAnnotations are the JPA and MyBatis annotations holding a query.
+var javaQueryAnnotations = map[string]bool{
+	"Query":            true,
+	"NamedQuery":       true,
+	"NamedNativeQuery": true,
+	"Select":           true,
+	"Insert":           true,
+	"Update":           true,
+	"Delete":           true,
+}
+
+// myBatisStatements are the elements of a MyBatis mapper holding a query.
+var myBatisStatements = map[string]bool{
+	"select": true,
+	"insert": true,
+	"update": true,
+	"delete": true,
+}
+
+// ExtractQueries parses the Go, Java and MyBatis XML mapper files of a
+// project and returns the SQL queries they pass to the database drivers,
+// without asking an LLM. Only the queries which are string literals, or
+// concatenations of literals and constants of the same file, are found.
+// Test files and the vendored dependencies are skipped. The paths of the
+// queries are relative to the project.
+func ExtractQueries(ctx context.Context, projectDir string) ([]ExtractedQuery, error) {
+	javaParser := newJavaParser()
+	defer javaParser.Close()
+
+	var queries []ExtractedQuery
+	err := filepath.Walk(projectDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != projectDir && isSkippedDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relPath, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return err
+		}
+		var extract func([]byte) ([]ExtractedQuery, error)
+		switch {
+		case strings.HasSuffix(path, "_test.go") || strings.Contains(filepath.ToSlash(path), "/src/test/"):
+			return nil
+		case strings.HasSuffix(path, ".go"):
+			extract = func(content []byte) ([]ExtractedQuery, error) { return extractGoQueries(relPath, content) }
+		case strings.HasSuffix(path, ".java"):
+			extract = func(content []byte) ([]ExtractedQuery, error) {
+				return extractJavaQueries(ctx, javaParser, relPath, content)
+			}
+		case strings.HasSuffix(path, ".xml"):
+			extract = func(content []byte) ([]ExtractedQuery, error) { return extractMyBatisQueries(relPath, content) }
+		default:
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fileQueries, err := extract(content)
+		if err != nil {
+			logger.Log.Warn("couldn't extract the queries of file", zap.String("path", path), zap.Error(err))
+			return nil
+		}
+		queries = append(queries, fileQueries...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't extract the queries of %s: %w", projectDir, err)
+	}
+	return queries, nil
+}
+
+func isSkippedDir(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+	switch name {
+	case "vendor", "node_modules", "target", "build", "testdata":
+		return true
+	}
+	return false
+}
+
+func newJavaParser() *sitter.Parser {
+	javaParser := sitter.NewParser()
+	javaParser.SetLanguage(java.GetLanguage())
+	return javaParser
+}
+
+// newExtractedQuery returns the query of a string if it looks like SQL.
+func newExtractedQuery(query, filePath string, line int) (ExtractedQuery, bool) {
+	query = strings.TrimSpace(whitespaceRegex.ReplaceAllString(query, " "))
+	if !sqlStatementRegex.MatchString(query + " ") {
+		return ExtractedQuery{}, false
+	}
+	return ExtractedQuery{Query: query, FilePath: filePath, Line: line}, true
+}
+
+func extractGoQueries(filePath string, content []byte) ([]ExtractedQuery, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	// Values of the constants and variables of the file, regardless of their
+	// scope, to resolve the queries which aren't literals.
+	values := make(map[string]ast.Expr)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch decl := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range decl.Names {
+				if i < len(decl.Values) {
+					values[name.Name] = decl.Values[i]
+				}
+			}
+		case *ast.AssignStmt:
+			if decl.Tok == token.DEFINE && len(decl.Lhs) == len(decl.Rhs) {
+				for i, lhs := range decl.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						values[ident.Name] = decl.Rhs[i]
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	var queries []ExtractedQuery
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		index, ok := goQueryMethods[selector.Sel.Name]
+		if !ok || index >= len(call.Args) {
+			return true
+		}
+		query, ok := goStringValue(call.Args[index], values, 0)
+		if !ok {
+			return true
+		}
+		if q, ok := newExtractedQuery(query, filePath, fset.Position(call.Args[index].Pos()).Line); ok {
+			queries = append(queries, q)
+		}
+		return true
+	})
+	return queries, nil
+}
+
+// goStringValue returns the value of a string expression made of literals and
+// constants.
+func goStringValue(expr ast.Expr, values map[string]ast.Expr, depth int) (string, bool) {
+	if depth > maxQueryResolutionDepth {
+		return "", false
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(e.Value)
+		return s, err == nil
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := goStringValue(e.X, values, depth+1)
+		if !ok {
+			return "", false
+		}
+		right, ok := goStringValue(e.Y, values, depth+1)
+		return left + right, ok
+	case *ast.ParenExpr:
+		return goStringValue(e.X, values, depth+1)
+	case *ast.Ident:
+		if value, ok := values[e.Name]; ok {
+			return goStringValue(value, values, depth+1)
+		}
+	}
+	return "", false
+}
+
+func extractJavaQueries(ctx context.Context, javaParser *sitter.Parser, filePath string, content []byte) ([]ExtractedQuery, error) {
+	tree, err := javaParser.ParseCtx(ctx, nil, content)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+	root := tree.RootNode()
+
+	// Values of the fields and variables of the file, to resolve the queries
+	// which are constants.
+	values := make(map[string]*sitter.Node)
+	err = forEachJavaMatch(root, `(variable_declarator name: (identifier) @name value: (_) @value)`, func(captures map[string]*sitter.Node) {
+		values[captures["name"].Content(content)] = captures["value"]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []ExtractedQuery
+	addQuery := func(node *sitter.Node) {
+		query, ok := javaStringValue(node, content, values, 0)
+		if !ok {
+			return
+		}
+		if q, ok := newExtractedQuery(query, filePath, int(node.StartPoint().Row)+1); ok {
+			queries = append(queries, q)
+		}
+	}
+
+	err = forEachJavaMatch(root, `(method_invocation name: (identifier) @method arguments: (argument_list . (_) @query))`, func(captures map[string]*sitter.Node) {
+		if javaQueryMethods[captures["method"].Content(content)] {
+			addQuery(captures["query"])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachJavaMatch(root, `(annotation name: (_) @name arguments: (annotation_argument_list) @arguments)`, func(captures map[string]*sitter.Node) {
+		name := captures["name"].Content(content)
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		if !javaQueryAnnotations[name] {
+			return
+		}
+		arguments := captures["arguments"]
+		for i := 0; i < int(arguments.NamedChildCount()); i++ {
+			argument := arguments.NamedChild(i)
+			if argument.Type() != "element_value_pair" {
+				addQuery(argument)
+				continue
+			}
+			key := argument.ChildByFieldName("key")
+			if key != nil && (key.Content(content) == "value" || key.Content(content) == "query") {
+				addQuery(argument.ChildByFieldName("value"))
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// forEachJavaMatch runs a tree-sitter query on a Java tree and calls f with
+// the nodes captured by each match.
+func forEachJavaMatch(root *sitter.Node, pattern string, f func(captures map[string]*sitter.Node)) error {
+	query, err := sitter.NewQuery([]byte(pattern), java.GetLanguage())
+	if err != nil {
+		return err
+	}
+	defer query.Close()
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(query, root)
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			return nil
+		}
+		captures := make(map[string]*sitter.Node, len(match.Captures))
+		for _, capture := range match.Captures {
+			captures[query.CaptureNameForId(capture.Index)] = capture.Node
+		}
+		f(captures)
+	}
+}
+
+// javaStringValue returns the value of a string expression made of literals,
+// text blocks and constants.
+func javaStringValue(node *sitter.Node, content []byte, values map[string]*sitter.Node, depth int) (string, bool) {
+	if node == nil || depth > maxQueryResolutionDepth {
+		return "", false
+	}
+	switch node.Type() {
+	case "string_literal":
+		text := node.Content(content)
+		if strings.HasPrefix(text, `"""`) {
+			return strings.TrimSuffix(strings.TrimPrefix(text, `"""`), `"""`), true
+		}
+		if s, err := strconv.Unquote(text); err == nil {
+			return s, true
+		}
+		return strings.Trim(text, `"`), true
+	case "binary_expression":
+		if operator := node.ChildByFieldName("operator"); operator == nil || operator.Type() != "+" {
+			return "", false
+		}
+		left, ok := javaStringValue(node.ChildByFieldName("left"), content, values, depth+1)
+		if !ok {
+			return "", false
+		}
+		right, ok := javaStringValue(node.ChildByFieldName("right"), content, values, depth+1)
+		return left + right, ok
+	case "parenthesized_expression":
+		return javaStringValue(node.NamedChild(0), content, values, depth+1)
+	case "identifier":
+		return javaStringValue(values[node.Content(content)], content, values, depth+1)
+	case "field_access":
+		return javaStringValue(values[node.ChildByFieldName("field").Content(content)], content, values, depth+1)
+	}
+	return "", false
+}
+
+// extractMyBatisQueries returns the statements of a MyBatis XML mapper, with
+// their parameters replaced by ?. The other XML files have no queries.
+func extractMyBatisQueries(filePath string, content []byte) ([]ExtractedQuery, error) {
+	if !bytes.Contains(content, []byte("<mapper")) {
+		return nil, nil
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var queries []ExtractedQuery
+	var statement *strings.Builder
+	line, depth := 0, 0
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return queries, nil
+			}
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if statement != nil {
+				depth++
+				// The conditions of the dynamic SQL tags are dropped but their
+				// content is kept, so the query has all its clauses.
+				statement.WriteString(" ")
+				continue
+			}
+			if myBatisStatements[strings.ToLower(t.Name.Local)] {
+				statement = &strings.Builder{}
+				line, _ = decoder.InputPos()
+				depth = 0
+			}
+		case xml.EndElement:
+			if statement == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				statement.WriteString(" ")
+				continue
+			}
+			query := myBatisParameterRegex.ReplaceAllString(statement.String(), "?")
+			if q, ok := newExtractedQuery(query, filePath, line); ok {
+				queries = append(queries, q)
+			}
+			statement = nil
+		case xml.CharData:
+			if statement != nil {
+				statement.Write(t)
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package assessment
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractGoQueries(t *testing.T) {
+	content := `package dao
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const selectUser = "SELECT id, name FROM users " +
+	"WHERE id = ?"
+
+func run(ctx context.Context, db *sql.DB, dbx *sqlx.DB) {
+	db.QueryRow(selectUser, 1)
+	db.ExecContext(ctx, ` + "`" + `UPDATE users
+		SET name = ? WHERE id = ?` + "`" + `, "a", 1)
+	var names []string
+	dbx.SelectContext(ctx, &names, "SELECT name FROM users")
+	dbx.NamedExec("INSERT INTO users (name) VALUES (:name)", map[string]any{})
+	query := "DELETE FROM users WHERE id = ?"
+	db.Exec(query, 1)
+	db.Exec(buildQuery(), 1)
+	db.Prepare("not a query")
+}
+`
+	queries, err := extractGoQueries("dao.go", []byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, []ExtractedQuery{
+		{Query: "SELECT id, name FROM users WHERE id = ?", FilePath: "dao.go", Line: 14},
+		{Query: "UPDATE users SET name = ? WHERE id = ?", FilePath: "dao.go", Line: 15},
+		{Query: "SELECT name FROM users", FilePath: "dao.go", Line: 18},
+		{Query: "INSERT INTO users (name) VALUES (:name)", FilePath: "dao.go", Line: 19},
+		{Query: "DELETE FROM users WHERE id = ?", FilePath: "dao.go", Line: 21},
+	}, queries)
+}
+
+func TestExtractJavaQueries(t *testing.T) {
+	content := `package com.example.dao;
+
+public class UserDao {
+    private static final String SELECT_USER = "SELECT id, name FROM users " +
+        "WHERE id = ?";
+
+    public User find(Connection conn, long id) throws SQLException {
+        PreparedStatement ps = conn.prepareStatement(SELECT_USER);
+        jdbcTemplate.update("UPDATE users SET name = ? WHERE id = ?", "a", id);
+        stmt.executeQuery(buildQuery());
+        logger.execute("not a query");
+        return null;
+    }
+}
+
+interface UserRepository extends JpaRepository<User, Long> {
+    @Query("SELECT u FROM User u WHERE u.name = :name")
+    List<User> findByName(String name);
+
+    @Query(value = """
+        SELECT * FROM users
+        WHERE email = ?1
+        """, nativeQuery = true)
+    User findByEmail(String email);
+}
+`
+	queries, err := extractJavaQueries(context.Background(), newJavaParser(), "UserDao.java", []byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, []ExtractedQuery{
+		{Query: "SELECT id, name FROM users WHERE id = ?", FilePath: "UserDao.java", Line: 8},
+		{Query: "UPDATE users SET name = ? WHERE id = ?", FilePath: "UserDao.java", Line: 9},
+		{Query: "SELECT u FROM User u WHERE u.name = :name", FilePath: "UserDao.java", Line: 17},
+		{Query: "SELECT * FROM users WHERE email = ?1", FilePath: "UserDao.java", Line: 20},
+	}, queries)
+}
+
+func TestExtractMyBatisQueries(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE mapper PUBLIC "-//mybatis.org//DTD Mapper 3.0//EN" "http://mybatis.org/dtd/mybatis-3-mapper.dtd">
+<mapper namespace="com.example.UserMapper">
+  <select id="findUser" resultType="User">
+    SELECT id, name FROM users
+    <where>
+      <if test="name != null">name = #{name}</if>
+    </where>
+  </select>
+  <insert id="insertUser">
+    INSERT INTO users (id, name) VALUES (#{id}, #{name})
+  </insert>
+  <delete id="deleteUsers">
+    DELETE FROM users WHERE id &lt; ${maxId}
+  </delete>
+</mapper>
+`
+	queries, err := extractMyBatisQueries("UserMapper.xml", []byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, []ExtractedQuery{
+		{Query: "SELECT id, name FROM users name = ?", FilePath: "UserMapper.xml", Line: 4},
+		{Query: "INSERT INTO users (id, name) VALUES (?, ?)", FilePath: "UserMapper.xml", Line: 10},
+		{Query: "DELETE FROM users WHERE id < ?", FilePath: "UserMapper.xml", Line: 13},
+	}, queries)
+
+	queries, err = extractMyBatisQueries("pom.xml", []byte(`<project><select>SELECT 1</select></project>`))
+	require.NoError(t, err)
+	assert.Empty(t, queries)
+}
+
+func TestExtractQueries(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"dao/user.go":           "package dao\n\nfunc f() { db.Query(\"SELECT 1 FROM users\") }\n",
+		"dao/user_test.go":      "package dao\n\nfunc g() { db.Query(\"SELECT 2 FROM users\") }\n",
+		"vendor/lib/lib.go":     "package lib\n\nfunc h() { db.Query(\"SELECT 3 FROM users\") }\n",
+		"dao/broken.go":         "package dao\n\nfunc {",
+		"src/main/Dao.java":     "class Dao { void f() { c.prepareStatement(\"SELECT 4 FROM users\"); } }\n",
+		"src/test/DaoTest.java": "class DaoTest { void f() { c.prepareStatement(\"SELECT 5 FROM users\"); } }\n",
+		"README.md":             "SELECT 6 FROM users",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	queries, err := ExtractQueries(context.Background(), dir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ExtractedQuery{
+		{Query: "SELECT 1 FROM users", FilePath: filepath.Join("dao", "user.go"), Line: 3},
+		{Query: "SELECT 4 FROM users", FilePath: filepath.Join("src", "main", "Dao.java"), Line: 1},
+	}, queries)
+
+	_, err = ExtractQueries(context.Background(), filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
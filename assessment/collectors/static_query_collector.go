@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package assessment
+
+import (
+	"context"
+	"fmt"
+
+	dependencyAnalyzer "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/project_analyzer"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"go.uber.org/zap"
+)
+
+// StaticQueryCollector collects the queries of the application code by
+// parsing it, without an LLM.
+type StaticQueryCollector struct {
+	Queries []dependencyAnalyzer.ExtractedQuery
+}
+
+// IsEmpty checks if the collector has any data
+func (c StaticQueryCollector) IsEmpty() bool {
+	return len(c.Queries) == 0
+}
+
+// GetStaticQueryCollector creates a new StaticQueryCollector with the queries
+// of the code of a project.
+func GetStaticQueryCollector(ctx context.Context, projectDir string) (StaticQueryCollector, error) {
+	logger.Log.Info("initializing static query collector")
+
+	queries, err := dependencyAnalyzer.ExtractQueries(ctx, projectDir)
+	if err != nil {
+		return StaticQueryCollector{}, fmt.Errorf("failed to extract queries: %w", err)
+	}
+
+	logger.Log.Info("static query collector initialized successfully",
+		zap.Int("query_count", len(queries)))
+
+	return StaticQueryCollector{
+		Queries: queries,
+	}, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package assessment
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dependencyAnalyzer "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors/project_analyzer"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestGetStaticQueryCollector(t *testing.T) {
+	logger.Log = zap.NewNop()
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dao.go"), []byte("package dao\n\nfunc f() { db.Query(\"SELECT id FROM users\") }\n"), 0644))
+
+	collector, err := GetStaticQueryCollector(context.Background(), dir)
+	assert.NoError(t, err)
+	assert.False(t, collector.IsEmpty())
+	assert.Equal(t, []dependencyAnalyzer.ExtractedQuery{{Query: "SELECT id FROM users", FilePath: "dao.go", Line: 3}}, collector.Queries)
+
+	collector, err = GetStaticQueryCollector(context.Background(), filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+	assert.True(t, collector.IsEmpty())
+}
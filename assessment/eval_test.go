@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	assessment "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/collectors"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/llm"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"go.uber.org/zap"
 )
@@ -115,7 +116,11 @@ func TestAccuracy(t *testing.T) {
 		if strings.HasSuffix(tc.FilePath, "java") {
 			language = "java"
 		}
-		summarizer, err := assessment.NewMigrationCodeSummarizer(ctx, nil, projectID, location, tc.SourceSchema, tc.TargetSchema, tc.FilePath, language, "go-sql-mysql", "go-sql-spanner")
+		llmProvider, err := llm.NewProvider(ctx, llm.Config{Provider: llm.VertexAI, ProjectID: projectID, Location: location})
+		if err != nil {
+			t.Fatal("Failed to create llm provider: ", err)
+		}
+		summarizer, err := assessment.NewMigrationCodeSummarizer(ctx, llmProvider, tc.SourceSchema, tc.TargetSchema, tc.FilePath, language, "go-sql-mysql", "go-sql-spanner")
 
 		if err != nil {
 			t.Fatal("Failed to initialize migration summarizer: ", err)
@@ -0,0 +1,123 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package llm
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Size of the embedding vectors of FakeProvider.
+const fakeEmbeddingSize = 64
+
+// FakeProvider is a deterministic provider which doesn't call any model, to
+// run the assessment offline and in tests.
+type FakeProvider struct {
+	// Responses maps a substring of the prompts to the response to them. When
+	// several substrings match, the longest one wins.
+	Responses map[string]string
+	// DefaultResponse is the response to the prompts matching no substring,
+	// {} when empty.
+	DefaultResponse string
+
+	mu      sync.Mutex
+	prompts []string
+}
+
+func (p *FakeProvider) GenerativeModel(tier ModelTier) GenerativeModel {
+	return &fakeModel{provider: p}
+}
+
+// Embed hashes the words of the texts into the vectors, so texts sharing
+// words are similar.
+func (p *FakeProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		vector := make([]float32, fakeEmbeddingSize)
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			h := fnv.New32a()
+			h.Write([]byte(word))
+			vector[h.Sum32()%fakeEmbeddingSize]++
+		}
+		var norm float64
+		for _, v := range vector {
+			norm += float64(v * v)
+		}
+		if norm > 0 {
+			for j := range vector {
+				vector[j] /= float32(math.Sqrt(norm))
+			}
+		}
+		embeddings[i] = vector
+	}
+	return embeddings, nil
+}
+
+func (p *FakeProvider) Close() error {
+	return nil
+}
+
+// Prompts returns the prompts the models of the provider received, in order.
+func (p *FakeProvider) Prompts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.prompts...)
+}
+
+func (p *FakeProvider) respond(prompt string) string {
+	p.mu.Lock()
+	p.prompts = append(p.prompts, prompt)
+	p.mu.Unlock()
+
+	match := ""
+	response, found := "", false
+	for substring, r := range p.Responses {
+		if !strings.Contains(prompt, substring) {
+			continue
+		}
+		if !found || len(substring) > len(match) || (len(substring) == len(match) && substring < match) {
+			match, response, found = substring, r, true
+		}
+	}
+	if found {
+		return response
+	}
+	if p.DefaultResponse != "" {
+		return p.DefaultResponse
+	}
+	return "{}"
+}
+
+type fakeModel struct {
+	provider *FakeProvider
+}
+
+func (m *fakeModel) GenerateContent(ctx context.Context, prompt string) (*Response, error) {
+	text := m.provider.respond(prompt)
+	promptTokens, candidateTokens := int32(len(strings.Fields(prompt))), int32(len(strings.Fields(text)))
+	return &Response{
+		Text:            text,
+		PromptTokens:    promptTokens,
+		CandidateTokens: candidateTokens,
+		TotalTokens:     promptTokens + candidateTokens,
+	}, nil
+}
+
+func (m *fakeModel) SetResponseMIMEType(string) {}
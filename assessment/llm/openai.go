@@ -0,0 +1,193 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// openAIProvider serves the models of an OpenAI-compatible HTTP API, like the
+// ones of vLLM or Ollama, so that the code never leaves the network.
+type openAIProvider struct {
+	endpoint   string
+	apiKey     string
+	cfg        Config
+	httpClient *http.Client
+}
+
+func newOpenAIProvider(cfg Config) (*openAIProvider, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("llmEndpoint is required for the %s llm provider", OpenAI)
+	}
+	if cfg.ProModel == "" {
+		return nil, fmt.Errorf("llmModel is required for the %s llm provider", OpenAI)
+	}
+	if cfg.FlashModel == "" {
+		cfg.FlashModel = cfg.ProModel
+	}
+	return &openAIProvider{
+		endpoint:   strings.TrimSuffix(cfg.Endpoint, "/"),
+		apiKey:     cfg.APIKey,
+		cfg:        cfg,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+type openAIChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int32 `json:"prompt_tokens"`
+		CompletionTokens int32 `json:"completion_tokens"`
+		TotalTokens      int32 `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+type openAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (p *openAIProvider) GenerativeModel(tier ModelTier) GenerativeModel {
+	name := p.cfg.ProModel
+	if tier == FlashModel {
+		name = p.cfg.FlashModel
+	}
+	return &openAIModel{provider: p, name: name}
+}
+
+func (p *openAIProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if p.cfg.EmbeddingModel == "" {
+		return nil, ErrNoEmbeddingModel
+	}
+	var resp openAIEmbeddingResponse
+	if err := p.post(ctx, "/embeddings", openAIEmbeddingRequest{Model: p.cfg.EmbeddingModel, Input: texts}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(resp.Data), len(texts))
+	}
+	embeddings := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+	return embeddings, nil
+}
+
+func (p *openAIProvider) Close() error {
+	return nil
+}
+
+// post sends a request to an API of the endpoint and decodes its response.
+// The status code is part of the errors, so that the callers can retry on
+// 429 and 502.
+func (p *openAIProvider) post(ctx context.Context, path string, body, out any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d: %s", p.endpoint+path, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("can't parse response of %s: %w", p.endpoint+path, err)
+	}
+	return nil
+}
+
+// openAIModel generates content with the chat completions API.
+type openAIModel struct {
+	provider *openAIProvider
+	name     string
+	jsonMode bool
+}
+
+func (m *openAIModel) GenerateContent(ctx context.Context, prompt string) (*Response, error) {
+	req := openAIChatRequest{
+		Model:    m.name,
+		Messages: []openAIMessage{{Role: "user", Content: prompt}},
+	}
+	if m.jsonMode {
+		req.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
+	}
+	var resp openAIChatResponse
+	if err := m.provider.post(ctx, "/chat/completions", req, &resp); err != nil {
+		return nil, err
+	}
+	response := &Response{
+		PromptTokens:    resp.Usage.PromptTokens,
+		CandidateTokens: resp.Usage.CompletionTokens,
+		TotalTokens:     resp.Usage.TotalTokens,
+	}
+	if len(resp.Choices) > 0 {
+		response.Text = resp.Choices[0].Message.Content
+	}
+	return response, nil
+}
+
+func (m *openAIModel) SetResponseMIMEType(mimeType string) {
+	m.jsonMode = mimeType == "application/json"
+}
@@ -0,0 +1,108 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOpenAIProvider(t *testing.T, handler http.HandlerFunc, cfg Config) *openAIProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	cfg.Endpoint = server.URL + "/v1"
+	if cfg.ProModel == "" {
+		cfg.ProModel = "pro-model"
+	}
+	p, err := newOpenAIProvider(cfg)
+	assert.NoError(t, err)
+	return p
+}
+
+func TestOpenAIModel_GenerateContent(t *testing.T) {
+	var got openAIChatRequest
+	p := newTestOpenAIProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Write([]byte(`{
+			"choices": [{"message": {"role": "assistant", "content": "{\"new_query\": \"SELECT 1\"}"}}],
+			"usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}
+		}`))
+	}, Config{APIKey: "secret", FlashModel: "flash-model"})
+
+	model := p.GenerativeModel(ProModel)
+	model.SetResponseMIMEType("application/json")
+	resp, err := model.GenerateContent(context.Background(), "Translate SELECT 1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Response{Text: `{"new_query": "SELECT 1"}`, PromptTokens: 10, CandidateTokens: 5, TotalTokens: 15}, resp)
+	assert.Equal(t, openAIChatRequest{
+		Model:          "pro-model",
+		Messages:       []openAIMessage{{Role: "user", Content: "Translate SELECT 1"}},
+		ResponseFormat: &openAIResponseFormat{Type: "json_object"},
+	}, got)
+
+	got = openAIChatRequest{}
+	_, err = p.GenerativeModel(FlashModel).GenerateContent(context.Background(), "Analyze")
+	assert.NoError(t, err)
+	assert.Equal(t, "flash-model", got.Model)
+	assert.Nil(t, got.ResponseFormat)
+}
+
+func TestOpenAIModel_GenerateContentError(t *testing.T) {
+	p := newTestOpenAIProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("slow down"))
+	}, Config{})
+
+	_, err := p.GenerativeModel(ProModel).GenerateContent(context.Background(), "Translate SELECT 1")
+
+	assert.ErrorContains(t, err, "returned 429: slow down")
+}
+
+func TestOpenAIProvider_Embed(t *testing.T) {
+	p := newTestOpenAIProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		var req openAIEmbeddingRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, openAIEmbeddingRequest{Model: "embedding-model", Input: []string{"a", "b"}}, req)
+		w.Write([]byte(`{"data": [{"index": 1, "embedding": [0, 1]}, {"index": 0, "embedding": [1, 0]}]}`))
+	}, Config{EmbeddingModel: "embedding-model"})
+
+	embeddings, err := p.Embed(context.Background(), []string{"a", "b"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, embeddings)
+}
+
+func TestOpenAIProvider_EmbedErrors(t *testing.T) {
+	p := newTestOpenAIProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"index": 0, "embedding": [1, 0]}]}`))
+	}, Config{EmbeddingModel: "embedding-model"})
+	_, err := p.Embed(context.Background(), []string{"a", "b"})
+	assert.ErrorContains(t, err, "got 1 embeddings for 2 texts")
+
+	p.cfg.EmbeddingModel = ""
+	_, err = p.Embed(context.Background(), []string{"a"})
+	assert.ErrorIs(t, err, ErrNoEmbeddingModel)
+}
@@ -0,0 +1,140 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+
+// Package llm provides the large language models the assessment uses to
+// analyze the application code and to translate the queries, behind a
+// provider abstraction so that they can be served by Vertex AI, by any
+// OpenAI-compatible endpoint, or faked in tests.
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Providers of the models, selected with the llmProvider key of the
+// assessment profile.
+const (
+	VertexAI = "vertexai"
+	OpenAI   = "openai"
+	Fake     = "fake"
+)
+
+// Default models of Vertex AI.
+const (
+	GeminiProModel       = "gemini-2.5-pro"
+	GeminiFlashModel     = "gemini-2.0-flash-001"
+	GeminiEmbeddingModel = "gemini-embedding-001"
+)
+
+// ErrNoEmbeddingModel is returned by Embed when the provider has no model to
+// compute embeddings.
+var ErrNoEmbeddingModel = errors.New("no embedding model configured")
+
+// ModelTier selects one of the models of a provider.
+type ModelTier int
+
+const (
+	// ProModel is the most capable model, for the code conversions and the
+	// query translations.
+	ProModel ModelTier = iota
+	// FlashModel is the faster model, for the simpler analyses.
+	FlashModel
+)
+
+// Response is the content generated by a model.
+type Response struct {
+	Text            string
+	PromptTokens    int32
+	CandidateTokens int32
+	TotalTokens     int32
+}
+
+// GenerativeModel generates content from a prompt.
+type GenerativeModel interface {
+	GenerateContent(ctx context.Context, prompt string) (*Response, error)
+	// SetResponseMIMEType sets the format of the responses, e.g.
+	// application/json.
+	SetResponseMIMEType(mimeType string)
+}
+
+// Provider gives access to the generative and embedding models of an LLM
+// backend.
+type Provider interface {
+	GenerativeModel(tier ModelTier) GenerativeModel
+	// Embed returns the embedding vector of each of the texts, for semantic
+	// similarity search.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	Close() error
+}
+
+// Config is the configuration of a provider.
+type Config struct {
+	Provider string
+	// ProjectID and Location are the Google Cloud project and region of
+	// Vertex AI.
+	ProjectID string
+	Location  string
+	// Endpoint is the base URL of an OpenAI-compatible API, e.g.
+	// http://localhost:11434/v1.
+	Endpoint string
+	APIKey   string
+	// Names of the models, the provider defaults are used when empty.
+	ProModel       string
+	FlashModel     string
+	EmbeddingModel string
+}
+
+// ConfigFromAssessmentProfile reads the configuration of the provider from
+// the assessment profile. The API key of an OpenAI-compatible endpoint can
+// also be set with the OPENAI_API_KEY environment variable.
+func ConfigFromAssessmentProfile(assessmentConfig map[string]string, projectID string) Config {
+	cfg := Config{
+		Provider:       strings.ToLower(assessmentConfig["llmProvider"]),
+		ProjectID:      projectID,
+		Location:       assessmentConfig["location"],
+		Endpoint:       assessmentConfig["llmEndpoint"],
+		APIKey:         assessmentConfig["llmApiKey"],
+		ProModel:       assessmentConfig["llmModel"],
+		FlashModel:     assessmentConfig["llmFlashModel"],
+		EmbeddingModel: assessmentConfig["llmEmbeddingModel"],
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = VertexAI
+	}
+	if cfg.APIKey == "" && cfg.Provider == OpenAI {
+		cfg.APIKey = os.Getenv("OPENAI_API_KEY")
+	}
+	return cfg
+}
+
+// NewProvider creates the provider of the configuration.
+func NewProvider(ctx context.Context, cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case VertexAI, "":
+		return newVertexAIProvider(ctx, cfg)
+	case OpenAI:
+		return newOpenAIProvider(cfg)
+	case Fake:
+		return &FakeProvider{}, nil
+	default:
+		return nil, fmt.Errorf("llm provider %s not supported, supported providers are %s, %s and %s", cfg.Provider, VertexAI, OpenAI, Fake)
+	}
+}
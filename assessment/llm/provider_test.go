@@ -0,0 +1,138 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFromAssessmentProfile(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "env-key")
+	tests := []struct {
+		name     string
+		config   map[string]string
+		expected Config
+	}{
+		{
+			name:     "defaults to Vertex AI",
+			config:   map[string]string{"location": "us-central1"},
+			expected: Config{Provider: VertexAI, ProjectID: "test-project", Location: "us-central1"},
+		},
+		{
+			name: "openai with the api key from the environment",
+			config: map[string]string{
+				"llmProvider":       "OpenAI",
+				"llmEndpoint":       "http://localhost:11434/v1",
+				"llmModel":          "qwen2.5-coder",
+				"llmFlashModel":     "llama3.2",
+				"llmEmbeddingModel": "nomic-embed-text",
+			},
+			expected: Config{
+				Provider:       OpenAI,
+				ProjectID:      "test-project",
+				Endpoint:       "http://localhost:11434/v1",
+				APIKey:         "env-key",
+				ProModel:       "qwen2.5-coder",
+				FlashModel:     "llama3.2",
+				EmbeddingModel: "nomic-embed-text",
+			},
+		},
+		{
+			name:     "api key from the profile",
+			config:   map[string]string{"llmProvider": "openai", "llmApiKey": "profile-key"},
+			expected: Config{Provider: OpenAI, ProjectID: "test-project", APIKey: "profile-key"},
+		},
+		{
+			name:     "fake",
+			config:   map[string]string{"llmProvider": "fake"},
+			expected: Config{Provider: Fake, ProjectID: "test-project"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ConfigFromAssessmentProfile(tc.config, "test-project"))
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	ctx := context.Background()
+
+	p, err := NewProvider(ctx, Config{Provider: Fake})
+	assert.NoError(t, err)
+	assert.IsType(t, &FakeProvider{}, p)
+
+	p, err = NewProvider(ctx, Config{Provider: OpenAI, Endpoint: "http://localhost:8000/v1/", ProModel: "llama3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8000/v1", p.(*openAIProvider).endpoint)
+	assert.Equal(t, "llama3", p.GenerativeModel(FlashModel).(*openAIModel).name)
+
+	_, err = NewProvider(ctx, Config{Provider: OpenAI, ProModel: "llama3"})
+	assert.ErrorContains(t, err, "llmEndpoint is required")
+
+	_, err = NewProvider(ctx, Config{Provider: OpenAI, Endpoint: "http://localhost:8000/v1"})
+	assert.ErrorContains(t, err, "llmModel is required")
+
+	_, err = NewProvider(ctx, Config{Provider: "bedrock"})
+	assert.ErrorContains(t, err, "llm provider bedrock not supported")
+}
+
+func TestFakeProvider(t *testing.T) {
+	ctx := context.Background()
+	p := &FakeProvider{
+		Responses: map[string]string{
+			"SELECT":          `{"kind": "query"}`,
+			"SELECT NOW()":    `{"kind": "now"}`,
+			"code conversion": `{"kind": "code"}`,
+		},
+	}
+	model := p.GenerativeModel(ProModel)
+
+	resp, err := model.GenerateContent(ctx, "Translate SELECT NOW() to Spanner")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind": "now"}`, resp.Text)
+	assert.Equal(t, resp.PromptTokens+resp.CandidateTokens, resp.TotalTokens)
+
+	resp, err = model.GenerateContent(ctx, "Translate SELECT 1 to Spanner")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind": "query"}`, resp.Text)
+
+	resp, err = p.GenerativeModel(FlashModel).GenerateContent(ctx, "Anything else")
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", resp.Text)
+
+	p.DefaultResponse = `{"questions": []}`
+	resp, err = model.GenerateContent(ctx, "Anything else")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"questions": []}`, resp.Text)
+
+	assert.Equal(t, []string{"Translate SELECT NOW() to Spanner", "Translate SELECT 1 to Spanner", "Anything else", "Anything else"}, p.Prompts())
+}
+
+func TestFakeProvider_Embed(t *testing.T) {
+	p := &FakeProvider{}
+	embeddings, err := p.Embed(context.Background(), []string{"select from users", "SELECT FROM users", "insert into orders", ""})
+	assert.NoError(t, err)
+	assert.Len(t, embeddings, 4)
+	assert.Len(t, embeddings[0], fakeEmbeddingSize)
+	assert.Equal(t, embeddings[0], embeddings[1], "embeddings are deterministic and case insensitive")
+	assert.NotEqual(t, embeddings[0], embeddings[2])
+	assert.Equal(t, make([]float32, fakeEmbeddingSize), embeddings[3])
+}
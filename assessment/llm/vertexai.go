@@ -0,0 +1,130 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	aiplatform "cloud.google.com/go/aiplatform/apiv1"
+	"cloud.google.com/go/aiplatform/apiv1/aiplatformpb"
+	"cloud.google.com/go/vertexai/genai"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// vertexAIProvider serves the Gemini models of Vertex AI.
+type vertexAIProvider struct {
+	client *genai.Client
+	cfg    Config
+}
+
+func newVertexAIProvider(ctx context.Context, cfg Config) (*vertexAIProvider, error) {
+	if cfg.APIKey != "" {
+		os.Setenv("GOOGLE_API_KEY", cfg.APIKey)
+	}
+	client, err := genai.NewClient(ctx, cfg.ProjectID, cfg.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vertex AI client: %w", err)
+	}
+	if cfg.ProModel == "" {
+		cfg.ProModel = GeminiProModel
+	}
+	if cfg.FlashModel == "" {
+		cfg.FlashModel = GeminiFlashModel
+	}
+	if cfg.EmbeddingModel == "" {
+		cfg.EmbeddingModel = GeminiEmbeddingModel
+	}
+	return &vertexAIProvider{client: client, cfg: cfg}, nil
+}
+
+func (p *vertexAIProvider) GenerativeModel(tier ModelTier) GenerativeModel {
+	name := p.cfg.ProModel
+	if tier == FlashModel {
+		name = p.cfg.FlashModel
+	}
+	return &vertexAIModel{p.client.GenerativeModel(name)}
+}
+
+func (p *vertexAIProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	client, err := aiplatform.NewPredictionClient(ctx, option.WithEndpoint(p.cfg.Location+"-aiplatform.googleapis.com:443"))
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	instances := make([]*structpb.Value, len(texts))
+	for i, text := range texts {
+		instances[i] = structpb.NewStructValue(&structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"content":   structpb.NewStringValue(text),
+				"task_type": structpb.NewStringValue("SEMANTIC_SIMILARITY"),
+			},
+		})
+	}
+	resp, err := client.Predict(ctx, &aiplatformpb.PredictRequest{
+		Endpoint:  fmt.Sprintf("projects/%s/locations/%s/publishers/google/models/%s", p.cfg.ProjectID, p.cfg.Location, p.cfg.EmbeddingModel),
+		Instances: instances,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(resp.Predictions))
+	for i, prediction := range resp.Predictions {
+		values := prediction.GetStructValue().GetFields()["embeddings"].GetStructValue().GetFields()["values"].GetListValue().GetValues()
+		embeddings[i] = make([]float32, len(values))
+		for j, v := range values {
+			embeddings[i][j] = float32(v.GetNumberValue())
+		}
+	}
+	return embeddings, nil
+}
+
+func (p *vertexAIProvider) Close() error {
+	return p.client.Close()
+}
+
+// vertexAIModel adapts a genai.GenerativeModel to GenerativeModel.
+type vertexAIModel struct {
+	model *genai.GenerativeModel
+}
+
+func (m *vertexAIModel) GenerateContent(ctx context.Context, prompt string) (*Response, error) {
+	resp, err := m.model.GenerateContent(ctx, genai.Text(prompt))
+	if err != nil {
+		return nil, err
+	}
+	response := &Response{}
+	if len(resp.Candidates) > 0 && resp.Candidates[0].Content != nil && len(resp.Candidates[0].Content.Parts) > 0 {
+		if part, ok := resp.Candidates[0].Content.Parts[0].(genai.Text); ok {
+			response.Text = string(part)
+		}
+	}
+	if resp.UsageMetadata != nil {
+		response.PromptTokens = resp.UsageMetadata.PromptTokenCount
+		response.CandidateTokens = resp.UsageMetadata.CandidatesTokenCount
+		response.TotalTokens = resp.UsageMetadata.TotalTokenCount
+	}
+	return response, nil
+}
+
+func (m *vertexAIModel) SetResponseMIMEType(mimeType string) {
+	m.model.ResponseMIMEType = mimeType
+}
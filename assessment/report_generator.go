@@ -129,6 +129,46 @@ func generateCodeSummary(appAssessment *utils.AppCodeAssessmentOutput) [][]strin
 	return rows
 }
 
+// generateCostSummary writes the sizing of the database on Spanner, the
+// average and peak query rates, the suggested capacity and monthly cost of
+// each region configuration, and the sizes of the tables.
+func generateCostSummary(costAssessment utils.CostAssessmentOutput) [][]string {
+	var rows [][]string
+	rows = append(rows, []string{"Source Size (GiB)", formatGib(costAssessment.SourceBytes)})
+	rows = append(rows, []string{"Estimated Spanner Size (GiB)", formatGib(costAssessment.SpannerBytes)})
+	rows = append(rows, []string{"Statistics Window (Hours)", fmt.Sprintf("%.1f", float64(costAssessment.StatisticsWindowSeconds)/3600)})
+	rows = append(rows, []string{"Average Reads per Second over the Window", fmt.Sprintf("%.2f", costAssessment.ReadQps)})
+	rows = append(rows, []string{"Average Writes per Second over the Window", fmt.Sprintf("%.2f", costAssessment.WriteQps)})
+	rows = append(rows, []string{"Peak to Average Ratio", fmt.Sprintf("%.2f", costAssessment.PeakToAverageRatio)})
+	rows = append(rows, []string{"Peak Reads per Second (Sizing)", fmt.Sprintf("%.2f", costAssessment.ReadQps*costAssessment.PeakToAverageRatio)})
+	rows = append(rows, []string{"Peak Writes per Second (Sizing)", fmt.Sprintf("%.2f", costAssessment.WriteQps*costAssessment.PeakToAverageRatio)})
+
+	rows = append(rows, []string{"Region Configuration", "Processing Units", "Minimum Monthly Cost (USD)", "Maximum Monthly Cost (USD)"})
+	for _, regionConfig := range costAssessment.RegionConfigs {
+		rows = append(rows, []string{
+			utils.SanitizeCsvRow(&regionConfig.RegionConfig),
+			fmt.Sprint(regionConfig.ProcessingUnits),
+			fmt.Sprintf("%.2f", regionConfig.MinMonthlyCostUsd),
+			fmt.Sprintf("%.2f", regionConfig.MaxMonthlyCostUsd),
+		})
+	}
+
+	rows = append(rows, []string{"Table", "Rows", "Source Size (GiB)", "Estimated Spanner Size (GiB)"})
+	for _, table := range costAssessment.Tables {
+		rows = append(rows, []string{
+			utils.SanitizeCsvRow(&table.TableName),
+			fmt.Sprint(table.RowCount),
+			formatGib(table.SourceBytes),
+			formatGib(table.SpannerBytes),
+		})
+	}
+	return rows
+}
+
+func formatGib(bytes int64) string {
+	return fmt.Sprintf("%.3f", float64(bytes)/(1024*1024*1024))
+}
+
 func convertToCodeReportRows(snippets *[]utils.Snippet) []CodeReportRow {
 
 	rows := []CodeReportRow{}
@@ -220,6 +260,12 @@ func GenerateReport(dbName string, assessmentOutput utils.AssessmentOutput) {
 		logger.Log.Info("not performing application assessment as code is not detected")
 	}
 
+	if len(assessmentOutput.CostAssessment.RegionConfigs) > 0 {
+		costFile := folderPath + "cost_assessment.csv"
+		dumpCsvReport(costFile, generateCostSummary(assessmentOutput.CostAssessment))
+		logger.Log.Info("completed publishing cost assessment report: " + costFile)
+	}
+
 	// Generate query assessment report
 	if assessmentOutput.QueryAssessment.QueryTranslationResult != nil {
 		queryFile := folderPath + "query_assessment_report.csv"
@@ -965,7 +1011,16 @@ func GenerateQueryAssessmentReport(queries []utils.QueryTranslationResult, outpu
 		"Query ID", "Query Type", "Normalized Query Text", "Original Query Example",
 		"Associated Source Table(s)", "Associated Spanner Table(s)", "Incompatibility Type(s)", "Suggested Spanner Query",
 		"Reason for Change", "Estimated Code Change Effort", "Code Change Details", "Number of Executions",
-		"Databases Referenced", "Source of Information",
+		"Databases Referenced", "Source of Information", "Total Latency (ms)", "Mean Latency (ms)", "Translation Source",
+	})
+
+	// Rank the hottest queries first, when the source recorded their latency or executions.
+	queries = slices.Clone(queries)
+	sort.SliceStable(queries, func(i, j int) bool {
+		if queries[i].TotalLatencyMs != queries[j].TotalLatencyMs {
+			return queries[i].TotalLatencyMs > queries[j].TotalLatencyMs
+		}
+		return queries[i].ExecutionCount > queries[j].ExecutionCount
 	})
 
 	for _, q := range queries {
@@ -1013,6 +1068,13 @@ func GenerateQueryAssessmentReport(queries []utils.QueryTranslationResult, outpu
 		if q.ExecutionCount > 0 {
 			numExec = fmt.Sprintf("%d", q.ExecutionCount)
 		}
+		totalLatency, meanLatency := "", ""
+		if q.TotalLatencyMs > 0 {
+			totalLatency = fmt.Sprintf("%.3f", q.TotalLatencyMs)
+			if q.ExecutionCount > 0 {
+				meanLatency = fmt.Sprintf("%.3f", q.TotalLatencyMs/float64(q.ExecutionCount))
+			}
+		}
 
 		databasesReferenced := ""
 		if len(q.DatabasesReferenced) > 0 {
@@ -1047,6 +1109,9 @@ func GenerateQueryAssessmentReport(queries []utils.QueryTranslationResult, outpu
 			numExec,
 			databasesReferenced,
 			q.AssessmentSource,
+			totalLatency,
+			meanLatency,
+			q.TranslationSource,
 		})
 	}
 	return nil
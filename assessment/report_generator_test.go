@@ -2584,7 +2584,7 @@ func TestGenerateQueryAssessmentReport(t *testing.T) {
 		"Query ID", "Query Type", "Normalized Query Text", "Original Query Example",
 		"Associated Source Table(s)", "Associated Spanner Table(s)", "Incompatibility Type(s)", "Suggested Spanner Query",
 		"Reason for Change", "Estimated Code Change Effort", "Code Change Details", "Number of Executions",
-		"Databases Referenced", "Source of Information",
+		"Databases Referenced", "Source of Information", "Total Latency (ms)", "Mean Latency (ms)", "Translation Source",
 	}
 	assert.Equal(t, expectedHeader, records[0])
 
@@ -2646,6 +2646,79 @@ func TestGenerateQueryAssessmentReport_FileCreationError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGenerateQueryAssessmentReport_RankedByLatency(t *testing.T) {
+	queries := []utils.QueryTranslationResult{
+		{NormalizedQuery: "SELECT ?", ExecutionCount: 1000},
+		{NormalizedQuery: "SELECT * FROM orders", ExecutionCount: 4, TotalLatencyMs: 10},
+		{NormalizedQuery: "SELECT * FROM users", ExecutionCount: 10},
+		{NormalizedQuery: "SELECT * FROM items", ExecutionCount: 3, TotalLatencyMs: 250.5},
+	}
+	tmpfile, err := os.CreateTemp("", "query_assessment_report_*.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+
+	err = GenerateQueryAssessmentReport(queries, tmpfile.Name())
+	assert.NoError(t, err)
+
+	f, err := os.Open(tmpfile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	reader := csv.NewReader(f)
+	reader.Comma = '\t'
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, records, 5)
+	var order [][]string
+	for _, record := range records[1:] {
+		order = append(order, []string{record[2], record[14], record[15]})
+	}
+	assert.Equal(t, [][]string{
+		{"SELECT * FROM items", "250.500", "83.500"},
+		{"SELECT * FROM orders", "10.000", "2.500"},
+		{"SELECT ?", "", ""},
+		{"SELECT * FROM users", "", ""},
+	}, order)
+	assert.Equal(t, "SELECT ?", queries[0].NormalizedQuery, "the queries passed in are not reordered")
+}
+
+func TestGenerateQueryAssessmentReport_TranslationSource(t *testing.T) {
+	queries := []utils.QueryTranslationResult{
+		{NormalizedQuery: "SELECT * FROM users LIMIT 10, 5", SpannerQuery: "SELECT * FROM `users` LIMIT 5 OFFSET 10", ExecutionCount: 2, TranslationSource: utils.TranslationSourceRules},
+		{NormalizedQuery: "SELECT GROUP_CONCAT(name) FROM users", SpannerQuery: "SELECT STRING_AGG(name) FROM users", ExecutionCount: 1, TranslationSource: utils.TranslationSourceLLM},
+	}
+	tmpfile, err := os.CreateTemp("", "query_assessment_report_*.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+
+	err = GenerateQueryAssessmentReport(queries, tmpfile.Name())
+	assert.NoError(t, err)
+
+	f, err := os.Open(tmpfile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	reader := csv.NewReader(f)
+	reader.Comma = '\t'
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, records, 3)
+	assert.Equal(t, []string{"SELECT * FROM `users` LIMIT 5 OFFSET 10", "rules"}, []string{records[1][7], records[1][16]})
+	assert.Equal(t, []string{"SELECT STRING_AGG(name) FROM users", "llm"}, []string{records[2][7], records[2][16]})
+}
+
 func TestCodeChangeEffort(t *testing.T) {
 	assert.Equal(t, "Low", codeChangeEffort("simple"))
 	assert.Equal(t, "Medium", codeChangeEffort("moderate"))
@@ -2653,3 +2726,35 @@ func TestCodeChangeEffort(t *testing.T) {
 	assert.Equal(t, "High", codeChangeEffort("complex"))
 	assert.Equal(t, "", codeChangeEffort("unknown"))
 }
+
+func TestGenerateCostSummary(t *testing.T) {
+	costAssessment := utils.CostAssessmentOutput{
+		Tables: []utils.TableCostAssessment{
+			{TableName: "orders", RowCount: 5000, SourceBytes: 3 * 1024 * 1024 * 1024, SpannerBytes: 4 * 1024 * 1024 * 1024},
+		},
+		SourceBytes:             3 * 1024 * 1024 * 1024,
+		SpannerBytes:            4 * 1024 * 1024 * 1024,
+		ReadQps:                 10,
+		WriteQps:                1.5,
+		StatisticsWindowSeconds: 5400,
+		PeakToAverageRatio:      3,
+		RegionConfigs: []utils.RegionConfigCostAssessment{
+			{RegionConfig: "regional-us-central1", ProcessingUnits: 100, MinMonthlyCostUsd: 40.62, MaxMonthlyCostUsd: 66.9},
+		},
+	}
+
+	assert.Equal(t, [][]string{
+		{"Source Size (GiB)", "3.000"},
+		{"Estimated Spanner Size (GiB)", "4.000"},
+		{"Statistics Window (Hours)", "1.5"},
+		{"Average Reads per Second over the Window", "10.00"},
+		{"Average Writes per Second over the Window", "1.50"},
+		{"Peak to Average Ratio", "3.00"},
+		{"Peak Reads per Second (Sizing)", "30.00"},
+		{"Peak Writes per Second (Sizing)", "4.50"},
+		{"Region Configuration", "Processing Units", "Minimum Monthly Cost (USD)", "Maximum Monthly Cost (USD)"},
+		{"regional-us-central1", "100", "40.62", "66.90"},
+		{"Table", "Rows", "Source Size (GiB)", "Estimated Spanner Size (GiB)"},
+		{"orders", "5000", "3.000", "4.000"},
+	}, generateCostSummary(costAssessment))
+}
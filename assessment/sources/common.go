@@ -28,12 +28,16 @@ type InfoSchema interface {
 	GetTableInfo(conv *internal.Conv) (map[string]utils.TableAssessmentInfo, error)
 	GetFunctionInfo() ([]utils.FunctionAssessmentInfo, error)
 	GetViewInfo() ([]utils.ViewAssessmentInfo, error)
+	GetTableSizeInfo() ([]utils.TableSizeAssessmentInfo, error)
 }
 
 type InfoSchemaImpl struct{}
 
 type PerformanceSchema interface {
 	GetAllQueryAssessments() ([]utils.QueryAssessmentInfo, error)
+	// GetStatisticsWindowSeconds returns how long the statistics of the
+	// queries have been gathered for.
+	GetStatisticsWindowSeconds() (int64, error)
 }
 
 type PerformanceSchemaImpl struct{}
@@ -43,3 +47,10 @@ type SourceSpecificComparison interface {
 }
 
 type SourceSpecificComparisonImpl struct{}
+
+// QueryTranslator translates the queries of a source to Spanner with rules,
+// without an LLM. It returns an error for the queries the rules can't
+// translate.
+type QueryTranslator interface {
+	TranslateQuery(query string) (utils.QueryTranslationResult, error)
+}
@@ -254,6 +254,42 @@ func (isi InfoSchemaImpl) GetViewInfo() ([]utils.ViewAssessmentInfo, error) {
 	return views, nil
 }
 
+// GetTableSizeInfo returns the row counts and sizes of the tables, which
+// InnoDB estimates from a sample of their pages.
+func (isi InfoSchemaImpl) GetTableSizeInfo() ([]utils.TableSizeAssessmentInfo, error) {
+	q := `SELECT TABLE_NAME, COALESCE(TABLE_ROWS, 0), COALESCE(DATA_LENGTH, 0), COALESCE(INDEX_LENGTH, 0)
+		FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE';`
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name string
+	var rowCount, dataBytes, indexBytes int64
+	var tableSizes []utils.TableSizeAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &rowCount, &dataBytes, &indexBytes); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		tableSizes = append(tableSizes, utils.TableSizeAssessmentInfo{
+			Name:       name,
+			RowCount:   rowCount,
+			DataBytes:  dataBytes,
+			IndexBytes: indexBytes,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return tableSizes, fmt.Errorf("%s", errString)
+	}
+	return tableSizes, nil
+}
+
 func getColumnMaxSize(dataType string, mods []int64, mysqlCharset string) int64 {
 	dataTypeLower := strings.ToLower(dataType)
 	bytesPerChar := int64(1) // Default for binary types or non-char types
@@ -708,6 +708,26 @@ func TestInfoSchemaImpl_GetViewInfo(t *testing.T) {
 	}
 }
 
+func TestInfoSchemaImpl_GetTableSizeInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	isi.DbName = "test_db"
+	defer isi.Db.Close()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT TABLE_NAME, COALESCE(TABLE_ROWS, 0), COALESCE(DATA_LENGTH, 0), COALESCE(INDEX_LENGTH, 0) FROM INFORMATION_SCHEMA.TABLES`)).
+		WithArgs("test_db").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "TABLE_ROWS", "DATA_LENGTH", "INDEX_LENGTH"}).
+			AddRow("orders", 1000, 163840, 32768).
+			AddRow("users", 0, 16384, 0))
+
+	result, err := isi.GetTableSizeInfo()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.TableSizeAssessmentInfo{
+		{Name: "orders", RowCount: 1000, DataBytes: 163840, IndexBytes: 32768, Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+		{Name: "users", DataBytes: 16384, Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetMaxBytesPerChar(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -70,3 +70,14 @@ ORDER BY
 	}
 	return queryInfo, nil
 }
+
+// GetStatisticsWindowSeconds returns the uptime of the server, since the
+// statement digests are gathered from its start unless they were truncated.
+func (psi PerformanceSchemaImpl) GetStatisticsWindowSeconds() (int64, error) {
+	q := `SELECT VARIABLE_VALUE FROM performance_schema.global_status WHERE VARIABLE_NAME = 'Uptime';`
+	var uptime int64
+	if err := psi.Db.QueryRow(q).Scan(&uptime); err != nil {
+		return 0, fmt.Errorf("couldn't read the uptime from performance schema : %s", err)
+	}
+	return uptime, nil
+}
@@ -190,3 +190,20 @@ func TestGetAllQueryAssessments_NoRows(t *testing.T) {
 	assert.Len(t, queries, 0)
 	assert.Nil(t, queries)
 }
+
+func TestGetStatisticsWindowSeconds(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: `SELECT VARIABLE_VALUE FROM performance_schema.global_status WHERE VARIABLE_NAME = 'Uptime';`,
+			cols:  []string{"VARIABLE_VALUE"},
+			rows:  [][]driver.Value{{"86400"}},
+		},
+	}
+	db := mkMockDB(t, ms)
+	defer db.Close()
+
+	window, err := PerformanceSchemaImpl{Db: db, DbName: "test_db"}.GetStatisticsWindowSeconds()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(86400), window)
+}
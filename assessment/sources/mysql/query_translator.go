@@ -0,0 +1,594 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+)
+
+// renamedFunctions maps the MySQL functions which have another name in
+// GoogleSQL to it.
+var renamedFunctions = map[string]string{
+	"ifnull":            "COALESCE",
+	"now":               "CURRENT_TIMESTAMP",
+	"current_timestamp": "CURRENT_TIMESTAMP",
+	"localtime":         "CURRENT_TIMESTAMP",
+	"localtimestamp":    "CURRENT_TIMESTAMP",
+	"curdate":           "CURRENT_DATE",
+	"current_date":      "CURRENT_DATE",
+	"lcase":             "LOWER",
+	"ucase":             "UPPER",
+	"substring":         "SUBSTR",
+	"length":            "BYTE_LENGTH",
+	"character_length":  "CHAR_LENGTH",
+	"date_format":       "FORMAT_TIMESTAMP",
+}
+
+// mismatchedFunctions are the functions GoogleSQL has with the same name as
+// MySQL but with other arguments or results.
+var mismatchedFunctions = map[string]bool{
+	"date_add": true,
+	"date_sub": true,
+	"extract":  true,
+	"format":   true,
+	"log":      true,
+	"md5":      true,
+	"sha1":     true,
+	"trim":     true,
+}
+
+// dateFormatSpecifiers maps the specifiers of DATE_FORMAT to the format
+// elements of FORMAT_TIMESTAMP.
+var dateFormatSpecifiers = map[byte]string{
+	'Y': "%Y",
+	'y': "%y",
+	'm': "%m",
+	'c': "%m",
+	'd': "%d",
+	'e': "%e",
+	'H': "%H",
+	'k': "%k",
+	'h': "%I",
+	'I': "%I",
+	'l': "%l",
+	'i': "%M",
+	's': "%S",
+	'S': "%S",
+	'p': "%p",
+	'M': "%B",
+	'b': "%b",
+	'W': "%A",
+	'a': "%a",
+	'j': "%j",
+	'T': "%T",
+	'r': "%r",
+	'%': "%%",
+}
+
+// QueryTranslatorImpl translates MySQL queries to GoogleSQL with rules, using
+// the schema conversion of Conv to rename the tables and columns.
+type QueryTranslatorImpl struct {
+	Conv *internal.Conv
+}
+
+// TranslateQuery translates a query with the rules, or returns an error when
+// the query uses a construct the rules can't translate.
+func (qt QueryTranslatorImpl) TranslateQuery(query string) (utils.QueryTranslationResult, error) {
+	if qt.Conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return utils.QueryTranslationResult{}, fmt.Errorf("rules only translate to GoogleSQL")
+	}
+	stmt, err := parser.New().ParseOneStmt(query, "", "")
+	if err != nil {
+		return utils.QueryTranslationResult{}, fmt.Errorf("can't parse query: %w", err)
+	}
+
+	result := utils.QueryTranslationResult{
+		OriginalQuery:     query,
+		NormalizedQuery:   query,
+		Complexity:        "simple",
+		QueryType:         utils.GetQueryType(query),
+		TranslationSource: utils.TranslationSourceRules,
+	}
+	if isLastInsertIdSelect(stmt) {
+		result.Complexity = "moderate"
+		result.Explanation = "Spanner has no LAST_INSERT_ID(), the key generated by an INSERT is read with its THEN RETURN clause instead."
+		return result, nil
+	}
+
+	t := &queryTranslation{conv: qt.Conv, tables: make(map[string]string)}
+	spannerQuery, err := t.translate(stmt)
+	if err != nil {
+		return utils.QueryTranslationResult{}, err
+	}
+	result.SpannerQuery = spannerQuery
+	result.SourceTablesAffected = t.sourceTables
+	if t.returnsKey {
+		result.Complexity = "moderate"
+	}
+	if len(t.changes) == 0 {
+		result.Explanation = "The query is compatible with Spanner."
+	} else {
+		result.Explanation = strings.Join(t.changes, " ")
+	}
+	return result, nil
+}
+
+// isLastInsertIdSelect tells if a statement only reads LAST_INSERT_ID().
+func isLastInsertIdSelect(stmt ast.StmtNode) bool {
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok || sel.From != nil || sel.Fields == nil || len(sel.Fields.Fields) != 1 {
+		return false
+	}
+	f, ok := sel.Fields.Fields[0].Expr.(*ast.FuncCallExpr)
+	return ok && f.FnName.L == "last_insert_id"
+}
+
+// queryTranslation is the state of the translation of a statement.
+type queryTranslation struct {
+	conv *internal.Conv
+	// tables maps the names and aliases of the tables of the statement to
+	// their source names.
+	tables       map[string]string
+	sourceTables []string
+	changes      []string
+	returnsKey   bool
+	err          error
+}
+
+func (t *queryTranslation) translate(stmt ast.StmtNode) (string, error) {
+	var limit *ast.Limit
+	var insertPrefix, suffix string
+	switch s := stmt.(type) {
+	case *ast.SelectStmt:
+		if s.LockInfo != nil && s.LockInfo.LockType != ast.SelectLockNone && s.LockInfo.LockType != ast.SelectLockForUpdate {
+			return "", fmt.Errorf("lock %s not supported", s.LockInfo.LockType)
+		}
+		limit, s.Limit = s.Limit, nil
+	case *ast.SetOprStmt:
+		limit, s.Limit = s.Limit, nil
+	case *ast.InsertStmt:
+		var err error
+		if insertPrefix, suffix, err = t.translateInsert(s); err != nil {
+			return "", err
+		}
+	case *ast.UpdateStmt:
+		if s.MultipleTable || s.Order != nil || s.Limit != nil {
+			return "", fmt.Errorf("multi-table, ordered and limited updates not supported")
+		}
+		if s.Where == nil {
+			suffix = " WHERE TRUE"
+			t.changes = append(t.changes, "Spanner requires a WHERE clause in UPDATE statements.")
+		}
+	case *ast.DeleteStmt:
+		if s.IsMultiTable || s.Order != nil || s.Limit != nil {
+			return "", fmt.Errorf("multi-table, ordered and limited deletes not supported")
+		}
+		if s.Where == nil {
+			suffix = " WHERE TRUE"
+			t.changes = append(t.changes, "Spanner requires a WHERE clause in DELETE statements.")
+		}
+	default:
+		return "", fmt.Errorf("statement %T not supported", stmt)
+	}
+
+	stmt.Accept(&tableCollector{t: t})
+	stmt.Accept(t)
+	if t.err != nil {
+		return "", t.err
+	}
+
+	query, err := restore(stmt)
+	if err != nil {
+		return "", err
+	}
+	if insertPrefix != "" {
+		if !strings.HasPrefix(query, "INSERT INTO ") {
+			return "", fmt.Errorf("INSERT modifiers not supported")
+		}
+		query = insertPrefix + strings.TrimPrefix(query, "INSERT ")
+	}
+	// The parameters are numbered in the order of the original query, where
+	// the offset of the limit is before its count.
+	query, n := namedParameters(query, 0)
+	if limit != nil {
+		var offset string
+		if limit.Offset != nil {
+			if offset, err = restore(limit.Offset); err != nil {
+				return "", err
+			}
+			offset, n = namedParameters(offset, n)
+		}
+		count, err := restore(limit.Count)
+		if err != nil {
+			return "", err
+		}
+		count, n = namedParameters(count, n)
+		query += " LIMIT " + count
+		if offset != "" {
+			query += " OFFSET " + offset
+			t.changes = append(t.changes, "LIMIT offset, count is written LIMIT count OFFSET offset.")
+		}
+	}
+	if n > 0 {
+		t.changes = append(t.changes, "The ? parameters are written @p1, @p2...")
+	}
+	return query + suffix, nil
+}
+
+// translateInsert rewrites the MySQL specific clauses of an INSERT. It returns
+// the GoogleSQL replacement of INSERT, when it isn't a plain insert, and the
+// THEN RETURN clause of the inserts generating an AUTO_INCREMENT key.
+func (t *queryTranslation) translateInsert(s *ast.InsertStmt) (string, string, error) {
+	if s.IsReplace {
+		return "", "", fmt.Errorf("REPLACE not supported")
+	}
+	if s.Setlist != nil {
+		return "", "", fmt.Errorf("INSERT ... SET not supported")
+	}
+	tableName, ok := insertTableName(s)
+	if !ok {
+		return "", "", fmt.Errorf("INSERT into %T not supported", s.Table)
+	}
+	prefix := ""
+	switch {
+	case s.IgnoreErr && s.OnDuplicate != nil:
+		return "", "", fmt.Errorf("INSERT IGNORE ... ON DUPLICATE KEY UPDATE not supported")
+	case s.IgnoreErr:
+		prefix = "INSERT OR IGNORE "
+		s.IgnoreErr = false
+		t.changes = append(t.changes, "INSERT IGNORE is written INSERT OR IGNORE.")
+	case s.OnDuplicate != nil:
+		if err := t.checkUpsert(s, tableName); err != nil {
+			return "", "", err
+		}
+		prefix = "INSERT OR UPDATE "
+		s.OnDuplicate = nil
+		t.changes = append(t.changes, "INSERT ... ON DUPLICATE KEY UPDATE is written INSERT OR UPDATE.")
+	}
+	return prefix, t.returnGeneratedKey(s, tableName), nil
+}
+
+func insertTableName(s *ast.InsertStmt) (string, bool) {
+	if s.Table == nil || s.Table.TableRefs == nil {
+		return "", false
+	}
+	ts, ok := s.Table.TableRefs.Left.(*ast.TableSource)
+	if !ok || s.Table.TableRefs.Right != nil {
+		return "", false
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok {
+		return "", false
+	}
+	return tn.Name.O, true
+}
+
+// checkUpsert checks that an ON DUPLICATE KEY UPDATE sets all the inserted
+// columns but the primary key to their new values, like INSERT OR UPDATE.
+func (t *queryTranslation) checkUpsert(s *ast.InsertStmt, tableName string) error {
+	if len(s.Columns) == 0 {
+		return fmt.Errorf("ON DUPLICATE KEY UPDATE without columns not supported")
+	}
+	updated := make(map[string]bool)
+	for _, a := range s.OnDuplicate {
+		v, ok := a.Expr.(*ast.ValuesExpr)
+		if !ok || v.Column == nil || v.Column.Name.Name.L != a.Column.Name.L {
+			return fmt.Errorf("ON DUPLICATE KEY UPDATE of %s not to its new value not supported", a.Column.Name.O)
+		}
+		updated[a.Column.Name.L] = true
+	}
+	keys := t.primaryKeys(tableName)
+	for _, c := range s.Columns {
+		if !updated[c.Name.L] && !keys[c.Name.L] {
+			return fmt.Errorf("ON DUPLICATE KEY UPDATE not updating %s not supported", c.Name.O)
+		}
+	}
+	return nil
+}
+
+func (t *queryTranslation) primaryKeys(tableName string) map[string]bool {
+	keys := make(map[string]bool)
+	tableId, err := internal.GetTableIdFromSrcName(t.conv.SrcSchema, tableName)
+	if err != nil {
+		return keys
+	}
+	table := t.conv.SrcSchema[tableId]
+	for _, k := range table.PrimaryKeys {
+		keys[strings.ToLower(table.ColDefs[k.ColId].Name)] = true
+	}
+	return keys
+}
+
+// returnGeneratedKey returns the THEN RETURN clause of an insert not setting
+// the AUTO_INCREMENT column of its table, which the application reads with
+// LAST_INSERT_ID() in MySQL.
+func (t *queryTranslation) returnGeneratedKey(s *ast.InsertStmt, tableName string) string {
+	if len(s.Columns) == 0 {
+		return ""
+	}
+	tableId, err := internal.GetTableIdFromSrcName(t.conv.SrcSchema, tableName)
+	if err != nil {
+		return ""
+	}
+	for _, colId := range t.conv.SrcSchema[tableId].ColIds {
+		col := t.conv.SrcSchema[tableId].ColDefs[colId]
+		if col.AutoGen.GenerationType != constants.AUTO_INCREMENT {
+			continue
+		}
+		if slices.ContainsFunc(s.Columns, func(c *ast.ColumnName) bool { return strings.EqualFold(c.Name.O, col.Name) }) {
+			return ""
+		}
+		t.returnsKey = true
+		t.changes = append(t.changes, fmt.Sprintf("The generated %s is returned with THEN RETURN instead of being read with LAST_INSERT_ID().", col.Name))
+		return " THEN RETURN `" + t.spannerColumn(tableName, col.Name) + "`"
+	}
+	return ""
+}
+
+// tableCollector collects the tables of a statement and their aliases.
+type tableCollector struct {
+	t *queryTranslation
+}
+
+func (c *tableCollector) Enter(n ast.Node) (ast.Node, bool) {
+	switch node := n.(type) {
+	case *ast.TableSource:
+		if tn, ok := node.Source.(*ast.TableName); ok && node.AsName.L != "" {
+			c.t.tables[node.AsName.L] = tn.Name.O
+		}
+	case *ast.TableName:
+		c.t.tables[node.Name.L] = node.Name.O
+		if _, ok := c.t.conv.ToSpanner[node.Name.O]; ok && !slices.Contains(c.t.sourceTables, node.Name.O) {
+			c.t.sourceTables = append(c.t.sourceTables, node.Name.O)
+		}
+	}
+	return n, false
+}
+
+func (c *tableCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// Enter rewrites the nodes of the statement, and records the first construct
+// the rules can't translate.
+func (t *queryTranslation) Enter(n ast.Node) (ast.Node, bool) {
+	if t.err != nil {
+		return n, true
+	}
+	switch node := n.(type) {
+	case *ast.TableName:
+		if node.Schema.O != "" {
+			t.err = fmt.Errorf("table %s.%s of another database not supported", node.Schema.O, node.Name.O)
+			return n, true
+		}
+		if name, ok := t.spannerTable(node.Name.O); ok && name != node.Name.O {
+			node.Name = model.NewCIStr(name)
+		}
+	case *ast.ColumnName:
+		t.renameColumn(node)
+	case *ast.FuncCallExpr:
+		t.err = t.translateFunction(node)
+	case *ast.AggregateFuncExpr:
+		if !utils.SupportedFunctions[strings.ToUpper(node.F)] {
+			t.err = fmt.Errorf("aggregate function %s not supported", node.F)
+		}
+	case *ast.Limit:
+		// The limit of the statement is restored apart, as GoogleSQL has no
+		// LIMIT offset, count.
+		if node.Offset != nil {
+			t.err = fmt.Errorf("LIMIT with offset in a subquery not supported")
+		}
+	case *ast.BinaryOperationExpr:
+		switch node.Op {
+		case opcode.Mod, opcode.IntDiv, opcode.LogicXor:
+			t.err = fmt.Errorf("operator %s not supported", node.Op)
+		}
+	case *driver.ValueExpr:
+		if s, ok := node.GetValue().(string); ok && strings.ContainsAny(s, `'\`) {
+			t.err = fmt.Errorf("string literal with quotes or backslashes not supported")
+		}
+	case *ast.SelectField:
+		// The wildcard of a field isn't visited.
+		if w := node.WildCard; w != nil {
+			if name, ok := t.spannerTable(t.tables[w.Table.L]); ok && w.Table.L == strings.ToLower(t.tables[w.Table.L]) {
+				w.Table = model.NewCIStr(name)
+			}
+		}
+	case *ast.VariableExpr, *ast.MatchAgainst, *ast.PatternRegexpExpr, *ast.FuncCastExpr, *ast.WindowFuncExpr, *ast.ValuesExpr:
+		t.err = fmt.Errorf("%T not supported", node)
+	}
+	return n, t.err != nil
+}
+
+func (t *queryTranslation) Leave(n ast.Node) (ast.Node, bool) {
+	return n, t.err == nil
+}
+
+// renameColumn renames a column to its Spanner name. Its table is the one of
+// its qualifier, or else the only table of the statement having it.
+func (t *queryTranslation) renameColumn(c *ast.ColumnName) {
+	if c.Table.L != "" {
+		tableName, ok := t.tables[c.Table.L]
+		if !ok {
+			return
+		}
+		c.Name = model.NewCIStr(t.spannerColumn(tableName, c.Name.O))
+		if c.Table.L == strings.ToLower(tableName) {
+			if name, ok := t.spannerTable(tableName); ok {
+				c.Table = model.NewCIStr(name)
+			}
+		}
+		return
+	}
+	name := ""
+	for _, tableName := range t.sourceTables {
+		if _, ok := lookupColumn(t.conv.ToSpanner[tableName].Cols, c.Name.O); !ok {
+			continue
+		}
+		spName := t.spannerColumn(tableName, c.Name.O)
+		if name != "" && name != spName {
+			t.err = fmt.Errorf("ambiguous column %s", c.Name.O)
+			return
+		}
+		name = spName
+	}
+	if name != "" {
+		c.Name = model.NewCIStr(name)
+	}
+}
+
+// spannerTable returns the Spanner name of a source table or view.
+func (t *queryTranslation) spannerTable(name string) (string, bool) {
+	if nameAndCols, ok := t.conv.ToSpanner[name]; ok {
+		return nameAndCols.Name, true
+	}
+	for id, view := range t.conv.SrcViews {
+		if spView, ok := t.conv.SpViews[id]; ok && view.Name == name {
+			return spView.Name, true
+		}
+	}
+	return "", false
+}
+
+// spannerColumn returns the Spanner name of a column of a source table.
+func (t *queryTranslation) spannerColumn(tableName, colName string) string {
+	if spName, ok := lookupColumn(t.conv.ToSpanner[tableName].Cols, colName); ok {
+		return spName
+	}
+	return colName
+}
+
+// lookupColumn finds a column case-insensitively, like MySQL.
+func lookupColumn(cols map[string]string, colName string) (string, bool) {
+	if spName, ok := cols[colName]; ok {
+		return spName, true
+	}
+	for srcName, spName := range cols {
+		if strings.EqualFold(srcName, colName) {
+			return spName, true
+		}
+	}
+	return "", false
+}
+
+func (t *queryTranslation) translateFunction(f *ast.FuncCallExpr) error {
+	name := f.FnName.L
+	if name == "last_insert_id" {
+		return fmt.Errorf("LAST_INSERT_ID() not supported in an expression")
+	}
+	if mismatchedFunctions[name] {
+		return fmt.Errorf("function %s not supported", f.FnName.O)
+	}
+	spName, renamed := renamedFunctions[name]
+	if !renamed {
+		spName = strings.ToUpper(name)
+	}
+	if !utils.SupportedFunctions[spName] {
+		return fmt.Errorf("function %s not supported", f.FnName.O)
+	}
+	if name == "date_format" {
+		if err := translateDateFormat(f); err != nil {
+			return err
+		}
+	}
+	if renamed && !strings.EqualFold(f.FnName.O, spName) {
+		t.changes = append(t.changes, fmt.Sprintf("%s is replaced by %s.", strings.ToUpper(f.FnName.O), spName))
+	}
+	f.FnName = model.NewCIStr(spName)
+	return nil
+}
+
+// translateDateFormat swaps the arguments of DATE_FORMAT(date, format) to
+// the ones of FORMAT_TIMESTAMP(format, timestamp), and translates its format.
+func translateDateFormat(f *ast.FuncCallExpr) error {
+	if len(f.Args) != 2 {
+		return fmt.Errorf("DATE_FORMAT with %d arguments not supported", len(f.Args))
+	}
+	v, ok := f.Args[1].(*driver.ValueExpr)
+	if !ok {
+		return fmt.Errorf("DATE_FORMAT with a format which isn't a literal not supported")
+	}
+	mysqlFormat, ok := v.GetValue().(string)
+	if !ok {
+		return fmt.Errorf("DATE_FORMAT with a format which isn't a string not supported")
+	}
+	var spFormat strings.Builder
+	for i := 0; i < len(mysqlFormat); i++ {
+		if mysqlFormat[i] != '%' {
+			spFormat.WriteByte(mysqlFormat[i])
+			continue
+		}
+		if i+1 == len(mysqlFormat) {
+			return fmt.Errorf("DATE_FORMAT format %s not supported", mysqlFormat)
+		}
+		i++
+		element, ok := dateFormatSpecifiers[mysqlFormat[i]]
+		if !ok {
+			return fmt.Errorf("DATE_FORMAT specifier %%%c not supported", mysqlFormat[i])
+		}
+		spFormat.WriteString(element)
+	}
+	f.Args = []ast.ExprNode{ast.NewValueExpr(spFormat.String(), "", ""), f.Args[0]}
+	return nil
+}
+
+// restore writes a node back to SQL, with the names quoted with backticks
+// like GoogleSQL.
+func restore(node ast.Node) (string, error) {
+	var sb strings.Builder
+	flags := format.RestoreStringSingleQuotes | format.RestoreStringWithoutCharset | format.RestoreKeyWordUppercase |
+		format.RestoreNameBackQuotes | format.RestoreSpacesAroundBinaryOperation
+	if err := node.Restore(format.NewRestoreCtx(flags, &sb)); err != nil {
+		return "", fmt.Errorf("can't restore query: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// namedParameters replaces the ? parameters of a query with the @p1, @p2...
+// named parameters of GoogleSQL, numbered after the n previous ones. It
+// returns the number of parameters so far.
+func namedParameters(query string, n int) (string, int) {
+	var sb strings.Builder
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '`' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			sb.WriteString(fmt.Sprintf("@p%d", n))
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String(), n
+}
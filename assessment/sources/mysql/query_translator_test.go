@@ -0,0 +1,226 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTranslatorTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name:   "users",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", AutoGen: ddl.AutoGenCol{Name: constants.AUTO_INCREMENT, GenerationType: constants.AUTO_INCREMENT}},
+				"c2": {Name: "name", Id: "c2"},
+				"c3": {Name: "created", Id: "c3"},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c1"}},
+		},
+		"t2": {
+			Name:   "orders",
+			Id:     "t2",
+			ColIds: []string{"c4", "c5"},
+			ColDefs: map[string]schema.Column{
+				"c4": {Name: "id", Id: "c4"},
+				"c5": {Name: "user_id", Id: "c5"},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c4"}},
+		},
+	}
+	conv.ToSpanner = map[string]internal.NameAndCols{
+		"users":  {Name: "users", Cols: map[string]string{"id": "id", "name": "full_name", "created": "created"}},
+		"orders": {Name: "customer_orders", Cols: map[string]string{"id": "id", "user_id": "user_id"}},
+	}
+	conv.SrcViews = map[string]schema.View{"v1": {Name: "active-users", Id: "v1"}}
+	conv.SpViews = map[string]ddl.CreateView{"v1": {Name: "active_users", Id: "v1"}}
+	return conv
+}
+
+// compact drops the whitespace of a query, to compare queries regardless of
+// how the parser spaces them.
+func compact(query string) string {
+	return strings.Join(strings.Fields(query), "")
+}
+
+func TestQueryTranslatorImpl_TranslateQuery(t *testing.T) {
+	translator := QueryTranslatorImpl{Conv: newTranslatorTestConv()}
+
+	tests := []struct {
+		name               string
+		query              string
+		wantSpannerQuery   string
+		wantContains       []string
+		wantComplexity     string
+		wantTables         []string
+		wantExplanationHas string
+	}{
+		{
+			name:               "limit with offset and renamed column",
+			query:              "SELECT name FROM users WHERE id = ? LIMIT 10, 20",
+			wantSpannerQuery:   "SELECT `full_name` FROM `users` WHERE `id` = @p1 LIMIT 20 OFFSET 10",
+			wantComplexity:     "simple",
+			wantTables:         []string{"users"},
+			wantExplanationHas: "LIMIT count OFFSET offset",
+		},
+		{
+			name:               "parameters of the limit are numbered like the original query",
+			query:              "SELECT id FROM users WHERE name = ? LIMIT ?, ?",
+			wantSpannerQuery:   "SELECT `id` FROM `users` WHERE `full_name` = @p1 LIMIT @p3 OFFSET @p2",
+			wantComplexity:     "simple",
+			wantTables:         []string{"users"},
+			wantExplanationHas: "@p1",
+		},
+		{
+			name:               "renamed functions",
+			query:              "SELECT IFNULL(name, 'none'), NOW() FROM users",
+			wantContains:       []string{"COALESCE(`full_name`,'none')", "CURRENT_TIMESTAMP"},
+			wantComplexity:     "simple",
+			wantTables:         []string{"users"},
+			wantExplanationHas: "IFNULL is replaced by COALESCE.",
+		},
+		{
+			name:           "date format",
+			query:          "SELECT DATE_FORMAT(created, '%Y-%m-%d %H:%i') FROM users",
+			wantContains:   []string{"FORMAT_TIMESTAMP('%Y-%m-%d %H:%M',`created`)"},
+			wantComplexity: "simple",
+			wantTables:     []string{"users"},
+		},
+		{
+			name:               "renamed table with aliases",
+			query:              "SELECT o.id, u.name FROM orders o JOIN users u ON o.user_id = u.id",
+			wantContains:       []string{"`o`.`id`", "`u`.`full_name`", "`customer_orders`AS`o`"},
+			wantComplexity:     "simple",
+			wantTables:         []string{"orders", "users"},
+			wantExplanationHas: "compatible",
+		},
+		{
+			name:               "renamed view and qualified wildcard",
+			query:              "SELECT orders.*, a.name FROM orders JOIN `active-users` a ON orders.user_id = a.id",
+			wantContains:       []string{"`customer_orders`.*", "`active_users`AS`a`"},
+			wantComplexity:     "simple",
+			wantTables:         []string{"orders"},
+			wantExplanationHas: "compatible",
+		},
+		{
+			name:               "on duplicate key update",
+			query:              "INSERT INTO users (id, name) VALUES (?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name)",
+			wantSpannerQuery:   "INSERT OR UPDATE INTO `users` (`id`,`full_name`) VALUES (@p1,@p2)",
+			wantComplexity:     "simple",
+			wantTables:         []string{"users"},
+			wantExplanationHas: "INSERT OR UPDATE",
+		},
+		{
+			name:               "insert ignore",
+			query:              "INSERT IGNORE INTO orders (id, user_id) VALUES (?, ?)",
+			wantSpannerQuery:   "INSERT OR IGNORE INTO `customer_orders` (`id`,`user_id`) VALUES (@p1,@p2)",
+			wantComplexity:     "simple",
+			wantTables:         []string{"orders"},
+			wantExplanationHas: "INSERT OR IGNORE",
+		},
+		{
+			name:               "insert generating an auto increment key",
+			query:              "INSERT INTO users (name) VALUES (?)",
+			wantSpannerQuery:   "INSERT INTO `users` (`full_name`) VALUES (@p1) THEN RETURN `id`",
+			wantComplexity:     "moderate",
+			wantTables:         []string{"users"},
+			wantExplanationHas: "LAST_INSERT_ID()",
+		},
+		{
+			name:               "delete without where",
+			query:              "DELETE FROM orders",
+			wantSpannerQuery:   "DELETE FROM `customer_orders` WHERE TRUE",
+			wantComplexity:     "simple",
+			wantTables:         []string{"orders"},
+			wantExplanationHas: "WHERE clause",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := translator.TranslateQuery(tc.query)
+			assert.NoError(t, err)
+			if tc.wantSpannerQuery != "" {
+				assert.Equal(t, compact(tc.wantSpannerQuery), compact(result.SpannerQuery))
+			}
+			for _, want := range tc.wantContains {
+				assert.Contains(t, compact(result.SpannerQuery), compact(want))
+			}
+			assert.Equal(t, tc.query, result.OriginalQuery)
+			assert.Equal(t, tc.wantComplexity, result.Complexity)
+			assert.Equal(t, tc.wantTables, result.SourceTablesAffected)
+			assert.Equal(t, utils.TranslationSourceRules, result.TranslationSource)
+			assert.Contains(t, result.Explanation, tc.wantExplanationHas)
+		})
+	}
+}
+
+func TestQueryTranslatorImpl_TranslateQuery_LastInsertId(t *testing.T) {
+	result, err := QueryTranslatorImpl{Conv: newTranslatorTestConv()}.TranslateQuery("SELECT LAST_INSERT_ID()")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.SpannerQuery)
+	assert.Equal(t, "moderate", result.Complexity)
+	assert.Contains(t, result.Explanation, "THEN RETURN")
+}
+
+func TestQueryTranslatorImpl_TranslateQuery_Unsupported(t *testing.T) {
+	translator := QueryTranslatorImpl{Conv: newTranslatorTestConv()}
+
+	queries := []string{
+		"not a query",
+		"SELECT GROUP_CONCAT(name) FROM users",
+		"SELECT * FROM users WHERE name REGEXP 'a.*'",
+		"SELECT @counter",
+		"SELECT id % 2 FROM users",
+		"SELECT DATE_ADD(created, INTERVAL 1 DAY) FROM users",
+		"SELECT DATE_FORMAT(created, name) FROM users",
+		"SELECT * FROM other_db.users",
+		"SELECT * FROM users WHERE id IN (SELECT user_id FROM orders LIMIT 5, 10)",
+		"SELECT * FROM users LOCK IN SHARE MODE",
+		"SELECT 'it''s'",
+		"REPLACE INTO users (id, name) VALUES (?, ?)",
+		"INSERT INTO users (id, name) VALUES (?, ?) ON DUPLICATE KEY UPDATE name = CONCAT(name, '!')",
+		"INSERT INTO users (id, name, created) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name)",
+		"UPDATE users SET name = ? ORDER BY id LIMIT 1",
+		"SELECT LAST_INSERT_ID() + 1 FROM users",
+		"CREATE TABLE t (id INT)",
+	}
+	for _, query := range queries {
+		_, err := translator.TranslateQuery(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestQueryTranslatorImpl_TranslateQuery_PostgreSQLDialect(t *testing.T) {
+	conv := newTranslatorTestConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+
+	_, err := QueryTranslatorImpl{Conv: conv}.TranslateQuery("SELECT id FROM users")
+
+	assert.Error(t, err)
+}
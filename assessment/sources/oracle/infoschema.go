@@ -0,0 +1,397 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// Maximum size of a LOB value in Oracle, with the default block size.
+const maxLobSize = 4294967295
+
+// Maximum size of a LONG or LONG RAW value in Oracle.
+const maxLongSize = 2147483647
+
+// InfoSchemaImpl reads the objects owned by the schema DbName, which is the
+// user of the connection like in sources/oracle.
+type InfoSchemaImpl struct {
+	Db     *sql.DB
+	DbName string
+}
+
+type SourceSpecificComparisonImpl struct{}
+
+// GetTableInfo returns the charset and collation of the tables, which are
+// the NLS settings of the database, and the virtual columns and sizes of
+// their columns.
+func (isi InfoSchemaImpl) GetTableInfo(conv *internal.Conv) (map[string]utils.TableAssessmentInfo, error) {
+	tb := make(map[string]utils.TableAssessmentInfo)
+	dbIdentifier := utils.DbIdentifier{
+		DatabaseName: isi.DbName,
+	}
+	var errString string
+	var charset, collation string
+	q := `SELECT (SELECT value FROM nls_database_parameters WHERE parameter = 'NLS_CHARACTERSET'),
+		(SELECT value FROM nls_database_parameters WHERE parameter = 'NLS_SORT') FROM dual`
+	err := isi.Db.QueryRow(q).Scan(&charset, &collation)
+	if err != nil {
+		errString = errString + fmt.Sprintf("couldn't get charset of database %s: %s", isi.DbName, err)
+	}
+	for _, table := range conv.SrcSchema {
+		columnAssessments := make(map[string]utils.ColumnAssessmentInfo[any])
+		for _, column := range table.ColDefs {
+			q = `SELECT data_length, virtual_column, data_default
+              FROM all_tab_cols
+              WHERE owner = :1 AND table_name = :2 AND column_name = :3`
+			var dataLength int64
+			var virtualColumn string
+			var dataDefault sql.NullString
+			var generatedColumn utils.GeneratedColumnInfo
+			err := isi.Db.QueryRow(q, isi.DbName, table.Name, column.Name).Scan(&dataLength, &virtualColumn, &dataDefault)
+			if err != nil {
+				errString = errString + fmt.Sprintf("couldn't get schema for column %s.%s: %s", table.Name, column.Name, err)
+			}
+			// Generated columns of Oracle are always virtual, and their
+			// expression is stored as the default.
+			if virtualColumn == "YES" {
+				generatedColumn = utils.GeneratedColumnInfo{
+					Statement: strings.TrimSpace(dataDefault.String),
+					IsPresent: true,
+					IsVirtual: true,
+				}
+			}
+			columnAssessments[column.Id] = utils.ColumnAssessmentInfo[any]{
+				Db:              dbIdentifier,
+				Name:            column.Name,
+				TableName:       table.Name,
+				ColumnDef:       column,
+				MaxColumnSize:   getColumnMaxSize(column.Type.Name, dataLength),
+				GeneratedColumn: generatedColumn,
+			}
+		}
+		tb[table.Id] = utils.TableAssessmentInfo{Name: table.Name, TableDef: table, ColumnAssessmentInfos: columnAssessments, Db: dbIdentifier, Charset: charset, Collation: collation}
+	}
+	if errString != "" {
+		return tb, fmt.Errorf("%s", errString)
+	}
+	return tb, nil
+}
+
+// GetIndexInfo returns the type of an index, e.g. NORMAL or BITMAP.
+func (isi InfoSchemaImpl) GetIndexInfo(table string, index schema.Index) (utils.IndexAssessmentInfo, error) {
+	q := `SELECT index_name, index_type
+		FROM all_indexes
+		WHERE table_owner = :1
+			AND table_name = :2
+			AND index_name = :3`
+
+	var name, indexType string
+	err := isi.Db.QueryRow(q, isi.DbName, table, index.Name).Scan(&name, &indexType)
+	if err != nil {
+		return utils.IndexAssessmentInfo{}, fmt.Errorf("couldn't get index for index name %s.%s: %s", table, index.Name, err)
+	}
+	return utils.IndexAssessmentInfo{
+		Ty:   indexType,
+		Name: name,
+		Db: utils.DbIdentifier{
+			DatabaseName: isi.DbName,
+		},
+		IndexDef: index,
+	}, nil
+}
+
+// GetTriggerInfo returns the triggers on the tables of the schema. The event
+// of a trigger can combine several operations, e.g. INSERT OR UPDATE.
+func (isi InfoSchemaImpl) GetTriggerInfo() ([]utils.TriggerAssessmentInfo, error) {
+	q := `SELECT trigger_name, table_name, trigger_body, trigger_type, triggering_event
+	FROM all_triggers
+	WHERE owner = :1 AND base_object_type = 'TABLE'`
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, table, actionStmt, triggerType, eventManipulation string
+	var triggers []utils.TriggerAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &table, &actionStmt, &triggerType, &eventManipulation); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		triggers = append(triggers, utils.TriggerAssessmentInfo{
+			Name:              name,
+			Operation:         actionStmt,
+			TargetTable:       table,
+			ActionTiming:      getActionTiming(triggerType),
+			EventManipulation: eventManipulation,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return triggers, fmt.Errorf("%s", errString)
+	}
+	return triggers, nil
+}
+
+// GetStoredProcedureInfo returns the standalone procedures of the schema,
+// with their source as definition.
+func (isi InfoSchemaImpl) GetStoredProcedureInfo() ([]utils.StoredProcedureAssessmentInfo, error) {
+	sources, err := isi.getSources("PROCEDURE")
+	if err != nil {
+		return nil, err
+	}
+	q := `SELECT object_name
+	FROM all_procedures
+	WHERE owner = :1 AND object_type = 'PROCEDURE'`
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name string
+	var storedProcedures []utils.StoredProcedureAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		storedProcedures = append(storedProcedures, utils.StoredProcedureAssessmentInfo{
+			Name:       name,
+			Definition: sources[name],
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return storedProcedures, fmt.Errorf("%s", errString)
+	}
+	return storedProcedures, nil
+}
+
+// GetFunctionInfo returns the standalone functions of the schema, with their
+// source as definition.
+func (isi InfoSchemaImpl) GetFunctionInfo() ([]utils.FunctionAssessmentInfo, error) {
+	sources, err := isi.getSources("FUNCTION")
+	if err != nil {
+		return nil, err
+	}
+	q := `SELECT p.object_name, p.deterministic, a.data_type
+	FROM all_procedures p
+		LEFT JOIN all_arguments a ON a.owner = p.owner AND a.object_name = p.object_name
+			AND a.package_name IS NULL AND a.position = 0
+	WHERE p.owner = :1 AND p.object_type = 'FUNCTION'`
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, isDeterministic string
+	var datatype sql.NullString
+	var functions []utils.FunctionAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &isDeterministic, &datatype); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		functions = append(functions, utils.FunctionAssessmentInfo{
+			Name:            name,
+			Definition:      sources[name],
+			IsDeterministic: isDeterministic == "YES",
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+			Datatype: datatype.String,
+		})
+	}
+	if errString != "" {
+		return functions, fmt.Errorf("%s", errString)
+	}
+	return functions, nil
+}
+
+// GetViewInfo returns the views and materialized views of the schema. Views
+// created WITH CHECK OPTION have a constraint of type V.
+func (isi InfoSchemaImpl) GetViewInfo() ([]utils.ViewAssessmentInfo, error) {
+	q := `SELECT v.view_name, v.text,
+		CASE WHEN EXISTS (SELECT 1 FROM all_constraints c WHERE c.owner = v.owner AND c.table_name = v.view_name AND c.constraint_type = 'V')
+			THEN 'CASCADED' ELSE 'NONE' END,
+		CASE WHEN v.read_only = 'Y' THEN 'NO' ELSE 'YES' END, 0
+	FROM all_views v
+	WHERE v.owner = :1
+	UNION ALL
+	SELECT m.mview_name, m.query, 'NONE', m.updatable, 1
+	FROM all_mviews m
+	WHERE m.owner = :2`
+	rows, err := isi.Db.Query(q, isi.DbName, isi.DbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, definition, checkOption, isUpdatable string
+	var isMaterialized int64
+	var views []utils.ViewAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &definition, &checkOption, &isUpdatable, &isMaterialized); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		views = append(views, utils.ViewAssessmentInfo{
+			Name:           name,
+			Definition:     definition,
+			CheckOption:    checkOption,
+			IsUpdatable:    isUpdatable == "YES" || isUpdatable == "Y",
+			IsMaterialized: isMaterialized == 1,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return views, fmt.Errorf("%s", errString)
+	}
+	return views, nil
+}
+
+// GetTableSizeInfo returns the row counts and sizes of the tables, from the
+// optimizer statistics of the tables and the segments of their indexes.
+func (isi InfoSchemaImpl) GetTableSizeInfo() ([]utils.TableSizeAssessmentInfo, error) {
+	q := `SELECT t.table_name, NVL(t.num_rows, 0), NVL(t.num_rows * t.avg_row_len, 0),
+		NVL((SELECT SUM(s.bytes) FROM all_indexes i JOIN user_segments s ON s.segment_name = i.index_name
+			WHERE i.table_owner = t.owner AND i.table_name = t.table_name), 0)
+	FROM all_tables t
+	WHERE t.owner = :1`
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name string
+	var rowCount, dataBytes, indexBytes int64
+	var tableSizes []utils.TableSizeAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &rowCount, &dataBytes, &indexBytes); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		tableSizes = append(tableSizes, utils.TableSizeAssessmentInfo{
+			Name:       name,
+			RowCount:   rowCount,
+			DataBytes:  dataBytes,
+			IndexBytes: indexBytes,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return tableSizes, fmt.Errorf("%s", errString)
+	}
+	return tableSizes, nil
+}
+
+// getSources returns the source of the objects of a type in the schema, which
+// all_source stores as one row per line.
+func (isi InfoSchemaImpl) getSources(objectType string) (map[string]string, error) {
+	q := `SELECT name, text
+	FROM all_source
+	WHERE owner = :1 AND type = :2
+	ORDER BY name, line`
+	rows, err := isi.Db.Query(q, isi.DbName, objectType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, text string
+	sources := make(map[string]string)
+	for rows.Next() {
+		if err := rows.Scan(&name, &text); err != nil {
+			return nil, fmt.Errorf("can't scan: %v", err)
+		}
+		sources[name] += text
+	}
+	return sources, nil
+}
+
+// getActionTiming returns when a trigger runs from its type, e.g. BEFORE
+// EACH ROW or INSTEAD OF.
+func getActionTiming(triggerType string) string {
+	for _, timing := range []string{"BEFORE", "AFTER", "INSTEAD OF"} {
+		if strings.HasPrefix(triggerType, timing) {
+			return timing
+		}
+	}
+	return triggerType
+}
+
+// getColumnMaxSize returns the maximum size of a column from data_length of
+// all_tab_cols, which is the size of the locator for LOB types.
+func getColumnMaxSize(dataType string, dataLength int64) int64 {
+	switch strings.ToUpper(dataType) {
+	case "BLOB", "CLOB", "NCLOB", "BFILE", "JSON", "XMLTYPE":
+		return maxLobSize
+	case "LONG", "LONG RAW":
+		return maxLongSize
+	default:
+		return dataLength
+	}
+}
+
+func (ssa SourceSpecificComparisonImpl) IsDataTypeCodeCompatible(srcColumnDef utils.SrcColumnDetails, spColumnDef utils.SpColumnDetails) bool {
+	srcType := strings.ToUpper(srcColumnDef.Datatype)
+	switch strings.ToUpper(spColumnDef.Datatype) {
+	case "BYTES":
+		switch srcType {
+		case "BLOB", "RAW", "LONG RAW", "BFILE":
+			return true
+		default:
+			return false
+		}
+	case "DATE":
+		return srcType == "DATE"
+	case "FLOAT32":
+		return srcType == "BINARY_FLOAT"
+	case "FLOAT64":
+		return srcType == "BINARY_DOUBLE" || srcType == "BINARY_FLOAT" || srcType == "FLOAT"
+	case "INT64", "NUMERIC":
+		return srcType == "NUMBER"
+	case "JSON":
+		return srcType == "JSON"
+	case "STRING":
+		switch srcType {
+		case "CHAR", "NCHAR", "VARCHAR", "VARCHAR2", "NVARCHAR2", "CLOB", "NCLOB", "LONG":
+			return true
+		default:
+			return false
+		}
+	case "TIMESTAMP":
+		return strings.HasPrefix(srcType, "TIMESTAMP")
+	default:
+		return false
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper to create InfoSchemaImpl with mock DB
+func newTestInfoSchemaImpl(t *testing.T) (InfoSchemaImpl, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+	return InfoSchemaImpl{Db: db, DbName: "HR"}, mock
+}
+
+func TestInfoSchemaImpl_GetTableInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	conv := &internal.Conv{
+		SrcSchema: map[string]schema.Table{"t1": {Name: "ORDERS", Schema: "HR", Id: "t1", ColDefs: map[string]schema.Column{
+			"c1": {Name: "TOTAL", Id: "c1", Type: schema.Type{Name: "NUMBER", Mods: []int64{10, 2}}},
+			"c2": {Name: "NOTES", Id: "c2", Type: schema.Type{Name: "CLOB"}},
+		}}},
+	}
+	mock.ExpectQuery(`SELECT \(SELECT value FROM nls_database_parameters WHERE parameter = 'NLS_CHARACTERSET'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"charset", "sort"}).AddRow("AL32UTF8", "BINARY"))
+	columnQuery := `SELECT data_length, virtual_column, data_default\s+FROM all_tab_cols`
+	// Columns are read in map order.
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(columnQuery).WithArgs("HR", "ORDERS", "TOTAL").
+		WillReturnRows(sqlmock.NewRows([]string{"data_length", "virtual_column", "data_default"}).AddRow(22, "YES", `"PRICE"*"QUANTITY" `))
+	mock.ExpectQuery(columnQuery).WithArgs("HR", "ORDERS", "NOTES").
+		WillReturnRows(sqlmock.NewRows([]string{"data_length", "virtual_column", "data_default"}).AddRow(4000, "NO", nil))
+
+	result, err := isi.GetTableInfo(conv)
+	assert.NoError(t, err)
+	tableInfo := result["t1"]
+	assert.Equal(t, "AL32UTF8", tableInfo.Charset)
+	assert.Equal(t, "BINARY", tableInfo.Collation)
+	assert.Equal(t, utils.GeneratedColumnInfo{Statement: `"PRICE"*"QUANTITY"`, IsPresent: true, IsVirtual: true}, tableInfo.ColumnAssessmentInfos["c1"].GeneratedColumn)
+	assert.Equal(t, int64(22), tableInfo.ColumnAssessmentInfos["c1"].MaxColumnSize)
+	assert.False(t, tableInfo.ColumnAssessmentInfos["c2"].GeneratedColumn.IsPresent)
+	assert.Equal(t, int64(maxLobSize), tableInfo.ColumnAssessmentInfos["c2"].MaxColumnSize)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTableInfoError(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	conv := &internal.Conv{
+		SrcSchema: map[string]schema.Table{"t1": {Name: "ORDERS", Schema: "HR", Id: "t1", ColDefs: map[string]schema.Column{
+			"c1": {Name: "ID", Id: "c1", Type: schema.Type{Name: "NUMBER"}},
+		}}},
+	}
+	mock.ExpectQuery(`SELECT \(SELECT value FROM nls_database_parameters`).WillReturnError(errors.New("db error"))
+	mock.ExpectQuery(`SELECT data_length`).WithArgs("HR", "ORDERS", "ID").WillReturnError(errors.New("column error"))
+
+	_, err := isi.GetTableInfo(conv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't get charset of database HR: db error")
+	assert.Contains(t, err.Error(), "couldn't get schema for column ORDERS.ID: column error")
+}
+
+func TestInfoSchemaImpl_GetIndexInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT index_name, index_type\s+FROM all_indexes`).WithArgs("HR", "ORDERS", "ORDERS_STATUS_IDX").
+		WillReturnRows(sqlmock.NewRows([]string{"index_name", "index_type"}).AddRow("ORDERS_STATUS_IDX", "BITMAP"))
+	index := schema.Index{Name: "ORDERS_STATUS_IDX", Id: "i1"}
+
+	result, err := isi.GetIndexInfo("ORDERS", index)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.IndexAssessmentInfo{Ty: "BITMAP", Name: "ORDERS_STATUS_IDX", Db: utils.DbIdentifier{DatabaseName: "HR"}, IndexDef: index}, result)
+
+	mock.ExpectQuery(`SELECT index_name`).WithArgs("HR", "ORDERS", "MISSING").WillReturnError(errors.New("no rows"))
+	_, err = isi.GetIndexInfo("ORDERS", schema.Index{Name: "MISSING"})
+	assert.EqualError(t, err, "couldn't get index for index name ORDERS.MISSING: no rows")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTriggerInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT trigger_name, table_name, trigger_body, trigger_type, triggering_event\s+FROM all_triggers`).WithArgs("HR").
+		WillReturnRows(sqlmock.NewRows([]string{"trigger_name", "table_name", "trigger_body", "trigger_type", "triggering_event"}).
+			AddRow("ORDERS_BI", "ORDERS", "BEGIN :new.id := orders_seq.nextval; END;", "BEFORE EACH ROW", "INSERT").
+			AddRow("ORDERS_AUDIT", "ORDERS", "BEGIN log_change; END;", "AFTER STATEMENT", "INSERT OR UPDATE"))
+
+	triggers, err := isi.GetTriggerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.TriggerAssessmentInfo{
+		{Name: "ORDERS_BI", TargetTable: "ORDERS", Operation: "BEGIN :new.id := orders_seq.nextval; END;", ActionTiming: "BEFORE", EventManipulation: "INSERT", Db: utils.DbIdentifier{DatabaseName: "HR"}},
+		{Name: "ORDERS_AUDIT", TargetTable: "ORDERS", Operation: "BEGIN log_change; END;", ActionTiming: "AFTER", EventManipulation: "INSERT OR UPDATE", Db: utils.DbIdentifier{DatabaseName: "HR"}},
+	}, triggers)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetStoredProcedureInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT name, text\s+FROM all_source`).WithArgs("HR", "PROCEDURE").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "text"}).
+			AddRow("ARCHIVE", "PROCEDURE archive AS\n").
+			AddRow("ARCHIVE", "BEGIN DELETE FROM orders; END;\n"))
+	mock.ExpectQuery(`SELECT object_name\s+FROM all_procedures`).WithArgs("HR").
+		WillReturnRows(sqlmock.NewRows([]string{"object_name"}).AddRow("ARCHIVE"))
+
+	procedures, err := isi.GetStoredProcedureInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.StoredProcedureAssessmentInfo{
+		{Name: "ARCHIVE", Definition: "PROCEDURE archive AS\nBEGIN DELETE FROM orders; END;\n", Db: utils.DbIdentifier{DatabaseName: "HR"}},
+	}, procedures)
+
+	mock.ExpectQuery(`SELECT name, text`).WithArgs("HR", "PROCEDURE").WillReturnError(errors.New("db error"))
+	_, err = isi.GetStoredProcedureInfo()
+	assert.EqualError(t, err, "db error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetFunctionInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT name, text\s+FROM all_source`).WithArgs("HR", "FUNCTION").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "text"}).
+			AddRow("ADD_TAX", "FUNCTION add_tax(p NUMBER) RETURN NUMBER DETERMINISTIC IS BEGIN RETURN p * 1.2; END;"))
+	mock.ExpectQuery(`SELECT p.object_name, p.deterministic, a.data_type\s+FROM all_procedures p`).WithArgs("HR").
+		WillReturnRows(sqlmock.NewRows([]string{"object_name", "deterministic", "data_type"}).AddRow("ADD_TAX", "YES", "NUMBER"))
+
+	functions, err := isi.GetFunctionInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.FunctionAssessmentInfo{
+		{Name: "ADD_TAX", Definition: "FUNCTION add_tax(p NUMBER) RETURN NUMBER DETERMINISTIC IS BEGIN RETURN p * 1.2; END;", IsDeterministic: true, Datatype: "NUMBER", Db: utils.DbIdentifier{DatabaseName: "HR"}},
+	}, functions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetViewInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT v.view_name, v.text,.*FROM all_views v.*FROM all_mviews m`).WithArgs("HR", "HR").
+		WillReturnRows(sqlmock.NewRows([]string{"view_name", "text", "check_option", "updatable", "materialized"}).
+			AddRow("ACTIVE_ORDERS", "SELECT * FROM orders WHERE active = 1", "CASCADED", "YES", 0).
+			AddRow("ORDER_TOTALS", "SELECT SUM(total) FROM orders", "NONE", "N", 1))
+
+	views, err := isi.GetViewInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.ViewAssessmentInfo{
+		{Name: "ACTIVE_ORDERS", Definition: "SELECT * FROM orders WHERE active = 1", CheckOption: "CASCADED", IsUpdatable: true, Db: utils.DbIdentifier{DatabaseName: "HR"}},
+		{Name: "ORDER_TOTALS", Definition: "SELECT SUM(total) FROM orders", CheckOption: "NONE", IsMaterialized: true, Db: utils.DbIdentifier{DatabaseName: "HR"}},
+	}, views)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTableSizeInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT t.table_name, NVL\(t.num_rows, 0\),.*FROM all_tables t`).WithArgs("HR").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "num_rows", "data_bytes", "index_bytes"}).
+			AddRow("ORDERS", 1000, 120000, 65536))
+
+	tableSizes, err := isi.GetTableSizeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.TableSizeAssessmentInfo{
+		{Name: "ORDERS", RowCount: 1000, DataBytes: 120000, IndexBytes: 65536, Db: utils.DbIdentifier{DatabaseName: "HR"}},
+	}, tableSizes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetActionTiming(t *testing.T) {
+	assert.Equal(t, "BEFORE", getActionTiming("BEFORE EACH ROW"))
+	assert.Equal(t, "AFTER", getActionTiming("AFTER STATEMENT"))
+	assert.Equal(t, "INSTEAD OF", getActionTiming("INSTEAD OF"))
+	assert.Equal(t, "COMPOUND", getActionTiming("COMPOUND"))
+}
+
+func TestGetColumnMaxSize(t *testing.T) {
+	assert.Equal(t, int64(22), getColumnMaxSize("NUMBER", 22))
+	assert.Equal(t, int64(400), getColumnMaxSize("VARCHAR2", 400))
+	assert.Equal(t, int64(11), getColumnMaxSize("TIMESTAMP(6)", 11))
+	assert.Equal(t, int64(maxLobSize), getColumnMaxSize("BLOB", 4000))
+	assert.Equal(t, int64(maxLongSize), getColumnMaxSize("LONG", 0))
+}
+
+func TestSourceSpecificComparisonImpl_IsDataTypeCodeCompatible(t *testing.T) {
+	testCases := []struct {
+		srcType string
+		spType  string
+		want    bool
+	}{
+		{"RAW", "BYTES", true},
+		{"NUMBER", "INT64", true},
+		{"NUMBER", "NUMERIC", true},
+		{"BINARY_FLOAT", "FLOAT32", true},
+		{"BINARY_DOUBLE", "FLOAT64", true},
+		{"VARCHAR2", "STRING", true},
+		{"CLOB", "STRING", true},
+		{"NUMBER", "STRING", false},
+		{"TIMESTAMP(6) WITH TIME ZONE", "TIMESTAMP", true},
+		{"DATE", "DATE", true},
+		{"JSON", "JSON", true},
+		{"XMLTYPE", "JSON", false},
+		{"NUMBER", "BOOL", false},
+	}
+	for _, tc := range testCases {
+		got := SourceSpecificComparisonImpl{}.IsDataTypeCodeCompatible(utils.SrcColumnDetails{Datatype: tc.srcType}, utils.SpColumnDetails{Datatype: tc.spType})
+		assert.Equal(t, tc.want, got, "%s to %s", tc.srcType, tc.spType)
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// Schemas of PostgreSQL itself, whose objects are not assessed.
+const systemSchemas = `('pg_catalog', 'information_schema', 'pg_toast')`
+
+// Maximum size of a single field value in PostgreSQL.
+const maxFieldSize = 1073741823
+
+type InfoSchemaImpl struct {
+	Db     *sql.DB
+	DbName string
+}
+
+type SourceSpecificComparisonImpl struct{}
+
+// GetTableInfo returns the charset and collation of the tables, which are
+// those of the database in PostgreSQL, and the generated columns and sizes
+// of their columns.
+func (isi InfoSchemaImpl) GetTableInfo(conv *internal.Conv) (map[string]utils.TableAssessmentInfo, error) {
+	tb := make(map[string]utils.TableAssessmentInfo)
+	dbIdentifier := utils.DbIdentifier{
+		DatabaseName: isi.DbName,
+	}
+	var errString string
+	var charset, collation string
+	q := `SELECT pg_encoding_to_char(encoding), datcollate FROM pg_database WHERE datname = current_database();`
+	err := isi.Db.QueryRow(q).Scan(&charset, &collation)
+	if err != nil {
+		errString = errString + fmt.Sprintf("couldn't get charset of database %s: %s", isi.DbName, err)
+	}
+	for _, table := range conv.SrcSchema {
+		columnAssessments := make(map[string]utils.ColumnAssessmentInfo[any])
+		for _, column := range table.ColDefs {
+			q = `SELECT a.attgenerated, pg_get_expr(d.adbin, d.adrelid)
+              FROM pg_attribute a
+                JOIN pg_class c ON c.oid = a.attrelid
+                JOIN pg_namespace n ON n.oid = c.relnamespace
+                LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+              WHERE n.nspname = $1 AND c.relname = $2 AND a.attname = $3;`
+			var generated string
+			var expression sql.NullString
+			var generatedColumn utils.GeneratedColumnInfo
+			err := isi.Db.QueryRow(q, table.Schema, unqualifiedTableName(table), column.Name).Scan(&generated, &expression)
+			if err != nil {
+				errString = errString + fmt.Sprintf("couldn't get schema for column %s.%s: %s", table.Name, column.Name, err)
+			}
+			// attgenerated is 's' for stored and 'v' for virtual generated
+			// columns, and empty otherwise.
+			if generated != "" && expression.Valid {
+				generatedColumn = utils.GeneratedColumnInfo{
+					Statement: expression.String,
+					IsPresent: true,
+					IsVirtual: generated == "v",
+				}
+			}
+			columnAssessments[column.Id] = utils.ColumnAssessmentInfo[any]{
+				Db:              dbIdentifier,
+				Name:            column.Name,
+				TableName:       table.Name,
+				ColumnDef:       column,
+				MaxColumnSize:   getColumnMaxSize(column.Type.Name, column.Type.Mods, charset),
+				GeneratedColumn: generatedColumn,
+			}
+		}
+		tb[table.Id] = utils.TableAssessmentInfo{Name: table.Name, TableDef: table, ColumnAssessmentInfos: columnAssessments, Db: dbIdentifier, Charset: charset, Collation: collation}
+	}
+	if errString != "" {
+		return tb, fmt.Errorf("%s", errString)
+	}
+	return tb, nil
+}
+
+// GetIndexInfo returns the access method of an index, e.g. BTREE or GIN. The
+// table can be qualified with its schema or not.
+func (isi InfoSchemaImpl) GetIndexInfo(table string, index schema.Index) (utils.IndexAssessmentInfo, error) {
+	q := `SELECT i.relname, am.amname
+		FROM pg_index ix
+			JOIN pg_class i ON i.oid = ix.indexrelid
+			JOIN pg_class t ON t.oid = ix.indrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			JOIN pg_am am ON am.oid = i.relam
+		WHERE (t.relname = $1 OR n.nspname || '.' || t.relname = $1)
+			AND i.relname = $2;`
+
+	var name, indexType string
+	err := isi.Db.QueryRow(q, table, index.Name).Scan(&name, &indexType)
+	if err != nil {
+		return utils.IndexAssessmentInfo{}, fmt.Errorf("couldn't get index for index name %s.%s: %s", table, index.Name, err)
+	}
+	return utils.IndexAssessmentInfo{
+		Ty:   strings.ToUpper(indexType),
+		Name: name,
+		Db: utils.DbIdentifier{
+			DatabaseName: isi.DbName,
+		},
+		IndexDef: index,
+	}, nil
+}
+
+// GetTriggerInfo returns a trigger for each of the events that fire it, like
+// information_schema.triggers does.
+func (isi InfoSchemaImpl) GetTriggerInfo() ([]utils.TriggerAssessmentInfo, error) {
+	q := `SELECT DISTINCT trigger_name, event_object_table, action_statement, action_timing, event_manipulation
+	FROM information_schema.triggers
+	WHERE trigger_schema NOT IN ` + systemSchemas
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, table, actionStmt, actionTiming, eventManipulation string
+	var triggers []utils.TriggerAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &table, &actionStmt, &actionTiming, &eventManipulation); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		triggers = append(triggers, utils.TriggerAssessmentInfo{
+			Name:              name,
+			Operation:         actionStmt,
+			TargetTable:       table,
+			ActionTiming:      actionTiming,
+			EventManipulation: eventManipulation,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return triggers, fmt.Errorf("%s", errString)
+	}
+	return triggers, nil
+}
+
+// GetStoredProcedureInfo returns the procedures of the database, leaving out
+// those installed by extensions.
+func (isi InfoSchemaImpl) GetStoredProcedureInfo() ([]utils.StoredProcedureAssessmentInfo, error) {
+	q := `SELECT p.proname, pg_get_functiondef(p.oid), p.provolatile
+	FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace
+	WHERE p.prokind = 'p' AND n.nspname NOT IN ` + systemSchemas + `
+		AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = p.oid AND d.deptype = 'e')`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, definition, volatility string
+	var storedProcedures []utils.StoredProcedureAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &definition, &volatility); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		storedProcedures = append(storedProcedures, utils.StoredProcedureAssessmentInfo{
+			Name:            name,
+			Definition:      definition,
+			IsDeterministic: volatility == "i",
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return storedProcedures, fmt.Errorf("%s", errString)
+	}
+	return storedProcedures, nil
+}
+
+// GetFunctionInfo returns the functions of the database, leaving out those
+// installed by extensions. Only IMMUTABLE functions are deterministic.
+func (isi InfoSchemaImpl) GetFunctionInfo() ([]utils.FunctionAssessmentInfo, error) {
+	q := `SELECT p.proname, pg_get_functiondef(p.oid), p.provolatile, pg_get_function_result(p.oid)
+	FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace
+	WHERE p.prokind = 'f' AND n.nspname NOT IN ` + systemSchemas + `
+		AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = p.oid AND d.deptype = 'e')`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, definition, volatility, datatype string
+	var functions []utils.FunctionAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &definition, &volatility, &datatype); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		functions = append(functions, utils.FunctionAssessmentInfo{
+			Name:            name,
+			Definition:      definition,
+			IsDeterministic: volatility == "i",
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+			Datatype: datatype,
+		})
+	}
+	if errString != "" {
+		return functions, fmt.Errorf("%s", errString)
+	}
+	return functions, nil
+}
+
+// GetViewInfo returns the views and materialized views of the database.
+func (isi InfoSchemaImpl) GetViewInfo() ([]utils.ViewAssessmentInfo, error) {
+	q := `SELECT table_name, view_definition, check_option, is_updatable, false
+	FROM information_schema.views
+	WHERE table_schema NOT IN ` + systemSchemas + `
+	UNION ALL
+	SELECT matviewname, definition, 'NONE', 'NO', true
+	FROM pg_matviews
+	WHERE schemaname NOT IN ` + systemSchemas
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, checkOption, isUpdatable string
+	var definition sql.NullString
+	var isMaterialized bool
+	var views []utils.ViewAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &definition, &checkOption, &isUpdatable, &isMaterialized); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		views = append(views, utils.ViewAssessmentInfo{
+			Name:           name,
+			Definition:     definition.String,
+			CheckOption:    checkOption,
+			IsUpdatable:    isUpdatable == "YES",
+			IsMaterialized: isMaterialized,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return views, fmt.Errorf("%s", errString)
+	}
+	return views, nil
+}
+
+// GetTableSizeInfo returns the row counts and sizes of the tables. The row
+// counts are the estimates of the last ANALYZE, and the index of the primary
+// key is counted with the indexes.
+func (isi InfoSchemaImpl) GetTableSizeInfo() ([]utils.TableSizeAssessmentInfo, error) {
+	q := `SELECT CASE WHEN n.nspname = 'public' THEN c.relname ELSE n.nspname || '.' || c.relname END,
+		GREATEST(c.reltuples, 0)::bigint, pg_table_size(c.oid), pg_indexes_size(c.oid)
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'p') AND NOT c.relispartition AND n.nspname NOT IN ` + systemSchemas
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name string
+	var rowCount, dataBytes, indexBytes int64
+	var tableSizes []utils.TableSizeAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &rowCount, &dataBytes, &indexBytes); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		tableSizes = append(tableSizes, utils.TableSizeAssessmentInfo{
+			Name:       name,
+			RowCount:   rowCount,
+			DataBytes:  dataBytes,
+			IndexBytes: indexBytes,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return tableSizes, fmt.Errorf("%s", errString)
+	}
+	return tableSizes, nil
+}
+
+// unqualifiedTableName returns the name of a table without the schema that
+// prefixes it when the database has several schemas.
+func unqualifiedTableName(table schema.Table) string {
+	return strings.TrimPrefix(table.Name, table.Schema+".")
+}
+
+func getColumnMaxSize(dataType string, mods []int64, encoding string) int64 {
+	switch strings.ToLower(dataType) {
+	case "bool", "boolean":
+		return 1
+	case "int2", "smallint", "smallserial":
+		return 2
+	case "int4", "integer", "serial":
+		return 4
+	case "int8", "bigint", "bigserial":
+		return 8
+	case "float4", "real":
+		return 4
+	case "float8", "double precision":
+		return 8
+	case "numeric", "decimal":
+		if len(mods) > 0 {
+			// Digits are stored in groups of 4 in 2 bytes, after a header.
+			return (mods[0]+3)/4*2 + 8
+		}
+		return 8
+	case "date":
+		return 4
+	case "time", "time without time zone", "timestamp", "timestamp without time zone", "timestamptz", "timestamp with time zone":
+		return 8
+	case "timetz", "time with time zone":
+		return 12
+	case "interval", "uuid":
+		return 16
+	case "bpchar", "character", "char":
+		maxChars := int64(1) // Default for CHAR is CHAR(1)
+		if len(mods) > 0 {
+			maxChars = mods[0]
+		}
+		return maxChars * getMaxBytesPerChar(encoding)
+	case "varchar", "character varying":
+		if len(mods) > 0 {
+			return mods[0] * getMaxBytesPerChar(encoding)
+		}
+		return maxFieldSize
+	case "text", "bytea", "json", "jsonb", "xml":
+		return maxFieldSize
+	default:
+		return 4
+	}
+}
+
+// getMaxBytesPerChar returns the maximum size of a character in a server
+// encoding of PostgreSQL.
+func getMaxBytesPerChar(encoding string) int64 {
+	encodingUpper := strings.ToUpper(encoding)
+	switch {
+	case encodingUpper == "SQL_ASCII", strings.HasPrefix(encodingUpper, "LATIN"), strings.HasPrefix(encodingUpper, "WIN"),
+		strings.HasPrefix(encodingUpper, "ISO_8859"), strings.HasPrefix(encodingUpper, "KOI8"):
+		return 1
+	case encodingUpper == "EUC_JP", encodingUpper == "EUC_JIS_2004", encodingUpper == "EUC_KR", encodingUpper == "EUC_CN", encodingUpper == "JOHAB":
+		return 3
+	default:
+		// UTF8, EUC_TW and MULE_INTERNAL use up to 4 bytes.
+		return 4
+	}
+}
+
+func (ssa SourceSpecificComparisonImpl) IsDataTypeCodeCompatible(srcColumnDef utils.SrcColumnDetails, spColumnDef utils.SpColumnDetails) bool {
+	srcType := strings.ToLower(srcColumnDef.Datatype)
+	switch strings.ToUpper(spColumnDef.Datatype) {
+	case "BOOL":
+		return srcType == "bool" || srcType == "boolean"
+	case "BYTES":
+		return srcType == "bytea"
+	case "DATE":
+		return srcType == "date"
+	case "FLOAT32":
+		return srcType == "float4" || srcType == "real"
+	case "FLOAT64":
+		return srcType == "float8" || srcType == "double precision" || srcType == "float4" || srcType == "real"
+	case "INT64":
+		switch srcType {
+		case "int2", "smallint", "smallserial", "int4", "integer", "serial", "int8", "bigint", "bigserial":
+			return true
+		default:
+			return false
+		}
+	case "JSON":
+		return srcType == "json" || srcType == "jsonb"
+	case "NUMERIC":
+		return srcType == "numeric" || srcType == "decimal"
+	case "STRING":
+		switch srcType {
+		case "bpchar", "character", "char", "varchar", "character varying", "text", "uuid":
+			return true
+		default:
+			return false
+		}
+	case "TIMESTAMP":
+		switch srcType {
+		case "timestamp", "timestamp without time zone", "timestamptz", "timestamp with time zone":
+			return true
+		default:
+			return false
+		}
+	case "UUID":
+		return srcType == "uuid"
+	default:
+		return false
+	}
+}
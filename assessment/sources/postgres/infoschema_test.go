@@ -0,0 +1,246 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper to create InfoSchemaImpl with mock DB
+func newTestInfoSchemaImpl(t *testing.T) (InfoSchemaImpl, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+	return InfoSchemaImpl{Db: db, DbName: "test_db"}, mock
+}
+
+func TestInfoSchemaImpl_GetTableInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	conv := &internal.Conv{
+		SrcSchema: map[string]schema.Table{"t1": {Name: "sales.orders", Schema: "sales", Id: "t1", ColDefs: map[string]schema.Column{
+			"c1": {Name: "total", Id: "c1", Type: schema.Type{Name: "numeric", Mods: []int64{10, 2}}},
+			"c2": {Name: "code", Id: "c2", Type: schema.Type{Name: "character varying", Mods: []int64{10}}},
+		}}},
+	}
+	mock.ExpectQuery(`SELECT pg_encoding_to_char\(encoding\), datcollate FROM pg_database`).
+		WillReturnRows(sqlmock.NewRows([]string{"encoding", "datcollate"}).AddRow("UTF8", "en_US.UTF-8"))
+	columnQuery := `SELECT a.attgenerated, pg_get_expr\(d.adbin, d.adrelid\)\s+FROM pg_attribute a`
+	// Columns are read in map order.
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(columnQuery).WithArgs("sales", "orders", "total").
+		WillReturnRows(sqlmock.NewRows([]string{"attgenerated", "expr"}).AddRow("s", "price * quantity"))
+	mock.ExpectQuery(columnQuery).WithArgs("sales", "orders", "code").
+		WillReturnRows(sqlmock.NewRows([]string{"attgenerated", "expr"}).AddRow("", nil))
+
+	result, err := isi.GetTableInfo(conv)
+	assert.NoError(t, err)
+	tableInfo := result["t1"]
+	assert.Equal(t, "UTF8", tableInfo.Charset)
+	assert.Equal(t, "en_US.UTF-8", tableInfo.Collation)
+	assert.Equal(t, utils.GeneratedColumnInfo{Statement: "price * quantity", IsPresent: true}, tableInfo.ColumnAssessmentInfos["c1"].GeneratedColumn)
+	assert.Equal(t, int64(14), tableInfo.ColumnAssessmentInfos["c1"].MaxColumnSize)
+	assert.False(t, tableInfo.ColumnAssessmentInfos["c2"].GeneratedColumn.IsPresent)
+	assert.Equal(t, int64(40), tableInfo.ColumnAssessmentInfos["c2"].MaxColumnSize)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTableInfoError(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	conv := &internal.Conv{
+		SrcSchema: map[string]schema.Table{"t1": {Name: "orders", Schema: "public", Id: "t1", ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+		}}},
+	}
+	mock.ExpectQuery(`SELECT pg_encoding_to_char`).WillReturnError(errors.New("db error"))
+	mock.ExpectQuery(`SELECT a.attgenerated`).WithArgs("public", "orders", "id").WillReturnError(errors.New("column error"))
+
+	result, err := isi.GetTableInfo(conv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't get charset of database test_db: db error")
+	assert.Contains(t, err.Error(), "couldn't get schema for column orders.id: column error")
+	assert.Equal(t, int64(8), result["t1"].ColumnAssessmentInfos["c1"].MaxColumnSize)
+}
+
+func TestInfoSchemaImpl_GetIndexInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT i.relname, am.amname\s+FROM pg_index ix`).WithArgs("sales.orders", "orders_idx").
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "amname"}).AddRow("orders_idx", "gin"))
+	index := schema.Index{Name: "orders_idx", Id: "i1"}
+
+	result, err := isi.GetIndexInfo("sales.orders", index)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.IndexAssessmentInfo{Ty: "GIN", Name: "orders_idx", Db: utils.DbIdentifier{DatabaseName: "test_db"}, IndexDef: index}, result)
+
+	mock.ExpectQuery(`SELECT i.relname`).WithArgs("orders", "missing").WillReturnError(errors.New("no rows"))
+	_, err = isi.GetIndexInfo("orders", schema.Index{Name: "missing"})
+	assert.EqualError(t, err, "couldn't get index for index name orders.missing: no rows")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTriggerInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT DISTINCT trigger_name, event_object_table, action_statement, action_timing, event_manipulation\s+FROM information_schema.triggers`).
+		WillReturnRows(sqlmock.NewRows([]string{"trigger_name", "event_object_table", "action_statement", "action_timing", "event_manipulation"}).
+			AddRow("audit", "orders", "EXECUTE FUNCTION log_order()", "AFTER", "INSERT").
+			AddRow("audit", "orders", "EXECUTE FUNCTION log_order()", "AFTER", "UPDATE"))
+
+	triggers, err := isi.GetTriggerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.TriggerAssessmentInfo{
+		{Name: "audit", TargetTable: "orders", Operation: "EXECUTE FUNCTION log_order()", ActionTiming: "AFTER", EventManipulation: "INSERT", Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+		{Name: "audit", TargetTable: "orders", Operation: "EXECUTE FUNCTION log_order()", ActionTiming: "AFTER", EventManipulation: "UPDATE", Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, triggers)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetStoredProcedureInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT p.proname, pg_get_functiondef\(p.oid\), p.provolatile\s+FROM pg_proc p .* p.prokind = 'p'`).
+		WillReturnRows(sqlmock.NewRows([]string{"proname", "def", "provolatile"}).
+			AddRow("archive", "CREATE PROCEDURE archive() AS $$ DELETE FROM orders; $$", "v"))
+
+	procedures, err := isi.GetStoredProcedureInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.StoredProcedureAssessmentInfo{
+		{Name: "archive", Definition: "CREATE PROCEDURE archive() AS $$ DELETE FROM orders; $$", Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, procedures)
+
+	mock.ExpectQuery(`SELECT p.proname`).WillReturnError(errors.New("db error"))
+	_, err = isi.GetStoredProcedureInfo()
+	assert.EqualError(t, err, "db error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetFunctionInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT p.proname, pg_get_functiondef\(p.oid\), p.provolatile, pg_get_function_result\(p.oid\)\s+FROM pg_proc p .* p.prokind = 'f'`).
+		WillReturnRows(sqlmock.NewRows([]string{"proname", "def", "provolatile", "result"}).
+			AddRow("add", "CREATE FUNCTION add(a int, b int) RETURNS integer AS 'select a + b;'", "i", "integer").
+			AddRow("now_utc", "CREATE FUNCTION now_utc() RETURNS timestamp AS 'select now();'", "s", "timestamp without time zone"))
+
+	functions, err := isi.GetFunctionInfo()
+	assert.NoError(t, err)
+	assert.Len(t, functions, 2)
+	assert.True(t, functions[0].IsDeterministic)
+	assert.Equal(t, "integer", functions[0].Datatype)
+	assert.False(t, functions[1].IsDeterministic)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetViewInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT table_name, view_definition, check_option, is_updatable, false\s+FROM information_schema.views .* FROM pg_matviews`).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "view_definition", "check_option", "is_updatable", "materialized"}).
+			AddRow("active_orders", " SELECT * FROM orders WHERE active;", "CASCADED", "YES", false).
+			AddRow("order_totals", " SELECT sum(total) FROM orders;", "NONE", "NO", true))
+
+	views, err := isi.GetViewInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.ViewAssessmentInfo{
+		{Name: "active_orders", Definition: " SELECT * FROM orders WHERE active;", CheckOption: "CASCADED", IsUpdatable: true, Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+		{Name: "order_totals", Definition: " SELECT sum(total) FROM orders;", CheckOption: "NONE", IsMaterialized: true, Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, views)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTableSizeInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT CASE WHEN n.nspname = 'public' THEN c.relname ELSE n.nspname \|\| '.' \|\| c.relname END,.*FROM pg_class c`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "reltuples", "table_size", "indexes_size"}).
+			AddRow("orders", 1000, 163840, 32768).
+			AddRow("sales.invoices", 10, 8192, 16384))
+
+	tableSizes, err := isi.GetTableSizeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.TableSizeAssessmentInfo{
+		{Name: "orders", RowCount: 1000, DataBytes: 163840, IndexBytes: 32768, Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+		{Name: "sales.invoices", RowCount: 10, DataBytes: 8192, IndexBytes: 16384, Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, tableSizes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetColumnMaxSize(t *testing.T) {
+	testCases := []struct {
+		dataType string
+		mods     []int64
+		encoding string
+		want     int64
+	}{
+		{"boolean", nil, "UTF8", 1},
+		{"smallint", nil, "UTF8", 2},
+		{"integer", nil, "UTF8", 4},
+		{"bigserial", nil, "UTF8", 8},
+		{"double precision", nil, "UTF8", 8},
+		{"numeric", []int64{10, 2}, "UTF8", 14},
+		{"numeric", nil, "UTF8", 8},
+		{"timestamp with time zone", nil, "UTF8", 8},
+		{"uuid", nil, "UTF8", 16},
+		{"bpchar", nil, "UTF8", 4},
+		{"character varying", []int64{100}, "UTF8", 400},
+		{"character varying", []int64{100}, "LATIN1", 100},
+		{"varchar", []int64{100}, "EUC_JP", 300},
+		{"varchar", nil, "UTF8", maxFieldSize},
+		{"jsonb", nil, "UTF8", maxFieldSize},
+		{"point", nil, "UTF8", 4},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, getColumnMaxSize(tc.dataType, tc.mods, tc.encoding), "%s %v %s", tc.dataType, tc.mods, tc.encoding)
+	}
+}
+
+func TestSourceSpecificComparisonImpl_IsDataTypeCodeCompatible(t *testing.T) {
+	testCases := []struct {
+		srcType string
+		spType  string
+		want    bool
+	}{
+		{"boolean", "BOOL", true},
+		{"bytea", "BYTES", true},
+		{"integer", "INT64", true},
+		{"bigint", "INT64", true},
+		{"numeric", "INT64", false},
+		{"real", "FLOAT32", true},
+		{"double precision", "FLOAT32", false},
+		{"jsonb", "JSON", true},
+		{"numeric", "NUMERIC", true},
+		{"character varying", "STRING", true},
+		{"uuid", "STRING", true},
+		{"uuid", "UUID", true},
+		{"text", "UUID", false},
+		{"integer", "STRING", false},
+		{"timestamptz", "TIMESTAMP", true},
+		{"date", "TIMESTAMP", false},
+		{"date", "DATE", true},
+		{"integer", "UNKNOWN", false},
+	}
+	for _, tc := range testCases {
+		got := SourceSpecificComparisonImpl{}.IsDataTypeCodeCompatible(utils.SrcColumnDetails{Datatype: tc.srcType}, utils.SpColumnDetails{Datatype: tc.spType})
+		assert.Equal(t, tc.want, got, "%s to %s", tc.srcType, tc.spType)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+)
+
+// Placeholders of the literals pg_stat_statements removed from the queries.
+var placeholderRegex = regexp.MustCompile(`\$\d+`)
+
+// PerformanceSchemaImpl reads the query statistics of the pg_stat_statements
+// extension, which has to be installed in the database.
+type PerformanceSchemaImpl struct {
+	Db     *sql.DB
+	DbName string
+}
+
+// GetAllQueryAssessments returns the queries run on the database, with how
+// many times they were executed and their total execution time. Requires
+// PostgreSQL 13 or later, where total_exec_time replaced total_time.
+func (psi PerformanceSchemaImpl) GetAllQueryAssessments() ([]utils.QueryAssessmentInfo, error) {
+	q := `SELECT
+    s.query,
+    SUM(s.calls) AS total_count,
+    SUM(s.total_exec_time) AS total_latency
+FROM
+    pg_stat_statements s
+    JOIN pg_database d ON d.oid = s.dbid
+WHERE
+  d.datname = $1
+  AND s.query !~* '^\s*(BEGIN|COMMIT|ROLLBACK|SET|SHOW|DEALLOCATE|DISCARD)\M'
+GROUP BY
+    s.query;`
+	rows, err := psi.Db.Query(q, psi.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read pg_stat_statements, is the extension installed? : %s", err)
+	}
+	defer rows.Close()
+	var query, errString string
+	var totalCount int
+	var totalLatency float64
+	var queryInfo []utils.QueryAssessmentInfo
+	for rows.Next() {
+		if err := rows.Scan(&query, &totalCount, &totalLatency); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		queryInfo = append(queryInfo, utils.QueryAssessmentInfo{
+			Query: utils.NormalizeQuery(placeholderRegex.ReplaceAllString(query, "?")),
+			Db: utils.DbIdentifier{
+				DatabaseName: psi.DbName,
+			},
+			Count:          totalCount,
+			TotalLatencyMs: totalLatency,
+		})
+	}
+	queryInfo = utils.MergeQueryAssessments(queryInfo)
+	if errString != "" {
+		return queryInfo, fmt.Errorf("%s", errString)
+	}
+	return queryInfo, nil
+}
+
+// GetStatisticsWindowSeconds returns the uptime of the server, since
+// pg_stat_statements gathers the statistics from its start unless they were
+// reset.
+func (psi PerformanceSchemaImpl) GetStatisticsWindowSeconds() (int64, error) {
+	q := `SELECT EXTRACT(EPOCH FROM now() - pg_postmaster_start_time())::bigint;`
+	var uptime int64
+	if err := psi.Db.QueryRow(q).Scan(&uptime); err != nil {
+		return 0, fmt.Errorf("couldn't read the uptime of the server : %s", err)
+	}
+	return uptime, nil
+}
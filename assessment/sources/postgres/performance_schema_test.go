@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPerformanceSchemaImpl(t *testing.T) (PerformanceSchemaImpl, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+	return PerformanceSchemaImpl{Db: db, DbName: "test_db"}, mock
+}
+
+func TestPerformanceSchemaImpl_GetAllQueryAssessments(t *testing.T) {
+	psi, mock := newTestPerformanceSchemaImpl(t)
+	mock.ExpectQuery(`FROM\s+pg_stat_statements s`).
+		WithArgs("test_db").
+		WillReturnRows(sqlmock.NewRows([]string{"query", "total_count", "total_latency"}).
+			AddRow("SELECT * FROM users WHERE id = $1", 100, 12.5).
+			AddRow("INSERT INTO orders (id, total) VALUES ($1, $2)", 10, 40.0).
+			AddRow("SELECT * FROM users WHERE id = 7", 5, 0.5))
+
+	queries, err := psi.GetAllQueryAssessments()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.QueryAssessmentInfo{
+		{
+			Query:          "INSERT INTO orders (id, total) VALUES (?)",
+			Db:             utils.DbIdentifier{DatabaseName: "test_db"},
+			Count:          10,
+			TotalLatencyMs: 40,
+		},
+		{
+			Query:          "SELECT * FROM users WHERE id = ?",
+			Db:             utils.DbIdentifier{DatabaseName: "test_db"},
+			Count:          105,
+			TotalLatencyMs: 13,
+		},
+	}, queries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPerformanceSchemaImpl_GetAllQueryAssessmentsError(t *testing.T) {
+	psi, mock := newTestPerformanceSchemaImpl(t)
+	mock.ExpectQuery(`FROM\s+pg_stat_statements s`).
+		WithArgs("test_db").
+		WillReturnError(errors.New(`relation "pg_stat_statements" does not exist`))
+
+	queries, err := psi.GetAllQueryAssessments()
+
+	assert.ErrorContains(t, err, "couldn't read pg_stat_statements")
+	assert.Nil(t, queries)
+}
+
+func TestPerformanceSchemaImpl_GetAllQueryAssessmentsScanError(t *testing.T) {
+	psi, mock := newTestPerformanceSchemaImpl(t)
+	mock.ExpectQuery(`FROM\s+pg_stat_statements s`).
+		WithArgs("test_db").
+		WillReturnRows(sqlmock.NewRows([]string{"query", "total_count", "total_latency"}).
+			AddRow("SELECT 1", "not_an_int", 1.0).
+			AddRow("SELECT * FROM users", 3, 2.0))
+
+	queries, err := psi.GetAllQueryAssessments()
+
+	assert.ErrorContains(t, err, "Can't scan")
+	assert.Len(t, queries, 1)
+	assert.Equal(t, "SELECT * FROM users", queries[0].Query)
+}
+
+func TestPerformanceSchemaImpl_GetStatisticsWindowSeconds(t *testing.T) {
+	psi, mock := newTestPerformanceSchemaImpl(t)
+	mock.ExpectQuery(`SELECT EXTRACT\(EPOCH FROM now\(\) - pg_postmaster_start_time\(\)\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"uptime"}).AddRow(3600))
+
+	window, err := psi.GetStatisticsWindowSeconds()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3600), window)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,394 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// Maximum size of a value of the (MAX) and legacy LOB types of SQL Server.
+const maxLobSize = 2147483647
+
+// Code page of the UTF-8 collations of SQL Server.
+const utf8CodePage = 65001
+
+type InfoSchemaImpl struct {
+	Db     *sql.DB
+	DbName string
+}
+
+type SourceSpecificComparisonImpl struct{}
+
+// GetTableInfo returns the charset and collation of the tables, which are
+// those of the database, and the computed columns and sizes of their columns.
+func (isi InfoSchemaImpl) GetTableInfo(conv *internal.Conv) (map[string]utils.TableAssessmentInfo, error) {
+	tb := make(map[string]utils.TableAssessmentInfo)
+	dbIdentifier := utils.DbIdentifier{
+		DatabaseName: isi.DbName,
+	}
+	var errString string
+	var collation string
+	var codePage int64
+	q := `SELECT CAST(DATABASEPROPERTYEX(DB_NAME(), 'Collation') AS NVARCHAR(128)),
+		CAST(COLLATIONPROPERTY(CAST(DATABASEPROPERTYEX(DB_NAME(), 'Collation') AS NVARCHAR(128)), 'CodePage') AS INT);`
+	err := isi.Db.QueryRow(q).Scan(&collation, &codePage)
+	if err != nil {
+		errString = errString + fmt.Sprintf("couldn't get collation of database %s: %s", isi.DbName, err)
+	}
+	charset := getCharset(codePage)
+	for _, table := range conv.SrcSchema {
+		columnAssessments := make(map[string]utils.ColumnAssessmentInfo[any])
+		for _, column := range table.ColDefs {
+			q = `SELECT c.max_length, c.is_computed, cc.definition, cc.is_persisted
+              FROM sys.columns c
+                JOIN sys.tables t ON t.object_id = c.object_id
+                JOIN sys.schemas s ON s.schema_id = t.schema_id
+                LEFT JOIN sys.computed_columns cc ON cc.object_id = c.object_id AND cc.column_id = c.column_id
+              WHERE s.name = @p1 AND t.name = @p2 AND c.name = @p3;`
+			var maxLength int64
+			var isComputed bool
+			var definition sql.NullString
+			var isPersisted sql.NullBool
+			var generatedColumn utils.GeneratedColumnInfo
+			err := isi.Db.QueryRow(q, table.Schema, unqualifiedTableName(table), column.Name).Scan(&maxLength, &isComputed, &definition, &isPersisted)
+			if err != nil {
+				errString = errString + fmt.Sprintf("couldn't get schema for column %s.%s: %s", table.Name, column.Name, err)
+			}
+			if isComputed {
+				generatedColumn = utils.GeneratedColumnInfo{
+					Statement: definition.String,
+					IsPresent: true,
+					IsVirtual: !isPersisted.Bool,
+				}
+			}
+			columnAssessments[column.Id] = utils.ColumnAssessmentInfo[any]{
+				Db:              dbIdentifier,
+				Name:            column.Name,
+				TableName:       table.Name,
+				ColumnDef:       column,
+				MaxColumnSize:   getColumnMaxSize(column.Type.Name, maxLength),
+				GeneratedColumn: generatedColumn,
+			}
+		}
+		tb[table.Id] = utils.TableAssessmentInfo{Name: table.Name, TableDef: table, ColumnAssessmentInfos: columnAssessments, Db: dbIdentifier, Charset: charset, Collation: collation}
+	}
+	if errString != "" {
+		return tb, fmt.Errorf("%s", errString)
+	}
+	return tb, nil
+}
+
+// GetIndexInfo returns the type of an index, e.g. CLUSTERED or NONCLUSTERED.
+// The table can be qualified with its schema or not.
+func (isi InfoSchemaImpl) GetIndexInfo(table string, index schema.Index) (utils.IndexAssessmentInfo, error) {
+	q := `SELECT i.name, i.type_desc
+		FROM sys.indexes i
+			JOIN sys.tables t ON t.object_id = i.object_id
+			JOIN sys.schemas s ON s.schema_id = t.schema_id
+		WHERE (t.name = @p1 OR s.name + '.' + t.name = @p1)
+			AND i.name = @p2;`
+
+	var name, indexType string
+	err := isi.Db.QueryRow(q, table, index.Name).Scan(&name, &indexType)
+	if err != nil {
+		return utils.IndexAssessmentInfo{}, fmt.Errorf("couldn't get index for index name %s.%s: %s", table, index.Name, err)
+	}
+	return utils.IndexAssessmentInfo{
+		Ty:   indexType,
+		Name: name,
+		Db: utils.DbIdentifier{
+			DatabaseName: isi.DbName,
+		},
+		IndexDef: index,
+	}, nil
+}
+
+// GetTriggerInfo returns a trigger for each of the events that fire it. DML
+// triggers of SQL Server run AFTER or INSTEAD OF the event.
+func (isi InfoSchemaImpl) GetTriggerInfo() ([]utils.TriggerAssessmentInfo, error) {
+	q := `SELECT tr.name, OBJECT_NAME(tr.parent_id), OBJECT_DEFINITION(tr.object_id),
+		CASE WHEN tr.is_instead_of_trigger = 1 THEN 'INSTEAD OF' ELSE 'AFTER' END, te.type_desc
+	FROM sys.triggers tr JOIN sys.trigger_events te ON te.object_id = tr.object_id
+	WHERE tr.parent_class = 1 AND tr.is_ms_shipped = 0`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, table, actionTiming, eventManipulation string
+	var actionStmt sql.NullString
+	var triggers []utils.TriggerAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &table, &actionStmt, &actionTiming, &eventManipulation); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		triggers = append(triggers, utils.TriggerAssessmentInfo{
+			Name:              name,
+			Operation:         actionStmt.String,
+			TargetTable:       table,
+			ActionTiming:      actionTiming,
+			EventManipulation: eventManipulation,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return triggers, fmt.Errorf("%s", errString)
+	}
+	return triggers, nil
+}
+
+// GetStoredProcedureInfo returns the user procedures of the database. SQL
+// Server doesn't track whether procedures are deterministic.
+func (isi InfoSchemaImpl) GetStoredProcedureInfo() ([]utils.StoredProcedureAssessmentInfo, error) {
+	q := `SELECT p.name, OBJECT_DEFINITION(p.object_id)
+	FROM sys.procedures p
+	WHERE p.is_ms_shipped = 0`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name string
+	var definition sql.NullString
+	var storedProcedures []utils.StoredProcedureAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &definition); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		storedProcedures = append(storedProcedures, utils.StoredProcedureAssessmentInfo{
+			Name:       name,
+			Definition: definition.String,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return storedProcedures, fmt.Errorf("%s", errString)
+	}
+	return storedProcedures, nil
+}
+
+// GetFunctionInfo returns the scalar and table-valued user functions of the
+// database. The datatype of table-valued functions is TABLE.
+func (isi InfoSchemaImpl) GetFunctionInfo() ([]utils.FunctionAssessmentInfo, error) {
+	q := `SELECT o.name, OBJECT_DEFINITION(o.object_id), OBJECTPROPERTY(o.object_id, 'IsDeterministic'),
+		COALESCE((SELECT TYPE_NAME(p.user_type_id) FROM sys.parameters p WHERE p.object_id = o.object_id AND p.parameter_id = 0), 'TABLE')
+	FROM sys.objects o
+	WHERE o.type IN ('FN', 'IF', 'TF') AND o.is_ms_shipped = 0`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, datatype string
+	var definition sql.NullString
+	var isDeterministic sql.NullInt64
+	var functions []utils.FunctionAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &definition, &isDeterministic, &datatype); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		functions = append(functions, utils.FunctionAssessmentInfo{
+			Name:            name,
+			Definition:      definition.String,
+			IsDeterministic: isDeterministic.Int64 == 1,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+			Datatype: datatype,
+		})
+	}
+	if errString != "" {
+		return functions, fmt.Errorf("%s", errString)
+	}
+	return functions, nil
+}
+
+// GetViewInfo returns the user views of the database. Indexed views are
+// reported as materialized, since their rows are stored.
+func (isi InfoSchemaImpl) GetViewInfo() ([]utils.ViewAssessmentInfo, error) {
+	q := `SELECT v.name, OBJECT_DEFINITION(v.object_id),
+		CASE WHEN v.with_check_option = 1 THEN 'CASCADED' ELSE 'NONE' END,
+		OBJECTPROPERTY(v.object_id, 'IsIndexed')
+	FROM sys.views v
+	WHERE v.is_ms_shipped = 0`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, checkOption string
+	var definition sql.NullString
+	var isIndexed sql.NullInt64
+	var views []utils.ViewAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &definition, &checkOption, &isIndexed); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		views = append(views, utils.ViewAssessmentInfo{
+			Name:           name,
+			Definition:     definition.String,
+			CheckOption:    checkOption,
+			IsMaterialized: isIndexed.Int64 == 1,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return views, fmt.Errorf("%s", errString)
+	}
+	return views, nil
+}
+
+// GetTableSizeInfo returns the row counts and sizes of the tables, from the
+// pages used by their partitions. The heap or clustered index holds the rows.
+func (isi InfoSchemaImpl) GetTableSizeInfo() ([]utils.TableSizeAssessmentInfo, error) {
+	q := `SELECT CASE WHEN s.name = 'dbo' THEN t.name ELSE s.name + '.' + t.name END,
+		SUM(CASE WHEN p.index_id IN (0, 1) THEN p.row_count ELSE 0 END),
+		SUM(CASE WHEN p.index_id IN (0, 1) THEN p.used_page_count ELSE 0 END) * 8192,
+		SUM(CASE WHEN p.index_id > 1 THEN p.used_page_count ELSE 0 END) * 8192
+	FROM sys.tables t
+	JOIN sys.schemas s ON s.schema_id = t.schema_id
+	JOIN sys.dm_db_partition_stats p ON p.object_id = t.object_id
+	WHERE t.is_ms_shipped = 0
+	GROUP BY s.name, t.name`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name string
+	var rowCount, dataBytes, indexBytes int64
+	var tableSizes []utils.TableSizeAssessmentInfo
+	var errString string
+	for rows.Next() {
+		if err := rows.Scan(&name, &rowCount, &dataBytes, &indexBytes); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		tableSizes = append(tableSizes, utils.TableSizeAssessmentInfo{
+			Name:       name,
+			RowCount:   rowCount,
+			DataBytes:  dataBytes,
+			IndexBytes: indexBytes,
+			Db: utils.DbIdentifier{
+				DatabaseName: isi.DbName,
+			},
+		})
+	}
+	if errString != "" {
+		return tableSizes, fmt.Errorf("%s", errString)
+	}
+	return tableSizes, nil
+}
+
+// unqualifiedTableName returns the name of a table without the schema that
+// prefixes it when the schema isn't dbo.
+func unqualifiedTableName(table schema.Table) string {
+	return strings.TrimPrefix(table.Name, table.Schema+".")
+}
+
+// getCharset returns the charset of a collation from its code page.
+func getCharset(codePage int64) string {
+	if codePage == utf8CodePage {
+		return "utf8"
+	}
+	return fmt.Sprintf("cp%d", codePage)
+}
+
+// getColumnMaxSize returns the maximum size of a column from max_length of
+// sys.columns, which is -1 for the (MAX) types and the size of a pointer for
+// the legacy LOB types.
+func getColumnMaxSize(dataType string, maxLength int64) int64 {
+	switch strings.ToLower(dataType) {
+	case "text", "ntext", "image", "xml":
+		return maxLobSize
+	}
+	if maxLength < 0 {
+		return maxLobSize
+	}
+	return maxLength
+}
+
+func (ssa SourceSpecificComparisonImpl) IsDataTypeCodeCompatible(srcColumnDef utils.SrcColumnDetails, spColumnDef utils.SpColumnDetails) bool {
+	srcType := strings.ToLower(srcColumnDef.Datatype)
+	switch strings.ToUpper(spColumnDef.Datatype) {
+	case "BOOL":
+		return srcType == "bit"
+	case "BYTES":
+		switch srcType {
+		case "binary", "varbinary", "image", "timestamp", "rowversion":
+			return true
+		default:
+			return false
+		}
+	case "DATE":
+		return srcType == "date"
+	case "FLOAT32":
+		return srcType == "real"
+	case "FLOAT64":
+		return srcType == "float" || srcType == "real"
+	case "INT64":
+		switch srcType {
+		case "tinyint", "smallint", "int", "bigint":
+			return true
+		default:
+			return false
+		}
+	case "NUMERIC":
+		switch srcType {
+		case "numeric", "decimal", "money", "smallmoney":
+			return true
+		default:
+			return false
+		}
+	case "STRING":
+		switch srcType {
+		case "char", "varchar", "nchar", "nvarchar", "text", "ntext", "uniqueidentifier":
+			return true
+		default:
+			return false
+		}
+	case "TIMESTAMP":
+		switch srcType {
+		case "datetime", "datetime2", "smalldatetime", "datetimeoffset":
+			return true
+		default:
+			return false
+		}
+	case "UUID":
+		return srcType == "uniqueidentifier"
+	default:
+		return false
+	}
+}
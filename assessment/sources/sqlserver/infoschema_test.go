@@ -0,0 +1,227 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper to create InfoSchemaImpl with mock DB
+func newTestInfoSchemaImpl(t *testing.T) (InfoSchemaImpl, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+	return InfoSchemaImpl{Db: db, DbName: "test_db"}, mock
+}
+
+func TestInfoSchemaImpl_GetTableInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	conv := &internal.Conv{
+		SrcSchema: map[string]schema.Table{"t1": {Name: "sales.orders", Schema: "sales", Id: "t1", ColDefs: map[string]schema.Column{
+			"c1": {Name: "total", Id: "c1", Type: schema.Type{Name: "decimal", Mods: []int64{10, 2}}},
+			"c2": {Name: "notes", Id: "c2", Type: schema.Type{Name: "nvarchar"}},
+		}}},
+	}
+	mock.ExpectQuery(`SELECT CAST\(DATABASEPROPERTYEX\(DB_NAME\(\), 'Collation'\) AS NVARCHAR\(128\)\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"collation", "code_page"}).AddRow("SQL_Latin1_General_CP1_CI_AS", 1252))
+	columnQuery := `SELECT c.max_length, c.is_computed, cc.definition, cc.is_persisted\s+FROM sys.columns c`
+	// Columns are read in map order.
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(columnQuery).WithArgs("sales", "orders", "total").
+		WillReturnRows(sqlmock.NewRows([]string{"max_length", "is_computed", "definition", "is_persisted"}).AddRow(9, true, "([price]*[quantity])", false))
+	mock.ExpectQuery(columnQuery).WithArgs("sales", "orders", "notes").
+		WillReturnRows(sqlmock.NewRows([]string{"max_length", "is_computed", "definition", "is_persisted"}).AddRow(-1, false, nil, nil))
+
+	result, err := isi.GetTableInfo(conv)
+	assert.NoError(t, err)
+	tableInfo := result["t1"]
+	assert.Equal(t, "cp1252", tableInfo.Charset)
+	assert.Equal(t, "SQL_Latin1_General_CP1_CI_AS", tableInfo.Collation)
+	assert.Equal(t, utils.GeneratedColumnInfo{Statement: "([price]*[quantity])", IsPresent: true, IsVirtual: true}, tableInfo.ColumnAssessmentInfos["c1"].GeneratedColumn)
+	assert.Equal(t, int64(9), tableInfo.ColumnAssessmentInfos["c1"].MaxColumnSize)
+	assert.False(t, tableInfo.ColumnAssessmentInfos["c2"].GeneratedColumn.IsPresent)
+	assert.Equal(t, int64(maxLobSize), tableInfo.ColumnAssessmentInfos["c2"].MaxColumnSize)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTableInfoError(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	conv := &internal.Conv{
+		SrcSchema: map[string]schema.Table{"t1": {Name: "orders", Schema: "dbo", Id: "t1", ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "int"}},
+		}}},
+	}
+	mock.ExpectQuery(`SELECT CAST\(DATABASEPROPERTYEX`).WillReturnError(errors.New("db error"))
+	mock.ExpectQuery(`SELECT c.max_length`).WithArgs("dbo", "orders", "id").WillReturnError(errors.New("column error"))
+
+	_, err := isi.GetTableInfo(conv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't get collation of database test_db: db error")
+	assert.Contains(t, err.Error(), "couldn't get schema for column orders.id: column error")
+}
+
+func TestInfoSchemaImpl_GetIndexInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT i.name, i.type_desc\s+FROM sys.indexes i`).WithArgs("orders", "IX_orders_date").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type_desc"}).AddRow("IX_orders_date", "NONCLUSTERED"))
+	index := schema.Index{Name: "IX_orders_date", Id: "i1"}
+
+	result, err := isi.GetIndexInfo("orders", index)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.IndexAssessmentInfo{Ty: "NONCLUSTERED", Name: "IX_orders_date", Db: utils.DbIdentifier{DatabaseName: "test_db"}, IndexDef: index}, result)
+
+	mock.ExpectQuery(`SELECT i.name`).WithArgs("orders", "missing").WillReturnError(errors.New("no rows"))
+	_, err = isi.GetIndexInfo("orders", schema.Index{Name: "missing"})
+	assert.EqualError(t, err, "couldn't get index for index name orders.missing: no rows")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTriggerInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT tr.name, OBJECT_NAME\(tr.parent_id\), OBJECT_DEFINITION\(tr.object_id\).*FROM sys.triggers tr`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "table", "definition", "timing", "event"}).
+			AddRow("trg_audit", "orders", "CREATE TRIGGER trg_audit ON orders AFTER INSERT AS ...", "AFTER", "INSERT").
+			AddRow("trg_view", "orders", nil, "INSTEAD OF", "DELETE"))
+
+	triggers, err := isi.GetTriggerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.TriggerAssessmentInfo{
+		{Name: "trg_audit", TargetTable: "orders", Operation: "CREATE TRIGGER trg_audit ON orders AFTER INSERT AS ...", ActionTiming: "AFTER", EventManipulation: "INSERT", Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+		{Name: "trg_view", TargetTable: "orders", ActionTiming: "INSTEAD OF", EventManipulation: "DELETE", Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, triggers)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetStoredProcedureInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT p.name, OBJECT_DEFINITION\(p.object_id\)\s+FROM sys.procedures p`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "definition"}).
+			AddRow("usp_archive", "CREATE PROCEDURE usp_archive AS DELETE FROM orders;"))
+
+	procedures, err := isi.GetStoredProcedureInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.StoredProcedureAssessmentInfo{
+		{Name: "usp_archive", Definition: "CREATE PROCEDURE usp_archive AS DELETE FROM orders;", Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, procedures)
+
+	mock.ExpectQuery(`SELECT p.name`).WillReturnError(errors.New("db error"))
+	_, err = isi.GetStoredProcedureInfo()
+	assert.EqualError(t, err, "db error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetFunctionInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT o.name, OBJECT_DEFINITION\(o.object_id\), OBJECTPROPERTY\(o.object_id, 'IsDeterministic'\).*FROM sys.objects o`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "definition", "deterministic", "datatype"}).
+			AddRow("fn_add", "CREATE FUNCTION fn_add(@a int, @b int) RETURNS int WITH SCHEMABINDING AS BEGIN RETURN @a + @b; END", 1, "int").
+			AddRow("fn_orders", "CREATE FUNCTION fn_orders() RETURNS TABLE AS RETURN SELECT * FROM orders;", nil, "TABLE"))
+
+	functions, err := isi.GetFunctionInfo()
+	assert.NoError(t, err)
+	assert.Len(t, functions, 2)
+	assert.True(t, functions[0].IsDeterministic)
+	assert.Equal(t, "int", functions[0].Datatype)
+	assert.False(t, functions[1].IsDeterministic)
+	assert.Equal(t, "TABLE", functions[1].Datatype)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetViewInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT v.name, OBJECT_DEFINITION\(v.object_id\).*FROM sys.views v`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "definition", "check_option", "is_indexed"}).
+			AddRow("active_orders", "CREATE VIEW active_orders AS SELECT * FROM orders WHERE active = 1 WITH CHECK OPTION", "CASCADED", 0).
+			AddRow("order_totals", "CREATE VIEW order_totals WITH SCHEMABINDING AS SELECT ...", "NONE", 1))
+
+	views, err := isi.GetViewInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.ViewAssessmentInfo{
+		{Name: "active_orders", Definition: "CREATE VIEW active_orders AS SELECT * FROM orders WHERE active = 1 WITH CHECK OPTION", CheckOption: "CASCADED", Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+		{Name: "order_totals", Definition: "CREATE VIEW order_totals WITH SCHEMABINDING AS SELECT ...", CheckOption: "NONE", IsMaterialized: true, Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, views)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInfoSchemaImpl_GetTableSizeInfo(t *testing.T) {
+	isi, mock := newTestInfoSchemaImpl(t)
+	defer isi.Db.Close()
+	mock.ExpectQuery(`SELECT CASE WHEN s.name = 'dbo' THEN t.name ELSE s.name \+ '.' \+ t.name END,.*FROM sys.tables t.*sys.dm_db_partition_stats`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "row_count", "data_bytes", "index_bytes"}).
+			AddRow("orders", 1000, 163840, 32768).
+			AddRow("sales.invoices", "not_an_int", 8192, 0))
+
+	tableSizes, err := isi.GetTableSizeInfo()
+	assert.ErrorContains(t, err, "Can't scan")
+	assert.Equal(t, []utils.TableSizeAssessmentInfo{
+		{Name: "orders", RowCount: 1000, DataBytes: 163840, IndexBytes: 32768, Db: utils.DbIdentifier{DatabaseName: "test_db"}},
+	}, tableSizes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCharset(t *testing.T) {
+	assert.Equal(t, "utf8", getCharset(65001))
+	assert.Equal(t, "cp1252", getCharset(1252))
+}
+
+func TestGetColumnMaxSize(t *testing.T) {
+	assert.Equal(t, int64(4), getColumnMaxSize("int", 4))
+	assert.Equal(t, int64(200), getColumnMaxSize("nvarchar", 200))
+	assert.Equal(t, int64(maxLobSize), getColumnMaxSize("varbinary", -1))
+	assert.Equal(t, int64(maxLobSize), getColumnMaxSize("ntext", 16))
+}
+
+func TestSourceSpecificComparisonImpl_IsDataTypeCodeCompatible(t *testing.T) {
+	testCases := []struct {
+		srcType string
+		spType  string
+		want    bool
+	}{
+		{"bit", "BOOL", true},
+		{"varbinary", "BYTES", true},
+		{"int", "INT64", true},
+		{"bigint", "INT64", true},
+		{"decimal", "INT64", false},
+		{"real", "FLOAT32", true},
+		{"float", "FLOAT64", true},
+		{"money", "NUMERIC", true},
+		{"nvarchar", "STRING", true},
+		{"uniqueidentifier", "STRING", true},
+		{"uniqueidentifier", "UUID", true},
+		{"nvarchar", "UUID", false},
+		{"xml", "STRING", false},
+		{"datetime2", "TIMESTAMP", true},
+		{"date", "DATE", true},
+		{"time", "TIMESTAMP", false},
+		{"int", "UNKNOWN", false},
+	}
+	for _, tc := range testCases {
+		got := SourceSpecificComparisonImpl{}.IsDataTypeCodeCompatible(utils.SrcColumnDetails{Datatype: tc.srcType}, utils.SpColumnDetails{Datatype: tc.spType})
+		assert.Equal(t, tc.want, got, "%s to %s", tc.srcType, tc.spType)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+)
+
+var (
+	// Parameters of parameterized queries, but not system functions like @@ROWCOUNT.
+	parameterRegex = regexp.MustCompile(`(^|[^@\w])@\w+`)
+	// Statements about transactions and sessions, which are not assessed.
+	utilityStatementRegex = regexp.MustCompile(`(?i)^(SET|USE|BEGIN\s+TRAN|COMMIT|ROLLBACK|SAVE\s+TRAN|IF\s+@@TRANCOUNT)`)
+)
+
+// PerformanceSchemaImpl reads the statistics of the query plans in the plan
+// cache of SQL Server, so the queries whose plans were evicted or which were
+// run before the last restart are missing.
+type PerformanceSchemaImpl struct {
+	Db     *sql.DB
+	DbName string
+}
+
+// GetAllQueryAssessments returns the queries run on the database, with how
+// many times they were executed and their total execution time.
+func (psi PerformanceSchemaImpl) GetAllQueryAssessments() ([]utils.QueryAssessmentInfo, error) {
+	q := `SELECT
+    SUBSTRING(st.text, (qs.statement_start_offset / 2) + 1,
+        ((CASE qs.statement_end_offset WHEN -1 THEN DATALENGTH(st.text) ELSE qs.statement_end_offset END
+            - qs.statement_start_offset) / 2) + 1) AS statement_text,
+    qs.execution_count,
+    qs.total_elapsed_time
+FROM
+    sys.dm_exec_query_stats qs
+    CROSS APPLY sys.dm_exec_sql_text(qs.sql_handle) st
+    CROSS APPLY sys.dm_exec_plan_attributes(qs.plan_handle) pa
+WHERE
+  pa.attribute = 'dbid'
+  AND CONVERT(INT, pa.value) = DB_ID(@p1);`
+	rows, err := psi.Db.Query(q, psi.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read dm_exec_query_stats : %s", err)
+	}
+	defer rows.Close()
+	var statement, errString string
+	var executionCount int
+	var totalElapsedTime int64
+	var queryInfo []utils.QueryAssessmentInfo
+	for rows.Next() {
+		if err := rows.Scan(&statement, &executionCount, &totalElapsedTime); err != nil {
+			errString = errString + fmt.Sprintf("Can't scan: %v", err)
+			continue
+		}
+		statement = strings.TrimSpace(stripParameterDeclaration(statement))
+		if statement == "" || utilityStatementRegex.MatchString(statement) {
+			continue
+		}
+		queryInfo = append(queryInfo, utils.QueryAssessmentInfo{
+			Query: utils.NormalizeQuery(parameterRegex.ReplaceAllString(statement, "${1}?")),
+			Db: utils.DbIdentifier{
+				DatabaseName: psi.DbName,
+			},
+			Count: executionCount,
+			// total_elapsed_time is in microseconds.
+			TotalLatencyMs: float64(totalElapsedTime) / 1000,
+		})
+	}
+	queryInfo = utils.MergeQueryAssessments(queryInfo)
+	if errString != "" {
+		return queryInfo, fmt.Errorf("%s", errString)
+	}
+	return queryInfo, nil
+}
+
+// stripParameterDeclaration removes the declaration of the parameters, like
+// "(@P1 int,@P2 nvarchar(50))", that precedes the text of the queries run
+// with sp_executesql.
+func stripParameterDeclaration(statement string) string {
+	trimmed := strings.TrimSpace(statement)
+	if !strings.HasPrefix(trimmed, "(@") {
+		return statement
+	}
+	depth := 0
+	for i, c := range trimmed {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return trimmed[i+1:]
+			}
+		}
+	}
+	return statement
+}
+
+// GetStatisticsWindowSeconds returns the uptime of the server. The plans
+// cached after its start have gathered their statistics for less time, so
+// the rates of their queries are underestimated.
+func (psi PerformanceSchemaImpl) GetStatisticsWindowSeconds() (int64, error) {
+	q := `SELECT DATEDIFF(SECOND, sqlserver_start_time, SYSDATETIME()) FROM sys.dm_os_sys_info;`
+	var uptime int64
+	if err := psi.Db.QueryRow(q).Scan(&uptime); err != nil {
+		return 0, fmt.Errorf("couldn't read the uptime of the server : %s", err)
+	}
+	return uptime, nil
+}
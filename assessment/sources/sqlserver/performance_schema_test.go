@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPerformanceSchemaImpl(t *testing.T) (PerformanceSchemaImpl, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+	return PerformanceSchemaImpl{Db: db, DbName: "test_db"}, mock
+}
+
+func TestPerformanceSchemaImpl_GetAllQueryAssessments(t *testing.T) {
+	psi, mock := newTestPerformanceSchemaImpl(t)
+	mock.ExpectQuery(`FROM\s+sys.dm_exec_query_stats qs`).
+		WithArgs("test_db").
+		WillReturnRows(sqlmock.NewRows([]string{"statement_text", "execution_count", "total_elapsed_time"}).
+			AddRow("(@P1 int,@P2 nvarchar(50))SELECT * FROM users WHERE id = @P1 AND name = @P2", 100, 25000).
+			AddRow("SELECT * FROM users WHERE id = 3 AND name = N'bob'", 2, 1000).
+			AddRow("UPDATE t SET a = @a WHERE b = 1; SELECT @@ROWCOUNT", 4, 500000).
+			AddRow("SET NOCOUNT ON", 1000, 1).
+			AddRow("BEGIN TRANSACTION", 1000, 1))
+
+	queries, err := psi.GetAllQueryAssessments()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []utils.QueryAssessmentInfo{
+		{
+			Query:          "UPDATE t SET a = ? WHERE b = ?; SELECT @@ROWCOUNT",
+			Db:             utils.DbIdentifier{DatabaseName: "test_db"},
+			Count:          4,
+			TotalLatencyMs: 500,
+		},
+		{
+			Query:          "SELECT * FROM users WHERE id = ? AND name = ?",
+			Db:             utils.DbIdentifier{DatabaseName: "test_db"},
+			Count:          102,
+			TotalLatencyMs: 26,
+		},
+	}, queries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPerformanceSchemaImpl_GetAllQueryAssessmentsError(t *testing.T) {
+	psi, mock := newTestPerformanceSchemaImpl(t)
+	mock.ExpectQuery(`FROM\s+sys.dm_exec_query_stats qs`).
+		WithArgs("test_db").
+		WillReturnError(errors.New("VIEW SERVER STATE permission was denied"))
+
+	queries, err := psi.GetAllQueryAssessments()
+
+	assert.ErrorContains(t, err, "couldn't read dm_exec_query_stats")
+	assert.Nil(t, queries)
+}
+
+func TestStripParameterDeclaration(t *testing.T) {
+	assert.Equal(t, "SELECT 1", stripParameterDeclaration("(@P1 decimal(10,2))SELECT 1"))
+	assert.Equal(t, "SELECT (1)", stripParameterDeclaration("SELECT (1)"))
+	assert.Equal(t, "(@P1 int", stripParameterDeclaration("(@P1 int"))
+}
+
+func TestPerformanceSchemaImpl_GetStatisticsWindowSeconds(t *testing.T) {
+	psi, mock := newTestPerformanceSchemaImpl(t)
+	mock.ExpectQuery(`SELECT DATEDIFF\(SECOND, sqlserver_start_time, SYSDATETIME\(\)\) FROM sys.dm_os_sys_info`).
+		WillReturnError(errors.New("VIEW SERVER STATE permission was denied"))
+
+	_, err := psi.GetStatisticsWindowSeconds()
+
+	assert.ErrorContains(t, err, "couldn't read the uptime")
+}
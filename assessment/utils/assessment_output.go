@@ -29,8 +29,38 @@ type AssessmentOutput struct {
 	PerformanceAssessment PerformanceAssessmentOutput
 }
 
+// CostAssessmentOutput is the estimate of the size and cost of the database
+// on Spanner.
 type CostAssessmentOutput struct {
-	//TBD
+	Tables       []TableCostAssessment
+	SourceBytes  int64   // Size of the tables and indexes at the source.
+	SpannerBytes int64   // Estimated size of the tables and indexes on Spanner.
+	ReadQps      float64 // Average reads per second over the statistics window, from the performance schema.
+	WriteQps     float64 // Average writes per second over the statistics window, from the performance schema.
+	// Length of the window the query counts of the performance schema were
+	// collected over, usually the uptime of the server.
+	StatisticsWindowSeconds int64
+	// Ratio of the peak query rates to the average ones, which the instance
+	// is sized for.
+	PeakToAverageRatio float64
+	RegionConfigs      []RegionConfigCostAssessment
+}
+
+type TableCostAssessment struct {
+	TableName    string
+	RowCount     int64
+	SourceBytes  int64
+	SpannerBytes int64
+}
+
+// RegionConfigCostAssessment is the suggested compute capacity of an instance
+// in a region configuration and its monthly cost, with and without a
+// committed use discount.
+type RegionConfigCostAssessment struct {
+	RegionConfig      string
+	ProcessingUnits   int
+	MinMonthlyCostUsd float64
+	MaxMonthlyCostUsd float64
 }
 
 type SchemaAssessmentOutput struct {
@@ -199,8 +229,9 @@ type QueryTranslationResult struct {
 	Explanation             string   `json:"explanation"`
 	Complexity              string   `json:"complexity"`
 	TranslationError        string   `json:"translation_error,omitempty"`
-	AssessmentSource        string   // "app_code" or "performance_schema" or "app_code,performance_schema"
+	AssessmentSource        string   // "app_code", "performance_schema", "static_analysis" or a comma separated list of them
 	ExecutionCount          int      `json:"execution_count,omitempty"`
+	TotalLatencyMs          float64  `json:"total_latency_ms,omitempty"`
 	SnippetId               string   `json:"snippet_id,omitempty"`
 	NumberOfQueryOccurances int      `json:"number_of_query_occurances,omitempty"`
 	SourceTablesAffected    []string `json:"tables_affected"`
@@ -212,8 +243,15 @@ type QueryTranslationResult struct {
 	SelectForUpdate         bool               `json:"select_for_update"`
 	ComparisonAnalysis      ComparisonAnalysis `json:"comparison_analysis"`
 	QueryType               string             // INSERT / UPDATE / DELETE / SELECT / CALL / DDL / OTHER
+	TranslationSource       string             // TranslationSourceRules or TranslationSourceLLM
 }
 
+// Paths producing the Spanner translation of a query.
+const (
+	TranslationSourceRules = "rules"
+	TranslationSourceLLM   = "llm"
+)
+
 type ComparisonAnalysis struct {
 	LiteralComparisons   *LiteralComparisonAnalysis   `json:"literal_comparisons,omitempty"`
 	DataTypeComparisons  *DataTypeComparisonAnalysis  `json:"data_type_comparisons,omitempty"`
@@ -238,6 +276,13 @@ type DateComparisonAnalysis struct {
 }
 
 type QueryTranslationInput struct {
-	Query string
-	Count int
+	Query          string
+	Count          int
+	TotalLatencyMs float64
+	// AssessmentSource is where the query was found, performance_schema when
+	// empty.
+	AssessmentSource string
+	// SnippetId locates the query in the application code, as file:line.
+	SnippetId               string
+	NumberOfQueryOccurances int
 }
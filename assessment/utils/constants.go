@@ -17,10 +17,12 @@
 // performend against other lower_case strings.
 package utils
 
+import "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/llm"
+
 const (
 	PARALLEL_TASK_RUNNER_COUNT int    = 40
-	GEMINI_PRO_MODEL           string = "gemini-2.5-pro"
-	GEMINI_FLASH_MODEL         string = "gemini-2.0-flash-001"
+	GEMINI_PRO_MODEL           string = llm.GeminiProModel
+	GEMINI_FLASH_MODEL         string = llm.GeminiFlashModel
 )
 
 // SupportedFunctions is a map of supported Spanner GoogleSQL functions.
@@ -0,0 +1,187 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package utils
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Hours in a month, as Google Cloud bills them.
+const hoursPerMonth = 730
+
+// Storage of a node, which is 1000 processing units.
+const storageBytesPerNode = 10 * 1024 * 1024 * 1024 * 1024
+
+const bytesPerGib = 1024 * 1024 * 1024
+
+//go:embed spanner_pricing.json
+var defaultSpannerPricing []byte
+
+// Spanner types whose values always have the same size, unlike STRING, BYTES
+// and JSON whose maximum size is far from the size of their values.
+var fixedSizeTypes = map[string]bool{
+	"BOOL":      true,
+	"DATE":      true,
+	"FLOAT32":   true,
+	"FLOAT64":   true,
+	"INT64":     true,
+	"NUMERIC":   true,
+	"TIMESTAMP": true,
+}
+
+// SpannerPricing is the price and the throughput of a node of Spanner in a
+// region configuration. The default pricing has list prices in USD, which can
+// be replaced with the prices of a customer with the pricingFile of the
+// assessment profile.
+type SpannerPricing struct {
+	RegionConfig         string  `json:"regionConfig"`
+	NodeHourUsd          float64 `json:"nodeHourUsd"`
+	StorageGibMonthUsd   float64 `json:"storageGibMonthUsd"`
+	CommittedUseDiscount float64 `json:"committedUseDiscount"` // Discount on compute with a committed use, between 0 and 1.
+	ReadQpsPerNode       float64 `json:"readQpsPerNode"`
+	WriteQpsPerNode      float64 `json:"writeQpsPerNode"`
+	MaxCpuUtilization    float64 `json:"maxCpuUtilization"` // Recommended maximum high priority CPU utilization, between 0 and 1.
+}
+
+// LoadSpannerPricing reads the pricing of the region configurations from a
+// JSON file, or returns the default pricing when the file is empty.
+func LoadSpannerPricing(pricingFile string) ([]SpannerPricing, error) {
+	content := defaultSpannerPricing
+	if pricingFile != "" {
+		var err error
+		content, err = os.ReadFile(pricingFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read pricing file: %w", err)
+		}
+	}
+	var pricing []SpannerPricing
+	if err := json.Unmarshal(content, &pricing); err != nil {
+		return nil, fmt.Errorf("can't parse pricing file: %w", err)
+	}
+	for _, p := range pricing {
+		if p.RegionConfig == "" || p.ReadQpsPerNode <= 0 || p.WriteQpsPerNode <= 0 ||
+			p.MaxCpuUtilization <= 0 || p.MaxCpuUtilization > 1 || p.CommittedUseDiscount < 0 || p.CommittedUseDiscount >= 1 {
+			return nil, fmt.Errorf("invalid pricing of region configuration %q", p.RegionConfig)
+		}
+	}
+	return pricing, nil
+}
+
+// EstimateSpannerTableBytes estimates the size of a table and its indexes on
+// Spanner from their size at the source. Each row grows by the size increase
+// of the table and of its fixed size columns, while the variable size columns
+// and the indexes are assumed to keep their size.
+func EstimateSpannerTableBytes(table TableAssessment, size TableSizeAssessmentInfo) int64 {
+	rowIncrease := int64(table.SizeIncreaseInBytes)
+	for _, column := range table.Columns {
+		if column.SpannerColDef == nil || column.SpannerColDef.IsArray || !fixedSizeTypes[strings.ToUpper(column.SpannerColDef.Datatype)] {
+			continue
+		}
+		rowIncrease += int64(column.SizeIncreaseInBytes)
+	}
+	return max(size.DataBytes+size.IndexBytes+size.RowCount*rowIncrease, 0)
+}
+
+// ParsePeakToAverageRatio parses the peakToAverageRatio of the assessment
+// profile, the ratio of the peak query rates of the source to their average
+// over the statistics window, which is often the whole uptime of the server.
+// The instance is sized for the peak rates. It defaults to 1.
+func ParsePeakToAverageRatio(value string) (float64, error) {
+	if value == "" {
+		return 1, nil
+	}
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil || ratio < 1 || math.IsInf(ratio, 0) {
+		return 0, fmt.Errorf("invalid peakToAverageRatio %q: must be a number greater than or equal to 1", value)
+	}
+	return ratio, nil
+}
+
+// QueryRates returns the average reads and writes per second of queries
+// executed during a window of time, or 0 when the window is unknown. Queries
+// are rarely evenly spread over the window, so the rates are below the peak
+// rates.
+func QueryRates(queries []QueryAssessmentInfo, windowSeconds int64) (float64, float64) {
+	if windowSeconds <= 0 {
+		return 0, 0
+	}
+	var reads, writes int
+	for _, q := range queries {
+		switch GetQueryType(q.Query) {
+		case "SELECT":
+			reads += q.Count
+		case "INSERT", "UPDATE", "DELETE":
+			writes += q.Count
+		}
+	}
+	return float64(reads) / float64(windowSeconds), float64(writes) / float64(windowSeconds)
+}
+
+// EstimateCost suggests the processing units of an instance holding the
+// tables and serving the peak query rates, the average ones multiplied by
+// peakToAverageRatio, in each region configuration, and estimates its monthly
+// cost.
+func EstimateCost(tables []TableCostAssessment, readQps, writeQps, peakToAverageRatio float64, pricing []SpannerPricing) CostAssessmentOutput {
+	output := CostAssessmentOutput{
+		Tables:             tables,
+		ReadQps:            readQps,
+		WriteQps:           writeQps,
+		PeakToAverageRatio: peakToAverageRatio,
+	}
+	sort.Slice(output.Tables, func(i, j int) bool {
+		return output.Tables[i].TableName < output.Tables[j].TableName
+	})
+	for _, table := range tables {
+		output.SourceBytes += table.SourceBytes
+		output.SpannerBytes += table.SpannerBytes
+	}
+	for _, p := range pricing {
+		processingUnits := suggestProcessingUnits(output.SpannerBytes, readQps*peakToAverageRatio, writeQps*peakToAverageRatio, p)
+		computeCost := float64(processingUnits) / 1000 * p.NodeHourUsd * hoursPerMonth
+		storageCost := float64(output.SpannerBytes) / bytesPerGib * p.StorageGibMonthUsd
+		output.RegionConfigs = append(output.RegionConfigs, RegionConfigCostAssessment{
+			RegionConfig:      p.RegionConfig,
+			ProcessingUnits:   processingUnits,
+			MinMonthlyCostUsd: computeCost*(1-p.CommittedUseDiscount) + storageCost,
+			MaxMonthlyCostUsd: computeCost + storageCost,
+		})
+	}
+	return output
+}
+
+// suggestProcessingUnits returns the processing units needed for the storage
+// and for the query rates below the recommended CPU utilization. Instances
+// have 100 processing units or more, in steps of 100 below 1000 and of 1000
+// above.
+func suggestProcessingUnits(spannerBytes int64, readQps, writeQps float64, pricing SpannerPricing) int {
+	storageNodes := float64(spannerBytes) / storageBytesPerNode
+	computeNodes := (readQps/pricing.ReadQpsPerNode + writeQps/pricing.WriteQpsPerNode) / pricing.MaxCpuUtilization
+	processingUnits := math.Max(storageNodes, computeNodes) * 1000
+	// Ignore the rounding errors of the divisions.
+	const epsilon = 1e-9
+	if processingUnits <= 1000 {
+		return max(int(math.Ceil(processingUnits/100-epsilon)), 1) * 100
+	}
+	return int(math.Ceil(processingUnits/1000-epsilon)) * 1000
+}
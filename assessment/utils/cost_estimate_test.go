@@ -0,0 +1,157 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPricing = SpannerPricing{
+	RegionConfig:         "regional-us-central1",
+	NodeHourUsd:          0.90,
+	StorageGibMonthUsd:   0.30,
+	CommittedUseDiscount: 0.40,
+	ReadQpsPerNode:       22500,
+	WriteQpsPerNode:      3500,
+	MaxCpuUtilization:    0.65,
+}
+
+func TestLoadSpannerPricing(t *testing.T) {
+	pricing, err := LoadSpannerPricing("")
+	require.NoError(t, err)
+	assert.NotEmpty(t, pricing)
+	assert.Equal(t, testPricing, pricing[0])
+
+	dir := t.TempDir()
+	pricingFile := filepath.Join(dir, "pricing.json")
+	require.NoError(t, os.WriteFile(pricingFile, []byte(`[{"regionConfig": "regional-europe-west1", "nodeHourUsd": 1, "storageGibMonthUsd": 0.33,
+		"readQpsPerNode": 22500, "writeQpsPerNode": 3500, "maxCpuUtilization": 0.65}]`), 0644))
+	pricing, err = LoadSpannerPricing(pricingFile)
+	require.NoError(t, err)
+	assert.Equal(t, []SpannerPricing{{RegionConfig: "regional-europe-west1", NodeHourUsd: 1, StorageGibMonthUsd: 0.33,
+		ReadQpsPerNode: 22500, WriteQpsPerNode: 3500, MaxCpuUtilization: 0.65}}, pricing)
+
+	invalidFile := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte(`[{"regionConfig": "nam6", "readQpsPerNode": 15000, "writeQpsPerNode": 2700}]`), 0644))
+	_, err = LoadSpannerPricing(invalidFile)
+	assert.ErrorContains(t, err, "invalid pricing of region configuration \"nam6\"")
+
+	_, err = LoadSpannerPricing(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestEstimateSpannerTableBytes(t *testing.T) {
+	table := TableAssessment{
+		SizeIncreaseInBytes: 1,
+		Columns: []ColumnAssessment{
+			{SpannerColDef: &SpColumnDetails{Datatype: "INT64"}, SizeIncreaseInBytes: 12},
+			{SpannerColDef: &SpColumnDetails{Datatype: "STRING", Len: 255}, SizeIncreaseInBytes: 100},
+			{SpannerColDef: &SpColumnDetails{Datatype: "INT64", IsArray: true}, SizeIncreaseInBytes: 1000},
+		},
+	}
+	size := TableSizeAssessmentInfo{RowCount: 1000, DataBytes: 100000, IndexBytes: 20000}
+
+	assert.Equal(t, int64(133000), EstimateSpannerTableBytes(table, size))
+}
+
+func TestQueryRates(t *testing.T) {
+	queries := []QueryAssessmentInfo{
+		{Query: "SELECT * FROM users WHERE id = ?", Count: 500},
+		{Query: "INSERT INTO users (name) VALUES (?)", Count: 100},
+		{Query: "UPDATE users SET name = ? WHERE id = ?", Count: 50},
+		{Query: "CALL refresh_totals()", Count: 10},
+	}
+
+	reads, writes := QueryRates(queries, 100)
+	assert.Equal(t, 5.0, reads)
+	assert.Equal(t, 1.5, writes)
+
+	reads, writes = QueryRates(queries, 0)
+	assert.Zero(t, reads)
+	assert.Zero(t, writes)
+}
+
+func TestSuggestProcessingUnits(t *testing.T) {
+	tests := []struct {
+		name         string
+		spannerBytes int64
+		readQps      float64
+		writeQps     float64
+		want         int
+	}{
+		{"empty database", 0, 0, 0, 100},
+		{"small database", 300 * bytesPerGib, 10, 1, 100},
+		{"reads", 0, 10000, 0, 700},
+		{"reads and writes", 0, 10000, 1000, 2000},
+		{"storage", 25 * 1024 * bytesPerGib, 100, 10, 3000},
+		{"exactly one node of storage", storageBytesPerNode, 0, 0, 1000},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, suggestProcessingUnits(tc.spannerBytes, tc.readQps, tc.writeQps, testPricing))
+		})
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	tables := []TableCostAssessment{
+		{TableName: "users", RowCount: 1000, SourceBytes: 4 * bytesPerGib, SpannerBytes: 6 * bytesPerGib},
+		{TableName: "orders", RowCount: 5000, SourceBytes: 3 * bytesPerGib, SpannerBytes: 4 * bytesPerGib},
+	}
+
+	output := EstimateCost(tables, 10, 1, 1, []SpannerPricing{testPricing})
+
+	assert.Equal(t, []string{"orders", "users"}, []string{output.Tables[0].TableName, output.Tables[1].TableName})
+	assert.Equal(t, int64(7*bytesPerGib), output.SourceBytes)
+	assert.Equal(t, int64(10*bytesPerGib), output.SpannerBytes)
+	assert.Equal(t, 10.0, output.ReadQps)
+	assert.Equal(t, 1.0, output.WriteQps)
+	require.Len(t, output.RegionConfigs, 1)
+	regionConfig := output.RegionConfigs[0]
+	assert.Equal(t, "regional-us-central1", regionConfig.RegionConfig)
+	assert.Equal(t, 100, regionConfig.ProcessingUnits)
+	// 0.1 node for 730 hours and 10 GiB of storage.
+	assert.InDelta(t, 68.7, regionConfig.MaxMonthlyCostUsd, 0.001)
+	assert.InDelta(t, 42.42, regionConfig.MinMonthlyCostUsd, 0.001)
+
+	// The instance is sized for the peak rates, while the average ones are
+	// reported.
+	output = EstimateCost(tables, 10000, 1000, 2, []SpannerPricing{testPricing})
+	assert.Equal(t, 10000.0, output.ReadQps)
+	assert.Equal(t, 2.0, output.PeakToAverageRatio)
+	assert.Equal(t, 3000, output.RegionConfigs[0].ProcessingUnits)
+}
+
+func TestParsePeakToAverageRatio(t *testing.T) {
+	ratio, err := ParsePeakToAverageRatio("")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, ratio)
+
+	ratio, err = ParsePeakToAverageRatio("2.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, ratio)
+
+	for _, value := range []string{"0.5", "-1", "abc", "Inf"} {
+		_, err = ParsePeakToAverageRatio(value)
+		assert.Error(t, err, value)
+	}
+}
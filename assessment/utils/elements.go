@@ -37,6 +37,16 @@ type TableAssessmentInfo struct {
 	ColumnAssessmentInfos map[string]ColumnAssessmentInfo[any]
 }
 
+// Size of a table, from the statistics of the source, which may be
+// approximate.
+type TableSizeAssessmentInfo struct {
+	Db         DbIdentifier
+	Name       string
+	RowCount   int64
+	DataBytes  int64 // Size of the rows, including the primary key.
+	IndexBytes int64 // Size of the secondary indexes.
+}
+
 // Information relevant to assessment of columns
 type ColumnAssessmentInfo[T any] struct {
 	Db                     DbIdentifier
@@ -102,11 +112,12 @@ type FunctionAssessmentInfo struct {
 // Information relevant to assessment of views
 // TODO : Capture information about view permissions
 type ViewAssessmentInfo struct {
-	Db          DbIdentifier
-	Name        string
-	Definition  string
-	CheckOption string // Determines how INSERT and UPDATE statements are handled when they affect a view. The value is one of NONE, CASCADE, or LOCAL.
-	IsUpdatable bool
+	Db             DbIdentifier
+	Name           string
+	Definition     string
+	CheckOption    string // Determines how INSERT and UPDATE statements are handled when they affect a view. The value is one of NONE, CASCADE, or LOCAL.
+	IsUpdatable    bool
+	IsMaterialized bool // Whether the view stores its rows, like materialized views of PostgreSQL and Oracle or indexed views of SQL Server.
 }
 
 // Information relevant to assessment of queries
@@ -116,6 +127,7 @@ type QueryAssessmentInfo struct {
 	LengthOfQuery  string
 	TablesAffected *[]string
 	Count          int
+	TotalLatencyMs float64 // Total execution time of all the executions, 0 if the source doesn't record it.
 }
 
 type Snippet struct {
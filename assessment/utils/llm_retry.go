@@ -6,26 +6,26 @@ import (
 	"strings"
 	"time"
 
-	"cloud.google.com/go/vertexai/genai"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/llm"
 	"go.uber.org/zap"
 )
 
 type LLMRetryClient interface {
-	GenerateContentWithRetry(ctx context.Context, model *genai.GenerativeModel, prompt genai.Part, maxRetries int,
-		logger *zap.Logger) (*genai.GenerateContentResponse, error)
+	GenerateContentWithRetry(ctx context.Context, model llm.GenerativeModel, prompt string, maxRetries int,
+		logger *zap.Logger) (*llm.Response, error)
 }
 
 type DefaultLLMRetryClient struct{}
 
-// GenerateContentWithRetry wraps a Vertex AI LLM call with retry logic for rate limiting/quota errors.
+// GenerateContentWithRetry wraps an LLM call with retry logic for rate limiting/quota errors.
 func (c *DefaultLLMRetryClient) GenerateContentWithRetry(
 	ctx context.Context,
-	model *genai.GenerativeModel,
-	prompt genai.Part,
+	model llm.GenerativeModel,
+	prompt string,
 	maxRetries int,
 	logger *zap.Logger,
-) (*genai.GenerateContentResponse, error) {
-	var resp *genai.GenerateContentResponse
+) (*llm.Response, error) {
+	var resp *llm.Response
 	var err error
 	for i := 0; i < maxRetries; i++ {
 		resp, err = model.GenerateContent(ctx, prompt)
@@ -34,7 +34,7 @@ func (c *DefaultLLMRetryClient) GenerateContentWithRetry(
 		}
 		if strings.Contains(err.Error(), "ResourceExhausted") || strings.Contains(err.Error(), "429") || strings.Contains(err.Error(), "502") {
 			backoff := time.Duration(math.Pow(2, float64(i))) * time.Second
-			logger.Warn("Vertex AI rate limited, backing off", zap.Int("attempt", i+1), zap.Duration("backoff", backoff), zap.Error(err))
+			logger.Warn("LLM rate limited, backing off", zap.Int("attempt", i+1), zap.Duration("backoff", backoff), zap.Error(err))
 			time.Sleep(backoff)
 			continue
 		}
@@ -0,0 +1,87 @@
+/*
+	Copyright 2026 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package utils
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	numberLiteralRegex  = regexp.MustCompile(`\b(0[xX][0-9a-fA-F]+|\d+(\.\d+)?([eE][-+]?\d+)?)\b`)
+	nationalStringRegex = regexp.MustCompile(`\b[Nn]\?`)
+	valueListRegex      = regexp.MustCompile(`\(\s*\?(\s*,\s*\?)+\s*\)`)
+	rowListRegex        = regexp.MustCompile(`\(\?\)(\s*,\s*\(\?\))+`)
+)
+
+// NormalizeQuery replaces the literals of a query with ?, and lists of them
+// with a single (?), so that executions of a query with different values
+// have the same text, like the digests of the MySQL performance schema.
+// Whitespace is collapsed and a trailing semicolon is dropped.
+func NormalizeQuery(query string) string {
+	var b strings.Builder
+	for i := 0; i < len(query); i++ {
+		if query[i] != '\'' {
+			b.WriteByte(query[i])
+			continue
+		}
+		// Skip to the end of the string literal, where '' is an escaped quote.
+		j := i + 1
+		for ; j < len(query); j++ {
+			if query[j] == '\'' {
+				if j+1 < len(query) && query[j+1] == '\'' {
+					j++
+					continue
+				}
+				break
+			}
+		}
+		b.WriteByte('?')
+		i = j
+	}
+	s := numberLiteralRegex.ReplaceAllString(b.String(), "?")
+	s = nationalStringRegex.ReplaceAllString(s, "?")
+	s = strings.Join(strings.Fields(s), " ")
+	s = valueListRegex.ReplaceAllString(s, "(?)")
+	s = rowListRegex.ReplaceAllString(s, "(?)")
+	return strings.TrimSpace(strings.TrimSuffix(s, ";"))
+}
+
+// MergeQueryAssessments merges the queries with the same text, adding up
+// their executions and latencies, and orders them from the hottest: by total
+// latency, then by number of executions.
+func MergeQueryAssessments(queries []QueryAssessmentInfo) []QueryAssessmentInfo {
+	var merged []QueryAssessmentInfo
+	index := make(map[string]int)
+	for _, q := range queries {
+		if i, ok := index[q.Query]; ok {
+			merged[i].Count += q.Count
+			merged[i].TotalLatencyMs += q.TotalLatencyMs
+			continue
+		}
+		index[q.Query] = len(merged)
+		merged = append(merged, q)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].TotalLatencyMs != merged[j].TotalLatencyMs {
+			return merged[i].TotalLatencyMs > merged[j].TotalLatencyMs
+		}
+		return merged[i].Count > merged[j].Count
+	})
+	return merged
+}
@@ -0,0 +1,62 @@
+/*
+	Copyright 2025 Google LLC
+
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"no literals", "SELECT * FROM users WHERE id = ?", "SELECT * FROM users WHERE id = ?"},
+		{"numbers", "SELECT * FROM users WHERE id = 42 AND score > 1.5e3", "SELECT * FROM users WHERE id = ? AND score > ?"},
+		{"hex number", "SELECT * FROM t WHERE flags = 0x1F", "SELECT * FROM t WHERE flags = ?"},
+		{"numbers in identifiers", "SELECT col1 FROM table2", "SELECT col1 FROM table2"},
+		{"strings", "SELECT * FROM users WHERE name = 'it''s' AND city = 'Paris'", "SELECT * FROM users WHERE name = ? AND city = ?"},
+		{"national string", "SELECT * FROM users WHERE name = N'abc'", "SELECT * FROM users WHERE name = ?"},
+		{"in list", "SELECT * FROM users WHERE id IN (1, 2,3)", "SELECT * FROM users WHERE id IN (?)"},
+		{"multi-row insert", "INSERT INTO t (a, b) VALUES (1, 'x'), (2, 'y')", "INSERT INTO t (a, b) VALUES (?)"},
+		{"whitespace and semicolon", "  SELECT *\n\tFROM users  ;", "SELECT * FROM users"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NormalizeQuery(tc.query))
+		})
+	}
+}
+
+func TestMergeQueryAssessments(t *testing.T) {
+	queries := []QueryAssessmentInfo{
+		{Query: "SELECT ?", Count: 1, TotalLatencyMs: 1},
+		{Query: "SELECT * FROM a WHERE id = ?", Count: 2, TotalLatencyMs: 5},
+		{Query: "SELECT ?", Count: 3, TotalLatencyMs: 7},
+		{Query: "SELECT * FROM b", Count: 10, TotalLatencyMs: 5},
+	}
+	expected := []QueryAssessmentInfo{
+		{Query: "SELECT ?", Count: 4, TotalLatencyMs: 8},
+		{Query: "SELECT * FROM b", Count: 10, TotalLatencyMs: 5},
+		{Query: "SELECT * FROM a WHERE id = ?", Count: 2, TotalLatencyMs: 5},
+	}
+	assert.Equal(t, expected, MergeQueryAssessments(queries))
+	assert.Nil(t, MergeQueryAssessments(nil))
+}
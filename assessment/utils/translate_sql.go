@@ -24,7 +24,7 @@ import (
 	"strings"
 	"sync"
 
-	"cloud.google.com/go/vertexai/genai"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/llm"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/task"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"go.uber.org/zap"
@@ -42,12 +42,18 @@ type LLMQueryTranslationInput struct {
 	MySQLQuery    string
 	MySQLSchema   string
 	SpannerSchema string
-	AIClient      *genai.Client
+	LLMProvider   llm.Provider
 	Count         int
 	RetryClient   LLMRetryClient
+	// The fields below are copied to the result of the translation, since
+	// the results of the parallel tasks don't come back in input order.
+	TotalLatencyMs          float64
+	AssessmentSource        string
+	SnippetId               string
+	NumberOfQueryOccurances int
 }
 
-func TranslateQueriesToSpanner(ctx context.Context, queries []QueryTranslationInput, aiClient *genai.Client, mysqlSchema, spannerSchema string) ([]QueryTranslationResult, error) {
+func TranslateQueriesToSpanner(ctx context.Context, queries []QueryTranslationInput, llmProvider llm.Provider, mysqlSchema, spannerSchema string) ([]QueryTranslationResult, error) {
 
 	if len(queries) == 0 {
 		return nil, fmt.Errorf("no performance schema queries to translate")
@@ -63,16 +69,21 @@ func TranslateQueriesToSpanner(ctx context.Context, queries []QueryTranslationIn
 			MySQLQuery:    query.Query,
 			MySQLSchema:   mysqlSchema,
 			SpannerSchema: spannerSchema,
-			AIClient:      aiClient,
+			LLMProvider:   llmProvider,
 			Count:         query.Count,
 			RetryClient:   &retryClient,
+
+			TotalLatencyMs:          query.TotalLatencyMs,
+			AssessmentSource:        query.AssessmentSource,
+			SnippetId:               query.SnippetId,
+			NumberOfQueryOccurances: query.NumberOfQueryOccurances,
 		})
 	}
 
 	// Process translations in parallel
 	parallelTaskRunner := &task.RunParallelTasksImpl[*LLMQueryTranslationInput, *QueryTranslationResult]{}
 
-	translationResults, err := parallelTaskRunner.RunParallelTasks(translationInputs, 10, TranslateQueryTask, false)
+	translationResults, err := parallelTaskRunner.RunParallelTasks(translationInputs, 10, translateQueryWithInput, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run parallel query translation: %w", err)
 	}
@@ -80,20 +91,32 @@ func TranslateQueriesToSpanner(ctx context.Context, queries []QueryTranslationIn
 	// Convert results to final format
 	results := make([]QueryTranslationResult, len(translationResults))
 	for i, result := range translationResults {
-		if result.Result != nil {
-			results[i] = *result.Result
-		} else {
-			results[i] = QueryTranslationResult{
-				OriginalQuery:    queries[i].Query,
-				TranslationError: "Translation failed",
-			}
-		}
+		results[i] = *result.Result
 	}
 
 	logger.Log.Info("query translation completed", zap.Int("translated_count", len(results)))
 	return results, nil
 }
 
+// translateQueryWithInput runs TranslateQueryTask and copies the details of
+// the query from the input to its result.
+func translateQueryWithInput(input *LLMQueryTranslationInput, mutex *sync.Mutex) task.TaskResult[*QueryTranslationResult] {
+	result := TranslateQueryTask(input, mutex)
+	if result.Result == nil {
+		result.Result = &QueryTranslationResult{
+			OriginalQuery:    input.MySQLQuery,
+			TranslationError: "Translation failed",
+		}
+	}
+	result.Result.TotalLatencyMs = input.TotalLatencyMs
+	if input.AssessmentSource != "" {
+		result.Result.AssessmentSource = input.AssessmentSource
+	}
+	result.Result.SnippetId = input.SnippetId
+	result.Result.NumberOfQueryOccurances = input.NumberOfQueryOccurances
+	return result
+}
+
 // TranslateQueryTaskFunc is the function type for query translation tasks.
 type TranslateQueryTaskFunc func(input *LLMQueryTranslationInput, mutex *sync.Mutex) task.TaskResult[*QueryTranslationResult]
 
@@ -103,26 +126,26 @@ var TranslateQueryTask TranslateQueryTaskFunc = defaultTranslateQueryTask
 
 // TranslateQueryTask is the task function for translating a single query
 func defaultTranslateQueryTask(input *LLMQueryTranslationInput, mutex *sync.Mutex) task.TaskResult[*QueryTranslationResult] {
-	// Use the provided AI client
-	if input.AIClient == nil {
-		logger.Log.Error("AI client not provided")
+	// Use the provided LLM provider
+	if input.LLMProvider == nil {
+		logger.Log.Error("LLM provider not provided")
 		return task.TaskResult[*QueryTranslationResult]{
 			Result: &QueryTranslationResult{
 				OriginalQuery:    input.MySQLQuery,
-				TranslationError: "AI client not provided",
+				TranslationError: "LLM provider not provided",
 			},
 		}
 	}
 
-	model := input.AIClient.GenerativeModel(GEMINI_PRO_MODEL)
-	model.ResponseMIMEType = "application/json"
+	model := input.LLMProvider.GenerativeModel(llm.ProModel)
+	model.SetResponseMIMEType("application/json")
 
 	// Build prompt
 	prompt := buildTranslationPrompt(input.MySQLQuery, input.MySQLSchema, input.SpannerSchema)
 	logger.Log.Debug("Prompt: " + prompt)
 
 	// Generate translation
-	response, err := input.RetryClient.GenerateContentWithRetry(input.Context, model, genai.Text(prompt), 5, logger.Log)
+	response, err := input.RetryClient.GenerateContentWithRetry(input.Context, model, prompt, 5, logger.Log)
 	if err != nil {
 		logger.Log.Error("failed to generate query translation",
 			zap.String("query", input.MySQLQuery),
@@ -137,10 +160,7 @@ func defaultTranslateQueryTask(input *LLMQueryTranslationInput, mutex *sync.Mute
 	}
 
 	// Parse response
-	var llmResponse string
-	if part, ok := response.Candidates[0].Content.Parts[0].(genai.Text); ok {
-		llmResponse = string(part)
-	}
+	llmResponse := response.Text
 	logger.Log.Debug("Response: " + llmResponse)
 	// Parse JSON response
 	var translationResult QueryTranslationResult
@@ -161,6 +181,7 @@ func defaultTranslateQueryTask(input *LLMQueryTranslationInput, mutex *sync.Mute
 	translationResult.AssessmentSource = "performance_schema"
 	translationResult.ExecutionCount = input.Count
 	translationResult.QueryType = GetQueryType(input.MySQLQuery)
+	translationResult.TranslationSource = TranslationSourceLLM
 
 	return task.TaskResult[*QueryTranslationResult]{
 		Result: &translationResult,
@@ -21,8 +21,9 @@ import (
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
-	"cloud.google.com/go/vertexai/genai"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/llm"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/task"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/stretchr/testify/assert"
@@ -34,28 +35,30 @@ func init() {
 	logger.Log = zap.NewNop()
 }
 
-// mockGenerativeModel is a mock implementation of the genai.GenerativeModel for testing.
+// mockGenerativeModel is a mock implementation of the llm.GenerativeModel for testing.
 type mockGenerativeModel struct {
-	GenerateContentFunc func(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error)
+	GenerateContentFunc func(ctx context.Context, prompt string) (*llm.Response, error)
 }
 
-func (m *mockGenerativeModel) GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+func (m *mockGenerativeModel) GenerateContent(ctx context.Context, prompt string) (*llm.Response, error) {
 	if m.GenerateContentFunc != nil {
-		return m.GenerateContentFunc(ctx, parts...)
+		return m.GenerateContentFunc(ctx, prompt)
 	}
 	return nil, errors.New("GenerateContentFunc not implemented")
 }
 
+func (m *mockGenerativeModel) SetResponseMIMEType(string) {}
+
 type MockLLMRetryClient struct {
 	mock.Mock
 }
 
-func (m *MockLLMRetryClient) GenerateContentWithRetry(ctx context.Context, model *genai.GenerativeModel, parts genai.Part, i int, log *zap.Logger) (*genai.GenerateContentResponse, error) {
-	args := m.Called(ctx, model, parts, i, log)
+func (m *MockLLMRetryClient) GenerateContentWithRetry(ctx context.Context, model llm.GenerativeModel, prompt string, i int, log *zap.Logger) (*llm.Response, error) {
+	args := m.Called(ctx, model, prompt, i, log)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*genai.GenerateContentResponse), args.Error(1)
+	return args.Get(0).(*llm.Response), args.Error(1)
 }
 
 // Mock data for embedded files
@@ -99,6 +102,30 @@ func TestTranslateQueriesToSpanner(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "Query from static analysis",
+			queries: []QueryTranslationInput{
+				{Query: "SELECT * FROM users WHERE id = ?", AssessmentSource: "static_analysis", SnippetId: "dao/user.go:12", NumberOfQueryOccurances: 2},
+			},
+			mockTaskResult: task.TaskResult[*QueryTranslationResult]{
+				Result: &QueryTranslationResult{
+					OriginalQuery:    "SELECT * FROM users WHERE id = ?",
+					SpannerQuery:     "SELECT * FROM users WHERE id = @p1",
+					AssessmentSource: "performance_schema",
+				},
+			},
+			mockTaskError: nil,
+			expectedResult: []QueryTranslationResult{
+				{
+					OriginalQuery:           "SELECT * FROM users WHERE id = ?",
+					SpannerQuery:            "SELECT * FROM users WHERE id = @p1",
+					AssessmentSource:        "static_analysis",
+					SnippetId:               "dao/user.go:12",
+					NumberOfQueryOccurances: 2,
+				},
+			},
+			expectedError: false,
+		},
 		{
 			name:           "No queries to translate",
 			queries:        []QueryTranslationInput{},
@@ -167,6 +194,40 @@ func TestTranslateQueriesToSpanner(t *testing.T) {
 	}
 }
 
+func TestTranslateQueriesToSpanner_OutOfOrderResults(t *testing.T) {
+	queries := []QueryTranslationInput{
+		{Query: "SELECT * FROM users", Count: 10, TotalLatencyMs: 100},
+		{Query: "SELECT * FROM orders WHERE id = ?", AssessmentSource: "static_analysis", SnippetId: "dao/order.go:7", NumberOfQueryOccurances: 3},
+	}
+	originalTranslateQueryTask := TranslateQueryTask
+	secondDone := make(chan struct{})
+	TranslateQueryTask = func(input *LLMQueryTranslationInput, mutex *sync.Mutex) task.TaskResult[*QueryTranslationResult] {
+		if input.MySQLQuery == queries[0].Query {
+			// Finish the first query after the second one.
+			<-secondDone
+			time.Sleep(10 * time.Millisecond)
+		} else {
+			defer close(secondDone)
+		}
+		return task.TaskResult[*QueryTranslationResult]{
+			Result: &QueryTranslationResult{
+				OriginalQuery:    input.MySQLQuery,
+				AssessmentSource: "performance_schema",
+				ExecutionCount:   input.Count,
+			},
+		}
+	}
+	defer func() { TranslateQueryTask = originalTranslateQueryTask }()
+
+	results, err := TranslateQueriesToSpanner(context.Background(), queries, nil, "", "")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []QueryTranslationResult{
+		{OriginalQuery: "SELECT * FROM users", AssessmentSource: "performance_schema", ExecutionCount: 10, TotalLatencyMs: 100},
+		{OriginalQuery: "SELECT * FROM orders WHERE id = ?", AssessmentSource: "static_analysis", SnippetId: "dao/order.go:7", NumberOfQueryOccurances: 3},
+	}, results)
+	assert.Equal(t, queries[1].Query, results[0].OriginalQuery)
+}
+
 func TestTranslateQueryTask(t *testing.T) {
 	ctx := context.Background()
 
@@ -176,7 +237,7 @@ func TestTranslateQueryTask(t *testing.T) {
 	tests := []struct {
 		name           string
 		input          *LLMQueryTranslationInput
-		mockResponse   *genai.GenerateContentResponse
+		mockResponse   *llm.Response
 		mockError      error
 		expectedResult *QueryTranslationResult
 		expectedError  bool
@@ -189,38 +250,33 @@ func TestTranslateQueryTask(t *testing.T) {
 				MySQLSchema:   "mysql_schema",
 				SpannerSchema: "spanner_schema",
 				Count:         10,
-				AIClient:      &genai.Client{},
+				LLMProvider:   &llm.FakeProvider{},
 			},
-			mockResponse: &genai.GenerateContentResponse{
-				Candidates: []*genai.Candidate{
-					{
-						Content: &genai.Content{
-							Parts: []genai.Part{genai.Text(`{"new_query": "SELECT * FROM users"}`)}, // Corrected JSON
-						},
-					},
-				},
+			mockResponse: &llm.Response{
+				Text: `{"new_query": "SELECT * FROM users"}`, // Corrected JSON
 			},
 			mockError: nil,
 			expectedResult: &QueryTranslationResult{
-				OriginalQuery:    "SELECT * FROM users",
-				SpannerQuery:     "SELECT * FROM users",
-				AssessmentSource: "performance_schema",
-				ExecutionCount:   10,
-				QueryType:        "SELECT",
+				OriginalQuery:     "SELECT * FROM users",
+				SpannerQuery:      "SELECT * FROM users",
+				AssessmentSource:  "performance_schema",
+				ExecutionCount:    10,
+				QueryType:         "SELECT",
+				TranslationSource: TranslationSourceLLM,
 			},
 			expectedError: false,
 		},
 		{
-			name: "AI Client not provided",
+			name: "LLM provider not provided",
 			input: &LLMQueryTranslationInput{
-				Context:    ctx,
-				MySQLQuery: "SELECT * FROM products",
-				Count:      5,
-				AIClient:   nil,
+				Context:     ctx,
+				MySQLQuery:  "SELECT * FROM products",
+				Count:       5,
+				LLMProvider: nil,
 			},
 			expectedResult: &QueryTranslationResult{
 				OriginalQuery:    "SELECT * FROM products",
-				TranslationError: "AI client not provided",
+				TranslationError: "LLM provider not provided",
 			},
 			expectedError: false,
 		},
@@ -232,7 +288,7 @@ func TestTranslateQueryTask(t *testing.T) {
 				MySQLSchema:   "mysql_schema",
 				SpannerSchema: "spanner_schema",
 				Count:         5,
-				AIClient:      &genai.Client{},
+				LLMProvider:   &llm.FakeProvider{},
 			},
 			mockError: errors.New("LLM API error"),
 			expectedResult: &QueryTranslationResult{
@@ -249,16 +305,10 @@ func TestTranslateQueryTask(t *testing.T) {
 				MySQLSchema:   "mysql_schema",
 				SpannerSchema: "spanner_schema",
 				Count:         15,
-				AIClient:      &genai.Client{},
+				LLMProvider:   &llm.FakeProvider{},
 			},
-			mockResponse: &genai.GenerateContentResponse{
-				Candidates: []*genai.Candidate{
-					{
-						Content: &genai.Content{
-							Parts: []genai.Part{genai.Text(`invalid json`)},
-						},
-					},
-				},
+			mockResponse: &llm.Response{
+				Text: `invalid json`,
 			},
 			mockError: nil,
 			expectedResult: &QueryTranslationResult{
@@ -279,7 +329,7 @@ func TestTranslateQueryTask(t *testing.T) {
 				tt.input.RetryClient = nil
 			}
 
-			if tt.input.AIClient != nil {
+			if tt.input.LLMProvider != nil {
 				tt.input.RetryClient = mockRetryClient
 			}
 
@@ -67,6 +67,16 @@ func (cmd *AssessmentCmd) Usage() string {
 Run an assessment on the existing source db and create a report on the complexity of 
 performing a migration to Spanner. The configuration of the assessment collectors is
 provided in the assessment-profile
+The LLM used for the app code and query assessment is selected with llmProvider=[vertexai|openai|fake]
+in the assessment-profile. The openai provider calls an OpenAI-compatible endpoint, e.g. a local vLLM
+or Ollama server, configured with llmEndpoint, llmModel, llmFlashModel, llmEmbeddingModel and llmApiKey
+(or the OPENAI_API_KEY environment variable).
+The queries of the Go, Java and MyBatis code of the codeDirectory are also extracted without the LLM,
+and assessed along with the ones of the performance schema.
+The cost assessment sizes a Spanner instance for the tables and query rates of the source, and prices it
+for each region configuration of a JSON pricing file set with pricingFile in the assessment-profile,
+or of default list prices. The query rates of the performance schema are averages over the uptime of the
+server; the instance is sized for them multiplied by peakToAverageRatio in the assessment-profile (default 1).
 The assessment flags are:
 `, path.Base(os.Args[0]))
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/proto/migration"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/google/subcommands"
 	"go.uber.org/zap"
@@ -52,7 +53,11 @@ type DataCmd struct {
 	logLevel         string
 	SkipForeignKeys  bool
 	validate         bool
-
+	chunksPerTable   int
+	readWorkers      int
+	resume           bool
+	writeMode        string
+	deadLetterUri    string
 }
 
 // Name returns the name of operation.
@@ -90,7 +95,11 @@ func (cmd *DataCmd) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
 	f.BoolVar(&cmd.SkipForeignKeys, "skip-foreign-keys", false, "Skip creating foreign keys after data migration is complete (ddl statements for foreign keys can still be found in the downloaded schema.ddl.txt file and the same can be applied separately)")
 	f.BoolVar(&cmd.validate, "validate", false, "Flag for validating if all the required input parameters are present")
-
+	f.IntVar(&cmd.chunksPerTable, "chunks-per-table", 1, "Number of primary key ranges each source table is split into for direct-connect data migration. Only tables with an integer leading primary key column are split")
+	f.IntVar(&cmd.readWorkers, "read-workers", common.DefaultWorkers, "Number of primary key ranges of a table that are read from the source database concurrently")
+	f.BoolVar(&cmd.resume, "resume", false, "Resume a data migration that failed partway through from its checkpoint file, skipping the tables and key ranges that were already written. Use with --write-mode=insert_or_update or replace, since partly written key ranges are read again")
+	f.StringVar(&cmd.writeMode, "write-mode", string(writer.WriteModeInsert), fmt.Sprintf("Kind of mutation used to write rows to Spanner. Valid values {%s, %s, %s}. Use %s or %s to safely re-run a load against a partly populated database", writer.WriteModeInsert, writer.WriteModeInsertOrUpdate, writer.WriteModeReplace, writer.WriteModeInsertOrUpdate, writer.WriteModeReplace))
+	f.StringVar(&cmd.deadLetterUri, "dead-letter-uri", "", "Local path or GCS URI of a file to write every rejected row to, with its Spanner table, columns, values and error. Optional. The file is CSV if it ends in .csv and JSONL otherwise, and can be imported again with the import command and --source-format=dead-letter once corrected")
 }
 
 func (cmd *DataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
@@ -125,6 +134,10 @@ func (cmd *DataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface
 			return subcommands.ExitUsageError
 		}
 	}
+	writeMode, err := writer.ParseWriteMode(cmd.writeMode)
+	if err != nil {
+		return subcommands.ExitUsageError
+	}
 	var (
 		bw     *writer.BatchWriter
 		banner string
@@ -155,6 +168,17 @@ func (cmd *DataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface
 		}
 	}
 
+	conv.DataReadOptions = internal.DataReadOptions{
+		ChunksPerTable: cmd.chunksPerTable,
+		Workers:        cmd.readWorkers,
+	}
+	conv.DataWriteMode = string(writeMode)
+	deadLetter, err := openDeadLetterSink(ctx, conv, cmd.deadLetterUri)
+	if err != nil {
+		return subcommands.ExitUsageError
+	}
+	defer closeDeadLetterSink(deadLetter)
+
 	var (
 		dbURI string
 	)
@@ -18,6 +18,7 @@ import (
         "flag"
         "testing"
 
+        "github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
         "github.com/stretchr/testify/assert"
 )
 
@@ -41,6 +42,9 @@ func TestDataSetFlags(t *testing.T) {
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
                                 validate:         false,
+                                chunksPerTable:   1,
+                                readWorkers:      common.DefaultWorkers,
+                                writeMode:        "insert",
                         },
                 },
                 {
@@ -57,6 +61,9 @@ func TestDataSetFlags(t *testing.T) {
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
                                 validate:         false,
+                                chunksPerTable:   1,
+                                readWorkers:      common.DefaultWorkers,
+                                writeMode:        "insert",
                         },
                 },
                 {
@@ -73,6 +80,9 @@ func TestDataSetFlags(t *testing.T) {
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
                                 validate:         false,
+                                chunksPerTable:   1,
+                                readWorkers:      common.DefaultWorkers,
+                                writeMode:        "insert",
                         },
                 },
                 {
@@ -89,6 +99,9 @@ func TestDataSetFlags(t *testing.T) {
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
                                 validate:         false,
+                                chunksPerTable:   1,
+                                readWorkers:      common.DefaultWorkers,
+                                writeMode:        "insert",
                         },
                 },
                 {
@@ -105,6 +118,9 @@ func TestDataSetFlags(t *testing.T) {
                                 logLevel:         "INFO",
                                 SkipForeignKeys:  false,
                                 validate:         false,
+                                chunksPerTable:   1,
+                                readWorkers:      common.DefaultWorkers,
+                                writeMode:        "insert",
                         },
                 },
                 {
@@ -121,6 +137,9 @@ func TestDataSetFlags(t *testing.T) {
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  true,
                                 validate:         true,
+                                chunksPerTable:   1,
+                                readWorkers:      common.DefaultWorkers,
+                                writeMode:        "insert",
                         },
                 },
                 {
@@ -136,6 +155,10 @@ func TestDataSetFlags(t *testing.T) {
                                 "--log-level=WARN",
                                 "--skip-foreign-keys",
                                 "--validate",
+                                "--chunks-per-table=8",
+                                "--read-workers=4",
+                                "--resume",
+                                "--write-mode=insert_or_update",
                         },
                         expectedValues: DataCmd{
                                 source:           "MySQL",
@@ -148,6 +171,10 @@ func TestDataSetFlags(t *testing.T) {
                                 logLevel:         "WARN",
                                 SkipForeignKeys:  true,
                                 validate:         true,
+                                chunksPerTable:   8,
+                                readWorkers:      4,
+                                resume:           true,
+                                writeMode:        "insert_or_update",
                         },
                 },
         }
@@ -31,12 +31,14 @@ import (
 	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/dead_letter"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/import_file"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/csv"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/google/subcommands"
 )
 
@@ -52,20 +54,32 @@ type ImportDataCmd struct {
 	project           string
 	databaseDialect   string
 	logLevel          string
+	writeMode         string
+	primaryKeys       string
+	fileWorkers       int
+	allowSchemaDrift  bool
+	deadLetterUri     string
+	sourceUris        []string              // Files matched by sourceUri.
+	deadLetter        *dead_letter.FileSink // Created from deadLetterUri, nil if not set.
 }
 
 func (cmd *ImportDataCmd) SetFlags(set *flag.FlagSet) {
 	set.StringVar(&cmd.instance, "instance", "", "Spanner instance Id")
 	set.StringVar(&cmd.database, "database", "", "Spanner database name. If one with the specified name does not exist, a new one will be created with the same")
 	set.StringVar(&cmd.tableName, "table-name", "", "Spanner table name. Optional. If not specified, source-uri name will be used")
-	set.StringVar(&cmd.sourceUri, "source-uri", "", "URI of the file to import")
-	set.StringVar(&cmd.sourceFormat, "source-format", "", fmt.Sprintf("Format of the file to import. Valid values {%s, %s, %s}", constants.MYSQLDUMP, constants.PGDUMP, constants.CSV))
+	set.StringVar(&cmd.sourceUri, "source-uri", "", fmt.Sprintf("URI of the file to import. For %s, %s and %s formats, a glob pattern such as gs://bucket/export/part-*.csv or a directory or GCS prefix ending in '/' imports every matching file into the table", constants.CSV, constants.PARQUET, constants.AVRO))
+	set.StringVar(&cmd.sourceFormat, "source-format", "", fmt.Sprintf("Format of the file to import. Valid values {%s, %s, %s, %s, %s, %s}", constants.MYSQLDUMP, constants.PGDUMP, constants.CSV, constants.PARQUET, constants.AVRO, constants.DEAD_LETTER))
 	set.StringVar(&cmd.schemaUri, "schema-uri", "", "URI of the file with schema for the csv to import. Only non-optional for csv format.")
 	set.StringVar(&cmd.csvLineDelimiter, "csv-line-delimiter", "\n", "Token to be used as line delimiter for csv format. Optional. Defaults to '\\n'. Only used for csv format.")
 	set.StringVar(&cmd.csvFieldDelimiter, "csv-field-delimiter", ",", "Token to be used as field delimiter for csv format. Optional. Defaults to ','. Only used for csv format.")
 	set.StringVar(&cmd.project, "project", "", "Project id for all resources related to this import. Optional")
 	set.StringVar(&cmd.databaseDialect, "database-dialect", constants.DIALECT_GOOGLESQL, fmt.Sprintf("Spanner database dialect. Defaults to %s. Valid values {%s, %s}", constants.DIALECT_GOOGLESQL, constants.DIALECT_GOOGLESQL, constants.DIALECT_POSTGRESQL))
 	set.StringVar(&cmd.logLevel, "log-level", "INFO", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
+	set.StringVar(&cmd.primaryKeys, "primary-keys", "", fmt.Sprintf("Comma separated primary key columns of the table created for %s and %s formats. Optional. If not specified, a synthetic primary key column is added. Ignored if the table exists.", constants.PARQUET, constants.AVRO))
+	set.BoolVar(&cmd.allowSchemaDrift, "allow-schema-drift", false, "Continue the csv import when the columns of an existing table differ from the schema file. Optional. Defaults to false, which fails the import")
+	set.IntVar(&cmd.fileWorkers, "file-workers", import_file.DefaultFileWorkers, "Number of files imported concurrently when source-uri matches several files. Optional")
+	set.StringVar(&cmd.deadLetterUri, "dead-letter-uri", "", "Local path or GCS URI of a file to write every rejected row to, with its Spanner table, columns, values and error. Optional. The file is CSV if it ends in .csv and JSONL otherwise, and can be imported again with --source-format=dead-letter once corrected")
+	set.StringVar(&cmd.writeMode, "write-mode", string(writer.WriteModeInsert), fmt.Sprintf("Kind of mutation used to write rows to Spanner. Optional. Defaults to %s. Valid values {%s, %s, %s}", writer.WriteModeInsert, writer.WriteModeInsert, writer.WriteModeInsertOrUpdate, writer.WriteModeReplace))
 }
 
 func (cmd *ImportDataCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
@@ -105,6 +119,13 @@ func (cmd *ImportDataCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...
 
 	defer sourceReader.Close()
 
+	cmd.deadLetter, err = cmd.createDeadLetterSink(ctx)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Input validation failed. Reason %v", err))
+		return subcommands.ExitFailure
+	}
+	defer closeDeadLetterSink(cmd.deadLetter)
+
 	switch cmd.sourceFormat {
 	case constants.CSV:
 		// schemaReader will only be valid if sourceFormat is CSV
@@ -115,6 +136,13 @@ func (cmd *ImportDataCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...
 			return subcommands.ExitFailure
 		}
 		return subcommands.ExitSuccess
+	case constants.PARQUET, constants.AVRO:
+		err := cmd.handleRecordFile(ctx, dbURI, dialect, spannerAccessor, sourceReader)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("Unable to handle %s file %v", cmd.sourceFormat, err))
+			return subcommands.ExitFailure
+		}
+		return subcommands.ExitSuccess
 	case constants.MYSQLDUMP, constants.PGDUMP:
 		err := cmd.handleDatabaseDumpFile(ctx, dbURI, cmd.sourceFormat, dialect, spannerAccessor, sourceReader)
 		if err != nil {
@@ -122,6 +150,13 @@ func (cmd *ImportDataCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...
 			return subcommands.ExitFailure
 		}
 		return subcommands.ExitSuccess
+	case constants.DEAD_LETTER:
+		err := cmd.handleDeadLetter(ctx, dbURI, dialect, sourceReader)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("Unable to replay dead-letter file %v", err))
+			return subcommands.ExitFailure
+		}
+		return subcommands.ExitSuccess
 	default:
 		logger.Log.Warn(fmt.Sprintf("format %s not supported yet", cmd.sourceFormat))
 	}
@@ -166,7 +201,17 @@ func validateSpannerAccessor(ctx context.Context, dbURI string) (spanneraccessor
 // validateUriRemote validate if source URI and schema URI are accessible. Return sourceReader, schemaReader, error.
 // If sourceFormat is not CSV, schemaReader will be nil.
 func validateUriRemote(ctx context.Context, input *ImportDataCmd) (file_reader.FileReader, file_reader.FileReader, error) {
-	sourceReader, err := file_reader.NewFileReader(ctx, input.sourceUri)
+	sourceUris, err := file_reader.ListFiles(ctx, input.sourceUri)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sourceUri:%v can't be listed: %v", input.sourceUri, err)
+	}
+	if len(sourceUris) > 1 && (input.sourceFormat == constants.MYSQLDUMP || input.sourceFormat == constants.PGDUMP || input.sourceFormat == constants.DEAD_LETTER) {
+		return nil, nil, fmt.Errorf("sourceUri:%v matches %d files, but %s format imports a single file", input.sourceUri, len(sourceUris), input.sourceFormat)
+	}
+	input.sourceUris = sourceUris
+
+	// The first file is used to create the schema of the table.
+	sourceReader, err := file_reader.NewFileReader(ctx, sourceUris[0])
 	if err != nil {
 		return nil, nil, fmt.Errorf("sourceUri:%v not accessible. Please check the input and access permissions and try again", input.sourceUri)
 	}
@@ -225,9 +270,63 @@ func validateInputLocal(input *ImportDataCmd) error {
 		return fmt.Errorf("Please specify schemaUri using the --schema-uri parameter. Received  schemaUri: %v", input.sourceFormat)
 	}
 
+	if _, err := writer.ParseWriteMode(input.writeMode); err != nil {
+		return fmt.Errorf("Please specify a valid writeMode using the --write-mode parameter: %v", err)
+	}
+
 	return err
 }
 
+// createDeadLetterSink creates the file of --dead-letter-uri, or returns nil
+// if the flag isn't set.
+func (cmd *ImportDataCmd) createDeadLetterSink(ctx context.Context) (*dead_letter.FileSink, error) {
+	if cmd.deadLetterUri == "" {
+		return nil, nil
+	}
+	if cmd.sourceFormat == constants.DEAD_LETTER && cmd.deadLetterUri == cmd.sourceUri {
+		return nil, fmt.Errorf("deadLetterUri:%v must differ from the replayed sourceUri", cmd.deadLetterUri)
+	}
+	return dead_letter.NewFileSink(ctx, cmd.deadLetterUri)
+}
+
+// closeDeadLetterSink closes the dead-letter file and reports how many rows
+// were written to it.
+func closeDeadLetterSink(sink *dead_letter.FileSink) {
+	if sink == nil {
+		return
+	}
+	if err := sink.Close(); err != nil {
+		logger.Log.Error(err.Error())
+		return
+	}
+	if n := sink.Rows(); n > 0 {
+		logger.Log.Warn(fmt.Sprintf("%d rejected rows were written to %s", n, sink.Uri()))
+	}
+}
+
+// openDeadLetterSink creates the file of the --dead-letter-uri flag of the data
+// and schema-and-data commands and sends the rows rejected by conv to it. It
+// returns nil if uri is empty.
+func openDeadLetterSink(ctx context.Context, conv *internal.Conv, uri string) (*dead_letter.FileSink, error) {
+	if uri == "" {
+		return nil, nil
+	}
+	sink, err := dead_letter.NewFileSink(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("can't create dead-letter file %s: %v", uri, err)
+	}
+	conv.DeadLetter = sink
+	return sink, nil
+}
+
+// setDeadLetter sends the rows rejected by conv to the dead-letter file, if
+// any.
+func (cmd *ImportDataCmd) setDeadLetter(conv *internal.Conv) {
+	if cmd.deadLetter != nil {
+		conv.DeadLetter = cmd.deadLetter
+	}
+}
+
 func (cmd *ImportDataCmd) handleCsv(ctx context.Context, dbURI, dialect string,
 	sp spanneraccessor.SpannerAccessor, sourceReader file_reader.FileReader, schemaReader file_reader.FileReader) error {
 
@@ -241,7 +340,7 @@ func (cmd *ImportDataCmd) handleCsv(ctx context.Context, dbURI, dialect string,
 
 	startTime := time.Now()
 	csvSchema := import_file.NewCsvSchema(cmd.project, cmd.instance,
-		cmd.database, cmd.tableName, cmd.schemaUri, schemaReader)
+		cmd.database, cmd.tableName, cmd.schemaUri, schemaReader, infoSchema, cmd.allowSchemaDrift)
 	err = csvSchema.CreateSchema(ctx, dialect, sp)
 
 	endTime1 := time.Now()
@@ -253,7 +352,18 @@ func (cmd *ImportDataCmd) handleCsv(ctx context.Context, dbURI, dialect string,
 
 	csvData := import_file.NewCsvData(cmd.project, cmd.instance,
 		cmd.database, cmd.tableName, cmd.sourceUri, cmd.csvFieldDelimiter, sourceReader)
-	err = csvData.ImportData(ctx, infoSchema, dialect, internal.MakeConv(), &common.InfoSchemaImpl{}, &csv.CsvImpl{})
+	conv := internal.MakeConv()
+	conv.DataWriteMode = cmd.writeMode
+	cmd.setDeadLetter(conv)
+	if len(cmd.sourceUris) > 1 {
+		var results []import_file.FileResult
+		results, err = csvData.ImportFiles(ctx, infoSchema, dialect, conv, &common.InfoSchemaImpl{}, &csv.CsvImpl{}, cmd.sourceUris, cmd.fileWorkers)
+		if err == nil {
+			err = reportFileResults(results)
+		}
+	} else {
+		err = csvData.ImportData(ctx, infoSchema, dialect, conv, &common.InfoSchemaImpl{}, &csv.CsvImpl{})
+	}
 
 	endTime2 := time.Now()
 	elapsedTime = endTime2.Sub(endTime1)
@@ -262,6 +372,99 @@ func (cmd *ImportDataCmd) handleCsv(ctx context.Context, dbURI, dialect string,
 
 }
 
+func (cmd *ImportDataCmd) handleRecordFile(ctx context.Context, dbURI, dialect string,
+	sp spanneraccessor.SpannerAccessor, sourceReader file_reader.FileReader) error {
+
+	cmd.tableName = handleTableNameDefaults(cmd.tableName, cmd.sourceUri)
+
+	infoSchema, err := spanner.NewInfoSchemaImplWithSpannerClient(ctx, dbURI, dialect)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to instantiate spanner client %v", err))
+		return err
+	}
+
+	startTime := time.Now()
+	recordFile := import_file.NewRecordFile(cmd.project, cmd.instance, cmd.database, cmd.tableName,
+		cmd.sourceFormat, parsePrimaryKeys(cmd.primaryKeys), sourceReader)
+	err = recordFile.CreateSchema(ctx, dialect, sp)
+
+	endTime1 := time.Now()
+	elapsedTime := endTime1.Sub(startTime)
+	logger.Log.Info(fmt.Sprintf("Schema creation took %f secs", elapsedTime.Seconds()))
+	if err != nil {
+		return err
+	}
+
+	conv := internal.MakeConv()
+	conv.DataWriteMode = cmd.writeMode
+	cmd.setDeadLetter(conv)
+	if len(cmd.sourceUris) > 1 {
+		var results []import_file.FileResult
+		results, err = recordFile.ImportFiles(ctx, infoSchema, dialect, conv, &common.InfoSchemaImpl{}, cmd.sourceUris, cmd.fileWorkers)
+		if err == nil {
+			err = reportFileResults(results)
+		}
+	} else {
+		err = recordFile.ImportData(ctx, infoSchema, dialect, conv, &common.InfoSchemaImpl{})
+	}
+
+	endTime2 := time.Now()
+	elapsedTime = endTime2.Sub(endTime1)
+	logger.Log.Info(fmt.Sprintf("Data import took %f secs", elapsedTime.Seconds()))
+	return err
+}
+
+func (cmd *ImportDataCmd) handleDeadLetter(ctx context.Context, dbURI, dialect string, sourceReader file_reader.FileReader) error {
+
+	infoSchema, err := spanner.NewInfoSchemaImplWithSpannerClient(ctx, dbURI, dialect)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to instantiate spanner client %v", err))
+		return err
+	}
+
+	startTime := time.Now()
+	replay := import_file.NewDeadLetterReplay(cmd.project, cmd.instance, cmd.database, cmd.sourceUri, sourceReader)
+	conv := internal.MakeConv()
+	conv.DataWriteMode = cmd.writeMode
+	cmd.setDeadLetter(conv)
+	err = replay.ImportData(ctx, infoSchema, dialect, conv, &common.InfoSchemaImpl{})
+
+	elapsedTime := time.Now().Sub(startTime)
+	logger.Log.Info(fmt.Sprintf("Data import took %f secs", elapsedTime.Seconds()))
+	return err
+}
+
+// reportFileResults logs the outcome of each imported file, and returns an
+// error if any of them failed.
+func reportFileResults(results []import_file.FileResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			logger.Log.Error(fmt.Sprintf("Failed to import %s after %d rows: %v", r.Uri, r.GoodRows, r.Err))
+		} else {
+			logger.Log.Info(fmt.Sprintf("Imported %s: %d rows, %d bad rows", r.Uri, r.GoodRows, r.BadRows))
+		}
+	}
+	logger.Log.Info(fmt.Sprintf("Imported %d of %d files", len(results)-failed, len(results)))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed to import", failed, len(results))
+	}
+	return nil
+}
+
+// parsePrimaryKeys splits the comma separated column names of the
+// --primary-keys flag.
+func parsePrimaryKeys(primaryKeys string) []string {
+	var keys []string
+	for _, k := range strings.Split(primaryKeys, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
 func getDBUri(projectId, instanceId, databaseName string) string {
 	return fmt.Sprintf("projects/%s/instances/%s/databases/%s", projectId, instanceId, databaseName)
 }
@@ -318,7 +521,28 @@ func (cmd *ImportDataCmd) Synopsis() string {
 func (cmd *ImportDataCmd) Usage() string {
 	return fmt.Sprintf(`%v import --instance-id=i1 --database-name=db1 --source-format=csv --source-uri=uri1 --schema-uri=uri2 ...
 
-Import data from supported source files to spanner
+Import data from supported source files to spanner. The schema of parquet and
+avro files is read from the file, and the table is created if it doesn't exist.
+
+For csv, parquet and avro formats, --source-uri can be a glob pattern or a
+directory or GCS prefix ending in '/'. The matching files are imported into the
+same table concurrently, and the outcome of each file is reported at the end.
+The schema of the table is created from the first matching file.
+
+If the table of a csv import already exists, its columns, types and primary key
+must match the schema file, or the import fails before writing any rows. Use
+--allow-schema-drift to import anyway.
+
+Use --dead-letter-uri to write every row that can't be converted or written to
+Spanner to a JSONL or CSV file, with its table, columns, values and error. Once
+corrected, the file can be replayed with --source-format=dead-letter, which
+writes each row to the existing table it names. Rows carry the Spanner names
+of their table and columns, and the values of BYTES columns are base64
+encoded.
+
+Files compressed with gzip, zstd or bzip2 are decompressed while reading. The
+compression is detected from the magic bytes of local files, and from the
+extension (.gz, .zst, .bz2) or content type of GCS objects.
 `, path.Base(os.Args[0]))
 
 }
@@ -342,6 +566,8 @@ func (cmd *ImportDataCmd) handleDatabaseDumpFile(ctx context.Context, dbUri, sou
 	elapsedTime := schemaEndTime.Sub(schemaStartTime)
 	logger.Log.Info(fmt.Sprintf("Schema creation took %f secs", elapsedTime.Seconds()))
 
+	conv.DataWriteMode = cmd.writeMode
+	cmd.setDeadLetter(conv)
 	err = importDump.ImportData(ctx, conv)
 
 	dataEndTime := time.Now()
@@ -18,6 +18,8 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -34,6 +36,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	sourcesspanner "github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/google/subcommands"
 	"github.com/stretchr/testify/assert"
 )
@@ -72,6 +75,11 @@ func TestImportDataCmd_SetFlags(t *testing.T) {
 	assert.NotNil(t, fs.Lookup("csv-line-delimiter"))
 	assert.NotNil(t, fs.Lookup("csv-field-delimiter"))
 	assert.NotNil(t, fs.Lookup("project"))
+	assert.NotNil(t, fs.Lookup("write-mode"))
+	assert.NotNil(t, fs.Lookup("primary-keys"))
+	assert.NotNil(t, fs.Lookup("file-workers"))
+	assert.NotNil(t, fs.Lookup("allow-schema-drift"))
+	assert.NotNil(t, fs.Lookup("dead-letter-uri"))
 }
 
 func TestValidateInputLocal_MissingInstanceID(t *testing.T) {
@@ -109,6 +117,13 @@ func TestValidateInputLocal_CSVMissingSchemaURI(t *testing.T) {
 	assert.Contains(t, err.Error(), "Please specify schemaUri")
 }
 
+func TestValidateInputLocal_InvalidWriteMode(t *testing.T) {
+	input := &ImportDataCmd{instance: "test-instance", database: "test-db", sourceUri: "gs://bucket/data.sql", sourceFormat: constants.MYSQLDUMP, writeMode: "upsert"}
+	err := validateInputLocal(input)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Please specify a valid writeMode")
+}
+
 func TestValidateInputLocal_SuccessCSV(t *testing.T) {
 	input := &ImportDataCmd{
 		instance:        "test-instance",
@@ -257,7 +272,7 @@ func TestImportDataCmd_HandleCsvExecute(t *testing.T) {
 		expectedError       error // Add expectedError
 		spannerAccessorMock func(ctx context.Context, dbURI string) (spanneraccessor.SpannerAccessor, error)
 		infoClientFunc      func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error)
-		csvSchemaFunc       func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader) import_file.CsvSchema
+		csvSchemaFunc       func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, allowSchemaDrift bool) import_file.CsvSchema
 		csvDataFunc         func(projectId, instanceId, dbName, tableName, sourceUri, csvFieldDelimiter string, sourceFileReader file_reader.FileReader) import_file.CsvData
 	}{
 		{
@@ -286,7 +301,7 @@ func TestImportDataCmd_HandleCsvExecute(t *testing.T) {
 			infoClientFunc: func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error) {
 				return &sourcesspanner.InfoSchemaImpl{}, nil
 			},
-			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader) import_file.CsvSchema {
+			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, allowSchemaDrift bool) import_file.CsvSchema {
 				return &import_file.MockCsvSchema{}
 			},
 			csvDataFunc: func(projectId, instanceId, dbName, tableName, sourceUri, csvFieldDelimiter string, sourceFileReader file_reader.FileReader) import_file.CsvData {
@@ -321,7 +336,7 @@ func TestImportDataCmd_HandleCsvExecute(t *testing.T) {
 			infoClientFunc: func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error) {
 				return &sourcesspanner.InfoSchemaImpl{}, nil
 			},
-			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader) import_file.CsvSchema {
+			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, allowSchemaDrift bool) import_file.CsvSchema {
 				return &import_file.MockCsvSchema{}
 			},
 			csvDataFunc: func(projectId, instanceId, dbName, tableName, sourceUri, csvFieldDelimiter string, sourceFileReader file_reader.FileReader) import_file.CsvData {
@@ -737,7 +752,7 @@ func TestHandleCsv(t *testing.T) {
 		desc           string
 		expectedErr    error
 		infoClientFunc func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error)
-		csvSchemaFunc  func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader) import_file.CsvSchema
+		csvSchemaFunc  func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, allowSchemaDrift bool) import_file.CsvSchema
 		csvDataFunc    func(projectId, instanceId, dbName, tableName, sourceUri, csvFieldDelimiter string, sourceFileReader file_reader.FileReader) import_file.CsvData
 	}{
 		{
@@ -748,12 +763,14 @@ func TestHandleCsv(t *testing.T) {
 				assert.Equal(t, expectedDialect, spDialect)
 				return &sourcesspanner.InfoSchemaImpl{}, nil
 			},
-			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader) import_file.CsvSchema {
+			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, allowSchemaDrift bool) import_file.CsvSchema {
 				assert.Equal(t, "test-project", projectId)
 				assert.Equal(t, "test-instance", instanceId)
 				assert.Equal(t, "test-db", dbName)
 				assert.Equal(t, "testtable", tableName)
 				assert.Equal(t, "gs://test-bucket/test_schema.json", schemaUri)
+				assert.NotNil(t, spannerInfoSchema)
+				assert.True(t, allowSchemaDrift)
 
 				return &import_file.MockCsvSchema{}
 			},
@@ -771,7 +788,7 @@ func TestHandleCsv(t *testing.T) {
 			infoClientFunc: func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error) {
 				return &sourcesspanner.InfoSchemaImpl{}, nil
 			},
-			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader) import_file.CsvSchema {
+			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, allowSchemaDrift bool) import_file.CsvSchema {
 				return &import_file.MockCsvSchema{
 					CreateSchemaFn: func(ctx context.Context, dialect string, sp spanneraccessor.SpannerAccessor) error {
 						return fmt.Errorf("schema creation error")
@@ -785,7 +802,7 @@ func TestHandleCsv(t *testing.T) {
 			infoClientFunc: func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error) {
 				return &sourcesspanner.InfoSchemaImpl{}, nil
 			},
-			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader) import_file.CsvSchema {
+			csvSchemaFunc: func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, allowSchemaDrift bool) import_file.CsvSchema {
 				return &import_file.MockCsvSchema{}
 			},
 			csvDataFunc: func(projectId, instanceId, dbName, tableName, sourceUri, csvFieldDelimiter string, sourceFileReader file_reader.FileReader) import_file.CsvData {
@@ -810,6 +827,7 @@ func TestHandleCsv(t *testing.T) {
 				sourceUri:         "gs://test-bucket/test.csv",
 				schemaUri:         "gs://test-bucket/test_schema.json",
 				csvFieldDelimiter: ",",
+				allowSchemaDrift:  true,
 			}
 			originalNewInfoSchemaFunc := sourcesspanner.NewInfoSchemaImplWithSpannerClient
 			originalNewCsvSchema := import_file.NewCsvSchema
@@ -839,6 +857,273 @@ func TestHandleCsv(t *testing.T) {
 	}
 }
 
+func TestHandleCsv_MultipleFiles(t *testing.T) {
+	uris := []string{"gs://test-bucket/export/part-00001.csv", "gs://test-bucket/export/part-00002.csv"}
+	testCases := []struct {
+		desc        string
+		results     []import_file.FileResult
+		importErr   error
+		expectedErr string
+	}{
+		{
+			desc:    "All files imported",
+			results: []import_file.FileResult{{Uri: uris[0], GoodRows: 10}, {Uri: uris[1], GoodRows: 5, BadRows: 1}},
+		},
+		{
+			desc:        "One file fails",
+			results:     []import_file.FileResult{{Uri: uris[0], GoodRows: 10}, {Uri: uris[1], Err: fmt.Errorf("read error")}},
+			expectedErr: "1 of 2 files failed to import",
+		},
+		{
+			desc:        "Import fails",
+			importErr:   fmt.Errorf("table not found"),
+			expectedErr: "table not found",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			cmd := &ImportDataCmd{
+				project:           "test-project",
+				instance:          "test-instance",
+				database:          "test-db",
+				sourceUri:         "gs://test-bucket/export/part-*.csv",
+				sourceUris:        uris,
+				schemaUri:         "gs://test-bucket/test_schema.json",
+				csvFieldDelimiter: ",",
+				fileWorkers:       8,
+			}
+			originalNewInfoSchemaFunc := sourcesspanner.NewInfoSchemaImplWithSpannerClient
+			originalNewCsvSchema := import_file.NewCsvSchema
+			originalNewCsvData := import_file.NewCsvData
+
+			defer func() {
+				sourcesspanner.NewInfoSchemaImplWithSpannerClient = originalNewInfoSchemaFunc
+				import_file.NewCsvSchema = originalNewCsvSchema
+				import_file.NewCsvData = originalNewCsvData
+			}()
+
+			sourcesspanner.NewInfoSchemaImplWithSpannerClient = func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error) {
+				return &sourcesspanner.InfoSchemaImpl{}, nil
+			}
+			import_file.NewCsvSchema = func(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, allowSchemaDrift bool) import_file.CsvSchema {
+				assert.Equal(t, "part", tableName)
+				return &import_file.MockCsvSchema{}
+			}
+			import_file.NewCsvData = func(projectId, instanceId, dbName, tableName, sourceUri, csvFieldDelimiter string, sourceFileReader file_reader.FileReader) import_file.CsvData {
+				return &import_file.MockCsvData{
+					ImportDataFn: func(ctx context.Context, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface) error {
+						t.Error("ImportData called for several files")
+						return nil
+					},
+					ImportFilesFn: func(ctx context.Context, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface, gotUris []string, workers int) ([]import_file.FileResult, error) {
+						assert.Equal(t, uris, gotUris)
+						assert.Equal(t, 8, workers)
+						return tC.results, tC.importErr
+					},
+				}
+			}
+
+			err := cmd.handleCsv(ctx, "projects/test-project/instances/test-instance/databases/test-db", constants.DIALECT_GOOGLESQL, &spanneraccessor.SpannerAccessorMock{}, &file_reader.GcsFileReaderImpl{}, &file_reader.LocalFileReaderImpl{})
+			if tC.expectedErr != "" {
+				assert.EqualError(t, err, tC.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateUriRemote_MultipleFiles(t *testing.T) {
+	ctx := context.Background()
+	cmd := &ImportDataCmd{sourceUri: "../test_data/mysql_*_dump.test.out", sourceFormat: constants.MYSQLDUMP}
+	_, _, err := validateUriRemote(ctx, cmd)
+	assert.ErrorContains(t, err, "but mysqldump format imports a single file")
+
+	cmd = &ImportDataCmd{sourceUri: "../test_data/*_csv.csv", sourceFormat: constants.CSV, schemaUri: "../test_data/basic_csv_schema.json"}
+	sourceReader, schemaReader, err := validateUriRemote(ctx, cmd)
+	assert.NoError(t, err)
+	defer sourceReader.Close()
+	defer schemaReader.Close()
+	assert.Equal(t, []string{"../test_data/basic_csv.csv"}, cmd.sourceUris)
+
+	cmd = &ImportDataCmd{sourceUri: "../test_data/*.parquet", sourceFormat: constants.PARQUET}
+	_, _, err = validateUriRemote(ctx, cmd)
+	assert.ErrorContains(t, err, "no files match")
+}
+
+func TestReportFileResults(t *testing.T) {
+	assert.NoError(t, reportFileResults([]import_file.FileResult{{Uri: "a.csv", GoodRows: 3}}))
+	err := reportFileResults([]import_file.FileResult{
+		{Uri: "a.csv", GoodRows: 3},
+		{Uri: "b.csv", Err: fmt.Errorf("read error")},
+		{Uri: "c.csv", Err: fmt.Errorf("read error")},
+	})
+	assert.EqualError(t, err, "2 of 3 files failed to import")
+}
+
+func TestHandleRecordFile(t *testing.T) {
+	expectedDbUri := "projects/test-project/instances/test-instance/databases/test-db"
+
+	testCases := []struct {
+		desc           string
+		expectedErr    error
+		sourceUris     []string
+		recordFileFunc func(projectId, instanceId, dbName, tableName, sourceFormat string, primaryKeys []string, sourceFileReader file_reader.FileReader) import_file.RecordFile
+	}{
+		{
+			desc: "Successful parquet import",
+			recordFileFunc: func(projectId, instanceId, dbName, tableName, sourceFormat string, primaryKeys []string, sourceFileReader file_reader.FileReader) import_file.RecordFile {
+				assert.Equal(t, "test-project", projectId)
+				assert.Equal(t, "test-instance", instanceId)
+				assert.Equal(t, "test-db", dbName)
+				assert.Equal(t, "events", tableName)
+				assert.Equal(t, constants.PARQUET, sourceFormat)
+				assert.Equal(t, []string{"id", "ts"}, primaryKeys)
+				return &import_file.MockRecordFile{}
+			},
+		},
+		{
+			desc: "Schema creation fails",
+			recordFileFunc: func(projectId, instanceId, dbName, tableName, sourceFormat string, primaryKeys []string, sourceFileReader file_reader.FileReader) import_file.RecordFile {
+				return &import_file.MockRecordFile{
+					CreateSchemaFn: func(ctx context.Context, dialect string, sp spanneraccessor.SpannerAccessor) error {
+						return fmt.Errorf("schema creation error")
+					},
+				}
+			},
+			expectedErr: fmt.Errorf("schema creation error"),
+		},
+		{
+			desc: "Data import fails",
+			recordFileFunc: func(projectId, instanceId, dbName, tableName, sourceFormat string, primaryKeys []string, sourceFileReader file_reader.FileReader) import_file.RecordFile {
+				return &import_file.MockRecordFile{
+					ImportDataFn: func(ctx context.Context, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error {
+						assert.Equal(t, string(writer.WriteModeInsertOrUpdate), conv.DataWriteMode)
+						return fmt.Errorf("data import error")
+					},
+				}
+			},
+			expectedErr: fmt.Errorf("data import error"),
+		},
+		{
+			desc:       "Several files imported",
+			sourceUris: []string{"gs://test-bucket/events.parquet", "gs://test-bucket/events2.parquet"},
+			recordFileFunc: func(projectId, instanceId, dbName, tableName, sourceFormat string, primaryKeys []string, sourceFileReader file_reader.FileReader) import_file.RecordFile {
+				return &import_file.MockRecordFile{
+					ImportFilesFn: func(ctx context.Context, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, uris []string, workers int) ([]import_file.FileResult, error) {
+						assert.Equal(t, []string{"gs://test-bucket/events.parquet", "gs://test-bucket/events2.parquet"}, uris)
+						assert.Equal(t, import_file.DefaultFileWorkers, workers)
+						return []import_file.FileResult{{Uri: uris[0]}, {Uri: uris[1], Err: fmt.Errorf("bad file")}}, nil
+					},
+				}
+			},
+			expectedErr: fmt.Errorf("1 of 2 files failed to import"),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			cmd := &ImportDataCmd{
+				project:      "test-project",
+				instance:     "test-instance",
+				database:     "test-db",
+				sourceUri:    "gs://test-bucket/events.parquet",
+				sourceUris:   tC.sourceUris,
+				sourceFormat: constants.PARQUET,
+				primaryKeys:  "id, ts",
+				writeMode:    string(writer.WriteModeInsertOrUpdate),
+				fileWorkers:  import_file.DefaultFileWorkers,
+			}
+			originalNewInfoSchemaFunc := sourcesspanner.NewInfoSchemaImplWithSpannerClient
+			originalNewRecordFile := import_file.NewRecordFile
+
+			defer func() {
+				sourcesspanner.NewInfoSchemaImplWithSpannerClient = originalNewInfoSchemaFunc
+				import_file.NewRecordFile = originalNewRecordFile
+			}()
+
+			sourcesspanner.NewInfoSchemaImplWithSpannerClient = func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error) {
+				assert.Equal(t, expectedDbUri, dbURI)
+				return &sourcesspanner.InfoSchemaImpl{}, nil
+			}
+			import_file.NewRecordFile = tC.recordFileFunc
+
+			err := cmd.handleRecordFile(ctx, expectedDbUri, constants.DIALECT_GOOGLESQL, &spanneraccessor.SpannerAccessorMock{}, &file_reader.GcsFileReaderImpl{})
+
+			if tC.expectedErr != nil {
+				assert.EqualError(t, err, tC.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHandleDeadLetter(t *testing.T) {
+	expectedDbUri := "projects/test-project/instances/test-instance/databases/test-db"
+	ctx := context.Background()
+	deadLetterUri := filepath.Join(t.TempDir(), "rejected-again.jsonl")
+	cmd := &ImportDataCmd{
+		project:       "test-project",
+		instance:      "test-instance",
+		database:      "test-db",
+		sourceUri:     "gs://test-bucket/rejected.jsonl",
+		sourceFormat:  constants.DEAD_LETTER,
+		writeMode:     string(writer.WriteModeInsertOrUpdate),
+		deadLetterUri: deadLetterUri,
+	}
+	var err error
+	cmd.deadLetter, err = cmd.createDeadLetterSink(ctx)
+	assert.NoError(t, err)
+
+	originalNewInfoSchemaFunc := sourcesspanner.NewInfoSchemaImplWithSpannerClient
+	originalNewDeadLetterReplay := import_file.NewDeadLetterReplay
+	defer func() {
+		sourcesspanner.NewInfoSchemaImplWithSpannerClient = originalNewInfoSchemaFunc
+		import_file.NewDeadLetterReplay = originalNewDeadLetterReplay
+	}()
+	sourcesspanner.NewInfoSchemaImplWithSpannerClient = func(ctx context.Context, dbURI string, spDialect string) (*sourcesspanner.InfoSchemaImpl, error) {
+		assert.Equal(t, expectedDbUri, dbURI)
+		return &sourcesspanner.InfoSchemaImpl{}, nil
+	}
+	import_file.NewDeadLetterReplay = func(projectId, instanceId, dbName, sourceUri string, sourceFileReader file_reader.FileReader) import_file.DeadLetterReplay {
+		assert.Equal(t, "gs://test-bucket/rejected.jsonl", sourceUri)
+		return &import_file.MockDeadLetterReplay{
+			ImportDataFn: func(ctx context.Context, spannerInfoSchema *sourcesspanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error {
+				assert.Equal(t, string(writer.WriteModeInsertOrUpdate), conv.DataWriteMode)
+				conv.CollectBadRow("t", []string{"a"}, []string{"x"}, fmt.Errorf("still bad"))
+				return nil
+			},
+		}
+	}
+
+	err = cmd.handleDeadLetter(ctx, expectedDbUri, constants.DIALECT_GOOGLESQL, &file_reader.GcsFileReaderImpl{})
+	assert.NoError(t, err)
+	closeDeadLetterSink(cmd.deadLetter)
+	content, err := os.ReadFile(deadLetterUri)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"error":"still bad"`)
+}
+
+func TestCreateDeadLetterSink(t *testing.T) {
+	ctx := context.Background()
+	sink, err := (&ImportDataCmd{}).createDeadLetterSink(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, sink)
+
+	_, err = (&ImportDataCmd{sourceFormat: constants.DEAD_LETTER, sourceUri: "rejected.jsonl", deadLetterUri: "rejected.jsonl"}).createDeadLetterSink(ctx)
+	assert.Error(t, err)
+}
+
+func TestParsePrimaryKeys(t *testing.T) {
+	assert.Nil(t, parsePrimaryKeys(""))
+	assert.Equal(t, []string{"id"}, parsePrimaryKeys("id"))
+	assert.Equal(t, []string{"a", "b"}, parsePrimaryKeys(" a, b ,"))
+}
+
 func fetchDDLString(conv *internal.Conv) string {
 	return strings.Replace(strings.Join(
 		ddl.GetDDL(
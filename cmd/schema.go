@@ -49,6 +49,7 @@ type SchemaCmd struct {
 	validate        bool
 	sessionJSON     string
 	sessionFileName string
+	customizations  string
 }
 
 // Name returns the name of operation.
@@ -85,6 +86,7 @@ func (cmd *SchemaCmd) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&cmd.validate, "validate", false, "Flag for validating if all the required input parameters are present")
 	f.StringVar(&cmd.sessionJSON, "session", "", "Optional. Specifies the file we restore session state from.")
 	f.StringVar(&cmd.sessionFileName, "session-file-name", "", "Optional. Specifies the name of the file we store session state in.")
+	f.StringVar(&cmd.customizations, "customizations", "", "Optional. Specifies a YAML or JSON file of schema customizations (rules and table edits) applied to the converted schema.")
 }
 
 func (cmd *SchemaCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
@@ -175,6 +177,12 @@ func (cmd *SchemaCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interfa
 		logger.Log.Error("Could not initialize conversion context from")
 		return subcommands.ExitFailure
 	}
+	if cmd.customizations != "" {
+		err = conversion.ApplyCustomizationsFile(conv, sourceProfile.Driver, cmd.customizations, ioHelper.Out)
+		if err != nil {
+			return subcommands.ExitFailure
+		}
+	}
 	conversion.WriteSchemaFile(conv, schemaConversionStartTime, cmd.filePrefix+schemaFile, ioHelper.Out, sourceProfile.Driver)
 
 	// We always write the session file to accommodate for a re-run that might change anything.
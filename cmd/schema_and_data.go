@@ -31,6 +31,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/proto/migration"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/google/subcommands"
 	"go.uber.org/zap"
@@ -49,8 +50,13 @@ type SchemaAndDataCmd struct {
 	dryRun           bool
 	logLevel         string
 	validate         bool
-
+	chunksPerTable   int
+	readWorkers      int
 	sessionFileName  string
+	resume           bool
+	writeMode        string
+	deadLetterUri    string
+	customizations   string
 }
 
 // Name returns the name of operation.
@@ -87,8 +93,13 @@ func (cmd *SchemaAndDataCmd) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&cmd.dryRun, "dry-run", false, "Flag for generating DDL and schema conversion report without creating a spanner database")
 	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
 	f.BoolVar(&cmd.validate, "validate", false, "Flag for validating if all the required input parameters are present")
-
+	f.IntVar(&cmd.chunksPerTable, "chunks-per-table", 1, "Number of primary key ranges each source table is split into for direct-connect data migration. Only tables with an integer leading primary key column are split")
+	f.IntVar(&cmd.readWorkers, "read-workers", common.DefaultWorkers, "Number of primary key ranges of a table that are read from the source database concurrently")
+	f.BoolVar(&cmd.resume, "resume", false, "Resume a data migration that failed partway through from its checkpoint file, skipping the tables and key ranges that were already written. Use with --write-mode=insert_or_update or replace, since partly written key ranges are read again")
+	f.StringVar(&cmd.writeMode, "write-mode", string(writer.WriteModeInsert), fmt.Sprintf("Kind of mutation used to write rows to Spanner. Valid values {%s, %s, %s}. Use %s or %s to safely re-run a load against a partly populated database", writer.WriteModeInsert, writer.WriteModeInsertOrUpdate, writer.WriteModeReplace, writer.WriteModeInsertOrUpdate, writer.WriteModeReplace))
+	f.StringVar(&cmd.deadLetterUri, "dead-letter-uri", "", "Local path or GCS URI of a file to write every rejected row to, with its Spanner table, columns, values and error. Optional. The file is CSV if it ends in .csv and JSONL otherwise, and can be imported again with the import command and --source-format=dead-letter once corrected")
 	f.StringVar(&cmd.sessionFileName, "session-file-name", "", "Optional. Specifies the name of the file we store session state in.")
+	f.StringVar(&cmd.customizations, "customizations", "", "Optional. Specifies a YAML or JSON file of schema customizations (rules and table edits) applied to the converted schema.")
 }
 
 func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
@@ -121,6 +132,10 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 			return subcommands.ExitUsageError
 		}
 	}
+	writeMode, err := writer.ParseWriteMode(cmd.writeMode)
+	if err != nil {
+		return subcommands.ExitUsageError
+	}
 	if cmd.validate {
 		return subcommands.ExitSuccess
 	}
@@ -165,6 +180,22 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 	conv.Audit.MigrationRequestId, _ = utils.GenerateName("smt-job")
 	conv.Audit.MigrationRequestId = strings.Replace(conv.Audit.MigrationRequestId, "_", "-", -1)
 	conv.Audit.MigrationType = migration.MigrationData_SCHEMA_AND_DATA.Enum()
+	conv.DataReadOptions = internal.DataReadOptions{
+		ChunksPerTable: cmd.chunksPerTable,
+		Workers:        cmd.readWorkers,
+	}
+	conv.DataWriteMode = string(writeMode)
+	deadLetter, err := openDeadLetterSink(ctx, conv, cmd.deadLetterUri)
+	if err != nil {
+		return subcommands.ExitUsageError
+	}
+	defer closeDeadLetterSink(deadLetter)
+	if cmd.customizations != "" {
+		err = conversion.ApplyCustomizationsFile(conv, sourceProfile.Driver, cmd.customizations, ioHelper.Out)
+		if err != nil {
+			return subcommands.ExitFailure
+		}
+	}
 
 	conversion.WriteSchemaFile(conv, schemaConversionStartTime, cmd.filePrefix+schemaFile, ioHelper.Out, sourceProfile.Driver)
 	sessionFileName := GetSessionFileName(cmd.sessionFileName, cmd.filePrefix)
@@ -18,6 +18,7 @@ import (
 	"flag"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,6 +42,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
 				validate:         false,
+				chunksPerTable:   1,
+				readWorkers:      common.DefaultWorkers,
+				writeMode:        "insert",
 				sessionFileName:  "",
 			},
 		},
@@ -58,6 +62,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
 				validate:         false,
+				chunksPerTable:   1,
+				readWorkers:      common.DefaultWorkers,
+				writeMode:        "insert",
 				sessionFileName:  "",
 			},
 		},
@@ -75,6 +82,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
 				validate:         false,
+				chunksPerTable:   1,
+				readWorkers:      common.DefaultWorkers,
+				writeMode:        "insert",
 				sessionFileName:  "",
 			},
 		},
@@ -92,6 +102,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
 				validate:         false,
+				chunksPerTable:   1,
+				readWorkers:      common.DefaultWorkers,
+				writeMode:        "insert",
 				sessionFileName:  "",
 			},
 		},
@@ -109,6 +122,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				logLevel:         "INFO",
 				SkipForeignKeys:  false,
 				validate:         false,
+				chunksPerTable:   1,
+				readWorkers:      common.DefaultWorkers,
+				writeMode:        "insert",
 				sessionFileName:  "",
 			},
 		},
@@ -126,6 +142,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  true,
 				validate:         true,
+				chunksPerTable:   1,
+				readWorkers:      common.DefaultWorkers,
+				writeMode:        "insert",
 				sessionFileName:  "",
 			},
 		},
@@ -143,6 +162,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
 				validate:         false,
+				chunksPerTable:   1,
+				readWorkers:      common.DefaultWorkers,
+				writeMode:        "insert",
 				sessionFileName:  "migration_session.json",
 			},
 		},
@@ -160,6 +182,11 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				"--skip-foreign-keys",
 				"--validate",
 				"--session-file-name=my_session_file",
+				"--chunks-per-table=8",
+				"--read-workers=4",
+				"--resume",
+				"--write-mode=insert_or_update",
+				"--customizations=customizations.yaml",
 			},
 			expectedValues: SchemaAndDataCmd{
 				source:           "MySQL",
@@ -172,7 +199,12 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				logLevel:         "WARN",
 				SkipForeignKeys:  true,
 				validate:         true,
+				chunksPerTable:   8,
+				readWorkers:      4,
+				resume:           true,
+				writeMode:        "insert_or_update",
 				sessionFileName:  "my_session_file",
+				customizations:   "customizations.yaml",
 			},
 		},
 	}
@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/conversion"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/subcommands"
+	"go.uber.org/zap"
+)
+
+// DefaultSchemaDiffBatchSize is the default number of statements applied per
+// UpdateDatabaseDdl request by the schema-diff command.
+const DefaultSchemaDiffBatchSize = 10
+
+// SchemaDiffCmd struct with flags.
+type SchemaDiffCmd struct {
+	sessionJSON   string
+	targetProfile string
+	apply         bool
+	allowDrops    bool
+	batchSize     int
+	logLevel      string
+}
+
+// Name returns the name of operation.
+func (cmd *SchemaDiffCmd) Name() string {
+	return "schema-diff"
+}
+
+// Synopsis returns summary of operation.
+func (cmd *SchemaDiffCmd) Synopsis() string {
+	return "compute and apply the DDL that evolves an existing Spanner database to the schema of a session file"
+}
+
+// Usage returns usage info of the command.
+func (cmd *SchemaDiffCmd) Usage() string {
+	return fmt.Sprintf(`%v schema-diff -session=[session_file] -target-profile="instance=my-instance,dbName=my-db" [-apply]...
+
+Compare the schema of an existing Spanner database with the Spanner schema of
+a session file, and print the ordered ALTER, CREATE and DROP statements that
+evolve the database to it. Tables, columns and indexes are matched by name.
+Changes that need a table to be recreated, such as a changed primary key or
+an added NOT NULL column without a default value, are reported and skipped.
+Views and change streams aren't compared. By default the plan is only printed; with -apply it is
+run against the database in batches. Statements that drop tables or columns
+are only run with -allow-drops. The schema-diff flags are:
+`, path.Base(os.Args[0]))
+}
+
+// SetFlags sets the flags.
+func (cmd *SchemaDiffCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&cmd.sessionJSON, "session", "", "Specifies the session file with the target Spanner schema")
+	f.StringVar(&cmd.targetProfile, "target-profile", "", "Flag for specifying connection profile for the existing Spanner database e.g., \"instance=my-instance,dbName=my-db\"")
+	f.BoolVar(&cmd.apply, "apply", false, "Run the statements against the database. Without it, the plan is only printed")
+	f.BoolVar(&cmd.allowDrops, "allow-drops", false, "Include the statements that drop tables or columns, and their data, in the plan")
+	f.IntVar(&cmd.batchSize, "batch-size", DefaultSchemaDiffBatchSize, "Maximum number of statements applied per schema update")
+	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
+}
+
+func (cmd *SchemaDiffCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	var err error
+	defer func() {
+		if err != nil {
+			logger.Log.Fatal("FATAL error", zap.Error(err))
+		}
+	}()
+	err = logger.InitializeLogger(cmd.logLevel)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("Error initialising logger, did you specify a valid log-level? [DEBUG, INFO, WARN, ERROR, FATAL]", err))
+		return subcommands.ExitFailure
+	}
+	defer logger.Log.Sync()
+
+	if cmd.sessionJSON == "" {
+		err = fmt.Errorf("cannot leave --session flag empty, please specify session file path e.g., --session=./session.json etc")
+		return subcommands.ExitUsageError
+	}
+	if cmd.batchSize <= 0 {
+		err = fmt.Errorf("--batch-size must be positive, got %d", cmd.batchSize)
+		return subcommands.ExitUsageError
+	}
+	targetProfile, err := profiles.NewTargetProfile(cmd.targetProfile, false)
+	if err != nil {
+		return subcommands.ExitUsageError
+	}
+	if targetProfile.Conn.Sp.Dbname == "" {
+		err = fmt.Errorf("please specify the existing Spanner database using the dbName param in --target-profile")
+		return subcommands.ExitUsageError
+	}
+
+	conv := internal.MakeConv()
+	err = conversion.ReadSessionFile(conv, cmd.sessionJSON)
+	if err != nil {
+		return subcommands.ExitUsageError
+	}
+
+	project, instance, dbName, err := targetProfile.GetResourceIds(ctx, time.Now(), conv.Source, os.Stdout, &utils.GetUtilInfoImpl{})
+	if err != nil {
+		return subcommands.ExitFailure
+	}
+	dbURI := fmt.Sprintf("projects/%s/instances/%s/databases/%s", project, instance, dbName)
+	spannerAccessor, err := spanneraccessor.NewSpannerAccessorClientImpl(ctx)
+	if err != nil {
+		return subcommands.ExitFailure
+	}
+	if exists, checkErr := spannerAccessor.CheckExistingDb(ctx, dbURI); checkErr != nil || !exists {
+		err = fmt.Errorf("database %s doesn't exist, use the schema command to create it: %v", dbURI, checkErr)
+		return subcommands.ExitFailure
+	}
+	dialect, err := spannerAccessor.GetDatabaseDialect(ctx, dbURI)
+	if err != nil {
+		return subcommands.ExitFailure
+	}
+	if conv.SpDialect != "" && conv.SpDialect != dialect {
+		err = fmt.Errorf("dialect of the session file (%s) is different from the dialect of database %s (%s)", conv.SpDialect, dbURI, dialect)
+		return subcommands.ExitUsageError
+	}
+
+	liveConv := internal.MakeConv()
+	liveConv.SpDialect = dialect
+	liveConv.SpProjectId = project
+	liveConv.SpInstanceId = instance
+	infoSchema, err := spanner.NewInfoSchemaImplWithSpannerClient(ctx, dbURI, dialect)
+	if err != nil {
+		return subcommands.ExitFailure
+	}
+	logger.Log.Info(fmt.Sprintf("Reading the schema of db %s", dbURI))
+	err = infoSchema.PopulateSpannerSchema(ctx, liveConv, &common.InfoSchemaImpl{})
+	if err != nil {
+		return subcommands.ExitFailure
+	}
+
+	diff := ddl.DiffSchemas(ddl.Config{ProtectIds: true, SpDialect: dialect, Source: conv.Source}, liveConv.SpSchema, conv.SpSchema)
+	fmt.Fprintf(os.Stdout, "\nSchema changes for db %s:\n\n", dbURI)
+	statements := writeSchemaDiffPlan(os.Stdout, diff, cmd.allowDrops)
+	if !cmd.apply || len(statements) == 0 {
+		return subcommands.ExitSuccess
+	}
+	logger.Log.Info(fmt.Sprintf("Applying %d statements to db %s in batches of %d", len(statements), dbURI, cmd.batchSize))
+	err = spannerAccessor.UpdateDatabaseDdlInBatches(ctx, dbURI, statements, cmd.batchSize)
+	if err != nil {
+		return subcommands.ExitFailure
+	}
+	fmt.Fprintf(os.Stdout, "Applied %d statements to db %s\n", len(statements), dbURI)
+	return subcommands.ExitSuccess
+}
+
+// writeSchemaDiffPlan writes the statements of the diff to w, followed by the
+// differences that can't be applied, and returns the statements to apply.
+// Destructive statements are written as comments and left out unless
+// allowDrops is set.
+func writeSchemaDiffPlan(w io.Writer, diff ddl.SchemaDiff, allowDrops bool) []string {
+	var statements []string
+	for _, change := range diff.Changes {
+		if change.Destructive && !allowDrops {
+			fmt.Fprintf(w, "-- Skipped, use --allow-drops to include: %s;\n", change.Statement)
+			continue
+		}
+		fmt.Fprintf(w, "%s;\n", change.Statement)
+		statements = append(statements, change.Statement)
+	}
+	if len(diff.Changes) == 0 {
+		fmt.Fprintln(w, "-- The database schema is up to date.")
+	}
+	for _, u := range diff.Unsupported {
+		fmt.Fprintf(w, "-- Unsupported, the table must be recreated: %s\n", u)
+	}
+	return statements
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaDiffSetFlags(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		flagArgs       []string
+		expectedValues SchemaDiffCmd
+	}{
+		{
+			testName: "Default Values",
+			flagArgs: []string{},
+			expectedValues: SchemaDiffCmd{
+				batchSize: DefaultSchemaDiffBatchSize,
+				logLevel:  "DEBUG",
+			},
+		},
+		{
+			testName: "Set all flags",
+			flagArgs: []string{"--session=session.json", "--target-profile=instance=i,dbName=db", "--apply", "--allow-drops", "--batch-size=5", "--log-level=INFO"},
+			expectedValues: SchemaDiffCmd{
+				sessionJSON:   "session.json",
+				targetProfile: "instance=i,dbName=db",
+				apply:         true,
+				allowDrops:    true,
+				batchSize:     5,
+				logLevel:      "INFO",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			fs := flag.NewFlagSet("testSetFlags", flag.ContinueOnError)
+			schemaDiffCmd := SchemaDiffCmd{}
+			schemaDiffCmd.SetFlags(fs)
+			err := fs.Parse(tc.flagArgs)
+			if err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			assert.Equal(t, tc.expectedValues, schemaDiffCmd, tc.testName)
+		})
+	}
+}
+
+func TestWriteSchemaDiffPlan(t *testing.T) {
+	diff := ddl.SchemaDiff{
+		Changes: []ddl.SchemaChange{
+			{Statement: "DROP INDEX users_by_name"},
+			{Statement: "ALTER TABLE users DROP COLUMN legacy", Destructive: true},
+			{Statement: "CREATE INDEX users_by_name ON users (name)"},
+		},
+		Unsupported: []string{"table orders: primary key changes from (id) to (user_id, id)"},
+	}
+	testCases := []struct {
+		name               string
+		allowDrops         bool
+		expectedPlan       string
+		expectedStatements []string
+	}{
+		{
+			name:       "Drops skipped",
+			allowDrops: false,
+			expectedPlan: "DROP INDEX users_by_name;\n" +
+				"-- Skipped, use --allow-drops to include: ALTER TABLE users DROP COLUMN legacy;\n" +
+				"CREATE INDEX users_by_name ON users (name);\n" +
+				"-- Unsupported, the table must be recreated: table orders: primary key changes from (id) to (user_id, id)\n",
+			expectedStatements: []string{"DROP INDEX users_by_name", "CREATE INDEX users_by_name ON users (name)"},
+		},
+		{
+			name:       "Drops allowed",
+			allowDrops: true,
+			expectedPlan: "DROP INDEX users_by_name;\n" +
+				"ALTER TABLE users DROP COLUMN legacy;\n" +
+				"CREATE INDEX users_by_name ON users (name);\n" +
+				"-- Unsupported, the table must be recreated: table orders: primary key changes from (id) to (user_id, id)\n",
+			expectedStatements: []string{"DROP INDEX users_by_name", "ALTER TABLE users DROP COLUMN legacy", "CREATE INDEX users_by_name ON users (name)"},
+		},
+	}
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		statements := writeSchemaDiffPlan(&buf, diff, tc.allowDrops)
+		assert.Equal(t, tc.expectedPlan, buf.String(), tc.name)
+		assert.Equal(t, tc.expectedStatements, statements, tc.name)
+	}
+
+	var buf bytes.Buffer
+	assert.Empty(t, writeSchemaDiffPlan(&buf, ddl.SchemaDiff{}, false))
+	assert.Equal(t, "-- The database schema is up to date.\n", buf.String())
+}
@@ -143,6 +143,7 @@ func TestSchemaSetFlags(t *testing.T) {
 				"--validate",
 				"--session=restored-session.json",
 				"--session-file-name=my-session.json",
+				"--customizations=customizations.yaml",
 			},
 			expectedValues: SchemaCmd{
 				source:          "MySQL",
@@ -156,6 +157,7 @@ func TestSchemaSetFlags(t *testing.T) {
 				validate:        true,
 				sessionJSON:     "restored-session.json",
 				sessionFileName: "my-session.json",
+				customizations:  "customizations.yaml",
 			},
 		},
 	}
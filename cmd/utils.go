@@ -214,8 +214,10 @@ func migrateData(ctx context.Context, migrationProjectId string, targetProfile p
 		}
 		logger.Log.Info(fmt.Sprintf("Schema validated successfully for data migration for db %s\n", dbURI))
 	}
-
-
+	err = loadCheckpoint(conv, sourceProfile, cmd.sessionJSON, dbURI, cmd.resume)
+	if err != nil {
+		return nil, err
+	}
 
 	c := &conversion.ConvImpl{}
 	bw, err = c.DataConv(ctx, migrationProjectId, sourceProfile, targetProfile, ioHelper, client, conv, true, cmd.WriteLimit, &conversion.DataFromSourceImpl{})
@@ -241,20 +243,30 @@ func migrateSchemaAndData(ctx context.Context, migrationProjectId string, target
 	if err != nil {
 		return nil, err
 	}
-	tablesExistingOnSpanner, err := spA.GetTableNamesFromSpanner(ctx, conv.SpDialect, dbURI, client)
+	err = loadCheckpoint(conv, sourceProfile, GetSessionFileName(cmd.sessionFileName, cmd.filePrefix), dbURI, cmd.resume)
 	if err != nil {
 		return nil, err
 	}
-	err = spA.CreateOrUpdateDatabase(ctx, dbURI, sourceProfile.Driver, conv, sourceProfile.Config.ConfigType, tablesExistingOnSpanner)
-	if err != nil {
-		err = fmt.Errorf("can't create/update database: %v", err)
-		return nil, err
+	if conv.Checkpoint != nil && conv.Checkpoint.SchemaCreated {
+		// The schema was created by the run being resumed.
+		logger.Log.Info(fmt.Sprintf("Skipping schema creation for db %s, resuming data migration\n", dbURI))
+	} else {
+		tablesExistingOnSpanner, err := spA.GetTableNamesFromSpanner(ctx, conv.SpDialect, dbURI, client)
+		if err != nil {
+			return nil, err
+		}
+		err = spA.CreateOrUpdateDatabase(ctx, dbURI, sourceProfile.Driver, conv, sourceProfile.Config.ConfigType, tablesExistingOnSpanner)
+		if err != nil {
+			err = fmt.Errorf("can't create/update database: %v", err)
+			return nil, err
+		}
+		if err = conv.Checkpoint.MarkSchemaCreated(); err != nil {
+			return nil, err
+		}
 	}
 	metricsPopulation(ctx, sourceProfile.Driver, conv)
 	conv.Audit.Progress.UpdateProgress("Schema migration complete.", completionPercentage, internal.SchemaMigrationComplete)
 
-
-
 	convImpl := &conversion.ConvImpl{}
 	bw, err := convImpl.DataConv(ctx, migrationProjectId, sourceProfile, targetProfile, ioHelper, client, conv, true, cmd.WriteLimit, &conversion.DataFromSourceImpl{})
 
@@ -270,4 +282,29 @@ func migrateSchemaAndData(ctx context.Context, migrationProjectId string, target
 	return bw, nil
 }
 
-
+// loadCheckpoint sets up the checkpoint file of a data migration next to the
+// session file, and restores the progress recorded in it when resume is set.
+// Checkpoints are only kept when data is read from a source database, either
+// directly or through a bulk sharded config. Key ranges that were partly
+// written before the interruption are read again, so a resumed run fails on
+// the rows that already exist unless it writes with insert_or_update or
+// replace.
+func loadCheckpoint(conv *internal.Conv, sourceProfile profiles.SourceProfile, sessionFile, dbURI string, resume bool) error {
+	if sourceProfile.Ty != profiles.SourceProfileTypeConnection &&
+		!(sourceProfile.Ty == profiles.SourceProfileTypeConfig && sourceProfile.Config.ConfigType == constants.BULK_MIGRATION) {
+		if resume {
+			logger.Log.Warn("--resume is only supported when migrating data from a source database connection, all data will be migrated")
+		}
+		return nil
+	}
+	if resume && (conv.DataWriteMode == "" || conv.DataWriteMode == string(writer.WriteModeInsert)) {
+		logger.Log.Warn(fmt.Sprintf("--resume re-reads the key ranges that were partly written, use --write-mode=%s or %s to avoid 'AlreadyExists' errors on their rows", writer.WriteModeInsertOrUpdate, writer.WriteModeReplace))
+	}
+	cp, err := internal.LoadCheckpoint(internal.GetCheckpointFileName(sessionFile), dbURI, resume)
+	if err != nil {
+		return fmt.Errorf("can't load checkpoint: %v", err)
+	}
+	logger.Log.Info(fmt.Sprintf("Recording data migration progress in %s\n", cp.Path()))
+	conv.Checkpoint = cp
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/conversion"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/validation"
+	"github.com/google/subcommands"
+	"go.uber.org/zap"
+)
+
+// ValidateCmd struct with flags.
+type ValidateCmd struct {
+	source        string
+	sourceProfile string
+	target        string
+	targetProfile string
+	sessionJSON   string
+	project       string
+	logLevel      string
+	keyRanges     int
+	sampleSize    int
+}
+
+// Name returns the name of operation.
+func (cmd *ValidateCmd) Name() string {
+	return "validate"
+}
+
+// Synopsis returns summary of operation.
+func (cmd *ValidateCmd) Synopsis() string {
+	return "compare the data migrated to Spanner with the source db"
+}
+
+// Usage returns usage info of the command.
+func (cmd *ValidateCmd) Usage() string {
+	return fmt.Sprintf(`%v validate -session=[session_file] -source=[source] -source-profile="..." -target-profile="instance=my-instance,dbName=my-db"...
+
+Compare the data of a migrated Spanner database with its source db. Source
+rows are read and converted using the schema mapping in the session file, and
+compared with the rows in Spanner. Row counts and checksums are compared per
+primary key range, and a per-table report listing sample primary keys of
+mismatched rows is printed. Only direct connections to the source db are
+supported. The validate flags are:
+`, path.Base(os.Args[0]))
+}
+
+// SetFlags sets the flags.
+func (cmd *ValidateCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&cmd.source, "source", "", "Flag for specifying source DB, (e.g., `PostgreSQL`, `MySQL`, `Cassandra`)")
+	f.StringVar(&cmd.sourceProfile, "source-profile", "", "Flag for specifying connection profile for source database e.g., \"host=localhost,user=root,dbName=db\"")
+	f.StringVar(&cmd.sessionJSON, "session", "", "Specifies the session file the data was migrated with")
+	f.StringVar(&cmd.target, "target", "Spanner", "Specifies the target DB, defaults to Spanner (accepted values: `Spanner`)")
+	f.StringVar(&cmd.targetProfile, "target-profile", "", "Flag for specifying connection profile for the migrated Spanner database e.g., \"instance=my-instance,dbName=my-db\"")
+	f.StringVar(&cmd.project, "project", "", "Flag spcifying default project id for all the generated resources for the migration")
+	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
+	f.IntVar(&cmd.keyRanges, "key-ranges", validation.DefaultRanges, "Number of primary key ranges each table is compared in. Only tables with an integer leading primary key column are split")
+	f.IntVar(&cmd.sampleSize, "sample-size", validation.DefaultSampleSize, "Maximum number of mismatched primary keys reported per table")
+}
+
+func (cmd *ValidateCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	var err error
+	defer func() {
+		if err != nil {
+			logger.Log.Fatal("FATAL error", zap.Error(err))
+		}
+	}()
+	err = logger.InitializeLogger(cmd.logLevel)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("Error initialising logger, did you specify a valid log-level? [DEBUG, INFO, WARN, ERROR, FATAL]", err))
+		return subcommands.ExitFailure
+	}
+	defer logger.Log.Sync()
+
+	if cmd.sessionJSON == "" {
+		err = fmt.Errorf("cannot leave --session flag empty, please specify session file path e.g., --session=./session.json etc")
+		return subcommands.ExitUsageError
+	}
+	sourceProfile, targetProfile, ioHelper, _, err := PrepareMigrationPrerequisites(cmd.sourceProfile, cmd.targetProfile, cmd.source, false)
+	if err != nil {
+		err = fmt.Errorf("error while preparing prerequisites for validation: %v", err)
+		return subcommands.ExitUsageError
+	}
+	if sourceProfile.Ty != profiles.SourceProfileTypeConnection && sourceProfile.Ty != profiles.SourceProfileTypeCloudSQL {
+		err = fmt.Errorf("validate only supports a direct connection to the source database, please specify its connection details in --source-profile")
+		return subcommands.ExitUsageError
+	}
+	if targetProfile.Conn.Sp.Dbname == "" {
+		err = fmt.Errorf("please specify the migrated Spanner database using the dbName param in --target-profile")
+		return subcommands.ExitUsageError
+	}
+	if cmd.project == "" {
+		getInfo := &utils.GetUtilInfoImpl{}
+		cmd.project, err = getInfo.GetProject()
+		if err != nil {
+			logger.Log.Error("Could not get project id from gcloud environment or --project flag. Either pass the projectId in the --project flag or configure in gcloud CLI using gcloud config set", zap.Error(err))
+			return subcommands.ExitUsageError
+		}
+	}
+
+	conv := internal.MakeConv()
+	err = conversion.ReadSessionFile(conv, cmd.sessionJSON)
+	if err != nil {
+		return subcommands.ExitUsageError
+	}
+	conv.DataReadOptions = internal.DataReadOptions{ChunksPerTable: 1, Workers: common.DefaultWorkers}
+
+	project, instance, dbName, err := targetProfile.GetResourceIds(ctx, time.Now(), sourceProfile.Driver, ioHelper.Out, &utils.GetUtilInfoImpl{})
+	if err != nil {
+		return subcommands.ExitFailure
+	}
+	dbURI := fmt.Sprintf("projects/%s/instances/%s/databases/%s", project, instance, dbName)
+	client, err := spannerclient.NewSpannerClientImpl(ctx, dbURI)
+	if err != nil {
+		err = fmt.Errorf("can't create client for db %s: %v", dbURI, err)
+		return subcommands.ExitFailure
+	}
+
+	gi := &conversion.GetInfoImpl{}
+	var infoSchema common.InfoSchema
+	if sourceProfile.Ty == profiles.SourceProfileTypeCloudSQL {
+		infoSchema, err = gi.GetInfoSchemaFromCloudSQL(cmd.project, sourceProfile, targetProfile)
+	} else {
+		infoSchema, err = gi.GetInfoSchema(cmd.project, sourceProfile, targetProfile)
+	}
+	if err != nil {
+		err = fmt.Errorf("can't connect to source database: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	v := validation.Validator{
+		Ctx:        ctx,
+		Conv:       conv,
+		InfoSchema: infoSchema,
+		Client:     client,
+		Ranges:     cmd.keyRanges,
+		SampleSize: cmd.sampleSize,
+	}
+	logger.Log.Info(fmt.Sprintf("Validating data of db %s against the source database", dbURI))
+	results := v.Validate()
+	fmt.Fprintf(ioHelper.Out, "\nValidation report for db %s:\n\n", dbURI)
+	if mismatches := validation.WriteReport(ioHelper.Out, results); mismatches > 0 {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSetFlags(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		flagArgs       []string
+		expectedValues ValidateCmd
+	}{
+		{
+			testName: "Default Values",
+			flagArgs: []string{},
+			expectedValues: ValidateCmd{
+				target:     "Spanner",
+				logLevel:   "DEBUG",
+				keyRanges:  validation.DefaultRanges,
+				sampleSize: validation.DefaultSampleSize,
+			},
+		},
+		{
+			testName: "Set all flags",
+			flagArgs: []string{"--source=MySQL", "--source-profile=host=localhost", "--session=session.json", "--target=Spanner", "--target-profile=instance=i,dbName=db", "--project=p", "--log-level=INFO", "--key-ranges=4", "--sample-size=3"},
+			expectedValues: ValidateCmd{
+				source:        "MySQL",
+				sourceProfile: "host=localhost",
+				sessionJSON:   "session.json",
+				target:        "Spanner",
+				targetProfile: "instance=i,dbName=db",
+				project:       "p",
+				logLevel:      "INFO",
+				keyRanges:     4,
+				sampleSize:    3,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			fs := flag.NewFlagSet("testSetFlags", flag.ContinueOnError)
+			validateCmd := ValidateCmd{}
+			validateCmd.SetFlags(fs)
+			err := fs.Parse(tc.flagArgs)
+			if err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			assert.Equal(t, tc.expectedValues, validateCmd, tc.testName)
+		})
+	}
+}
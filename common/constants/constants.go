@@ -36,6 +36,16 @@ const (
 	// CSV is the driver name when loading data using csv.
 	CSV string = "csv"
 
+	// PARQUET is the source format for importing Parquet files.
+	PARQUET string = "parquet"
+
+	// AVRO is the source format for importing Avro object container files.
+	AVRO string = "avro"
+
+	// DEAD_LETTER is the source format for replaying the rows of a dead-letter
+	// file written by an earlier migration or import.
+	DEAD_LETTER string = "dead-letter"
+
 	// ORACLE is the driver name for Oracle.
 	// This is an experimental driver; implementation in progress.
 	ORACLE string = "oracle"
@@ -82,9 +82,11 @@ func (ci *ConvImpl) DataConv(ctx context.Context, migrationProjectId string, sou
 		WriteLimit: writeLimit,
 		RetryLimit: 1000,
 		Verbose:    internal.Verbose(),
+		WriteMode:  writer.WriteMode(conv.DataWriteMode),
+		DeadLetter: conv.DeadLetter,
 	}
 	switch sourceProfile.Driver {
-	case constants.POSTGRES, constants.MYSQL, constants.SQLSERVER, constants.ORACLE:
+	case constants.POSTGRES, constants.MYSQL, constants.SQLSERVER, constants.ORACLE, constants.CASSANDRA:
 		return dataFromSource.dataFromDatabase(ctx, migrationProjectId, sourceProfile, targetProfile, config, conv, client, &GetInfoImpl{}, &DataFromDatabaseImpl{}, &SnapshotMigrationImpl{})
 	case constants.PGDUMP, constants.MYSQLDUMP:
 		if conv.SpSchema.CheckInterleaved() {
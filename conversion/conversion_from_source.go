@@ -15,7 +15,6 @@
 package conversion
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 
@@ -126,7 +125,11 @@ func (sads *SchemaFromSourceImpl) SchemaFromDump(SpProjectId string, SpInstanceI
 		StartCounterWith: defaultIdentityOptions.StartCounterWith,
 	}
 	p := internal.NewProgress(n, "Generating schema", internal.Verbose(), false, int(internal.SchemaCreationInProgress))
-	r := internal.NewReader(bufio.NewReader(f), p)
+	r, err := newDumpReader(f, p)
+	if err != nil {
+		fmt.Fprintf(ioHelper.Out, "Failed to read the data file: %v", err)
+		return nil, fmt.Errorf("failed to read the data file: %w", err)
+	}
 	conv.SetSchemaMode() // Build schema and ignore data in dump.
 	conv.SetDataSink(nil)
 	err = processDump.ProcessDump(driver, conv, r)
@@ -161,7 +164,10 @@ func (sads *DataFromSourceImpl) dataFromDump(driver string, config writer.BatchW
 	totalRows := conv.Rows()
 
 	conv.Audit.Progress = *internal.NewProgress(totalRows, "Writing data to Spanner", internal.Verbose(), false, int(internal.DataWriteInProgress))
-	r := internal.NewReader(bufio.NewReader(ioHelper.SeekableIn), nil)
+	r, err := newDumpReader(ioHelper.SeekableIn, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the data file: %w", err)
+	}
 	batchWriter := populateDataConv.populateDataConv(conv, config, client)
 	processDump.ProcessDump(driver, conv, r)
 	batchWriter.Flush()
@@ -15,6 +15,7 @@
 package conversion
 
 import (
+	"bufio"
 	"context"
 	"encoding/base64"
 	"fmt"
@@ -30,6 +31,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/metrics"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
@@ -88,6 +90,44 @@ func getSeekable(f *os.File) (*os.File, int64, error) {
 	return fcopy, n, nil
 }
 
+// newDumpReader returns a line reader of the dump file f from its current
+// offset. Dumps compressed with gzip, zstd or bzip2 are decompressed while
+// reading, and their progress is reported on the compressed bytes read, since
+// the size of the decompressed dump isn't known upfront.
+func newDumpReader(f *os.File, p *internal.Progress) (*internal.Reader, error) {
+	compression, err := file_reader.DetectFileCompression(f)
+	if err != nil {
+		return nil, err
+	}
+	if compression == file_reader.CompressionNone {
+		return internal.NewReader(bufio.NewReader(f), p), nil
+	}
+	logger.Log.Debug(fmt.Sprintf("Decompressing %s dump file %s", compression, f.Name()))
+	var in io.Reader = f
+	if p != nil {
+		in = &progressReader{r: f, progress: p}
+	}
+	d, err := file_reader.NewDecompressor(in, compression)
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s compressed dump file: %w", compression, err)
+	}
+	return internal.NewReader(bufio.NewReader(d), nil), nil
+}
+
+// progressReader reports the number of bytes read from r to progress.
+type progressReader struct {
+	r        io.Reader
+	n        int64
+	progress *internal.Progress
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	pr.n += int64(n)
+	pr.progress.MaybeReport(pr.n)
+	return n, err
+}
+
 // ProcessDump invokes process dump function from a sql package based on driver selected.
 func (pdd *ProcessDumpByDialectImpl) ProcessDump(driver string, conv *internal.Conv, r *internal.Reader) error {
 	switch driver {
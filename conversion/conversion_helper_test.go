@@ -0,0 +1,59 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDumpReader(t *testing.T) {
+	dump := "CREATE TABLE t (a INT);\nINSERT INTO t VALUES (1);\n"
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(dump))
+	w.Close()
+
+	for name, content := range map[string][]byte{"dump.sql": []byte(dump), "dump.sql.gz": gz.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			assert.Nil(t, os.WriteFile(path, content, 0644))
+			f, err := os.Open(path)
+			assert.Nil(t, err)
+			defer f.Close()
+
+			// Both passes over the dump read the same lines.
+			for pass := 0; pass < 2; pass++ {
+				_, err = f.Seek(0, 0)
+				assert.Nil(t, err)
+				p := internal.NewProgress(int64(len(content)), "Generating schema", false, false, int(internal.SchemaCreationInProgress))
+				r, err := newDumpReader(f, p)
+				assert.Nil(t, err)
+				assert.Equal(t, "CREATE TABLE t (a INT);\n", string(r.ReadLine()))
+				assert.Equal(t, "INSERT INTO t VALUES (1);\n", string(r.ReadLine()))
+				r.ReadLine()
+				assert.True(t, r.EOF)
+				pct, _ := p.ReportProgress()
+				assert.Equal(t, 100, pct)
+			}
+		})
+	}
+}
@@ -219,12 +219,13 @@ func (gi *GetInfoImpl) GetInfoSchema(migrationProjectId string, sourceProfile pr
 		}
 		return oracle.InfoSchemaImpl{DbName: strings.ToUpper(dbName), Db: db, MigrationProjectId: migrationProjectId, SourceProfile: sourceProfile, TargetProfile: targetProfile}, nil
 	case constants.CASSANDRA:
-		_, ksMetadata, err := ca.NewCassandraAccessor(sourceProfile)
+		accessor, ksMetadata, err := ca.NewCassandraAccessor(sourceProfile)
 		if err != nil {
 			return nil, err
 		}
 		return cassandra.InfoSchemaImpl{
 			KeyspaceMetadata: ksMetadata,
+			DataAccessor:     accessor,
 			SourceProfile:    sourceProfile,
 			TargetProfile:    targetProfile,
 		}, nil
@@ -232,3 +233,22 @@ func (gi *GetInfoImpl) GetInfoSchema(migrationProjectId string, sourceProfile pr
 		return nil, fmt.Errorf("driver %s not supported", driver)
 	}
 }
+
+// GetToDdl returns the source type mapping used by the schema conversion of
+// driver, or nil if there is none.
+func GetToDdl(driver string) common.ToDdl {
+	switch driver {
+	case constants.MYSQL, constants.MYSQLDUMP:
+		return mysql.InfoSchemaImpl{}.GetToDdl()
+	case constants.POSTGRES, constants.PGDUMP:
+		return postgres.InfoSchemaImpl{}.GetToDdl()
+	case constants.SQLSERVER:
+		return sqlserver.InfoSchemaImpl{}.GetToDdl()
+	case constants.ORACLE:
+		return oracle.InfoSchemaImpl{}.GetToDdl()
+	case constants.CASSANDRA:
+		return cassandra.InfoSchemaImpl{}.GetToDdl()
+	default:
+		return nil
+	}
+}
@@ -15,8 +15,11 @@
 package conversion
 
 import (
+	"fmt"
+
 	sp "cloud.google.com/go/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 )
@@ -33,7 +36,11 @@ func (sm *SnapshotMigrationImpl) performSnapshotMigration(config writer.BatchWri
 		conv.Audit.Progress = *internal.NewProgress(totalRows, "Writing data to Spanner", internal.Verbose(), false, int(internal.DataWriteInProgress))
 	}
 	batchWriter := populateDataConv.populateDataConv(conv, config, client)
+	conv.Checkpoint.SetDroppedRowsFunc(additionalAttributes.ShardId, batchWriter.DroppedRowsByTable)
 	infoSchemaI.ProcessData(conv, infoSchema, additionalAttributes)
 	batchWriter.Flush()
+	if err := conv.Checkpoint.Save(); err != nil {
+		logger.Log.Warn(fmt.Sprintf("couldn't update checkpoint: %v", err))
+	}
 	return batchWriter
 }
\ No newline at end of file
@@ -24,6 +24,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 )
@@ -43,7 +44,11 @@ func WriteSchemaFile(conv *internal.Conv, now time.Time, name string, out *os.Fi
 	// and doesn't add backticks around table and column names. This file is
 	// intended for explanatory and documentation purposes, and is not strictly
 	// legal Cloud Spanner DDL (Cloud Spanner doesn't currently support comments).
-	spDDL := ddl.GetDDL(ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	protoBundle := conv.ProtoBundle()
+	spDDL := ddl.GetProtoBundleDDL(ddl.Config{ProtectIds: false, SpDialect: conv.SpDialect}, protoBundle)
+	spDDL = append(spDDL, ddl.GetDDL(ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)...)
+	spDDL = append(spDDL, ddl.GetViewsDDL(ddl.Config{Comments: true, ProtectIds: false, SpDialect: conv.SpDialect}, conv.SpViews)...)
+	spDDL = append(spDDL, ddl.GetChangeStreamsDDL(ddl.Config{Comments: true, ProtectIds: false, SpDialect: conv.SpDialect}, conv.SpSchema, conv.SpChangeStreams)...)
 	if len(spDDL) == 0 {
 		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
 	}
@@ -71,7 +76,10 @@ func WriteSchemaFile(conv *internal.Conv, now time.Time, name string, out *os.Fi
 
 	// We change 'Comments' to false and 'ProtectIds' to true below to write out a
 	// schema file that is a legal Cloud Spanner DDL.
-	spDDL = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	spDDL = ddl.GetProtoBundleDDL(ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect}, protoBundle)
+	spDDL = append(spDDL, ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)...)
+	spDDL = append(spDDL, ddl.GetViewsDDL(ddl.Config{Comments: false, ProtectIds: true, SpDialect: conv.SpDialect}, conv.SpViews)...)
+	spDDL = append(spDDL, ddl.GetChangeStreamsDDL(ddl.Config{Comments: false, ProtectIds: true, SpDialect: conv.SpDialect}, conv.SpSchema, conv.SpChangeStreams)...)
 	if len(spDDL) == 0 {
 		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
 	}
@@ -84,6 +92,27 @@ func WriteSchemaFile(conv *internal.Conv, now time.Time, name string, out *os.Fi
 		return
 	}
 	fmt.Fprintf(out, "Wrote legal schema ddl to file '%s'.\n", name)
+
+	if len(ddl.GetProtoBundleDDL(ddl.Config{SpDialect: conv.SpDialect}, protoBundle)) > 0 {
+		// Convert <file_name>.ddl.<ext> to <file_name>.proto.
+		writeProtoFile(protoBundle, strings.Join(nameSplit[:len(nameSplit)-2], ".")+".proto", out)
+	}
+}
+
+// writeProtoFile writes the .proto file that defines the proto enums of
+// the schema, which are needed to read their columns with a Spanner client.
+func writeProtoFile(pb ddl.ProtoBundle, name string, out *os.File) {
+	f, err := os.Create(name)
+	if err != nil {
+		fmt.Fprintf(out, "Can't create proto file %s: %v\n", name, err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.WriteString(pb.ProtoFile()); err != nil {
+		fmt.Fprintf(out, "Can't write out proto file: %v\n", err)
+		return
+	}
+	fmt.Fprintf(out, "Wrote proto definitions to file '%s'.\n", name)
 }
 
 // WriteSessionFile writes conv struct to a file in JSON format.
@@ -145,6 +174,24 @@ func ReadSessionFile(conv *internal.Conv, sessionJSON string) error {
 	return nil
 }
 
+// ApplyCustomizationsFile applies the customizations of a YAML or JSON file
+// to the Spanner schema of conv, see common.ApplyCustomizations.
+func ApplyCustomizationsFile(conv *internal.Conv, driver, name string, out *os.File) error {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("can't read customizations file %s: %w", name, err)
+	}
+	c, err := common.ParseCustomizations(data)
+	if err != nil {
+		return fmt.Errorf("customizations file %s: %w", name, err)
+	}
+	if err := common.ApplyCustomizations(conv, GetToDdl(driver), c); err != nil {
+		return fmt.Errorf("can't apply customizations file %s: %w", name, err)
+	}
+	fmt.Fprintf(out, "Applied customizations from file '%s'.\n", name)
+	return nil
+}
+
 // WriteBadData prints summary stats about bad rows and writes detailed info
 // to file 'name'.
 func WriteBadData(bw *writer.BatchWriter, conv *internal.Conv, banner, name string, out *os.File) {
@@ -0,0 +1,226 @@
+// Package dead_letter writes the rows rejected during a data migration to a
+// structured file, and reads them back so that they can be replayed once
+// corrected.
+package dead_letter
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+)
+
+// Formats of dead-letter files, chosen from the extension of their uri.
+const (
+	// FormatJsonl files have one JSON object per row, with the fields of
+	// internal.DeadLetterRow.
+	FormatJsonl = "jsonl"
+	// FormatCsv files have a header and the columns stage, table, error, cols
+	// and vals, where cols and vals are JSON arrays of strings.
+	FormatCsv = "csv"
+)
+
+var csvHeader = []string{"stage", "table", "error", "cols", "vals"}
+
+var NewStorageClient = func(ctx context.Context) (storageclient.StorageClient, error) {
+	return storageclient.NewStorageClientImpl(ctx)
+}
+
+// GetFormat returns the format of a dead-letter file from its uri: files
+// ending in .csv are CSV files and all others are JSONL files.
+func GetFormat(uri string) string {
+	if strings.HasSuffix(strings.ToLower(uri), ".csv") {
+		return FormatCsv
+	}
+	return FormatJsonl
+}
+
+// FileSink is an internal.DeadLetterSink that writes rows to a local file or
+// to a GCS object. Since Write can't return errors, the first error is kept
+// and returned by Close, and the rows that follow it are dropped.
+type FileSink struct {
+	uri       string
+	format    string
+	mutex     sync.Mutex
+	out       io.WriteCloser
+	w         *bufio.Writer
+	csvWriter *csv.Writer
+	rows      int64
+	err       error
+}
+
+// NewFileSink creates the dead-letter file at uri, which is either a local
+// path or a gs:// uri, overwriting any existing file.
+func NewFileSink(ctx context.Context, uri string) (*FileSink, error) {
+	out, err := createFile(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("can't create dead-letter file %s: %w", uri, err)
+	}
+	sink := &FileSink{uri: uri, format: GetFormat(uri), out: out, w: bufio.NewWriter(out)}
+	if sink.format == FormatCsv {
+		sink.csvWriter = csv.NewWriter(sink.w)
+		sink.err = sink.csvWriter.Write(csvHeader)
+	}
+	return sink, nil
+}
+
+func createFile(ctx context.Context, uri string) (io.WriteCloser, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != constants.GCS_SCHEME {
+		return os.Create(uri)
+	}
+	client, err := NewStorageClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Bucket(u.Host).Object(strings.TrimPrefix(u.Path, "/")).NewWriter(ctx), nil
+}
+
+// Write appends row to the file.
+func (sink *FileSink) Write(row internal.DeadLetterRow) {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.err != nil {
+		return
+	}
+	if sink.format == FormatCsv {
+		cols, _ := json.Marshal(row.Cols)
+		vals, _ := json.Marshal(row.Vals)
+		sink.err = sink.csvWriter.Write([]string{row.Stage, row.Table, row.Error, string(cols), string(vals)})
+	} else {
+		var b []byte
+		b, sink.err = json.Marshal(row)
+		if sink.err == nil {
+			b = append(b, '\n')
+			_, sink.err = sink.w.Write(b)
+		}
+	}
+	if sink.err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't write to dead-letter file %s, further rejected rows will be dropped: %v", sink.uri, sink.err))
+		return
+	}
+	sink.rows++
+}
+
+// Rows returns the number of rows written to the file.
+func (sink *FileSink) Rows() int64 {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	return sink.rows
+}
+
+// Uri returns the uri of the file.
+func (sink *FileSink) Uri() string {
+	return sink.uri
+}
+
+// Close flushes the rows to the file and closes it. It returns the first
+// error encountered while writing rows, if any.
+func (sink *FileSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.csvWriter != nil {
+		sink.csvWriter.Flush()
+		if sink.err == nil {
+			sink.err = sink.csvWriter.Error()
+		}
+	}
+	if err := sink.w.Flush(); sink.err == nil {
+		sink.err = err
+	}
+	if err := sink.out.Close(); sink.err == nil {
+		sink.err = err
+	}
+	if sink.err != nil {
+		return fmt.Errorf("can't write dead-letter file %s: %w", sink.uri, sink.err)
+	}
+	return nil
+}
+
+// Reader reads the rows of a dead-letter file.
+type Reader struct {
+	format    string
+	decoder   *json.Decoder
+	csvReader *csv.Reader
+	line      int
+}
+
+// NewReader returns a reader of the rows of a dead-letter file in the given
+// format.
+func NewReader(r io.Reader, format string) *Reader {
+	if format == FormatCsv {
+		csvReader := csv.NewReader(r)
+		csvReader.FieldsPerRecord = -1
+		return &Reader{format: format, csvReader: csvReader}
+	}
+	return &Reader{format: format, decoder: json.NewDecoder(r)}
+}
+
+// Next returns the next row of the file, or io.EOF at the end of the file.
+func (r *Reader) Next() (internal.DeadLetterRow, error) {
+	var row internal.DeadLetterRow
+	r.line++
+	if r.format != FormatCsv {
+		if err := r.decoder.Decode(&row); err != nil {
+			if err == io.EOF {
+				return row, err
+			}
+			return row, fmt.Errorf("can't read row %d of dead-letter file: %w", r.line, err)
+		}
+		return row, validateRow(row, r.line)
+	}
+	if r.line == 1 {
+		header, err := r.csvReader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return row, err
+			}
+			return row, fmt.Errorf("can't read header of dead-letter file: %w", err)
+		}
+		if strings.Join(header, ",") != strings.Join(csvHeader, ",") {
+			return row, fmt.Errorf("dead-letter file must start with the header %s, found %s", strings.Join(csvHeader, ","), strings.Join(header, ","))
+		}
+	}
+	record, err := r.csvReader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return row, err
+		}
+		return row, fmt.Errorf("can't read row %d of dead-letter file: %w", r.line, err)
+	}
+	if len(record) != len(csvHeader) {
+		return row, fmt.Errorf("row %d of dead-letter file has %d fields, expected %d", r.line, len(record), len(csvHeader))
+	}
+	row = internal.DeadLetterRow{Stage: record[0], Table: record[1], Error: record[2]}
+	if err := json.Unmarshal([]byte(record[3]), &row.Cols); err != nil {
+		return row, fmt.Errorf("can't read cols of row %d of dead-letter file: %w", r.line, err)
+	}
+	if err := json.Unmarshal([]byte(record[4]), &row.Vals); err != nil {
+		return row, fmt.Errorf("can't read vals of row %d of dead-letter file: %w", r.line, err)
+	}
+	return row, validateRow(row, r.line)
+}
+
+func validateRow(row internal.DeadLetterRow, line int) error {
+	if row.Table == "" {
+		return fmt.Errorf("row %d of dead-letter file has no table", line)
+	}
+	if len(row.Cols) != len(row.Vals) {
+		return fmt.Errorf("row %d of dead-letter file has %d cols but %d vals", line, len(row.Cols), len(row.Vals))
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package dead_letter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+var testRows = []internal.DeadLetterRow{
+	{Stage: internal.DeadLetterConversion, Table: "users", Cols: []string{"id", "born"}, Vals: []string{"1", "1990-13-01"}, Error: "can't convert to date"},
+	{Stage: internal.DeadLetterWrite, Table: "orders", Cols: []string{"id", "note"}, Vals: []string{"7", "a \"quoted\", multi\nline note"}, Error: "AlreadyExists"},
+}
+
+func readAll(t *testing.T, r io.Reader, format string) []internal.DeadLetterRow {
+	reader := NewReader(r, format)
+	var rows []internal.DeadLetterRow
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			return rows
+		}
+		assert.Nil(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestGetFormat(t *testing.T) {
+	assert.Equal(t, FormatCsv, GetFormat("gs://bucket/rejected.CSV"))
+	assert.Equal(t, FormatJsonl, GetFormat("rejected.jsonl"))
+	assert.Equal(t, FormatJsonl, GetFormat("rejected"))
+}
+
+func TestFileSink_RoundTrip(t *testing.T) {
+	for _, name := range []string{"rejected.jsonl", "rejected.csv"} {
+		t.Run(name, func(t *testing.T) {
+			uri := filepath.Join(t.TempDir(), name)
+			sink, err := NewFileSink(context.Background(), uri)
+			assert.Nil(t, err)
+			for _, row := range testRows {
+				sink.Write(row)
+			}
+			assert.Nil(t, sink.Close())
+			assert.Equal(t, int64(2), sink.Rows())
+
+			f, err := os.Open(uri)
+			assert.Nil(t, err)
+			defer f.Close()
+			assert.Equal(t, testRows, readAll(t, f, GetFormat(uri)))
+		})
+	}
+}
+
+func TestFileSink_ConcurrentWrites(t *testing.T) {
+	uri := filepath.Join(t.TempDir(), "rejected.jsonl")
+	sink, err := NewFileSink(context.Background(), uri)
+	assert.Nil(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				sink.Write(internal.DeadLetterRow{Stage: internal.DeadLetterWrite, Table: "t", Cols: []string{"id"}, Vals: []string{fmt.Sprint(i*100 + j)}})
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Nil(t, sink.Close())
+	f, err := os.Open(uri)
+	assert.Nil(t, err)
+	defer f.Close()
+	assert.Equal(t, 1000, len(readAll(t, f, FormatJsonl)))
+}
+
+func TestFileSink_Gcs(t *testing.T) {
+	var buf bytes.Buffer
+	var bucket, object string
+	closed := false
+	orig := NewStorageClient
+	defer func() { NewStorageClient = orig }()
+	NewStorageClient = func(ctx context.Context) (storageclient.StorageClient, error) {
+		return &storageclient.StorageClientMock{
+			BucketMock: func(name string) storageclient.BucketHandle {
+				bucket = name
+				return &storageclient.BucketHandleMock{
+					ObjectMock: func(name string) storageclient.ObjectHandle {
+						object = name
+						return &storageclient.ObjectHandleMock{
+							NewWriterMock: func(ctx context.Context) io.WriteCloser {
+								return &storageclient.WriterMock{
+									WriteMock: buf.Write,
+									CloseMock: func() error {
+										closed = true
+										return nil
+									},
+								}
+							},
+						}
+					},
+				}
+			},
+		}, nil
+	}
+	sink, err := NewFileSink(context.Background(), "gs://my-bucket/dead/rejected.jsonl")
+	assert.Nil(t, err)
+	sink.Write(testRows[0])
+	assert.Nil(t, sink.Close())
+	assert.Equal(t, "my-bucket", bucket)
+	assert.Equal(t, "dead/rejected.jsonl", object)
+	assert.True(t, closed)
+	assert.Equal(t, testRows[:1], readAll(t, &buf, FormatJsonl))
+}
+
+func TestFileSink_WriteError(t *testing.T) {
+	orig := NewStorageClient
+	defer func() { NewStorageClient = orig }()
+	NewStorageClient = func(ctx context.Context) (storageclient.StorageClient, error) {
+		return &storageclient.StorageClientMock{
+			BucketMock: func(name string) storageclient.BucketHandle {
+				return &storageclient.BucketHandleMock{
+					ObjectMock: func(name string) storageclient.ObjectHandle {
+						return &storageclient.ObjectHandleMock{
+							NewWriterMock: func(ctx context.Context) io.WriteCloser {
+								return &storageclient.WriterMock{
+									WriteMock: func(p []byte) (int, error) { return 0, fmt.Errorf("permission denied") },
+									CloseMock: func() error { return nil },
+								}
+							},
+						}
+					},
+				}
+			},
+		}, nil
+	}
+	sink, err := NewFileSink(context.Background(), "gs://my-bucket/rejected.jsonl")
+	assert.Nil(t, err)
+	sink.Write(testRows[0])
+	err = sink.Close()
+	assert.ErrorContains(t, err, "permission denied")
+}
+
+func TestReader_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		wantErr string
+	}{
+		{"invalid json", FormatJsonl, "{\"table\": \n", "can't read row 1"},
+		{"missing table", FormatJsonl, "{\"cols\": [\"a\"], \"vals\": [\"1\"]}\n", "row 1 of dead-letter file has no table"},
+		{"cols and vals differ", FormatJsonl, "{\"table\": \"t\", \"cols\": [\"a\", \"b\"], \"vals\": [\"1\"]}\n", "has 2 cols but 1 vals"},
+		{"wrong csv header", FormatCsv, "table,cols,vals\n", "must start with the header"},
+		{"invalid csv cols", FormatCsv, "stage,table,error,cols,vals\nwrite,t,e,a,[]\n", "can't read cols of row 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(strings.NewReader(tt.content), tt.format).Next()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
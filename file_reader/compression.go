@@ -0,0 +1,103 @@
+package file_reader
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression formats of files that are decompressed while reading.
+const (
+	CompressionNone  = ""
+	CompressionGzip  = "gzip"
+	CompressionZstd  = "zstd"
+	CompressionBzip2 = "bzip2"
+)
+
+var compressionMagicBytes = []struct {
+	compression string
+	magic       []byte
+}{
+	{CompressionGzip, []byte{0x1f, 0x8b}},
+	{CompressionZstd, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+}
+
+// bzip2Magic starts bzip2 content, followed by the block size, a digit from
+// 1 to 9. Both are checked, since plain text can start with "BZh" too.
+var bzip2Magic = []byte("BZh")
+
+var compressionExtensions = map[string]string{
+	".gz":   CompressionGzip,
+	".gzip": CompressionGzip,
+	".zst":  CompressionZstd,
+	".zstd": CompressionZstd,
+	".bz2":  CompressionBzip2,
+}
+
+var compressionContentTypes = map[string]string{
+	"application/gzip":    CompressionGzip,
+	"application/x-gzip":  CompressionGzip,
+	"application/zstd":    CompressionZstd,
+	"application/x-bzip2": CompressionBzip2,
+}
+
+// DetectCompression returns the compression of a file from the magic bytes at
+// the start of its content, or else from the extension of its uri.
+func DetectCompression(uri string, header []byte) string {
+	for _, m := range compressionMagicBytes {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.compression
+		}
+	}
+	if len(header) >= 4 && bytes.HasPrefix(header, bzip2Magic) && header[3] >= '1' && header[3] <= '9' {
+		return CompressionBzip2
+	}
+	return compressionFromExtension(uri)
+}
+
+// DetectFileCompression returns the compression of f without moving its offset.
+func DetectFileCompression(f *os.File) (string, error) {
+	header := make([]byte, 4)
+	n, err := f.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return CompressionNone, err
+	}
+	return DetectCompression(f.Name(), header[:n]), nil
+}
+
+func compressionFromExtension(uri string) string {
+	lower := strings.ToLower(uri)
+	for ext, compression := range compressionExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return compression
+		}
+	}
+	return CompressionNone
+}
+
+// NewDecompressor returns a reader of the decompressed content of r, which is
+// compressed with the given compression.
+func NewDecompressor(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(r), nil
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case CompressionBzip2:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	default:
+		return nil, fmt.Errorf("compression %s not supported", compression)
+	}
+}
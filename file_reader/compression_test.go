@@ -0,0 +1,142 @@
+package file_reader
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipBytes(t *testing.T, content string) []byte {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	_, err := w.Write([]byte(content))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	return b.Bytes()
+}
+
+func zstdBytes(t *testing.T, content string) []byte {
+	var b bytes.Buffer
+	w, err := zstd.NewWriter(&b)
+	assert.Nil(t, err)
+	_, err = w.Write([]byte(content))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	return b.Bytes()
+}
+
+// bzip2Bytes is "hello\n" compressed with bzip2, since the standard library
+// has no bzip2 writer.
+var bzip2Bytes = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xc1, 0xc0, 0x80, 0xe2, 0x00, 0x00,
+	0x01, 0x41, 0x00, 0x00, 0x10, 0x02, 0x44, 0xa0, 0x00, 0x30, 0xcd, 0x00, 0xc3, 0x46, 0x29, 0x97,
+	0x17, 0x72, 0x45, 0x38, 0x50, 0x90, 0xc1, 0xc0, 0x80, 0xe2,
+}
+
+func TestDetectCompression(t *testing.T) {
+	tests := []struct {
+		name   string
+		uri    string
+		header []byte
+		want   string
+	}{
+		{"gzip magic", "dump.sql", []byte{0x1f, 0x8b, 0x08, 0x00}, CompressionGzip},
+		{"zstd magic", "dump.sql", []byte{0x28, 0xb5, 0x2f, 0xfd}, CompressionZstd},
+		{"bzip2 magic", "dump.sql", []byte("BZh9"), CompressionBzip2},
+		{"text starting like bzip2", "dump.sql", []byte("BZhi"), CompressionNone},
+		{"bzip2 magic without block size", "dump.sql", []byte("BZh"), CompressionNone},
+		{"gzip extension", "gs://bucket/dump.SQL.GZ", nil, CompressionGzip},
+		{"zstd extension", "dump.sql.zst", nil, CompressionZstd},
+		{"bzip2 extension", "dump.sql.bz2", nil, CompressionBzip2},
+		{"uncompressed", "dump.sql", []byte("CREA"), CompressionNone},
+		{"magic over extension", "dump.sql.gz", []byte{0x28, 0xb5, 0x2f, 0xfd}, CompressionZstd},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectCompression(tt.uri, tt.header))
+		})
+	}
+}
+
+func TestNewDecompressor(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression string
+		content     []byte
+	}{
+		{"none", CompressionNone, []byte("hello\n")},
+		{"gzip", CompressionGzip, gzipBytes(t, "hello\n")},
+		{"zstd", CompressionZstd, zstdBytes(t, "hello\n")},
+		{"bzip2", CompressionBzip2, bzip2Bytes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewDecompressor(bytes.NewReader(tt.content), tt.compression)
+			assert.Nil(t, err)
+			defer r.Close()
+			b, err := io.ReadAll(r)
+			assert.Nil(t, err)
+			assert.Equal(t, "hello\n", string(b))
+		})
+	}
+	_, err := NewDecompressor(bytes.NewReader([]byte("hello\n")), CompressionGzip)
+	assert.NotNil(t, err)
+	_, err = NewDecompressor(bytes.NewReader(nil), "lz4")
+	assert.NotNil(t, err)
+}
+
+func TestLocalFileReaderImpl_Compressed(t *testing.T) {
+	content := "CREATE TABLE t (a INT);\nINSERT INTO t VALUES (1);\n"
+	for name, b := range map[string][]byte{
+		"dump.sql.gz":  gzipBytes(t, content),
+		"dump.sql.zst": zstdBytes(t, content),
+		"dump.bin":     gzipBytes(t, content), // Detected from the magic bytes.
+	} {
+		t.Run(name, func(t *testing.T) {
+			uri := filepath.Join(t.TempDir(), name)
+			assert.Nil(t, os.WriteFile(uri, b, 0644))
+			reader, err := NewFileReader(context.Background(), uri)
+			assert.Nil(t, err)
+			defer reader.Close()
+
+			r, err := reader.CreateReader(context.Background())
+			assert.Nil(t, err)
+			got, err := io.ReadAll(r)
+			assert.Nil(t, err)
+			assert.Equal(t, content, string(got))
+
+			// The second pass reads the decompressed content from the start again.
+			r, err = reader.ResetReader(context.Background())
+			assert.Nil(t, err)
+			got, err = io.ReadAll(r)
+			assert.Nil(t, err)
+			assert.Equal(t, content, string(got))
+		})
+	}
+}
+
+func TestLocalFileReaderImpl_ReadAllCompressed(t *testing.T) {
+	uri := filepath.Join(t.TempDir(), "schema.json.gz")
+	assert.Nil(t, os.WriteFile(uri, gzipBytes(t, "[]"), 0644))
+	reader, err := NewLocalFileReader(uri)
+	assert.Nil(t, err)
+	defer reader.Close()
+	b, err := reader.ReadAll(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(b))
+}
+
+func TestGcsObjectCompression(t *testing.T) {
+	assert.Equal(t, CompressionGzip, gcsObjectCompression("gs://b/dump.sql.gz", &storage.ObjectAttrs{}))
+	assert.Equal(t, CompressionZstd, gcsObjectCompression("gs://b/dump", &storage.ObjectAttrs{ContentType: "application/zstd"}))
+	assert.Equal(t, CompressionNone, gcsObjectCompression("gs://b/dump.sql.gz", &storage.ObjectAttrs{ContentEncoding: "gzip"}))
+	assert.Equal(t, CompressionNone, gcsObjectCompression("gs://b/dump.sql", &storage.ObjectAttrs{ContentType: "text/plain"}))
+}
@@ -17,6 +17,10 @@ type FileReader interface {
 	CreateReader(ctx context.Context) (io.Reader, error)
 	// ReadAll return all the bytes in the file.
 	ReadAll(ctx context.Context) ([]byte, error)
+	// ReaderAt returns a reader with random access to the content of the file,
+	// and the size of the file. It fails for compressed files, which can only
+	// be read from their start.
+	ReaderAt(ctx context.Context) (io.ReaderAt, int64, error)
 	Close()
 }
 
@@ -38,6 +42,7 @@ type MockFileReader struct {
 	CloseFn        func()
 	CreateReaderFn func(ctx context.Context) (io.Reader, error)
 	ResetReaderFn  func(ctx context.Context) (io.Reader, error)
+	ReaderAtFn     func(ctx context.Context) (io.ReaderAt, int64, error)
 }
 
 func (reader *MockFileReader) ReadAll(ctx context.Context) ([]byte, error) {
@@ -67,3 +72,10 @@ func (reader *MockFileReader) ResetReader(ctx context.Context) (io.Reader, error
 	}
 	return nil, nil
 }
+
+func (reader *MockFileReader) ReaderAt(ctx context.Context) (io.ReaderAt, int64, error) {
+	if reader.ReaderAtFn != nil {
+		return reader.ReaderAtFn(ctx)
+	}
+	return nil, 0, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"google.golang.org/api/option"
 	"io"
+	"strings"
 )
 
 var GoogleStorageNewClient = func(ctx context.Context, opts ...option.ClientOption) (*storage.Client, error) {
@@ -20,6 +21,16 @@ type GcsFileReaderImpl struct {
 	gcsFilePath   string
 	storageClient *storage.Client
 	storageReader *storage.Reader
+	// compression of the object, detected from its name or content type.
+	compression string
+	// size of the object as stored.
+	size int64
+	// transcoded is true if GCS decompresses the object while downloading it,
+	// which happens for objects stored with a gzip content encoding.
+	transcoded bool
+	// decompressor reads the decompressed content of storageReader, if the
+	// object is compressed.
+	decompressor io.ReadCloser
 }
 
 func NewGcsFileReader(ctx context.Context, uri, host, path string) (*GcsFileReaderImpl, error) {
@@ -28,7 +39,7 @@ func NewGcsFileReader(ctx context.Context, uri, host, path string) (*GcsFileRead
 	if err != nil {
 		return nil, err
 	}
-	err = validateObjectExists(ctx, storageClient, host, path[1:])
+	attrs, err := getObjectAttrs(ctx, storageClient, host, path[1:])
 	if err != nil {
 		storageClient.Close()
 		return nil, err
@@ -38,10 +49,17 @@ func NewGcsFileReader(ctx context.Context, uri, host, path string) (*GcsFileRead
 		bucket:        host,
 		gcsFilePath:   path[1:], // removes "/" from beginning of path
 		storageClient: storageClient,
+		compression:   gcsObjectCompression(uri, attrs),
+		size:          attrs.Size,
+		transcoded:    attrs.ContentEncoding == "gzip",
 	}, nil
 }
 
 func (reader *GcsFileReaderImpl) ResetReader(ctx context.Context) (io.Reader, error) {
+	if reader.decompressor != nil {
+		reader.decompressor.Close()
+		reader.decompressor = nil
+	}
 	if reader.storageReader != nil {
 		reader.storageReader.Close()
 	}
@@ -49,6 +67,8 @@ func (reader *GcsFileReaderImpl) ResetReader(ctx context.Context) (io.Reader, er
 	return reader.CreateReader(ctx)
 }
 
+// CreateReader returns a reader of the object, which decompresses its content
+// if the object is gzip, zstd or bzip2 compressed.
 func (reader *GcsFileReaderImpl) CreateReader(ctx context.Context) (io.Reader, error) {
 
 	rc, err := reader.storageClient.Bucket(reader.bucket).Object(reader.gcsFilePath).NewReader(ctx)
@@ -57,10 +77,21 @@ func (reader *GcsFileReaderImpl) CreateReader(ctx context.Context) (io.Reader, e
 		return nil, err
 	}
 	reader.storageReader = rc
-	return rc, nil
+	if reader.compression == CompressionNone {
+		return rc, nil
+	}
+	reader.decompressor, err = NewDecompressor(rc, reader.compression)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("readFile: unable to decompress %s fileHandle from bucket %q, fileHandle %q: %v", reader.compression, reader.bucket, reader.gcsFilePath, err))
+		return nil, fmt.Errorf("can't read %s compressed file %s: %w", reader.compression, reader.uri, err)
+	}
+	return reader.decompressor, nil
 }
 
 func (reader *GcsFileReaderImpl) Close() {
+	if reader.decompressor != nil {
+		reader.decompressor.Close()
+	}
 	if reader.storageReader != nil {
 		reader.storageReader.Close()
 	}
@@ -77,10 +108,60 @@ func (reader *GcsFileReaderImpl) ReadAll(ctx context.Context) ([]byte, error) {
 			return nil, err
 		}
 	}
+	if reader.decompressor != nil {
+		return io.ReadAll(reader.decompressor)
+	}
 	return io.ReadAll(reader.storageReader)
 }
 
-func validateObjectExists(ctx context.Context, client *storage.Client, bucket, object string) error {
-	_, err := client.Bucket(bucket).Object(object).Attrs(ctx)
-	return err
+// ReaderAt returns a reader that reads the object with range requests, unless
+// the object is compressed.
+func (reader *GcsFileReaderImpl) ReaderAt(ctx context.Context) (io.ReaderAt, int64, error) {
+	if reader.compression != CompressionNone {
+		return nil, 0, fmt.Errorf("can't read %s compressed file %s at random offsets", reader.compression, reader.uri)
+	}
+	if reader.transcoded {
+		return nil, 0, fmt.Errorf("can't read file %s with gzip content encoding at random offsets", reader.uri)
+	}
+	return &gcsRangeReader{ctx: ctx, object: reader.storageClient.Bucket(reader.bucket).Object(reader.gcsFilePath)}, reader.size, nil
+}
+
+// gcsRangeReader reads ranges of an object, with a request for each read.
+type gcsRangeReader struct {
+	ctx    context.Context
+	object *storage.ObjectHandle
+}
+
+func (r *gcsRangeReader) ReadAt(p []byte, off int64) (int, error) {
+	rc, err := r.object.NewRangeReader(r.ctx, off, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	n, err := io.ReadFull(rc, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// getObjectAttrs returns the attributes of the object, which fails if the
+// object doesn't exist.
+func getObjectAttrs(ctx context.Context, client *storage.Client, bucket, object string) (*storage.ObjectAttrs, error) {
+	return client.Bucket(bucket).Object(object).Attrs(ctx)
+}
+
+// gcsObjectCompression returns the compression of an object from the extension
+// of its name, or else from its content type. Objects stored with a gzip
+// content encoding are decompressed by GCS while downloading, so they are read
+// as is. Unlike local files, the magic bytes aren't checked, since that would
+// need an extra read of the object.
+func gcsObjectCompression(uri string, attrs *storage.ObjectAttrs) string {
+	if attrs.ContentEncoding == "gzip" {
+		return CompressionNone
+	}
+	if compression := compressionFromExtension(uri); compression != CompressionNone {
+		return compression
+	}
+	return compressionContentTypes[strings.ToLower(attrs.ContentType)]
 }
@@ -0,0 +1,124 @@
+package file_reader
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"google.golang.org/api/iterator"
+)
+
+var ListFiles = listFiles
+
+// listFiles returns the sorted URIs of the files that uri refers to. uri can
+// be a single file, a glob pattern such as gs://bucket/export/part-*.csv, or a
+// directory or GCS prefix ending in "/", which matches every file under it.
+// A single file is returned as is, without checking that it exists.
+func listFiles(ctx context.Context, uri string) ([]string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	if u.Scheme == constants.GCS_SCHEME {
+		object := strings.TrimPrefix(u.Path, "/")
+		if !isPattern(object) {
+			return []string{uri}, nil
+		}
+		files, err = listGcsObjects(ctx, u.Host, object)
+	} else {
+		if !isPattern(uri) {
+			return []string{uri}, nil
+		}
+		files, err = listLocalFiles(uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %s", uri)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// isPattern returns whether p matches several files, because it has glob
+// metacharacters or is a directory.
+func isPattern(p string) bool {
+	return strings.ContainsAny(p, "*?[") || strings.HasSuffix(p, "/")
+}
+
+func listLocalFiles(pattern string) ([]string, error) {
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "*"
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, m)
+		}
+	}
+	return files, nil
+}
+
+func listGcsObjects(ctx context.Context, bucket, pattern string) ([]string, error) {
+	storageClient, err := GoogleStorageNewClient(ctx, clients.FetchStorageClientOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	defer storageClient.Close()
+	it := storageClient.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: gcsListPrefix(pattern)})
+	var files []string
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't list objects of bucket %s: %w", bucket, err)
+		}
+		if matchObject(pattern, attrs.Name) {
+			files = append(files, fmt.Sprintf("%s://%s/%s", constants.GCS_SCHEME, bucket, attrs.Name))
+		}
+	}
+	return files, nil
+}
+
+// gcsListPrefix returns the part of an object name pattern before its first
+// glob metacharacter, which all matching objects start with.
+func gcsListPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?["); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
+
+// matchObject returns whether the object name matches pattern. A pattern
+// ending in "/" matches every object under that prefix. Objects ending in
+// "/" are folder placeholders and never match.
+func matchObject(pattern, name string) bool {
+	if strings.HasSuffix(name, "/") {
+		return false
+	}
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(name, pattern)
+	}
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
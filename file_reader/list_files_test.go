@@ -0,0 +1,68 @@
+package file_reader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListFilesLocal(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"part-00002.csv", "part-00001.csv", "other.txt"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("a"), 0644))
+	}
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "part-sub.csv"), 0755))
+	ctx := context.Background()
+
+	files, err := ListFiles(ctx, filepath.Join(dir, "part-*.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "part-00001.csv"), filepath.Join(dir, "part-00002.csv")}, files)
+
+	files, err = ListFiles(ctx, dir+"/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "other.txt"), filepath.Join(dir, "part-00001.csv"), filepath.Join(dir, "part-00002.csv")}, files)
+
+	// A single file is returned even if it doesn't exist, so that opening it
+	// reports the error.
+	files, err = ListFiles(ctx, filepath.Join(dir, "missing.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "missing.csv")}, files)
+
+	files, err = ListFiles(ctx, "gs://bucket/export/part-00001.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gs://bucket/export/part-00001.csv"}, files)
+
+	_, err = ListFiles(ctx, filepath.Join(dir, "*.parquet"))
+	assert.ErrorContains(t, err, "no files match")
+
+	_, err = ListFiles(ctx, "://invalid-uri*")
+	assert.Error(t, err)
+}
+
+func TestGcsListPrefix(t *testing.T) {
+	assert.Equal(t, "export/part-", gcsListPrefix("export/part-*.csv"))
+	assert.Equal(t, "export/", gcsListPrefix("export/"))
+	assert.Equal(t, "", gcsListPrefix("[ab]*.csv"))
+}
+
+func TestMatchObject(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"export/part-*.csv", "export/part-00001.csv", true},
+		{"export/part-*.csv", "export/part-00001.csv.gz", false},
+		{"export/part-*.csv", "export/sub/part-00001.csv", false},
+		{"export/", "export/part-00001.csv", true},
+		{"export/", "export/sub/part-00001.csv", true},
+		{"export/", "export/sub/", false},
+		{"export/", "exports/part-00001.csv", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchObject(tt.pattern, tt.name), "%s %s", tt.pattern, tt.name)
+	}
+}
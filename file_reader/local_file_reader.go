@@ -11,6 +11,9 @@ import (
 type LocalFileReaderImpl struct {
 	uri        string
 	fileHandle *os.File
+	// decompressor reads the decompressed content of fileHandle, if the file
+	// is compressed.
+	decompressor io.ReadCloser
 }
 
 func NewLocalFileReader(uri string) (*LocalFileReaderImpl, error) {
@@ -25,14 +28,16 @@ func (reader *LocalFileReaderImpl) ResetReader(ctx context.Context) (io.Reader,
 	if reader.fileHandle != nil {
 		_, err := reader.fileHandle.Seek(0, 0)
 		if err == nil {
-			return reader.fileHandle, nil
+			return reader.wrapDecompressor()
 		}
-		reader.fileHandle.Close()
+		reader.Close()
 	}
 	return reader.CreateReader(ctx)
 
 }
 
+// CreateReader returns the file itself, or a reader of its decompressed
+// content if the file is gzip, zstd or bzip2 compressed.
 func (reader *LocalFileReaderImpl) CreateReader(_ context.Context) (io.Reader, error) {
 	f, err := os.Open(reader.uri)
 	if err != nil {
@@ -40,10 +45,59 @@ func (reader *LocalFileReaderImpl) CreateReader(_ context.Context) (io.Reader, e
 		return nil, err
 	}
 	reader.fileHandle = f
-	return f, nil
+	return reader.wrapDecompressor()
+}
+
+// wrapDecompressor returns a reader of the file from its current offset,
+// decompressing it if needed.
+func (reader *LocalFileReaderImpl) wrapDecompressor() (io.Reader, error) {
+	if reader.decompressor != nil {
+		reader.decompressor.Close()
+		reader.decompressor = nil
+	}
+	compression, err := DetectFileCompression(reader.fileHandle)
+	if err != nil {
+		return nil, err
+	}
+	if compression == CompressionNone {
+		return reader.fileHandle, nil
+	}
+	reader.decompressor, err = NewDecompressor(reader.fileHandle, compression)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("readFile: unable to decompress %s file: %s. Error: %q", compression, reader.uri, err))
+		return nil, fmt.Errorf("can't read %s compressed file %s: %w", compression, reader.uri, err)
+	}
+	return reader.decompressor, nil
+}
+
+// ReaderAt returns the file itself, unless the file is compressed.
+func (reader *LocalFileReaderImpl) ReaderAt(_ context.Context) (io.ReaderAt, int64, error) {
+	if reader.fileHandle == nil {
+		f, err := os.Open(reader.uri)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("readFile: unable to open fileHandle: %s. Error: %q", reader.uri, err))
+			return nil, 0, err
+		}
+		reader.fileHandle = f
+	}
+	compression, err := DetectFileCompression(reader.fileHandle)
+	if err != nil {
+		return nil, 0, err
+	}
+	if compression != CompressionNone {
+		return nil, 0, fmt.Errorf("can't read %s compressed file %s at random offsets", compression, reader.uri)
+	}
+	info, err := reader.fileHandle.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	return reader.fileHandle, info.Size(), nil
 }
 
 func (reader *LocalFileReaderImpl) Close() {
+	if reader.decompressor != nil {
+		reader.decompressor.Close()
+	}
 	if reader.fileHandle != nil {
 		reader.fileHandle.Close()
 	}
@@ -56,5 +110,8 @@ func (reader *LocalFileReaderImpl) ReadAll(_ context.Context) ([]byte, error) {
 			return nil, err
 		}
 	}
+	if reader.decompressor != nil {
+		return io.ReadAll(reader.decompressor)
+	}
 	return io.ReadAll(reader.fileHandle)
 }
@@ -260,3 +260,39 @@ func TestLocalFileReaderImpl_ReadAll(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalFileReaderImpl_ReaderAt(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "test_file_*.txt")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.WriteString("This is a test file content."); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	reader, err := NewLocalFileReader(tmpFile.Name())
+	assert.NoError(t, err)
+	defer reader.Close()
+	r, size, err := reader.ReaderAt(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(28), size)
+	b := make([]byte, 4)
+	_, err = r.ReadAt(b, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", string(b))
+
+	// Compressed files can't be read at random offsets.
+	gzFile, err := os.CreateTemp("", "test_file_*.gz")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(gzFile.Name())
+	if _, err := gzFile.Write([]byte{0x1f, 0x8b, 0x08, 0x00}); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	reader, err = NewLocalFileReader(gzFile.Name())
+	assert.NoError(t, err)
+	defer reader.Close()
+	_, _, err = reader.ReaderAt(context.Background())
+	assert.Error(t, err)
+}
@@ -19,6 +19,7 @@ var NewCsvData = newCsvData
 
 type CsvData interface {
 	ImportData(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface) error
+	ImportFiles(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface, uris []string, workers int) ([]FileResult, error)
 }
 
 type CsvDataImpl struct {
@@ -76,6 +77,41 @@ func (source *CsvDataImpl) ImportData(ctx context.Context, spannerInfoSchema *sp
 	return err
 }
 
+// ImportFiles imports several CSV files with the same columns into the table
+// on a pool of workers, and returns the outcome of each file.
+func (source *CsvDataImpl) ImportFiles(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface, uris []string, workers int) ([]FileResult, error) {
+	conv = getConvObject(source.ProjectId, source.InstanceId, dialect, conv)
+	batchWriter := writer.GetBatchWriterWithConfig(ctx, spannerInfoSchema.SpannerClient, conv)
+
+	err := spannerInfoSchema.PopulateSpannerSchema(ctx, conv, commonInfoSchema)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to read Spanner schema %v", err))
+		return nil, err
+	}
+
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, source.TableName)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Table %s not found in Spanner", source.TableName))
+		return nil, err
+	}
+	columnNames := []string{}
+	for _, v := range conv.SpSchema[tableId].ColIds {
+		columnNames = append(columnNames, conv.SpSchema[tableId].ColDefs[v].Name)
+	}
+
+	results := importFiles(ctx, conv, batchWriter, source.TableName, uris, workers,
+		func(ctx context.Context, fileConv *internal.Conv, reader file_reader.FileReader) error {
+			sourceIoReader, err := reader.CreateReader(ctx)
+			if err != nil {
+				return err
+			}
+			return csv.ProcessSingleCSV(fileConv, source.TableName, columnNames,
+				conv.SpSchema[tableId].ColDefs, sourceIoReader, "", rune(source.CsvFieldDelimiter[0]))
+		})
+	batchWriter.Flush()
+	return results, nil
+}
+
 func getConvObject(projectId, instanceId, dialect string, conv *internal.Conv) *internal.Conv {
 	conv.Audit.MigrationType = migration.MigrationData_DATA_ONLY.Enum()
 	conv.Audit.SkipMetricsPopulation = true
@@ -14,6 +14,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/parse"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
 	adminpb "google.golang.org/genproto/googleapis/spanner/admin/database/v1"
 )
 
@@ -30,16 +31,24 @@ type CsvSchemaImpl struct {
 	TableName        string
 	SchemaUri        string
 	SchemaFileReader file_reader.FileReader
+	// SpannerInfoSchema reads the columns of the table if it already exists.
+	SpannerInfoSchema *spanner.InfoSchemaImpl
+	// AllowSchemaDrift continues the import when the columns of an existing
+	// table differ from the schema file, instead of failing.
+	AllowSchemaDrift bool
 }
 
-func newCsvSchema(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader) CsvSchema {
+func newCsvSchema(projectId, instanceId, dbName, tableName, schemaUri string, schemaFileReader file_reader.FileReader,
+	spannerInfoSchema *spanner.InfoSchemaImpl, allowSchemaDrift bool) CsvSchema {
 	return &CsvSchemaImpl{
-		ProjectId:        projectId,
-		InstanceId:       instanceId,
-		DbName:           dbName,
-		TableName:        tableName,
-		SchemaUri:        schemaUri,
-		SchemaFileReader: schemaFileReader,
+		ProjectId:         projectId,
+		InstanceId:        instanceId,
+		DbName:            dbName,
+		TableName:         tableName,
+		SchemaUri:         schemaUri,
+		SchemaFileReader:  schemaFileReader,
+		SpannerInfoSchema: spannerInfoSchema,
+		AllowSchemaDrift:  allowSchemaDrift,
 	}
 }
 
@@ -56,6 +65,9 @@ type PrimaryKey struct {
 	PkOrder int // defines the order in the PK for the table, 0 means absence.
 }
 
+// CreateSchema creates the table with the columns of the schema file. If the
+// table already exists, its columns are compared with the schema file, and
+// any difference fails the import unless AllowSchemaDrift is set.
 func (source *CsvSchemaImpl) CreateSchema(ctx context.Context, dialect string, sp spanneraccessor.SpannerAccessor) error {
 
 	dbURI := fmt.Sprintf("projects/%s/instances/%s/databases/%s", source.ProjectId, source.InstanceId, source.DbName)
@@ -80,9 +92,7 @@ func (source *CsvSchemaImpl) CreateSchema(ctx context.Context, dialect string, s
 
 	if dbExists {
 		logger.Log.Info(fmt.Sprintf("table %s exists ", source.TableName))
-		// if exists, verify table schema is same as passed
-		// TODO: validate schema matches
-		return nil
+		return source.checkSchemaDrift(colDef)
 	}
 
 	ddl := getCreateTableStmt(source.TableName, colDef, dialect)
@@ -104,6 +114,25 @@ func (source *CsvSchemaImpl) CreateSchema(ctx context.Context, dialect string, s
 	return nil
 }
 
+// checkSchemaDrift compares the columns of the schema file with the columns
+// of the existing table.
+func (source *CsvSchemaImpl) checkSchemaDrift(colDef []ColumnDefinition) error {
+	tableCols, err := getSpannerTableColumns(source.SpannerInfoSchema, source.TableName)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to read columns of table %s %v", source.TableName, err))
+		return err
+	}
+	drift := getSchemaDrift(colDef, tableCols)
+	if drift.IsEmpty() {
+		return nil
+	}
+	if source.AllowSchemaDrift {
+		logger.Log.Warn(fmt.Sprintf("Schema file %s doesn't match existing table %s, continuing: %s", source.SchemaUri, source.TableName, drift))
+		return nil
+	}
+	return fmt.Errorf("schema file %s doesn't match existing table %s: %s", source.SchemaUri, source.TableName, drift)
+}
+
 func parseSchema(schemaFile []byte) ([]ColumnDefinition, error) {
 
 	var schema []ColumnDefinition
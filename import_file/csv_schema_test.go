@@ -66,16 +66,51 @@ func TestCsvSchemaImpl_CreateSchema(t *testing.T) {
 				DbName:     "test-db",
 				TableName:  "test-table",
 				SchemaUri:  "../test_data/basic_csv_schema.json",
+				SpannerInfoSchema: getTableInfoSchemaMock([][]string{
+					{"c3", "INT64", "NO"},
+					{"c4", "STRING(250)", "NO"},
+				}, []string{"c3"}),
 			},
-			dialect: constants.DIALECT_GOOGLESQL,
-			spannerClientMock: getSpannerClientMock(&spannerclient.RowIteratorMock{
-				NextMock: func() (*spanner.Row, error) {
-					return &spanner.Row{}, nil
-				},
-				StopMock: func() {},
-			}),
-			adminClientMock: getSpannerAdminClientMock(nil),
-			wantErr:         false,
+			dialect:           constants.DIALECT_GOOGLESQL,
+			spannerClientMock: getSpannerClientMock(getTableExistsRowIteratorMock()),
+			adminClientMock:   getSpannerAdminClientMock(nil),
+			wantErr:           false,
+		},
+		{
+			name: "table exists with different columns",
+			source: CsvSchemaImpl{
+				ProjectId:  "test-project",
+				InstanceId: "test-instance",
+				DbName:     "test-db",
+				TableName:  "test-table",
+				SchemaUri:  "../test_data/basic_csv_schema.json",
+				SpannerInfoSchema: getTableInfoSchemaMock([][]string{
+					{"c3", "INT64", "NO"},
+					{"c4", "STRING(MAX)", "YES"},
+				}, []string{"c4", "c3"}),
+			},
+			dialect:           constants.DIALECT_GOOGLESQL,
+			spannerClientMock: getSpannerClientMock(getTableExistsRowIteratorMock()),
+			adminClientMock:   getSpannerAdminClientMock(nil),
+			wantErr:           true,
+		},
+		{
+			name: "table exists with different columns and drift allowed",
+			source: CsvSchemaImpl{
+				ProjectId:  "test-project",
+				InstanceId: "test-instance",
+				DbName:     "test-db",
+				TableName:  "test-table",
+				SchemaUri:  "../test_data/basic_csv_schema.json",
+				SpannerInfoSchema: getTableInfoSchemaMock([][]string{
+					{"c3", "INT64", "NO"},
+				}, []string{"c3"}),
+				AllowSchemaDrift: true,
+			},
+			dialect:           constants.DIALECT_GOOGLESQL,
+			spannerClientMock: getSpannerClientMock(getTableExistsRowIteratorMock()),
+			adminClientMock:   getSpannerAdminClientMock(nil),
+			wantErr:           false,
 		},
 		{
 			name: "update database ddl error",
@@ -162,6 +197,15 @@ func getSpannerClientMock(riMock *spannerclient.RowIteratorMock) spannerclient.S
 	}
 }
 
+func getTableExistsRowIteratorMock() *spannerclient.RowIteratorMock {
+	return &spannerclient.RowIteratorMock{
+		NextMock: func() (*spanner.Row, error) {
+			return &spanner.Row{}, nil
+		},
+		StopMock: func() {},
+	}
+}
+
 func getDefaultRowIteratoMock() *spannerclient.RowIteratorMock {
 	return &spannerclient.RowIteratorMock{
 		NextMock: func() (*spanner.Row, error) {
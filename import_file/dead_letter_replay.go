@@ -0,0 +1,141 @@
+package import_file
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/dead_letter"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/csv"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
+)
+
+var NewDeadLetterReplay = newDeadLetterReplay
+
+// DeadLetterReplay writes the rows of a dead-letter file to the existing
+// tables of a Spanner database, once they have been corrected.
+type DeadLetterReplay interface {
+	ImportData(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error
+}
+
+type DeadLetterReplayImpl struct {
+	ProjectId        string
+	InstanceId       string
+	DbName           string
+	SourceUri        string
+	SourceFileReader file_reader.FileReader
+}
+
+func newDeadLetterReplay(projectId, instanceId, dbName, sourceUri string, sourceFileReader file_reader.FileReader) DeadLetterReplay {
+	return &DeadLetterReplayImpl{
+		ProjectId:        projectId,
+		InstanceId:       instanceId,
+		DbName:           dbName,
+		SourceUri:        sourceUri,
+		SourceFileReader: sourceFileReader,
+	}
+}
+
+// ImportData writes every row of the dead-letter file to the Spanner table
+// named by the row. Values are converted to the types of the columns with
+// the rules of CSV files, except for bytes, which are base64 encoded. Rows of tables or columns that don't exist, or
+// with values that still can't be converted, are counted as bad rows and
+// sent to conv.DeadLetter, if set.
+func (source *DeadLetterReplayImpl) ImportData(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error {
+	r, err := source.SourceFileReader.CreateReader(ctx)
+	if err != nil {
+		return fmt.Errorf("can't read dead-letter file %s: %v", source.SourceUri, err)
+	}
+
+	conv = getConvObject(source.ProjectId, source.InstanceId, dialect, conv)
+	batchWriter := writer.GetBatchWriterWithConfig(ctx, spannerInfoSchema.SpannerClient, conv)
+
+	err = spannerInfoSchema.PopulateSpannerSchema(ctx, conv, commonInfoSchema)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to read Spanner schema %v", err))
+		return err
+	}
+
+	reader := dead_letter.NewReader(r, dead_letter.GetFormat(source.SourceUri))
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			batchWriter.Flush()
+			return err
+		}
+		replayRow(conv, row)
+	}
+	batchWriter.Flush()
+
+	dropped := int64(0)
+	for _, n := range batchWriter.DroppedRowsByTable() {
+		dropped += n
+	}
+	logger.Log.Info(fmt.Sprintf("Read %d rows of %s: %d rows couldn't be converted and %d rows couldn't be written",
+		conv.Rows(), source.SourceUri, conv.BadRows(), dropped))
+	return nil
+}
+
+// replayRow converts the values of row and writes it to its table.
+func replayRow(conv *internal.Conv, row internal.DeadLetterRow) {
+	conv.StatsAddRow(row.Table, conv.DataMode())
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, row.Table)
+	if err != nil {
+		err = fmt.Errorf("table %s not found in Spanner", row.Table)
+	} else {
+		colDefs := conv.SpSchema[tableId].ColDefs
+		var vals []interface{}
+		vals, err = csv.ConvertRow(conv.SpDialect, row.Cols, colDefs, row.Vals)
+		if err == nil {
+			err = decodeBytes(row.Cols, colDefs, vals)
+		}
+		if err == nil {
+			conv.WriteRow(row.Table, row.Table, row.Cols, vals)
+			return
+		}
+	}
+	logger.Log.Error(fmt.Sprintf("Can't replay row of table %s: %v", row.Table, err))
+	conv.StatsAddBadRow(row.Table, conv.DataMode())
+	conv.CollectBadDeadLetterRow(row, err)
+}
+
+// decodeBytes decodes the values of the BYTES columns among vals, which are
+// base64 encoded in dead-letter files.
+func decodeBytes(cols []string, colDefs map[string]ddl.ColumnDef, vals []interface{}) error {
+	for i, c := range cols {
+		colId, err := internal.GetColIdFromSpName(colDefs, c)
+		if err != nil || colDefs[colId].T.Name != ddl.Bytes {
+			continue
+		}
+		switch v := vals[i].(type) {
+		case []byte:
+			b, err := base64.StdEncoding.DecodeString(string(v))
+			if err != nil {
+				return fmt.Errorf("can't decode base64 value of column %s: %w", c, err)
+			}
+			vals[i] = b
+		case [][]byte:
+			for j, e := range v {
+				if e == nil {
+					continue
+				}
+				b, err := base64.StdEncoding.DecodeString(string(e))
+				if err != nil {
+					return fmt.Errorf("can't decode base64 value of column %s: %w", c, err)
+				}
+				v[j] = b
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package import_file
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+type testDeadLetterSink []internal.DeadLetterRow
+
+func (s *testDeadLetterSink) Write(row internal.DeadLetterRow) {
+	*s = append(*s, row)
+}
+
+func Test_replayRow(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:   "users",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c3": {Name: "data", Id: "c3", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+				"c4": {Name: "chunks", Id: "c4", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength, IsArray: true}},
+			},
+		},
+	}
+	var written [][]interface{}
+	conv.SetDataMode()
+	conv.SetDataSink(func(table string, cols []string, vals []interface{}) {
+		assert.Equal(t, "users", table)
+		written = append(written, vals)
+	})
+	sink := &testDeadLetterSink{}
+	conv.DeadLetter = sink
+
+	replayRow(conv, internal.DeadLetterRow{Stage: internal.DeadLetterWrite, Table: "users", Cols: []string{"id", "name"}, Vals: []string{"1", ""}})
+	replayRow(conv, internal.DeadLetterRow{Stage: internal.DeadLetterConversion, Table: "users", Cols: []string{"id"}, Vals: []string{"one"}})
+	replayRow(conv, internal.DeadLetterRow{Stage: internal.DeadLetterWrite, Table: "orders", Cols: []string{"id"}, Vals: []string{"1"}})
+	// Bytes are base64 encoded.
+	replayRow(conv, internal.DeadLetterRow{Stage: internal.DeadLetterWrite, Table: "users", Cols: []string{"id", "data", "chunks"}, Vals: []string{"2", "/wBh", "[YQ==,NULL]"}})
+	replayRow(conv, internal.DeadLetterRow{Stage: internal.DeadLetterWrite, Table: "users", Cols: []string{"id", "data"}, Vals: []string{"3", "not base64"}})
+
+	assert.Equal(t, [][]interface{}{{int64(1), ""}, {int64(2), []byte{0xff, 0x00, 'a'}, [][]byte{[]byte("a"), nil}}}, written)
+	assert.Equal(t, int64(5), conv.Rows())
+	assert.Equal(t, int64(3), conv.BadRows())
+	assert.Equal(t, 3, len(*sink))
+	assert.Equal(t, internal.DeadLetterConversion, (*sink)[0].Stage)
+	assert.Contains(t, (*sink)[0].Error, "can't convert to int64")
+	assert.Equal(t, "table orders not found in Spanner", (*sink)[1].Error)
+	assert.Contains(t, (*sink)[2].Error, "can't decode base64 value of column data")
+}
+
+func Test_replayRowTwice(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:   "users",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "data", Id: "c2", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			},
+		},
+	}
+	// The schema of the database is also the source schema of the replay.
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "users", Id: "t1", ColIds: []string{"c1", "c2"}, ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "INT64"}},
+			"c2": {Name: "data", Id: "c2", Type: schema.Type{Name: "BYTES"}},
+		}},
+	}
+	var written [][]interface{}
+	conv.SetDataMode()
+	conv.SetDataSink(func(table string, cols []string, vals []interface{}) {
+		written = append(written, vals)
+	})
+	sink := &testDeadLetterSink{}
+	conv.DeadLetter = sink
+
+	// The rows rejected by a replay are written to the new dead-letter file
+	// as they were read, so that they can be replayed again.
+	replayRow(conv, internal.DeadLetterRow{Stage: internal.DeadLetterConversion, Table: "users", Cols: []string{"id", "data"}, Vals: []string{"one", "/wBh"}})
+	assert.Equal(t, 1, len(*sink))
+	rejected := (*sink)[0]
+	assert.Equal(t, []string{"one", "/wBh"}, rejected.Vals)
+
+	rejected.Vals[0] = "1"
+	replayRow(conv, rejected)
+	assert.Equal(t, [][]interface{}{{int64(1), []byte{0xff, 0x00, 'a'}}}, written)
+	assert.Equal(t, 1, len(*sink))
+}
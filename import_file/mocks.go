@@ -23,7 +23,8 @@ func (m *MockCsvSchema) CreateSchema(ctx context.Context, dialect string, sp spa
 
 // MockCsvData for testing.
 type MockCsvData struct {
-	ImportDataFn func(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface) error
+	ImportDataFn  func(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface) error
+	ImportFilesFn func(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface, uris []string, workers int) ([]FileResult, error)
 }
 
 func (m *MockCsvData) ImportData(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface) error {
@@ -32,3 +33,50 @@ func (m *MockCsvData) ImportData(ctx context.Context, spannerInfoSchema *spanner
 	}
 	return nil
 }
+
+func (m *MockCsvData) ImportFiles(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, csv csv.CsvInterface, uris []string, workers int) ([]FileResult, error) {
+	if m.ImportFilesFn != nil {
+		return m.ImportFilesFn(ctx, spannerInfoSchema, dialect, conv, commonInfoSchema, csv, uris, workers)
+	}
+	return nil, nil
+}
+
+// MockRecordFile for testing.
+type MockRecordFile struct {
+	CreateSchemaFn func(ctx context.Context, dialect string, sp spanneraccessor.SpannerAccessor) error
+	ImportDataFn   func(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error
+	ImportFilesFn  func(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, uris []string, workers int) ([]FileResult, error)
+}
+
+func (m *MockRecordFile) CreateSchema(ctx context.Context, dialect string, sp spanneraccessor.SpannerAccessor) error {
+	if m.CreateSchemaFn != nil {
+		return m.CreateSchemaFn(ctx, dialect, sp)
+	}
+	return nil
+}
+
+func (m *MockRecordFile) ImportData(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error {
+	if m.ImportDataFn != nil {
+		return m.ImportDataFn(ctx, spannerInfoSchema, dialect, conv, commonInfoSchema)
+	}
+	return nil
+}
+
+func (m *MockRecordFile) ImportFiles(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, uris []string, workers int) ([]FileResult, error) {
+	if m.ImportFilesFn != nil {
+		return m.ImportFilesFn(ctx, spannerInfoSchema, dialect, conv, commonInfoSchema, uris, workers)
+	}
+	return nil, nil
+}
+
+// MockDeadLetterReplay for testing.
+type MockDeadLetterReplay struct {
+	ImportDataFn func(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error
+}
+
+func (m *MockDeadLetterReplay) ImportData(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error {
+	if m.ImportDataFn != nil {
+		return m.ImportDataFn(ctx, spannerInfoSchema, dialect, conv, commonInfoSchema)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package import_file
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/task"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
+)
+
+// DefaultFileWorkers is the default number of files that are imported
+// concurrently when the source URI matches several files.
+const DefaultFileWorkers = 4
+
+// FileResult is the outcome of importing one of several source files.
+type FileResult struct {
+	Uri      string
+	GoodRows int64 // Rows converted and sent to Spanner.
+	BadRows  int64 // Rows that couldn't be converted.
+	Err      error // Error that stopped the import of the file, if any.
+}
+
+// importFiles imports the files at uris into a single table on a pool of
+// workers, and returns the outcome of each file in the order of uris.
+//
+// Each file is converted with its own copy of conv, so that rows are counted
+// per file, and its rows are written to the shared batchWriter. The row counts
+// of each file are added to the stats of conv when it completes. A file that
+// fails doesn't stop the import of the other files.
+func importFiles(ctx context.Context, conv *internal.Conv, batchWriter *writer.BatchWriter, tableName string, uris []string, workers int,
+	importFile func(ctx context.Context, fileConv *internal.Conv, reader file_reader.FileReader) error) []FileResult {
+	if workers < 1 {
+		workers = DefaultFileWorkers
+	}
+	logger.Log.Info(fmt.Sprintf("importing %d files into table %s using %d workers", len(uris), tableName, workers))
+	var writeMutex sync.Mutex
+	write := func(table string, cols []string, vals []interface{}) {
+		writeMutex.Lock()
+		defer writeMutex.Unlock()
+		batchWriter.AddRow(table, cols, vals)
+	}
+	asyncImportFile := func(uri string, mutex *sync.Mutex) task.TaskResult[FileResult] {
+		fileConv := newFileConv(conv, write)
+		res := FileResult{Uri: uri}
+		reader, err := file_reader.NewFileReader(ctx, uri)
+		if err == nil {
+			err = importFile(ctx, fileConv, reader)
+			reader.Close()
+		}
+		res.GoodRows = fileConv.Stats.GoodRows[tableName]
+		res.BadRows = fileConv.Stats.BadRows[tableName]
+		res.Err = err
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		for t, n := range fileConv.Stats.Rows {
+			conv.Stats.Rows[t] += n
+		}
+		for t, n := range fileConv.Stats.GoodRows {
+			conv.Stats.GoodRows[t] += n
+		}
+		for t, n := range fileConv.Stats.BadRows {
+			conv.Stats.BadRows[t] += n
+		}
+		return task.TaskResult[FileResult]{Result: res, Err: err}
+	}
+
+	r := task.RunParallelTasksImpl[string, FileResult]{}
+	taskResults, _ := r.RunParallelTasks(uris, workers, asyncImportFile, false)
+
+	// Results are returned in the order in which files completed.
+	results := make([]FileResult, len(uris))
+	index := map[string]int{}
+	for i, uri := range uris {
+		index[uri] = i
+	}
+	for _, tr := range taskResults {
+		results[index[tr.Result.Uri]] = tr.Result
+	}
+	return results
+}
+
+// newFileConv returns a conv for converting the rows of a single file, with
+// the schemas and timezone policies of conv, that writes rows with write.
+func newFileConv(conv *internal.Conv, write func(table string, cols []string, vals []interface{})) *internal.Conv {
+	fileConv := internal.MakeConv()
+	fileConv.SrcSchema = conv.SrcSchema
+	fileConv.SpSchema = conv.SpSchema
+	fileConv.TimezonePolicies = conv.TimezonePolicies
+	fileConv.SpDialect = conv.SpDialect
+	fileConv.SpProjectId = conv.SpProjectId
+	fileConv.SpInstanceId = conv.SpInstanceId
+	fileConv.Audit.MigrationType = conv.Audit.MigrationType
+	fileConv.Audit.DryRun = conv.Audit.DryRun
+	fileConv.DeadLetter = conv.DeadLetter
+	fileConv.SetDataMode()
+	fileConv.SetDataSink(write)
+	return fileConv
+}
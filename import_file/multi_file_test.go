@@ -0,0 +1,135 @@
+package import_file
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_importFiles(t *testing.T) {
+	dir := t.TempDir()
+	var uris []string
+	for i, content := range []string{"a\nb\nc\n", "d\nbad\n", "e\n"} {
+		uri := filepath.Join(dir, fmt.Sprintf("part-%d.txt", i))
+		assert.Nil(t, os.WriteFile(uri, []byte(content), 0644))
+		uris = append(uris, uri)
+	}
+	uris = append(uris, filepath.Join(dir, "missing.txt"))
+
+	var mu sync.Mutex
+	var written int
+	batchWriter := writer.NewBatchWriter(writer.BatchWriterConfig{
+		WriteLimit: 10,
+		BytesLimit: 1000,
+		RetryLimit: 10,
+		Write: func(m []*sp.Mutation) error {
+			mu.Lock()
+			defer mu.Unlock()
+			written += len(m)
+			return nil
+		},
+	})
+	conv := internal.MakeConv()
+	conv.SetDataMode()
+
+	// Each line of the files is a row, and "bad" lines can't be converted.
+	results := importFiles(context.Background(), conv, batchWriter, "t", uris, 2,
+		func(ctx context.Context, fileConv *internal.Conv, reader file_reader.FileReader) error {
+			r, err := reader.CreateReader(ctx)
+			if err != nil {
+				return err
+			}
+			b, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			for _, line := range strings.Fields(string(b)) {
+				if line == "bad" {
+					fileConv.StatsAddBadRow("t", fileConv.DataMode())
+					continue
+				}
+				fileConv.WriteRow("t", "t", []string{"c"}, []interface{}{line})
+			}
+			return nil
+		})
+	batchWriter.Flush()
+
+	assert.Equal(t, 4, len(results))
+	for i, r := range results {
+		assert.Equal(t, uris[i], r.Uri)
+	}
+	assert.Equal(t, []int64{3, 1, 1, 0}, []int64{results[0].GoodRows, results[1].GoodRows, results[2].GoodRows, results[3].GoodRows})
+	assert.Equal(t, int64(1), results[1].BadRows)
+	assert.Nil(t, results[0].Err)
+	assert.NotNil(t, results[3].Err)
+	assert.Equal(t, int64(5), conv.Stats.GoodRows["t"])
+	assert.Equal(t, int64(1), conv.Stats.BadRows["t"])
+	assert.Equal(t, 5, written)
+}
+
+func Test_importFilesDeadLetter(t *testing.T) {
+	dir := t.TempDir()
+	var uris []string
+	for i := 0; i < 2; i++ {
+		uri := filepath.Join(dir, fmt.Sprintf("part-%d.txt", i))
+		assert.Nil(t, os.WriteFile(uri, []byte("bad\n"), 0644))
+		uris = append(uris, uri)
+	}
+	batchWriter := writer.NewBatchWriter(writer.BatchWriterConfig{
+		WriteLimit: 10,
+		BytesLimit: 1000,
+		RetryLimit: 10,
+		Write:      func(m []*sp.Mutation) error { return nil },
+	})
+	conv := internal.MakeConv()
+	conv.SetDataMode()
+	conv.SrcSchema = map[string]schema.Table{"t1": {Name: "t", Id: "t1", ColIds: []string{"c1", "c2"}, ColDefs: map[string]schema.Column{
+		"c1": {Name: "c", Id: "c1", Type: schema.Type{Name: "INT64"}},
+		"c2": {Name: "data", Id: "c2", Type: schema.Type{Name: "BYTES"}},
+	}}}
+	conv.SpSchema = ddl.Schema{"t1": {Name: "t", Id: "t1", ColIds: []string{"c1", "c2"}, ColDefs: map[string]ddl.ColumnDef{
+		"c1": {Name: "c", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+		"c2": {Name: "data", Id: "c2", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+	}}}
+	sink := &lockedDeadLetterSink{}
+	conv.DeadLetter = sink
+
+	// The values of BYTES columns of the rows rejected by each file are
+	// base64 encoded, as they are for a single file.
+	importFiles(context.Background(), conv, batchWriter, "t", uris, 2,
+		func(ctx context.Context, fileConv *internal.Conv, reader file_reader.FileReader) error {
+			fileConv.CollectBadRow("t", []string{"c", "data"}, []string{"bad", "\xff"}, fmt.Errorf("can't convert"))
+			return nil
+		})
+
+	assert.Equal(t, testDeadLetterSink{
+		{Stage: internal.DeadLetterConversion, Table: "t", Cols: []string{"c", "data"}, Vals: []string{"bad", "/w=="}, Error: "can't convert"},
+		{Stage: internal.DeadLetterConversion, Table: "t", Cols: []string{"c", "data"}, Vals: []string{"bad", "/w=="}, Error: "can't convert"},
+	}, sink.rows)
+}
+
+// lockedDeadLetterSink is a testDeadLetterSink that files can write to
+// concurrently.
+type lockedDeadLetterSink struct {
+	mu   sync.Mutex
+	rows testDeadLetterSink
+}
+
+func (s *lockedDeadLetterSink) Write(row internal.DeadLetterRow) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows.Write(row)
+}
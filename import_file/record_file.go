@@ -0,0 +1,507 @@
+package import_file
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/parse"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/avro"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/parquet"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
+	adminpb "google.golang.org/genproto/googleapis/spanner/admin/database/v1"
+)
+
+var NewRecordFile = newRecordFile
+
+// RecordFile imports a file whose format embeds its schema, such as Parquet
+// or Avro, into a single Spanner table.
+type RecordFile interface {
+	CreateSchema(ctx context.Context, dialect string, sp spanneraccessor.SpannerAccessor) error
+	ImportData(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error
+	ImportFiles(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, uris []string, workers int) ([]FileResult, error)
+}
+
+type RecordFileImpl struct {
+	ProjectId        string
+	InstanceId       string
+	DbName           string
+	TableName        string
+	SourceFormat     string
+	PrimaryKeys      []string // Columns of the primary key of a created table. A synthetic key is added if empty.
+	SourceFileReader file_reader.FileReader
+}
+
+func newRecordFile(projectId, instanceId, dbName, tableName, sourceFormat string, primaryKeys []string, sourceFileReader file_reader.FileReader) RecordFile {
+	return &RecordFileImpl{
+		ProjectId:        projectId,
+		InstanceId:       instanceId,
+		DbName:           dbName,
+		TableName:        tableName,
+		SourceFormat:     sourceFormat,
+		PrimaryKeys:      primaryKeys,
+		SourceFileReader: sourceFileReader,
+	}
+}
+
+// CreateSchema creates the table with the columns of the file's schema, if
+// it doesn't exist. The columns of an existing table are validated against
+// the file's schema when importing the data.
+func (source *RecordFileImpl) CreateSchema(ctx context.Context, dialect string, sp spanneraccessor.SpannerAccessor) error {
+	dbURI := fmt.Sprintf("projects/%s/instances/%s/databases/%s", source.ProjectId, source.InstanceId, source.DbName)
+
+	reader, err := openRecordReader(ctx, source.SourceFormat, source.SourceFileReader)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to read %s file %v", source.SourceFormat, err))
+		return err
+	}
+
+	tableExists, err := sp.TableExists(ctx, source.TableName)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to check existing schema %v", err))
+		return err
+	}
+	if tableExists {
+		logger.Log.Info(fmt.Sprintf("table %s exists ", source.TableName))
+		return nil
+	}
+
+	ct, err := getRecordFileTable(source.TableName, reader.Columns(), source.PrimaryKeys)
+	if err != nil {
+		return err
+	}
+	stmt := ct.PrintCreateTable(ddl.Schema{}, ddl.Config{ProtectIds: true, SpDialect: dialect})
+	logger.Log.Debug(fmt.Sprintf("create table cmd %s ==", stmt))
+
+	req := &adminpb.UpdateDatabaseDdlRequest{
+		Database:   dbURI,
+		Statements: []string{stmt},
+	}
+	op, err := sp.GetSpannerAdminClient().UpdateDatabaseDdl(ctx, req)
+	if err != nil {
+		return fmt.Errorf("can't build UpdateDatabaseDdlRequest: %w", parse.AnalyzeError(err, dbURI))
+	}
+	if err := op.Wait(ctx); err != nil {
+		return fmt.Errorf("UpdateDatabaseDdl call failed: %w", parse.AnalyzeError(err, dbURI))
+	}
+
+	logger.Log.Info(fmt.Sprintf("Created table %v successfully\n", source.TableName))
+	return nil
+}
+
+// ImportData writes the rows of the file to the table.
+func (source *RecordFileImpl) ImportData(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error {
+	reader, err := openRecordReader(ctx, source.SourceFormat, source.SourceFileReader)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to read %s file %v", source.SourceFormat, err))
+		return err
+	}
+
+	conv = getConvObject(source.ProjectId, source.InstanceId, dialect, conv)
+	batchWriter := writer.GetBatchWriterWithConfig(ctx, spannerInfoSchema.SpannerClient, conv)
+
+	err = spannerInfoSchema.PopulateSpannerSchema(ctx, conv, commonInfoSchema)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to read Spanner schema %v", err))
+		return err
+	}
+
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, source.TableName)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Table %s not found in Spanner", source.TableName))
+		return err
+	}
+	if err := source.writeRecords(conv, conv.SpSchema[tableId], reader); err != nil {
+		return err
+	}
+	batchWriter.Flush()
+	return nil
+}
+
+// ImportFiles imports several files into the table on a pool of workers,
+// and returns the outcome of each file. The columns of each file are
+// validated against the table separately.
+func (source *RecordFileImpl) ImportFiles(ctx context.Context, spannerInfoSchema *spanner.InfoSchemaImpl, dialect string, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface, uris []string, workers int) ([]FileResult, error) {
+	conv = getConvObject(source.ProjectId, source.InstanceId, dialect, conv)
+	batchWriter := writer.GetBatchWriterWithConfig(ctx, spannerInfoSchema.SpannerClient, conv)
+
+	err := spannerInfoSchema.PopulateSpannerSchema(ctx, conv, commonInfoSchema)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to read Spanner schema %v", err))
+		return nil, err
+	}
+
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, source.TableName)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Table %s not found in Spanner", source.TableName))
+		return nil, err
+	}
+	results := importFiles(ctx, conv, batchWriter, source.TableName, uris, workers,
+		func(ctx context.Context, fileConv *internal.Conv, fileReader file_reader.FileReader) error {
+			reader, err := openRecordReader(ctx, source.SourceFormat, fileReader)
+			if err != nil {
+				return err
+			}
+			return source.writeRecords(fileConv, conv.SpSchema[tableId], reader)
+		})
+	batchWriter.Flush()
+	return results, nil
+}
+
+// writeRecords writes the rows read by reader to table. Rows with values
+// that can't be converted to the types of the table's columns are skipped
+// and counted as bad rows.
+func (source *RecordFileImpl) writeRecords(conv *internal.Conv, table ddl.CreateTable, reader common.RecordReader) error {
+	colDefs, err := matchRecordFileColumns(table, reader.Columns())
+	if err != nil {
+		return err
+	}
+	columnNames := []string{}
+	for _, cd := range colDefs {
+		columnNames = append(columnNames, cd.Name)
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("can't read row for file due to: %v", err)
+		}
+		values, err := convertRecordRow(conv.SpDialect, colDefs, row)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("Error while converting data: %s\n", err))
+			conv.StatsAddBadRow(source.TableName, conv.DataMode())
+			cols, vals := recordRowStrings(columnNames, row)
+			conv.CollectBadRow(source.TableName, cols, vals, err)
+			continue
+		}
+		conv.WriteRow(source.TableName, source.TableName, columnNames, values)
+	}
+	return nil
+}
+
+// openRecordReader opens a reader of the file from its start. Parquet files
+// are read from their end and at the offsets of their column chunks, so they
+// are read with random access, which isn't possible for compressed files.
+func openRecordReader(ctx context.Context, sourceFormat string, fileReader file_reader.FileReader) (common.RecordReader, error) {
+	switch sourceFormat {
+	case constants.PARQUET:
+		r, size, err := fileReader.ReaderAt(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return parquet.NewReader(r, size)
+	case constants.AVRO:
+		r, err := fileReader.ResetReader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return avro.NewReader(r)
+	}
+	return nil, fmt.Errorf("format %s is not a record file format", sourceFormat)
+}
+
+// getRecordFileTable returns the table for a file with the given columns.
+// The primary key is made of primaryKeys, or is a synthetic column with
+// generated UUIDs if primaryKeys is empty.
+func getRecordFileTable(tableName string, columns []common.FileColumn, primaryKeys []string) (ddl.CreateTable, error) {
+	ct := ddl.CreateTable{Name: tableName, Id: tableName, ColDefs: map[string]ddl.ColumnDef{}}
+	for _, c := range columns {
+		if _, ok := ct.ColDefs[c.Name]; ok {
+			return ddl.CreateTable{}, fmt.Errorf("column %s appears more than once in the file", c.Name)
+		}
+		ct.ColIds = append(ct.ColIds, c.Name)
+		ct.ColDefs[c.Name] = ddl.ColumnDef{Name: c.Name, Id: c.Name, T: c.Type, NotNull: c.NotNull}
+	}
+	for i, pk := range primaryKeys {
+		cd, ok := ct.ColDefs[pk]
+		if !ok {
+			return ddl.CreateTable{}, fmt.Errorf("primary key column %s is not a column of the file", pk)
+		}
+		if cd.T.IsArray {
+			return ddl.CreateTable{}, fmt.Errorf("primary key column %s is an array", pk)
+		}
+		ct.PrimaryKeys = append(ct.PrimaryKeys, ddl.IndexKey{ColId: pk, Order: i + 1})
+	}
+	if len(primaryKeys) > 0 {
+		return ct, nil
+	}
+	synthId := internal.SyntheticPrimaryKey
+	for i := 0; ; i++ {
+		if _, ok := ct.ColDefs[synthId]; !ok {
+			break
+		}
+		synthId = fmt.Sprintf("%s%d", internal.SyntheticPrimaryKey, i)
+	}
+	ct.ColIds = append(ct.ColIds, synthId)
+	ct.ColDefs[synthId] = ddl.ColumnDef{
+		Name:    synthId,
+		Id:      synthId,
+		T:       ddl.Type{Name: ddl.String, Len: 36},
+		AutoGen: ddl.AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"},
+	}
+	ct.PrimaryKeys = []ddl.IndexKey{{ColId: synthId, Order: 1}}
+	return ct, nil
+}
+
+// matchRecordFileColumns returns the columns of table that the columns of a
+// file are written to. Every column of the file must be a column of the
+// table with a compatible type, and every NOT NULL column of the table
+// without a default value must be a column of the file.
+func matchRecordFileColumns(table ddl.CreateTable, columns []common.FileColumn) ([]ddl.ColumnDef, error) {
+	var colDefs []ddl.ColumnDef
+	inFile := map[string]bool{}
+	for _, c := range columns {
+		colId, err := internal.GetColIdFromSpName(table.ColDefs, c.Name)
+		if err != nil {
+			return nil, fmt.Errorf("column %s of the file is not a column of table %s", c.Name, table.Name)
+		}
+		cd := table.ColDefs[colId]
+		if !compatibleRecordFileType(c.Type, cd.T) {
+			return nil, fmt.Errorf("column %s of the file has type %s, which can't be written to column %s of type %s",
+				c.Name, c.Type.PrintColumnDefType(false), cd.Name, cd.T.PrintColumnDefType(false))
+		}
+		inFile[colId] = true
+		colDefs = append(colDefs, cd)
+	}
+	for _, colId := range table.ColIds {
+		cd := table.ColDefs[colId]
+		if cd.NotNull && !inFile[colId] && !cd.DefaultValue.IsPresent && cd.AutoGen.GenerationType == "" && !cd.GeneratedColumn.IsPresent {
+			return nil, fmt.Errorf("NOT NULL column %s of table %s is not a column of the file", cd.Name, table.Name)
+		}
+	}
+	return colDefs, nil
+}
+
+// compatibleRecordFileType returns whether values of a file column of type
+// from can be written to a Spanner column of type to.
+func compatibleRecordFileType(from, to ddl.Type) bool {
+	if from.IsArray != to.IsArray {
+		return false
+	}
+	if from.Name == to.Name {
+		return true
+	}
+	switch to.Name {
+	case ddl.Float64:
+		return from.Name == ddl.Int64 || from.Name == ddl.Float32
+	case ddl.Numeric:
+		return from.Name == ddl.Int64
+	case ddl.String:
+		return from.Name == ddl.JSON
+	case ddl.JSON:
+		return from.Name == ddl.String
+	}
+	return false
+}
+
+// recordRowStrings returns the values of a row of a record file as strings,
+// leaving out NULL values and their columns.
+func recordRowStrings(columnNames []string, row []interface{}) ([]string, []string) {
+	var cols, vals []string
+	for i, v := range row {
+		if v == nil || i >= len(columnNames) {
+			continue
+		}
+		cols = append(cols, columnNames[i])
+		vals = append(vals, fmt.Sprint(v))
+	}
+	return cols, vals
+}
+
+func convertRecordRow(dialect string, colDefs []ddl.ColumnDef, row []interface{}) ([]interface{}, error) {
+	values := make([]interface{}, len(row))
+	for i, v := range row {
+		var err error
+		if colDefs[i].T.IsArray {
+			values[i], err = convRecordArray(dialect, colDefs[i].T, v)
+		} else {
+			values[i], err = convRecordScalar(dialect, colDefs[i].T, v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", colDefs[i].Name, err)
+		}
+	}
+	return values, nil
+}
+
+// convRecordScalar converts a value read from a record file to the Go type
+// the Spanner client expects for a column of type spannerType. NULL values
+// are returned as nil.
+func convRecordScalar(dialect string, spannerType ddl.Type, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch spannerType.Name {
+	case ddl.Bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case ddl.Bytes:
+		if b, ok := v.([]byte); ok {
+			return b, nil
+		}
+	case ddl.Date:
+		if d, ok := v.(civil.Date); ok {
+			return d, nil
+		}
+	case ddl.Float32:
+		if f, ok := v.(float32); ok {
+			return f, nil
+		}
+	case ddl.Float64:
+		switch f := v.(type) {
+		case float64:
+			return f, nil
+		case float32:
+			return float64(f), nil
+		case int64:
+			return float64(f), nil
+		}
+	case ddl.Int64:
+		if i, ok := v.(int64); ok {
+			return i, nil
+		}
+	case ddl.Numeric:
+		var r *big.Rat
+		switch n := v.(type) {
+		case *big.Rat:
+			r = n
+		case int64:
+			r = new(big.Rat).SetInt64(n)
+		}
+		if r != nil {
+			if dialect == constants.DIALECT_POSTGRESQL {
+				return sp.PGNumeric{Numeric: sp.NumericString(r), Valid: true}, nil
+			}
+			return *r, nil
+		}
+	case ddl.String, ddl.JSON:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case ddl.Timestamp:
+		if t, ok := v.(time.Time); ok {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("can't convert value of type %T to %s", v, spannerType.Name)
+}
+
+// convRecordArray converts an array read from a record file to the slice
+// of Spanner client null types for a column of type spannerType.
+func convRecordArray(dialect string, spannerType ddl.Type, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("can't convert value of type %T to an array", v)
+	}
+	elems := make([]interface{}, len(items))
+	for i, item := range items {
+		var err error
+		if elems[i], err = convRecordScalar(dialect, spannerType, item); err != nil {
+			return nil, err
+		}
+	}
+	switch spannerType.Name {
+	case ddl.Bool:
+		r := make([]sp.NullBool, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = sp.NullBool{Bool: e.(bool), Valid: true}
+			}
+		}
+		return r, nil
+	case ddl.Bytes:
+		r := make([][]byte, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = e.([]byte)
+			}
+		}
+		return r, nil
+	case ddl.Date:
+		r := make([]sp.NullDate, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = sp.NullDate{Date: e.(civil.Date), Valid: true}
+			}
+		}
+		return r, nil
+	case ddl.Float32:
+		r := make([]sp.NullFloat32, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = sp.NullFloat32{Float32: e.(float32), Valid: true}
+			}
+		}
+		return r, nil
+	case ddl.Float64:
+		r := make([]sp.NullFloat64, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = sp.NullFloat64{Float64: e.(float64), Valid: true}
+			}
+		}
+		return r, nil
+	case ddl.Int64:
+		r := make([]sp.NullInt64, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = sp.NullInt64{Int64: e.(int64), Valid: true}
+			}
+		}
+		return r, nil
+	case ddl.Numeric:
+		if dialect == constants.DIALECT_POSTGRESQL {
+			r := make([]sp.PGNumeric, len(elems))
+			for i, e := range elems {
+				if e != nil {
+					r[i] = e.(sp.PGNumeric)
+				}
+			}
+			return r, nil
+		}
+		r := make([]sp.NullNumeric, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = sp.NullNumeric{Numeric: e.(big.Rat), Valid: true}
+			}
+		}
+		return r, nil
+	case ddl.String, ddl.JSON:
+		// JSON values are written as their text, as for scalar columns.
+		r := make([]sp.NullString, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = sp.NullString{StringVal: e.(string), Valid: true}
+			}
+		}
+		return r, nil
+	case ddl.Timestamp:
+		r := make([]sp.NullTime, len(elems))
+		for i, e := range elems {
+			if e != nil {
+				r[i] = sp.NullTime{Time: e.(time.Time), Valid: true}
+			}
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("data conversion not implemented for arrays of %s", spannerType.Name)
+}
@@ -0,0 +1,199 @@
+package import_file
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	spanneradmin "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/admin"
+	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var recordFileColumns = []common.FileColumn{
+	{Name: "id", Type: ddl.Type{Name: ddl.Int64}, NotNull: true},
+	{Name: "name", Type: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+	{Name: "tags", Type: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+}
+
+func writeAvroFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "events.avro")
+	f, err := os.Create(path)
+	assert.Nil(t, err)
+	defer f.Close()
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{W: f, Schema: `{"type": "record", "name": "event", "fields": [
+		{"name": "id", "type": "long"},
+		{"name": "name", "type": ["null", "string"]}
+	]}`})
+	assert.Nil(t, err)
+	assert.Nil(t, w.Append([]interface{}{map[string]interface{}{"id": int64(1), "name": goavro.Union("string", "a")}}))
+	return path
+}
+
+func TestRecordFileImpl_CreateSchema(t *testing.T) {
+	ctx := context.Background()
+	path := writeAvroFile(t)
+
+	tests := []struct {
+		name        string
+		dialect     string
+		primaryKeys []string
+		updateErr   error
+		wantStmt    string
+		wantErr     bool
+	}{
+		{
+			name:        "primary key from the file",
+			dialect:     constants.DIALECT_GOOGLESQL,
+			primaryKeys: []string{"id"},
+			wantStmt:    "CREATE TABLE `events` (\n\t`id` INT64 NOT NULL ,\n\t`name` STRING(MAX),\n) PRIMARY KEY (`id`)",
+		},
+		{
+			name:     "synthetic primary key",
+			dialect:  constants.DIALECT_POSTGRESQL,
+			wantStmt: "CREATE TABLE \"events\" (\n\t\"id\" INT8 NOT NULL ,\n\t\"name\" VARCHAR(2621440),\n\t\"synth_id\" VARCHAR(36) DEFAULT (spanner.generate_uuid()),\n\tPRIMARY KEY (\"synth_id\")\n)",
+		},
+		{
+			name:        "unknown primary key",
+			dialect:     constants.DIALECT_GOOGLESQL,
+			primaryKeys: []string{"ts"},
+			wantErr:     true,
+		},
+		{
+			name:      "update database ddl error",
+			dialect:   constants.DIALECT_GOOGLESQL,
+			updateErr: errors.New("update error"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stmts []string
+			adminClientMock := &spanneradmin.AdminClientMock{
+				UpdateDatabaseDdlMock: func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+					stmts = req.Statements
+					return &spanneradmin.UpdateDatabaseDdlOperationMock{
+						WaitMock: func(ctx context.Context, opts ...gax.CallOption) error { return nil },
+					}, tt.updateErr
+				},
+			}
+			spannerAccessor := &spanneraccessor.SpannerAccessorImpl{SpannerClient: getSpannerClientMock(getDefaultRowIteratoMock()), AdminClient: adminClientMock}
+			fileReader, err := file_reader.NewFileReader(ctx, path)
+			assert.Nil(t, err)
+			source := NewRecordFile("test-project", "test-instance", "test-db", "events", constants.AVRO, tt.primaryKeys, fileReader)
+			err = source.CreateSchema(ctx, tt.dialect, spannerAccessor)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if tt.wantStmt != "" {
+				assert.Equal(t, []string{tt.wantStmt}, stmts)
+			}
+		})
+	}
+
+	t.Run("not an avro file", func(t *testing.T) {
+		fileReader, err := file_reader.NewFileReader(ctx, "../test_data/basic_csv_schema.json")
+		assert.Nil(t, err)
+		source := NewRecordFile("test-project", "test-instance", "test-db", "events", constants.AVRO, nil, fileReader)
+		assert.Error(t, source.CreateSchema(ctx, constants.DIALECT_GOOGLESQL, nil))
+	})
+
+	t.Run("not a parquet file", func(t *testing.T) {
+		fileReader, err := file_reader.NewFileReader(ctx, path)
+		assert.Nil(t, err)
+		source := NewRecordFile("test-project", "test-instance", "test-db", "events", constants.PARQUET, nil, fileReader)
+		assert.Error(t, source.CreateSchema(ctx, constants.DIALECT_GOOGLESQL, nil))
+	})
+}
+
+func Test_getRecordFileTable(t *testing.T) {
+	ct, err := getRecordFileTable("t", recordFileColumns, []string{"name", "id"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id", "name", "tags"}, ct.ColIds)
+	assert.Equal(t, []ddl.IndexKey{{ColId: "name", Order: 1}, {ColId: "id", Order: 2}}, ct.PrimaryKeys)
+
+	columns := append([]common.FileColumn{{Name: "synth_id", Type: ddl.Type{Name: ddl.Int64}}}, recordFileColumns...)
+	ct, err = getRecordFileTable("t", columns, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []ddl.IndexKey{{ColId: "synth_id0", Order: 1}}, ct.PrimaryKeys)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 36}, ct.ColDefs["synth_id0"].T)
+	assert.Equal(t, constants.UUID, ct.ColDefs["synth_id0"].AutoGen.Name)
+
+	_, err = getRecordFileTable("t", recordFileColumns, []string{"tags"})
+	assert.ErrorContains(t, err, "primary key column tags is an array")
+
+	_, err = getRecordFileTable("t", append(recordFileColumns, recordFileColumns[0]), nil)
+	assert.ErrorContains(t, err, "column id appears more than once")
+}
+
+func Test_matchRecordFileColumns(t *testing.T) {
+	table := ddl.CreateTable{
+		Name:   "t",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Numeric}, NotNull: true},
+			"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.JSON}},
+			"c3": {Name: "tags", Id: "c3", T: ddl.Type{Name: ddl.String, Len: 10, IsArray: true}},
+			"c4": {Name: "created", Id: "c4", T: ddl.Type{Name: ddl.Timestamp}, NotNull: true, DefaultValue: ddl.DefaultValue{IsPresent: true}},
+		},
+	}
+	colDefs, err := matchRecordFileColumns(table, recordFileColumns)
+	assert.Nil(t, err)
+	assert.Equal(t, []ddl.ColumnDef{table.ColDefs["c1"], table.ColDefs["c2"], table.ColDefs["c3"]}, colDefs)
+
+	_, err = matchRecordFileColumns(table, recordFileColumns[1:])
+	assert.ErrorContains(t, err, "NOT NULL column id of table t is not a column of the file")
+
+	_, err = matchRecordFileColumns(table, append(recordFileColumns, common.FileColumn{Name: "other", Type: ddl.Type{Name: ddl.Bool}}))
+	assert.ErrorContains(t, err, "column other of the file is not a column of table t")
+
+	_, err = matchRecordFileColumns(table, []common.FileColumn{{Name: "id", Type: ddl.Type{Name: ddl.Float64}}})
+	assert.ErrorContains(t, err, "column id of the file has type FLOAT64, which can't be written to column id of type NUMERIC")
+}
+
+func Test_convertRecordRow(t *testing.T) {
+	date := civil.Date{Year: 2024, Month: 5, Day: 6}
+	colDefs := []ddl.ColumnDef{
+		{Name: "i", T: ddl.Type{Name: ddl.Int64}},
+		{Name: "f", T: ddl.Type{Name: ddl.Float64}},
+		{Name: "n", T: ddl.Type{Name: ddl.Numeric}},
+		{Name: "d", T: ddl.Type{Name: ddl.Date, IsArray: true}},
+		{Name: "a", T: ddl.Type{Name: ddl.Numeric, IsArray: true}},
+		{Name: "s", T: ddl.Type{Name: ddl.String}},
+	}
+	row := []interface{}{int64(1), float32(0.5), big.NewRat(5, 4), []interface{}{date, nil}, []interface{}{int64(2)}, nil}
+
+	values, err := convertRecordRow(constants.DIALECT_GOOGLESQL, colDefs, row)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{
+		int64(1), 0.5, *big.NewRat(5, 4),
+		[]sp.NullDate{{Date: date, Valid: true}, {}},
+		[]sp.NullNumeric{{Numeric: *big.NewRat(2, 1), Valid: true}},
+		nil,
+	}, values)
+
+	values, err = convertRecordRow(constants.DIALECT_POSTGRESQL, colDefs, row)
+	assert.Nil(t, err)
+	assert.Equal(t, sp.PGNumeric{Numeric: "1.250000000", Valid: true}, values[2])
+	assert.Equal(t, []sp.PGNumeric{{Numeric: "2.000000000", Valid: true}}, values[4])
+
+	_, err = convertRecordRow(constants.DIALECT_GOOGLESQL, colDefs[:1], []interface{}{"x"})
+	assert.ErrorContains(t, err, "column i: can't convert value of type string to INT64")
+
+	jsonCols := []ddl.ColumnDef{{Name: "j", T: ddl.Type{Name: ddl.JSON, IsArray: true}}}
+	for _, dialect := range []string{constants.DIALECT_GOOGLESQL, constants.DIALECT_POSTGRESQL} {
+		values, err = convertRecordRow(dialect, jsonCols, []interface{}{[]interface{}{`{"a": 1}`, nil}})
+		assert.Nil(t, err, dialect)
+		assert.Equal(t, []interface{}{[]sp.NullString{{StringVal: `{"a": 1}`, Valid: true}, {}}}, values, dialect)
+	}
+}
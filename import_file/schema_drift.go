@@ -0,0 +1,199 @@
+package import_file
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// SchemaDrift lists the differences between the columns of a schema file
+// and the columns of an existing Spanner table.
+type SchemaDrift struct {
+	MissingColumns []string // Columns of the schema file that the table doesn't have.
+	ExtraColumns   []string // Columns of the table that the schema file doesn't have.
+	TypeMismatches []string // Columns whose type or nullability differ, described as "<column>: <file type> vs <table type>".
+	PrimaryKey     string   // Description of the difference in primary key columns or order, if any.
+}
+
+// IsEmpty returns whether the schema file matches the table.
+func (d SchemaDrift) IsEmpty() bool {
+	return len(d.MissingColumns) == 0 && len(d.ExtraColumns) == 0 && len(d.TypeMismatches) == 0 && d.PrimaryKey == ""
+}
+
+func (d SchemaDrift) String() string {
+	var parts []string
+	if len(d.MissingColumns) > 0 {
+		parts = append(parts, fmt.Sprintf("columns missing from the table: %s", strings.Join(d.MissingColumns, ", ")))
+	}
+	if len(d.ExtraColumns) > 0 {
+		parts = append(parts, fmt.Sprintf("columns missing from the schema file: %s", strings.Join(d.ExtraColumns, ", ")))
+	}
+	if len(d.TypeMismatches) > 0 {
+		parts = append(parts, fmt.Sprintf("columns with different types (schema file vs table): %s", strings.Join(d.TypeMismatches, ", ")))
+	}
+	if d.PrimaryKey != "" {
+		parts = append(parts, d.PrimaryKey)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// getSpannerTableColumns returns the columns of an existing Spanner table as
+// column definitions, in the order of the table.
+func getSpannerTableColumns(spannerInfoSchema *spanner.InfoSchemaImpl, tableName string) ([]ColumnDefinition, error) {
+	conv := internal.MakeConv()
+	table := common.SchemaAndName{Name: tableName}
+	primaryKeys, _, constraints, err := spannerInfoSchema.GetConstraints(conv, table)
+	if err != nil {
+		return nil, err
+	}
+	cols, colIds, err := spannerInfoSchema.GetColumns(conv, table, constraints, primaryKeys)
+	if err != nil {
+		return nil, err
+	}
+	var colDefs []ColumnDefinition
+	for _, colId := range colIds {
+		c := cols[colId]
+		cd := ColumnDefinition{Name: c.Name, Type: printSchemaType(c.Type), NotNull: c.NotNull}
+		for i, pk := range primaryKeys {
+			if pk == c.Name {
+				cd.PkOrder = i + 1
+			}
+		}
+		colDefs = append(colDefs, cd)
+	}
+	return colDefs, nil
+}
+
+// printSchemaType prints a type read from the information schema of Spanner
+// in the form used by schema files, e.g. STRING(MAX) or ARRAY<INT64>.
+func printSchemaType(t schema.Type) string {
+	s := t.Name
+	if len(t.Mods) > 0 {
+		if t.Mods[0] == ddl.MaxLength {
+			s += "(MAX)"
+		} else {
+			s += fmt.Sprintf("(%d)", t.Mods[0])
+		}
+	}
+	if len(t.ArrayBounds) > 0 {
+		s = fmt.Sprintf("ARRAY<%s>", s)
+	}
+	return s
+}
+
+// pgTypes maps the types of PostgreSQL dialect databases, as reported by the
+// information schema, to GoogleSQL types. Their short names, e.g. INT8, are
+// mapped by ddl.PGSQL_TO_STANDARD_TYPE_TYPEMAP.
+var pgTypes = map[string]string{
+	"BIGINT":                   ddl.Int64,
+	"BOOLEAN":                  ddl.Bool,
+	"CHARACTER VARYING":        ddl.String,
+	"DOUBLE PRECISION":         ddl.Float64,
+	"REAL":                     ddl.Float32,
+	"TIMESTAMP WITH TIME ZONE": ddl.Timestamp,
+}
+
+// typeSpaces removes the spaces around the delimiters of types.
+var typeSpaces = strings.NewReplacer(" <", "<", "< ", "<", " >", ">", "( ", "(", " (", "(", " )", ")", " [", "[", "[ ", "[")
+
+// normalizeType returns a type of a schema file or of the information schema
+// of Spanner in a canonical GoogleSQL form for comparisons, e.g. STRING(MAX)
+// or ARRAY<INT64>. Types are case-insensitive and can contain spaces, and the
+// types of PostgreSQL dialect databases, e.g. bigint or character
+// varying(10)[], are mapped to their GoogleSQL equivalents.
+func normalizeType(t string) string {
+	s := typeSpaces.Replace(strings.ToUpper(strings.Join(strings.Fields(t), " ")))
+	isArray := false
+	if strings.HasPrefix(s, "ARRAY<") && strings.HasSuffix(s, ">") {
+		s, isArray = s[len("ARRAY<"):len(s)-1], true
+	} else if strings.HasSuffix(s, "[]") {
+		s, isArray = strings.TrimSuffix(s, "[]"), true
+	}
+	name, length := s, ""
+	if i := strings.Index(s, "("); i >= 0 && strings.HasSuffix(s, ")") {
+		name, length = s[:i], s[i+1:len(s)-1]
+	}
+	spName, ok := ddl.PGSQL_TO_STANDARD_TYPE_TYPEMAP[name]
+	if !ok {
+		spName, ok = pgTypes[name]
+	}
+	if ok {
+		name = spName
+		// Strings and bytes of PostgreSQL dialect databases have no length,
+		// unless it's less than the maximum.
+		if length == "" && (name == ddl.String || name == ddl.Bytes) {
+			length = "MAX"
+		}
+	}
+	if length != "" {
+		name += "(" + length + ")"
+	}
+	if isArray {
+		name = "ARRAY<" + name + ">"
+	}
+	return name
+}
+
+// getSchemaDrift compares the columns of a schema file with the columns of a
+// Spanner table. Column names are compared case-insensitively, like Spanner
+// does.
+func getSchemaDrift(fileCols, tableCols []ColumnDefinition) SchemaDrift {
+	var drift SchemaDrift
+	tableColsByName := map[string]ColumnDefinition{}
+	for _, c := range tableCols {
+		tableColsByName[strings.ToLower(c.Name)] = c
+	}
+	inFile := map[string]bool{}
+	for _, fc := range fileCols {
+		inFile[strings.ToLower(fc.Name)] = true
+		tc, ok := tableColsByName[strings.ToLower(fc.Name)]
+		if !ok {
+			drift.MissingColumns = append(drift.MissingColumns, fc.Name)
+			continue
+		}
+		if normalizeType(fc.Type) != normalizeType(tc.Type) || fc.NotNull != tc.NotNull {
+			drift.TypeMismatches = append(drift.TypeMismatches, fmt.Sprintf("%s: %s vs %s", fc.Name, describeColumnType(fc), describeColumnType(tc)))
+		}
+	}
+	for _, tc := range tableCols {
+		if !inFile[strings.ToLower(tc.Name)] {
+			drift.ExtraColumns = append(drift.ExtraColumns, tc.Name)
+		}
+	}
+	filePk, tablePk := primaryKeyNames(fileCols), primaryKeyNames(tableCols)
+	if !strings.EqualFold(strings.Join(filePk, ","), strings.Join(tablePk, ",")) {
+		drift.PrimaryKey = fmt.Sprintf("primary key is (%s) in the schema file but (%s) in the table", strings.Join(filePk, ", "), strings.Join(tablePk, ", "))
+	}
+	return drift
+}
+
+func describeColumnType(c ColumnDefinition) string {
+	if c.NotNull {
+		return c.Type + " NOT NULL"
+	}
+	return c.Type
+}
+
+// primaryKeyNames returns the names of the primary key columns, in key order.
+func primaryKeyNames(cols []ColumnDefinition) []string {
+	var pks []PrimaryKey
+	for _, c := range cols {
+		if c.PkOrder != 0 {
+			pks = append(pks, PrimaryKey{c.Name, c.PkOrder})
+		}
+	}
+	sort.Slice(pks, func(i, j int) bool {
+		return pks[i].PkOrder < pks[j].PkOrder
+	})
+	var names []string
+	for _, pk := range pks {
+		names = append(names, pk.Name)
+	}
+	return names
+}
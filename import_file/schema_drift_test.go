@@ -0,0 +1,160 @@
+package import_file
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sp "cloud.google.com/go/spanner"
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+)
+
+// getTableInfoSchemaMock returns an info schema for a table with columns
+// given as (name, spanner_type, is_nullable) and the primary key pks.
+func getTableInfoSchemaMock(columns [][]string, pks []string) *spanner.InfoSchemaImpl {
+	rowIterator := func(rows []*sp.Row) *spannerclient.RowIteratorMock {
+		i := 0
+		return &spannerclient.RowIteratorMock{
+			NextMock: func() (*sp.Row, error) {
+				if i == len(rows) {
+					return nil, iterator.Done
+				}
+				i++
+				return rows[i-1], nil
+			},
+			StopMock: func() {},
+		}
+	}
+	return &spanner.InfoSchemaImpl{
+		Ctx:       context.Background(),
+		SpDialect: constants.DIALECT_GOOGLESQL,
+		SpannerClient: spannerclient.SpannerClientMock{
+			SingleMock: func() spannerclient.ReadOnlyTransaction {
+				return &spannerclient.ReadOnlyTransactionMock{
+					QueryMock: func(ctx context.Context, stmt sp.Statement) spannerclient.RowIterator {
+						var rows []*sp.Row
+						if strings.Contains(stmt.SQL, "information_schema.columns") {
+							for _, c := range columns {
+								row, _ := sp.NewRow([]string{"column_name", "spanner_type", "is_nullable"}, []interface{}{c[0], c[1], c[2]})
+								rows = append(rows, row)
+							}
+						} else {
+							for _, pk := range pks {
+								row, _ := sp.NewRow([]string{"column_name", "constraint_type"}, []interface{}{pk, "PRIMARY KEY"})
+								rows = append(rows, row)
+							}
+						}
+						return rowIterator(rows)
+					},
+				}
+			},
+		},
+	}
+}
+
+func Test_getSpannerTableColumns(t *testing.T) {
+	infoSchema := getTableInfoSchemaMock([][]string{
+		{"a", "INT64", "NO"},
+		{"b", "STRING(MAX)", "YES"},
+		{"c", "ARRAY<STRING(10)>", "YES"},
+	}, []string{"b", "a"})
+	cols, err := getSpannerTableColumns(infoSchema, "t")
+	assert.Nil(t, err)
+	assert.Equal(t, []ColumnDefinition{
+		{Name: "a", Type: "INT64", NotNull: true, PkOrder: 2},
+		{Name: "b", Type: "STRING(MAX)", PkOrder: 1},
+		{Name: "c", Type: "ARRAY<STRING(10)>"},
+	}, cols)
+}
+
+func Test_printSchemaType(t *testing.T) {
+	assert.Equal(t, "INT64", printSchemaType(schema.Type{Name: "INT64"}))
+	assert.Equal(t, "STRING(MAX)", printSchemaType(schema.Type{Name: "STRING", Mods: []int64{ddl.MaxLength}}))
+	assert.Equal(t, "ARRAY<BYTES(16)>", printSchemaType(schema.Type{Name: "BYTES", Mods: []int64{16}, ArrayBounds: []int64{-1}}))
+}
+
+func Test_getSchemaDrift(t *testing.T) {
+	table := []ColumnDefinition{
+		{Name: "id", Type: "INT64", NotNull: true, PkOrder: 1},
+		{Name: "Name", Type: "STRING(MAX)"},
+		{Name: "created", Type: "TIMESTAMP"},
+	}
+
+	drift := getSchemaDrift([]ColumnDefinition{
+		{Name: "id", Type: "int64", NotNull: true, PkOrder: 1},
+		{Name: "name", Type: "STRING( MAX )"},
+		{Name: "created", Type: "TIMESTAMP"},
+	}, table)
+	assert.True(t, drift.IsEmpty())
+
+	drift = getSchemaDrift([]ColumnDefinition{
+		{Name: "id", Type: "STRING(36)", NotNull: true, PkOrder: 2},
+		{Name: "name", Type: "STRING(MAX)", NotNull: true, PkOrder: 1},
+		{Name: "email", Type: "STRING(MAX)"},
+	}, table)
+	assert.Equal(t, SchemaDrift{
+		MissingColumns: []string{"email"},
+		ExtraColumns:   []string{"created"},
+		TypeMismatches: []string{"id: STRING(36) NOT NULL vs INT64 NOT NULL", "name: STRING(MAX) NOT NULL vs STRING(MAX)"},
+		PrimaryKey:     "primary key is (name, id) in the schema file but (id) in the table",
+	}, drift)
+	assert.Equal(t, "columns missing from the table: email; columns missing from the schema file: created; "+
+		"columns with different types (schema file vs table): id: STRING(36) NOT NULL vs INT64 NOT NULL, name: STRING(MAX) NOT NULL vs STRING(MAX); "+
+		"primary key is (name, id) in the schema file but (id) in the table", drift.String())
+}
+
+func Test_normalizeType(t *testing.T) {
+	assert.Equal(t, "STRING(MAX)", normalizeType("string( max )"))
+	assert.Equal(t, "ARRAY<INT64>", normalizeType("ARRAY <int64>"))
+	assert.Equal(t, "INT64", normalizeType("bigint"))
+	assert.Equal(t, "INT64", normalizeType("int8"))
+	assert.Equal(t, "STRING(MAX)", normalizeType("character varying"))
+	assert.Equal(t, "STRING(10)", normalizeType("varchar(10)"))
+	assert.Equal(t, "ARRAY<STRING(10)>", normalizeType("ARRAY<character varying(10)>"))
+	assert.Equal(t, "ARRAY<FLOAT64>", normalizeType("double precision[]"))
+	assert.Equal(t, "TIMESTAMP", normalizeType("timestamp with time zone"))
+	assert.Equal(t, "BYTES(MAX)", normalizeType("bytea"))
+	assert.Equal(t, "JSON", normalizeType("jsonb"))
+}
+
+func Test_getSchemaDrift_PostgreSQL(t *testing.T) {
+	infoSchema := getTableInfoSchemaMock([][]string{
+		{"id", "bigint", "NO"},
+		{"name", "character varying(50)", "YES"},
+		{"tags", "character varying[]", "YES"},
+		{"created", "timestamp with time zone", "YES"},
+	}, []string{"id"})
+	infoSchema.SpDialect = constants.DIALECT_POSTGRESQL
+	table, err := getSpannerTableColumns(infoSchema, "t")
+	assert.Nil(t, err)
+
+	// Schema files can use GoogleSQL or PostgreSQL types.
+	drift := getSchemaDrift([]ColumnDefinition{
+		{Name: "id", Type: "INT64", NotNull: true, PkOrder: 1},
+		{Name: "name", Type: "STRING(50)"},
+		{Name: "tags", Type: "ARRAY<STRING(MAX)>"},
+		{Name: "created", Type: "TIMESTAMP"},
+	}, table)
+	assert.True(t, drift.IsEmpty(), drift.String())
+	drift = getSchemaDrift([]ColumnDefinition{
+		{Name: "id", Type: "int8", NotNull: true, PkOrder: 1},
+		{Name: "name", Type: "varchar(50)"},
+		{Name: "tags", Type: "varchar[]"},
+		{Name: "created", Type: "timestamptz"},
+	}, table)
+	assert.True(t, drift.IsEmpty(), drift.String())
+
+	drift = getSchemaDrift([]ColumnDefinition{
+		{Name: "id", Type: "INT64", NotNull: true, PkOrder: 1},
+		{Name: "name", Type: "STRING(MAX)"},
+		{Name: "tags", Type: "ARRAY<STRING(MAX)>"},
+		{Name: "created", Type: "DATE"},
+	}, table)
+	assert.Equal(t, []string{"name: STRING(MAX) vs character varying(50)", "created: DATE vs timestamp with time zone"}, drift.TypeMismatches)
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Checkpoint records the progress of a data migration in a local JSON file,
+// so that a migration that fails partway through can be resumed without
+// rewriting the tables and key ranges that were already committed to Spanner.
+//
+// Tables are keyed by Spanner table name (prefixed by the shard id for
+// sharded migrations) since table ids are regenerated on every schema
+// conversion. All methods are safe to call on a nil *Checkpoint, in which
+// case they do nothing.
+type Checkpoint struct {
+	DbURI         string                      `json:"dbURI"`
+	SchemaCreated bool                        `json:"schemaCreated"`
+	Tables        map[string]*TableCheckpoint `json:"tables"`
+
+	path        string
+	mutex       sync.Mutex
+	droppedRows func() map[string]int64
+	// Dropped row counts recorded by earlier runs, which the dropped row
+	// counts of the current run are added to.
+	baseDroppedRows map[string]int64
+}
+
+// TableCheckpoint is the progress of a single table.
+type TableCheckpoint struct {
+	Completed bool `json:"completed"`
+	// LastCommittedKey is the value of the leading primary key column below
+	// which all rows of the table have been committed. It is only set for
+	// tables that are read in key ranges.
+	LastCommittedKey *int64 `json:"lastCommittedKey,omitempty"`
+	DroppedRows      int64  `json:"droppedRows"`
+}
+
+// GetCheckpointFileName returns the name of the checkpoint file kept next to
+// the given session file.
+func GetCheckpointFileName(sessionFile string) string {
+	return strings.TrimSuffix(sessionFile, filepath.Ext(sessionFile)) + ".checkpoint.json"
+}
+
+// LoadCheckpoint returns the checkpoint stored at path. When resume is false,
+// or no checkpoint file exists yet, a new empty checkpoint is returned and the
+// file is (re)written. It is an error to resume a checkpoint that was written
+// for a different database.
+func LoadCheckpoint(path, dbURI string, resume bool) (*Checkpoint, error) {
+	cp := &Checkpoint{DbURI: dbURI, Tables: make(map[string]*TableCheckpoint), path: path}
+	if resume {
+		data, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			// Nothing to resume from, start afresh.
+		case err != nil:
+			return nil, fmt.Errorf("can't read checkpoint file %s: %v", path, err)
+		default:
+			if err := json.Unmarshal(data, cp); err != nil {
+				return nil, fmt.Errorf("can't parse checkpoint file %s: %v", path, err)
+			}
+			if cp.DbURI != dbURI {
+				return nil, fmt.Errorf("checkpoint file %s was written for database %s, not %s", path, cp.DbURI, dbURI)
+			}
+			if cp.Tables == nil {
+				cp.Tables = make(map[string]*TableCheckpoint)
+			}
+		}
+	}
+	cp.baseDroppedRows = make(map[string]int64)
+	for key, t := range cp.Tables {
+		cp.baseDroppedRows[key] = t.DroppedRows
+	}
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	if err := cp.save(); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// CheckpointKey returns the key of a table in the checkpoint.
+func CheckpointKey(shardId, spTableName string) string {
+	if shardId == "" {
+		return spTableName
+	}
+	return shardId + "/" + spTableName
+}
+
+// Path returns the location of the checkpoint file.
+func (cp *Checkpoint) Path() string {
+	if cp == nil {
+		return ""
+	}
+	return cp.path
+}
+
+// SetDroppedRowsFunc sets the source of the per-table dropped row counts of
+// the current run, typically BatchWriter.DroppedRowsByTable. The counts are
+// keyed by Spanner table name and recorded under shardId.
+func (cp *Checkpoint) SetDroppedRowsFunc(shardId string, f func() map[string]int64) {
+	if cp == nil {
+		return
+	}
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	cp.droppedRows = func() map[string]int64 {
+		m := make(map[string]int64)
+		for table, n := range f() {
+			m[CheckpointKey(shardId, table)] = n
+		}
+		return m
+	}
+}
+
+// MarkSchemaCreated records that the Spanner schema has been created, so that
+// a resumed migration doesn't try to create it again.
+func (cp *Checkpoint) MarkSchemaCreated() error {
+	if cp == nil {
+		return nil
+	}
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	cp.SchemaCreated = true
+	return cp.save()
+}
+
+// TableCompleted returns true if all rows of the table have been committed.
+func (cp *Checkpoint) TableCompleted(key string) bool {
+	if cp == nil {
+		return false
+	}
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	t, ok := cp.Tables[key]
+	return ok && t.Completed
+}
+
+// LastCommittedKey returns the key below which all rows of the table have
+// been committed, if any.
+func (cp *Checkpoint) LastCommittedKey(key string) (int64, bool) {
+	if cp == nil {
+		return 0, false
+	}
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	t, ok := cp.Tables[key]
+	if !ok || t.LastCommittedKey == nil {
+		return 0, false
+	}
+	return *t.LastCommittedKey, true
+}
+
+// CommitKey records that all rows of the table with a leading primary key
+// value below k have been committed.
+func (cp *Checkpoint) CommitKey(key string, k int64) error {
+	if cp == nil {
+		return nil
+	}
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	t := cp.table(key)
+	t.LastCommittedKey = &k
+	return cp.save()
+}
+
+// CompleteTable records that all rows of the table have been committed.
+func (cp *Checkpoint) CompleteTable(key string) error {
+	if cp == nil {
+		return nil
+	}
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	t := cp.table(key)
+	t.Completed = true
+	t.LastCommittedKey = nil
+	return cp.save()
+}
+
+// Save writes the checkpoint to its file.
+func (cp *Checkpoint) Save() error {
+	if cp == nil {
+		return nil
+	}
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	return cp.save()
+}
+
+func (cp *Checkpoint) table(key string) *TableCheckpoint {
+	t, ok := cp.Tables[key]
+	if !ok {
+		t = &TableCheckpoint{}
+		cp.Tables[key] = t
+	}
+	return t
+}
+
+// save refreshes the dropped row counts and writes the checkpoint to a
+// temporary file that is then renamed over the checkpoint file, so that a
+// crash never leaves a partially written checkpoint behind. cp.mutex must be
+// held.
+func (cp *Checkpoint) save() error {
+	if cp.droppedRows != nil {
+		for key, n := range cp.droppedRows() {
+			cp.table(key).DroppedRows = cp.baseDroppedRows[key] + n
+		}
+	}
+	data, err := json.MarshalIndent(cp, "", " ")
+	if err != nil {
+		return fmt.Errorf("can't encode checkpoint: %v", err)
+	}
+	tmp := cp.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("can't write checkpoint file %s: %v", tmp, err)
+	}
+	if err := os.Rename(tmp, cp.path); err != nil {
+		return fmt.Errorf("can't write checkpoint file %s: %v", cp.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCheckpointFileName(t *testing.T) {
+	assert.Equal(t, "out/db.session.checkpoint.json", GetCheckpointFileName("out/db.session.json"))
+	assert.Equal(t, "session.checkpoint.json", GetCheckpointFileName("session"))
+}
+
+func TestCheckpointKey(t *testing.T) {
+	assert.Equal(t, "orders", CheckpointKey("", "orders"))
+	assert.Equal(t, "shard1/orders", CheckpointKey("shard1", "orders"))
+}
+
+func TestCheckpoint_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.checkpoint.json")
+	dbURI := "projects/p/instances/i/databases/db"
+
+	cp, err := LoadCheckpoint(path, dbURI, false)
+	assert.Nil(t, err)
+	dropped := map[string]int64{"orders": 2}
+	cp.SetDroppedRowsFunc("", func() map[string]int64 { return dropped })
+	assert.Nil(t, cp.MarkSchemaCreated())
+	assert.Nil(t, cp.CompleteTable("customers"))
+	assert.Nil(t, cp.CommitKey("orders", 100))
+
+	// A later run resumes from the recorded progress, and adds its own
+	// dropped rows to those of the earlier run.
+	cp, err = LoadCheckpoint(path, dbURI, true)
+	assert.Nil(t, err)
+	assert.True(t, cp.SchemaCreated)
+	assert.True(t, cp.TableCompleted("customers"))
+	assert.False(t, cp.TableCompleted("orders"))
+	k, ok := cp.LastCommittedKey("orders")
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), k)
+	_, ok = cp.LastCommittedKey("customers")
+	assert.False(t, ok)
+	cp.SetDroppedRowsFunc("", func() map[string]int64 { return map[string]int64{"orders": 3} })
+	assert.Nil(t, cp.CompleteTable("orders"))
+	assert.Equal(t, int64(5), cp.Tables["orders"].DroppedRows)
+
+	// Without resume, the earlier progress is discarded.
+	cp, err = LoadCheckpoint(path, dbURI, false)
+	assert.Nil(t, err)
+	assert.False(t, cp.SchemaCreated)
+	assert.False(t, cp.TableCompleted("customers"))
+}
+
+func TestCheckpoint_DifferentDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.checkpoint.json")
+	_, err := LoadCheckpoint(path, "projects/p/instances/i/databases/db1", false)
+	assert.Nil(t, err)
+	_, err = LoadCheckpoint(path, "projects/p/instances/i/databases/db2", true)
+	assert.NotNil(t, err)
+}
+
+func TestCheckpoint_Nil(t *testing.T) {
+	var cp *Checkpoint
+	assert.False(t, cp.TableCompleted("orders"))
+	_, ok := cp.LastCommittedKey("orders")
+	assert.False(t, ok)
+	assert.Nil(t, cp.CommitKey("orders", 1))
+	assert.Nil(t, cp.CompleteTable("orders"))
+	assert.Nil(t, cp.Save())
+}
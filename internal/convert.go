@@ -16,6 +16,7 @@ package internal
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -39,27 +40,38 @@ type Conv struct {
 	ToSource               map[string]NameAndCols       `json:"-"` // Maps from Spanner table name to source-DB table name and column mapping.
 	UsedNames              map[string]bool              `json:"-"` // Map storing the names that are already assigned to tables, indices or foreign key contraints.
 	dataSink               func(table string, cols []string, values []interface{})
-	DataFlush              func()                  `json:"-"` // Data flush is used to flush out remaining writes and wait for them to complete.
-	Location               *time.Location          // Timezone (for timestamp conversion).
-	sampleBadRows          rowSamples              // Rows that generated errors during conversion.
-	Stats                  stats                   `json:"-"`
-	TimezoneOffset         string                  // Timezone offset for timestamp conversion.
-	SpDialect              string                  // The dialect of the spanner database to which Spanner migration tool is writing.
-	UniquePKey             map[string][]string     // Maps Spanner table name to unique column name being used as primary key (if needed).
-	Audit                  Audit                   `json:"-"` // Stores the audit information for the database conversion
-	Rules                  []Rule                  // Stores applied rules during schema conversion
-	IsSharded              bool                    // Flag denoting if the migration is sharded or not
-	ConvLock               sync.RWMutex            `json:"-"` // ConvLock prevents concurrent map read/write operations. This lock will be used in all the APIs that either read or write elements to the conv object.
-	SpRegion               string                  // Leader Region for Spanner Instance
-	ResourceValidation     bool                    // Flag denoting if validation for resources to generated is complete
-	UI                     bool                    // Flag if UI interface was used for migration. ToDo: Remove flag after resource generation is introduced to UI
-	SpSequences            map[string]ddl.Sequence // Maps Spanner Sequences to Sequence Schema
-	SrcSequences           map[string]ddl.Sequence // Maps source-DB Sequences to Sequence schema information
-	SpProjectId            string                  // Spanner Project Id
-	SpInstanceId           string                  // Spanner Instance Id
-	Source                 string                  // Source Database type being migrated
+	DataFlush              func()                      `json:"-"` // Data flush is used to flush out remaining writes and wait for them to complete.
+	Location               *time.Location              // Timezone (for timestamp conversion).
+	sampleBadRows          rowSamples                  // Rows that generated errors during conversion.
+	unexpectedMutex        sync.Mutex                  // Serializes the updates of Stats.Unexpected, see Unexpected.
+	Stats                  stats                       `json:"-"`
+	TimezoneOffset         string                      // Timezone offset for timestamp conversion.
+	TimezonePolicies       map[string]TableTimezones   // Maps table id to the timezone policies of its columns.
+	SpDialect              string                      // The dialect of the spanner database to which Spanner migration tool is writing.
+	UniquePKey             map[string][]string         // Maps Spanner table name to unique column name being used as primary key (if needed).
+	Audit                  Audit                       `json:"-"` // Stores the audit information for the database conversion
+	Rules                  []Rule                      // Stores applied rules during schema conversion
+	IsSharded              bool                        // Flag denoting if the migration is sharded or not
+	ConvLock               sync.RWMutex                `json:"-"` // ConvLock prevents concurrent map read/write operations. This lock will be used in all the APIs that either read or write elements to the conv object.
+	SpRegion               string                      // Leader Region for Spanner Instance
+	ResourceValidation     bool                        // Flag denoting if validation for resources to generated is complete
+	UI                     bool                        // Flag if UI interface was used for migration. ToDo: Remove flag after resource generation is introduced to UI
+	SpSequences            map[string]ddl.Sequence     // Maps Spanner Sequences to Sequence Schema
+	SrcSequences           map[string]ddl.Sequence     // Maps source-DB Sequences to Sequence schema information
+	SrcEnumTypes           map[string][]string         // Maps source-DB enum types of a dump to their labels.
+	SpViews                map[string]ddl.CreateView   // Maps view id to Spanner view.
+	SrcViews               map[string]schema.View      // Maps view id to source-DB view.
+	ViewIssues             map[string][]string         // Maps view id to the constructs of its query that couldn't be translated.
+	SpChangeStreams        map[string]ddl.ChangeStream // Maps change stream id to Spanner change stream.
+	SpProjectId            string                      // Spanner Project Id
+	SpInstanceId           string                      // Spanner Instance Id
+	Source                 string                      // Source Database type being migrated
 	DatabaseOptions        ddl.DatabaseOptions
 	DefaultIdentityOptions ddl.IdentityOptions // Default values to use for IDENTITY columns
+	DataReadOptions        DataReadOptions     `json:"-"` // Controls how rows are read from the source database during data migration.
+	DataWriteMode          string              `json:"-"` // Kind of mutation used to write rows to Spanner, see writer.WriteMode. Empty means insert.
+	Checkpoint             *Checkpoint         `json:"-"` // Progress of the data migration, used to resume it. Nil if checkpointing is disabled.
+	DeadLetter             DeadLetterSink      `json:"-"` // Receives every row rejected during the data migration. Nil if disabled.
 }
 
 type InvalidCheckExp struct {
@@ -80,6 +92,13 @@ type AdditionalDataAttributes struct {
 	ShardId string
 }
 
+// DataReadOptions controls how tables are read from a source database
+// during a direct-connect data migration.
+type DataReadOptions struct {
+	ChunksPerTable int // Number of primary key ranges each table is split into. Values below 2 read each table with a single query.
+	Workers        int // Number of key ranges of a table that are read concurrently.
+}
+
 type mode int
 
 const (
@@ -226,11 +245,6 @@ type Audit struct {
 	SkipMetricsPopulation    bool                                   `json:"-"` // Flag to identify if outgoing metrics metadata needs to skipped
 }
 
-
-
-
-
-
 // Stores information related to rules during schema conversion
 type Rule struct {
 	Id                string
@@ -304,14 +318,20 @@ func MakeConv() *Conv {
 			Statement:  make(map[string]*statementStat),
 			Unexpected: make(map[string]int64),
 		},
-		TimezoneOffset: "+00:00", // By default, use +00:00 offset which is equal to UTC timezone
-		UniquePKey:     make(map[string][]string),
+		TimezoneOffset:   "+00:00", // By default, use +00:00 offset which is equal to UTC timezone
+		TimezonePolicies: make(map[string]TableTimezones),
+		UniquePKey:       make(map[string][]string),
 		Audit: Audit{
-			MigrationType:  migration.MigrationData_SCHEMA_ONLY.Enum(),
+			MigrationType: migration.MigrationData_SCHEMA_ONLY.Enum(),
 		},
 		Rules:           []Rule{},
 		SpSequences:     make(map[string]ddl.Sequence),
 		SrcSequences:    make(map[string]ddl.Sequence),
+		SrcEnumTypes:    make(map[string][]string),
+		SpViews:         make(map[string]ddl.CreateView),
+		SrcViews:        make(map[string]schema.View),
+		ViewIssues:      make(map[string][]string),
+		SpChangeStreams: make(map[string]ddl.ChangeStream),
 		DatabaseOptions: ddl.DatabaseOptions{},
 	}
 }
@@ -414,9 +434,31 @@ func (conv *Conv) Unexpecteds() int64 {
 }
 
 // CollectBadRow updates the list of bad rows, while respecting
-// the byte limit for bad rows.
-func (conv *Conv) CollectBadRow(srcTable string, srcCols, vals []string) {
-	r := &row{table: srcTable, cols: srcCols, vals: vals}
+// the byte limit for bad rows. The row is also sent to the dead-letter
+// sink, if any, with err as the reason it couldn't be converted, and with
+// the Spanner names of its table and columns.
+func (conv *Conv) CollectBadRow(srcTable string, srcCols, vals []string, err error) {
+	if conv.DeadLetter != nil {
+		table, cols, spVals := conv.spannerNames(srcTable, srcCols, vals)
+		conv.DeadLetter.Write(DeadLetterRow{Stage: DeadLetterConversion, Table: table, Cols: cols, Vals: spVals, Error: errorString(err)})
+	}
+	conv.addSampleBadRow(srcTable, srcCols, vals)
+}
+
+// CollectBadDeadLetterRow is CollectBadRow for a row read from a dead-letter
+// file. The row is sent to the dead-letter sink as is, since its table,
+// columns and values are already those of a dead-letter row.
+func (conv *Conv) CollectBadDeadLetterRow(deadLetterRow DeadLetterRow, err error) {
+	if conv.DeadLetter != nil {
+		deadLetterRow.Stage = DeadLetterConversion
+		deadLetterRow.Error = errorString(err)
+		conv.DeadLetter.Write(deadLetterRow)
+	}
+	conv.addSampleBadRow(deadLetterRow.Table, deadLetterRow.Cols, deadLetterRow.Vals)
+}
+
+func (conv *Conv) addSampleBadRow(table string, cols, vals []string) {
+	r := &row{table: table, cols: cols, vals: vals}
 	bytes := byteSize(r)
 	// Cap storage used by badRows. Keep at least one bad row.
 	if len(conv.sampleBadRows.rows) == 0 || bytes+conv.sampleBadRows.bytes < conv.sampleBadRows.bytesLimit {
@@ -506,6 +548,58 @@ func addMissingPrimaryKeyWarning(tableId string, colId string, conv *Conv, schem
 	}
 }
 
+// ReadyViews returns the Spanner views that can be created: views with
+// issues, and the views built on them, are left out since a failing CREATE
+// VIEW would fail the whole schema update. The user fixes them in the UI, or
+// creates them after the migration.
+func (conv *Conv) ReadyViews() map[string]ddl.CreateView {
+	views := make(map[string]ddl.CreateView)
+	for viewId, view := range conv.SpViews {
+		if len(conv.ViewIssues[viewId]) == 0 {
+			views[viewId] = view
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for viewId, view := range views {
+			for otherId, other := range conv.SpViews {
+				if _, ok := views[otherId]; !ok && view.DependsOn(other.Name) {
+					delete(views, viewId)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return views
+}
+
+// ProtoBundle returns the proto bundle of the proto enums used by the
+// Spanner ENUM columns, with the labels of the source columns they are
+// converted from. Source enum types used by several columns, such as
+// PostgreSQL enum types, map to a single proto enum.
+func (conv *Conv) ProtoBundle() ddl.ProtoBundle {
+	enums := make(map[string]ddl.ProtoEnum)
+	for tableId, spTable := range conv.SpSchema {
+		for colId, colDef := range spTable.ColDefs {
+			if colDef.T.Name != ddl.Enum {
+				continue
+			}
+			if _, ok := enums[colDef.T.ProtoName]; ok {
+				continue
+			}
+			srcCol := conv.SrcSchema[tableId].ColDefs[colId]
+			enums[colDef.T.ProtoName] = ddl.ProtoEnum{Name: colDef.T.ProtoName, Labels: srcCol.Type.EnumValues}
+		}
+	}
+	var pb ddl.ProtoBundle
+	for _, e := range enums {
+		pb.Enums = append(pb.Enums, e)
+	}
+	sort.Slice(pb.Enums, func(i, j int) bool { return pb.Enums[i].Name < pb.Enums[j].Name })
+	return pb
+}
+
 // SetLocation configures the timezone for data conversion.
 func (conv *Conv) SetLocation(loc *time.Location) {
 	conv.Location = loc
@@ -543,6 +637,9 @@ func (conv *Conv) Unexpected(u string) {
 	VerbosePrintf("Unexpected condition: %s\n", u)
 	logger.Log.Debug("Unexpected condition", zap.String("condition", u))
 
+	// Rows of a table read in key ranges are converted concurrently.
+	conv.unexpectedMutex.Lock()
+	defer conv.unexpectedMutex.Unlock()
 	// Limit size of unexpected map. If over limit, then only
 	// update existing entries.
 	if _, ok := conv.Stats.Unexpected[u]; ok || len(conv.Stats.Unexpected) < 1000 {
@@ -15,12 +15,14 @@
 package internal
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
 
@@ -87,6 +89,45 @@ func TestGetBadRows(t *testing.T) {
 	assert.Equal(t, 2, len(conv.SampleBadRows(100)))
 }
 
+type testDeadLetterSink []DeadLetterRow
+
+func (s *testDeadLetterSink) Write(row DeadLetterRow) {
+	*s = append(*s, row)
+}
+
+func TestCollectBadRow(t *testing.T) {
+	conv := MakeConv()
+	conv.CollectBadRow("table", []string{"col1"}, []string{"a"}, fmt.Errorf("can't convert"))
+	assert.Equal(t, 1, len(conv.SampleBadRows(100)))
+
+	sink := &testDeadLetterSink{}
+	conv.DeadLetter = sink
+	conv.CollectBadRow("table", []string{"col1", "col2"}, []string{"b", "2"}, fmt.Errorf("can't convert"))
+	assert.Equal(t, testDeadLetterSink{
+		{Stage: DeadLetterConversion, Table: "table", Cols: []string{"col1", "col2"}, Vals: []string{"b", "2"}, Error: "can't convert"},
+	}, *sink)
+	assert.Equal(t, 2, len(conv.SampleBadRows(100)))
+
+	// Rows of source tables are recorded with the Spanner names of their
+	// table and columns, without the columns dropped from Spanner.
+	conv.SrcSchema = map[string]schema.Table{"t1": {Name: "my-table", Id: "t1", ColDefs: map[string]schema.Column{
+		"c1": {Name: "my-col", Id: "c1"},
+		"c2": {Name: "dropped", Id: "c2"},
+		"c3": {Name: "data", Id: "c3"},
+	}}}
+	conv.SpSchema = ddl.Schema{"t1": {Name: "my_table", Id: "t1", ColDefs: map[string]ddl.ColumnDef{
+		"c1": {Name: "my_col", Id: "c1"},
+		"c3": {Name: "data", Id: "c3", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+	}}}
+	conv.CollectBadRow("my-table", []string{"my-col", "dropped", "data"}, []string{"x", "y", "\xff"}, fmt.Errorf("can't convert"))
+	assert.Equal(t, DeadLetterRow{Stage: DeadLetterConversion, Table: "my_table", Cols: []string{"my_col", "data"}, Vals: []string{"x", "/w=="}, Error: "can't convert"}, (*sink)[1])
+
+	// Rows read from a dead-letter file are written back as they were read.
+	conv.CollectBadDeadLetterRow(DeadLetterRow{Stage: DeadLetterWrite, Table: "my_table", Cols: []string{"data"}, Vals: []string{"/w=="}, Error: "can't write"}, fmt.Errorf("can't convert"))
+	assert.Equal(t, DeadLetterRow{Stage: DeadLetterConversion, Table: "my_table", Cols: []string{"data"}, Vals: []string{"/w=="}, Error: "can't convert"}, (*sink)[2])
+	assert.Equal(t, 4, len(conv.SampleBadRows(100)))
+}
+
 func TestAddPrimaryKeys(t *testing.T) {
 	addPrimaryKeyTests := []struct {
 		name           string
@@ -284,3 +325,45 @@ func TestAddPrimaryKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestReadyViews(t *testing.T) {
+	conv := MakeConv()
+	conv.SpViews = map[string]ddl.CreateView{
+		"v1": {Name: "active_users", Id: "v1", Query: "SELECT id FROM users WHERE active"},
+		"v2": {Name: "user_dates", Id: "v2", Query: "SELECT id, DATE_FORMAT(created, '%Y') FROM users"},
+		"v3": {Name: "recent_users", Id: "v3", Query: "SELECT id FROM user_dates"},
+		"v4": {Name: "first_recent_user", Id: "v4", Query: "SELECT id FROM recent_users LIMIT 1"},
+	}
+	conv.ViewIssues = map[string][]string{"v2": {"function DATE_FORMAT isn't supported, or has other arguments in Spanner"}}
+	assert.Equal(t, map[string]ddl.CreateView{"v1": conv.SpViews["v1"]}, conv.ReadyViews())
+}
+
+func TestProtoBundle(t *testing.T) {
+	conv := MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "users", ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Type: schema.Type{Name: "int"}},
+			"c2": {Name: "status", Type: schema.Type{Name: "enum", EnumValues: []string{"active", "banned"}}},
+			"c3": {Name: "mood", Type: schema.Type{Name: "mood", EnumValues: []string{"sad", "happy"}}},
+		}},
+		"t2": {Name: "posts", ColDefs: map[string]schema.Column{
+			"c4": {Name: "mood", Type: schema.Type{Name: "mood", EnumValues: []string{"sad", "happy"}}},
+		}},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {Name: "users", ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "id", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "status", T: ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.users_status"}},
+			"c3": {Name: "mood", T: ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.mood"}},
+		}},
+		"t2": {Name: "posts", ColDefs: map[string]ddl.ColumnDef{
+			"c4": {Name: "mood", T: ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.mood"}},
+		}},
+	}
+	expected := ddl.ProtoBundle{Enums: []ddl.ProtoEnum{
+		{Name: "spanner_migration.mood", Labels: []string{"sad", "happy"}},
+		{Name: "spanner_migration.users_status", Labels: []string{"active", "banned"}},
+	}}
+	assert.Equal(t, expected, conv.ProtoBundle())
+	assert.Equal(t, ddl.ProtoBundle{}, MakeConv().ProtoBundle())
+}
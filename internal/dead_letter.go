@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/base64"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Stages at which a dead-letter row was rejected.
+const (
+	// DeadLetterConversion rows couldn't be converted to Spanner types. Their
+	// table and columns are those of the Spanner database, so that they can
+	// be replayed, but their values are those of the source database.
+	DeadLetterConversion = "conversion"
+	// DeadLetterWrite rows were converted but couldn't be written to Spanner.
+	// Their table and columns are those of the Spanner database.
+	DeadLetterWrite = "write"
+)
+
+// DeadLetterRow is a row that was rejected during a data migration, together
+// with the reason it was rejected. Unlike the sample of bad rows kept in Conv
+// and BatchWriter, every rejected row is sent to the DeadLetterSink so that
+// the rows can be corrected and replayed.
+type DeadLetterRow struct {
+	Stage string   `json:"stage"`
+	Table string   `json:"table"`
+	Cols  []string `json:"cols"`
+	// Vals holds the values of Cols as strings. NULL values of write rows
+	// are left out, together with their column. Values of BYTES columns are
+	// base64 encoded, since they can hold any bytes.
+	Vals  []string `json:"vals"`
+	Error string   `json:"error"`
+}
+
+// DeadLetterSink receives the rows rejected during a data migration. Write is
+// called concurrently by the goroutines that convert and write rows, so
+// implementations must be thread-safe.
+type DeadLetterSink interface {
+	Write(row DeadLetterRow)
+}
+
+// spannerNames returns the Spanner names of the source table srcTable and of
+// its columns srcCols, together with the values vals of the columns, where
+// the values of BYTES columns are base64 encoded. Columns that were dropped
+// from the Spanner table are left out. Names and values are returned as is
+// if srcTable isn't in the source schema, e.g. for files imported directly
+// into Spanner tables.
+func (conv *Conv) spannerNames(srcTable string, srcCols, vals []string) (string, []string, []string) {
+	tableId, err := GetTableIdFromSrcName(conv.SrcSchema, srcTable)
+	if err != nil {
+		return srcTable, srcCols, vals
+	}
+	spTable, ok := conv.SpSchema[tableId]
+	if !ok {
+		return srcTable, srcCols, vals
+	}
+	var cols, spVals []string
+	for i, c := range srcCols {
+		colId, err := GetColIdFromSrcName(conv.SrcSchema[tableId].ColDefs, c)
+		if err != nil {
+			cols, spVals = append(cols, c), append(spVals, vals[i])
+			continue
+		}
+		cd, ok := spTable.ColDefs[colId]
+		if !ok {
+			continue
+		}
+		v := vals[i]
+		if cd.T.Name == ddl.Bytes && !cd.T.IsArray {
+			v = base64.StdEncoding.EncodeToString([]byte(v))
+		}
+		cols, spVals = append(cols, cd.Name), append(spVals, v)
+	}
+	return spTable.Name, cols, spVals
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
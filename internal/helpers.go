@@ -91,6 +91,9 @@ func GenerateFunctionId() string {
 func GenerateViewId() string {
 	return GenerateId("vw")
 }
+func GenerateChangeStreamId() string {
+	return GenerateId("cs")
+}
 
 func GetSrcColNameIdMap(srcs schema.Table) map[string]string {
 	if len(srcs.ColNameIdMap) > 0 {
@@ -165,6 +165,16 @@ func fetchTableReports(inputTableReports []tableReport, conv *internal.Conv) (ta
 		//1. src and Sp Table Names
 		tableReport := TableReport{SrcTableName: conv.SrcSchema[t.SrcTable].Name}
 		tableReport.SpTableName = conv.SrcSchema[t.SrcTable].Name
+		tableReport.Comment = conv.SrcSchema[t.SrcTable].Comment
+		for _, colDef := range conv.SrcSchema[t.SrcTable].ColDefs {
+			if colDef.Comment == "" {
+				continue
+			}
+			if tableReport.ColumnComments == nil {
+				tableReport.ColumnComments = make(map[string]string)
+			}
+			tableReport.ColumnComments[colDef.Name] = colDef.Comment
+		}
 
 		//2. Schema Report
 		migrationType := *conv.Audit.MigrationType
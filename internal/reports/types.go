@@ -90,11 +90,13 @@ type DataReport struct {
 }
 
 type TableReport struct {
-	SrcTableName string       `json:"srcTableName"`
-	SpTableName  string       `json:"spTableName"`
-	SchemaReport SchemaReport `json:"schemaReport"`
-	DataReport   DataReport   `json:"dataReport"`
-	Issues       []Issues     `json:"issues"`
+	SrcTableName   string            `json:"srcTableName"`
+	SpTableName    string            `json:"spTableName"`
+	Comment        string            `json:"comment,omitempty"`
+	ColumnComments map[string]string `json:"columnComments,omitempty"`
+	SchemaReport   SchemaReport      `json:"schemaReport"`
+	DataReport     DataReport        `json:"dataReport"`
+	Issues         []Issues          `json:"issues"`
 }
 
 type UnexpectedCondition struct {
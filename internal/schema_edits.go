@@ -0,0 +1,233 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// This file has the edits of the Spanner schema that are made both by the
+// web UI and by the customizations file of the schema commands.
+
+// CheckInterleaving returns an error telling why the table tableId can't be
+// interleaved in the table parentId: the primary key of the parent must be a
+// prefix of the primary key of the table, and the table can't be an
+// ancestor of the parent.
+func (conv *Conv) CheckInterleaving(tableId, parentId string) error {
+	table, parent := conv.SpSchema[tableId], conv.SpSchema[parentId]
+	if len(parent.PrimaryKeys) == 0 || len(table.PrimaryKeys) == 0 {
+		return fmt.Errorf("Both parent table '%s' and child table '%s' must have primary keys.", parent.Name, table.Name)
+	}
+	if len(table.PrimaryKeys) < len(parent.PrimaryKeys) {
+		return fmt.Errorf("The child table '%s' has '%d' primary keys, which is less than the parent table '%s' primary keys count of '%d'.", table.Name, len(table.PrimaryKeys), parent.Name, len(parent.PrimaryKeys))
+	}
+	for _, parentPk := range parent.PrimaryKeys {
+		parentCol := parent.ColDefs[parentPk.ColId]
+		var pk *ddl.IndexKey
+		for i, k := range table.PrimaryKeys {
+			col := table.ColDefs[k.ColId]
+			if col.Name == parentCol.Name && col.T.Name == parentCol.T.Name && col.T.Len == parentCol.T.Len && col.NotNull == parentCol.NotNull {
+				pk = &table.PrimaryKeys[i]
+				break
+			}
+		}
+		if pk == nil {
+			return fmt.Errorf("The child table '%s' does not have primary key '%s' of parent table '%s'.", table.Name, parentCol.Name, parent.Name)
+		}
+		if pk.Order != parentPk.Order {
+			return fmt.Errorf("The primary key '%s' of parent table '%s' is at order '%d', but in child table '%s' it is at order '%d'.", parentCol.Name, parent.Name, parentPk.Order, table.Name, pk.Order)
+		}
+	}
+	if parentId == tableId || conv.SpSchema.IsAncestor(parentId, tableId) {
+		return fmt.Errorf("Interleaving table '%s' in parent table '%s' will create a cycle.", table.Name, parent.Name)
+	}
+	return nil
+}
+
+// DropSpannerTable removes a table from the Spanner schema, along with the
+// foreign keys that refer to it and its timezone policies. Tables
+// interleaved in it aren't interleaved anymore, and change streams stop
+// watching it.
+func (conv *Conv) DropSpannerTable(tableId string) {
+	spTable, ok := conv.SpSchema[tableId]
+	if !ok {
+		return
+	}
+	delete(conv.UsedNames, strings.ToLower(spTable.Name))
+	for _, index := range spTable.Indexes {
+		delete(conv.UsedNames, strings.ToLower(index.Name))
+	}
+	for _, index := range spTable.SearchIndexes {
+		delete(conv.UsedNames, strings.ToLower(index.Name))
+	}
+	for _, index := range spTable.VectorIndexes {
+		delete(conv.UsedNames, strings.ToLower(index.Name))
+	}
+	for _, fk := range spTable.ForeignKeys {
+		delete(conv.UsedNames, strings.ToLower(fk.Name))
+	}
+	delete(conv.SpSchema, tableId)
+	delete(conv.SyntheticPKeys, tableId)
+	delete(conv.TimezonePolicies, tableId)
+	conv.SchemaIssues[tableId] = TableIssues{
+		TableLevelIssues:  []SchemaIssue{},
+		ColumnLevelIssues: map[string][]SchemaIssue{},
+	}
+
+	for id, t := range conv.SpSchema {
+		fks := []ddl.Foreignkey{}
+		for _, fk := range t.ForeignKeys {
+			if fk.ReferTableId == tableId {
+				delete(conv.UsedNames, strings.ToLower(fk.Name))
+				continue
+			}
+			fks = append(fks, fk)
+		}
+		t.ForeignKeys = fks
+		if t.ParentTable.Id == tableId {
+			t.ParentTable = ddl.InterleavedParent{}
+		}
+		conv.SpSchema[id] = t
+	}
+
+	for id, cs := range conv.SpChangeStreams {
+		tables := []ddl.ChangeStreamTable{}
+		for _, t := range cs.Tables {
+			if t.TableId != tableId {
+				tables = append(tables, t)
+			}
+		}
+		cs.Tables = tables
+		conv.SpChangeStreams[id] = cs
+	}
+
+	// Columns can't be interleaved in the dropped table anymore.
+	for id, tableIssues := range conv.SchemaIssues {
+		for colId, colIssues := range tableIssues.ColumnLevelIssues {
+			issues := filter(colIssues, func(issue SchemaIssue) bool { return issue != InterleavedOrder })
+			if len(issues) == 0 {
+				delete(conv.SchemaIssues[id].ColumnLevelIssues, colId)
+			} else {
+				conv.SchemaIssues[id].ColumnLevelIssues[colId] = issues
+			}
+		}
+	}
+}
+
+// DropSpannerColumn removes a column from a Spanner table. Foreign keys on
+// the column or referring to it and its timezone policy are dropped, and
+// the column is removed from the primary key and the indexes, which are
+// dropped if they have no keys left.
+func (conv *Conv) DropSpannerColumn(tableId, colId string) {
+	for id, t := range conv.SpSchema {
+		t.ForeignKeys = filter(t.ForeignKeys, func(fk ddl.Foreignkey) bool {
+			if (id == tableId && Contains(fk.ColIds, colId)) || (fk.ReferTableId == tableId && Contains(fk.ReferColumnIds, colId)) {
+				delete(conv.UsedNames, strings.ToLower(fk.Name))
+				return false
+			}
+			return true
+		})
+		conv.SpSchema[id] = t
+	}
+	spTable := conv.SpSchema[tableId]
+	otherCol := func(id string) bool { return id != colId }
+	otherKey := func(k ddl.IndexKey) bool { return k.ColId != colId }
+
+	spTable.PrimaryKeys = filter(spTable.PrimaryKeys, otherKey)
+
+	var indexes []ddl.CreateIndex
+	for _, index := range spTable.Indexes {
+		if index.Keys = filter(index.Keys, otherKey); len(index.Keys) == 0 {
+			delete(conv.UsedNames, strings.ToLower(index.Name))
+			continue
+		}
+		index.StoredColumnIds = filter(index.StoredColumnIds, otherCol)
+		indexes = append(indexes, index)
+	}
+	spTable.Indexes = indexes
+
+	var searchIndexes []ddl.CreateSearchIndex
+	for _, si := range spTable.SearchIndexes {
+		if si.Keys = filter(si.Keys, func(k ddl.SearchIndexKey) bool { return k.ColId != colId }); len(si.Keys) == 0 {
+			delete(conv.UsedNames, strings.ToLower(si.Name))
+			continue
+		}
+		si.StoredColumnIds = filter(si.StoredColumnIds, otherCol)
+		si.PartitionColIds = filter(si.PartitionColIds, otherCol)
+		si.OrderBy = filter(si.OrderBy, otherKey)
+		searchIndexes = append(searchIndexes, si)
+	}
+	spTable.SearchIndexes = searchIndexes
+
+	var vectorIndexes []ddl.CreateVectorIndex
+	for _, vi := range spTable.VectorIndexes {
+		if vi.ColId == colId {
+			delete(conv.UsedNames, strings.ToLower(vi.Name))
+			continue
+		}
+		vi.StoredColumnIds = filter(vi.StoredColumnIds, otherCol)
+		vectorIndexes = append(vectorIndexes, vi)
+	}
+	spTable.VectorIndexes = vectorIndexes
+
+	for id, cs := range conv.SpChangeStreams {
+		for i, t := range cs.Tables {
+			if t.TableId == tableId && len(t.ColumnIds) > 0 {
+				cs.Tables[i].ColumnIds = filter(t.ColumnIds, otherCol)
+				// Keep watching the keys rather than all the columns.
+				cs.Tables[i].KeysOnly = len(cs.Tables[i].ColumnIds) == 0
+			}
+		}
+		conv.SpChangeStreams[id] = cs
+	}
+
+	if autoGen := spTable.ColDefs[colId].AutoGen; autoGen.GenerationType == constants.SEQUENCE {
+		for id, seq := range conv.SpSequences {
+			if seq.Name == autoGen.Name && seq.ColumnsUsingSeq != nil {
+				seq.ColumnsUsingSeq[tableId] = filter(seq.ColumnsUsingSeq[tableId], otherCol)
+				conv.SpSequences[id] = seq
+			}
+		}
+	}
+
+	if spTable.RowDeletionPolicy.ColId == colId {
+		spTable.RowDeletionPolicy = ddl.RowDeletionPolicy{}
+	}
+	if _, ok := conv.TimezonePolicies[tableId]; ok {
+		conv.SetTimezonePolicy(tableId, colId, TimezonePolicy{})
+	}
+	spTable.ColIds = filter(spTable.ColIds, otherCol)
+	delete(spTable.ColDefs, colId)
+	if conv.SchemaIssues[tableId].ColumnLevelIssues != nil {
+		delete(conv.SchemaIssues[tableId].ColumnLevelIssues, colId)
+	}
+	conv.SpSchema[tableId] = spTable
+}
+
+// filter returns the elements of l for which keep returns true, or nil if
+// there are none.
+func filter[T any](l []T, keep func(T) bool) []T {
+	var kept []T
+	for _, e := range l {
+		if keep(e) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSchemaEditsTestConv returns a conv with the tables users (t1), orders
+// (t2), which refers to users, and items (t3), which is interleaved in
+// orders.
+func newSchemaEditsTestConv() *Conv {
+	conv := MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name: "users", Id: "t1", ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "user_id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys:   []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			Indexes:       []ddl.CreateIndex{{Name: "users_by_name", Id: "i1", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}}}},
+			SearchIndexes: []ddl.CreateSearchIndex{{Name: "users_search", Id: "s1", TableId: "t1", Keys: []ddl.SearchIndexKey{{ColId: "c2"}}}},
+		},
+		"t2": {
+			Name: "orders", Id: "t2", ColIds: []string{"c3", "c4", "c5"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c3": {Name: "user_id", Id: "c3", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c4": {Name: "order_id", Id: "c4", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c5": {Name: "created", Id: "c5", T: ddl.Type{Name: ddl.Timestamp}},
+			},
+			PrimaryKeys:       []ddl.IndexKey{{ColId: "c3", Order: 1}, {ColId: "c4", Order: 2}},
+			ForeignKeys:       []ddl.Foreignkey{{Name: "orders_user", Id: "f1", ColIds: []string{"c3"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}}},
+			Indexes:           []ddl.CreateIndex{{Name: "orders_by_created", Id: "i2", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c4", Order: 1}, {ColId: "c5", Order: 2}}}},
+			RowDeletionPolicy: ddl.RowDeletionPolicy{ColId: "c5", Days: 30},
+		},
+		"t3": {
+			Name: "items", Id: "t3", ColIds: []string{"c6", "c7", "c8"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c6": {Name: "user_id", Id: "c6", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c7": {Name: "order_id", Id: "c7", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c8": {Name: "item_id", Id: "c8", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c6", Order: 1}, {ColId: "c7", Order: 2}, {ColId: "c8", Order: 3}},
+			ParentTable: ddl.InterleavedParent{Id: "t2", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+		},
+	}
+	conv.SpChangeStreams = map[string]ddl.ChangeStream{
+		"cs1": {Name: "orders_changes", Id: "cs1", Tables: []ddl.ChangeStreamTable{{TableId: "t1"}, {TableId: "t2", ColumnIds: []string{"c5"}}}},
+	}
+	conv.SchemaIssues = map[string]TableIssues{
+		"t2": {ColumnLevelIssues: map[string][]SchemaIssue{"c5": {Timestamp}}},
+		"t3": {ColumnLevelIssues: map[string][]SchemaIssue{"c8": {InterleavedOrder, Widened}}},
+	}
+	conv.UsedNames = ComputeUsedNames(conv)
+	conv.UsedNames["users_search"] = true
+	conv.SetTimezonePolicy("t2", "", TimezonePolicy{Mode: TimezoneUTC})
+	conv.SetTimezonePolicy("t2", "c5", TimezonePolicy{Mode: TimezoneZone, Location: "Europe/Paris"})
+	return conv
+}
+
+func TestCheckInterleaving(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tableId  string
+		parentId string
+		wantErr  string
+	}{
+		{"Primary key is a prefix", "t2", "t1", ""},
+		{"Interleaving in the grandparent", "t3", "t1", ""},
+		{"Fewer primary keys than the parent", "t1", "t2", "The child table 'users' has '1' primary keys, which is less than the parent table 'orders' primary keys count of '2'."},
+		{"Parent is the table", "t2", "t2", "Interleaving table 'orders' in parent table 'orders' will create a cycle."},
+	}
+	for _, tc := range testCases {
+		err := newSchemaEditsTestConv().CheckInterleaving(tc.tableId, tc.parentId)
+		if tc.wantErr == "" {
+			assert.Nil(t, err, tc.name)
+			continue
+		}
+		assert.EqualError(t, err, tc.wantErr, tc.name)
+	}
+
+	conv := newSchemaEditsTestConv()
+	// The primary key of orders is also that of its child items.
+	orders := conv.SpSchema["t2"]
+	orders.ParentTable = ddl.InterleavedParent{Id: "t3", InterleaveType: "IN"}
+	conv.SpSchema["t2"] = orders
+	assert.EqualError(t, conv.CheckInterleaving("t3", "t2"), "Interleaving table 'items' in parent table 'orders' will create a cycle.")
+
+	conv = newSchemaEditsTestConv()
+	col := conv.SpSchema["t2"].ColDefs["c3"]
+	col.Name = "customer_id"
+	conv.SpSchema["t2"].ColDefs["c3"] = col
+	assert.EqualError(t, conv.CheckInterleaving("t2", "t1"), "The child table 'orders' does not have primary key 'user_id' of parent table 'users'.")
+
+	conv = newSchemaEditsTestConv()
+	conv.SpSchema["t2"].PrimaryKeys[0].Order = 2
+	assert.EqualError(t, conv.CheckInterleaving("t2", "t1"), "The primary key 'user_id' of parent table 'users' is at order '1', but in child table 'orders' it is at order '2'.")
+}
+
+func TestCheckInterleavingCycle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		parents  map[string]string
+		tableId  string
+		parentId string
+		cycle    bool
+	}{
+		{"No cycle in a chain", map[string]string{"b": "a"}, "c", "b", false},
+		{"Cycle in a chain", map[string]string{"b": "a", "c": "b"}, "a", "c", true},
+		{"No cycle with a disconnected chain", map[string]string{"b": "a", "d": "c"}, "c", "b", false},
+		{"Cycle in a disconnected chain", map[string]string{"b": "a", "d": "c"}, "c", "d", true},
+	}
+	for _, tc := range testCases {
+		conv := MakeConv()
+		for _, id := range []string{"a", "b", "c", "d"} {
+			conv.SpSchema[id] = ddl.CreateTable{
+				Name: id, Id: id, ColIds: []string{id + "1"},
+				ColDefs:     map[string]ddl.ColumnDef{id + "1": {Name: "id", Id: id + "1", T: ddl.Type{Name: ddl.Int64}, NotNull: true}},
+				PrimaryKeys: []ddl.IndexKey{{ColId: id + "1", Order: 1}},
+				ParentTable: ddl.InterleavedParent{Id: tc.parents[id]},
+			}
+		}
+		err := conv.CheckInterleaving(tc.tableId, tc.parentId)
+		assert.Equal(t, tc.cycle, err != nil, tc.name)
+	}
+}
+
+func TestDropSpannerTable(t *testing.T) {
+	conv := newSchemaEditsTestConv()
+
+	conv.DropSpannerTable("t2")
+	assert.NotContains(t, conv.SpSchema, "t2")
+	assert.Equal(t, ddl.InterleavedParent{}, conv.SpSchema["t3"].ParentTable)
+	assert.Equal(t, []ddl.ChangeStreamTable{{TableId: "t1"}}, conv.SpChangeStreams["cs1"].Tables)
+	assert.Equal(t, map[string][]SchemaIssue{"c8": {Widened}}, conv.SchemaIssues["t3"].ColumnLevelIssues)
+	assert.Empty(t, conv.SchemaIssues["t2"].ColumnLevelIssues)
+	for _, name := range []string{"orders", "orders_user", "orders_by_created"} {
+		assert.False(t, conv.UsedNames[name], name)
+	}
+	assert.True(t, conv.UsedNames["users"])
+	assert.NotContains(t, conv.TimezonePolicies, "t2")
+
+	conv.DropSpannerTable("t1")
+	assert.Equal(t, []ddl.ChangeStreamTable{}, conv.SpChangeStreams["cs1"].Tables)
+	assert.False(t, conv.UsedNames["users_search"])
+
+	// Dropping a table twice does nothing.
+	conv.DropSpannerTable("t1")
+	assert.Len(t, conv.SpSchema, 1)
+}
+
+func TestDropSpannerColumn(t *testing.T) {
+	conv := newSchemaEditsTestConv()
+
+	conv.DropSpannerColumn("t2", "c5")
+	orders := conv.SpSchema["t2"]
+	assert.Equal(t, []string{"c3", "c4"}, orders.ColIds)
+	assert.NotContains(t, orders.ColDefs, "c5")
+	assert.Equal(t, []ddl.IndexKey{{ColId: "c4", Order: 1}}, orders.Indexes[0].Keys)
+	assert.Equal(t, ddl.RowDeletionPolicy{}, orders.RowDeletionPolicy)
+	assert.Equal(t, ddl.ChangeStreamTable{TableId: "t2", KeysOnly: true}, conv.SpChangeStreams["cs1"].Tables[1])
+	assert.NotContains(t, conv.SchemaIssues["t2"].ColumnLevelIssues, "c5")
+	assert.NotContains(t, conv.TimezonePolicies["t2"].Columns, "c5")
+	assert.Equal(t, TimezonePolicy{Mode: TimezoneUTC}, conv.TimezonePolicies["t2"].Default)
+
+	// Indexes left without keys are dropped, and so are the foreign keys
+	// referring to the column.
+	conv.DropSpannerColumn("t1", "c2")
+	assert.Nil(t, conv.SpSchema["t1"].Indexes)
+	assert.Nil(t, conv.SpSchema["t1"].SearchIndexes)
+	assert.False(t, conv.UsedNames["users_by_name"])
+	assert.False(t, conv.UsedNames["users_search"])
+	conv.DropSpannerColumn("t1", "c1")
+	assert.Nil(t, conv.SpSchema["t1"].PrimaryKeys)
+	assert.Nil(t, conv.SpSchema["t2"].ForeignKeys)
+	assert.False(t, conv.UsedNames["orders_user"])
+}
@@ -143,6 +143,7 @@ func AssertSrcSchema(t *testing.T, conv *Conv, expectedSchema, actualSchema map[
 	for tableName, expectedTable := range expectedSchema {
 		tableId, _ := GetTableIdFromSrcName(conv.SrcSchema, tableName)
 		assert.NotEqual(t, tableId, "")
+		assert.Equal(t, expectedTable.Comment, actualSchema[tableId].Comment)
 		assertSrcColDef(t, conv, tableId, expectedTable.ColDefs, actualSchema[tableId].ColDefs)
 		assertSrcPk(t, conv, tableId, expectedTable.PrimaryKeys, actualSchema[tableId].PrimaryKeys)
 		assertSrcFk(t, conv, tableId, expectedTable.ForeignKeys, actualSchema[tableId].ForeignKeys)
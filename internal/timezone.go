@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Modes of a timezone policy.
+const (
+	// TimezoneZone interprets the values in the IANA zone of the policy.
+	TimezoneZone string = "ZONE"
+	// TimezoneUTC interprets the values as UTC.
+	TimezoneUTC string = "UTC"
+	// TimezoneString keeps the values as text, in a STRING column.
+	TimezoneString string = "STRING"
+	// TimezoneDate keeps the date of the values, in a DATE column.
+	TimezoneDate string = "DATE"
+)
+
+// TimezonePolicy controls the conversion of the date and time values of a
+// column that have no timezone of their own, e.g. MySQL DATETIME values.
+// Values that carry a timezone or an offset keep it. The zero policy leaves
+// the conversion to the database-wide setting (conv.TimezoneOffset for MySQL,
+// conv.Location for PostgreSQL, UTC otherwise).
+type TimezonePolicy struct {
+	Mode     string // One of the Timezone modes, or empty.
+	Location string // IANA zone name, e.g. Europe/Paris, for TimezoneZone.
+}
+
+// TableTimezones are the timezone policies of a table.
+type TableTimezones struct {
+	Default TimezonePolicy            // Applies to the columns without a policy of their own.
+	Columns map[string]TimezonePolicy // Maps column id to policy.
+}
+
+// Validate checks that the mode of the policy is known and that its
+// location, if any, is a valid IANA zone.
+func (p TimezonePolicy) Validate() error {
+	switch p.Mode {
+	case "", TimezoneUTC, TimezoneString, TimezoneDate:
+		if p.Location != "" {
+			return fmt.Errorf("a location can only be set with mode %s", TimezoneZone)
+		}
+	case TimezoneZone:
+		if p.Location == "" {
+			return fmt.Errorf("mode %s needs a location", TimezoneZone)
+		}
+		if _, err := loadLocation(p.Location); err != nil {
+			return fmt.Errorf("invalid location %s: %w", p.Location, err)
+		}
+	default:
+		return fmt.Errorf("unknown timezone mode %s: expected %s, %s, %s or %s", p.Mode, TimezoneZone, TimezoneUTC, TimezoneString, TimezoneDate)
+	}
+	return nil
+}
+
+// TimezonePolicy returns the timezone policy of a column: its own policy if
+// it has one, and the default policy of its table otherwise.
+func (conv *Conv) TimezonePolicy(tableId, colId string) TimezonePolicy {
+	tp := conv.TimezonePolicies[tableId]
+	if p, ok := tp.Columns[colId]; ok {
+		return p
+	}
+	return tp.Default
+}
+
+// SetTimezonePolicy sets the timezone policy of a column, or the default
+// policy of the table if colId is empty. The zero policy removes the policy.
+// It doesn't change the type of the columns, see common.SetTimezonePolicy.
+func (conv *Conv) SetTimezonePolicy(tableId, colId string, p TimezonePolicy) {
+	if conv.TimezonePolicies == nil {
+		conv.TimezonePolicies = make(map[string]TableTimezones)
+	}
+	tp := conv.TimezonePolicies[tableId]
+	if colId == "" {
+		tp.Default = p
+	} else if p == (TimezonePolicy{}) {
+		delete(tp.Columns, colId)
+	} else {
+		if tp.Columns == nil {
+			tp.Columns = make(map[string]TimezonePolicy)
+		}
+		tp.Columns[colId] = p
+	}
+	if tp.Default == (TimezonePolicy{}) && len(tp.Columns) == 0 {
+		delete(conv.TimezonePolicies, tableId)
+		return
+	}
+	conv.TimezonePolicies[tableId] = tp
+}
+
+// TimezoneLocation returns the location in which the values without a
+// timezone of a column are interpreted, as set by its timezone policy. It
+// returns nil when the policy doesn't set one, and the database-wide setting
+// applies.
+func (conv *Conv) TimezoneLocation(tableId, colId string) *time.Location {
+	p := conv.TimezonePolicy(tableId, colId)
+	switch p.Mode {
+	case TimezoneUTC:
+		return time.UTC
+	case TimezoneZone:
+		loc, err := loadLocation(p.Location)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Invalid timezone location %s: %s", p.Location, err))
+			return nil
+		}
+		return loc
+	}
+	return nil
+}
+
+// InLocation returns the time with the same date and wall clock as t in
+// loc. Drivers return the values of columns without a timezone as UTC times.
+func InLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// locations caches the loaded locations, which are looked up for every
+// converted value.
+var locations sync.Map
+
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
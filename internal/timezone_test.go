@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimezonePolicyValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		p       TimezonePolicy
+		wantErr bool
+	}{
+		{"No policy", TimezonePolicy{}, false},
+		{"Zone", TimezonePolicy{Mode: TimezoneZone, Location: "Asia/Kolkata"}, false},
+		{"UTC", TimezonePolicy{Mode: TimezoneUTC}, false},
+		{"String", TimezonePolicy{Mode: TimezoneString}, false},
+		{"Date", TimezonePolicy{Mode: TimezoneDate}, false},
+		{"Zone without location", TimezonePolicy{Mode: TimezoneZone}, true},
+		{"Unknown location", TimezonePolicy{Mode: TimezoneZone, Location: "Mars/Olympus_Mons"}, true},
+		{"Location without zone", TimezonePolicy{Mode: TimezoneUTC, Location: "Asia/Kolkata"}, true},
+		{"Unknown mode", TimezonePolicy{Mode: "LOCAL"}, true},
+	}
+	for _, tc := range testCases {
+		err := tc.p.Validate()
+		assert.Equal(t, tc.wantErr, err != nil, tc.name)
+	}
+}
+
+func TestSetTimezonePolicy(t *testing.T) {
+	conv := MakeConv()
+	zone := TimezonePolicy{Mode: TimezoneZone, Location: "Europe/Paris"}
+	utc := TimezonePolicy{Mode: TimezoneUTC}
+
+	conv.SetTimezonePolicy("t1", "", zone)
+	conv.SetTimezonePolicy("t1", "c2", utc)
+	assert.Equal(t, zone, conv.TimezonePolicy("t1", "c1"))
+	assert.Equal(t, utc, conv.TimezonePolicy("t1", "c2"))
+	assert.Equal(t, TimezonePolicy{}, conv.TimezonePolicy("t2", "c3"))
+
+	// Removing the policy of a column leaves the default of its table.
+	conv.SetTimezonePolicy("t1", "c2", TimezonePolicy{})
+	assert.Equal(t, zone, conv.TimezonePolicy("t1", "c2"))
+
+	conv.SetTimezonePolicy("t1", "", TimezonePolicy{})
+	assert.Equal(t, map[string]TableTimezones{}, conv.TimezonePolicies)
+
+	// Session files written before timezone policies have none.
+	conv.TimezonePolicies = nil
+	assert.Equal(t, TimezonePolicy{}, conv.TimezonePolicy("t1", "c1"))
+	conv.SetTimezonePolicy("t1", "c1", utc)
+	assert.Equal(t, utc, conv.TimezonePolicy("t1", "c1"))
+}
+
+func TestTimezoneLocation(t *testing.T) {
+	conv := MakeConv()
+	conv.SetTimezonePolicy("t1", "", TimezonePolicy{Mode: TimezoneZone, Location: "Asia/Kolkata"})
+	conv.SetTimezonePolicy("t1", "c2", TimezonePolicy{Mode: TimezoneUTC})
+	conv.SetTimezonePolicy("t1", "c3", TimezonePolicy{Mode: TimezoneString})
+
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	assert.Nil(t, err)
+	assert.Equal(t, kolkata.String(), conv.TimezoneLocation("t1", "c1").String())
+	assert.Equal(t, time.UTC, conv.TimezoneLocation("t1", "c2"))
+	assert.Nil(t, conv.TimezoneLocation("t1", "c3"))
+	assert.Nil(t, conv.TimezoneLocation("t2", "c4"))
+}
+
+func TestInLocation(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	assert.Nil(t, err)
+	got := InLocation(time.Date(2024, 3, 1, 12, 30, 0, 500, time.UTC), kolkata)
+	assert.Equal(t, time.Date(2024, 3, 1, 7, 0, 0, 500, time.UTC), got.UTC())
+}
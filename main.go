@@ -55,6 +55,8 @@ func main() {
 	subcommands.Register(&cmd.AssessmentCmd{}, "")
 	subcommands.Register(&webv2.WebCmd{DistDir: distDir}, "")
 	subcommands.Register(&cmd.ImportDataCmd{}, "")
+	subcommands.Register(&cmd.ValidateCmd{}, "")
+	subcommands.Register(&cmd.SchemaDiffCmd{}, "")
 	flag.Parse()
 	os.Exit(int(subcommands.Execute(ctx)))
 }
@@ -45,6 +45,16 @@ type Table struct {
 	CheckConstraints []CheckConstraint
 	Indexes          []Index
 	Id               string
+	Comment          string // Comment of the table in the source database.
+}
+
+// View represents a database view.
+type View struct {
+	Name        string
+	Schema      string
+	Id          string
+	Definition  string // Query of the view, in the dialect of the source database.
+	CheckOption bool   // If true, the view has a WITH CHECK OPTION clause.
 }
 
 // Column represents a database column.
@@ -58,6 +68,7 @@ type Column struct {
 	AutoGen         ddl.AutoGenCol
 	DefaultValue    ddl.DefaultValue
 	GeneratedColumn ddl.GeneratedColumn
+	Comment         string // Comment of the column in the source database.
 }
 
 // ForeignKey represents a foreign key.
@@ -116,8 +127,9 @@ type Index struct {
 // Type represents the type of a column.
 type Type struct {
 	Name        string
-	Mods        []int64 // List of modifiers (aka type parameters e.g. varchar(8) or numeric(6, 4).
-	ArrayBounds []int64 // Empty for scalar types.
+	Mods        []int64  // List of modifiers (aka type parameters e.g. varchar(8) or numeric(6, 4).
+	ArrayBounds []int64  // Empty for scalar types.
+	EnumValues  []string // Labels of enum and set types, in order.
 }
 
 // Ignored represents column properties/constraints that are not
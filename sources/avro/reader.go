@@ -0,0 +1,275 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package avro reads the rows of Avro object container files, so that they
+// can be imported into Spanner. The writer schema of the file must be a
+// record whose fields are primitive types, arrays of primitive types, or
+// unions of one of those with null.
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/linkedin/goavro/v2"
+)
+
+type converter func(interface{}) (interface{}, error)
+
+type column struct {
+	common.FileColumn
+	convert converter
+}
+
+// Reader reads the records of an Avro object container file.
+type Reader struct {
+	ocf     *goavro.OCFReader
+	columns []column
+	// named holds the definitions of named types, which later fields can
+	// refer to by name.
+	named map[string]map[string]interface{}
+}
+
+// NewReader returns a Reader for the Avro object container file r.
+func NewReader(r io.Reader) (*Reader, error) {
+	ocf, err := goavro.NewOCFReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("can't read Avro file: %v", err)
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(ocf.Codec().Schema()), &parsed); err != nil {
+		return nil, fmt.Errorf("can't parse Avro schema: %v", err)
+	}
+	schema, _ := parsed.(map[string]interface{})
+	if schema["type"] != "record" {
+		return nil, fmt.Errorf("Avro schema must be a record, found %v", parsed)
+	}
+	fields, _ := schema["fields"].([]interface{})
+	ar := &Reader{ocf: ocf, named: map[string]map[string]interface{}{}}
+	for _, f := range fields {
+		field, _ := f.(map[string]interface{})
+		name, _ := field["name"].(string)
+		t, notNull, convert, err := ar.fieldType(field["type"])
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", name, err)
+		}
+		ar.columns = append(ar.columns, column{FileColumn: common.FileColumn{Name: name, Type: t, NotNull: notNull}, convert: convert})
+	}
+	return ar, nil
+}
+
+// Columns returns the columns of the file.
+func (ar *Reader) Columns() []common.FileColumn {
+	var cols []common.FileColumn
+	for _, c := range ar.columns {
+		cols = append(cols, c.FileColumn)
+	}
+	return cols
+}
+
+// Read returns the values of the next record of the file, or io.EOF if
+// there are no records left.
+func (ar *Reader) Read() ([]interface{}, error) {
+	if !ar.ocf.Scan() {
+		if err := ar.ocf.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	datum, err := ar.ocf.Read()
+	if err != nil {
+		return nil, err
+	}
+	record, ok := datum.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected Avro record %T", datum)
+	}
+	row := make([]interface{}, len(ar.columns))
+	for i, c := range ar.columns {
+		if row[i], err = c.convert(unwrapUnion(record[c.Name])); err != nil {
+			return nil, fmt.Errorf("field %s: %v", c.Name, err)
+		}
+	}
+	return row, nil
+}
+
+// unwrapUnion returns the value of a union, which goavro encodes as a map
+// from the name of the value's type to the value.
+func unwrapUnion(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		for _, x := range m {
+			return x
+		}
+	}
+	return v
+}
+
+// fieldType returns the Spanner type of the Avro type t, whether it's not
+// nullable, and a function that converts goavro values of t to Go values of
+// the Spanner type.
+func (ar *Reader) fieldType(t interface{}) (ddl.Type, bool, converter, error) {
+	if union, ok := t.([]interface{}); ok {
+		var types []interface{}
+		for _, u := range union {
+			if u != "null" {
+				types = append(types, u)
+			}
+		}
+		if len(types) != 1 {
+			return ddl.Type{}, false, nil, fmt.Errorf("unions of more than one non-null type aren't supported")
+		}
+		st, _, convert, err := ar.fieldType(types[0])
+		return st, len(types) == len(union), convert, err
+	}
+	if m, ok := t.(map[string]interface{}); ok && m["type"] == "array" {
+		et, _, convert, err := ar.primitiveType(unwrapNullable(m["items"]))
+		if err != nil {
+			return ddl.Type{}, false, nil, fmt.Errorf("array items: %v", err)
+		}
+		et.IsArray = true
+		return et, true, func(v interface{}) (interface{}, error) {
+			if v == nil {
+				return nil, nil
+			}
+			items, ok := v.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unexpected Avro array %T", v)
+			}
+			values := make([]interface{}, len(items))
+			for i, item := range items {
+				var err error
+				if values[i], err = convert(unwrapUnion(item)); err != nil {
+					return nil, err
+				}
+			}
+			return values, nil
+		}, nil
+	}
+	return ar.primitiveType(t)
+}
+
+// unwrapNullable returns the non-null type of a union of a type with null,
+// and t for any other type.
+func unwrapNullable(t interface{}) interface{} {
+	if union, ok := t.([]interface{}); ok && len(union) == 2 {
+		if union[0] == "null" {
+			return union[1]
+		}
+		if union[1] == "null" {
+			return union[0]
+		}
+	}
+	return t
+}
+
+func (ar *Reader) primitiveType(t interface{}) (ddl.Type, bool, converter, error) {
+	identity := func(v interface{}) (interface{}, error) { return v, nil }
+	str := ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+	var name, logical string
+	switch x := t.(type) {
+	case string:
+		name = x
+		if def, ok := ar.named[x]; ok {
+			return ar.primitiveType(def)
+		}
+	case map[string]interface{}:
+		name, _ = x["type"].(string)
+		logical, _ = x["logicalType"].(string)
+		if n, ok := x["name"].(string); ok {
+			ar.named[n] = x
+		}
+		if _, ok := x["type"].(map[string]interface{}); ok {
+			return ar.primitiveType(x["type"])
+		}
+	default:
+		return ddl.Type{}, false, nil, fmt.Errorf("unsupported Avro type %v", t)
+	}
+	switch logical {
+	case "decimal":
+		return ddl.Type{Name: ddl.Numeric}, true, identity, nil
+	case "uuid":
+		return ddl.Type{Name: ddl.String, Len: 36}, true, identity, nil
+	case "date":
+		return ddl.Type{Name: ddl.Date}, true, func(v interface{}) (interface{}, error) {
+			switch d := v.(type) {
+			case nil:
+				return nil, nil
+			case time.Time:
+				return civil.DateOf(d.UTC()), nil
+			case int32:
+				return civil.DateOf(time.Unix(int64(d)*24*60*60, 0).UTC()), nil
+			}
+			return nil, fmt.Errorf("unexpected Avro date %T", v)
+		}, nil
+	case "time-millis", "time-micros":
+		return str, true, func(v interface{}) (interface{}, error) {
+			switch d := v.(type) {
+			case nil:
+				return nil, nil
+			case time.Duration:
+				return time.Time{}.Add(d).Format("15:04:05.999999"), nil
+			}
+			return nil, fmt.Errorf("unexpected Avro time %T", v)
+		}, nil
+	case "timestamp-millis", "local-timestamp-millis":
+		return ddl.Type{Name: ddl.Timestamp}, true, timestampConverter(time.UnixMilli), nil
+	case "timestamp-micros", "local-timestamp-micros":
+		return ddl.Type{Name: ddl.Timestamp}, true, timestampConverter(time.UnixMicro), nil
+	case "timestamp-nanos", "local-timestamp-nanos":
+		return ddl.Type{Name: ddl.Timestamp}, true, timestampConverter(func(n int64) time.Time { return time.Unix(0, n) }), nil
+	}
+	switch name {
+	case "boolean":
+		return ddl.Type{Name: ddl.Bool}, true, identity, nil
+	case "int":
+		return ddl.Type{Name: ddl.Int64}, true, func(v interface{}) (interface{}, error) {
+			if i, ok := v.(int32); ok {
+				return int64(i), nil
+			}
+			return v, nil
+		}, nil
+	case "long":
+		return ddl.Type{Name: ddl.Int64}, true, identity, nil
+	case "float":
+		return ddl.Type{Name: ddl.Float32}, true, identity, nil
+	case "double":
+		return ddl.Type{Name: ddl.Float64}, true, identity, nil
+	case "string", "enum":
+		return str, true, identity, nil
+	case "bytes", "fixed":
+		return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, true, identity, nil
+	}
+	return ddl.Type{}, false, nil, fmt.Errorf("unsupported Avro type %s", name)
+}
+
+// timestampConverter returns a converter for timestamps, which goavro
+// decodes as time.Time, or as an int64 for logical types it doesn't know.
+func timestampConverter(fromInt func(int64) time.Time) converter {
+	return func(v interface{}) (interface{}, error) {
+		switch t := v.(type) {
+		case nil:
+			return nil, nil
+		case time.Time:
+			return t.UTC(), nil
+		case int64:
+			return fromInt(t).UTC(), nil
+		}
+		return nil, fmt.Errorf("unexpected Avro timestamp %T", v)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeOCF(t *testing.T, schema string, records ...map[string]interface{}) []byte {
+	var b bytes.Buffer
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &b, Schema: schema})
+	assert.Nil(t, err)
+	for _, r := range records {
+		assert.Nil(t, w.Append([]interface{}{r}))
+	}
+	return b.Bytes()
+}
+
+func TestReader(t *testing.T) {
+	schema := `{"type": "record", "name": "event", "fields": [
+		{"name": "id", "type": "long"},
+		{"name": "count", "type": ["null", "int"]},
+		{"name": "name", "type": "string"},
+		{"name": "kind", "type": {"type": "enum", "name": "kind", "symbols": ["A", "B"]}},
+		{"name": "other_kind", "type": ["null", "kind"]},
+		{"name": "score", "type": "double"},
+		{"name": "ratio", "type": "float"},
+		{"name": "ok", "type": "boolean"},
+		{"name": "data", "type": "bytes"},
+		{"name": "price", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+		{"name": "day", "type": {"type": "int", "logicalType": "date"}},
+		{"name": "ts", "type": ["null", {"type": "long", "logicalType": "timestamp-micros"}]},
+		{"name": "at", "type": {"type": "int", "logicalType": "time-millis"}},
+		{"name": "tags", "type": {"type": "array", "items": ["null", "string"]}}
+	]}`
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+	file := writeOCF(t, schema,
+		map[string]interface{}{
+			"id": int64(1), "count": goavro.Union("int", int32(3)), "name": "a", "kind": "B",
+			"other_kind": goavro.Union("kind", "A"), "score": 1.5, "ratio": float32(0.5), "ok": true,
+			"data": []byte{1, 2}, "price": big.NewRat(12345, 100), "day": time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+			"ts": goavro.Union("long.timestamp-micros", ts), "at": 3723 * time.Second,
+			"tags": []interface{}{goavro.Union("string", "x"), nil},
+		},
+		map[string]interface{}{
+			"id": int64(2), "count": nil, "name": "b", "kind": "A", "other_kind": nil, "score": -2.0,
+			"ratio": float32(0), "ok": false, "data": []byte{}, "price": big.NewRat(-5, 100),
+			"day": time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC), "ts": nil, "at": time.Duration(0),
+			"tags": []interface{}{},
+		})
+
+	r, err := NewReader(bytes.NewReader(file))
+	assert.Nil(t, err)
+	str := ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+	assert.Equal(t, []common.FileColumn{
+		{Name: "id", Type: ddl.Type{Name: ddl.Int64}, NotNull: true},
+		{Name: "count", Type: ddl.Type{Name: ddl.Int64}},
+		{Name: "name", Type: str, NotNull: true},
+		{Name: "kind", Type: str, NotNull: true},
+		{Name: "other_kind", Type: str},
+		{Name: "score", Type: ddl.Type{Name: ddl.Float64}, NotNull: true},
+		{Name: "ratio", Type: ddl.Type{Name: ddl.Float32}, NotNull: true},
+		{Name: "ok", Type: ddl.Type{Name: ddl.Bool}, NotNull: true},
+		{Name: "data", Type: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, NotNull: true},
+		{Name: "price", Type: ddl.Type{Name: ddl.Numeric}, NotNull: true},
+		{Name: "day", Type: ddl.Type{Name: ddl.Date}, NotNull: true},
+		{Name: "ts", Type: ddl.Type{Name: ddl.Timestamp}},
+		{Name: "at", Type: str, NotNull: true},
+		{Name: "tags", Type: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, NotNull: true},
+	}, r.Columns())
+
+	row, err := r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, "123.45", row[9].(*big.Rat).FloatString(2))
+	row[9] = nil
+	assert.Equal(t, []interface{}{
+		int64(1), int64(3), "a", "B", "A", 1.5, float32(0.5), true, []byte{1, 2}, nil,
+		civil.Date{Year: 2024, Month: 5, Day: 6}, ts, "01:02:03", []interface{}{"x", nil},
+	}, row)
+
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, "-0.05", row[9].(*big.Rat).FloatString(2))
+	row[9] = nil
+	assert.Equal(t, []interface{}{
+		int64(2), nil, "b", "A", nil, -2.0, float32(0), false, []byte{}, nil,
+		civil.Date{Year: 1969, Month: 12, Day: 31}, nil, "00:00:00", []interface{}{},
+	}, row)
+
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestNewReader_Errors(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("not avro")))
+	assert.NotNil(t, err)
+
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"not a record", `"long"`, "must be a record"},
+		{"union", `{"type": "record", "name": "r", "fields": [{"name": "u", "type": ["int", "string"]}]}`, "field u: unions of more than one non-null type"},
+		{"map", `{"type": "record", "name": "r", "fields": [{"name": "m", "type": {"type": "map", "values": "int"}}]}`, "field m: unsupported Avro type map"},
+		{"nested record", `{"type": "record", "name": "r", "fields": [{"name": "n", "type": {"type": "record", "name": "n", "fields": []}}]}`, "field n: unsupported Avro type record"},
+		{"nested array", `{"type": "record", "name": "r", "fields": [{"name": "a", "type": {"type": "array", "items": {"type": "array", "items": "int"}}}]}`, "field a: array items"},
+	}
+	for _, tc := range tests {
+		_, err := NewReader(bytes.NewReader(writeOCF(t, tc.schema)))
+		assert.ErrorContains(t, err, tc.want, tc.name)
+	}
+}
@@ -0,0 +1,397 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+)
+
+// processRow converts a row read from Cassandra and writes it out to
+// Spanner. row is keyed by Cassandra column name, and holds the values
+// returned by gocql, with nil for NULL. The row is converted without
+// holding mutex; the updates of the stats of conv and the write hold it,
+// since conv and the batch writer aren't thread-safe.
+func processRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, row map[string]interface{}, mutex *sync.Mutex, additionalAttributes internal.AdditionalDataAttributes) {
+	cvtCols, cvtVals, err := convertRow(conv, tableId, colIds, srcSchema, spSchema, row, additionalAttributes)
+	mutex.Lock()
+	defer mutex.Unlock()
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't process cassandra data row: %s", err))
+		conv.StatsAddBadRow(srcSchema.Name, conv.DataMode())
+		var srcCols, vals []string
+		for _, colId := range colIds {
+			name := srcSchema.ColDefs[colId].Name
+			srcCols = append(srcCols, name)
+			vals = append(vals, fmt.Sprintf("%v", columnValue(row, name)))
+		}
+		conv.CollectBadRow(srcSchema.Name, srcCols, vals, err)
+		return
+	}
+	conv.WriteRow(srcSchema.Name, spSchema.Name, cvtCols, cvtVals)
+}
+
+// convertRow maps a Cassandra row into Spanner columns and values, based
+// on the Spanner and Cassandra schemas. NULL values are dropped, so the
+// returned columns can be a subset of colIds.
+func convertRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, row map[string]interface{}, additionalAttributes internal.AdditionalDataAttributes) ([]string, []interface{}, error) {
+	var cs []string
+	var vs []interface{}
+	for _, colId := range colIds {
+		srcCd, ok1 := srcSchema.ColDefs[colId]
+		spCd, ok2 := spSchema.ColDefs[colId]
+		if !ok1 || !ok2 {
+			return nil, nil, fmt.Errorf("data conversion: can't find schema for column id %s of table %s", colId, srcSchema.Name)
+		}
+		val := columnValue(row, srcCd.Name)
+		if val == nil {
+			continue
+		}
+		spVal, err := convValue(conv, spCd.T, srcCd.Type.Name, val)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't convert column %s: %w", srcCd.Name, err)
+		}
+		cs = append(cs, spCd.Name)
+		vs = append(vs, spVal)
+	}
+	if colId := spSchema.ShardIdColumn; colId != "" {
+		cs = append(cs, spSchema.ColDefs[colId].Name)
+		vs = append(vs, additionalAttributes.ShardId)
+	}
+	return cs, vs, nil
+}
+
+// columnValue returns the value of column name in row. gocql returns the
+// elements of a tuple column as separate columns, which are collected
+// into a slice here.
+func columnValue(row map[string]interface{}, name string) interface{} {
+	if v, ok := row[name]; ok {
+		return v
+	}
+	var elems []interface{}
+	allNull := true
+	for i := 0; ; i++ {
+		v, ok := row[gocql.TupleColumnName(name, i)]
+		if !ok {
+			break
+		}
+		elems = append(elems, v)
+		allNull = allNull && v == nil
+	}
+	if allNull {
+		return nil
+	}
+	return elems
+}
+
+// convValue converts a non-NULL value returned by gocql for a column of
+// Cassandra type srcTypeName to a value of Spanner type spType.
+func convValue(conv *internal.Conv, spType ddl.Type, srcTypeName string, val interface{}) (interface{}, error) {
+	if spType.IsArray {
+		return convArray(conv, spType, srcTypeName, val)
+	}
+	return convScalar(conv, spType, srcTypeName, val)
+}
+
+// convScalar converts a single Cassandra value to a Spanner value of type
+// spType. The conversions follow the options offered by typeMappings.
+func convScalar(conv *internal.Conv, spType ddl.Type, srcTypeName string, val interface{}) (interface{}, error) {
+	switch spType.Name {
+	case ddl.Bool:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+	case ddl.Int64:
+		return convInt64(val)
+	case ddl.Float32:
+		switch v := val.(type) {
+		case float32:
+			return v, nil
+		case float64:
+			return float32(v), nil
+		}
+		if i, err := convInt64(val); err == nil {
+			return float32(i), nil
+		}
+	case ddl.Float64:
+		switch v := val.(type) {
+		case float32:
+			// Go via the shortest decimal representation so that e.g. 0.1
+			// isn't widened to 0.10000000149011612.
+			return strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+		case float64:
+			return v, nil
+		}
+		if i, err := convInt64(val); err == nil {
+			return float64(i), nil
+		}
+	case ddl.Numeric:
+		return convNumeric(conv, val)
+	case ddl.String:
+		return convString(srcTypeName, val)
+	case ddl.Bytes:
+		return convBytes(val)
+	case ddl.Date:
+		if t, ok := val.(time.Time); ok {
+			return civil.DateOf(t.UTC()), nil
+		}
+	case ddl.Timestamp:
+		if t, ok := val.(time.Time); ok {
+			return t, nil
+		}
+	case ddl.JSON:
+		return convJSON(val)
+	case ddl.UUID:
+		if u, ok := val.(gocql.UUID); ok {
+			return uuid.UUID(u), nil
+		}
+		return common.ConvertUUID(val)
+	default:
+		return nil, fmt.Errorf("data conversion not implemented for type %v", spType.Name)
+	}
+	return nil, fmt.Errorf("can't convert value of type %T to %s", val, spType.Name)
+}
+
+func convInt64(val interface{}) (int64, error) {
+	switch v := val.(type) {
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case time.Duration:
+		// Cassandra time values are nanoseconds since midnight.
+		return int64(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case *big.Int:
+		if !v.IsInt64() {
+			return 0, fmt.Errorf("varint %s overflows int64", v)
+		}
+		return v.Int64(), nil
+	}
+	return 0, fmt.Errorf("can't convert value of type %T to int64", val)
+}
+
+// convNumeric maps decimal, varint and other numeric values to a Spanner
+// numeric value for the database dialect.
+func convNumeric(conv *internal.Conv, val interface{}) (interface{}, error) {
+	var s string
+	switch v := val.(type) {
+	case *inf.Dec:
+		s = v.String()
+	case *big.Int:
+		s = v.String()
+	case float32, float64, int8, int16, int, int32, int64:
+		s = fmt.Sprintf("%v", v)
+	default:
+		return nil, fmt.Errorf("can't convert value of type %T to numeric", val)
+	}
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return spanner.PGNumeric{Numeric: s, Valid: true}, nil
+	}
+	r := new(big.Rat)
+	if _, ok := r.SetString(s); !ok {
+		return nil, fmt.Errorf("can't convert %q to big.Rat", s)
+	}
+	return r, nil
+}
+
+// convString returns the CQL text representation of a value.
+func convString(srcTypeName string, val interface{}) (string, error) {
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case gocql.UUID:
+		return v.String(), nil
+	case *big.Int:
+		return v.String(), nil
+	case *inf.Dec:
+		return v.String(), nil
+	case time.Time:
+		if strings.ToLower(srcTypeName) == "date" {
+			return v.UTC().Format("2006-01-02"), nil
+		}
+		return v.UTC().Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return time.Time{}.Add(v).Format("15:04:05.999999999"), nil
+	case gocql.Duration:
+		return formatDuration(v), nil
+	case bool, int8, int16, int, int32, int64:
+		return fmt.Sprintf("%v", v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	}
+	// Collections, UDTs and tuples without a better mapping are stored as JSON.
+	return convJSON(val)
+}
+
+func convBytes(val interface{}) ([]byte, error) {
+	switch v := val.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	case gocql.UUID:
+		return v.Bytes(), nil
+	case *big.Int:
+		// Use the CQL encoding of varint i.e. big-endian two's complement.
+		return gocql.Marshal(gocql.NewNativeType(4, gocql.TypeVarint, ""), v)
+	}
+	return nil, fmt.Errorf("can't convert value of type %T to bytes", val)
+}
+
+// convJSON encodes maps, UDTs, tuples and other collections as a JSON
+// string. Map keys are converted to their text representation.
+func convJSON(val interface{}) (string, error) {
+	b, err := json.Marshal(jsonValue(val))
+	if err != nil {
+		return "", fmt.Errorf("can't convert value of type %T to json: %w", val, err)
+	}
+	return string(b), nil
+}
+
+// jsonValue returns val in a form encoding/json renders the way the
+// value reads in CQL.
+func jsonValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil, string, bool, []byte:
+		return v
+	case *big.Int:
+		return json.Number(v.String())
+	case *inf.Dec:
+		return json.Number(v.String())
+	case gocql.UUID, time.Time, time.Duration, gocql.Duration:
+		s, _ := convString("", v)
+		return s
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Map:
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k, err := convString("", iter.Key().Interface())
+			if err != nil {
+				k = fmt.Sprintf("%v", iter.Key().Interface())
+			}
+			m[k] = jsonValue(iter.Value().Interface())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		a := make([]interface{}, rv.Len())
+		for i := range a {
+			a[i] = jsonValue(rv.Index(i).Interface())
+		}
+		return a
+	}
+	return val
+}
+
+// convArray converts a Cassandra list or set to a Spanner array. The
+// Spanner client doesn't accept []interface{}, so the elements are
+// collected into a slice of the type of the converted elements.
+func convArray(conv *internal.Conv, spType ddl.Type, srcTypeName string, val interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("can't convert value of type %T to array", val)
+	}
+	if rv.Len() == 0 {
+		// Cassandra doesn't distinguish empty collections from NULL.
+		return nil, nil
+	}
+	elemType := spType
+	elemType.IsArray = false
+	elemSrcType := srcTypeName
+	if m := listSetRegex.FindStringSubmatch(strings.ToUpper(strings.ReplaceAll(srcTypeName, " ", ""))); len(m) > 0 {
+		elemSrcType = strings.ToLower(m[2])
+	}
+	var elems reflect.Value
+	for i := 0; i < rv.Len(); i++ {
+		e, err := convScalar(conv, elemType, elemSrcType, rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			elems = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(e)), 0, rv.Len())
+		}
+		elems = reflect.Append(elems, reflect.ValueOf(e))
+	}
+	return elems.Interface(), nil
+}
+
+// formatDuration formats a Cassandra duration as a CQL duration literal
+// e.g. 1y2mo3d4h5m6s7ms8us9ns.
+func formatDuration(d gocql.Duration) string {
+	var sb strings.Builder
+	months, days, nanos := int64(d.Months), int64(d.Days), d.Nanoseconds
+	// All components of a Cassandra duration have the same sign.
+	if months < 0 || days < 0 || nanos < 0 {
+		sb.WriteString("-")
+		months, days, nanos = -months, -days, -nanos
+	}
+	units := []struct {
+		n    int64
+		unit string
+	}{
+		{months / 12, "y"}, {months % 12, "mo"}, {days, "d"},
+		{nanos / int64(time.Hour), "h"},
+		{nanos % int64(time.Hour) / int64(time.Minute), "m"},
+		{nanos % int64(time.Minute) / int64(time.Second), "s"},
+		{nanos % int64(time.Second) / int64(time.Millisecond), "ms"},
+		{nanos % int64(time.Millisecond) / int64(time.Microsecond), "us"},
+		{nanos % int64(time.Microsecond), "ns"},
+	}
+	empty := true
+	for _, u := range units {
+		if u.n != 0 {
+			sb.WriteString(fmt.Sprintf("%d%s", u.n, u.unit))
+			empty = false
+		}
+	}
+	if empty {
+		return "0s"
+	}
+	return sb.String()
+}
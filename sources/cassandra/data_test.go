@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/inf.v0"
+)
+
+func TestConvValue(t *testing.T) {
+	uuid, _ := gocql.ParseUUID("123e4567-e89b-12d3-a456-426614174000")
+	ts := time.Date(2024, 3, 1, 12, 30, 45, 500000000, time.UTC)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	strType := ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+	tc := []struct {
+		name    string
+		srcType string
+		spType  ddl.Type
+		in      interface{}
+		e       interface{}
+	}{
+		{name: "tinyint", srcType: "tinyint", spType: ddl.Type{Name: ddl.Int64}, in: int8(-5), e: int64(-5)},
+		{name: "int", srcType: "int", spType: ddl.Type{Name: ddl.Int64}, in: 42, e: int64(42)},
+		{name: "counter", srcType: "counter", spType: ddl.Type{Name: ddl.Int64}, in: int64(7), e: int64(7)},
+		{name: "int as text", srcType: "int", spType: strType, in: 42, e: "42"},
+		{name: "boolean as int", srcType: "boolean", spType: ddl.Type{Name: ddl.Int64}, in: true, e: int64(1)},
+		{name: "float", srcType: "float", spType: ddl.Type{Name: ddl.Float32}, in: float32(1.5), e: float32(1.5)},
+		{name: "float as double", srcType: "float", spType: ddl.Type{Name: ddl.Float64}, in: float32(0.1), e: float64(0.1)},
+		{name: "double", srcType: "double", spType: ddl.Type{Name: ddl.Float64}, in: 2.25, e: 2.25},
+		{name: "decimal", srcType: "decimal", spType: ddl.Type{Name: ddl.Numeric}, in: inf.NewDec(12345, 2), e: big.NewRat(12345, 100)},
+		{name: "varint", srcType: "varint", spType: ddl.Type{Name: ddl.Numeric}, in: big.NewInt(-99), e: big.NewRat(-99, 1)},
+		{name: "varint as text", srcType: "varint", spType: strType, in: big.NewInt(123456789), e: "123456789"},
+		{name: "varint as blob", srcType: "varint", spType: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, in: big.NewInt(-1), e: []byte{0xff}},
+		{name: "text", srcType: "text", spType: strType, in: "hello", e: "hello"},
+		{name: "text as blob", srcType: "text", spType: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, in: "hi", e: []byte("hi")},
+		{name: "uuid", srcType: "uuid", spType: strType, in: uuid, e: "123e4567-e89b-12d3-a456-426614174000"},
+		{name: "timeuuid as bytes", srcType: "timeuuid", spType: ddl.Type{Name: ddl.Bytes, Len: 16}, in: uuid, e: uuid.Bytes()},
+		{name: "inet", srcType: "inet", spType: strType, in: net.ParseIP("10.0.0.1").String(), e: "10.0.0.1"},
+		{name: "blob", srcType: "blob", spType: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, in: []byte{1, 2}, e: []byte{1, 2}},
+		{name: "date", srcType: "date", spType: ddl.Type{Name: ddl.Date}, in: date, e: civil.Date{Year: 2024, Month: 3, Day: 1}},
+		{name: "date as text", srcType: "date", spType: strType, in: date, e: "2024-03-01"},
+		{name: "timestamp", srcType: "timestamp", spType: ddl.Type{Name: ddl.Timestamp}, in: ts, e: ts},
+		{name: "timestamp as text", srcType: "timestamp", spType: strType, in: ts, e: "2024-03-01T12:30:45.5Z"},
+		{name: "time", srcType: "time", spType: ddl.Type{Name: ddl.Int64}, in: 90 * time.Second, e: int64(90 * time.Second)},
+		{name: "time as text", srcType: "time", spType: strType, in: 90*time.Second + time.Millisecond, e: "00:01:30.001"},
+		{name: "duration", srcType: "duration", spType: strType, in: gocql.Duration{Months: 14, Days: 3, Nanoseconds: int64(time.Hour + 5*time.Millisecond)}, e: "1y2mo3d1h5ms"},
+		{name: "boolean", srcType: "boolean", spType: ddl.Type{Name: ddl.Bool}, in: true, e: true},
+		{name: "list", srcType: "list<int>", spType: ddl.Type{Name: ddl.Int64, IsArray: true}, in: []int{1, 2}, e: []int64{1, 2}},
+		{name: "set of text", srcType: "set<text>", spType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, in: []string{"a"}, e: []string{"a"}},
+		{name: "list of timestamp", srcType: "list<timestamp>", spType: ddl.Type{Name: ddl.Timestamp, IsArray: true}, in: []time.Time{ts}, e: []time.Time{ts}},
+		{name: "list of decimal", srcType: "list<decimal>", spType: ddl.Type{Name: ddl.Numeric, IsArray: true}, in: []*inf.Dec{inf.NewDec(5, 1)}, e: []*big.Rat{big.NewRat(1, 2)}},
+		{name: "map", srcType: "map<text,int>", spType: ddl.Type{Name: ddl.JSON}, in: map[string]int{"b": 2, "a": 1}, e: `{"a":1,"b":2}`},
+		{name: "map with uuid keys", srcType: "map<uuid,decimal>", spType: ddl.Type{Name: ddl.JSON}, in: map[gocql.UUID]*inf.Dec{uuid: inf.NewDec(15, 1)}, e: `{"123e4567-e89b-12d3-a456-426614174000":1.5}`},
+		{name: "udt", srcType: "udt", spType: strType, in: map[string]interface{}{"street": "Main", "zip": 123}, e: `{"street":"Main","zip":123}`},
+		{name: "tuple", srcType: "tuple", spType: strType, in: []interface{}{1, "x", nil}, e: `[1,"x",null]`},
+	}
+	conv := internal.MakeConv()
+	for _, tt := range tc {
+		v, err := convValue(conv, tt.spType, tt.srcType, tt.in)
+		assert.Nil(t, err, tt.name)
+		assert.Equal(t, tt.e, v, tt.name)
+	}
+}
+
+func TestConvValue_UUID(t *testing.T) {
+	conv := internal.MakeConv()
+	u := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	in, _ := gocql.ParseUUID("123e4567-e89b-12d3-a456-426614174000")
+	v, err := convValue(conv, ddl.Type{Name: ddl.UUID}, "uuid", in)
+	assert.Nil(t, err)
+	assert.Equal(t, u, v)
+	v, err = convValue(conv, ddl.Type{Name: ddl.UUID, IsArray: true}, "set<timeuuid>", []gocql.UUID{in})
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{u}, v)
+	_, err = convValue(conv, ddl.Type{Name: ddl.UUID}, "uuid", 1)
+	assert.NotNil(t, err)
+}
+
+func TestConvValue_Errors(t *testing.T) {
+	conv := internal.MakeConv()
+	_, err := convValue(conv, ddl.Type{Name: ddl.Int64}, "varint", new(big.Int).Lsh(big.NewInt(1), 70))
+	assert.NotNil(t, err)
+	_, err = convValue(conv, ddl.Type{Name: ddl.Bool}, "text", "true")
+	assert.NotNil(t, err)
+	_, err = convValue(conv, ddl.Type{Name: ddl.Int64, IsArray: true}, "list<int>", 1)
+	assert.NotNil(t, err)
+}
+
+func TestConvValue_PGNumeric(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	v, err := convValue(conv, ddl.Type{Name: ddl.Numeric}, "decimal", inf.NewDec(-12345, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, spanner.PGNumeric{Numeric: "-12.345", Valid: true}, v)
+}
+
+func TestConvertRow(t *testing.T) {
+	srcSchema := schema.Table{
+		Name: "t",
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Type: schema.Type{Name: "int"}},
+			"c2": {Name: "pt", Type: schema.Type{Name: "tuple"}},
+			"c3": {Name: "note", Type: schema.Type{Name: "text"}},
+		},
+	}
+	spSchema := ddl.CreateTable{
+		Name: "t",
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "id", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "pt", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c3": {Name: "note", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c4": {Name: "shard", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		},
+		ShardIdColumn: "c4",
+	}
+	// gocql returns each element of a tuple as a separate column.
+	row := map[string]interface{}{"id": 1, "pt[0]": 1.5, "pt[1]": 2.5, "note": nil}
+	cols, vals, err := convertRow(internal.MakeConv(), "t", []string{"c1", "c2", "c3"}, srcSchema, spSchema, row, internal.AdditionalDataAttributes{ShardId: "s1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id", "pt", "shard"}, cols)
+	assert.Equal(t, []interface{}{int64(1), "[1.5,2.5]", "s1"}, vals)
+}
@@ -16,9 +16,13 @@ package cassandra
 
 import (
 	"fmt"
+	"sync"
 
+	ca "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/cassandra"
 	cc "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/cassandra"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/task"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
@@ -29,6 +33,7 @@ import (
 // InfoSchemaImpl is Cassandra specific implementation for InfoSchema
 type InfoSchemaImpl struct {
 	KeyspaceMetadata cc.KeyspaceMetadataInterface
+	DataAccessor     DataAccessor
 	SourceProfile    profiles.SourceProfile
 	TargetProfile    profiles.TargetProfile
 }
@@ -231,19 +236,100 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 	return indexes, nil
 }
 
-// Data Migration Related Methods (Stubs for Schema Migration)
+// DataAccessor reads the rows of Cassandra tables. It is implemented by
+// accessors/cassandra.CassandraAccessor.
+type DataAccessor interface {
+	CountRows(table string) (int64, error)
+	ScanTokenRange(table string, cols []string, partitionKeys []string, tr ca.TokenRange, f func(row map[string]interface{})) error
+}
 
-var errNotSupported = fmt.Errorf("operation not supported")
+// getPartitionKeys returns the names of the partition key columns of a table.
+func (isi InfoSchemaImpl) getPartitionKeys(tableName string) ([]string, error) {
+	tableMetadata, ok := isi.getTableMetadata(tableName)
+	if !ok {
+		return nil, fmt.Errorf("table '%s' not found in keyspace metadata", tableName)
+	}
+	var partitionKeys []string
+	for _, colMeta := range tableMetadata.PartitionKey {
+		partitionKeys = append(partitionKeys, colMeta.Name)
+	}
+	return partitionKeys, nil
+}
 
+// GetRowsFromTable returns all rows of a table, keyed by column name.
+// ProcessData doesn't use it, since it reads tables in token ranges
+// without buffering them.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
-	return nil, errNotSupported
+	if isi.DataAccessor == nil {
+		return nil, fmt.Errorf("cassandra data accessor not initialized")
+	}
+	srcSchema := conv.SrcSchema[tableId]
+	partitionKeys, err := isi.getPartitionKeys(srcSchema.Name)
+	if err != nil {
+		return nil, err
+	}
+	var srcCols []string
+	for _, colId := range srcSchema.ColIds {
+		srcCols = append(srcCols, srcSchema.ColDefs[colId].Name)
+	}
+	var rows []map[string]interface{}
+	err = isi.DataAccessor.ScanTokenRange(srcSchema.Name, srcCols, partitionKeys, ca.TokenRange{}, func(row map[string]interface{}) {
+		rows = append(rows, row)
+	})
+	return rows, err
 }
 
+// GetRowCount returns an estimate of the number of rows in a table, see
+// CassandraAccessor.CountRows.
 func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
-	return 0, errNotSupported
+	if isi.DataAccessor == nil {
+		return 0, fmt.Errorf("cassandra data accessor not initialized")
+	}
+	return isi.DataAccessor.CountRows(table.Name)
 }
 
+// ProcessData performs data conversion for a Cassandra table. The token
+// ring is split into ranges that are scanned in parallel, conv.DataReadOptions.ChunksPerTable
+// ranges if set and otherwise one range per worker.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, spCols []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
-	return errNotSupported
+	if isi.DataAccessor == nil {
+		return fmt.Errorf("cassandra data accessor not initialized")
+	}
+	partitionKeys, err := isi.getPartitionKeys(srcSchema.Name)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get partition keys for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	var srcCols []string
+	for _, colId := range spCols {
+		srcCols = append(srcCols, srcSchema.ColDefs[colId].Name)
+	}
+	numWorkers := conv.DataReadOptions.Workers
+	if numWorkers < 1 {
+		numWorkers = common.DefaultWorkers
+	}
+	numRanges := conv.DataReadOptions.ChunksPerTable
+	if numRanges <= 1 {
+		numRanges = numWorkers
+	}
+	ranges := ca.SplitTokenRanges(numRanges)
+	logger.Log.Info(fmt.Sprintf("reading table %s in %d token ranges using %d workers", srcSchema.Name, len(ranges), numWorkers))
+	asyncScan := func(tr ca.TokenRange, mutex *sync.Mutex) task.TaskResult[ca.TokenRange] {
+		err := isi.DataAccessor.ScanTokenRange(srcSchema.Name, srcCols, partitionKeys, tr, func(row map[string]interface{}) {
+			processRow(conv, tableId, spCols, srcSchema, spSchema, row, mutex, additionalAttributes)
+		})
+		return task.TaskResult[ca.TokenRange]{Result: tr, Err: err}
+	}
+	r := task.RunParallelTasksImpl[ca.TokenRange, ca.TokenRange]{}
+	results, err := r.RunParallelTasks(ranges, numWorkers, asyncScan, false)
+	if err != nil {
+		return err
+	}
+	for _, res := range results {
+		if res.Err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read table %s : err = %s", srcSchema.Name, res.Err))
+			return res.Err
+		}
+	}
+	return nil
 }
-
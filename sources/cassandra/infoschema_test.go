@@ -15,8 +15,10 @@ package cassandra
 
 import (
 	"sort"
+	"sync"
 	"testing"
 
+	ca "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/cassandra"
 	cc "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/cassandra"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
@@ -393,18 +395,107 @@ func TestGetIndexes(t *testing.T) {
 	mockKeyspace.AssertExpectations(t)
 }
 
-func TestDataMigrationStubs(t *testing.T) {
+// fakeDataAccessor returns rows for the first token range only, and
+// records the token ranges that were scanned.
+type fakeDataAccessor struct {
+	rows   []map[string]interface{}
+	count  int64
+	mutex  sync.Mutex
+	ranges []ca.TokenRange
+}
+
+func (f *fakeDataAccessor) CountRows(table string) (int64, error) {
+	return f.count, nil
+}
+
+func (f *fakeDataAccessor) ScanTokenRange(table string, cols []string, partitionKeys []string, tr ca.TokenRange, fn func(row map[string]interface{})) error {
+	f.mutex.Lock()
+	f.ranges = append(f.ranges, tr)
+	f.mutex.Unlock()
+	if tr.Start == nil {
+		for _, row := range f.rows {
+			fn(row)
+		}
+	}
+	return nil
+}
+
+func TestDataMigrationWithoutAccessor(t *testing.T) {
 	isi := InfoSchemaImpl{}
 	conv := internal.MakeConv()
 
 	_, err := isi.GetRowsFromTable(conv, "table1")
-	assert.ErrorIs(t, err, errNotSupported)
+	assert.EqualError(t, err, "cassandra data accessor not initialized")
 
 	_, err = isi.GetRowCount(common.SchemaAndName{Name: "table1"})
-	assert.ErrorIs(t, err, errNotSupported)
+	assert.EqualError(t, err, "cassandra data accessor not initialized")
 
 	err = isi.ProcessData(conv, "table1", schema.Table{}, nil, ddl.CreateTable{}, internal.AdditionalDataAttributes{})
-	assert.ErrorIs(t, err, errNotSupported)
+	assert.EqualError(t, err, "cassandra data accessor not initialized")
+}
+
+func TestGetRowCount(t *testing.T) {
+	isi := InfoSchemaImpl{DataAccessor: &fakeDataAccessor{count: 42}}
+	count, err := isi.GetRowCount(common.SchemaAndName{Name: "table1"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), count)
+}
 
+func TestProcessData(t *testing.T) {
+	mockKeyspace := &cc.MockKeyspaceMetadata{}
+	mockKeyspace.On("Tables").Return(map[string]*gocql.TableMetadata{
+		"users": {Name: "users", PartitionKey: []*gocql.ColumnMetadata{{Name: "id"}}},
+	})
+	accessor := &fakeDataAccessor{rows: []map[string]interface{}{
+		{"id": int64(1), "name": "alice", "tags": []string{"a", "b"}},
+		{"id": int64(2), "name": nil, "tags": nil},
+		{"id": "three", "name": "carol", "tags": nil}, // Bad row.
+	}}
+	srcSchema := schema.Table{
+		Name:   "users",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+			"c2": {Name: "name", Id: "c2", Type: schema.Type{Name: "text"}},
+			"c3": {Name: "tags", Id: "c3", Type: schema.Type{Name: "set<text>"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1"}},
+	}
+	spSchema := ddl.CreateTable{
+		Name:   "users",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c3": {Name: "tags", Id: "c3", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+		},
+		PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+	}
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = srcSchema
+	conv.SpSchema["t1"] = spSchema
+	conv.SetDataMode()
+	conv.DataReadOptions = internal.DataReadOptions{ChunksPerTable: 4, Workers: 2}
+	type written struct {
+		cols []string
+		vals []interface{}
+	}
+	var rows []written
+	conv.SetDataSink(func(table string, cols []string, vals []interface{}) {
+		rows = append(rows, written{cols, vals})
+	})
+	isi := InfoSchemaImpl{KeyspaceMetadata: mockKeyspace, DataAccessor: accessor}
+
+	err := isi.ProcessData(conv, "t1", srcSchema, []string{"c1", "c2", "c3"}, spSchema, internal.AdditionalDataAttributes{})
 
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(accessor.ranges))
+	assert.Equal(t, []written{
+		{[]string{"id", "name", "tags"}, []interface{}{int64(1), "alice", []string{"a", "b"}}},
+		{[]string{"id"}, []interface{}{int64(2)}},
+	}, rows)
+	assert.Equal(t, int64(1), conv.BadRows())
 }
+
@@ -196,6 +196,11 @@ var typeMappings = map[string][]CassandraDdlInfo{
 		},
 	},
 	"UUID": {
+		{
+			SpannerType:         ddl.Type{Name: ddl.UUID},
+			CassandraTypeOption: "uuid",
+			Issues:              nil,
+		},
 		{
 			SpannerType:         ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
 			CassandraTypeOption: "uuid",
@@ -208,6 +213,11 @@ var typeMappings = map[string][]CassandraDdlInfo{
 		},
 	},
 	"TIMEUUID": {
+		{
+			SpannerType:         ddl.Type{Name: ddl.UUID},
+			CassandraTypeOption: "timeuuid",
+			Issues:              nil,
+		},
 		{
 			SpannerType:         ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
 			CassandraTypeOption: "timeuuid",
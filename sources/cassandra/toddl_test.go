@@ -257,6 +257,13 @@ func TestCassandraTypeMapper(t *testing.T) {
 		{
 			name:                "Default uuid",
 			cassandraType:       "uuid",
+			expectedSpannerType: ddl.Type{Name: ddl.UUID},
+			expectedOption:      "uuid",
+		},
+		{
+			name:                "Override uuid to STRING",
+			cassandraType:       "uuid",
+			userSpannerType:     ddl.String,
 			expectedSpannerType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
 			expectedOption:      "uuid",
 		},
@@ -271,6 +278,13 @@ func TestCassandraTypeMapper(t *testing.T) {
 		{
 			name:                "Default timeuuid",
 			cassandraType:       "timeuuid",
+			expectedSpannerType: ddl.Type{Name: ddl.UUID},
+			expectedOption:      "timeuuid",
+		},
+		{
+			name:                "Override timeuuid to STRING",
+			cassandraType:       "timeuuid",
+			userSpannerType:     ddl.String,
 			expectedSpannerType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
 			expectedOption:      "timeuuid",
 		},
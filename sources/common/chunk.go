@@ -0,0 +1,248 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/task"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ChunkedInfoSchema supports reading a table in primary key ranges, so
+// that the ranges of a large table can be read concurrently.
+type ChunkedInfoSchema interface {
+	InfoSchema
+	// GetKeyBounds returns the smallest and largest value of the integer column
+	// colId in the source table. found is false if the table has no rows.
+	GetKeyBounds(conv *internal.Conv, tableId string, colId string) (min int64, max int64, found bool, err error)
+	// ProcessDataChunk performs data conversion for the rows of the table that fall
+	// in keyRange. Calls that modify conv must hold mutex, since chunks of the same
+	// table are processed concurrently. It returns an error unless all the rows of
+	// the range were read, even if some of them couldn't be converted.
+	ProcessDataChunk(conv *internal.Conv, tableId string, srcSchema schema.Table, spCols []string, spSchema ddl.CreateTable, keyRange KeyRange, mutex *sync.Mutex, additionalAttributes internal.AdditionalDataAttributes) error
+}
+
+// KeyRange is a half-open range [Start, End) of values of the leading
+// primary key column of a source table. A nil Start or End leaves that
+// side of the range unbounded.
+type KeyRange struct {
+	Index int    // Position of the range within the table, starting at 0.
+	ColId string // Id of the source column the range applies to.
+	Start *int64
+	End   *int64
+}
+
+// integerKeyTypes lists the source types (lower-cased) that can be used to
+// split a table into key ranges. Key bounds are read as int64, so unsigned
+// types such as MySQL's bigint unsigned aren't listed: their values can be
+// larger than math.MaxInt64, and those tables are read in a single range.
+var integerKeyTypes = map[string]bool{
+	"tinyint":   true,
+	"smallint":  true,
+	"mediumint": true,
+	"int":       true,
+	"integer":   true,
+	"bigint":    true,
+	"int2":      true,
+	"int4":      true,
+	"int8":      true,
+	"serial":    true,
+	"bigserial": true,
+}
+
+// GetChunkColumn returns the id of the column used to split a source table
+// into key ranges. Only tables whose leading primary key column has an
+// integer type can be split.
+func GetChunkColumn(srcTable schema.Table) (string, bool) {
+	if len(srcTable.PrimaryKeys) == 0 {
+		return "", false
+	}
+	colId := srcTable.PrimaryKeys[0].ColId
+	col, ok := srcTable.ColDefs[colId]
+	if !ok || len(col.Type.ArrayBounds) > 0 {
+		return "", false
+	}
+	tyName := strings.ToLower(col.Type.Name)
+	if integerKeyTypes[tyName] {
+		return colId, true
+	}
+	// Oracle integer columns are NUMBER(p) or NUMBER(p, 0). Values of more
+	// than 18 digits may not fit in an int64.
+	if tyName == "number" && (len(col.Type.Mods) == 1 || (len(col.Type.Mods) == 2 && col.Type.Mods[1] == 0)) && col.Type.Mods[0] <= 18 {
+		return colId, true
+	}
+	return "", false
+}
+
+// SplitKeyRange splits the closed interval [min, max] into at most n
+// contiguous key ranges. The first and last ranges are left unbounded so
+// that rows outside [min, max] are never skipped.
+func SplitKeyRange(colId string, min, max int64, n int) []KeyRange {
+	if n < 1 {
+		n = 1
+	}
+	// Compute in uint64 to avoid overflow when the interval spans most of int64.
+	span := uint64(max) - uint64(min)
+	step := span / uint64(n)
+	if span%uint64(n) != 0 {
+		step++
+	}
+	if step == 0 {
+		step = 1
+	}
+	var bounds []int64
+	for i := 1; i < n; i++ {
+		offset := uint64(i) * step
+		if offset > span || offset/uint64(i) != step {
+			break
+		}
+		bounds = append(bounds, int64(uint64(min)+offset))
+	}
+	ranges := make([]KeyRange, 0, len(bounds)+1)
+	var start *int64
+	for i := range bounds {
+		end := bounds[i]
+		ranges = append(ranges, KeyRange{Index: i, ColId: colId, Start: start, End: &end})
+		start = &bounds[i]
+	}
+	ranges = append(ranges, KeyRange{Index: len(bounds), ColId: colId, Start: start})
+	return ranges
+}
+
+// WhereClause returns a SQL condition (without the WHERE keyword) selecting
+// the rows in kr, and the corresponding query arguments. quotedCol is the
+// quoted column name and placeholder returns the parameter marker for the
+// i'th argument (starting at 1), since markers differ across databases.
+func (kr KeyRange) WhereClause(quotedCol string, placeholder func(i int) string) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if kr.Start != nil {
+		args = append(args, *kr.Start)
+		conds = append(conds, fmt.Sprintf("%s >= %s", quotedCol, placeholder(len(args))))
+	}
+	if kr.End != nil {
+		args = append(args, *kr.End)
+		conds = append(conds, fmt.Sprintf("%s < %s", quotedCol, placeholder(len(args))))
+	}
+	if len(conds) == 0 {
+		return "1 = 1", nil
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// GetKeyRanges returns the key ranges a source table is read in. A table
+// that can't be split is returned as a single unbounded range. If start is
+// set, rows with a key below start are skipped, which is used to resume a
+// table from its last committed key.
+func GetKeyRanges(conv *internal.Conv, cis ChunkedInfoSchema, tableId string, n int, start *int64) ([]KeyRange, error) {
+	colId, ok := GetChunkColumn(conv.SrcSchema[tableId])
+	if !ok {
+		return []KeyRange{{ColId: colId}}, nil
+	}
+	if n < 2 {
+		return []KeyRange{{ColId: colId, Start: start}}, nil
+	}
+	min, max, found, err := cis.GetKeyBounds(conv, tableId, colId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return []KeyRange{{ColId: colId, Start: start}}, nil
+	}
+	if start != nil {
+		if *start > max {
+			return []KeyRange{{ColId: colId, Start: start}}, nil
+		}
+		if *start > min {
+			min = *start
+		}
+	}
+	ranges := SplitKeyRange(colId, min, max, n)
+	ranges[0].Start = start
+	return ranges, nil
+}
+
+// processDataChunked reads a single table in key ranges on a pool of
+// workers. Progress is reported per table as the number of completed
+// ranges.
+//
+// When checkpointing is enabled, rows are flushed to Spanner as each range
+// completes and the end of the longest run of completed ranges from the start
+// of the table is recorded as its last committed key. Ranges that completed
+// beyond that run are read again if the migration is resumed, and so are
+// ranges that were partly written, so resumed runs must write with
+// insert_or_update or replace mutations.
+func (is *InfoSchemaImpl) processDataChunked(conv *internal.Conv, cis ChunkedInfoSchema, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	cpKey := internal.CheckpointKey(additionalAttributes.ShardId, spSchema.Name)
+	var start *int64
+	if k, ok := conv.Checkpoint.LastCommittedKey(cpKey); ok {
+		logger.Log.Info(fmt.Sprintf("resuming table %s from key %d", srcSchema.Name, k))
+		start = &k
+	}
+	ranges, err := GetKeyRanges(conv, cis, tableId, conv.DataReadOptions.ChunksPerTable, start)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't compute key ranges for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	numWorkers := conv.DataReadOptions.Workers
+	if numWorkers < 1 {
+		numWorkers = DefaultWorkers
+	}
+	logger.Log.Info(fmt.Sprintf("reading table %s in %d key ranges using %d workers", srcSchema.Name, len(ranges), numWorkers))
+	p := internal.NewProgress(int64(len(ranges)), fmt.Sprintf("Reading key ranges of table %s", srcSchema.Name), internal.Verbose(), true, int(internal.DataWriteInProgress))
+	defer p.Done()
+	completed := int64(0)
+	done := make([]bool, len(ranges))
+	committed := 0 // Number of leading ranges that have been committed.
+	asyncProcessChunk := func(kr KeyRange, mutex *sync.Mutex) task.TaskResult[KeyRange] {
+		err := cis.ProcessDataChunk(conv, tableId, srcSchema, colIds, spSchema, kr, mutex, additionalAttributes)
+		mutex.Lock()
+		defer mutex.Unlock()
+		completed++
+		p.MaybeReport(completed)
+		if err == nil && conv.Checkpoint != nil {
+			if conv.DataFlush != nil {
+				conv.DataFlush()
+			}
+			done[kr.Index] = true
+			for committed < len(ranges) && done[committed] && ranges[committed].End != nil {
+				committed++
+			}
+			if committed > 0 {
+				if cpErr := conv.Checkpoint.CommitKey(cpKey, *ranges[committed-1].End); cpErr != nil {
+					logger.Log.Warn(fmt.Sprintf("couldn't update checkpoint for table %s: %v", srcSchema.Name, cpErr))
+				}
+			}
+		}
+		return task.TaskResult[KeyRange]{Result: kr, Err: err}
+	}
+	r := task.RunParallelTasksImpl[KeyRange, KeyRange]{}
+	results, err := r.RunParallelTasks(ranges, numWorkers, asyncProcessChunk, false)
+	if err != nil {
+		return err
+	}
+	for _, res := range results {
+		if res.Err != nil {
+			return fmt.Errorf("couldn't read key range %d of table %s: %w", res.Result.Index, srcSchema.Name, res.Err)
+		}
+	}
+	return nil
+}
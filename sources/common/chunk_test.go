@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestSplitKeyRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		min      int64
+		max      int64
+		n        int
+		expected []KeyRange
+	}{
+		{
+			name:     "single chunk",
+			min:      1,
+			max:      100,
+			n:        1,
+			expected: []KeyRange{{Index: 0, ColId: "c1"}},
+		},
+		{
+			name: "even split",
+			min:  0,
+			max:  99,
+			n:    4,
+			expected: []KeyRange{
+				{Index: 0, ColId: "c1", End: int64Ptr(25)},
+				{Index: 1, ColId: "c1", Start: int64Ptr(25), End: int64Ptr(50)},
+				{Index: 2, ColId: "c1", Start: int64Ptr(50), End: int64Ptr(75)},
+				{Index: 3, ColId: "c1", Start: int64Ptr(75)},
+			},
+		},
+		{
+			name:     "fewer keys than chunks",
+			min:      7,
+			max:      7,
+			n:        4,
+			expected: []KeyRange{{Index: 0, ColId: "c1"}},
+		},
+		{
+			name: "full int64 span",
+			min:  math.MinInt64,
+			max:  math.MaxInt64,
+			n:    2,
+			expected: []KeyRange{
+				{Index: 0, ColId: "c1", End: int64Ptr(0)},
+				{Index: 1, ColId: "c1", Start: int64Ptr(0)},
+			},
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, SplitKeyRange("c1", tc.min, tc.max, tc.n), tc.name)
+	}
+}
+
+func TestKeyRangeWhereClause(t *testing.T) {
+	placeholder := func(i int) string { return fmt.Sprintf("$%d", i) }
+	where, args := KeyRange{Start: int64Ptr(10), End: int64Ptr(20)}.WhereClause(`"id"`, placeholder)
+	assert.Equal(t, `"id" >= $1 AND "id" < $2`, where)
+	assert.Equal(t, []interface{}{int64(10), int64(20)}, args)
+
+	where, args = KeyRange{End: int64Ptr(20)}.WhereClause(`"id"`, placeholder)
+	assert.Equal(t, `"id" < $1`, where)
+	assert.Equal(t, []interface{}{int64(20)}, args)
+
+	where, args = KeyRange{}.WhereClause(`"id"`, placeholder)
+	assert.Equal(t, "1 = 1", where)
+	assert.Nil(t, args)
+}
+
+func TestGetChunkColumn(t *testing.T) {
+	tests := []struct {
+		name     string
+		colType  schema.Type
+		noPk     bool
+		expected bool
+	}{
+		{name: "mysql bigint", colType: schema.Type{Name: "bigint"}, expected: true},
+		{name: "postgres int8", colType: schema.Type{Name: "int8"}, expected: true},
+		{name: "oracle integer number", colType: schema.Type{Name: "NUMBER", Mods: []int64{10, 0}}, expected: true},
+		{name: "oracle decimal number", colType: schema.Type{Name: "NUMBER", Mods: []int64{10, 2}}, expected: false},
+		{name: "oracle number wider than int64", colType: schema.Type{Name: "NUMBER", Mods: []int64{20}}, expected: false},
+		{name: "mysql bigint unsigned", colType: schema.Type{Name: "bigint unsigned", Mods: []int64{20}}, expected: false},
+		{name: "string key", colType: schema.Type{Name: "varchar"}, expected: false},
+		{name: "integer array", colType: schema.Type{Name: "int8", ArrayBounds: []int64{-1}}, expected: false},
+		{name: "no primary key", colType: schema.Type{Name: "bigint"}, noPk: true, expected: false},
+	}
+	for _, tc := range tests {
+		tbl := schema.Table{
+			ColDefs: map[string]schema.Column{"c1": {Name: "id", Id: "c1", Type: tc.colType}},
+		}
+		if !tc.noPk {
+			tbl.PrimaryKeys = []schema.Key{{ColId: "c1"}}
+		}
+		colId, ok := GetChunkColumn(tbl)
+		assert.Equal(t, tc.expected, ok, tc.name)
+		if ok {
+			assert.Equal(t, "c1", colId, tc.name)
+		}
+	}
+}
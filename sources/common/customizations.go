@@ -0,0 +1,636 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"gopkg.in/yaml.v3"
+)
+
+// Customizations are the edits of the Spanner schema read from a
+// customizations file. They are the headless equivalent of the rules and the
+// table edits of the web UI. Tables and columns are referred to by their
+// source names, which remain the same across runs of the schema conversion.
+type Customizations struct {
+	Rules  []CustomizationRule           `yaml:"rules"`
+	Tables map[string]TableCustomization `yaml:"tables"` // Keyed by source table name.
+}
+
+// CustomizationRule is a rule of the web UI. Which fields are used depends
+// on its Type, one of the rule types in common/constants.
+type CustomizationRule struct {
+	Name string `yaml:"name"` // Rules are recorded in conv.Rules once per name.
+	Type string `yaml:"type"`
+
+	// global_datatype_change: maps source types to Spanner types.
+	TypeMap map[string]string `yaml:"typeMap"`
+
+	// edit_column_max_length: sets the length of the SpannerType columns
+	// of Table, or of all the tables if Table is empty, that have the
+	// maximum length.
+	Table       string `yaml:"table"`
+	SpannerType string `yaml:"spannerType"`
+	MaxLength   string `yaml:"maxLength"`
+
+	// add_index: adds the index Index on Table.
+	Index   string                  `yaml:"index"`
+	Unique  bool                    `yaml:"unique"`
+	Keys    []IndexKeyCustomization `yaml:"keys"`
+	Storing []string                `yaml:"storing"`
+
+	// add_shard_id_primary_key: adds the shard id column to the primary
+	// keys, first or last, and to the foreign keys.
+	AddedAtTheStart bool `yaml:"addedAtTheStart"`
+}
+
+// IndexKeyCustomization is a key of an index added by a rule.
+type IndexKeyCustomization struct {
+	Column string `yaml:"column"` // Source column name.
+	Desc   bool   `yaml:"desc"`
+}
+
+// TableCustomization edits a Spanner table.
+type TableCustomization struct {
+	Drop    bool                           `yaml:"drop"`
+	Name    string                         `yaml:"name"`
+	Columns map[string]ColumnCustomization `yaml:"columns"` // Keyed by source column name.
+
+	// Parent is the source name of the table to interleave the table in.
+	// An empty parent removes the interleaving, and no parent leaves it as is.
+	Parent         *string `yaml:"parent"`
+	InterleaveType string  `yaml:"interleaveType"` // IN or IN PARENT (default).
+	OnDelete       string  `yaml:"onDelete"`       // CASCADE or NO ACTION (default), for IN PARENT.
+
+	// RowDeletionPolicy sets the row deletion policy (TTL) of the table. An
+	// empty column removes the policy, and no policy leaves it as is.
+	RowDeletionPolicy *RowDeletionPolicyCustomization `yaml:"rowDeletionPolicy"`
+
+	// Timezone sets the default timezone policy of the timestamp columns of
+	// the table. An empty mode removes the policy, and no policy leaves it as
+	// is.
+	Timezone *TimezoneCustomization `yaml:"timezone"`
+}
+
+// RowDeletionPolicyCustomization makes Spanner delete the rows of a table
+// whose Column is older than Days days.
+type RowDeletionPolicyCustomization struct {
+	Column string `yaml:"column"` // Source name of a TIMESTAMP column.
+	Days   int64  `yaml:"days"`
+}
+
+// ColumnCustomization edits a column of a Spanner table.
+type ColumnCustomization struct {
+	Drop      bool   `yaml:"drop"`
+	Name      string `yaml:"name"`
+	Type      string `yaml:"type"`      // Spanner type, as for global_datatype_change.
+	MaxLength string `yaml:"maxLength"` // A length or MAX, for STRING and BYTES columns.
+	NotNull   *bool  `yaml:"notNull"`
+
+	// Timezone sets the timezone policy of a timestamp column, overriding
+	// the default policy of the table. An empty mode removes the policy, and
+	// no policy leaves it as is.
+	Timezone *TimezoneCustomization `yaml:"timezone"`
+}
+
+// TimezoneCustomization controls how the values without a timezone of a
+// timestamp column are converted, see internal.TimezonePolicy.
+type TimezoneCustomization struct {
+	Mode     string `yaml:"mode"`     // ZONE, UTC, STRING or DATE.
+	Location string `yaml:"location"` // IANA zone name, for ZONE.
+}
+
+// ParseCustomizations parses a customizations file, in YAML or JSON.
+// Unknown fields are rejected, so that typos don't go unnoticed.
+func ParseCustomizations(data []byte) (Customizations, error) {
+	var c Customizations
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return Customizations{}, fmt.Errorf("can't parse customizations: %w", err)
+	}
+	return c, nil
+}
+
+// ApplyCustomizations applies the customizations to the Spanner schema of
+// conv. toddl maps source types to the Spanner types of type changes.
+// Customizations set the schema to a state rather than change it, so
+// applying them again, e.g. to a session file they were already applied to,
+// leaves the schema unchanged.
+//
+// Tables are dropped first. Rules are applied next, in order, and then the
+// edits of the tables and their columns, so that they take precedence over
+// the rules for the columns they name. Tables are interleaved last.
+func ApplyCustomizations(conv *internal.Conv, toddl ToDdl, c Customizations) error {
+	var tableNames []string
+	for name := range c.Tables {
+		tableNames = append(tableNames, name)
+	}
+	sort.Strings(tableNames)
+	tableIds := make(map[string]string)
+	for _, name := range tableNames {
+		tableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, name)
+		if err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+		tableIds[name] = tableId
+	}
+
+	for _, name := range tableNames {
+		if c.Tables[name].Drop {
+			conv.DropSpannerTable(tableIds[name])
+		}
+	}
+	for _, rule := range c.Rules {
+		if err := applyCustomizationRule(conv, toddl, rule); err != nil {
+			return fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+	}
+	for _, name := range tableNames {
+		if _, ok := conv.SpSchema[tableIds[name]]; !ok {
+			continue
+		}
+		if err := applyTableCustomization(conv, toddl, tableIds[name], c.Tables[name]); err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+	for _, name := range tableNames {
+		t := c.Tables[name]
+		if _, ok := conv.SpSchema[tableIds[name]]; !ok || t.Parent == nil {
+			continue
+		}
+		if err := setParentTable(conv, tableIds[name], *t.Parent, t.InterleaveType, t.OnDelete); err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func applyCustomizationRule(conv *internal.Conv, toddl ToDdl, rule CustomizationRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("rules must have a name")
+	}
+	r := internal.Rule{Name: rule.Name, Type: rule.Type, Enabled: true}
+	switch rule.Type {
+	case constants.GlobalDataTypeChange:
+		if len(rule.TypeMap) == 0 {
+			return fmt.Errorf("typeMap is empty")
+		}
+		for tableId, spTable := range conv.SpSchema {
+			for colId := range spTable.ColDefs {
+				srcCol, ok := conv.SrcSchema[tableId].ColDefs[colId]
+				if !ok {
+					continue
+				}
+				if spType, ok := rule.TypeMap[srcCol.Type.Name]; ok {
+					if err := updateColumnType(conv, toddl, tableId, colId, spType); err != nil {
+						return err
+					}
+				}
+			}
+			ComputeNonKeyColumnSize(conv, tableId)
+		}
+		r.ObjectType, r.AssociatedObjects, r.Data = "Column", "All Columns", rule.TypeMap
+	case constants.EditColumnMaxLength:
+		length, err := parseColumnLength(rule.SpannerType, rule.MaxLength)
+		if err != nil {
+			return err
+		}
+		r.ObjectType, r.AssociatedObjects = "Table", "All tables"
+		tableIds := []string{}
+		if rule.Table != "" {
+			tableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, rule.Table)
+			if err != nil {
+				return err
+			}
+			tableIds = append(tableIds, tableId)
+			r.AssociatedObjects = tableId
+		} else {
+			for tableId := range conv.SpSchema {
+				tableIds = append(tableIds, tableId)
+			}
+		}
+		for _, tableId := range tableIds {
+			for colId, colDef := range conv.SpSchema[tableId].ColDefs {
+				if colDef.T.Name == rule.SpannerType && colDef.T.Len == ddl.MaxLength {
+					colDef.T.Len = length
+					conv.SpSchema[tableId].ColDefs[colId] = colDef
+				}
+			}
+			ComputeNonKeyColumnSize(conv, tableId)
+		}
+		r.Data = map[string]string{"spDataType": rule.SpannerType, "spColMaxLength": rule.MaxLength}
+	case constants.AddIndex:
+		index, err := addIndexFromRule(conv, rule)
+		if err != nil {
+			return err
+		}
+		r.ObjectType, r.AssociatedObjects, r.Data = "Table", index.TableId, index
+	case constants.AddShardIdPrimaryKey:
+		for _, spTable := range conv.SpSchema {
+			if spTable.ParentTable.Id != "" {
+				return fmt.Errorf("table %s is interleaved, remove the interleaving to add the shard id to the primary keys", spTable.Name)
+			}
+		}
+		addShardIdToKeys(conv, rule.AddedAtTheStart)
+		r.AssociatedObjects = "All Tables"
+		r.Data = map[string]bool{"AddedAtTheStart": rule.AddedAtTheStart}
+	default:
+		return fmt.Errorf("unknown rule type '%s'", rule.Type)
+	}
+	for _, existing := range conv.Rules {
+		if existing.Name == r.Name && existing.Type == r.Type {
+			return nil
+		}
+	}
+	r.Id = internal.GenerateRuleId()
+	conv.Rules = append(conv.Rules, r)
+	return nil
+}
+
+// addIndexFromRule adds the index of an add_index rule, unless the table
+// already has an index with the same name.
+func addIndexFromRule(conv *internal.Conv, rule CustomizationRule) (ddl.CreateIndex, error) {
+	tableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, rule.Table)
+	if err != nil {
+		return ddl.CreateIndex{}, err
+	}
+	spTable, ok := conv.SpSchema[tableId]
+	if !ok {
+		return ddl.CreateIndex{}, fmt.Errorf("table %s is dropped", rule.Table)
+	}
+	for _, index := range spTable.Indexes {
+		if strings.EqualFold(index.Name, rule.Index) {
+			return index, nil
+		}
+	}
+	if err := checkSpannerName(conv, rule.Index); err != nil {
+		return ddl.CreateIndex{}, err
+	}
+	if len(rule.Keys) == 0 {
+		return ddl.CreateIndex{}, fmt.Errorf("index %s has no keys", rule.Index)
+	}
+	index := ddl.CreateIndex{Name: rule.Index, TableId: tableId, Unique: rule.Unique, Id: internal.GenerateIndexesId()}
+	for i, key := range rule.Keys {
+		colId, err := spannerColumnId(conv, tableId, key.Column)
+		if err != nil {
+			return ddl.CreateIndex{}, err
+		}
+		index.Keys = append(index.Keys, ddl.IndexKey{ColId: colId, Desc: key.Desc, Order: i + 1})
+	}
+	for _, col := range rule.Storing {
+		colId, err := spannerColumnId(conv, tableId, col)
+		if err != nil {
+			return ddl.CreateIndex{}, err
+		}
+		index.StoredColumnIds = append(index.StoredColumnIds, colId)
+	}
+	conv.UsedNames[strings.ToLower(index.Name)] = true
+	spTable.Indexes = append(spTable.Indexes, index)
+	conv.SpSchema[tableId] = spTable
+	return index, nil
+}
+
+// addShardIdToKeys adds the shard id column of the tables to their primary
+// keys and foreign keys, unless it's already part of the primary key.
+func addShardIdToKeys(conv *internal.Conv, addedAtTheStart bool) {
+	updated := make(map[string]bool)
+	for tableId, spTable := range conv.SpSchema {
+		if spTable.ShardIdColumn == "" || isSpannerPrimaryKey(spTable, spTable.ShardIdColumn) {
+			continue
+		}
+		shardKey := ddl.IndexKey{ColId: spTable.ShardIdColumn, Order: 1}
+		var pks []ddl.IndexKey
+		if addedAtTheStart {
+			pks = append(pks, shardKey)
+		}
+		for _, pk := range spTable.PrimaryKeys {
+			if addedAtTheStart {
+				pk.Order++
+			}
+			pks = append(pks, pk)
+		}
+		if !addedAtTheStart {
+			shardKey.Order = len(pks) + 1
+			pks = append(pks, shardKey)
+		}
+		spTable.PrimaryKeys = pks
+		conv.SpSchema[tableId] = spTable
+		updated[tableId] = true
+	}
+	for tableId, spTable := range conv.SpSchema {
+		if !updated[tableId] {
+			continue
+		}
+		for i, fk := range spTable.ForeignKeys {
+			referShardId := conv.SpSchema[fk.ReferTableId].ShardIdColumn
+			if referShardId == "" {
+				continue
+			}
+			if addedAtTheStart {
+				fk.ColIds = append([]string{spTable.ShardIdColumn}, fk.ColIds...)
+				fk.ReferColumnIds = append([]string{referShardId}, fk.ReferColumnIds...)
+			} else {
+				fk.ColIds = append(fk.ColIds, spTable.ShardIdColumn)
+				fk.ReferColumnIds = append(fk.ReferColumnIds, referShardId)
+			}
+			spTable.ForeignKeys[i] = fk
+		}
+	}
+}
+
+func applyTableCustomization(conv *internal.Conv, toddl ToDdl, tableId string, t TableCustomization) error {
+	if t.Name != "" && t.Name != conv.SpSchema[tableId].Name {
+		if err := checkSpannerName(conv, t.Name); err != nil {
+			return err
+		}
+		renameSpannerTable(conv, tableId, t.Name)
+	}
+	if t.Timezone != nil {
+		p := internal.TimezonePolicy{Mode: t.Timezone.Mode, Location: t.Timezone.Location}
+		if err := SetTimezonePolicy(conv, toddl, tableId, "", p); err != nil {
+			return fmt.Errorf("timezone: %w", err)
+		}
+	}
+	var colNames []string
+	for name := range t.Columns {
+		colNames = append(colNames, name)
+	}
+	sort.Strings(colNames)
+	for _, name := range colNames {
+		colId, err := internal.GetColIdFromSrcName(conv.SrcSchema[tableId].ColDefs, name)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", name, err)
+		}
+		if _, ok := conv.SpSchema[tableId].ColDefs[colId]; !ok {
+			// Dropped by an earlier run.
+			continue
+		}
+		if err := applyColumnCustomization(conv, toddl, tableId, colId, t.Columns[name]); err != nil {
+			return fmt.Errorf("column %s: %w", name, err)
+		}
+	}
+	ComputeNonKeyColumnSize(conv, tableId)
+	if t.RowDeletionPolicy != nil {
+		if err := setRowDeletionPolicy(conv, tableId, *t.RowDeletionPolicy); err != nil {
+			return fmt.Errorf("row deletion policy: %w", err)
+		}
+	} else if p := conv.SpSchema[tableId].RowDeletionPolicy; p.ColId != "" {
+		// The column of the policy may have been retyped.
+		if err := conv.SpSchema[tableId].ValidateRowDeletionPolicy(p); err != nil {
+			return fmt.Errorf("row deletion policy: %w", err)
+		}
+	}
+	return nil
+}
+
+// setRowDeletionPolicy sets the row deletion policy of a table, after its
+// columns are edited so that the type of the new column is final.
+func setRowDeletionPolicy(conv *internal.Conv, tableId string, p RowDeletionPolicyCustomization) error {
+	spTable := conv.SpSchema[tableId]
+	if p.Column == "" {
+		spTable.RowDeletionPolicy = ddl.RowDeletionPolicy{}
+		conv.SpSchema[tableId] = spTable
+		return nil
+	}
+	colId, err := spannerColumnId(conv, tableId, p.Column)
+	if err != nil {
+		return err
+	}
+	policy := ddl.RowDeletionPolicy{ColId: colId, Days: p.Days}
+	if err := spTable.ValidateRowDeletionPolicy(policy); err != nil {
+		return err
+	}
+	spTable.RowDeletionPolicy = policy
+	conv.SpSchema[tableId] = spTable
+	return nil
+}
+
+func applyColumnCustomization(conv *internal.Conv, toddl ToDdl, tableId, colId string, c ColumnCustomization) error {
+	spTable := conv.SpSchema[tableId]
+	if c.Drop {
+		if isSpannerPrimaryKey(spTable, colId) {
+			return fmt.Errorf("primary key columns can't be dropped")
+		}
+		conv.DropSpannerColumn(tableId, colId)
+		return nil
+	}
+	if c.Type != "" {
+		if err := updateColumnType(conv, toddl, tableId, colId, c.Type); err != nil {
+			return err
+		}
+	}
+	if c.Timezone != nil {
+		p := internal.TimezonePolicy{Mode: c.Timezone.Mode, Location: c.Timezone.Location}
+		if err := SetTimezonePolicy(conv, toddl, tableId, colId, p); err != nil {
+			return fmt.Errorf("timezone: %w", err)
+		}
+	}
+	colDef := spTable.ColDefs[colId]
+	if c.MaxLength != "" {
+		length, err := parseColumnLength(colDef.T.Name, c.MaxLength)
+		if err != nil {
+			return err
+		}
+		colDef.T.Len = length
+	}
+	if c.NotNull != nil {
+		colDef.NotNull = *c.NotNull
+	}
+	if c.Name != "" && c.Name != colDef.Name {
+		if _, changed := internal.FixName(c.Name); changed {
+			return fmt.Errorf("'%s' is not a valid Spanner identifier", c.Name)
+		}
+		for id, other := range spTable.ColDefs {
+			if id != colId && strings.EqualFold(other.Name, c.Name) {
+				return fmt.Errorf("column name '%s' is already used", c.Name)
+			}
+		}
+		colDef.Name = c.Name
+		srcTable := conv.SrcSchema[tableId]
+		if m, ok := conv.ToSpanner[srcTable.Name]; ok && m.Cols != nil {
+			m.Cols[srcTable.ColDefs[colId].Name] = c.Name
+		}
+	}
+	spTable.ColDefs[colId] = colDef
+	return nil
+}
+
+// updateColumnType sets the type of a column to the Spanner type spType
+// converted from its source type, as the web UI does.
+func updateColumnType(conv *internal.Conv, toddl ToDdl, tableId, colId, spType string) error {
+	if toddl == nil {
+		return fmt.Errorf("type changes aren't supported for this source")
+	}
+	srcCol := conv.SrcSchema[tableId].ColDefs[colId]
+	ty, issues := toddl.ToSpannerType(conv, spType, srcCol.Type, IsPrimaryKey(colId, conv.SrcSchema[tableId]))
+	if len(srcCol.Type.ArrayBounds) > 0 && conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+	} else if len(srcCol.Type.ArrayBounds) > 1 {
+		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+		issues = append(issues, internal.MultiDimensionalArray)
+	}
+	if srcCol.Ignored.Default {
+		issues = append(issues, internal.DefaultValue)
+	}
+	if srcCol.Ignored.AutoIncrement {
+		issues = append(issues, internal.AutoIncrement)
+	}
+	if tableIssues, ok := conv.SchemaIssues[tableId]; ok && tableIssues.ColumnLevelIssues != nil {
+		tableIssues.ColumnLevelIssues[colId] = issues
+	}
+	if conv.Source != constants.CASSANDRA {
+		ty.IsArray = len(srcCol.Type.ArrayBounds) == 1
+	}
+	colDef := conv.SpSchema[tableId].ColDefs[colId]
+	colDef.T = ToProtoEnumType(ty, conv.SrcSchema[tableId], srcCol)
+	if optionProvider, ok := toddl.(OptionProvider); ok && conv.Source == constants.CASSANDRA {
+		if colDef.Opts == nil {
+			colDef.Opts = make(map[string]string)
+		}
+		colDef.Opts["cassandra_type"] = optionProvider.GetTypeOption(srcCol.Type.Name, ty)
+	}
+	conv.SpSchema[tableId].ColDefs[colId] = colDef
+	return nil
+}
+
+// parseColumnLength parses a length of a column of Spanner type spType.
+func parseColumnLength(spType, length string) (int64, error) {
+	if spType != ddl.String && spType != ddl.Bytes {
+		return 0, fmt.Errorf("maxLength can only be set for %s and %s columns, not %s", ddl.String, ddl.Bytes, spType)
+	}
+	if strings.EqualFold(length, "MAX") {
+		return ddl.MaxLength, nil
+	}
+	l, err := strconv.ParseInt(length, 10, 64)
+	if err != nil || l <= 0 {
+		return 0, fmt.Errorf("invalid maxLength '%s', must be a positive number or MAX", length)
+	}
+	return l, nil
+}
+
+// setParentTable interleaves a table in the table with source name
+// parentName, or removes its interleaving if parentName is empty. The
+// primary key of the parent must be a prefix of the primary key of the table.
+func setParentTable(conv *internal.Conv, tableId, parentName, interleaveType, onDelete string) error {
+	spTable := conv.SpSchema[tableId]
+	if parentName == "" {
+		spTable.ParentTable = ddl.InterleavedParent{}
+		conv.SpSchema[tableId] = spTable
+		return nil
+	}
+	if interleaveType == "" {
+		interleaveType = "IN PARENT"
+	}
+	switch {
+	case interleaveType == "IN PARENT" && onDelete == "":
+		onDelete = constants.FK_NO_ACTION
+	case interleaveType == "IN PARENT" && onDelete != constants.FK_NO_ACTION && onDelete != constants.FK_CASCADE:
+		return fmt.Errorf("onDelete must be %s or %s", constants.FK_CASCADE, constants.FK_NO_ACTION)
+	case interleaveType == "IN" && onDelete != "":
+		return fmt.Errorf("onDelete can't be set for interleaveType IN")
+	case interleaveType != "IN PARENT" && interleaveType != "IN":
+		return fmt.Errorf("interleaveType must be IN or IN PARENT")
+	}
+	parentId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, parentName)
+	if err != nil {
+		return err
+	}
+	if _, ok := conv.SpSchema[parentId]; !ok {
+		return fmt.Errorf("parent table %s is dropped", parentName)
+	}
+	if _, found := conv.SyntheticPKeys[tableId]; found {
+		return fmt.Errorf("tables with a synthetic primary key can't be interleaved")
+	}
+	if err := conv.CheckInterleaving(tableId, parentId); err != nil {
+		return err
+	}
+	spTable.ParentTable = ddl.InterleavedParent{Id: parentId, OnDelete: onDelete, InterleaveType: interleaveType}
+	conv.SpSchema[tableId] = spTable
+	return nil
+}
+
+// renameSpannerTable renames a Spanner table and updates the name mappings.
+func renameSpannerTable(conv *internal.Conv, tableId, name string) {
+	spTable := conv.SpSchema[tableId]
+	delete(conv.UsedNames, strings.ToLower(spTable.Name))
+	conv.UsedNames[strings.ToLower(name)] = true
+	if m, ok := conv.ToSource[spTable.Name]; ok {
+		delete(conv.ToSource, spTable.Name)
+		conv.ToSource[name] = m
+	}
+	srcName := conv.SrcSchema[tableId].Name
+	if m, ok := conv.ToSpanner[srcName]; ok {
+		m.Name = name
+		conv.ToSpanner[srcName] = m
+	}
+	spTable.Name = name
+	conv.SpSchema[tableId] = spTable
+}
+
+// checkSpannerName returns an error if name isn't a valid Spanner identifier
+// or is already the name of a table, index or foreign key.
+func checkSpannerName(conv *internal.Conv, name string) error {
+	if _, changed := internal.FixName(name); changed {
+		return fmt.Errorf("'%s' is not a valid Spanner identifier", name)
+	}
+	inUse := conv.UsedNames[strings.ToLower(name)]
+	for _, t := range conv.SpSchema {
+		if strings.EqualFold(t.Name, name) {
+			inUse = true
+		}
+		for _, index := range t.Indexes {
+			inUse = inUse || strings.EqualFold(index.Name, name)
+		}
+		for _, fk := range t.ForeignKeys {
+			inUse = inUse || strings.EqualFold(fk.Name, name)
+		}
+	}
+	if inUse {
+		return fmt.Errorf("name '%s' is used by another table, index or foreign key", name)
+	}
+	return nil
+}
+
+// spannerColumnId returns the id of the Spanner column of a table converted
+// from the source column srcName.
+func spannerColumnId(conv *internal.Conv, tableId, srcName string) (string, error) {
+	colId, err := internal.GetColIdFromSrcName(conv.SrcSchema[tableId].ColDefs, srcName)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := conv.SpSchema[tableId].ColDefs[colId]; !ok {
+		return "", fmt.Errorf("column %s is dropped", srcName)
+	}
+	return colId, nil
+}
+
+func isSpannerPrimaryKey(spTable ddl.CreateTable, colId string) bool {
+	for _, pk := range spTable.PrimaryKeys {
+		if pk.ColId == colId {
+			return true
+		}
+	}
+	return false
+}
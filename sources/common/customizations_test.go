@@ -0,0 +1,449 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCustomizationsTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.Source = constants.MYSQL
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name: "users", Id: "t1", ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+				"c2": {Name: "name", Id: "c2", Type: schema.Type{Name: "varchar"}},
+				"c3": {Name: "created", Id: "c3", Type: schema.Type{Name: "datetime"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c1"}},
+		},
+		"t2": {
+			Name: "orders", Id: "t2", ColIds: []string{"c4", "c5", "c6"},
+			ColDefs: map[string]schema.Column{
+				"c4": {Name: "id", Id: "c4", Type: schema.Type{Name: "bigint"}},
+				"c5": {Name: "user_id", Id: "c5", Type: schema.Type{Name: "bigint"}},
+				"c6": {Name: "note", Id: "c6", Type: schema.Type{Name: "varchar"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c5"}, {ColId: "c4"}},
+		},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name: "users", Id: "t1", ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c3": {Name: "created", Id: "c3", T: ddl.Type{Name: ddl.Timestamp}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			Indexes:     []ddl.CreateIndex{{Name: "users_by_created", Id: "i1", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c3", Order: 1}}}},
+		},
+		"t2": {
+			Name: "orders", Id: "t2", ColIds: []string{"c4", "c5", "c6"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c4": {Name: "id", Id: "c4", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c5": {Name: "user_id", Id: "c5", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c6": {Name: "note", Id: "c6", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c5", Order: 1}, {ColId: "c4", Order: 2}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "orders_user", Id: "f1", ColIds: []string{"c5"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}}},
+		},
+	}
+	for _, name := range []string{"users", "orders", "users_by_created", "orders_user"} {
+		conv.UsedNames[name] = true
+	}
+	conv.ToSpanner = map[string]internal.NameAndCols{
+		"users":  {Name: "users", Cols: map[string]string{"id": "id", "name": "name", "created": "created"}},
+		"orders": {Name: "orders", Cols: map[string]string{"id": "id", "user_id": "user_id", "note": "note"}},
+	}
+	conv.ToSource = map[string]internal.NameAndCols{
+		"users":  {Name: "users", Cols: map[string]string{"id": "id", "name": "name", "created": "created"}},
+		"orders": {Name: "orders", Cols: map[string]string{"id": "id", "user_id": "user_id", "note": "note"}},
+	}
+	return conv
+}
+
+func TestParseCustomizations(t *testing.T) {
+	yamlFile := `
+rules:
+  - name: timestamps
+    type: global_datatype_change
+    typeMap:
+      datetime: STRING
+tables:
+  users:
+    name: customers
+    columns:
+      name:
+        maxLength: 100
+        notNull: true
+  orders:
+    parent: users
+    onDelete: CASCADE
+`
+	c, err := ParseCustomizations([]byte(yamlFile))
+	assert.Nil(t, err)
+	assert.Equal(t, []CustomizationRule{{Name: "timestamps", Type: constants.GlobalDataTypeChange, TypeMap: map[string]string{"datetime": "STRING"}}}, c.Rules)
+	notNull := true
+	assert.Equal(t, TableCustomization{Name: "customers", Columns: map[string]ColumnCustomization{"name": {MaxLength: "100", NotNull: &notNull}}}, c.Tables["users"])
+	assert.Equal(t, "users", *c.Tables["orders"].Parent)
+	assert.Equal(t, "CASCADE", c.Tables["orders"].OnDelete)
+
+	jsonFile := `{"tables": {"users": {"columns": {"created": {"drop": true}}}}}`
+	c, err = ParseCustomizations([]byte(jsonFile))
+	assert.Nil(t, err)
+	assert.True(t, c.Tables["users"].Columns["created"].Drop)
+
+	c, err = ParseCustomizations([]byte(""))
+	assert.Nil(t, err)
+	assert.Equal(t, Customizations{}, c)
+
+	_, err = ParseCustomizations([]byte("tables:\n  users:\n    rename: customers\n"))
+	assert.NotNil(t, err)
+}
+
+func TestApplyCustomizations_TableEdits(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	toddl := new(MockOptionProvider)
+	toddl.On("ToSpannerType", mock.Anything, ddl.String, schema.Type{Name: "datetime"}, false).Return(ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue(nil))
+	notNull := true
+	c := Customizations{
+		Tables: map[string]TableCustomization{
+			"users": {
+				Name: "customers",
+				Columns: map[string]ColumnCustomization{
+					"name":    {Name: "full_name", MaxLength: "100", NotNull: &notNull},
+					"created": {Type: ddl.String, MaxLength: "30"},
+				},
+			},
+			"orders": {
+				Columns: map[string]ColumnCustomization{"note": {Drop: true}},
+			},
+		},
+	}
+
+	assert.Nil(t, ApplyCustomizations(conv, toddl, c))
+	users := conv.SpSchema["t1"]
+	assert.Equal(t, "customers", users.Name)
+	assert.Equal(t, ddl.ColumnDef{Name: "full_name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 100}, NotNull: true}, users.ColDefs["c2"])
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 30}, users.ColDefs["c3"].T)
+	assert.Equal(t, "customers", conv.ToSpanner["users"].Name)
+	assert.Equal(t, "full_name", conv.ToSpanner["users"].Cols["name"])
+	assert.Contains(t, conv.ToSource, "customers")
+	assert.True(t, conv.UsedNames["customers"])
+	assert.False(t, conv.UsedNames["users"])
+	orders := conv.SpSchema["t2"]
+	assert.Equal(t, []string{"c4", "c5"}, orders.ColIds)
+	assert.NotContains(t, orders.ColDefs, "c6")
+
+	// Applying the customizations again leaves the schema unchanged.
+	reapplied := newCustomizationsTestConv()
+	assert.Nil(t, ApplyCustomizations(reapplied, toddl, c))
+	assert.Nil(t, ApplyCustomizations(reapplied, toddl, c))
+	assert.Equal(t, conv.SpSchema, reapplied.SpSchema)
+	assert.Equal(t, conv.ToSpanner, reapplied.ToSpanner)
+}
+
+func TestApplyCustomizations_EnumColumn(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	status := schema.Type{Name: "enum", EnumValues: []string{"active", "banned"}}
+	col := conv.SrcSchema["t1"].ColDefs["c2"]
+	col.Type = status
+	conv.SrcSchema["t1"].ColDefs["c2"] = col
+	toddl := new(MockOptionProvider)
+	toddl.On("ToSpannerType", mock.Anything, ddl.Enum, status, false).Return(ddl.Type{Name: ddl.Enum}, []internal.SchemaIssue(nil))
+	c := Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"name": {Type: ddl.Enum}}}}}
+
+	assert.Nil(t, ApplyCustomizations(conv, toddl, c))
+	assert.Equal(t, ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.users_name"}, conv.SpSchema["t1"].ColDefs["c2"].T)
+	assert.Equal(t, ddl.ProtoBundle{Enums: []ddl.ProtoEnum{{Name: "spanner_migration.users_name", Labels: []string{"active", "banned"}}}}, conv.ProtoBundle())
+}
+
+func TestApplyCustomizations_DropTable(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	conv.SetTimezonePolicy("t1", "", internal.TimezonePolicy{Mode: internal.TimezoneUTC})
+	conv.SetTimezonePolicy("t1", "c3", internal.TimezonePolicy{Mode: internal.TimezoneString})
+	c := Customizations{Tables: map[string]TableCustomization{"users": {Drop: true}}}
+
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.Empty(t, conv.TimezonePolicies)
+	assert.NotContains(t, conv.SpSchema, "t1")
+	assert.Empty(t, conv.SpSchema["t2"].ForeignKeys)
+	assert.False(t, conv.UsedNames["users"])
+	assert.False(t, conv.UsedNames["users_by_created"])
+	assert.False(t, conv.UsedNames["orders_user"])
+
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.NotContains(t, conv.SpSchema, "t1")
+}
+
+func TestApplyCustomizations_DropColumn(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	conv.SetTimezonePolicy("t1", "c3", internal.TimezonePolicy{Mode: internal.TimezoneString})
+	c := Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"created": {Drop: true}}}}}
+
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.Empty(t, conv.TimezonePolicies)
+	assert.Empty(t, conv.SpSchema["t1"].Indexes)
+	assert.False(t, conv.UsedNames["users_by_created"])
+	assert.NotContains(t, conv.SpSchema["t1"].ColDefs, "c3")
+
+	c = Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"id": {Drop: true}}}}}
+	assert.NotNil(t, ApplyCustomizations(conv, nil, c))
+}
+
+func TestApplyCustomizations_RowDeletionPolicy(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	c, err := ParseCustomizations([]byte(`
+tables:
+  users:
+    rowDeletionPolicy:
+      column: created
+      days: 30
+`))
+	assert.Nil(t, err)
+
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.Equal(t, ddl.RowDeletionPolicy{ColId: "c3", Days: 30}, conv.SpSchema["t1"].RowDeletionPolicy)
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.Equal(t, ddl.RowDeletionPolicy{ColId: "c3", Days: 30}, conv.SpSchema["t1"].RowDeletionPolicy)
+
+	// The column must be a TIMESTAMP column.
+	c = Customizations{Tables: map[string]TableCustomization{"users": {RowDeletionPolicy: &RowDeletionPolicyCustomization{Column: "name", Days: 30}}}}
+	assert.NotNil(t, ApplyCustomizations(conv, nil, c))
+	assert.Equal(t, ddl.RowDeletionPolicy{ColId: "c3", Days: 30}, conv.SpSchema["t1"].RowDeletionPolicy)
+
+	// An empty column removes the policy.
+	c = Customizations{Tables: map[string]TableCustomization{"users": {RowDeletionPolicy: &RowDeletionPolicyCustomization{}}}}
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.Equal(t, ddl.RowDeletionPolicy{}, conv.SpSchema["t1"].RowDeletionPolicy)
+
+	// Dropping the column removes the policy.
+	c = Customizations{Tables: map[string]TableCustomization{"users": {
+		RowDeletionPolicy: &RowDeletionPolicyCustomization{Column: "created", Days: 7},
+	}}}
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	c = Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"created": {Drop: true}}}}}
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.Equal(t, ddl.RowDeletionPolicy{}, conv.SpSchema["t1"].RowDeletionPolicy)
+}
+
+func TestApplyCustomizations_Timezone(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	toddl := new(MockOptionProvider)
+	toddl.On("ToSpannerType", mock.Anything, "", schema.Type{Name: "datetime"}, false).Return(ddl.Type{Name: ddl.Timestamp}, []internal.SchemaIssue(nil))
+	c, err := ParseCustomizations([]byte(`
+tables:
+  users:
+    timezone:
+      mode: ZONE
+      location: America/New_York
+    columns:
+      created:
+        timezone:
+          mode: STRING
+`))
+	assert.Nil(t, err)
+
+	zone := internal.TimezonePolicy{Mode: internal.TimezoneZone, Location: "America/New_York"}
+	want := map[string]internal.TableTimezones{
+		"t1": {Default: zone, Columns: map[string]internal.TimezonePolicy{"c3": {Mode: internal.TimezoneString}}},
+	}
+	assert.Nil(t, ApplyCustomizations(conv, toddl, c))
+	assert.Equal(t, want, conv.TimezonePolicies)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, conv.SpSchema["t1"].ColDefs["c3"].T)
+	assert.Nil(t, ApplyCustomizations(conv, toddl, c))
+	assert.Equal(t, want, conv.TimezonePolicies)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, conv.SpSchema["t1"].ColDefs["c3"].T)
+
+	// An empty mode removes the policy of the column, which then follows the
+	// default policy of the table.
+	c = Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"created": {Timezone: &TimezoneCustomization{}}}}}}
+	assert.Nil(t, ApplyCustomizations(conv, toddl, c))
+	assert.Equal(t, map[string]internal.TableTimezones{"t1": {Default: zone, Columns: map[string]internal.TimezonePolicy{}}}, conv.TimezonePolicies)
+	assert.Equal(t, ddl.Type{Name: ddl.Timestamp}, conv.SpSchema["t1"].ColDefs["c3"].T)
+
+	c = Customizations{Tables: map[string]TableCustomization{"users": {Timezone: &TimezoneCustomization{Mode: internal.TimezoneDate}}}}
+	assert.Nil(t, ApplyCustomizations(conv, toddl, c))
+	assert.Equal(t, ddl.Type{Name: ddl.Date}, conv.SpSchema["t1"].ColDefs["c3"].T)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, conv.SpSchema["t1"].ColDefs["c2"].T)
+}
+
+func TestApplyCustomizations_Errors(t *testing.T) {
+	testCases := []struct {
+		name string
+		c    Customizations
+	}{
+		{"Unknown table", Customizations{Tables: map[string]TableCustomization{"carts": {Name: "baskets"}}}},
+		{"Unknown column", Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"email": {NotNull: new(bool)}}}}}},
+		{"Table name in use", Customizations{Tables: map[string]TableCustomization{"users": {Name: "orders"}}}},
+		{"Invalid table name", Customizations{Tables: map[string]TableCustomization{"users": {Name: "my users"}}}},
+		{"Column name in use", Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"name": {Name: "ID"}}}}}},
+		{"Max length of INT64", Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"id": {MaxLength: "10"}}}}}},
+		{"Invalid max length", Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"name": {MaxLength: "-1"}}}}}},
+		{"Type change without ToDdl", Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"created": {Type: ddl.String}}}}}},
+		{"Unknown timezone location", Customizations{Tables: map[string]TableCustomization{"users": {Timezone: &TimezoneCustomization{Mode: "ZONE", Location: "Mars/Olympus_Mons"}}}}},
+		{"Timezone of a STRING column", Customizations{Tables: map[string]TableCustomization{"users": {Columns: map[string]ColumnCustomization{"name": {Timezone: &TimezoneCustomization{Mode: "UTC"}}}}}}},
+		{"Rule without name", Customizations{Rules: []CustomizationRule{{Type: constants.GlobalDataTypeChange, TypeMap: map[string]string{"datetime": ddl.String}}}}},
+		{"Unknown rule type", Customizations{Rules: []CustomizationRule{{Name: "r", Type: "rename_everything"}}}},
+	}
+	for _, tc := range testCases {
+		conv := newCustomizationsTestConv()
+		assert.NotNil(t, ApplyCustomizations(conv, nil, tc.c), tc.name)
+	}
+}
+
+func TestApplyCustomizations_Interleave(t *testing.T) {
+	users := "users"
+	orders := "orders"
+	none := ""
+	testCases := []struct {
+		name           string
+		c              Customizations
+		expectedParent ddl.InterleavedParent
+		expectError    bool
+	}{
+		{
+			name:           "In parent by default",
+			c:              Customizations{Tables: map[string]TableCustomization{"orders": {Parent: &users}}},
+			expectedParent: ddl.InterleavedParent{Id: "t1", OnDelete: constants.FK_NO_ACTION, InterleaveType: "IN PARENT"},
+		},
+		{
+			name:           "On delete cascade",
+			c:              Customizations{Tables: map[string]TableCustomization{"orders": {Parent: &users, OnDelete: constants.FK_CASCADE}}},
+			expectedParent: ddl.InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+		},
+		{
+			name:           "Interleave in",
+			c:              Customizations{Tables: map[string]TableCustomization{"orders": {Parent: &users, InterleaveType: "IN"}}},
+			expectedParent: ddl.InterleavedParent{Id: "t1", InterleaveType: "IN"},
+		},
+		{
+			name: "Remove interleaving",
+			c:    Customizations{Tables: map[string]TableCustomization{"orders": {Parent: &none}}},
+		},
+		{
+			name:        "On delete with interleave in",
+			c:           Customizations{Tables: map[string]TableCustomization{"orders": {Parent: &users, InterleaveType: "IN", OnDelete: constants.FK_CASCADE}}},
+			expectError: true,
+		},
+		{
+			name:        "Primary key isn't a prefix",
+			c:           Customizations{Tables: map[string]TableCustomization{"users": {Parent: &orders}}},
+			expectError: true,
+		},
+		{
+			name:        "Column name differs",
+			c:           Customizations{Tables: map[string]TableCustomization{"orders": {Parent: &users, Columns: map[string]ColumnCustomization{"user_id": {Name: "customer_id"}}}}},
+			expectError: true,
+		},
+		{
+			name:        "Parent is the table",
+			c:           Customizations{Tables: map[string]TableCustomization{"users": {Parent: &users}}},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		conv := newCustomizationsTestConv()
+		// The column of users is renamed to match the primary key of orders.
+		c := tc.c
+		if _, ok := c.Tables["users"]; !ok {
+			c.Tables["users"] = TableCustomization{Columns: map[string]ColumnCustomization{"id": {Name: "user_id"}}}
+		}
+		err := ApplyCustomizations(conv, nil, c)
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedParent, conv.SpSchema["t2"].ParentTable, tc.name)
+	}
+}
+
+func TestApplyCustomizations_Rules(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	toddl := new(MockOptionProvider)
+	toddl.On("ToSpannerType", mock.Anything, ddl.String, schema.Type{Name: "datetime"}, false).Return(ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue(nil))
+	c := Customizations{
+		Rules: []CustomizationRule{
+			{Name: "timestamps", Type: constants.GlobalDataTypeChange, TypeMap: map[string]string{"datetime": ddl.String}},
+			{Name: "strings", Type: constants.EditColumnMaxLength, SpannerType: ddl.String, MaxLength: "200"},
+			{Name: "notes", Type: constants.EditColumnMaxLength, Table: "orders", SpannerType: ddl.String, MaxLength: "50"},
+			{Name: "users_by_name", Type: constants.AddIndex, Table: "users", Index: "users_by_name", Unique: true, Keys: []IndexKeyCustomization{{Column: "name", Desc: true}}, Storing: []string{"created"}},
+		},
+	}
+
+	assert.Nil(t, ApplyCustomizations(conv, toddl, c))
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 200}, conv.SpSchema["t1"].ColDefs["c2"].T)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 200}, conv.SpSchema["t1"].ColDefs["c3"].T)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 200}, conv.SpSchema["t2"].ColDefs["c6"].T)
+	indexes := conv.SpSchema["t1"].Indexes
+	assert.Equal(t, 2, len(indexes))
+	assert.Equal(t, "users_by_name", indexes[1].Name)
+	assert.True(t, indexes[1].Unique)
+	assert.Equal(t, []ddl.IndexKey{{ColId: "c2", Desc: true, Order: 1}}, indexes[1].Keys)
+	assert.Equal(t, []string{"c3"}, indexes[1].StoredColumnIds)
+	assert.True(t, conv.UsedNames["users_by_name"])
+
+	assert.Equal(t, 4, len(conv.Rules))
+	assert.Equal(t, "All Columns", conv.Rules[0].AssociatedObjects)
+	assert.Equal(t, "All tables", conv.Rules[1].AssociatedObjects)
+	assert.Equal(t, "t2", conv.Rules[2].AssociatedObjects)
+	assert.Equal(t, "t1", conv.Rules[3].AssociatedObjects)
+
+	// Rules are recorded once, and the index isn't added again.
+	assert.Nil(t, ApplyCustomizations(conv, toddl, c))
+	assert.Equal(t, 4, len(conv.Rules))
+	assert.Equal(t, 2, len(conv.SpSchema["t1"].Indexes))
+}
+
+func TestApplyCustomizations_ShardIdRule(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	for _, tableId := range []string{"t1", "t2"} {
+		spTable := conv.SpSchema[tableId]
+		spTable.ShardIdColumn = "s" + tableId
+		spTable.ColIds = append(spTable.ColIds, spTable.ShardIdColumn)
+		spTable.ColDefs[spTable.ShardIdColumn] = ddl.ColumnDef{Name: "migration_shard_id", Id: spTable.ShardIdColumn, T: ddl.Type{Name: ddl.String, Len: 50}}
+		conv.SpSchema[tableId] = spTable
+	}
+	c := Customizations{Rules: []CustomizationRule{{Name: "shards", Type: constants.AddShardIdPrimaryKey, AddedAtTheStart: true}}}
+
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.Equal(t, []ddl.IndexKey{{ColId: "st1", Order: 1}, {ColId: "c1", Order: 2}}, conv.SpSchema["t1"].PrimaryKeys)
+	assert.Equal(t, []ddl.IndexKey{{ColId: "st2", Order: 1}, {ColId: "c5", Order: 2}, {ColId: "c4", Order: 3}}, conv.SpSchema["t2"].PrimaryKeys)
+	fk := conv.SpSchema["t2"].ForeignKeys[0]
+	assert.Equal(t, []string{"st2", "c5"}, fk.ColIds)
+	assert.Equal(t, []string{"st1", "c1"}, fk.ReferColumnIds)
+
+	assert.Nil(t, ApplyCustomizations(conv, nil, c))
+	assert.Equal(t, 2, len(conv.SpSchema["t1"].PrimaryKeys))
+	assert.Equal(t, []string{"st2", "c5"}, conv.SpSchema["t2"].ForeignKeys[0].ColIds)
+	assert.Equal(t, 1, len(conv.Rules))
+
+	conv = newCustomizationsTestConv()
+	orders := conv.SpSchema["t2"]
+	orders.ParentTable = ddl.InterleavedParent{Id: "t1", OnDelete: constants.FK_NO_ACTION, InterleaveType: "IN PARENT"}
+	conv.SpSchema["t2"] = orders
+	assert.NotNil(t, ApplyCustomizations(conv, nil, c))
+}
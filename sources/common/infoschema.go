@@ -90,9 +90,10 @@ type BatchedInfoSchema interface {
 
 // SchemaAndName contains the schema and name for a table
 type SchemaAndName struct {
-	Schema string
-	Name   string
-	Id     string
+	Schema  string
+	Name    string
+	Id      string
+	Comment string // Comment of the table, for sources that read it along with the table name.
 }
 
 // FkConstraint contains foreign key constraints
@@ -170,6 +171,16 @@ func (is *InfoSchemaImpl) GenerateSrcSchema(conv *internal.Conv, infoSchema Info
 	}
 
 	internal.ResolveForeignKeyIds(conv.SrcSchema)
+	if vis, ok := infoSchema.(ViewInfoSchema); ok {
+		views, err := vis.GetViews(conv)
+		if err != nil {
+			// Views aren't needed to migrate the tables.
+			logger.Log.Warn(fmt.Sprintf("couldn't get views: %s", err))
+		}
+		for _, view := range views {
+			AddSrcView(conv, view)
+		}
+	}
 	return tableCount, nil
 }
 
@@ -266,13 +277,32 @@ func (is *InfoSchemaImpl) ProcessData(conv *internal.Conv, infoSchema InfoSchema
 		// Extract common spColds. We get column ids common to both source and
 		// spanner table so that we can read these records from source
 		colIds := GetCommonColumnIds(conv, tableId, spSchema.ColIds)
-		err := infoSchema.ProcessData(conv, tableId, srcSchema, colIds, spSchema, additionalAttributes)
+		cpKey := internal.CheckpointKey(additionalAttributes.ShardId, spSchema.Name)
+		if conv.Checkpoint.TableCompleted(cpKey) {
+			logger.Log.Info(fmt.Sprintf("skipping table %s, its data was migrated by an earlier run", srcSchema.Name))
+			continue
+		}
+		_, resumable := conv.Checkpoint.LastCommittedKey(cpKey)
+		var err error
+		// Large tables can be read in primary key ranges on a pool of workers
+		// when the source supports it. Tables with a last committed key are
+		// always read in ranges so that they restart after that key.
+		if cis, ok := infoSchema.(ChunkedInfoSchema); ok && (conv.DataReadOptions.ChunksPerTable > 1 || resumable) {
+			err = is.processDataChunked(conv, cis, tableId, srcSchema, colIds, spSchema, additionalAttributes)
+		} else {
+			err = infoSchema.ProcessData(conv, tableId, srcSchema, colIds, spSchema, additionalAttributes)
+		}
+		// A table whose rows couldn't all be read, e.g. after a dropped
+		// connection, isn't recorded as completed.
 		if err != nil {
 			return
 		}
 		if conv.DataFlush != nil {
 			conv.DataFlush()
 		}
+		if err := conv.Checkpoint.CompleteTable(cpKey); err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't update checkpoint for table %s: %v", srcSchema.Name, err))
+		}
 	}
 }
 
@@ -345,7 +375,8 @@ func BuildSchemaTable(table SchemaAndName, name string, colDefs map[string]schem
 		PrimaryKeys:      schemaPKeys,
 		CheckConstraints: checkConstraints,
 		Indexes:          indexes,
-		ForeignKeys:      foreignKeys}
+		ForeignKeys:      foreignKeys,
+		Comment:          table.Comment}
 }
 
 
@@ -0,0 +1,39 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// FileColumn is a column of a file format that embeds its schema, such as
+// Parquet or Avro, along with the Spanner type its values map to.
+type FileColumn struct {
+	Name    string
+	Type    ddl.Type
+	NotNull bool
+}
+
+// RecordReader reads the rows of a file format that embeds its schema.
+type RecordReader interface {
+	// Columns returns the columns of the file, in file order.
+	Columns() []FileColumn
+	// Read returns the values of the next row, ordered as Columns. NULL
+	// values are nil, and array values are []interface{}. Other values are
+	// int64, float32, float64, bool, string, []byte, *big.Rat, civil.Date or
+	// time.Time, as appropriate for the column's Spanner type. Read returns
+	// io.EOF once all rows have been read.
+	Read() ([]interface{}, error)
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// SetTimezonePolicy sets the timezone policy of a column of a Spanner table,
+// or the default policy of the table if colId is empty, and retypes the
+// columns it applies to: STRING(MAX) for the STRING mode, DATE for the DATE
+// mode and TIMESTAMP otherwise. The policy applies to the TIMESTAMP columns
+// and to the columns retyped by their previous policy. toddl maps the source
+// types to their default Spanner type, to find the latter.
+func SetTimezonePolicy(conv *internal.Conv, toddl ToDdl, tableId, colId string, p internal.TimezonePolicy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	spTable, ok := conv.SpSchema[tableId]
+	if !ok {
+		return fmt.Errorf("table %s not found", tableId)
+	}
+	var colIds []string
+	if colId != "" {
+		colDef, ok := spTable.ColDefs[colId]
+		if !ok {
+			return fmt.Errorf("column %s not found", colId)
+		}
+		if _, ok := conv.TimezonePolicies[tableId].Columns[colId]; !ok && p == (internal.TimezonePolicy{}) {
+			return nil
+		}
+		if !isTimezonePolicyColumn(conv, toddl, tableId, colId) {
+			return fmt.Errorf("column %s is not a TIMESTAMP column", colDef.Name)
+		}
+		colIds = []string{colId}
+	} else {
+		for _, id := range spTable.ColIds {
+			if _, ok := conv.TimezonePolicies[tableId].Columns[id]; !ok && isTimezonePolicyColumn(conv, toddl, tableId, id) {
+				colIds = append(colIds, id)
+			}
+		}
+	}
+	if p.Mode == internal.TimezoneString || p.Mode == internal.TimezoneDate {
+		for _, id := range colIds {
+			if id == spTable.RowDeletionPolicy.ColId {
+				return fmt.Errorf("column %s is used by the row deletion policy of the table", spTable.ColDefs[id].Name)
+			}
+		}
+	}
+
+	conv.SetTimezonePolicy(tableId, colId, p)
+	for _, id := range colIds {
+		colDef := spTable.ColDefs[id]
+		switch conv.TimezonePolicy(tableId, id).Mode {
+		case internal.TimezoneString:
+			colDef.T = ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: colDef.T.IsArray}
+		case internal.TimezoneDate:
+			colDef.T = ddl.Type{Name: ddl.Date, IsArray: colDef.T.IsArray}
+		default:
+			colDef.T = ddl.Type{Name: ddl.Timestamp, IsArray: colDef.T.IsArray}
+		}
+		spTable.ColDefs[id] = colDef
+	}
+	ComputeNonKeyColumnSize(conv, tableId)
+	return nil
+}
+
+// isTimezonePolicyColumn tells whether a timezone policy applies to a column:
+// whether it is a TIMESTAMP column, or a column that was retyped by its
+// current policy from TIMESTAMP, the default type of its source type.
+func isTimezonePolicyColumn(conv *internal.Conv, toddl ToDdl, tableId, colId string) bool {
+	colDef := conv.SpSchema[tableId].ColDefs[colId]
+	switch mode := conv.TimezonePolicy(tableId, colId).Mode; {
+	case colDef.T.Name == ddl.Timestamp:
+		return true
+	case colDef.T.Name == ddl.String && mode == internal.TimezoneString,
+		colDef.T.Name == ddl.Date && mode == internal.TimezoneDate:
+		srcCol, ok := conv.SrcSchema[tableId].ColDefs[colId]
+		if !ok || toddl == nil {
+			return false
+		}
+		ty, _ := toddl.ToSpannerType(conv, "", srcCol.Type, false)
+		return ty.Name == ddl.Timestamp
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestSetTimezonePolicy(t *testing.T) {
+	conv := newCustomizationsTestConv()
+	// users gets a DATE column, and its name column holds datetime values
+	// that were retyped to STRING.
+	srcTable, spTable := conv.SrcSchema["t1"], conv.SpSchema["t1"]
+	srcTable.ColIds = append(srcTable.ColIds, "c7")
+	srcTable.ColDefs["c7"] = schema.Column{Name: "birthday", Id: "c7", Type: schema.Type{Name: "date"}}
+	srcTable.ColDefs["c2"] = schema.Column{Name: "name", Id: "c2", Type: schema.Type{Name: "datetime"}}
+	spTable.ColIds = append(spTable.ColIds, "c7")
+	spTable.ColDefs["c7"] = ddl.ColumnDef{Name: "birthday", Id: "c7", T: ddl.Type{Name: ddl.Date}}
+	conv.SrcSchema["t1"], conv.SpSchema["t1"] = srcTable, spTable
+	toddl := new(MockOptionProvider)
+	toddl.On("ToSpannerType", mock.Anything, "", schema.Type{Name: "datetime"}, false).Return(ddl.Type{Name: ddl.Timestamp}, []internal.SchemaIssue(nil))
+	toddl.On("ToSpannerType", mock.Anything, "", schema.Type{Name: "date"}, false).Return(ddl.Type{Name: ddl.Date}, []internal.SchemaIssue(nil))
+
+	// Only the TIMESTAMP columns are retyped.
+	assert.Nil(t, SetTimezonePolicy(conv, toddl, "t1", "", internal.TimezonePolicy{Mode: internal.TimezoneDate}))
+	assert.Equal(t, ddl.Type{Name: ddl.Date}, conv.SpSchema["t1"].ColDefs["c3"].T)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, conv.SpSchema["t1"].ColDefs["c2"].T)
+	assert.Nil(t, SetTimezonePolicy(conv, toddl, "t1", "", internal.TimezonePolicy{Mode: internal.TimezoneUTC}))
+	assert.Equal(t, ddl.Type{Name: ddl.Timestamp}, conv.SpSchema["t1"].ColDefs["c3"].T)
+	assert.Equal(t, ddl.Type{Name: ddl.Date}, conv.SpSchema["t1"].ColDefs["c7"].T)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, conv.SpSchema["t1"].ColDefs["c2"].T)
+
+	// The policies apply to TIMESTAMP columns only.
+	assert.NotNil(t, SetTimezonePolicy(conv, toddl, "t1", "c2", internal.TimezonePolicy{Mode: internal.TimezoneUTC}))
+	assert.NotNil(t, SetTimezonePolicy(conv, toddl, "t1", "c7", internal.TimezonePolicy{Mode: internal.TimezoneString}))
+	assert.NotNil(t, SetTimezonePolicy(conv, toddl, "t1", "c9", internal.TimezonePolicy{Mode: internal.TimezoneUTC}))
+	assert.NotNil(t, SetTimezonePolicy(conv, toddl, "t9", "", internal.TimezonePolicy{Mode: internal.TimezoneUTC}))
+
+	// The column of the row deletion policy must stay a TIMESTAMP column.
+	spTable = conv.SpSchema["t1"]
+	spTable.RowDeletionPolicy = ddl.RowDeletionPolicy{ColId: "c3", Days: 30}
+	conv.SpSchema["t1"] = spTable
+	assert.NotNil(t, SetTimezonePolicy(conv, toddl, "t1", "c3", internal.TimezonePolicy{Mode: internal.TimezoneString}))
+	assert.Nil(t, SetTimezonePolicy(conv, toddl, "t1", "c3", internal.TimezonePolicy{Mode: internal.TimezoneZone, Location: "Europe/Berlin"}))
+	assert.Equal(t, ddl.Type{Name: ddl.Timestamp}, conv.SpSchema["t1"].ColDefs["c3"].T)
+}
@@ -126,6 +126,7 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDL(conv *internal.Conv, toddl ToD
 	}
 
 	internal.ResolveRefs(conv)
+	ViewsToSpanner(conv, toddl)
 	return nil
 }
 
@@ -443,11 +444,15 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 		if len(issues) > 0 {
 			columnLevelIssues[srcColId] = issues
 		}
+		colComment := "From: " + quoteIfNeeded(srcCol.Name) + " " + srcCol.Type.Print()
+		if srcCol.Comment != "" {
+			colComment += ". " + srcCol.Comment
+		}
 		spColDef[srcColId] = ddl.ColumnDef{
 			Name:    colName,
 			T:       ty,
 			NotNull: isNotNull,
-			Comment: "From: " + quoteIfNeeded(srcCol.Name) + " " + srcCol.Type.Print(),
+			Comment: colComment,
 			Id:      srcColId,
 			AutoGen: *autoGenCol,
 		}
@@ -475,6 +480,9 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 		ColumnLevelIssues: columnLevelIssues,
 	}
 	comment := "Spanner schema for source table " + quoteIfNeeded(srcTable.Name)
+	if srcTable.Comment != "" {
+		comment += "\n" + srcTable.Comment
+	}
 	conv.SpSchema[srcTable.Id] = ddl.CreateTable{
 		Name:             spTableName,
 		ColIds:           spColIds,
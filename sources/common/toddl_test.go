@@ -899,6 +899,30 @@ func TestSchemaToSpannerDDLHelper_CassandraOpts(t *testing.T) {
 	mockToddl.AssertCalled(t, "GetTypeOption", "uuid", expectedSpannerType)
 }
 
+func TestSchemaToSpannerDDLHelper_Comments(t *testing.T) {
+	conv := internal.MakeConv()
+	srcTable := schema.Table{
+		Name:    "users",
+		Id:      "t1",
+		ColIds:  []string{"c1", "c2"},
+		Comment: "Registered users",
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+			"c2": {Name: "name", Id: "c2", Type: schema.Type{Name: "text"}, Comment: "Display name"},
+		},
+	}
+	mockToddl := new(MockOptionProvider)
+	mockToddl.On("ToSpannerType", mock.Anything, "", mock.Anything, mock.Anything).Return(ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue(nil))
+
+	ss := SchemaToSpannerImpl{}
+	err := ss.SchemaToSpannerDDLHelper(conv, mockToddl, srcTable, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Spanner schema for source table users\nRegistered users", conv.SpSchema["t1"].Comment)
+	assert.Equal(t, "From: id bigint", conv.SpSchema["t1"].ColDefs["c1"].Comment)
+	assert.Equal(t, "From: name text. Display name", conv.SpSchema["t1"].ColDefs["c2"].Comment)
+}
+
 func TestCreatePrimaryKeyExpressionVerifyInput(t *testing.T) {
 	expressions := internal.VerifyExpressionsOutput{
 		ExpressionVerificationOutputList: []internal.ExpressionVerificationOutput{
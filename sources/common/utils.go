@@ -21,6 +21,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 )
 
 type UtilsOrderInterface interface {
@@ -171,6 +172,10 @@ func ToPGDialectType(standardType ddl.Type, isPk bool) (ddl.Type, []internal.Sch
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false},
 			[]internal.SchemaIssue{internal.ArrayTypeNotSupported}
 	}
+	// The PostgreSQL dialect has no proto types.
+	if standardType.Name == ddl.Enum || standardType.Name == ddl.Proto {
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.NoGoodType}
+	}
 	if isPk && standardType.Name == ddl.Numeric {
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false},
 			[]internal.SchemaIssue{internal.NumericPKNotSupported}
@@ -178,6 +183,42 @@ func ToPGDialectType(standardType ddl.Type, isPk bool) (ddl.Type, []internal.Sch
 	return standardType, nil
 }
 
+// ToProtoEnumType names the proto enum of a column converted to ENUM after
+// its table and column, unless the ToDdl of the source named it after a
+// named source type.
+func ToProtoEnumType(ty ddl.Type, srcTable schema.Table, srcCol schema.Column) ddl.Type {
+	if ty.Name == ddl.Enum && ty.ProtoName == "" {
+		ty.ProtoName = ddl.ProtoEnumName(srcTable.Name + "_" + srcCol.Name)
+	}
+	return ty
+}
+
+// ConvertUUID converts a source UUID value to a Spanner UUID. The value is
+// either the canonical text form, or the 16 bytes of a binary column.
+func ConvertUUID(val interface{}) (uuid.UUID, error) {
+	var b []byte
+	switch v := val.(type) {
+	case uuid.UUID:
+		return v, nil
+	case [16]byte:
+		return uuid.UUID(v), nil
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return uuid.UUID{}, fmt.Errorf("can't convert value of type %T to uuid", val)
+	}
+	if len(b) == 16 {
+		return uuid.FromBytes(b)
+	}
+	u, err := uuid.ParseBytes(b)
+	if err != nil {
+		return u, fmt.Errorf("can't convert to uuid: %w", err)
+	}
+	return u, nil
+}
+
 func IsPrimaryKey(colId string, table schema.Table) bool {
 	for _, pk := range table.PrimaryKeys {
 		if pk.ColId == colId {
@@ -191,12 +232,14 @@ func IsPrimaryKey(colId string, table schema.Table) bool {
 var DATATYPE_TO_STORAGE_SIZE = map[string]int{
 	ddl.Bool:      1,
 	ddl.Date:      4,
+	ddl.Enum:      8,
 	ddl.Float32:   4,
 	ddl.Float64:   8,
 	ddl.Int64:     8,
 	ddl.JSON:      ddl.StringMaxLength,
 	ddl.Numeric:   22,
 	ddl.Timestamp: 12,
+	ddl.UUID:      16,
 }
 
 func getColumnSize(dataType string, length int64) int {
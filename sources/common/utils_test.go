@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 )
 
 func init() {
@@ -39,6 +40,33 @@ func TestToNotNull(t *testing.T) {
 	assert.Equal(t, int64(1), conv.Unexpecteds())
 }
 
+func TestConvertUUID(t *testing.T) {
+	u := uuid.MustParse("8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3")
+	tests := []struct {
+		name    string
+		in      interface{}
+		wantErr bool
+	}{
+		{name: "canonical text", in: "8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3"},
+		{name: "upper case text", in: "8F14E45F-CEEA-467F-A8A4-39E7C6F6B1E3"},
+		{name: "text bytes", in: []byte("8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3")},
+		{name: "binary string", in: string(u[:])},
+		{name: "binary bytes", in: u[:]},
+		{name: "array", in: [16]byte(u)},
+		{name: "invalid text", in: "8f14e45f-ceea-467f", wantErr: true},
+		{name: "invalid type", in: int64(1), wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := ConvertUUID(tc.in)
+		if tc.wantErr {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, u, got, tc.name)
+	}
+}
+
 func TestGetColsAndSchemas(t *testing.T) {
 	tableName := "testtable"
 	tableId := "t1"
@@ -0,0 +1,598 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ViewInfoSchema is implemented by the sources whose views are migrated.
+type ViewInfoSchema interface {
+	InfoSchema
+	// GetViews returns the views of the source database.
+	GetViews(conv *internal.Conv) ([]schema.View, error)
+}
+
+// ViewTranslator is an interface that can be implemented by ToDdl
+// implementations for sources whose queries can be parsed, to translate the
+// queries of their views to GoogleSQL. The views of the other sources, and
+// views written in the PostgreSQL dialect, are translated token by token.
+type ViewTranslator interface {
+	// TranslateView returns the query of view in GoogleSQL and the
+	// constructs of the query that couldn't be translated.
+	TranslateView(conv *internal.Conv, view schema.View) (string, []string, error)
+}
+
+// AddSrcView adds a view to conv.SrcViews. A view replaces the view of the
+// same name, since dumps create a placeholder for each view before its real
+// definition.
+func AddSrcView(conv *internal.Conv, view schema.View) {
+	for id, v := range conv.SrcViews {
+		if v.Name == view.Name {
+			view.Id = id
+		}
+	}
+	if view.Id == "" {
+		view.Id = internal.GenerateViewId()
+	}
+	conv.SrcViews[view.Id] = view
+}
+
+// Functions that have another name in Spanner.
+var renamedViewFunctions = map[string]map[string]string{
+	constants.MYSQL: {
+		"CURDATE": "CURRENT_DATE",
+		"IFNULL":  "COALESCE",
+		"LCASE":   "LOWER",
+		"NOW":     "CURRENT_TIMESTAMP",
+		"UCASE":   "UPPER",
+	},
+	constants.POSTGRES: {
+		"NOW": "CURRENT_TIMESTAMP",
+	},
+}
+
+// Functions that have no equivalent in Spanner, or whose arguments differ.
+var unsupportedViewFunctions = map[string]map[string]bool{
+	constants.MYSQL: {
+		"CAST": true, "CONVERT": true, "DATE_ADD": true, "DATE_FORMAT": true, "DATE_SUB": true, "DATEDIFF": true,
+		"FROM_UNIXTIME": true, "GROUP_CONCAT": true, "STR_TO_DATE": true, "UNIX_TIMESTAMP": true,
+	},
+	constants.POSTGRES: {
+		"AGE": true, "DATE_PART": true, "DATE_TRUNC": true, "TO_CHAR": true, "TO_DATE": true,
+	},
+}
+
+// Keywords that end a table reference, so the word that follows a table
+// reference is only an alias when it isn't one of them.
+var viewClauseKeywords = map[string]bool{
+	"AND": true, "AS": true, "CROSS": true, "EXCEPT": true, "FOR": true, "FORCE": true, "FROM": true, "FULL": true,
+	"GROUP": true, "HAVING": true, "IGNORE": true, "INNER": true, "INTERSECT": true, "JOIN": true, "LATERAL": true,
+	"LEFT": true, "LIMIT": true, "NATURAL": true, "OFFSET": true, "ON": true, "OR": true, "ORDER": true, "OUTER": true,
+	"RIGHT": true, "SELECT": true, "STRAIGHT_JOIN": true, "UNION": true, "USE": true, "USING": true, "WHERE": true,
+	"WINDOW": true, "WITH": true,
+}
+
+type viewTokenKind int
+
+const (
+	viewSpace  viewTokenKind = iota // Whitespace and comments.
+	viewWord                        // Unquoted identifier or keyword.
+	viewQuoted                      // Quoted identifier, whose text is the unquoted name.
+	viewString
+	viewNumber
+	viewOther
+)
+
+type viewToken struct {
+	kind viewTokenKind
+	text string
+}
+
+// tokenizeView splits the query of a view into tokens, for the views that
+// aren't translated by a ViewTranslator. Identifiers are
+// quoted with backticks in MySQL, where double quotes delimit strings, and
+// with double quotes in PostgreSQL.
+func tokenizeView(query string, mysql bool) ([]viewToken, error) {
+	var tokens []viewToken
+	isWordChar := func(r rune) bool {
+		return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	r := []rune(query)
+	for i := 0; i < len(r); {
+		c := r[i]
+		start := i
+		switch {
+		case unicode.IsSpace(c):
+			for i < len(r) && unicode.IsSpace(r[i]) {
+				i++
+			}
+			tokens = append(tokens, viewToken{viewSpace, string(r[start:i])})
+		case c == '-' && i+1 < len(r) && r[i+1] == '-', mysql && c == '#':
+			for i < len(r) && r[i] != '\n' {
+				i++
+			}
+			tokens = append(tokens, viewToken{viewSpace, string(r[start:i])})
+		case c == '/' && i+1 < len(r) && r[i+1] == '*':
+			for i += 2; i+1 < len(r) && !(r[i] == '*' && r[i+1] == '/'); i++ {
+			}
+			if i+1 >= len(r) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += 2
+			tokens = append(tokens, viewToken{viewSpace, string(r[start:i])})
+		case c == '\'' || (mysql && c == '"'), c == '`' || (!mysql && c == '"'):
+			i++
+			for {
+				if i >= len(r) {
+					return nil, fmt.Errorf("unterminated quote %c", c)
+				}
+				if mysql && r[i] == '\\' && c != '`' {
+					i += 2
+					continue
+				}
+				if r[i] == c {
+					if i+1 < len(r) && r[i+1] == c {
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				i++
+			}
+			if c == '\'' || (mysql && c == '"') {
+				tokens = append(tokens, viewToken{viewString, string(r[start:i])})
+			} else {
+				name := strings.ReplaceAll(string(r[start+1:i-1]), string([]rune{c, c}), string(c))
+				tokens = append(tokens, viewToken{viewQuoted, name})
+			}
+		case unicode.IsDigit(c):
+			for i < len(r) && (unicode.IsDigit(r[i]) || r[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, viewToken{viewNumber, string(r[start:i])})
+		case isWordChar(c):
+			for i < len(r) && isWordChar(r[i]) {
+				i++
+			}
+			tokens = append(tokens, viewToken{viewWord, string(r[start:i])})
+		case c == ':' && i+1 < len(r) && r[i+1] == ':':
+			i += 2
+			tokens = append(tokens, viewToken{viewOther, "::"})
+		default:
+			i++
+			tokens = append(tokens, viewToken{viewOther, string(c)})
+		}
+	}
+	return tokens, nil
+}
+
+type viewIdentRole int
+
+const (
+	viewColumn viewIdentRole = iota
+	viewTable
+	viewAlias
+	viewFunction
+	viewKeyword
+)
+
+// viewIdent is a dotted chain of identifiers of the query of a view.
+type viewIdent struct {
+	first, last int // Indices of the first and last tokens of the chain.
+	parts       []string
+	quoted      []bool
+	role        viewIdentRole
+	tableId     string // Table an alias refers to, if any.
+}
+
+// viewTranslator translates the query of a source view to Spanner, renaming
+// the tables and columns it refers to like the Spanner schema does.
+type viewTranslator struct {
+	conv     *internal.Conv
+	source   string // constants.MYSQL or constants.POSTGRES.
+	tables   map[string]string
+	views    map[string]string
+	aliases  map[string]string
+	refs     []string // Ids of the tables referred to by the query.
+	issues   []string
+	reported map[string]bool
+}
+
+func newViewTranslator(conv *internal.Conv) *viewTranslator {
+	source := constants.POSTGRES
+	if conv.Source == constants.MYSQL || conv.Source == constants.MYSQLDUMP {
+		source = constants.MYSQL
+	}
+	vt := &viewTranslator{conv: conv, source: source, tables: make(map[string]string), views: make(map[string]string)}
+	for id, t := range conv.SrcSchema {
+		vt.tables[t.Name] = id
+	}
+	for id, v := range conv.SrcViews {
+		vt.views[v.Name] = id
+	}
+	return vt
+}
+
+func (vt *viewTranslator) addIssue(format string, a ...interface{}) {
+	issue := fmt.Sprintf(format, a...)
+	if !vt.reported[issue] {
+		vt.reported[issue] = true
+		vt.issues = append(vt.issues, issue)
+	}
+}
+
+// lookupName finds name in m, ignoring case when there is no exact match.
+func lookupName(m map[string]string, name string) (string, bool) {
+	if v, ok := m[name]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// translate returns the query of view in the dialect of the Spanner database
+// and the constructs of the query that couldn't be translated.
+func (vt *viewTranslator) translate(view schema.View) (string, []string, error) {
+	vt.aliases = make(map[string]string)
+	vt.refs = nil
+	vt.issues = nil
+	vt.reported = make(map[string]bool)
+	mysql := vt.source == constants.MYSQL
+	tokens, err := tokenizeView(strings.TrimRight(strings.TrimSpace(view.Definition), ";"), mysql)
+	if err != nil {
+		return "", nil, err
+	}
+	if mysql {
+		tokens = dropCharsetIntroducers(tokens)
+	}
+	idents, byFirst := vt.findIdents(tokens)
+	if view.CheckOption {
+		vt.addIssue("WITH CHECK OPTION isn't supported by Spanner views")
+	}
+
+	pg := vt.conv.SpDialect == constants.DIALECT_POSTGRESQL
+	var sb strings.Builder
+	for i := 0; i < len(tokens); i++ {
+		if n, ok := byFirst[i]; ok {
+			sb.WriteString(vt.printIdent(idents[n]))
+			i = idents[n].last
+			continue
+		}
+		t := tokens[i]
+		prev := previousSignificant(tokens, i)
+		switch {
+		case t.kind == viewOther && t.text == "::" && !pg:
+			vt.addIssue("casts with :: aren't supported, use CAST")
+		case t.kind == viewOther && t.text == "@":
+			vt.addIssue("variables aren't supported")
+		case t.kind == viewOther && t.text == "*" && prev >= 0 && (isWord(tokens[prev], "SELECT") || isWord(tokens[prev], "DISTINCT") || tokens[prev].text == "," || tokens[prev].text == "."):
+			vt.addIssue("SELECT * must be replaced with the list of columns")
+		case t.kind == viewNumber && mysql && prev >= 0 && isWord(tokens[prev], "LIMIT"):
+			if next := nextSignificant(tokens, i); next >= 0 && tokens[next].text == "," {
+				vt.addIssue("LIMIT offset, count must be replaced with LIMIT count OFFSET offset")
+			}
+		}
+		sb.WriteString(t.text)
+	}
+	return strings.TrimSpace(sb.String()), vt.issues, nil
+}
+
+// findIdents finds the identifiers of the query and their role, and returns
+// them with a map from the index of their first token to their index.
+func (vt *viewTranslator) findIdents(tokens []viewToken) ([]viewIdent, map[int]int) {
+	var idents []viewIdent
+	byFirst := make(map[int]int)
+	inFrom := false
+	lastTable := -1 // Index of the last table reference.
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].kind != viewWord && tokens[i].kind != viewQuoted {
+			if tokens[i].text == "(" {
+				inFrom = false
+			}
+			continue
+		}
+		id := viewIdent{first: i, last: i}
+		for j := i; ; j += 2 {
+			id.parts = append(id.parts, tokens[j].text)
+			id.quoted = append(id.quoted, tokens[j].kind == viewQuoted)
+			id.last = j
+			if j+2 >= len(tokens) || tokens[j+1].text != "." || (tokens[j+2].kind != viewWord && tokens[j+2].kind != viewQuoted) {
+				break
+			}
+		}
+		i = id.last
+		prev := previousSignificant(tokens, id.first)
+		next := nextSignificant(tokens, id.last)
+		keyword := strings.ToUpper(id.parts[0])
+		switch {
+		case len(id.parts) == 1 && !id.quoted[0] && viewClauseKeywords[keyword]:
+			id.role = viewKeyword
+			switch keyword {
+			case "FROM", "JOIN", "STRAIGHT_JOIN":
+				inFrom = true
+			case "AS", "INNER", "LEFT", "RIGHT", "FULL", "CROSS", "NATURAL", "OUTER", "LATERAL":
+			default:
+				inFrom = false
+			}
+		case len(id.parts) == 1 && !id.quoted[0] && next >= 0 && tokens[next].text == "(":
+			id.role = viewFunction
+		case prev >= 0 && isWord(tokens[prev], "AS"):
+			id.role = viewAlias
+			if lastTable >= 0 && previousSignificant(tokens, prev) == idents[lastTable].last {
+				id.tableId = idents[lastTable].tableId
+				vt.aliases[id.parts[0]] = id.tableId
+			} else if inFrom && tokens[previousSignificant(tokens, prev)].text == ")" {
+				vt.aliases[id.parts[0]] = ""
+			}
+		case inFrom && prev >= 0 && (isWord(tokens[prev], "FROM") || isWord(tokens[prev], "JOIN") || isWord(tokens[prev], "STRAIGHT_JOIN") || tokens[prev].text == ","):
+			id.role = viewTable
+			id.tableId = vt.resolveTable(id.parts)
+			if id.tableId != "" {
+				vt.refs = append(vt.refs, id.tableId)
+			}
+			lastTable = len(idents)
+		case inFrom && len(id.parts) == 1 && prev >= 0 && ((lastTable >= 0 && prev == idents[lastTable].last) || tokens[prev].text == ")"):
+			// Alias without AS, of a table or of a subquery.
+			id.role = viewAlias
+			if tokens[prev].text != ")" {
+				id.tableId = idents[lastTable].tableId
+			}
+			vt.aliases[id.parts[0]] = id.tableId
+		default:
+			id.role = viewColumn
+		}
+		byFirst[id.first] = len(idents)
+		idents = append(idents, id)
+	}
+	return idents, byFirst
+}
+
+// resolveTable returns the id of the source table a table reference refers
+// to, ignoring its database or schema unless the table name includes it.
+func (vt *viewTranslator) resolveTable(parts []string) string {
+	if len(parts) >= 2 {
+		if id, ok := lookupName(vt.tables, parts[len(parts)-2]+"."+parts[len(parts)-1]); ok {
+			return id
+		}
+	}
+	id, _ := lookupName(vt.tables, parts[len(parts)-1])
+	return id
+}
+
+func (vt *viewTranslator) printIdent(id viewIdent) string {
+	switch id.role {
+	case viewKeyword, viewAlias:
+		return vt.printNames(id.parts, id.quoted)
+	case viewFunction:
+		name := strings.ToUpper(id.parts[0])
+		if unsupportedViewFunctions[vt.source][name] {
+			vt.addIssue("function %s isn't supported, or has other arguments in Spanner", name)
+		}
+		if renamed, ok := renamedViewFunctions[vt.source][name]; ok {
+			return renamed
+		}
+		return id.parts[0]
+	case viewTable:
+		name := id.parts[len(id.parts)-1]
+		if id.tableId != "" {
+			if sp, ok := vt.conv.SpSchema[id.tableId]; ok {
+				return vt.printName(sp.Name, id.quoted[len(id.parts)-1] || sp.Name != name)
+			}
+			vt.addIssue("table %s isn't in the Spanner schema", vt.conv.SrcSchema[id.tableId].Name)
+			return vt.printName(name, id.quoted[len(id.parts)-1])
+		}
+		if viewId, ok := lookupName(vt.views, name); ok {
+			if sp, ok := vt.conv.SpViews[viewId]; ok {
+				return vt.printName(sp.Name, id.quoted[len(id.parts)-1] || sp.Name != name)
+			}
+		}
+		vt.addIssue("table %s isn't migrated to Spanner", strings.Join(id.parts, "."))
+		return vt.printName(name, id.quoted[len(id.parts)-1])
+	}
+	return vt.printColumn(id)
+}
+
+// printColumn prints a column reference, which may be qualified with a
+// table or an alias, and a database or schema that is dropped.
+func (vt *viewTranslator) printColumn(id viewIdent) string {
+	parts, quoted := id.parts, id.quoted
+	if len(parts) > 3 {
+		return vt.printNames(parts, quoted)
+	}
+	colName := parts[len(parts)-1]
+	if len(parts) == 1 {
+		var spName string
+		for _, tableId := range vt.refs {
+			if name, ok := vt.spColumnName(tableId, colName); ok {
+				if spName != "" && spName != name {
+					// Ambiguous column, keep it as it is.
+					return vt.printName(colName, quoted[0])
+				}
+				spName = name
+			}
+		}
+		if spName == "" {
+			return vt.printName(colName, quoted[0])
+		}
+		return vt.printName(spName, quoted[0] || spName != colName)
+	}
+	qualifier := parts[len(parts)-2]
+	if tableId, ok := vt.aliases[qualifier]; ok {
+		if name, ok := vt.spColumnName(tableId, colName); ok {
+			return vt.printName(qualifier, quoted[len(parts)-2]) + "." + vt.printName(name, quoted[len(parts)-1] || name != colName)
+		}
+		return vt.printName(qualifier, quoted[len(parts)-2]) + "." + vt.printName(colName, quoted[len(parts)-1])
+	}
+	tableId := vt.resolveTable(parts[:len(parts)-1])
+	if tableId == "" {
+		return vt.printNames(parts, quoted)
+	}
+	sp, ok := vt.conv.SpSchema[tableId]
+	if !ok {
+		vt.addIssue("table %s isn't in the Spanner schema", vt.conv.SrcSchema[tableId].Name)
+		return vt.printNames(parts, quoted)
+	}
+	name, ok := vt.spColumnName(tableId, colName)
+	if !ok {
+		name = colName
+	}
+	tableName := parts[len(parts)-2]
+	return vt.printName(sp.Name, quoted[len(parts)-2] || sp.Name != tableName) + "." + vt.printName(name, quoted[len(parts)-1] || name != colName)
+}
+
+// spColumnName returns the Spanner name of a column of a source table, and
+// reports an issue if the column was dropped from the Spanner table.
+func (vt *viewTranslator) spColumnName(tableId, colName string) (string, bool) {
+	srcTable, ok := vt.conv.SrcSchema[tableId]
+	if !ok {
+		return "", false
+	}
+	colId, ok := lookupName(internal.GetSrcColNameIdMap(srcTable), colName)
+	if !ok {
+		return "", false
+	}
+	spCol, ok := vt.conv.SpSchema[tableId].ColDefs[colId]
+	if !ok {
+		vt.addIssue("column %s of table %s isn't in the Spanner schema", srcTable.ColDefs[colId].Name, srcTable.Name)
+		return "", false
+	}
+	return spCol.Name, true
+}
+
+func (vt *viewTranslator) printNames(parts []string, quoted []bool) string {
+	l := make([]string, len(parts))
+	for i := range parts {
+		l[i] = vt.printName(parts[i], quoted[i])
+	}
+	return strings.Join(l, ".")
+}
+
+// printName prints an identifier, quoted in the dialect of the Spanner
+// database when quote is true.
+func (vt *viewTranslator) printName(name string, quote bool) string {
+	if !quote {
+		return name
+	}
+	if vt.conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""
+	}
+	return "`" + name + "`"
+}
+
+// dropCharsetIntroducers drops the character set introducers of MySQL
+// strings, like _utf8mb4 in _utf8mb4'abc'.
+func dropCharsetIntroducers(tokens []viewToken) []viewToken {
+	var l []viewToken
+	for i, t := range tokens {
+		if t.kind == viewWord && strings.HasPrefix(t.text, "_") && i+1 < len(tokens) && tokens[i+1].kind == viewString {
+			continue
+		}
+		l = append(l, t)
+	}
+	return l
+}
+
+func isWord(t viewToken, word string) bool {
+	return t.kind == viewWord && strings.EqualFold(t.text, word)
+}
+
+func previousSignificant(tokens []viewToken, i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if tokens[j].kind != viewSpace {
+			return j
+		}
+	}
+	return -1
+}
+
+func nextSignificant(tokens []viewToken, i int) int {
+	for j := i + 1; j < len(tokens); j++ {
+		if tokens[j].kind != viewSpace {
+			return j
+		}
+	}
+	return -1
+}
+
+// ViewsToSpanner translates the source views in conv.SrcViews to Spanner
+// views in conv.SpViews, and records the constructs of their queries that
+// couldn't be translated in conv.ViewIssues. The queries are translated by
+// toddl when it implements ViewTranslator. Views whose query can't be read
+// are left out of the Spanner schema.
+func ViewsToSpanner(conv *internal.Conv, toddl ToDdl) {
+	var viewIds []string
+	for id := range conv.SrcViews {
+		viewIds = append(viewIds, id)
+	}
+	sort.Slice(viewIds, func(i, j int) bool {
+		return conv.SrcViews[viewIds[i]].Name < conv.SrcViews[viewIds[j]].Name
+	})
+	// Views are named before their queries are translated, since a view can
+	// refer to another view.
+	for _, id := range viewIds {
+		srcView := conv.SrcViews[id]
+		conv.SpViews[id] = ddl.CreateView{
+			Name:        internal.GetSpannerValidName(conv, srcView.Name),
+			Id:          id,
+			SqlSecurity: "INVOKER",
+			Comment:     "Spanner schema for source view " + quoteIfNeeded(srcView.Name),
+		}
+	}
+	vt := newViewTranslator(conv)
+	viewTranslator, ok := toddl.(ViewTranslator)
+	if !ok || conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		viewTranslator = nil
+	}
+	for _, id := range viewIds {
+		var (
+			query  string
+			issues []string
+			err    error
+		)
+		if viewTranslator != nil {
+			query, issues, err = viewTranslator.TranslateView(conv, conv.SrcViews[id])
+		} else {
+			query, issues, err = vt.translate(conv.SrcViews[id])
+		}
+		if err != nil {
+			delete(conv.SpViews, id)
+			conv.ViewIssues[id] = []string{fmt.Sprintf("can't read the query of the view: %s", err)}
+			conv.Unexpected(fmt.Sprintf("Can't translate view %s: %s", conv.SrcViews[id].Name, err))
+			continue
+		}
+		spView := conv.SpViews[id]
+		spView.Query = query
+		conv.SpViews[id] = spView
+		if len(issues) > 0 {
+			conv.ViewIssues[id] = issues
+		} else {
+			delete(conv.ViewIssues, id)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func newViewsTestConv(source, dialect string) *internal.Conv {
+	conv := internal.MakeConv()
+	conv.Source = source
+	conv.SpDialect = dialect
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name: "users", Id: "t1", ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1"},
+				"c2": {Name: "name", Id: "c2"},
+				"c3": {Name: "secret", Id: "c3"},
+			},
+		},
+		"t2": {
+			Name: "sales.orders", Id: "t2", ColIds: []string{"c4", "c5", "c6"},
+			ColDefs: map[string]schema.Column{
+				"c4": {Name: "id", Id: "c4"},
+				"c5": {Name: "user_id", Id: "c5"},
+				"c6": {Name: "total", Id: "c6"},
+			},
+		},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name: "users", Id: "t1", ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1"},
+				"c2": {Name: "full_name", Id: "c2"},
+			},
+		},
+		"t2": {
+			Name: "sales_orders", Id: "t2", ColIds: []string{"c4", "c5", "c6"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c4": {Name: "id", Id: "c4"},
+				"c5": {Name: "user_id", Id: "c5"},
+				"c6": {Name: "total", Id: "c6"},
+			},
+		},
+	}
+	conv.UsedNames = map[string]bool{"users": true, "sales_orders": true}
+	return conv
+}
+
+func TestViewsToSpanner(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		dialect    string
+		view       schema.View
+		wantQuery  string
+		wantIssues []string
+	}{
+		{
+			name:      "mysql information schema definition",
+			source:    constants.MYSQL,
+			dialect:   constants.DIALECT_GOOGLESQL,
+			view:      schema.View{Name: "named_users", Definition: "select `db`.`users`.`id` AS `id`,ifnull(`db`.`users`.`name`,_utf8mb4'none') AS `name` from `db`.`users`"},
+			wantQuery: "select `users`.`id` AS `id`,COALESCE(`users`.`full_name`,'none') AS `name` from `users`",
+		},
+		{
+			name:      "postgres aliases and schema",
+			source:    constants.POSTGRES,
+			dialect:   constants.DIALECT_GOOGLESQL,
+			view:      schema.View{Name: "user_totals", Definition: " SELECT u.name,\n    sum(o.total) AS \"Total\"\n   FROM users u\n     JOIN sales.orders o ON o.user_id = u.id\n  GROUP BY u.name;"},
+			wantQuery: "SELECT u.`full_name`,\n    sum(o.total) AS `Total`\n   FROM users u\n     JOIN `sales_orders` o ON o.user_id = u.id\n  GROUP BY u.`full_name`",
+		},
+		{
+			name:      "postgres dialect unqualified columns",
+			source:    constants.PGDUMP,
+			dialect:   constants.DIALECT_POSTGRESQL,
+			view:      schema.View{Name: "user_names", Definition: "SELECT id, name::text AS name FROM users WHERE name <> ''"},
+			wantQuery: "SELECT id, \"full_name\"::text AS name FROM users WHERE \"full_name\" <> ''",
+		},
+		{
+			name:      "untranslatable constructs",
+			source:    constants.MYSQLDUMP,
+			dialect:   constants.DIALECT_GOOGLESQL,
+			view:      schema.View{Name: "report", Definition: "SELECT *, GROUP_CONCAT(secret) FROM users, archive LIMIT 5, 10", CheckOption: true},
+			wantQuery: "SELECT *, GROUP_CONCAT(secret) FROM users, archive LIMIT 5, 10",
+			wantIssues: []string{
+				"WITH CHECK OPTION isn't supported by Spanner views",
+				"SELECT * must be replaced with the list of columns",
+				"function GROUP_CONCAT isn't supported, or has other arguments in Spanner",
+				"column secret of table users isn't in the Spanner schema",
+				"table archive isn't migrated to Spanner",
+				"LIMIT offset, count must be replaced with LIMIT count OFFSET offset",
+			},
+		},
+		{
+			name:       "postgres casts",
+			source:     constants.POSTGRES,
+			dialect:    constants.DIALECT_GOOGLESQL,
+			view:       schema.View{Name: "ids", Definition: "SELECT id::text AS id FROM users"},
+			wantQuery:  "SELECT id::text AS id FROM users",
+			wantIssues: []string{"casts with :: aren't supported, use CAST"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conv := newViewsTestConv(tc.source, tc.dialect)
+			tc.view.Id = "vw1"
+			conv.SrcViews["vw1"] = tc.view
+
+			ViewsToSpanner(conv, nil)
+
+			assert.Equal(t, ddl.CreateView{
+				Name:        tc.view.Name,
+				Id:          "vw1",
+				SqlSecurity: "INVOKER",
+				Query:       tc.wantQuery,
+				Comment:     "Spanner schema for source view " + tc.view.Name,
+			}, conv.SpViews["vw1"])
+			assert.Equal(t, tc.wantIssues, conv.ViewIssues["vw1"])
+		})
+	}
+}
+
+func TestViewsToSpanner_ViewOfView(t *testing.T) {
+	conv := newViewsTestConv(constants.MYSQL, constants.DIALECT_GOOGLESQL)
+	conv.UsedNames["users_view"] = true
+	conv.SrcViews["vw1"] = schema.View{Name: "users_view", Id: "vw1", Definition: "SELECT id FROM users"}
+	conv.SrcViews["vw2"] = schema.View{Name: "first_user", Id: "vw2", Definition: "SELECT id FROM users_view LIMIT 1"}
+
+	ViewsToSpanner(conv, nil)
+
+	spName := conv.SpViews["vw1"].Name
+	assert.NotEqual(t, "users_view", spName)
+	assert.Equal(t, "SELECT id FROM `"+spName+"` LIMIT 1", conv.SpViews["vw2"].Query)
+	assert.Empty(t, conv.ViewIssues)
+}
+
+func TestViewsToSpanner_UnreadableQuery(t *testing.T) {
+	conv := newViewsTestConv(constants.MYSQL, constants.DIALECT_GOOGLESQL)
+	conv.SrcViews["vw1"] = schema.View{Name: "broken", Id: "vw1", Definition: "SELECT 'abc FROM users"}
+
+	ViewsToSpanner(conv, nil)
+
+	assert.Empty(t, conv.SpViews)
+	assert.Equal(t, []string{"can't read the query of the view: unterminated quote '"}, conv.ViewIssues["vw1"])
+}
+
+// fakeViewTranslator is a ToDdl translating the queries of views with a
+// parser.
+type fakeViewTranslator struct {
+	ToDdl
+}
+
+func (fakeViewTranslator) TranslateView(conv *internal.Conv, view schema.View) (string, []string, error) {
+	return "SELECT parsed", []string{"parsed issue"}, nil
+}
+
+func TestViewsToSpanner_ViewTranslator(t *testing.T) {
+	conv := newViewsTestConv(constants.MYSQL, constants.DIALECT_GOOGLESQL)
+	conv.SrcViews["vw1"] = schema.View{Name: "names", Id: "vw1", Definition: "SELECT name FROM users"}
+
+	ViewsToSpanner(conv, fakeViewTranslator{})
+
+	assert.Equal(t, "SELECT parsed", conv.SpViews["vw1"].Query)
+	assert.Equal(t, []string{"parsed issue"}, conv.ViewIssues["vw1"])
+
+	// The parsers only write GoogleSQL.
+	conv = newViewsTestConv(constants.MYSQL, constants.DIALECT_POSTGRESQL)
+	conv.SrcViews["vw1"] = schema.View{Name: "names", Id: "vw1", Definition: "SELECT name FROM users"}
+
+	ViewsToSpanner(conv, fakeViewTranslator{})
+
+	assert.Equal(t, "SELECT \"full_name\" FROM users", conv.SpViews["vw1"].Query)
+	assert.Empty(t, conv.ViewIssues)
+}
+
+func TestAddSrcView(t *testing.T) {
+	conv := internal.MakeConv()
+
+	AddSrcView(conv, schema.View{Name: "v", Definition: "SELECT 1 AS id"})
+	AddSrcView(conv, schema.View{Name: "v", Definition: "SELECT id FROM users"})
+	AddSrcView(conv, schema.View{Name: "w", Definition: "SELECT 2 AS id"})
+
+	assert.Len(t, conv.SrcViews, 2)
+	for id, view := range conv.SrcViews {
+		assert.Equal(t, id, view.Id)
+		if view.Name == "v" {
+			assert.Equal(t, "SELECT id FROM users", view.Definition)
+		}
+	}
+}
@@ -242,6 +242,7 @@ func (c *CsvImpl) ProcessSingleCSV(conv *internal.Conv, tableName string,
 
 	r := csvReader.NewReader(csvFile)
 	r.Comma = delimiter
+	locations := timestampLocations(conv, tableName, colDefs)
 
 	srcCols, err := r.Read()
 	if err == io.EOF {
@@ -256,7 +257,7 @@ func (c *CsvImpl) ProcessSingleCSV(conv *internal.Conv, tableName string,
 		columnNames = srcCols
 	} else {
 		// Write the first row since it was not a column header.
-		processDataRow(conv, nullStr, tableName, columnNames, colDefs, srcCols)
+		processDataRow(conv, nullStr, tableName, columnNames, colDefs, locations, srcCols)
 	}
 
 	for {
@@ -267,26 +268,61 @@ func (c *CsvImpl) ProcessSingleCSV(conv *internal.Conv, tableName string,
 		if err != nil {
 			return fmt.Errorf("can't read row for file due to: %v", err)
 		}
-		processDataRow(conv, nullStr, tableName, columnNames, colDefs, values)
+		processDataRow(conv, nullStr, tableName, columnNames, colDefs, locations, values)
 	}
 	return nil
 }
 
+// timestampLocations maps the ids of the columns of a table whose timezone
+// policy sets a location to this location, in which their timestamps without
+// a timezone are interpreted. The other timestamps are interpreted as UTC.
+func timestampLocations(conv *internal.Conv, tableName string, colDefs map[string]ddl.ColumnDef) map[string]*time.Location {
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, tableName)
+	if err != nil {
+		return nil
+	}
+	locations := make(map[string]*time.Location)
+	for colId := range colDefs {
+		if location := conv.TimezoneLocation(tableId, colId); location != nil {
+			locations[colId] = location
+		}
+	}
+	return locations
+}
+
 // processDataRow converts a row into go data types as per the client libs.
 func processDataRow(conv *internal.Conv, nullStr, tableName string,
-	srcCols []string, colDefs map[string]ddl.ColumnDef, values []string) {
+	srcCols []string, colDefs map[string]ddl.ColumnDef, locations map[string]*time.Location, values []string) {
 	// Pass nullStr from source-profile.
-	cvtCols, cvtVals, err := convertData(conv.SpDialect, nullStr, srcCols, colDefs, values)
+	cvtCols, cvtVals, err := convertData(conv.SpDialect, nullStr, srcCols, colDefs, locations, values)
 	if err != nil {
 		logger.Log.Error(fmt.Sprintf("Error while converting data: %s\n", err))
+		conv.CollectBadRow(tableName, srcCols, values, err)
 	} else {
 		conv.WriteRow(tableName, tableName, cvtCols, cvtVals)
 	}
 }
 
-// convertData currently only supports scalar data types.
+// ConvertRow converts the values of a row given as strings to the types of
+// the Spanner columns cols, using the same rules as CSV files. Unlike CSV
+// files, no value stands for NULL.
+func ConvertRow(dialect string, cols []string, colDefs map[string]ddl.ColumnDef, values []string) ([]interface{}, error) {
+	var v []interface{}
+	for i, val := range values {
+		x, err := convertValue(dialect, cols[i], colDefs, nil, val)
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, x)
+	}
+	return v, nil
+}
+
+// convertData currently only supports scalar data types. Timestamps without
+// a timezone are interpreted in the locations of their columns, see
+// timestampLocations.
 func convertData(dialect, nullStr string, srcCols []string,
-	colDefs map[string]ddl.ColumnDef, values []string) (
+	colDefs map[string]ddl.ColumnDef, locations map[string]*time.Location, values []string) (
 	[]string, []interface{}, error) {
 	var v []interface{}
 	var cvtCols []string
@@ -296,18 +332,7 @@ func convertData(dialect, nullStr string, srcCols []string,
 			continue
 		}
 		colName := srcCols[i]
-		colId, err := internal.GetColIdFromSpName(colDefs, colName)
-		if err != nil {
-			return cvtCols, v, fmt.Errorf("Unable to get colId from SpName for column %s ", colName)
-		}
-		spColDef := colDefs[colId]
-
-		var x interface{}
-		if spColDef.T.IsArray {
-			x, err = convArray(spColDef.T, val)
-		} else {
-			x, err = convScalar(dialect, spColDef.T, val)
-		}
+		x, err := convertValue(dialect, colName, colDefs, locations, val)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -317,7 +342,20 @@ func convertData(dialect, nullStr string, srcCols []string,
 	return cvtCols, v, nil
 }
 
-func convArray(spannerType ddl.Type, val string) (interface{}, error) {
+// convertValue converts val to the type of the Spanner column colName.
+func convertValue(dialect, colName string, colDefs map[string]ddl.ColumnDef, locations map[string]*time.Location, val string) (interface{}, error) {
+	colId, err := internal.GetColIdFromSpName(colDefs, colName)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get colId from SpName for column %s ", colName)
+	}
+	spColDef := colDefs[colId]
+	if spColDef.T.IsArray {
+		return convArray(spColDef.T, locations[colId], val)
+	}
+	return convScalar(dialect, spColDef.T, locations[colId], val)
+}
+
+func convArray(spannerType ddl.Type, location *time.Location, val string) (interface{}, error) {
 	val = strings.TrimSpace(val)
 	// Handle empty array. Note that we use an empty NullString array
 	// for all Spanner array types since this will be converted to the
@@ -486,7 +524,7 @@ func convArray(spannerType ddl.Type, val string) (interface{}, error) {
 			if err != nil {
 				return []spanner.NullTime{}, err
 			}
-			t, err := convTimestamp(s)
+			t, err := convTimestamp(location, s)
 			if err != nil {
 				return []spanner.NullTime{}, err
 			}
@@ -497,7 +535,7 @@ func convArray(spannerType ddl.Type, val string) (interface{}, error) {
 	return []interface{}{}, fmt.Errorf("array type conversion not implemented for type []%v", spannerType.Name)
 }
 
-func convScalar(dialect string, spannerType ddl.Type, val string) (interface{}, error) {
+func convScalar(dialect string, spannerType ddl.Type, location *time.Location, val string) (interface{}, error) {
 	switch spannerType.Name {
 	case ddl.Bool:
 		return convBool(val)
@@ -519,7 +557,7 @@ func convScalar(dialect string, spannerType ddl.Type, val string) (interface{},
 	case ddl.String:
 		return val, nil
 	case ddl.Timestamp:
-		return convTimestamp(val)
+		return convTimestamp(location, val)
 	case ddl.JSON:
 		return val, nil
 	default:
@@ -542,7 +580,9 @@ func convBytes(val string) ([]byte, error) {
 }
 
 func convDate(val string) (civil.Date, error) {
-	d, err := civil.ParseDate(val)
+	// Timestamps converted to dates, e.g. by a DATE timezone policy, keep
+	// just their date.
+	d, err := civil.ParseDate(strings.SplitN(val, " ", 2)[0])
 	if err != nil {
 		return d, fmt.Errorf("can't convert to date: %w", err)
 	}
@@ -583,8 +623,18 @@ func convNumeric(val string) (big.Rat, error) {
 	return *r, nil
 }
 
-func convTimestamp(val string) (t time.Time, err error) {
-	t, err = time.Parse("2006-01-02 15:04:05", val)
+// convTimestamp interprets the timestamps without a timezone in location, or
+// as UTC if it is nil.
+func convTimestamp(location *time.Location, val string) (t time.Time, err error) {
+	// Timestamps of dead-letter files are in RFC 3339 format, to keep their
+	// fractional seconds and time zone.
+	if t, err = time.Parse(time.RFC3339Nano, val); err == nil {
+		return t, nil
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	t, err = time.ParseInLocation("2006-01-02 15:04:05", val, location)
 	if err != nil {
 		return t, fmt.Errorf("can't convert to timestamp: %s", val)
 	}
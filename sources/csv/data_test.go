@@ -24,6 +24,7 @@ import (
 
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
@@ -190,6 +191,22 @@ func TestGetCSVFilesWithManifest(t *testing.T) {
 	assert.Equal(t, []string{fmt.Sprintf("%s.csv", "singers_1")}, tables[0].File_patterns)
 }
 
+func TestConvertRow(t *testing.T) {
+	colDefs := map[string]ddl.ColumnDef{
+		"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+		"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+	}
+	// Empty strings are values, not NULLs.
+	v, err := ConvertRow(constants.DIALECT_GOOGLESQL, []string{"name", "id"}, colDefs, []string{"", "7"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"", int64(7)}, v)
+
+	_, err = ConvertRow(constants.DIALECT_GOOGLESQL, []string{"id"}, colDefs, []string{"x"})
+	assert.NotNil(t, err)
+	_, err = ConvertRow(constants.DIALECT_GOOGLESQL, []string{"missing"}, colDefs, []string{"1"})
+	assert.NotNil(t, err)
+}
+
 func TestConvertData(t *testing.T) {
 	singleColTests := []struct {
 		name string
@@ -207,6 +224,7 @@ func TestConvertData(t *testing.T) {
 		{"numeric", ddl.Type{Name: ddl.Numeric}, "42.6", *big.NewRat(426, 10)},
 		{"string", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "eh", "eh"},
 		{"timestamp", ddl.Type{Name: ddl.Timestamp}, "2019-10-29 05:30:00", getTime(t, "2019-10-29T05:30:00Z")},
+		{"rfc3339 timestamp", ddl.Type{Name: ddl.Timestamp}, "2019-10-29T05:30:00.123+01:00", getTime(t, "2019-10-29T05:30:00.123+01:00")},
 		{"json", ddl.Type{Name: ddl.JSON}, "{\"key1\": \"value1\"}", "{\"key1\": \"value1\"}"},
 		{"int_array", ddl.Type{Name: ddl.Int64, IsArray: true}, "{1,2,NULL}", []spanner.NullInt64{{Int64: int64(1), Valid: true}, {Int64: int64(2), Valid: true}, {Valid: false}}},
 		{"string_array", ddl.Type{Name: ddl.String, IsArray: true}, "[ab,cd]", []spanner.NullString{{StringVal: "ab", Valid: true}, {StringVal: "cd", Valid: true}}},
@@ -224,7 +242,7 @@ func TestConvertData(t *testing.T) {
 			Id:      "t1",
 			ColDefs: colDefs,
 		}})
-		_, av, err := convertData(conv.SpDialect, "", []string{col}, colDefs, []string{tc.in})
+		_, av, err := convertData(conv.SpDialect, "", []string{col}, colDefs, nil, []string{tc.in})
 		// NULL scenario.
 		if tc.ev == nil {
 			var empty []interface{}
@@ -265,11 +283,35 @@ func TestConvertData(t *testing.T) {
 	}
 	for _, tc := range errorTests {
 		conv := buildConv([]ddl.CreateTable{spTable})
-		_, _, err := convertData(conv.SpDialect, "", cols, colDefs, tc.vals)
+		_, _, err := convertData(conv.SpDialect, "", cols, colDefs, nil, tc.vals)
 		assert.NotNil(t, err, tc.name)
 	}
 }
 
+func TestConvertData_TimezonePolicy(t *testing.T) {
+	colDefs := map[string]ddl.ColumnDef{
+		"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Timestamp}},
+		"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+		"c3": {Name: "c", Id: "c3", T: ddl.Type{Name: ddl.Timestamp, IsArray: true}},
+		"c4": {Name: "d", Id: "c4", T: ddl.Type{Name: ddl.Date}},
+	}
+	conv := buildConv([]ddl.CreateTable{{Name: "testtable", Id: "t1", ColIds: []string{"c1", "c2", "c3", "c4"}, ColDefs: colDefs}})
+	conv.SetTimezonePolicy("t1", "", internal.TimezonePolicy{Mode: internal.TimezoneZone, Location: "Asia/Kolkata"})
+	conv.SetTimezonePolicy("t1", "c4", internal.TimezonePolicy{Mode: internal.TimezoneDate})
+	locations := timestampLocations(conv, "testtable", colDefs)
+	assert.Equal(t, 3, len(locations))
+
+	cols := []string{"a", "b", "c", "d"}
+	vals := []string{"2019-10-29 05:30:00", "2019-10-29T05:30:00+01:00", "{2019-10-29 05:30:00}", "2019-10-29 05:30:00"}
+	_, av, err := convertData(conv.SpDialect, "", cols, colDefs, locations, vals)
+	assert.Nil(t, err)
+	assert.True(t, getTime(t, "2019-10-29T00:00:00Z").Equal(av[0].(time.Time)))
+	// Timestamps with a timezone keep it.
+	assert.True(t, getTime(t, "2019-10-29T04:30:00Z").Equal(av[1].(time.Time)))
+	assert.True(t, getTime(t, "2019-10-29T00:00:00Z").Equal(av[2].([]spanner.NullTime)[0].Time))
+	assert.Equal(t, getDate("2019-10-29"), av[3])
+}
+
 func getCreateSingersTable() []ddl.CreateTable {
 	return []ddl.CreateTable{
 		{
@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
 
@@ -35,16 +36,22 @@ import (
 // and vals contains string data to be converted to appropriate types
 // to send to Spanner. ProcessDataRow is only called in DataMode.
 func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, vals []string, additionalAttributes internal.AdditionalDataAttributes) {
+	spTableName, cvtCols, cvtVals, err := ConvertData(conv, tableId, colIds, srcSchema, spSchema, vals, additionalAttributes)
+	writeDataRow(conv, colIds, srcSchema, vals, spTableName, cvtCols, cvtVals, err)
+}
+
+// writeDataRow writes out a row converted by ConvertData, or records it as a
+// bad row if err, the error of its conversion, is set.
+func writeDataRow(conv *internal.Conv, colIds []string, srcSchema schema.Table, vals []string, spTableName string, cvtCols []string, cvtVals []interface{}, err error) {
 	srcTableName := srcSchema.Name
 	srcCols := []string{}
 	for _, colId := range colIds {
 		srcCols = append(srcCols, srcSchema.ColDefs[colId].Name)
 	}
-	spTableName, cvtCols, cvtVals, err := ConvertData(conv, tableId, colIds, srcSchema, spSchema, vals, additionalAttributes)
 	if err != nil {
 		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 		conv.StatsAddBadRow(srcTableName, conv.DataMode())
-		conv.CollectBadRow(srcTableName, srcCols, vals)
+		conv.CollectBadRow(srcTableName, srcCols, vals, err)
 	} else {
 		conv.WriteRow(srcTableName, spTableName, cvtCols, cvtVals)
 	}
@@ -79,9 +86,9 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, srcSchema
 		var x interface{}
 		var err error
 		if spColDef.T.IsArray {
-			x, err = convArray(spColDef.T, srcColDef.Type.Name, vals[i])
+			x, err = convArray(spColDef.T, srcColDef.Type, vals[i])
 		} else {
-			x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.TimezoneOffset, vals[i])
+			x, err = convScalar(conv, spColDef.T, srcColDef.Type, conv.TimezoneOffset, conv.TimezoneLocation(tableId, colId), vals[i])
 		}
 		if err != nil {
 			return "", []string{}, []interface{}{}, err
@@ -107,7 +114,7 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, srcSchema
 // appropriate Spanner value. It is the caller's responsibility to
 // detect and handle NULL values: convScalar will return error if a
 // NULL value is passed.
-func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, TimezoneOffset string, val string) (interface{}, error) {
+func convScalar(conv *internal.Conv, spannerType ddl.Type, srcType schema.Type, TimezoneOffset string, location *time.Location, val string) (interface{}, error) {
 	// Whitespace within the val string is considered part of the data value.
 	// Note that many of the underlying conversions functions we use (like
 	// strconv.ParseFloat and strconv.ParseInt) return "invalid syntax"
@@ -115,7 +122,7 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, T
 	// We do not expect mysqldump to generate such output.
 	switch spannerType.Name {
 	case ddl.Bool:
-		return convBool(conv, spannerType, srcTypeName, val)
+		return convBool(conv, spannerType, srcType.Name, val)
 	case ddl.Bytes:
 		return convBytes(val)
 	case ddl.Date:
@@ -131,9 +138,13 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, T
 	case ddl.String:
 		return val, nil
 	case ddl.Timestamp:
-		return convTimestamp(srcTypeName, TimezoneOffset, val)
+		return convTimestamp(srcType.Name, TimezoneOffset, location, val)
 	case ddl.JSON:
 		return val, nil
+	case ddl.Enum:
+		return ddl.EnumNumber(srcType.EnumValues, val)
+	case ddl.UUID:
+		return common.ConvertUUID(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
@@ -179,7 +190,9 @@ func convBytes(val string) ([]byte, error) {
 }
 
 func convDate(val string) (civil.Date, error) {
-	d, err := civil.ParseDate(val)
+	// Datetime values converted to dates, e.g. by a DATE timezone policy,
+	// keep just their date.
+	d, err := civil.ParseDate(strings.SplitN(val, " ", 2)[0])
 	if err != nil {
 		return d, fmt.Errorf("can't convert to date: %w", err)
 	}
@@ -226,10 +239,14 @@ func convNumeric(conv *internal.Conv, val string) (interface{}, error) {
 
 // convTimestamp maps a source DB timestamp into a go Time Spanner timestamp
 // It handles both datetime and timestamp conversions.
-func convTimestamp(srcTypeName string, TimezoneOffset string, val string) (t time.Time, err error) {
+func convTimestamp(srcTypeName string, TimezoneOffset string, location *time.Location, val string) (t time.Time, err error) {
 	// mysqldump outputs timestamps as ISO 8601, except
 	// it uses space instead of T.
-	if srcTypeName == "timestamp" {
+	if location != nil {
+		// The timezone policy of the column sets the zone of its values,
+		// for both timestamp and datetime.
+		t, err = time.ParseInLocation("2006-01-02 15:04:05", val, location)
+	} else if srcTypeName == "timestamp" {
 		// We consider timezone for timestamp datatype.
 		// If timezone is not specified in mysqldump, we consider UTC time.
 		if TimezoneOffset == "" {
@@ -260,7 +277,7 @@ func convTimestamp(srcTypeName string, TimezoneOffset string, val string) (t tim
 // array elements are NULL. In other words, convArray handles "{1,
 // NULL, 2}", but it does not handle "NULL" (it returns error).
 // NOTE : convArray would only be called when MySQL 'SET' datatype is encountered.
-func convArray(spannerType ddl.Type, srcTypeName string, v string) (interface{}, error) {
+func convArray(spannerType ddl.Type, srcType schema.Type, v string) (interface{}, error) {
 	v = strings.TrimSpace(v)
 	// Handle empty array. Note that we use an empty NullString array
 	// for all Spanner array types since this will be converted to the
@@ -275,7 +292,8 @@ func convArray(spannerType ddl.Type, srcTypeName string, v string) (interface{},
 	// Instead it only accepts slices of a specific type eg: []string
 	// Hence we have to do the following case analysis.
 	// NOTE: MySQL only supports SET of string which will be translated
-	// to spanner array<string>.
+	// to spanner array<string>, or to an array of the numbers of its
+	// values for array<enum>.
 	switch spannerType.Name {
 	case ddl.String:
 		var r []spanner.NullString
@@ -291,6 +309,24 @@ func convArray(spannerType ddl.Type, srcTypeName string, v string) (interface{},
 			r = append(r, spanner.NullString{StringVal: s, Valid: true})
 		}
 		return r, nil
+	case ddl.Enum:
+		var r []spanner.NullInt64
+		for _, s := range a {
+			if s == "NULL" {
+				r = append(r, spanner.NullInt64{Valid: false})
+				continue
+			}
+			s, err := processQuote(s)
+			if err != nil {
+				return []spanner.NullInt64{}, err
+			}
+			n, err := ddl.EnumNumber(srcType.EnumValues, s)
+			if err != nil {
+				return []spanner.NullInt64{}, err
+			}
+			r = append(r, spanner.NullInt64{Int64: n, Valid: true})
+		}
+		return r, nil
 	}
 	return []interface{}{}, fmt.Errorf("array type conversion not implemented for type %v", spannerType.Name)
 }
@@ -22,6 +22,7 @@ import (
 
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
@@ -126,6 +127,58 @@ func TestConvertData(t *testing.T) {
 	}
 }
 
+func TestConvertData_Enum(t *testing.T) {
+	tests := []struct {
+		name  string
+		ty    ddl.Type
+		srcTy schema.Type
+		in    string      // Input value for conversion.
+		e     interface{} // Expected result.
+	}{
+		{"enum", ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.t_a"}, schema.Type{Name: "enum", EnumValues: []string{"small", "medium", "large"}}, "medium", int64(2)},
+		{"set", ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.t_a", IsArray: true}, schema.Type{Name: "set", ArrayBounds: []int64{-1}, EnumValues: []string{"read", "write"}}, "write,read", []spanner.NullInt64{
+			{Int64: 2, Valid: true},
+			{Int64: 1, Valid: true}}},
+		{"empty set", ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.t_a", IsArray: true}, schema.Type{Name: "set", ArrayBounds: []int64{-1}, EnumValues: []string{"read", "write"}}, "", []spanner.NullString{}},
+	}
+	for _, tc := range tests {
+		conv := buildConv(
+			ddl.CreateTable{Name: "t", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1", T: tc.ty}}},
+			schema.Table{Name: "t", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]schema.Column{"c1": {Name: "a", Id: "c1", Type: tc.srcTy}}})
+		at, ac, av, err := ConvertData(conv, "t1", []string{"c1"}, conv.SrcSchema["t1"], conv.SpSchema["t1"], []string{tc.in}, internal.AdditionalDataAttributes{})
+		checkResults(t, at, ac, av, err, "t", []string{"a"}, []interface{}{tc.e}, tc.name)
+	}
+	conv := buildConv(
+		ddl.CreateTable{Name: "t", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Enum}}}},
+		schema.Table{Name: "t", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]schema.Column{"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "enum", EnumValues: []string{"small"}}}}})
+	_, _, _, err := ConvertData(conv, "t1", []string{"c1"}, conv.SrcSchema["t1"], conv.SpSchema["t1"], []string{"huge"}, internal.AdditionalDataAttributes{})
+	assert.NotNil(t, err)
+}
+
+func TestConvertData_UUID(t *testing.T) {
+	u := uuid.MustParse("8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3")
+	tests := []struct {
+		name  string
+		srcTy schema.Type
+		in    string
+	}{
+		{"char(36)", schema.Type{Name: "char", Mods: []int64{36}}, "8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3"},
+		{"binary(16)", schema.Type{Name: "binary", Mods: []int64{16}}, string(u[:])},
+	}
+	for _, tc := range tests {
+		conv := buildConv(
+			ddl.CreateTable{Name: "t", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.UUID}}}},
+			schema.Table{Name: "t", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]schema.Column{"c1": {Name: "a", Id: "c1", Type: tc.srcTy}}})
+		at, ac, av, err := ConvertData(conv, "t1", []string{"c1"}, conv.SrcSchema["t1"], conv.SpSchema["t1"], []string{tc.in}, internal.AdditionalDataAttributes{})
+		checkResults(t, at, ac, av, err, "t", []string{"a"}, []interface{}{u}, tc.name)
+	}
+	conv := buildConv(
+		ddl.CreateTable{Name: "t", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.UUID}}}},
+		schema.Table{Name: "t", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]schema.Column{"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "char", Mods: []int64{36}}}}})
+	_, _, _, err := ConvertData(conv, "t1", []string{"c1"}, conv.SrcSchema["t1"], conv.SpSchema["t1"], []string{"not-a-uuid"}, internal.AdditionalDataAttributes{})
+	assert.NotNil(t, err)
+}
+
 func TestConvertTimestampData(t *testing.T) {
 	timestampTests := []struct {
 		name  string
@@ -167,6 +220,36 @@ func TestConvertTimestampData(t *testing.T) {
 	}
 }
 
+func TestConvertData_TimezonePolicy(t *testing.T) {
+	colIds := []string{"c1", "c2", "c3", "c4"}
+	conv := buildConv(
+		ddl.CreateTable{Name: "t", Id: "t1", ColIds: colIds, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Timestamp}},
+			"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+			"c3": {Name: "c", Id: "c3", T: ddl.Type{Name: ddl.Timestamp}},
+			"c4": {Name: "d", Id: "c4", T: ddl.Type{Name: ddl.Date}},
+		}},
+		schema.Table{Name: "t", Id: "t1", ColIds: colIds, ColDefs: map[string]schema.Column{
+			"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "datetime"}},
+			"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "datetime"}},
+			"c3": {Name: "c", Id: "c3", Type: schema.Type{Name: "timestamp"}},
+			"c4": {Name: "d", Id: "c4", Type: schema.Type{Name: "datetime"}},
+		}})
+	conv.TimezoneOffset = "+10:00"
+	conv.SetTimezonePolicy("t1", "", internal.TimezonePolicy{Mode: internal.TimezoneZone, Location: "Asia/Kolkata"})
+	conv.SetTimezonePolicy("t1", "c2", internal.TimezonePolicy{Mode: internal.TimezoneUTC})
+	conv.SetTimezonePolicy("t1", "c4", internal.TimezonePolicy{Mode: internal.TimezoneDate})
+	vals := []string{"2019-10-29 05:30:00", "2019-10-29 05:30:00", "2019-10-29 05:30:00", "2019-10-29 05:30:00"}
+	_, ac, av, err := ConvertData(conv, "t1", colIds, conv.SrcSchema["t1"], conv.SpSchema["t1"], vals, internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ac)
+	assert.True(t, getTime(t, "2019-10-29T00:00:00Z").Equal(av[0].(time.Time)))
+	assert.True(t, getTime(t, "2019-10-29T05:30:00Z").Equal(av[1].(time.Time)))
+	// The policy of the column takes precedence over the timezone of the dump.
+	assert.True(t, getTime(t, "2019-10-29T00:00:00Z").Equal(av[2].(time.Time)))
+	assert.Equal(t, getDate("2019-10-29"), av[3])
+}
+
 func TestConvertMultiColData(t *testing.T) {
 	multiColTests := []struct {
 		name   string
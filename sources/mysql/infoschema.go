@@ -20,6 +20,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 
 	_ "github.com/go-sql-driver/mysql" // The driver should be used via the database/sql package.
 	_ "github.com/lib/pq"
@@ -104,6 +105,49 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 	}
 	rows := rowsInterface.(*sql.Rows)
 	defer rows.Close()
+	return isi.processRows(conv, tableId, srcSchema, commonColIds, spSchema, rows, &sync.Mutex{}, additionalAttributes)
+}
+
+// GetKeyBounds returns the smallest and largest value of an integer column.
+func (isi InfoSchemaImpl) GetKeyBounds(conv *internal.Conv, tableId string, colId string) (int64, int64, bool, error) {
+	srcSchema := conv.SrcSchema[tableId]
+	colName := srcSchema.ColDefs[colId].Name
+	q := fmt.Sprintf("SELECT MIN(`%s`), MAX(`%s`) FROM `%s`.`%s`;", colName, colName, isi.DbName, srcSchema.Name)
+	var min, max sql.NullInt64
+	err := isi.Db.QueryRow(q).Scan(&min, &max)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return min.Int64, max.Int64, min.Valid && max.Valid, nil
+}
+
+// ProcessDataChunk performs data conversion for the rows of a table in keyRange.
+func (isi InfoSchemaImpl) ProcessDataChunk(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, keyRange common.KeyRange, mutex *sync.Mutex, additionalAttributes internal.AdditionalDataAttributes) error {
+	srcCols := []string{}
+	for _, srcColId := range srcSchema.ColIds {
+		srcCols = append(srcCols, srcSchema.ColDefs[srcColId].Name)
+	}
+	colNameList := buildColNameList(srcSchema, srcCols)
+	where, args := keyRange.WhereClause("`"+srcSchema.ColDefs[keyRange.ColId].Name+"`", func(int) string { return "?" })
+	q := fmt.Sprintf("SELECT %s FROM `%s`.`%s` WHERE %s;", colNameList, isi.DbName, srcSchema.Name, where)
+	rows, err := isi.Db.Query(q, args...)
+	if err != nil {
+		mutex.Lock()
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		mutex.Unlock()
+		return err
+	}
+	defer rows.Close()
+	return isi.processRows(conv, tableId, srcSchema, commonColIds, spSchema, rows, mutex, additionalAttributes)
+}
+
+// processRows converts the rows returned by a query on a source table and
+// writes them out. Rows are scanned and converted without holding mutex; the
+// updates of the stats of conv and the writes hold it. It returns the error,
+// if any, that ended the iteration, e.g. a dropped connection, so that a
+// partial read isn't taken for a complete one.
+func (isi InfoSchemaImpl) processRows(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, rows *sql.Rows, mutex *sync.Mutex, additionalAttributes internal.AdditionalDataAttributes) error {
+	srcTableName := conv.SrcSchema[tableId].Name
 	srcCols, _ := rows.Columns()
 	v, scanArgs := buildVals(len(srcCols))
 	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
@@ -111,24 +155,31 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 		// get RawBytes from data.
 		err := rows.Scan(scanArgs...)
 		if err != nil {
+			mutex.Lock()
 			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
 			// Scan failed, so we don't have any data to add to bad rows.
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
+			mutex.Unlock()
 			continue
 		}
 		values := valsToStrings(v)
 
 		newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
 		if err != nil {
+			mutex.Lock()
 			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
-			conv.CollectBadRow(srcTableName, srcCols, values)
+			conv.CollectBadRow(srcTableName, srcCols, values, err)
+			mutex.Unlock()
 			continue
 		}
 
-		ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues, additionalAttributes)
+		spTableName, cvtCols, cvtVals, err := ConvertData(conv, tableId, commonColIds, srcSchema, spSchema, newValues, additionalAttributes)
+		mutex.Lock()
+		writeDataRow(conv, commonColIds, srcSchema, newValues, spTableName, cvtCols, cvtVals, err)
+		mutex.Unlock()
 	}
-	return nil
+	return rows.Err()
 }
 
 // GetRowCount with number of rows in each table.
@@ -156,17 +207,18 @@ func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error)
 // but unfortunately there is no way to extract it from sql.DB.
 func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	// In MySQL, schema is the same as database name.
-	q := "SELECT table_name FROM information_schema.tables where table_type = 'BASE TABLE' and table_schema=?"
+	q := "SELECT table_name, table_comment FROM information_schema.tables where table_type = 'BASE TABLE' and table_schema=?"
 	rows, err := isi.Db.Query(q, isi.DbName)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get tables: %w", err)
 	}
 	defer rows.Close()
 	var tableName string
+	var tableComment sql.NullString
 	var tables []common.SchemaAndName
 	for rows.Next() {
-		rows.Scan(&tableName)
-		tables = append(tables, common.SchemaAndName{Schema: isi.DbName, Name: tableName})
+		rows.Scan(&tableName, &tableComment)
+		tables = append(tables, common.SchemaAndName{Schema: isi.DbName, Name: tableName, Comment: tableComment.String})
 	}
 	return tables, nil
 }
@@ -176,6 +228,32 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 
 
 
+// GetViews returns the views of the database, with the query that MySQL
+// stores for them, whose names are qualified with the database name.
+func (isi InfoSchemaImpl) GetViews(conv *internal.Conv) ([]schema.View, error) {
+	q := "SELECT table_name, view_definition, check_option FROM information_schema.views WHERE table_schema = ?"
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get views: %w", err)
+	}
+	defer rows.Close()
+	var viewName, definition, checkOption string
+	var views []schema.View
+	for rows.Next() {
+		if err := rows.Scan(&viewName, &definition, &checkOption); err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		views = append(views, schema.View{
+			Name:        viewName,
+			Schema:      isi.DbName,
+			Definition:  definition,
+			CheckOption: checkOption != "NONE",
+		})
+	}
+	return views, nil
+}
+
 // GetColumnsBatch returns a list of Column objects and names for a batch of tables.
 func (isi InfoSchemaImpl) GetColumnsBatch(conv *internal.Conv, tables []common.SchemaAndName) (map[string]common.TableColumns, error) {
 	if len(tables) == 0 {
@@ -190,7 +268,7 @@ func (isi InfoSchemaImpl) GetColumnsBatch(conv *internal.Conv, tables []common.S
 	for i := range placeholders {
 		placeholders[i] = "?"
 	}
-	q := fmt.Sprintf(`SELECT c.table_name, c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.generation_expression, c.extra
+	q := fmt.Sprintf(`SELECT c.table_name, c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.generation_expression, c.extra, c.column_comment
               FROM information_schema.COLUMNS c
               where table_schema = ? and table_name IN (%s) ORDER BY c.table_name, c.ordinal_position;`, strings.Join(placeholders, ","))
 
@@ -210,17 +288,18 @@ func (isi InfoSchemaImpl) GetColumnsBatch(conv *internal.Conv, tables []common.S
 	colIds := make(map[string][]string)
 
 	var tableName, colName, dataType, isNullable, columnType string
-	var colDefault, colExtra, colGeneratedExpression sql.NullString
+	var colDefault, colExtra, colGeneratedExpression, colComment sql.NullString
 	var charMaxLen, numericPrecision, numericScale sql.NullInt64
 
 	for cols.Next() {
-		err := cols.Scan(&tableName, &colName, &dataType, &columnType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colGeneratedExpression, &colExtra)
+		err := cols.Scan(&tableName, &colName, &dataType, &columnType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colGeneratedExpression, &colExtra, &colComment)
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
 		}
 		colId := internal.GenerateColumnId()
 		c := buildColumn(conv, colId, colName, dataType, columnType, isNullable, colDefault, colExtra, colGeneratedExpression, charMaxLen, numericPrecision, numericScale)
+		c.Comment = colComment.String
 
 		if _, ok := colDefs[tableName]; !ok {
 			colDefs[tableName] = make(map[string]schema.Column)
@@ -671,7 +750,9 @@ func buildIndexes(indexNames map[string][]string, indexMap map[string]map[string
 func toType(dataType string, columnType string, charLen sql.NullInt64, numericPrecision, numericScale sql.NullInt64) schema.Type {
 	switch {
 	case dataType == "set":
-		return schema.Type{Name: dataType, ArrayBounds: []int64{-1}}
+		return schema.Type{Name: dataType, ArrayBounds: []int64{-1}, EnumValues: parseEnumValues(columnType)}
+	case dataType == "enum" && charLen.Valid:
+		return schema.Type{Name: dataType, Mods: []int64{charLen.Int64}, EnumValues: parseEnumValues(columnType)}
 	case charLen.Valid:
 		return schema.Type{Name: dataType, Mods: []int64{charLen.Int64}}
 	// We only want to parse the length for tinyints when it is present, in the form tinyint(12). columnType can also be just 'tinyint',
@@ -700,6 +781,36 @@ func toType(dataType string, columnType string, charLen sql.NullInt64, numericPr
 	}
 }
 
+// parseEnumValues returns the labels of an enum or set column type such as
+// enum('a','b'), in which quotes in labels are doubled. It returns nil if
+// columnType doesn't list any labels.
+func parseEnumValues(columnType string) []string {
+	open := strings.Index(columnType, "(")
+	if open < 0 || !strings.HasSuffix(columnType, ")") {
+		return nil
+	}
+	list := columnType[open+1 : len(columnType)-1]
+	var labels []string
+	for i := 0; i < len(list); i++ {
+		if list[i] != '\'' {
+			continue
+		}
+		var label strings.Builder
+		for i++; i < len(list); i++ {
+			if list[i] == '\'' {
+				if i+1 < len(list) && list[i+1] == '\'' {
+					i++
+				} else {
+					break
+				}
+			}
+			label.WriteByte(list[i])
+		}
+		labels = append(labels, label.String())
+	}
+	return labels
+}
+
 // buildVals constructs []sql.RawBytes value containers to scan row
 // results into.  Returns both the underlying containers (as a slice)
 // as well as an interface{} of pointers to containers to pass to
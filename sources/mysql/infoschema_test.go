@@ -44,57 +44,57 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 	ms := []mockSpec{
 		{
 			query: "SELECT (.+) FROM information_schema.tables where table_type = 'BASE TABLE' and (.+)",
-			cols:  []string{"table_name"},
+			cols:  []string{"table_name", "table_comment"},
 			rows: [][]driver.Value{
-				{"user"},
-				{"cart"},
-				{"product"},
-				{"test"},
-				{"test_ref"},
+				{"user", "Registered users"},
+				{"cart", ""},
+				{"product", ""},
+				{"test", ""},
+				{"test_ref", ""},
 			},
 		},
 		{
 			query: "(?is)SELECT c.table_name.+?FROM information_schema.COLUMNS.+?table_name IN \\(.+\\).+",
 			args:  []driver.Value{"test", "user", "cart", "product", "test", "test_ref"},
-			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra"},
+			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra", "column_comment"},
 			rows: [][]driver.Value{
 				// user
-				{"user", "user_id", "text", "text", "NO", "uuid()", nil, nil, nil, nil, constants.DEFAULT_GENERATED},
-				{"user", "name", "text", "text", "NO", "default_name", nil, nil, nil, nil, nil},
-				{"user", "ref", "bigint", "bigint", "NO", nil, nil, nil, nil, nil, nil},
+				{"user", "user_id", "text", "text", "NO", "uuid()", nil, nil, nil, nil, constants.DEFAULT_GENERATED, nil},
+				{"user", "name", "text", "text", "NO", "default_name", nil, nil, nil, nil, nil, "Display name"},
+				{"user", "ref", "bigint", "bigint", "NO", nil, nil, nil, nil, nil, nil, nil},
 				// cart
-				{"cart", "productid", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"cart", "userid", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"cart", "quantity", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil},
+				{"cart", "productid", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"cart", "userid", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"cart", "quantity", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil, nil},
 				// product
-				{"product", "product_id", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"product", "product_name", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"product", "product_id", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"product", "product_name", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
 				// test
-				{"test", "id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil},
-				{"test", "s", "set", "set", "YES", nil, nil, nil, nil, nil, nil},
-				{"test", "txt", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"test", "b", "boolean", "boolean", "YES", nil, nil, nil, nil, nil, nil},
-				{"test", "bs", "bigint", "bigint", "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil, nil},
-				{"test", "bl", "blob", "blob", "YES", nil, nil, nil, nil, nil, nil},
-				{"test", "c", "char", "char(1)", "YES", nil, 1, nil, nil, nil, nil},
-				{"test", "c8", "char", "char(8)", "YES", nil, 8, nil, nil, nil, nil},
-				{"test", "d", "date", "date", "YES", nil, nil, nil, nil, nil, nil},
-				{"test", "dec", "decimal", "decimal(20,5)", "YES", nil, nil, 20, 5, nil, nil},
-				{"test", "f8", "double", "double", "YES", nil, nil, 53, nil, nil, nil},
-				{"test", "f4", "float", "float", "YES", nil, nil, 24, nil, nil, nil},
-				{"test", "i8", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil},
-				{"test", "i4", "integer", "integer", "YES", nil, nil, 32, 0, nil, "auto_increment"},
-				{"test", "i2", "smallint", "smallint", "YES", nil, nil, 16, 0, nil, nil},
-				{"test", "si", "integer", "integer", "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil, nil},
-				{"test", "ts", "datetime", "datetime", "YES", nil, nil, nil, nil, nil, nil},
-				{"test", "tz", "timestamp", "timestamp", "YES", nil, nil, nil, nil, nil, nil},
-				{"test", "vc", "varchar", "varchar", "YES", nil, nil, nil, nil, nil, nil},
-				{"test", "vc6", "varchar", "varchar(6)", "YES", nil, 6, nil, nil, nil, nil},
-				{"test", "bu", "bigint", "bigint(20) unsigned", "YES", nil, nil, 20, 0, nil, nil},
+				{"test", "id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil, nil},
+				{"test", "s", "set", "set", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "txt", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "b", "boolean", "boolean", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "bs", "bigint", "bigint", "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil, nil, nil},
+				{"test", "bl", "blob", "blob", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "c", "char", "char(1)", "YES", nil, 1, nil, nil, nil, nil, nil},
+				{"test", "c8", "char", "char(8)", "YES", nil, 8, nil, nil, nil, nil, nil},
+				{"test", "d", "date", "date", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "dec", "decimal", "decimal(20,5)", "YES", nil, nil, 20, 5, nil, nil, nil},
+				{"test", "f8", "double", "double", "YES", nil, nil, 53, nil, nil, nil, nil},
+				{"test", "f4", "float", "float", "YES", nil, nil, 24, nil, nil, nil, nil},
+				{"test", "i8", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil, nil},
+				{"test", "i4", "integer", "integer", "YES", nil, nil, 32, 0, nil, "auto_increment", nil},
+				{"test", "i2", "smallint", "smallint", "YES", nil, nil, 16, 0, nil, nil, nil},
+				{"test", "si", "integer", "integer", "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil, nil, nil},
+				{"test", "ts", "datetime", "datetime", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "tz", "timestamp", "timestamp", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "vc", "varchar", "varchar", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "vc6", "varchar", "varchar(6)", "YES", nil, 6, nil, nil, nil, nil, nil},
+				{"test", "bu", "bigint", "bigint(20) unsigned", "YES", nil, nil, 20, 0, nil, nil, nil},
 				// test_ref
-				{"test_ref", "ref_id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil},
-				{"test_ref", "ref_txt", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"test_ref", "abc", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"test_ref", "ref_id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil, nil},
+				{"test_ref", "ref_txt", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"test_ref", "abc", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
 			},
 		},
 		{
@@ -214,12 +214,12 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 			ForeignKeys: []schema.ForeignKey(nil),
 			Indexes:     []schema.Index(nil), Id: ""},
 		"user": schema.Table{Name: "user", Schema: "test", ColIds: []string{"user_id", "name", "ref"}, ColDefs: map[string]schema.Column{
-			"name":    schema.Column{Name: "name", Type: schema.Type{Name: "text", Mods: []int64(nil), ArrayBounds: []int64(nil)}, NotNull: true, Ignored: schema.Ignored{Check: false, Identity: false, Default: true, Exclusion: false, ForeignKey: false, AutoIncrement: false}, Id: "", DefaultValue: ddl.DefaultValue{Value: ddl.Expression{ExpressionId: "", Statement: "'default_name'"}, IsPresent: true}},
+			"name":    schema.Column{Name: "name", Type: schema.Type{Name: "text", Mods: []int64(nil), ArrayBounds: []int64(nil)}, NotNull: true, Ignored: schema.Ignored{Check: false, Identity: false, Default: true, Exclusion: false, ForeignKey: false, AutoIncrement: false}, Id: "", DefaultValue: ddl.DefaultValue{Value: ddl.Expression{ExpressionId: "", Statement: "'default_name'"}, IsPresent: true}, Comment: "Display name"},
 			"ref":     schema.Column{Name: "ref", Type: schema.Type{Name: "bigint", Mods: []int64(nil), ArrayBounds: []int64(nil)}, NotNull: true, Ignored: schema.Ignored{Check: false, Identity: false, Default: false, Exclusion: false, ForeignKey: false, AutoIncrement: false}, Id: ""},
 			"user_id": schema.Column{Name: "user_id", Type: schema.Type{Name: "text", Mods: []int64(nil), ArrayBounds: []int64(nil)}, NotNull: true, Ignored: schema.Ignored{Check: false, Identity: false, Default: true, Exclusion: false, ForeignKey: false, AutoIncrement: false}, Id: "", DefaultValue: ddl.DefaultValue{Value: ddl.Expression{ExpressionId: "", Statement: "uuid()"}, IsPresent: true}}},
 			PrimaryKeys: []schema.Key{schema.Key{ColId: "user_id", Desc: false, Order: 0}},
 			ForeignKeys: []schema.ForeignKey{schema.ForeignKey{Name: "fk_test", ColIds: []string{"ref"}, ReferTableId: "test", ReferColumnIds: []string{"id"}, OnUpdate: constants.FK_CASCADE, OnDelete: constants.FK_SET_NULL, Id: ""}},
-			Indexes:     []schema.Index(nil), Id: ""}}
+			Indexes:     []schema.Index(nil), Id: "", Comment: "Registered users"}}
 	internal.AssertSrcSchema(t, conv, expectedSchema, conv.SrcSchema)
 	assert.Equal(t, int64(0), conv.Unexpecteds())
 }
@@ -229,18 +229,18 @@ func TestProcessSchemaMYSQLPKOrdering(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.tables where table_type = 'BASE TABLE' and (.+)",
 			args:  []driver.Value{"test"},
-			cols:  []string{"table_name"},
+			cols:  []string{"table_name", "table_comment"},
 			rows: [][]driver.Value{
-				{"pk_order"},
+				{"pk_order", ""},
 			},
 		},
 		{
 			query: "(?is)SELECT c.table_name.+?FROM information_schema.COLUMNS.+?table_name IN \\(.+\\).+",
 			args:  []driver.Value{"test", "pk_order"},
-			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra"},
+			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra", "column_comment"},
 			rows: [][]driver.Value{
-				{"pk_order", "pk_1", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"pk_order", "pk_2", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"pk_order", "pk_1", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"pk_order", "pk_2", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
 			},
 		},
 		{
@@ -362,19 +362,19 @@ func TestProcessData_MultiCol(t *testing.T) {
 	// ProcessInfoSchema.
 	ms := []mockSpec{
 		{
-			query: "SELECT table_name FROM information_schema.tables where table_type = 'BASE TABLE' and (.+)",
+			query: "SELECT table_name, table_comment FROM information_schema.tables where table_type = 'BASE TABLE' and (.+)",
 			args:  []driver.Value{"test"},
-			cols:  []string{"table_name"},
-			rows:  [][]driver.Value{{"test"}},
+			cols:  []string{"table_name", "table_comment"},
+			rows:  [][]driver.Value{{"test", ""}},
 		},
 		{
 			query: "(?is)SELECT c.table_name.+?FROM information_schema.COLUMNS.+?table_name IN \\(.+\\).+",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra"},
+			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra", "column_comment"},
 			rows: [][]driver.Value{
-				{"test", "a", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"test", "b", "double", "double", "YES", nil, nil, 53, nil, []byte("a+2.0"), "STORED GENERATED"},
-				{"test", "c", "bigint", "bigint", "YES", nil, nil, 64, 0, []byte("a+1"), "VIRTUAL GENERATED"},
+				{"test", "a", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "b", "double", "double", "YES", nil, nil, 53, nil, []byte("a+2.0"), "STORED GENERATED", nil},
+				{"test", "c", "bigint", "bigint", "YES", nil, nil, 64, 0, []byte("a+1"), "VIRTUAL GENERATED", nil},
 			},
 		},
 		{
@@ -522,19 +522,19 @@ func TestProcessSchema_Sharded(t *testing.T) {
 	// ProcessInfoSchema.
 	ms := []mockSpec{
 		{
-			query: "SELECT table_name FROM information_schema.tables where table_type = 'BASE TABLE' and (.+)",
+			query: "SELECT table_name, table_comment FROM information_schema.tables where table_type = 'BASE TABLE' and (.+)",
 			args:  []driver.Value{"test"},
-			cols:  []string{"table_name"},
-			rows:  [][]driver.Value{{"test"}},
+			cols:  []string{"table_name", "table_comment"},
+			rows:  [][]driver.Value{{"test", ""}},
 		},
 		{
 			query: "(?is)SELECT c.table_name.+?FROM information_schema.COLUMNS.+?table_name IN \\(.+\\).+",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra"},
+			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra", "column_comment"},
 			rows: [][]driver.Value{
-				{"test", "a", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"test", "b", "double", "double", "YES", nil, nil, 53, nil, []byte("a+2.0"), nil},
-				{"test", "c", "bigint", "bigint", "YES", nil, nil, 64, 0, []byte("a+1"), []byte("VIRTUAL GENERATED")},
+				{"test", "a", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"test", "b", "double", "double", "YES", nil, nil, 53, nil, []byte("a+2.0"), nil, nil},
+				{"test", "c", "bigint", "bigint", "YES", nil, nil, 64, 0, []byte("a+1"), []byte("VIRTUAL GENERATED"), nil},
 			},
 		},
 		{
@@ -651,10 +651,10 @@ func TestProcessSchema_Sharded(t *testing.T) {
 func TestSetRowStats(t *testing.T) {
 	ms := []mockSpec{
 		{
-			query: "SELECT table_name FROM information_schema.tables where table_type = 'BASE TABLE' and (.+)",
+			query: "SELECT table_name, table_comment FROM information_schema.tables where table_type = 'BASE TABLE' and (.+)",
 			args:  []driver.Value{"test"},
-			cols:  []string{"table_name"},
-			rows:  [][]driver.Value{{"test1"}, {"test2"}},
+			cols:  []string{"table_name", "table_comment"},
+			rows:  [][]driver.Value{{"test1", ""}, {"test2", ""}},
 		}, {
 			query: "SELECT COUNT[(][*][)] FROM `test`.`test1`",
 			cols:  []string{"count"},
@@ -693,6 +693,30 @@ func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
 	return db
 }
 
+func TestGetViews(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT table_name, view_definition, check_option FROM information_schema.views WHERE table_schema = ?"),
+			args:  []driver.Value{"test"},
+			cols:  []string{"table_name", "view_definition", "check_option"},
+			rows: [][]driver.Value{
+				{"big_carts", "select `test`.`cart`.`productid` AS `productid` from `test`.`cart` where (`test`.`cart`.`quantity` > 10)", "NONE"},
+				{"checked", "select `test`.`cart`.`productid` AS `productid` from `test`.`cart`", "CASCADED"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db, DbName: "test"}
+
+	views, err := isi.GetViews(internal.MakeConv())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.View{
+		{Name: "big_carts", Schema: "test", Definition: "select `test`.`cart`.`productid` AS `productid` from `test`.`cart` where (`test`.`cart`.`quantity` > 10)"},
+		{Name: "checked", Schema: "test", Definition: "select `test`.`cart`.`productid` AS `productid` from `test`.`cart`", CheckOption: true},
+	}, views)
+}
+
 func TestGetConstraints_CheckConstraintsTableExists(t *testing.T) {
 	ms := []mockSpec{
 		{
@@ -769,13 +793,13 @@ func TestGetConstraints_CheckConstraintsTableAbsent(t *testing.T) {
 func TestGetColumnsBatch(t *testing.T) {
 	ms := []mockSpec{
 		{
-			query: regexp.QuoteMeta("SELECT c.table_name, c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.generation_expression, c.extra FROM information_schema.COLUMNS c where table_schema = ? and table_name IN (?,?) ORDER BY c.table_name, c.ordinal_position;"),
+			query: regexp.QuoteMeta("SELECT c.table_name, c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.generation_expression, c.extra, c.column_comment FROM information_schema.COLUMNS c where table_schema = ? and table_name IN (?,?) ORDER BY c.table_name, c.ordinal_position;"),
 			args:  []driver.Value{"test", "user", "cart"},
-			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra"},
+			cols:  []string{"table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "extra", "column_comment"},
 			rows: [][]driver.Value{
-				{"cart", "productid", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"cart", "userid", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
-				{"user", "user_id", "text", "text", "NO", "uuid()", nil, nil, nil, nil, "DEFAULT_GENERATED"},
+				{"cart", "productid", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"cart", "userid", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"user", "user_id", "text", "text", "NO", "uuid()", nil, nil, nil, nil, "DEFAULT_GENERATED", nil},
 			},
 		},
 	}
@@ -821,4 +845,15 @@ func TestBuildColumn(t *testing.T) {
 	// Test case 4: Auto increment
 	c = buildColumn(conv, colId, colName, "int", "int(11)", "NO", sql.NullString{Valid: false}, sql.NullString{Valid: true, String: "auto_increment"}, sql.NullString{Valid: false}, sql.NullInt64{Valid: false}, sql.NullInt64{Valid: false}, sql.NullInt64{Valid: false})
 	assert.Equal(t, constants.AUTO_INCREMENT, c.AutoGen.Name)
+
+	// Test case 5: Enum column
+	c = buildColumn(conv, colId, colName, "enum", "enum('small','it''s large')", "YES", sql.NullString{Valid: false}, sql.NullString{Valid: false}, sql.NullString{Valid: false}, sql.NullInt64{Valid: true, Int64: 10}, sql.NullInt64{Valid: false}, sql.NullInt64{Valid: false})
+	assert.Equal(t, schema.Type{Name: "enum", Mods: []int64{10}, EnumValues: []string{"small", "it's large"}}, c.Type)
+}
+
+func TestParseEnumValues(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, parseEnumValues("enum('a','b')"))
+	assert.Equal(t, []string{"read", "write,delete", ""}, parseEnumValues("set('read','write,delete','')"))
+	assert.Equal(t, []string{"it's"}, parseEnumValues("enum('it''s')"))
+	assert.Nil(t, parseEnumValues("set"))
 }
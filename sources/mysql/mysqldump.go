@@ -177,6 +177,10 @@ func processStatement(conv *internal.Conv, stmt ast.StmtNode) bool {
 		if conv.SchemaMode() {
 			processCreateIndex(conv, s)
 		}
+	case *ast.CreateViewStmt:
+		if conv.SchemaMode() {
+			processCreateView(conv, s)
+		}
 	default:
 		conv.SkipStatement(NodeType(stmt))
 	}
@@ -209,6 +213,34 @@ func processCreateIndex(conv *internal.Conv, stmt *ast.CreateIndexStmt) {
 	}
 }
 
+// processCreateView adds the view of a CREATE VIEW statement to
+// conv.SrcViews. mysqldump first creates a placeholder for each view, which
+// is replaced by the real definition at the end of the dump.
+func processCreateView(conv *internal.Conv, stmt *ast.CreateViewStmt) {
+	if stmt.ViewName == nil || stmt.Select == nil {
+		logStmtError(conv, stmt, fmt.Errorf("view name or query is nil"))
+		return
+	}
+	viewName, err := getTableName(stmt.ViewName)
+	if err != nil {
+		logStmtError(conv, stmt, fmt.Errorf("can't get view name: %w", err))
+		return
+	}
+	if len(stmt.Cols) > 0 {
+		// Spanner views take their column names from the query.
+		conv.Unexpected(fmt.Sprintf("Column names of view %s are ignored", viewName))
+	}
+	var sb strings.Builder
+	if err := stmt.Select.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		logStmtError(conv, stmt, fmt.Errorf("can't restore query of view %s: %w", viewName, err))
+		return
+	}
+	common.AddSrcView(conv, schema.View{
+		Name:       viewName,
+		Definition: sb.String(),
+	})
+}
+
 func processSetStmt(conv *internal.Conv, stmt *ast.SetStmt) {
 	if stmt.Variables != nil && len(stmt.Variables) > 0 {
 		for _, variable := range stmt.Variables {
@@ -304,6 +336,7 @@ func processCreateTable(conv *internal.Conv, stmt *ast.CreateTableStmt) {
 		ForeignKeys:      fkeys,
 		Indexes:          index,
 		CheckConstraints: checkConstraints,
+		Comment:          getTableComment(stmt.Options),
 	}
 	for _, constraint := range stmt.Constraints {
 		processConstraint(conv, tableId, constraint, "CREATE TABLE", conv.SrcSchema[tableId].ColNameIdMap)
@@ -532,6 +565,9 @@ func processColumn(conv *internal.Conv, tableName string, col *ast.ColumnDef) (s
 		Name:        tid,
 		Mods:        mods,
 		ArrayBounds: getArrayBounds(col.Tp.String(), col.Tp.GetElems())}
+	if tid == "enum" || tid == "set" {
+		ty.EnumValues = col.Tp.GetElems()
+	}
 	column := schema.Column{Name: name, Type: ty}
 	return name, column, updateColsByOption(conv, tableName, col, &column), nil
 }
@@ -574,6 +610,10 @@ func updateColsByOption(conv *internal.Conv, tableName string, col *ast.ColumnDe
 			cc.isUniqueKey = true
 		case ast.ColumnOptionCheck:
 			column.Ignored.Check = true
+		case ast.ColumnOptionComment:
+			if v, ok := elem.Expr.(*driver.ValueExpr); ok {
+				column.Comment = v.GetString()
+			}
 		case ast.ColumnOptionReference:
 			column := col.Name.String()
 			referTable, err := getTableName(elem.Refer.Table)
@@ -607,6 +647,16 @@ func updateColsByOption(conv *internal.Conv, tableName string, col *ast.ColumnDe
 	return cc
 }
 
+// getTableComment returns the COMMENT table option, if any.
+func getTableComment(options []*ast.TableOption) string {
+	for _, op := range options {
+		if op.Tp == ast.TableOptionComment {
+			return op.StrValue
+		}
+	}
+	return ""
+}
+
 // getTypeModsAndID returns ID and mods of column datatype.
 func getTypeModsAndID(conv *internal.Conv, columnType string) (string, []int64) {
 	// There are no methods in pincap parser to retirieve ID and mods.
@@ -833,7 +883,7 @@ func processInsertStmt(conv *internal.Conv, stmt *ast.InsertStmt) {
 		if err2 != nil {
 			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 			conv.StatsAddBadRow(srcSchema.Name, conv.DataMode())
-			conv.CollectBadRow(srcSchema.Name, srcCols, values)
+			conv.CollectBadRow(srcSchema.Name, srcCols, values, err2)
 			continue
 		}
 		ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues, internal.AdditionalDataAttributes{ShardId: ""})
@@ -906,6 +906,31 @@ func TestProcessMySQLDump_GetDDL(t *testing.T) {
 	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions), " "))
 }
 
+func TestProcessMySQLDump_Views(t *testing.T) {
+	conv, _ := runProcessMySQLDump("CREATE TABLE cart (productid varchar(20) PRIMARY KEY, quantity bigint);\n" +
+		"CREATE VIEW `big_carts` AS SELECT 1 AS `productid`, 1 AS `quantity`;\n" +
+		"DROP VIEW IF EXISTS `big_carts`;\n" +
+		"CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `big_carts` AS select `c`.`productid` AS `productid`,`c`.`quantity` AS `quantity` from `cart` `c` where (`c`.`quantity` > 10);")
+	assert.Len(t, conv.SrcViews, 1)
+	ddlStmts := ddl.GetViewsDDL(ddl.Config{}, conv.SpViews)
+	assert.Len(t, ddlStmts, 1)
+	// Compare without whitespace, which depends on how the parser prints the query.
+	assert.Equal(t, "CREATEVIEWbig_cartsSQLSECURITYINVOKERASSELECT`c`.`productid`AS`productid`,`c`.`quantity`AS`quantity`FROM`cart`AS`c`WHERE(`c`.`quantity`>10)",
+		strings.Join(strings.Fields(ddlStmts[0]), ""))
+}
+
+func TestProcessMySQLDump_Comments(t *testing.T) {
+	conv, _ := runProcessMySQLDump("CREATE TABLE cart (productid varchar(20) PRIMARY KEY, note varchar(40) COMMENT 'Gift note') COMMENT='Shopping carts';")
+	tableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, "cart")
+	assert.Nil(t, err)
+	colId, err := internal.GetColIdFromSrcName(conv.SrcSchema[tableId].ColDefs, "note")
+	assert.Nil(t, err)
+	assert.Equal(t, "Shopping carts", conv.SrcSchema[tableId].Comment)
+	assert.Equal(t, "Gift note", conv.SrcSchema[tableId].ColDefs[colId].Comment)
+	assert.Equal(t, "Spanner schema for source table cart\nShopping carts", conv.SpSchema[tableId].Comment)
+	assert.Equal(t, "From: note varchar(40). Gift note", conv.SpSchema[tableId].ColDefs[colId].Comment)
+}
+
 func TestProcessMySQLDump_Rows(t *testing.T) {
 	conv, _ := runProcessMySQLDump("CREATE TABLE cart (a text, n bigint);\n" +
 		"INSERT INTO cart (a, n) VALUES ('a42', 2);")
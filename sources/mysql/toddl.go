@@ -64,7 +64,7 @@ func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType s
 	if len(srcType.ArrayBounds) > 1 {
 		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
 		issues = append(issues, internal.MultiDimensionalArray)
-	} else if len(srcType.ArrayBounds) == 1 {
+	} else if len(srcType.ArrayBounds) == 1 && ty.Name != ddl.Enum {
 		// This check has been added because we don't support Array<primitive type> to string conversions.
 		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
 		issues = append(issues, internal.ArrayTypeNotSupported)
@@ -181,6 +181,10 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 			return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil
 		}
 	case "varchar", "char":
+		// CHAR(36) columns commonly hold UUIDs in their text form.
+		if spType == ddl.UUID && srcType.Name == "char" && len(srcType.Mods) > 0 && srcType.Mods[0] == 36 {
+			return ddl.Type{Name: ddl.UUID}, nil
+		}
 		switch spType {
 		case ddl.Bytes:
 			if len(srcType.Mods) > 0 {
@@ -201,7 +205,16 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
 		}
 	case "set", "enum":
-		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		switch spType {
+		case ddl.Enum:
+			if len(srcType.EnumValues) > 0 {
+				// A set is an array of values of its enum.
+				return ddl.Type{Name: ddl.Enum, IsArray: srcType.Name == "set"}, nil
+			}
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		default:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		}
 	case "json":
 		switch spType {
 		case ddl.String:
@@ -212,6 +225,10 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 			return ddl.Type{Name: ddl.JSON}, nil
 		}
 	case "binary", "varbinary":
+		// BINARY(16) columns commonly hold UUIDs in their binary form.
+		if spType == ddl.UUID && srcType.Name == "binary" && len(srcType.Mods) > 0 && srcType.Mods[0] == 16 {
+			return ddl.Type{Name: ddl.UUID}, nil
+		}
 		switch spType {
 		case ddl.String:
 			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
@@ -425,6 +425,43 @@ func dropComments(t *ddl.CreateTable) {
 	}
 }
 
+func TestToSpannerType_Enum(t *testing.T) {
+	conv := internal.MakeConv()
+	enum := schema.Type{Name: "enum", EnumValues: []string{"small", "large"}}
+	set := schema.Type{Name: "set", ArrayBounds: []int64{-1}, EnumValues: []string{"read", "write"}}
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, ddl.Enum, enum, false)
+	assert.Equal(t, ddl.Type{Name: ddl.Enum}, ty)
+	assert.Empty(t, issues)
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, ddl.Enum, set, false)
+	assert.Equal(t, ddl.Type{Name: ddl.Enum, IsArray: true}, ty)
+	assert.Empty(t, issues)
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, "", set, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.ArrayTypeNotSupported}, issues)
+	ty, _ = ToDdlImpl{}.ToSpannerType(conv, ddl.Enum, schema.Type{Name: "enum"}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+}
+
+func TestToSpannerType_UUID(t *testing.T) {
+	conv := internal.MakeConv()
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, ddl.UUID, schema.Type{Name: "char", Mods: []int64{36}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, ty)
+	assert.Empty(t, issues)
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, ddl.UUID, schema.Type{Name: "binary", Mods: []int64{16}}, true)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, ty)
+	assert.Empty(t, issues)
+	// UUID is only offered for the sizes of the text and binary forms.
+	ty, _ = ToDdlImpl{}.ToSpannerType(conv, ddl.UUID, schema.Type{Name: "char", Mods: []int64{32}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 32}, ty)
+	ty, _ = ToDdlImpl{}.ToSpannerType(conv, ddl.UUID, schema.Type{Name: "varbinary", Mods: []int64{16}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.Bytes, Len: 16}, ty)
+	// The defaults are unchanged.
+	ty, _ = ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "char", Mods: []int64{36}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 36}, ty)
+	ty, _ = ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "binary", Mods: []int64{16}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.Bytes, Len: 16}, ty)
+}
+
 func Test_GetColumnAutoGen(t *testing.T) {
 	conv := internal.MakeConv()
 	tc := []struct {
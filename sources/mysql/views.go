@@ -0,0 +1,133 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	assessment "github.com/GoogleCloudPlatform/spanner-migration-tool/assessment/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+// TranslateView translates the query of a view to GoogleSQL with the rules
+// that translate the queries of the assessment. When the rules can't
+// translate the query, it is returned without the database of its names,
+// and the reason is reported as an issue so that the user fixes the view.
+func (tdi ToDdlImpl) TranslateView(conv *internal.Conv, view schema.View) (string, []string, error) {
+	stmt, err := parser.New().ParseOneStmt(strings.TrimRight(strings.TrimSpace(view.Definition), ";"), "", "")
+	if err != nil {
+		return "", nil, fmt.Errorf("can't parse query: %w", err)
+	}
+	vc := &viewChecker{conv: conv, database: view.Schema, ctes: make(map[string]bool)}
+	if view.CheckOption {
+		vc.addIssue("WITH CHECK OPTION isn't supported by Spanner views")
+	}
+	stmt.Accept(vc)
+
+	var sb strings.Builder
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", nil, fmt.Errorf("can't restore query: %w", err)
+	}
+	query := sb.String()
+	result, err := assessment.QueryTranslatorImpl{Conv: conv}.TranslateQuery(query)
+	switch {
+	case err != nil:
+		vc.addIssue(fmt.Sprintf("can't translate the query: %s", err))
+		return query, vc.issues, nil
+	case result.SpannerQuery == "":
+		vc.addIssue(result.Explanation)
+		return query, vc.issues, nil
+	}
+	return result.SpannerQuery, vc.issues, nil
+}
+
+// viewChecker drops the database of the names of the query of a view, which
+// information_schema adds to them, and reports the tables and constructs
+// that Spanner views can't have.
+type viewChecker struct {
+	conv     *internal.Conv
+	database string
+	ctes     map[string]bool // Names of the common table expressions of the query.
+	issues   []string
+}
+
+func (vc *viewChecker) addIssue(issue string) {
+	for _, i := range vc.issues {
+		if i == issue {
+			return
+		}
+	}
+	vc.issues = append(vc.issues, issue)
+}
+
+// ownDatabase tells if a name is qualified with the database of the view.
+func (vc *viewChecker) ownDatabase(db model.CIStr) bool {
+	return db.O != "" && (vc.database == "" || strings.EqualFold(db.O, vc.database))
+}
+
+func (vc *viewChecker) Enter(n ast.Node) (ast.Node, bool) {
+	switch node := n.(type) {
+	case *ast.WithClause:
+		for _, cte := range node.CTEs {
+			vc.ctes[cte.Name.L] = true
+		}
+	case *ast.TableName:
+		if vc.ownDatabase(node.Schema) {
+			node.Schema = model.CIStr{}
+		}
+		if node.Schema.O == "" && !vc.ctes[node.Name.L] {
+			vc.checkTable(node.Name.O)
+		}
+	case *ast.ColumnName:
+		if vc.ownDatabase(node.Schema) {
+			node.Schema = model.CIStr{}
+		}
+	case *ast.SelectField:
+		if node.WildCard != nil {
+			if vc.ownDatabase(node.WildCard.Schema) {
+				node.WildCard.Schema = model.CIStr{}
+			}
+			vc.addIssue("SELECT * must be replaced with the list of columns")
+		}
+	}
+	return n, false
+}
+
+func (vc *viewChecker) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// checkTable reports the tables a view refers to that aren't migrated.
+// Views can refer to other views, which are migrated with them.
+func (vc *viewChecker) checkTable(name string) {
+	if tableId, err := internal.GetTableIdFromSrcName(vc.conv.SrcSchema, name); err == nil {
+		if _, ok := vc.conv.SpSchema[tableId]; !ok {
+			vc.addIssue(fmt.Sprintf("table %s isn't in the Spanner schema", name))
+		}
+		return
+	}
+	for _, view := range vc.conv.SrcViews {
+		if view.Name == name {
+			return
+		}
+	}
+	vc.addIssue(fmt.Sprintf("table %s isn't migrated to Spanner", name))
+}
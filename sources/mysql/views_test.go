@@ -0,0 +1,99 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func newViewsTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "users", Id: "t1", ColDefs: map[string]schema.Column{"c1": {Name: "id", Id: "c1"}, "c2": {Name: "name", Id: "c2"}}},
+		"t2": {Name: "audit", Id: "t2", ColDefs: map[string]schema.Column{"c3": {Name: "id", Id: "c3"}}},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "users", Id: "t1", ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "id", Id: "c1"}, "c2": {Name: "full_name", Id: "c2"}}},
+	}
+	conv.ToSpanner = map[string]internal.NameAndCols{
+		"users": {Name: "users", Cols: map[string]string{"id": "id", "name": "full_name"}},
+	}
+	conv.SrcViews = map[string]schema.View{"v1": {Name: "user-names", Id: "v1"}}
+	conv.SpViews = map[string]ddl.CreateView{"v1": {Name: "user_names", Id: "v1"}}
+	return conv
+}
+
+func TestTranslateView(t *testing.T) {
+	tests := []struct {
+		name       string
+		view       schema.View
+		wantQuery  string // Not checked when empty.
+		wantIssues []string
+	}{
+		{
+			name:      "information schema definition",
+			view:      schema.View{Name: "named_users", Schema: "db", Definition: "select `db`.`users`.`id` AS `id`,ifnull(`db`.`users`.`name`,_utf8mb4'none') AS `name` from `db`.`users`"},
+			wantQuery: "SELECT `users`.`id` AS `id`, COALESCE(`users`.`full_name`, 'none') AS `name` FROM `users`",
+		},
+		{
+			name:      "view of a view with a common table expression",
+			view:      schema.View{Name: "first_names", Definition: "WITH n AS (SELECT `id` FROM `user-names`) SELECT `id` FROM n LIMIT 1;"},
+			wantQuery: "WITH `n` AS (SELECT `id` FROM `user_names`) SELECT `id` FROM `n` LIMIT 1",
+		},
+		{
+			name: "untranslatable constructs",
+			view: schema.View{Name: "report", Definition: "SELECT *, MD5(name) FROM users, archive, audit", CheckOption: true},
+			wantIssues: []string{
+				"WITH CHECK OPTION isn't supported by Spanner views",
+				"SELECT * must be replaced with the list of columns",
+				"table archive isn't migrated to Spanner",
+				"table audit isn't in the Spanner schema",
+				"can't translate the query: function MD5 not supported",
+			},
+		},
+		{
+			name:       "table of another database",
+			view:       schema.View{Name: "other", Schema: "db", Definition: "SELECT id FROM other_db.users"},
+			wantQuery:  "SELECT `id` FROM `other_db`.`users`",
+			wantIssues: []string{"can't translate the query: table other_db.users of another database not supported"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, issues, err := ToDdlImpl{}.TranslateView(newViewsTestConv(), tc.view)
+
+			assert.NoError(t, err)
+			if tc.wantQuery != "" {
+				// Compare without whitespace, which depends on how the parser prints the query.
+				assert.Equal(t, strings.Join(strings.Fields(tc.wantQuery), ""), strings.Join(strings.Fields(query), ""))
+			}
+			assert.ElementsMatch(t, tc.wantIssues, issues)
+		})
+	}
+}
+
+func TestTranslateView_Unparsable(t *testing.T) {
+	_, _, err := ToDdlImpl{}.TranslateView(newViewsTestConv(), schema.View{Name: "broken", Definition: "SELECT 'abc FROM users"})
+
+	assert.Error(t, err)
+}
@@ -34,6 +34,12 @@ import (
 
 func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, vals []string) {
 	spTableName, cvtCols, cvtVals, err := convertData(conv, tableId, colIds, srcSchema, spSchema, vals)
+	writeDataRow(conv, colIds, srcSchema, vals, spTableName, cvtCols, cvtVals, err)
+}
+
+// writeDataRow writes out a row converted by convertData, or records it as a
+// bad row if err, the error of its conversion, is set.
+func writeDataRow(conv *internal.Conv, colIds []string, srcSchema schema.Table, vals []string, spTableName string, cvtCols []string, cvtVals []interface{}, err error) {
 	srcTableName := srcSchema.Name
 	srcCols := []string{}
 	for _, colId := range colIds {
@@ -42,7 +48,7 @@ func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSch
 	if err != nil {
 		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 		conv.StatsAddBadRow(srcTableName, conv.DataMode())
-		conv.CollectBadRow(srcTableName, srcCols, vals)
+		conv.CollectBadRow(srcTableName, srcCols, vals, err)
 	} else {
 		conv.WriteRow(srcTableName, spTableName, cvtCols, cvtVals)
 	}
@@ -76,7 +82,7 @@ func convertData(conv *internal.Conv, tableId string, colIds []string, srcSchema
 		if spColDef.T.IsArray {
 			x, err = convArray(spColDef.T, srcColDef.Type.Name, vals[i])
 		} else {
-			x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.TimezoneOffset, vals[i])
+			x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.TimezoneOffset, conv.TimezoneLocation(tableId, colId), vals[i])
 		}
 		if err != nil {
 			return "", []string{}, []interface{}{}, err
@@ -97,7 +103,7 @@ func convertData(conv *internal.Conv, tableId string, colIds []string, srcSchema
 // appropriate Spanner value. It is the caller's responsibility to
 // detect and handle NULL values: convScalar will return error if a
 // NULL value is passed.
-func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, TimezoneOffset string, val string) (interface{}, error) {
+func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, TimezoneOffset string, location *time.Location, val string) (interface{}, error) {
 	// Whitespace within the val string is considered part of the data value.
 	// Note that many of the underlying conversions functions we use (like
 	// strconv.ParseFloat and strconv.ParseInt) return "invalid syntax"
@@ -120,7 +126,7 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, T
 	case ddl.String:
 		return val, nil
 	case ddl.Timestamp:
-		return convTimestamp(srcTypeName, val)
+		return convTimestamp(srcTypeName, location, val)
 	case ddl.JSON:
 		if srcTypeName == "OBJECT" {
 			return convertXmlToJson(val)
@@ -198,8 +204,10 @@ func convNumeric(conv *internal.Conv, val string) (interface{}, error) {
 	}
 }
 
-// convTimestamp maps a source DB timestamp into a go Time Spanner timestamp
-func convTimestamp(srcTypeName string, val string) (t time.Time, err error) {
+// convTimestamp maps a source DB timestamp into a go Time Spanner timestamp.
+// The values of the types without a timezone are interpreted in location, the
+// location of the timezone policy of the column, if it isn't nil.
+func convTimestamp(srcTypeName string, location *time.Location, val string) (t time.Time, err error) {
 	// we are getting all timestamp value in UTC from the oracle.
 	// e.g. 2022-02-01T08:14:36.254Z 			(timestamp)
 	// e.g. 2022-02-01T12:14:36.254Z 		    (timestamp with timezone)
@@ -209,6 +217,10 @@ func convTimestamp(srcTypeName string, val string) (t time.Time, err error) {
 	if err != nil {
 		return t, fmt.Errorf("can't convert to timestamp (type: %s)", srcTypeName)
 	}
+	if location != nil && !strings.Contains(srcTypeName, "TIME ZONE") {
+		// Values without a timezone are extracted as UTC.
+		t = internal.InLocation(t, location)
+	}
 	return t, err
 }
 
@@ -134,6 +134,31 @@ func TestConvertData(t *testing.T) {
 	}
 }
 
+func TestConvertData_TimezonePolicy(t *testing.T) {
+	colIds := []string{"c1", "c2", "c3"}
+	conv := buildConv(
+		ddl.CreateTable{Name: "t", Id: "t1", ColIds: colIds, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Timestamp}},
+			"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+			"c3": {Name: "c", Id: "c3", T: ddl.Type{Name: ddl.Date}},
+		}},
+		schema.Table{Name: "t", Id: "t1", ColIds: colIds, ColDefs: map[string]schema.Column{
+			"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "TIMESTAMP(6)"}},
+			"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "TIMESTAMP(6) WITH TIME ZONE"}},
+			"c3": {Name: "c", Id: "c3", Type: schema.Type{Name: "TIMESTAMP(6)"}},
+		}})
+	conv.SetTimezonePolicy("t1", "", internal.TimezonePolicy{Mode: internal.TimezoneZone, Location: "Asia/Kolkata"})
+	conv.SetTimezonePolicy("t1", "c3", internal.TimezonePolicy{Mode: internal.TimezoneDate})
+	vals := []string{"2022-01-19T09:34:06.47Z", "2022-01-19T09:34:06.47Z", "2022-01-19T09:34:06.47Z"}
+	_, ac, av, err := convertData(conv, "t1", colIds, conv.SrcSchema["t1"], conv.SpSchema["t1"], vals)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, ac)
+	assert.True(t, getTime("2022-01-19T04:04:06.47Z").Equal(av[0].(time.Time)))
+	// Values with a timezone keep it.
+	assert.True(t, getTime("2022-01-19T09:34:06.47Z").Equal(av[1].(time.Time)))
+	assert.Equal(t, getDate("2022-01-19"), av[2])
+}
+
 func TestConvertsyntheticPKey(t *testing.T) {
 	syntheticPKeyTests := []struct {
 		name   string
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -107,6 +108,44 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 	}
 	rows := rowsInterface.(*sql.Rows)
 	defer rows.Close()
+	return processRows(conv, tableId, srcSchema, commonColIds, spSchema, rows, &sync.Mutex{})
+}
+
+// GetKeyBounds returns the smallest and largest value of an integer column.
+func (isi InfoSchemaImpl) GetKeyBounds(conv *internal.Conv, tableId string, colId string) (int64, int64, bool, error) {
+	srcSchema := conv.SrcSchema[tableId]
+	colName := srcSchema.ColDefs[colId].Name
+	q := fmt.Sprintf(`SELECT MIN("%s"), MAX("%s") FROM "%s"."%s"`, colName, colName, srcSchema.Schema, srcSchema.Name)
+	var min, max sql.NullInt64
+	err := isi.Db.QueryRow(q).Scan(&min, &max)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return min.Int64, max.Int64, min.Valid && max.Valid, nil
+}
+
+// ProcessDataChunk performs data conversion for the rows of a table in keyRange.
+func (isi InfoSchemaImpl) ProcessDataChunk(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, keyRange common.KeyRange, mutex *sync.Mutex, additionalAttributes internal.AdditionalDataAttributes) error {
+	where, args := keyRange.WhereClause(fmt.Sprintf(`"%s"`, srcSchema.ColDefs[keyRange.ColId].Name), func(i int) string { return fmt.Sprintf(":%d", i) })
+	q := getSelectQuery(isi.DbName, srcSchema.Schema, srcSchema.Name, srcSchema.ColIds, srcSchema.ColDefs) + " WHERE " + where
+	rows, err := isi.Db.Query(q, args...)
+	if err != nil {
+		mutex.Lock()
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		mutex.Unlock()
+		return err
+	}
+	defer rows.Close()
+	return processRows(conv, tableId, srcSchema, commonColIds, spSchema, rows, mutex)
+}
+
+// processRows converts the rows returned by a query on a source table and
+// writes them out. Rows are scanned and converted without holding mutex; the
+// updates of the stats of conv and the writes hold it. It returns the error,
+// if any, that ended the iteration, e.g. a dropped connection, so that a
+// partial read isn't taken for a complete one.
+func processRows(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, rows *sql.Rows, mutex *sync.Mutex) error {
+	srcTableName := conv.SrcSchema[tableId].Name
 	srcCols, _ := rows.Columns()
 	v, scanArgs := buildVals(len(srcCols))
 	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
@@ -114,22 +153,29 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 		// get RawBytes from data.
 		err := rows.Scan(scanArgs...)
 		if err != nil {
+			mutex.Lock()
 			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
 			// Scan failed, so we don't have any data to add to bad rows.
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
+			mutex.Unlock()
 			continue
 		}
 		values := valsToStrings(v)
 		newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
 		if err != nil {
+			mutex.Lock()
 			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
-			conv.CollectBadRow(srcTableName, srcCols, values)
+			conv.CollectBadRow(srcTableName, srcCols, values, err)
+			mutex.Unlock()
 			continue
 		}
-		ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+		spTableName, cvtCols, cvtVals, err := convertData(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+		mutex.Lock()
+		writeDataRow(conv, commonColIds, srcSchema, newValues, spTableName, cvtCols, cvtVals, err)
+		mutex.Unlock()
 	}
-	return nil
+	return rows.Err()
 }
 
 // GetRowCount with number of rows in each table.
@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parquet reads the rows of Parquet files, so that they can be
+// imported into Spanner. It supports files with top level columns of
+// primitive types and lists of primitive types. Files are decoded with
+// github.com/parquet-go/parquet-go, which reads the column chunks of a file
+// with random access, one row group at a time.
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/parquet-go/parquet-go"
+)
+
+const (
+	// rowBufferSize is the number of rows decoded at a time.
+	rowBufferSize = 256
+	// readBufferSize is the size of the reads of column chunks, so that files
+	// in GCS are read with few range requests.
+	readBufferSize = 1 << 20
+)
+
+// Reader reads the rows of a Parquet file.
+type Reader struct {
+	rows    *parquet.Reader
+	columns []*column
+	buf     []parquet.Row
+	n       int   // Number of rows in buf.
+	next    int   // Index of the next row of buf.
+	err     error // Error that ended the last read into buf, returned once buf is consumed.
+}
+
+// NewReader returns a Reader for the Parquet file r of the given size. Only
+// the footer is read; the rows are read when needed.
+func NewReader(r io.ReaderAt, size int64) (pr *Reader, err error) {
+	// The library panics on some malformed schemas.
+	defer func() {
+		if p := recover(); p != nil {
+			pr, err = nil, fmt.Errorf("invalid Parquet file: %v", p)
+		}
+	}()
+	// The page indexes and bloom filters aren't needed to read all the rows.
+	f, err := parquet.OpenFile(r, size, parquet.ReadBufferSize(readBufferSize), parquet.SkipPageIndex(true), parquet.SkipBloomFilters(true))
+	if err != nil {
+		return nil, fmt.Errorf("can't open Parquet file: %v", err)
+	}
+	columns, err := buildColumns(f.Schema(), f.Metadata().Schema)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		rows:    parquet.NewReader(f),
+		columns: columns,
+		buf:     make([]parquet.Row, rowBufferSize),
+	}, nil
+}
+
+// Columns returns the columns of the file.
+func (pr *Reader) Columns() []common.FileColumn {
+	var cols []common.FileColumn
+	for _, c := range pr.columns {
+		cols = append(cols, c.FileColumn)
+	}
+	return cols
+}
+
+// Read returns the values of the next row of the file, or io.EOF if there
+// are no rows left.
+func (pr *Reader) Read() ([]interface{}, error) {
+	if pr.next == pr.n {
+		if pr.err != nil {
+			return nil, pr.err
+		}
+		pr.n, pr.err = pr.rows.ReadRows(pr.buf)
+		pr.next = 0
+		if pr.err != nil && pr.err != io.EOF {
+			pr.err = fmt.Errorf("can't read Parquet rows: %v", pr.err)
+		}
+		if pr.n == 0 {
+			if pr.err == nil {
+				pr.err = io.EOF
+			}
+			return nil, pr.err
+		}
+	}
+	values := make([][]parquet.Value, len(pr.columns))
+	pr.buf[pr.next].Range(func(i int, vs []parquet.Value) bool {
+		if i < len(values) {
+			values[i] = vs
+		}
+		return true
+	})
+	pr.next++
+	row := make([]interface{}, len(pr.columns))
+	for i, col := range pr.columns {
+		v, err := col.value(values[col.index])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", col.Name, err)
+		}
+		row[i] = v
+	}
+	return row, nil
+}
+
+// value returns the value of col in a row, given the values of its leaf
+// column in the row.
+func (col *column) value(values []parquet.Value) (interface{}, error) {
+	if !col.isList {
+		if len(values) != 1 {
+			return nil, fmt.Errorf("expected 1 value, found %d", len(values))
+		}
+		if values[0].IsNull() {
+			return nil, nil
+		}
+		return col.convert(values[0])
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("expected at least 1 value, found none")
+	}
+	// Null and empty lists are stored as a single value whose definition
+	// level is below that of the elements.
+	if d := values[0].DefinitionLevel(); len(values) == 1 && d <= col.listDef {
+		if d < col.listDef {
+			return nil, nil
+		}
+		return []interface{}{}, nil
+	}
+	list := make([]interface{}, len(values))
+	for i, v := range values {
+		if v.IsNull() {
+			continue
+		}
+		var err error
+		if list[i], err = col.convert(v); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeFile returns a Parquet file with the given schema and rows, written
+// with row groups of at most two rows.
+func writeFile(t *testing.T, schema *parquet.Schema, rows []parquet.Row, codec compress.Codec) []byte {
+	var buf bytes.Buffer
+	w := parquet.NewWriter(&buf, schema, parquet.Compression(codec), parquet.MaxRowsPerRowGroup(2))
+	_, err := w.WriteRows(rows)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	return buf.Bytes()
+}
+
+func readAll(t *testing.T, r *Reader) [][]interface{} {
+	var rows [][]interface{}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rows
+		}
+		if !assert.Nil(t, err) {
+			return rows
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestReader(t *testing.T) {
+	// The fields of a group are sorted by name.
+	schema := parquet.NewSchema("test", parquet.Group{
+		"day":   parquet.Date(),
+		"id":    parquet.Leaf(parquet.Int64Type),
+		"name":  parquet.Optional(parquet.String()),
+		"price": parquet.Decimal(2, 10, parquet.Int64Type),
+		"score": parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+		"tags":  parquet.Optional(parquet.List(parquet.Optional(parquet.Int(32)))),
+		"ts":    parquet.Optional(parquet.Timestamp(parquet.Microsecond)),
+	})
+	rows := []parquet.Row{
+		{
+			parquet.Int32Value(0).Level(0, 0, 0),
+			parquet.Int64Value(1).Level(0, 0, 1),
+			parquet.ByteArrayValue([]byte("a")).Level(0, 1, 2),
+			parquet.Int64Value(12345).Level(0, 0, 3),
+			parquet.DoubleValue(1.5).Level(0, 1, 4),
+			parquet.Int32Value(7).Level(0, 3, 5),
+			parquet.NullValue().Level(1, 2, 5),
+			parquet.Int32Value(8).Level(1, 3, 5),
+			parquet.Int64Value(1700000000123456).Level(0, 1, 6),
+		},
+		{
+			parquet.Int32Value(19000).Level(0, 0, 0),
+			parquet.Int64Value(2).Level(0, 0, 1),
+			parquet.NullValue().Level(0, 0, 2),
+			parquet.Int64Value(-5).Level(0, 0, 3),
+			parquet.DoubleValue(-2.25).Level(0, 1, 4),
+			parquet.NullValue().Level(0, 0, 5),
+			parquet.NullValue().Level(0, 0, 6),
+		},
+		{
+			parquet.Int32Value(-1).Level(0, 0, 0),
+			parquet.Int64Value(3).Level(0, 0, 1),
+			parquet.ByteArrayValue([]byte("c")).Level(0, 1, 2),
+			parquet.Int64Value(0).Level(0, 0, 3),
+			parquet.NullValue().Level(0, 0, 4),
+			parquet.NullValue().Level(0, 1, 5),
+			parquet.NullValue().Level(0, 0, 6),
+		},
+	}
+	for _, codec := range []compress.Codec{&parquet.Uncompressed, &parquet.Snappy, &parquet.Gzip, &parquet.Zstd} {
+		file := writeFile(t, schema, rows, codec)
+		r, err := NewReader(bytes.NewReader(file), int64(len(file)))
+		if !assert.Nil(t, err, codec.String()) {
+			continue
+		}
+		assert.Equal(t, []common.FileColumn{
+			{Name: "day", Type: ddl.Type{Name: ddl.Date}, NotNull: true},
+			{Name: "id", Type: ddl.Type{Name: ddl.Int64}, NotNull: true},
+			{Name: "name", Type: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			{Name: "price", Type: ddl.Type{Name: ddl.Numeric}, NotNull: true},
+			{Name: "score", Type: ddl.Type{Name: ddl.Float64}},
+			{Name: "tags", Type: ddl.Type{Name: ddl.Int64, IsArray: true}},
+			{Name: "ts", Type: ddl.Type{Name: ddl.Timestamp}},
+		}, r.Columns())
+
+		got := readAll(t, r)
+		// Compare decimals by value.
+		for _, row := range got {
+			row[3] = row[3].(*big.Rat).FloatString(2)
+		}
+		assert.Equal(t, [][]interface{}{
+			{civil.Date{Year: 1970, Month: 1, Day: 1}, int64(1), "a", "123.45", 1.5, []interface{}{int64(7), nil, int64(8)}, time.UnixMicro(1700000000123456).UTC()},
+			{civil.Date{Year: 2022, Month: 1, Day: 8}, int64(2), nil, "-0.05", -2.25, nil, nil},
+			{civil.Date{Year: 1969, Month: 12, Day: 31}, int64(3), "c", "0.00", nil, []interface{}{}, nil},
+		}, got, codec.String())
+	}
+}
+
+func TestReader_RepeatedPrimitive(t *testing.T) {
+	schema := parquet.NewSchema("test", parquet.Group{"labels": parquet.Repeated(parquet.String())})
+	file := writeFile(t, schema, []parquet.Row{
+		{parquet.ByteArrayValue([]byte("x")).Level(0, 1, 0), parquet.ByteArrayValue([]byte("y")).Level(1, 1, 0)},
+		{parquet.NullValue().Level(0, 0, 0)},
+	}, &parquet.Uncompressed)
+	r, err := NewReader(bytes.NewReader(file), int64(len(file)))
+	assert.Nil(t, err)
+	assert.Equal(t, []common.FileColumn{{Name: "labels", Type: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, NotNull: true}}, r.Columns())
+	assert.Equal(t, [][]interface{}{{[]interface{}{"x", "y"}}, {[]interface{}{}}}, readAll(t, r))
+}
+
+func TestReader_ManyRows(t *testing.T) {
+	// The rows span several row groups and reads of the row buffer.
+	schema := parquet.NewSchema("test", parquet.Group{"data": parquet.Leaf(parquet.ByteArrayType)})
+	var rows []parquet.Row
+	var want [][]interface{}
+	for i := 0; i < rowBufferSize+3; i++ {
+		b := []byte{byte(i), byte(i >> 8)}
+		rows = append(rows, parquet.Row{parquet.ByteArrayValue(b).Level(0, 0, 0)})
+		want = append(want, []interface{}{b})
+	}
+	file := writeFile(t, schema, rows, &parquet.Snappy)
+	r, err := NewReader(bytes.NewReader(file), int64(len(file)))
+	assert.Nil(t, err)
+	assert.Equal(t, want, readAll(t, r))
+}
+
+func TestReader_Errors(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("PAR1")), 4)
+	assert.NotNil(t, err)
+
+	_, err = NewReader(bytes.NewReader([]byte("PAR1\x00\x00\x00\x00PAR2")), 12)
+	assert.NotNil(t, err)
+
+	// Structs can't be imported.
+	file := writeFile(t, parquet.NewSchema("test", parquet.Group{
+		"address": parquet.Optional(parquet.Group{"city": parquet.String()}),
+	}), nil, &parquet.Uncompressed)
+	_, err = NewReader(bytes.NewReader(file), int64(len(file)))
+	assert.ErrorContains(t, err, "column address has a nested type")
+
+	file = writeFile(t, parquet.NewSchema("test", parquet.Group{
+		"addresses": parquet.List(parquet.Group{"city": parquet.String(), "zip": parquet.String()}),
+	}), nil, &parquet.Uncompressed)
+	_, err = NewReader(bytes.NewReader(file), int64(len(file)))
+	assert.ErrorContains(t, err, "column addresses is a list of a nested type")
+
+	// A file that is cut short.
+	file = writeFile(t, parquet.NewSchema("test", parquet.Group{"id": parquet.Leaf(parquet.Int64Type)}),
+		[]parquet.Row{{parquet.Int64Value(1).Level(0, 0, 0)}}, &parquet.Uncompressed)
+	_, err = NewReader(bytes.NewReader(file[:len(file)-10]), int64(len(file)-10))
+	assert.NotNil(t, err)
+}
+
+func TestLeafType(t *testing.T) {
+	var int96 deprecated.Int96
+	nanos := int64(time.Hour)
+	int96[0], int96[1], int96[2] = uint32(nanos), uint32(nanos>>32), julianUnixEpoch+1
+	uuid := []byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	tests := []struct {
+		name string
+		t    parquet.Type
+		v    parquet.Value
+		want ddl.Type
+		val  interface{}
+	}{
+		{"int32", parquet.Int32Type, parquet.Int32Value(-4), ddl.Type{Name: ddl.Int64}, int64(-4)},
+		{"uint32", parquet.Uint(32).Type(), parquet.Int32Value(-1), ddl.Type{Name: ddl.Int64}, int64(4294967295)},
+		{"bool", parquet.BooleanType, parquet.BooleanValue(true), ddl.Type{Name: ddl.Bool}, true},
+		{"float", parquet.FloatType, parquet.FloatValue(1.5), ddl.Type{Name: ddl.Float32}, float32(1.5)},
+		{"bytes", parquet.ByteArrayType, parquet.ByteArrayValue([]byte{1}), ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, []byte{1}},
+		{"enum", parquet.Enum().Type(), parquet.ByteArrayValue([]byte("RED")), ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "RED"},
+		{"json", parquet.JSON().Type(), parquet.ByteArrayValue([]byte(`{"a":1}`)), ddl.Type{Name: ddl.JSON}, `{"a":1}`},
+		{"uuid", parquet.UUID().Type(), parquet.FixedLenByteArrayValue(uuid), ddl.Type{Name: ddl.String, Len: 36}, "123e4567-e89b-12d3-a456-426614174000"},
+		{"time", parquet.Time(parquet.Microsecond).Type(), parquet.Int64Value(3723000001), ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "01:02:03.000001"},
+		{"timestamp millis", parquet.Timestamp(parquet.Millisecond).Type(), parquet.Int64Value(1000), ddl.Type{Name: ddl.Timestamp}, time.Unix(1, 0).UTC()},
+		{"int96", parquet.Int96Type, parquet.Int96Value(int96), ddl.Type{Name: ddl.Timestamp}, time.Unix(24*60*60+60*60, 0).UTC()},
+	}
+	for _, tc := range tests {
+		ty, convert, err := leafType(tc.t)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, ty, tc.name)
+		v, err := convert(tc.v)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.val, v, tc.name)
+	}
+
+	ty, convert, err := leafType(parquet.Decimal(1, 4, parquet.FixedLenByteArrayType(2)).Type())
+	assert.Nil(t, err)
+	assert.Equal(t, ddl.Type{Name: ddl.Numeric}, ty)
+	v, err := convert(parquet.FixedLenByteArrayValue([]byte{0xff, 0x85}))
+	assert.Nil(t, err)
+	assert.Equal(t, "-12.3", v.(*big.Rat).FloatString(1))
+
+	ty, convert, err = leafType(parquet.Uint(64).Type())
+	assert.Nil(t, err)
+	assert.Equal(t, ddl.Type{Name: ddl.Numeric}, ty)
+	v, err = convert(parquet.Int64Value(-1))
+	assert.Nil(t, err)
+	assert.Equal(t, "18446744073709551615", v.(*big.Rat).FloatString(0))
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// julianUnixEpoch is the Julian day number of 1970-01-01, which INT96
+// timestamps are relative to.
+const julianUnixEpoch = 2440588
+
+// column is a top level field of a Parquet file that maps to a Spanner
+// column. Only primitive fields and lists of primitive values are supported,
+// so each column has a single leaf column in the file.
+type column struct {
+	common.FileColumn
+	index   int // Index of the leaf column in the rows of the file.
+	listDef int // Definition level of an empty list; only used for lists.
+	isList  bool
+	convert func(parquet.Value) (interface{}, error)
+}
+
+// buildColumns returns the columns of a file with the given schema. The
+// library maps the types of elements that aren't supported, such as INTERVAL
+// and FLOAT16, to plain byte arrays, so they are rejected using the schema
+// elements of the file's metadata.
+func buildColumns(s *parquet.Schema, elems []format.SchemaElement) ([]*column, error) {
+	for _, e := range elems {
+		if e.ConvertedType != nil && *e.ConvertedType == deprecated.Interval {
+			return nil, fmt.Errorf("column %s: unsupported logical type INTERVAL", e.Name)
+		}
+		if e.LogicalType != nil && e.LogicalType.Float16 != nil {
+			return nil, fmt.Errorf("column %s: unsupported logical type FLOAT16", e.Name)
+		}
+	}
+	var cols []*column
+	for _, f := range s.Fields() {
+		col, err := buildColumn(s, f)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+func buildColumn(s *parquet.Schema, f parquet.Field) (*column, error) {
+	col := &column{FileColumn: common.FileColumn{Name: f.Name()}}
+	path := []string{f.Name()}
+	leaf := parquet.Node(f)
+	switch {
+	case f.Leaf() && f.Repeated():
+		// A repeated primitive field is a list of required values.
+		col.isList = true
+		col.NotNull = true
+	case f.Leaf():
+		col.NotNull = f.Required()
+	case isList(f) && len(f.Fields()) == 1 && f.Fields()[0].Repeated():
+		// A list is a group with a repeated child, which either is the
+		// element or has the element as its only child.
+		repeated := f.Fields()[0]
+		path = append(path, repeated.Name())
+		leaf = repeated
+		if !repeated.Leaf() {
+			elems := repeated.Fields()
+			if len(elems) != 1 || !elems[0].Leaf() || elems[0].Repeated() {
+				return nil, fmt.Errorf("column %s is a list of a nested type, which can't be imported", f.Name())
+			}
+			leaf = elems[0]
+			path = append(path, elems[0].Name())
+		}
+		col.isList = true
+		if f.Optional() {
+			col.listDef = 1
+		}
+		col.NotNull = f.Required()
+	default:
+		return nil, fmt.Errorf("column %s has a nested type, which can't be imported", f.Name())
+	}
+	lc, ok := s.Lookup(path...)
+	if !ok {
+		return nil, fmt.Errorf("column %s not found in the schema", f.Name())
+	}
+	col.index = lc.ColumnIndex
+	t, convert, err := leafType(leaf.Type())
+	if err != nil {
+		return nil, fmt.Errorf("column %s: %v", f.Name(), err)
+	}
+	t.IsArray = col.isList
+	col.Type, col.convert = t, convert
+	return col, nil
+}
+
+func isList(n parquet.Node) bool {
+	lt := n.Type().LogicalType()
+	return lt != nil && lt.List != nil
+}
+
+// leafType returns the Spanner type of the primitive type t, and a function
+// that converts its values to Go values of that type.
+func leafType(t parquet.Type) (ddl.Type, func(parquet.Value) (interface{}, error), error) {
+	lt := t.LogicalType()
+	if lt == nil {
+		lt = &format.LogicalType{}
+	}
+	kind := t.Kind()
+	str := ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+	switch {
+	case lt.UTF8 != nil || lt.Enum != nil:
+		if kind == parquet.ByteArray || kind == parquet.FixedLenByteArray {
+			return str, func(v parquet.Value) (interface{}, error) { return string(v.ByteArray()), nil }, nil
+		}
+	case lt.Json != nil:
+		if kind == parquet.ByteArray {
+			return ddl.Type{Name: ddl.JSON}, func(v parquet.Value) (interface{}, error) { return string(v.ByteArray()), nil }, nil
+		}
+	case lt.UUID != nil:
+		if kind == parquet.FixedLenByteArray && t.Length() == 16 {
+			return ddl.Type{Name: ddl.String, Len: 36}, func(v parquet.Value) (interface{}, error) { return formatUUID(v.ByteArray()), nil }, nil
+		}
+	case lt.Decimal != nil:
+		scale := int(lt.Decimal.Scale)
+		return ddl.Type{Name: ddl.Numeric}, func(v parquet.Value) (interface{}, error) { return decimal(v, scale) }, nil
+	case lt.Date != nil:
+		if kind == parquet.Int32 {
+			return ddl.Type{Name: ddl.Date}, func(v parquet.Value) (interface{}, error) {
+				return civil.DateOf(time.Unix(int64(v.Int32())*24*60*60, 0).UTC()), nil
+			}, nil
+		}
+	case lt.Time != nil:
+		unit := timeUnit(lt.Time.Unit)
+		return str, func(v parquet.Value) (interface{}, error) {
+			d := time.Duration(toInt64(v)) * unit
+			return time.Time{}.Add(d).Format("15:04:05.999999999"), nil
+		}, nil
+	case lt.Timestamp != nil:
+		unit := timeUnit(lt.Timestamp.Unit)
+		if kind == parquet.Int64 {
+			return ddl.Type{Name: ddl.Timestamp}, func(v parquet.Value) (interface{}, error) {
+				switch n := v.Int64(); unit {
+				case time.Millisecond:
+					return time.UnixMilli(n).UTC(), nil
+				case time.Microsecond:
+					return time.UnixMicro(n).UTC(), nil
+				default:
+					return time.Unix(0, n).UTC(), nil
+				}
+			}, nil
+		}
+	case lt.Integer != nil && !lt.Integer.IsSigned && lt.Integer.BitWidth == 64:
+		return ddl.Type{Name: ddl.Numeric}, func(v parquet.Value) (interface{}, error) {
+			return new(big.Rat).SetUint64(v.Uint64()), nil
+		}, nil
+	case lt.Integer != nil && !lt.Integer.IsSigned:
+		if kind == parquet.Int32 {
+			return ddl.Type{Name: ddl.Int64}, func(v parquet.Value) (interface{}, error) { return int64(v.Uint32()), nil }, nil
+		}
+	}
+	switch kind {
+	case parquet.Boolean:
+		return ddl.Type{Name: ddl.Bool}, func(v parquet.Value) (interface{}, error) { return v.Boolean(), nil }, nil
+	case parquet.Int32:
+		return ddl.Type{Name: ddl.Int64}, func(v parquet.Value) (interface{}, error) { return int64(v.Int32()), nil }, nil
+	case parquet.Int64:
+		return ddl.Type{Name: ddl.Int64}, func(v parquet.Value) (interface{}, error) { return v.Int64(), nil }, nil
+	case parquet.Int96:
+		return ddl.Type{Name: ddl.Timestamp}, func(v parquet.Value) (interface{}, error) {
+			i := v.Int96()
+			nanos := i.Int64()
+			days := int64(i[2])
+			return time.Unix((days-julianUnixEpoch)*24*60*60, nanos).UTC(), nil
+		}, nil
+	case parquet.Float:
+		return ddl.Type{Name: ddl.Float32}, func(v parquet.Value) (interface{}, error) { return v.Float(), nil }, nil
+	case parquet.Double:
+		return ddl.Type{Name: ddl.Float64}, func(v parquet.Value) (interface{}, error) { return v.Double(), nil }, nil
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		// The reader reuses its buffers, so byte arrays must be copied.
+		return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, func(v parquet.Value) (interface{}, error) { return bytes.Clone(v.ByteArray()), nil }, nil
+	}
+	return ddl.Type{}, nil, fmt.Errorf("unknown physical type %s", kind)
+}
+
+// timeUnit returns the duration of the time unit u.
+func timeUnit(u format.TimeUnit) time.Duration {
+	switch {
+	case u.Micros != nil:
+		return time.Microsecond
+	case u.Nanos != nil:
+		return time.Nanosecond
+	}
+	return time.Millisecond
+}
+
+func toInt64(v parquet.Value) int64 {
+	if v.Kind() == parquet.Int32 {
+		return int64(v.Int32())
+	}
+	return v.Int64()
+}
+
+// decimal returns the DECIMAL value with unscaled value v, which is an
+// integer or a big-endian two's complement byte array.
+func decimal(v parquet.Value, scale int) (interface{}, error) {
+	var n *big.Int
+	switch v.Kind() {
+	case parquet.Int32:
+		n = big.NewInt(int64(v.Int32()))
+	case parquet.Int64:
+		n = big.NewInt(v.Int64())
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		b := v.ByteArray()
+		n = new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+	default:
+		return nil, fmt.Errorf("can't convert %s to decimal", v.Kind())
+	}
+	d := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	return new(big.Rat).SetFrac(n, d), nil
+}
+
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
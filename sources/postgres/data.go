@@ -28,6 +28,8 @@ import (
 	"cloud.google.com/go/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
 
@@ -46,7 +48,7 @@ func ProcessDataRow(conv *internal.Conv, tableId string, colIds, vals []string)
 	if err != nil {
 		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 		conv.StatsAddBadRow(srcTableName, conv.DataMode())
-		conv.CollectBadRow(srcTableName, srcCols, vals)
+		conv.CollectBadRow(srcTableName, srcCols, vals, err)
 	} else {
 		conv.WriteRow(srcTableName, spTableName, spCols, spVals)
 	}
@@ -86,10 +88,11 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, vals []st
 		}
 		var x interface{}
 		var err error
+		location := timestampLocation(conv, tableId, colId, srcColDef.Type.Name)
 		if spColDef.T.IsArray {
-			x, err = convArray(spColDef.T, srcColDef.Type.Name, conv.Location, vals[i])
+			x, err = convArray(spColDef.T, srcColDef.Type.Name, location, vals[i])
 		} else {
-			x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.Location, vals[i])
+			x, err = convScalar(conv, spColDef.T, srcColDef.Type, location, vals[i])
 		}
 		if err != nil {
 			return "", []string{}, []interface{}{}, err
@@ -110,7 +113,7 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, vals []st
 // appropriate Spanner value. It is the caller's responsibility to
 // detect and handle NULL values: convScalar will return error if a
 // NULL value is passed.
-func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, location *time.Location, val string) (interface{}, error) {
+func convScalar(conv *internal.Conv, spannerType ddl.Type, srcType schema.Type, location *time.Location, val string) (interface{}, error) {
 	// Whitespace within the val string is considered part of the data value.
 	// Note that many of the underlying conversions functions we use (like
 	// strconv.ParseFloat and strconv.ParseInt) return "invalid syntax"
@@ -134,9 +137,13 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, l
 	case ddl.String:
 		return val, nil
 	case ddl.Timestamp:
-		return convTimestamp(srcTypeName, location, val)
+		return convTimestamp(srcType.Name, location, val)
 	case ddl.JSON:
 		return val, nil
+	case ddl.Enum:
+		return ddl.EnumNumber(srcType.EnumValues, val)
+	case ddl.UUID:
+		return common.ConvertUUID(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
@@ -162,7 +169,9 @@ func convBytes(val string) ([]byte, error) {
 }
 
 func convDate(val string) (civil.Date, error) {
-	d, err := civil.ParseDate(val)
+	// Timestamps converted to dates, e.g. by a DATE timezone policy, keep
+	// just their date.
+	d, err := civil.ParseDate(strings.SplitN(val, " ", 2)[0])
 	if err != nil {
 		return d, fmt.Errorf("can't convert to date: %w", err)
 	}
@@ -207,9 +216,23 @@ func convNumeric(conv *internal.Conv, val string) (interface{}, error) {
 	}
 }
 
+// timestampLocation returns the location in which the timestamps without a
+// timezone of a column are interpreted: the location of its timezone policy
+// if it has one, conv.Location for timestamptz and UTC for timestamp.
+func timestampLocation(conv *internal.Conv, tableId, colId, srcTypeName string) *time.Location {
+	if location := conv.TimezoneLocation(tableId, colId); location != nil {
+		return location
+	}
+	if srcTypeName == "timestamptz" || srcTypeName == "timestamp with time zone" {
+		return conv.Location
+	}
+	return time.UTC
+}
+
 // convTimestamp maps a source DB timestamp into a go Time (which
 // is translated to a Spanner timestamp by the go Spanner client library).
-// It handles both timestamptz and timestamp conversions.
+// It handles both timestamptz and timestamp conversions. Timestamps without
+// a timezone are interpreted in location, see timestampLocation.
 // Note that PostgreSQL supports a wide variety of different timestamp
 // formats (see https://www.postgresql.org/docs/9.1/datatype-datetime.html).
 // We don't attempt to support all of these timestamp formats. Our goal
@@ -237,9 +260,9 @@ func convTimestamp(srcTypeName string, location *time.Location, val string) (t t
 		}
 	} else {
 		// timestamp without time zone: data should just consist of date and time.
-		// timestamp conversion should ignore timezone. We mimic this using Parse
-		// i.e. treat it as UTC, so it will be stored 'as-is' in Spanner.
-		t, err = time.Parse("2006-01-02 15:04:05", val)
+		// timestamp conversion should ignore timezone. We mimic this by
+		// default using UTC, so it will be stored 'as-is' in Spanner.
+		t, err = time.ParseInLocation("2006-01-02 15:04:05", val, location)
 	}
 	if err != nil {
 		return t, fmt.Errorf("can't convert to timestamp (posgres type: %s)", srcTypeName)
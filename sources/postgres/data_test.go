@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -308,6 +309,76 @@ func TestConvertData(t *testing.T) {
 	}
 }
 
+func TestConvertData_Enum(t *testing.T) {
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:    "testtable",
+			Id:      "t1",
+			ColIds:  []string{"c1"},
+			ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.mood"}}}},
+		schema.Table{
+			Name:    "testtable",
+			Id:      "t1",
+			ColIds:  []string{"c1"},
+			ColDefs: map[string]schema.Column{"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "mood", EnumValues: []string{"sad", "ok", "happy"}}}}})
+	at, ac, av, err := ConvertData(conv, "t1", []string{"c1"}, []string{"happy"})
+	checkResults(t, at, ac, av, err, "testtable", []string{"a"}, []interface{}{int64(3)}, "enum")
+	_, _, _, err = ConvertData(conv, "t1", []string{"c1"}, []string{"angry"})
+	assert.NotNil(t, err)
+}
+
+func TestConvertData_UUID(t *testing.T) {
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:    "testtable",
+			Id:      "t1",
+			ColIds:  []string{"c1"},
+			ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.UUID}}}},
+		schema.Table{
+			Name:    "testtable",
+			Id:      "t1",
+			ColIds:  []string{"c1"},
+			ColDefs: map[string]schema.Column{"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "uuid"}}}})
+	at, ac, av, err := ConvertData(conv, "t1", []string{"c1"}, []string{"8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3"})
+	checkResults(t, at, ac, av, err, "testtable", []string{"a"}, []interface{}{uuid.MustParse("8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3")}, "uuid")
+	_, _, _, err = ConvertData(conv, "t1", []string{"c1"}, []string{"8f14e45f"})
+	assert.NotNil(t, err)
+}
+
+func TestConvertData_TimezonePolicy(t *testing.T) {
+	colIds := []string{"c1", "c2", "c3", "c4"}
+	conv := buildConv(
+		ddl.CreateTable{Name: "t", Id: "t1", ColIds: colIds, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Timestamp}},
+			"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+			"c3": {Name: "c", Id: "c3", T: ddl.Type{Name: ddl.Timestamp}},
+			"c4": {Name: "d", Id: "c4", T: ddl.Type{Name: ddl.Date}},
+		}},
+		schema.Table{Name: "t", Id: "t1", ColIds: colIds, ColDefs: map[string]schema.Column{
+			"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "timestamp"}},
+			"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "timestamptz"}},
+			"c3": {Name: "c", Id: "c3", Type: schema.Type{Name: "timestamptz"}},
+			"c4": {Name: "d", Id: "c4", Type: schema.Type{Name: "timestamp"}},
+		}})
+	conv.SetTimezonePolicy("t1", "", internal.TimezonePolicy{Mode: internal.TimezoneZone, Location: "Asia/Kolkata"})
+	conv.SetTimezonePolicy("t1", "c4", internal.TimezonePolicy{Mode: internal.TimezoneDate})
+	vals := []string{"2019-10-29 05:30:00", "2019-10-29 05:30:00+02", "2019-10-29 05:30:00", "2019-10-29 05:30:00"}
+	_, ac, av, err := ConvertData(conv, "t1", colIds, vals)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ac)
+	assert.True(t, getTime(t, "2019-10-29T00:00:00Z").Equal(av[0].(time.Time)))
+	// Timestamps with a timezone keep it.
+	assert.True(t, getTime(t, "2019-10-29T03:30:00Z").Equal(av[1].(time.Time)))
+	assert.True(t, getTime(t, "2019-10-29T00:00:00Z").Equal(av[2].(time.Time)))
+	assert.Equal(t, getDate("2019-10-29"), av[3])
+
+	// The driver returns timestamps without a timezone as UTC.
+	srcCd, spCd := conv.SrcSchema["t1"].ColDefs["c1"], conv.SpSchema["t1"].ColDefs["c1"]
+	v, err := cvtSQLScalar(conv, srcCd, spCd, timestampLocation(conv, "t1", "c1", srcCd.Type.Name), getTime(t, "2019-10-29T05:30:00Z"))
+	assert.Nil(t, err)
+	assert.True(t, getTime(t, "2019-10-29T00:00:00Z").Equal(v.(time.Time)))
+}
+
 func buildConv(spTable ddl.CreateTable, srcTable schema.Table) *internal.Conv {
 	conv := internal.MakeConv()
 	conv.SpSchema[spTable.Id] = spTable
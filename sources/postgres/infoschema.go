@@ -16,6 +16,8 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/bits"
 	"reflect"
@@ -23,6 +25,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/civil"
@@ -124,28 +127,77 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 	}
 	rows := rowsInterface.(*sql.Rows)
 	defer rows.Close()
+	return processRows(conv, tableId, srcSchema, colIds, spSchema, rows, &sync.Mutex{})
+}
+
+// GetKeyBounds returns the smallest and largest value of an integer column.
+func (isi InfoSchemaImpl) GetKeyBounds(conv *internal.Conv, tableId string, colId string) (int64, int64, bool, error) {
+	srcSchema := conv.SrcSchema[tableId]
+	colName := srcSchema.ColDefs[colId].Name
+	q := fmt.Sprintf(`SELECT MIN("%s"), MAX("%s") FROM "%s"."%s";`, colName, colName, srcSchema.Schema, unqualifiedTableName(srcSchema))
+	var min, max sql.NullInt64
+	err := isi.Db.QueryRow(q).Scan(&min, &max)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return min.Int64, max.Int64, min.Valid && max.Valid, nil
+}
+
+// ProcessDataChunk performs data conversion for the rows of a table in keyRange.
+func (isi InfoSchemaImpl) ProcessDataChunk(conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, keyRange common.KeyRange, mutex *sync.Mutex, additionalAttributes internal.AdditionalDataAttributes) error {
+	where, args := keyRange.WhereClause(fmt.Sprintf(`"%s"`, srcSchema.ColDefs[keyRange.ColId].Name), func(i int) string { return fmt.Sprintf("$%d", i) })
+	q := fmt.Sprintf(`SELECT * FROM "%s"."%s" WHERE %s;`, srcSchema.Schema, unqualifiedTableName(srcSchema), where)
+	rows, err := isi.Db.Query(q, args...)
+	if err != nil {
+		mutex.Lock()
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		mutex.Unlock()
+		return err
+	}
+	defer rows.Close()
+	return processRows(conv, tableId, srcSchema, colIds, spSchema, rows, mutex)
+}
+
+// unqualifiedTableName returns the name of a source table without the
+// schema prefix that GetTableName adds for non-public schemas.
+func unqualifiedTableName(srcSchema schema.Table) string {
+	return strings.TrimPrefix(srcSchema.Name, srcSchema.Schema+".")
+}
+
+// processRows converts the rows returned by a query on a source table and
+// writes them out. Rows are scanned and converted without holding mutex; the
+// updates of the stats of conv and the writes hold it. It returns the error,
+// if any, that ended the iteration, e.g. a dropped connection, so that a
+// partial read isn't taken for a complete one.
+func processRows(conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, rows *sql.Rows, mutex *sync.Mutex) error {
+	srcTableName := conv.SrcSchema[tableId].Name
 	srcCols, _ := rows.Columns()
 	v, iv := buildVals(len(srcCols))
 	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
 	for rows.Next() {
 		err := rows.Scan(iv...)
 		if err != nil {
+			mutex.Lock()
 			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
 			// Scan failed, so we don't have any data to add to bad rows.
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
+			mutex.Unlock()
 			continue
 		}
 		newValues, err1 := common.PrepareValues(conv, tableId, colNameIdMap, colIds, srcCols, v)
 		cvtCols, cvtVals, err2 := convertSQLRow(conv, tableId, colIds, srcSchema, spSchema, newValues)
+		mutex.Lock()
 		if err1 != nil || err2 != nil {
 			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
-			conv.CollectBadRow(srcTableName, srcCols, valsToStrings(v))
+			conv.CollectBadRow(srcTableName, srcCols, valsToStrings(v), errors.Join(err1, err2))
+			mutex.Unlock()
 			continue
 		}
 		conv.WriteRow(srcTableName, conv.SpSchema[tableId].Name, cvtCols, cvtVals)
+		mutex.Unlock()
 	}
-	return nil
+	return rows.Err()
 }
 
 // ConvertSQLRow performs data conversion for a single row of data
@@ -168,10 +220,11 @@ func convertSQLRow(conv *internal.Conv, tableId string, colIds []string, srcSche
 		}
 		var spVal interface{}
 		var err error
+		location := timestampLocation(conv, tableId, colId, srcCd.Type.Name)
 		if spCd.T.IsArray {
-			spVal, err = cvtSQLArray(conv, srcCd, spCd, srcVals[i])
+			spVal, err = cvtSQLArray(conv, srcCd, spCd, location, srcVals[i])
 		} else {
-			spVal, err = cvtSQLScalar(conv, srcCd, spCd, srcVals[i])
+			spVal, err = cvtSQLScalar(conv, srcCd, spCd, location, srcVals[i])
 		}
 		if err != nil { // Skip entire row if we hit error.
 			return nil, nil, fmt.Errorf("can't convert sql data for column id %s of table %s: %w", colIds, conv.SrcSchema[tableId].Name, err)
@@ -218,27 +271,66 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	for _, s := range []string{"information_schema", "postgres", "pg_catalog", "pg_temp_1", "pg_toast", "pg_toast_temp_1"} {
 		ignored[s] = true
 	}
-	q := "SELECT table_schema, table_name FROM information_schema.tables where table_type = 'BASE TABLE'"
+	q := "SELECT table_schema, table_name, obj_description(format('%I.%I', table_schema, table_name)::regclass, 'pg_class') FROM information_schema.tables where table_type = 'BASE TABLE'"
 	rows, err := isi.Db.Query(q)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get tables: %w", err)
 	}
 	defer rows.Close()
 	var tableSchema, tableName string
+	var tableComment sql.NullString
 	var tables []common.SchemaAndName
 	for rows.Next() {
-		rows.Scan(&tableSchema, &tableName)
+		rows.Scan(&tableSchema, &tableName, &tableComment)
 		if !ignored[tableSchema] {
-			tables = append(tables, common.SchemaAndName{Schema: tableSchema, Name: tableName})
+			tables = append(tables, common.SchemaAndName{Schema: tableSchema, Name: tableName, Comment: tableComment.String})
 		}
 	}
 	isi.populateSchemaIsUnique(tables)
 	return tables, nil
 }
 
+// GetViews returns the views of the user schemas, with the query that
+// PostgreSQL reconstructs from their definition.
+func (isi InfoSchemaImpl) GetViews(conv *internal.Conv) ([]schema.View, error) {
+	q := `SELECT table_schema, table_name, view_definition, check_option
+	FROM information_schema.views
+	WHERE table_schema NOT IN ('information_schema', 'pg_catalog')`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get views: %w", err)
+	}
+	defer rows.Close()
+	var viewSchema, viewName, checkOption string
+	var definition sql.NullString
+	var views []schema.View
+	for rows.Next() {
+		if err := rows.Scan(&viewSchema, &viewName, &definition, &checkOption); err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		if !definition.Valid {
+			// Only the owner of a view can read its definition.
+			conv.Unexpected(fmt.Sprintf("Can't read the definition of view %s.%s", viewSchema, viewName))
+			continue
+		}
+		views = append(views, schema.View{
+			Name:        isi.GetTableName(viewSchema, viewName),
+			Schema:      viewSchema,
+			Definition:  definition.String,
+			CheckOption: checkOption != "NONE",
+		})
+	}
+	return views, nil
+}
+
 // GetColumns returns a list of Column objects and names
 func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
-	q := `SELECT c.column_name, c.data_type, e.data_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale
+	q := `SELECT c.column_name, c.data_type, e.data_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale,
+                col_description(format('%I.%I', c.table_schema, c.table_name)::regclass, c.ordinal_position), c.udt_name,
+                (SELECT json_agg(en.enumlabel ORDER BY en.enumsortorder) FROM pg_enum en
+                   JOIN pg_type t ON en.enumtypid = t.oid JOIN pg_namespace n ON t.typnamespace = n.oid
+                   WHERE t.typname = c.udt_name AND n.nspname = c.udt_schema)
               FROM information_schema.COLUMNS c LEFT JOIN information_schema.element_types e
                  ON ((c.table_catalog, c.table_schema, c.table_name, 'TABLE', c.dtd_identifier)
                      = (e.object_catalog, e.object_schema, e.object_name, e.object_type, e.collection_type_identifier))
@@ -252,10 +344,10 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	colDefs := make(map[string]schema.Column)
 	var colIds []string
 	var colName, dataType, isNullable string
-	var colDefault, elementDataType sql.NullString
+	var colDefault, elementDataType, colComment, udtName, enumLabels sql.NullString
 	var charMaxLen, numericPrecision, numericScale sql.NullInt64
 	for cols.Next() {
-		err := cols.Scan(&colName, &dataType, &elementDataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale)
+		err := cols.Scan(&colName, &dataType, &elementDataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colComment, &udtName, &enumLabels)
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
@@ -275,13 +367,22 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 		isSerialColumn := slices.Contains(serialCols, colName)
 		ignored.Default = colDefault.Valid && !isSerialColumn
 		colId := internal.GenerateColumnId()
+		ty := toType(dataType, elementDataType, charMaxLen, numericPrecision, numericScale)
+		if dataType == "USER-DEFINED" && udtName.Valid && enumLabels.Valid {
+			if err := json.Unmarshal([]byte(enumLabels.String), &ty.EnumValues); err != nil {
+				conv.Unexpected(fmt.Sprintf("Can't parse labels of enum type %s: %v", udtName.String, err))
+			} else {
+				ty.Name = udtName.String
+			}
+		}
 		c := schema.Column{
 			Id:      colId,
 			Name:    colName,
-			Type:    toType(dataType, elementDataType, charMaxLen, numericPrecision, numericScale),
+			Type:    ty,
 			NotNull: common.ToNotNull(conv, isNullable),
 			Ignored: ignored,
 			AutoGen: toAutoGen(isSerialColumn),
+			Comment: colComment.String,
 		}
 		colDefs[colId] = c
 		colIds = append(colIds, colId)
@@ -515,12 +616,12 @@ func toAutoGen(isSerial bool) ddl.AutoGenCol {
 	return autoGen
 }
 
-func cvtSQLArray(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef, val interface{}) (interface{}, error) {
+func cvtSQLArray(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef, location *time.Location, val interface{}) (interface{}, error) {
 	a, ok := val.([]byte)
 	if !ok {
 		return nil, fmt.Errorf("can't convert array values to []byte")
 	}
-	return convArray(spCd.T, srcCd.Type.Name, conv.Location, string(a))
+	return convArray(spCd.T, srcCd.Type.Name, location, string(a))
 }
 
 // cvtSQLScalar converts a values returned from a SQL query to a
@@ -539,7 +640,10 @@ func cvtSQLArray(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef, v
 //	float64
 //	string
 //	time.Time
-func cvtSQLScalar(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef, val interface{}) (interface{}, error) {
+//
+// Timestamps without a timezone are interpreted in location, see
+// timestampLocation.
+func cvtSQLScalar(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef, location *time.Location, val interface{}) (interface{}, error) {
 	switch spCd.T.Name {
 	case ddl.Bool:
 		switch v := val.(type) {
@@ -630,8 +734,12 @@ func cvtSQLScalar(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef,
 	case ddl.Timestamp:
 		switch v := val.(type) {
 		case string:
-			return convTimestamp(srcCd.Type.Name, conv.Location, v)
+			return convTimestamp(srcCd.Type.Name, location, v)
 		case time.Time:
+			if srcCd.Type.Name == "timestamp" || srcCd.Type.Name == "timestamp without time zone" {
+				// The driver returns timestamps without a timezone as UTC.
+				return internal.InLocation(v, location), nil
+			}
 			return v, nil
 		}
 	case ddl.JSON:
@@ -641,6 +749,15 @@ func cvtSQLScalar(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef,
 		case []uint8:
 			return string(v), nil
 		}
+	case ddl.Enum:
+		switch v := val.(type) {
+		case string:
+			return ddl.EnumNumber(srcCd.Type.EnumValues, v)
+		case []uint8:
+			return ddl.EnumNumber(srcCd.Type.EnumValues, string(v))
+		}
+	case ddl.UUID:
+		return common.ConvertUUID(val)
 	}
 	return nil, fmt.Errorf("can't convert value of type %s to Spanner type %s", reflect.TypeOf(val), reflect.TypeOf(spCd.T))
 }
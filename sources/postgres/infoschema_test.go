@@ -18,7 +18,9 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"math/big"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -52,14 +54,14 @@ type mockSpec struct {
 func TestProcessSchema(t *testing.T) {
 	ms := []mockSpec{
 		{
-			query: "SELECT table_schema, table_name FROM information_schema.tables where table_type = 'BASE TABLE'",
-			cols:  []string{"table_schema", "table_name"},
+			query: "SELECT table_schema, table_name, (.+) FROM information_schema.tables where table_type = 'BASE TABLE'",
+			cols:  []string{"table_schema", "table_name", "obj_description"},
 			rows: [][]driver.Value{
-				{"public", "user"},
-				{"public", "cart"},
-				{"public", "product"},
-				{"public", "test"},
-				{"public", "test_ref"}},
+				{"public", "user", "Registered users"},
+				{"public", "cart", nil},
+				{"public", "product", nil},
+				{"public", "test", nil},
+				{"public", "test_ref", nil}},
 		},
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS (.+)",
@@ -85,11 +87,11 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "user"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "col_description", "udt_name", "enum_labels"},
 			rows: [][]driver.Value{
-				{"user_id", "text", nil, "NO", nil, nil, nil, nil},
-				{"name", "text", nil, "NO", nil, nil, nil, nil},
-				{"ref", "bigint", nil, "YES", nil, nil, nil, nil}},
+				{"user_id", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"name", "text", nil, "NO", nil, nil, nil, nil, "Display name", nil, nil},
+				{"ref", "bigint", nil, "YES", nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
@@ -122,11 +124,11 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "cart"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "col_description", "udt_name", "enum_labels"},
 			rows: [][]driver.Value{
-				{"productid", "text", nil, "NO", nil, nil, nil, nil},
-				{"userid", "text", nil, "NO", nil, nil, nil, nil},
-				{"quantity", "bigint", nil, "YES", nil, nil, 64, 0}},
+				{"productid", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"userid", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"quantity", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
@@ -160,10 +162,10 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "product"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "col_description", "udt_name", "enum_labels"},
 			rows: [][]driver.Value{
-				{"product_id", "text", nil, "NO", nil, nil, nil, nil},
-				{"product_name", "text", nil, "NO", nil, nil, nil, nil}},
+				{"product_id", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"product_name", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
@@ -194,29 +196,30 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "col_description", "udt_name", "enum_labels"},
 			rows: [][]driver.Value{
-				{"id", "bigint", nil, "NO", "nextval('public.test_id_seq'::regclass)", nil, 64, 0},
-				{"aint", "ARRAY", "integer", "YES", nil, nil, nil, nil},
-				{"atext", "ARRAY", "text", "YES", nil, nil, nil, nil},
-				{"b", "boolean", nil, "YES", nil, nil, nil, nil},
-				{"bs", "bigint", nil, "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0},
-				{"by", "bytea", nil, "YES", nil, nil, nil, nil},
-				{"c", "character", nil, "YES", nil, 1, nil, nil},
-				{"c_8", "character", nil, "YES", nil, 8, nil, nil},
-				{"d", "date", nil, "YES", nil, nil, nil, nil},
-				{"f8", "double precision", nil, "YES", nil, nil, 53, nil},
-				{"f4", "real", nil, "YES", nil, nil, 24, nil},
-				{"i8", "bigint", nil, "YES", nil, nil, 64, 0},
-				{"i4", "integer", nil, "YES", nil, nil, 32, 0},
-				{"i2", "smallint", nil, "YES", nil, nil, 16, 0},
-				{"num", "numeric", nil, "YES", nil, nil, nil, nil},
-				{"s", "integer", nil, "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0},
-				{"ts", "timestamp without time zone", nil, "YES", nil, nil, nil, nil},
-				{"tz", "timestamp with time zone", nil, "YES", nil, nil, nil, nil},
-				{"txt", "text", nil, "NO", nil, nil, nil, nil},
-				{"vc", "character varying", nil, "YES", nil, nil, nil, nil},
-				{"vc6", "character varying", nil, "YES", nil, 6, nil, nil}},
+				{"id", "bigint", nil, "NO", "nextval('public.test_id_seq'::regclass)", nil, 64, 0, nil, nil, nil},
+				{"aint", "ARRAY", "integer", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"atext", "ARRAY", "text", "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"b", "boolean", nil, "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"bs", "bigint", nil, "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil, nil, nil},
+				{"by", "bytea", nil, "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"c", "character", nil, "YES", nil, 1, nil, nil, nil, nil, nil},
+				{"c_8", "character", nil, "YES", nil, 8, nil, nil, nil, nil, nil},
+				{"d", "date", nil, "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"f8", "double precision", nil, "YES", nil, nil, 53, nil, nil, nil, nil},
+				{"f4", "real", nil, "YES", nil, nil, 24, nil, nil, nil, nil},
+				{"i8", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil, nil},
+				{"i4", "integer", nil, "YES", nil, nil, 32, 0, nil, nil, nil},
+				{"i2", "smallint", nil, "YES", nil, nil, 16, 0, nil, nil, nil},
+				{"num", "numeric", nil, "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"s", "integer", nil, "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil, nil, nil},
+				{"ts", "timestamp without time zone", nil, "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"tz", "timestamp with time zone", nil, "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"txt", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"vc", "character varying", nil, "YES", nil, nil, nil, nil, nil, nil, nil},
+				{"vc6", "character varying", nil, "YES", nil, 6, nil, nil, nil, nil, nil},
+				{"mood", "USER-DEFINED", nil, "YES", nil, nil, nil, nil, nil, "mood", `["sad","ok","happy"]`}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
@@ -245,11 +248,11 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test_ref"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "col_description", "udt_name", "enum_labels"},
 			rows: [][]driver.Value{
-				{"ref_id", "bigint", nil, "NO", nil, nil, 64, 0},
-				{"ref_txt", "text", nil, "NO", nil, nil, nil, nil},
-				{"abc", "text", nil, "NO", nil, nil, nil, nil}},
+				{"ref_id", "bigint", nil, "NO", nil, nil, 64, 0, nil, nil, nil},
+				{"ref_txt", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"abc", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
@@ -309,7 +312,7 @@ func TestProcessSchema(t *testing.T) {
 			PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "product_id", Order: 1}}},
 		"test": ddl.CreateTable{
 			Name:   "test",
-			ColIds: []string{"id", "aint", "atext", "b", "bs", "by", "c", "c_8", "d", "f8", "f4", "i8", "i4", "i2", "num", "s", "ts", "tz", "txt", "vc", "vc6"},
+			ColIds: []string{"id", "aint", "atext", "b", "bs", "by", "c", "c_8", "d", "f8", "f4", "i8", "i4", "i2", "num", "s", "ts", "tz", "txt", "vc", "vc6", "mood"},
 			ColDefs: map[string]ddl.ColumnDef{
 				"id":    ddl.ColumnDef{Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true, AutoGen: ddl.AutoGenCol{Name: constants.IDENTITY, GenerationType: constants.IDENTITY}},
 				"aint":  ddl.ColumnDef{Name: "aint", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false}},
@@ -332,6 +335,7 @@ func TestProcessSchema(t *testing.T) {
 				"txt":   ddl.ColumnDef{Name: "txt", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
 				"vc":    ddl.ColumnDef{Name: "vc", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
 				"vc6":   ddl.ColumnDef{Name: "vc6", T: ddl.Type{Name: ddl.String, Len: int64(6)}},
+				"mood":  ddl.ColumnDef{Name: "mood", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
 			},
 			PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "id", Order: 1}},
 			ForeignKeys: []ddl.Foreignkey{ddl.Foreignkey{Name: "fk_test4", ColIds: []string{"id", "txt"}, ReferTableId: "test_ref", ReferColumnIds: []string{"ref_id", "ref_txt"}, OnDelete: constants.FK_CASCADE, OnUpdate: constants.FK_NO_ACTION}}},
@@ -346,6 +350,17 @@ func TestProcessSchema(t *testing.T) {
 			PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "ref_id", Order: 1}, ddl.IndexKey{ColId: "ref_txt", Order: 2}}},
 	}
 	internal.AssertSpSchema(conv, t, expectedSchema, stripSchemaComments(conv.SpSchema))
+	userTableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, "user")
+	assert.Nil(t, err)
+	nameColId, err := internal.GetColIdFromSrcName(conv.SrcSchema[userTableId].ColDefs, "name")
+	assert.Nil(t, err)
+	assert.Equal(t, "Registered users", conv.SrcSchema[userTableId].Comment)
+	assert.Equal(t, "Display name", conv.SrcSchema[userTableId].ColDefs[nameColId].Comment)
+	srcTestTableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, "test")
+	assert.Nil(t, err)
+	moodColId, err := internal.GetColIdFromSrcName(conv.SrcSchema[srcTestTableId].ColDefs, "mood")
+	assert.Nil(t, err)
+	assert.Equal(t, schema.Type{Name: "mood", EnumValues: []string{"sad", "ok", "happy"}}, conv.SrcSchema[srcTestTableId].ColDefs[moodColId].Type)
 	cartTableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "cart")
 	assert.Equal(t, nil, err)
 	assert.Equal(t, len(conv.SchemaIssues[cartTableId].ColumnLevelIssues), 0)
@@ -421,6 +436,195 @@ func TestProcessData(t *testing.T) {
 	assert.Equal(t, int64(1), conv.Unexpecteds()) // Bad row generates an entry in unexpected.
 }
 
+func TestProcessData_Chunked(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: `SELECT MIN[(]" b"[)], MAX[(]" b"[)] FROM "public"."te st"`,
+			cols:  []string{"min", "max"},
+			rows:  [][]driver.Value{{1, 10}},
+		},
+		{
+			query: `SELECT [*] FROM "public"."te st" WHERE " b" < [$]1`,
+			args:  []driver.Value{6},
+			cols:  []string{"a a", " b", " c "},
+			rows:  [][]driver.Value{{42.3, 3, "cat"}},
+		},
+		{
+			query: `SELECT [*] FROM "public"."te st" WHERE " b" >= [$]1`,
+			args:  []driver.Value{6},
+			cols:  []string{"a a", " b", " c "},
+			rows:  [][]driver.Value{{6.6, 8, "dog"}},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   "te_st",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "a_a", Id: "c1", T: ddl.Type{Name: ddl.Float64}},
+				"c2": {Name: "Ab", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+				"c3": {Name: "Ac_", Id: "c3", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c2", Order: 1}}},
+		schema.Table{
+			Name:   "te st",
+			Id:     "t1",
+			Schema: "public",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "a a", Id: "c1", Type: schema.Type{Name: "float8"}},
+				"c2": {Name: " b", Id: "c2", Type: schema.Type{Name: "int8"}},
+				"c3": {Name: " c ", Id: "c3", Type: schema.Type{Name: "text"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c2", Order: 1}}})
+	conv.SetDataMode()
+	// A single worker reads the key ranges in order, which keeps the mock
+	// query expectations deterministic.
+	conv.DataReadOptions = internal.DataReadOptions{ChunksPerTable: 2, Workers: 1}
+	var rows []spannerData
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, internal.AdditionalDataAttributes{})
+
+	assert.Equal(t,
+		[]spannerData{
+			{table: "te_st", cols: []string{"a_a", "Ab", "Ac_"}, vals: []interface{}{float64(42.3), int64(3), "cat"}},
+			{table: "te_st", cols: []string{"a_a", "Ab", "Ac_"}, vals: []interface{}{float64(6.6), int64(8), "dog"}},
+		},
+		rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
+
+func TestProcessData_Resume(t *testing.T) {
+	// Rows with a key below the last committed key were written by an earlier
+	// run and are not read again.
+	ms := []mockSpec{
+		{
+			query: `SELECT [*] FROM "public"."te st" WHERE " b" >= [$]1`,
+			args:  []driver.Value{6},
+			cols:  []string{"a a", " b"},
+			rows:  [][]driver.Value{{6.6, 8}},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   "te_st",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "a_a", Id: "c1", T: ddl.Type{Name: ddl.Float64}},
+				"c2": {Name: "Ab", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c2", Order: 1}}},
+		schema.Table{
+			Name:   "te st",
+			Id:     "t1",
+			Schema: "public",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "a a", Id: "c1", Type: schema.Type{Name: "float8"}},
+				"c2": {Name: " b", Id: "c2", Type: schema.Type{Name: "int8"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c2", Order: 1}}})
+	conv.SetDataMode()
+	cp, err := internal.LoadCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"), "db", false)
+	assert.Nil(t, err)
+	assert.Nil(t, cp.CommitKey("te_st", 6))
+	conv.Checkpoint = cp
+	var rows []spannerData
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, internal.AdditionalDataAttributes{})
+
+	assert.Equal(t,
+		[]spannerData{
+			{table: "te_st", cols: []string{"a_a", "Ab"}, vals: []interface{}{float64(6.6), int64(8)}},
+		},
+		rows)
+	assert.True(t, cp.TableCompleted("te_st"))
+
+	// A completed table is skipped altogether.
+	rows = nil
+	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, internal.AdditionalDataAttributes{})
+	assert.Empty(t, rows)
+}
+
+func TestProcessData_RowError(t *testing.T) {
+	// A read that ends with an error, e.g. a dropped connection, must not be
+	// recorded in the checkpoint, so that a resumed migration reads it again.
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(`SELECT MIN[(]" b"[)], MAX[(]" b"[)] FROM "public"."te st"`).
+		WillReturnRows(sqlmock.NewRows([]string{"min", "max"}).AddRow(1, 10))
+	mock.ExpectQuery(`SELECT [*] FROM "public"."te st" WHERE " b" < [$]1`).WithArgs(6).
+		WillReturnRows(sqlmock.NewRows([]string{"a a", " b"}).AddRow(42.3, 3).AddRow(4.2, 4).RowError(1, errors.New("connection reset")))
+	mock.ExpectQuery(`SELECT [*] FROM "public"."te st" WHERE " b" >= [$]1`).WithArgs(6).
+		WillReturnRows(sqlmock.NewRows([]string{"a a", " b"}).AddRow(6.6, 8))
+	mock.ExpectQuery(`SELECT [*] FROM "public"."te st"`).
+		WillReturnRows(sqlmock.NewRows([]string{"a a", " b"}).AddRow(42.3, 3).RowError(0, errors.New("connection reset")))
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   "te_st",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "a_a", Id: "c1", T: ddl.Type{Name: ddl.Float64}},
+				"c2": {Name: "Ab", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c2", Order: 1}}},
+		schema.Table{
+			Name:   "te st",
+			Id:     "t1",
+			Schema: "public",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "a a", Id: "c1", Type: schema.Type{Name: "float8"}},
+				"c2": {Name: " b", Id: "c2", Type: schema.Type{Name: "int8"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c2", Order: 1}}})
+	conv.SetDataMode()
+	conv.DataReadOptions = internal.DataReadOptions{ChunksPerTable: 2, Workers: 1}
+	cp, err := internal.LoadCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"), "db", false)
+	assert.Nil(t, err)
+	conv.Checkpoint = cp
+	var rows []spannerData
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	commonInfoSchema := common.InfoSchemaImpl{}
+	isi := InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}
+
+	// The second key range is read, but the first one isn't complete, so
+	// no key is committed.
+	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
+	assert.Equal(t,
+		[]spannerData{
+			{table: "te_st", cols: []string{"a_a", "Ab"}, vals: []interface{}{float64(42.3), int64(3)}},
+			{table: "te_st", cols: []string{"a_a", "Ab"}, vals: []interface{}{float64(6.6), int64(8)}},
+		},
+		rows)
+	_, ok := cp.LastCommittedKey("te_st")
+	assert.False(t, ok)
+	assert.False(t, cp.TableCompleted("te_st"))
+
+	// The same holds for a table read with a single query.
+	conv.DataReadOptions = internal.DataReadOptions{}
+	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
+	assert.False(t, cp.TableCompleted("te_st"))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestConvertSqlRow_SingleCol(t *testing.T) {
 	tDate, _ := time.Parse("2006-01-02", "2019-10-29")
 	tc := []struct {
@@ -518,9 +722,9 @@ func TestConvertSqlRow_MultiCol(t *testing.T) {
 	// ProcessInfoSchema.
 	ms := []mockSpec{
 		{
-			query: "SELECT table_schema, table_name FROM information_schema.tables where table_type = 'BASE TABLE'",
-			cols:  []string{"table_schema", "table_name"},
-			rows:  [][]driver.Value{{"public", "test"}},
+			query: "SELECT table_schema, table_name, (.+) FROM information_schema.tables where table_type = 'BASE TABLE'",
+			cols:  []string{"table_schema", "table_name", "obj_description"},
+			rows:  [][]driver.Value{{"public", "test", nil}},
 		}, {
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS (.+)",
 			args:  []driver.Value{"public", "test"},
@@ -540,11 +744,11 @@ func TestConvertSqlRow_MultiCol(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "col_description", "udt_name", "enum_labels"},
 			rows: [][]driver.Value{
-				{"a", "text", nil, "NO", nil, nil, nil, nil},
-				{"b", "double precision", nil, "YES", nil, nil, 53, nil},
-				{"c", "bigint", nil, "YES", nil, nil, 64, 0}},
+				{"a", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil},
+				{"b", "double precision", nil, "YES", nil, nil, 53, nil, nil, nil, nil},
+				{"c", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
@@ -591,12 +795,38 @@ func TestConvertSqlRow_MultiCol(t *testing.T) {
 	assert.Equal(t, int64(0), conv.Unexpecteds())
 }
 
+func TestGetViews(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT table_schema, table_name, view_definition, check_option FROM information_schema.views",
+			cols:  []string{"table_schema", "table_name", "view_definition", "check_option"},
+			rows: [][]driver.Value{
+				{"public", "active_users", " SELECT id,\n    name\n   FROM users\n  WHERE active;", "NONE"},
+				{"sales", "big_orders", " SELECT id\n   FROM sales.orders\n  WHERE total > 100;", "CASCADED"},
+				{"sales", "hidden", nil, "NONE"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	isi := InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}
+
+	views, err := isi.GetViews(conv)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []schema.View{
+		{Name: "active_users", Schema: "public", Definition: " SELECT id,\n    name\n   FROM users\n  WHERE active;"},
+		{Name: "sales.big_orders", Schema: "sales", Definition: " SELECT id\n   FROM sales.orders\n  WHERE total > 100;", CheckOption: true},
+	}, views)
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+}
+
 func TestSetRowStats(t *testing.T) {
 	ms := []mockSpec{
 		{
-			query: "SELECT table_schema, table_name FROM information_schema.tables where table_type = 'BASE TABLE'",
-			cols:  []string{"table_schema", "table_name"},
-			rows:  [][]driver.Value{{"public", "test1"}, {"public", "test2"}},
+			query: "SELECT table_schema, table_name, (.+) FROM information_schema.tables where table_type = 'BASE TABLE'",
+			cols:  []string{"table_schema", "table_name", "obj_description"},
+			rows:  [][]driver.Value{{"public", "test1", nil}, {"public", "test2", nil}},
 		}, {
 			query: `SELECT COUNT[(][*][)] FROM "public"."test1"`,
 			cols:  []string{"count"},
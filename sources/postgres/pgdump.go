@@ -109,7 +109,7 @@ func processPgDump(conv *internal.Conv, r *internal.Reader) error {
 						srcTableName := conv.SrcSchema[ci.table].Name
 						conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 						conv.StatsAddBadRow(srcTableName, conv.DataMode())
-						conv.CollectBadRow(srcTableName, colNames, vals)
+						conv.CollectBadRow(srcTableName, colNames, vals, err)
 						continue
 					}
 					ProcessDataRow(conv, ci.table, commonColIds, newVals)
@@ -200,7 +200,7 @@ func processCopyBlock(conv *internal.Conv, tableId string, commonColIds, srcCols
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
-			conv.CollectBadRow(srcTableName, srcCols, values)
+			conv.CollectBadRow(srcTableName, srcCols, values, err)
 			continue
 		}
 		ProcessDataRow(conv, tableId, commonColIds, newValues)
@@ -249,6 +249,18 @@ func processStatements(conv *internal.Conv, rawStmts []*pg_query.RawStmt) *copyO
 			if conv.SchemaMode() {
 				processAlterSeqStmt(conv, n.AlterSeqStmt)
 			}
+		case *pg_query.Node_ViewStmt:
+			if conv.SchemaMode() {
+				processViewStmt(conv, n.ViewStmt)
+			}
+		case *pg_query.Node_CommentStmt:
+			if conv.SchemaMode() {
+				processCommentStmt(conv, n.CommentStmt)
+			}
+		case *pg_query.Node_CreateEnumStmt:
+			if conv.SchemaMode() {
+				processCreateEnumStmt(conv, n.CreateEnumStmt)
+			}
 		default:
 			conv.SkipStatement(printNodeType(n))
 		}
@@ -256,6 +268,120 @@ func processStatements(conv *internal.Conv, rawStmts []*pg_query.RawStmt) *copyO
 	return nil
 }
 
+// processCreateEnumStmt records the labels of the enum type of a CREATE
+// TYPE ... AS ENUM statement, for the columns of that type.
+func processCreateEnumStmt(conv *internal.Conv, n *pg_query.CreateEnumStmt) {
+	name, err := getTypeID(n.TypeName)
+	if err != nil {
+		logStmtError(conv, n, fmt.Errorf("can't get enum type name: %w", err))
+		return
+	}
+	var labels []string
+	for _, val := range n.Vals {
+		label, err := getString(val)
+		if err != nil {
+			logStmtError(conv, n, fmt.Errorf("can't get label of enum type %s: %w", name, err))
+			return
+		}
+		labels = append(labels, label)
+	}
+	conv.SrcEnumTypes[enumTypeKey(name)] = labels
+}
+
+// enumTypeKey returns the key of enum type name in conv.SrcEnumTypes. As
+// for table names, the default "public" schema is dropped, so that "mood"
+// and "public.mood" are the same type.
+func enumTypeKey(name string) string {
+	return strings.TrimPrefix(name, "public.")
+}
+
+// processViewStmt adds the view of a CREATE VIEW statement to
+// conv.SrcViews, with its query printed back from the parse tree.
+func processViewStmt(conv *internal.Conv, n *pg_query.ViewStmt) {
+	if n.View == nil || n.Query == nil {
+		logStmtError(conv, n, fmt.Errorf("can't get view name or query"))
+		return
+	}
+	name, err := getTableName(conv, n.View)
+	if err != nil {
+		logStmtError(conv, n, fmt.Errorf("can't get view name: %w", err))
+		return
+	}
+	if len(n.Aliases) > 0 {
+		// Spanner views take their column names from the query.
+		conv.Unexpected(fmt.Sprintf("Column names of view %s are ignored", name))
+	}
+	query, err := pg_query.Deparse(&pg_query.ParseResult{Stmts: []*pg_query.RawStmt{{Stmt: n.Query}}})
+	if err != nil {
+		logStmtError(conv, n, fmt.Errorf("can't print query of view %s: %w", name, err))
+		return
+	}
+	common.AddSrcView(conv, schema.View{
+		Name:        name,
+		Schema:      n.View.Schemaname,
+		Definition:  query,
+		CheckOption: n.WithCheckOption == pg_query.ViewCheckOption_LOCAL_CHECK_OPTION || n.WithCheckOption == pg_query.ViewCheckOption_CASCADED_CHECK_OPTION,
+	})
+}
+
+// processCommentStmt records the comment of a COMMENT ON TABLE or COMMENT ON
+// COLUMN statement in the source schema. Comments on other objects are
+// skipped.
+func processCommentStmt(conv *internal.Conv, n *pg_query.CommentStmt) {
+	if n.Objtype != pg_query.ObjectType_OBJECT_TABLE && n.Objtype != pg_query.ObjectType_OBJECT_COLUMN {
+		conv.SkipStatement(printNodeType(n))
+		return
+	}
+	var names []string
+	for _, item := range n.Object.GetList().GetItems() {
+		name, err := getString(item)
+		if err != nil {
+			logStmtError(conv, n, fmt.Errorf("can't get name of commented object: %w", err))
+			return
+		}
+		names = append(names, name)
+	}
+	var colName string
+	if n.Objtype == pg_query.ObjectType_OBJECT_COLUMN {
+		if len(names) < 2 {
+			logStmtError(conv, n, fmt.Errorf("can't get table and column names from %v", names))
+			return
+		}
+		colName, names = names[len(names)-1], names[:len(names)-1]
+	}
+	if len(names) == 0 {
+		logStmtError(conv, n, fmt.Errorf("can't get table name"))
+		return
+	}
+	// Build the table name the same way as getTableName.
+	if len(names) > 1 && names[len(names)-2] == "public" {
+		names = append(names[:len(names)-2], names[len(names)-1])
+	}
+	tableName := strings.Join(names, ".")
+	tbl, ok := internal.GetSrcTableByName(conv.SrcSchema, tableName)
+	if !ok {
+		conv.Unexpected(fmt.Sprintf("Table %s not found while processing comment statement", tableName))
+		conv.SkipStatement(printNodeType(n))
+		return
+	}
+	ctable := conv.SrcSchema[tbl.Id]
+	if colName == "" {
+		ctable.Comment = n.Comment
+	} else {
+		colId, ok := ctable.ColNameIdMap[colName]
+		if !ok {
+			conv.Unexpected(fmt.Sprintf("Column %s of table %s not found while processing comment statement", colName, tableName))
+			conv.SkipStatement(printNodeType(n))
+			return
+		}
+		col := ctable.ColDefs[colId]
+		col.Comment = n.Comment
+		ctable.ColDefs[colId] = col
+	}
+	conv.SrcSchema[tbl.Id] = ctable
+	conv.SchemaStatement(printNodeType(n))
+}
+
 func processIndexStmt(conv *internal.Conv, n *pg_query.IndexStmt) {
 	if n.Relation == nil {
 		logStmtError(conv, n, fmt.Errorf("cannot process index statement with nil relation"))
@@ -411,7 +537,8 @@ func processColumn(conv *internal.Conv, n *pg_query.ColumnDef, table string) (st
 	ty := schema.Type{
 		Name:        tid,
 		Mods:        mods,
-		ArrayBounds: getArrayBounds(conv, n.TypeName.ArrayBounds)}
+		ArrayBounds: getArrayBounds(conv, n.TypeName.ArrayBounds),
+		EnumValues:  conv.SrcEnumTypes[enumTypeKey(tid)]}
 	autoGen := getAutoGenFromTypeName(tid)
 	return name, schema.Column{Name: name, Type: ty, AutoGen: autoGen}, analyzeColDefConstraints(conv, printNodeType(n), table, n.Constraints, name), nil
 }
@@ -1688,6 +1688,64 @@ func TestProcessPgDump_GetPGDDL(t *testing.T) {
 	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions), " "))
 }
 
+func TestProcessPgDump_Views(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TABLE public.cart (productid text PRIMARY KEY, userid text, quantity bigint);\n" +
+		"CREATE VIEW public.big_carts AS\n SELECT c.productid,\n    c.quantity\n   FROM public.cart c\n  WHERE c.quantity > 10;\n" +
+		"CREATE VIEW public.checked AS SELECT productid FROM cart WHERE quantity > 0 WITH CHECK OPTION;")
+	assert.Len(t, conv.SpViews, 2)
+	c := ddl.Config{}
+	assert.Equal(t, []string{
+		"CREATE VIEW big_carts SQL SECURITY INVOKER AS SELECT c.productid, c.quantity FROM cart c WHERE c.quantity > 10",
+		"CREATE VIEW checked SQL SECURITY INVOKER AS SELECT productid FROM cart WHERE quantity > 0",
+	}, ddl.GetViewsDDL(c, conv.SpViews))
+	for viewId, view := range conv.SpViews {
+		if view.Name == "checked" {
+			assert.Equal(t, []string{"WITH CHECK OPTION isn't supported by Spanner views"}, conv.ViewIssues[viewId])
+		} else {
+			assert.Empty(t, conv.ViewIssues[viewId])
+		}
+	}
+}
+
+func TestProcessPgDump_Comments(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TABLE public.cart (productid text PRIMARY KEY, quantity bigint);\n" +
+		"COMMENT ON TABLE public.cart IS 'Shopping carts\nof registered users';\n" +
+		"COMMENT ON COLUMN public.cart.quantity IS 'Number of\nitems';\n" +
+		"COMMENT ON COLUMN public.cart.missing IS 'Unknown column';\n" +
+		"COMMENT ON SCHEMA public IS 'Standard public schema';")
+	tableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, "cart")
+	assert.Nil(t, err)
+	colId, err := internal.GetColIdFromSrcName(conv.SrcSchema[tableId].ColDefs, "quantity")
+	assert.Nil(t, err)
+	assert.Equal(t, "Shopping carts\nof registered users", conv.SrcSchema[tableId].Comment)
+	assert.Equal(t, "Number of\nitems", conv.SrcSchema[tableId].ColDefs[colId].Comment)
+	assert.Equal(t, int64(1), conv.Stats.Unexpected["Column missing of table cart not found while processing comment statement"])
+	c := ddl.Config{Comments: true, Tables: true}
+	assert.Equal(t, []string{
+		"--\n" +
+			"-- Spanner schema for source table cart\n" +
+			"-- Shopping carts\n" +
+			"-- of registered users\n" +
+			"--\n" +
+			"CREATE TABLE cart (\n" +
+			"\tproductid STRING(MAX) NOT NULL , -- From: productid text\n" +
+			"\tquantity INT64,                  -- From: quantity int8. Number of items\n" +
+			") PRIMARY KEY (productid)"},
+		ddl.GetDDL(c, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions))
+}
+
+func TestProcessPgDump_Enum(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TYPE public.mood AS ENUM ('sad', 'ok', 'happy');\n" +
+		"CREATE TABLE public.person (id bigint PRIMARY KEY, current_mood mood NOT NULL);")
+	tableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, "person")
+	assert.Nil(t, err)
+	colId, err := internal.GetColIdFromSrcName(conv.SrcSchema[tableId].ColDefs, "current_mood")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"sad", "ok", "happy"}, conv.SrcSchema[tableId].ColDefs[colId].Type.EnumValues)
+	// Enum columns keep the STRING mapping unless they are converted to ENUM.
+	assert.Equal(t, ddl.ColumnDef{Name: "current_mood", Id: colId, T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true, Comment: "From: current_mood mood"}, conv.SpSchema[tableId].ColDefs[colId])
+}
+
 func TestProcessPgDump_Rows(t *testing.T) {
 	conv, _ := runProcessPgDump("CREATE TABLE cart (a text, n bigint);\n" +
 		"INSERT INTO cart (a, n) VALUES ('a42', 2);")
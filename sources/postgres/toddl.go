@@ -74,6 +74,15 @@ func (tdi ToDdlImpl) GetColumnAutoGen(conv *internal.Conv, autoGenCol ddl.AutoGe
 // then it will be used to build the returned ddl.Type. If not, the default
 // Spanner type for this source type will be used.
 func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []internal.SchemaIssue) {
+	if len(srcType.EnumValues) > 0 {
+		// Enum types are named, so their columns share a proto enum.
+		switch spType {
+		case ddl.Enum:
+			return ddl.Type{Name: ddl.Enum, ProtoName: ddl.ProtoEnumName(srcType.Name)}, nil
+		default:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		}
+	}
 	switch srcType.Name {
 	case "bool", "boolean":
 		switch spType {
@@ -187,6 +196,13 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 		default:
 			return ddl.Type{Name: ddl.JSON}, nil
 		}
+	case "uuid":
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: 36}, nil
+		default:
+			return ddl.Type{Name: ddl.UUID}, nil
+		}
 	case "varchar", "character varying":
 		switch spType {
 		case ddl.Bytes:
@@ -322,6 +322,37 @@ func TestToExperimentalSpannerType(t *testing.T) {
 	assert.Equal(t, expectedIssues, actualIssues)
 }
 
+func TestToSpannerType_Enum(t *testing.T) {
+	conv := internal.MakeConv()
+	mood := schema.Type{Name: "mood", EnumValues: []string{"sad", "ok", "happy"}}
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, ddl.Enum, mood, false)
+	assert.Equal(t, ddl.Type{Name: ddl.Enum, ProtoName: "spanner_migration.mood"}, ty)
+	assert.Empty(t, issues)
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, "", mood, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Empty(t, issues)
+	ty, _ = ToDdlImpl{}.ToSpannerType(conv, ddl.Enum, schema.Type{Name: "text"}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, ddl.Enum, mood, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.NoGoodType}, issues)
+}
+
+func TestToSpannerType_UUID(t *testing.T) {
+	conv := internal.MakeConv()
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "uuid"}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, ty)
+	assert.Empty(t, issues)
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, ddl.String, schema.Type{Name: "uuid"}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 36}, ty)
+	assert.Empty(t, issues)
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "uuid"}, true)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, ty)
+	assert.Empty(t, issues)
+}
+
 func Test_GetColumnAutoGen(t *testing.T) {
 	conv := internal.MakeConv()
 	tc := []struct {
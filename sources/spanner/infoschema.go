@@ -142,7 +142,6 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 }
 
 func (sp *InfoSchemaImpl) PopulateSpannerSchema(ctx context.Context, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error {
-	processSchema := common.ProcessSchemaImpl{}
 	expressionVerificationAccessor, _ := expressions_api.NewExpressionVerificationAccessorImpl(ctx, conv.SpProjectId, conv.SpInstanceId)
 	ddlVerifier, err := expressions_api.NewDDLVerifierImpl(ctx, conv.SpProjectId, conv.SpInstanceId)
 	if err != nil {
@@ -152,7 +151,14 @@ func (sp *InfoSchemaImpl) PopulateSpannerSchema(ctx context.Context, conv *inter
 		DdlV:                           ddlVerifier,
 		ExpressionVerificationAccessor: expressionVerificationAccessor,
 	}
-	err = processSchema.ProcessSchema(conv, sp, common.DefaultWorkers, internal.AdditionalSchemaAttributes{IsSharded: false}, &schemaToSpanner, &common.UtilsOrderImpl{}, commonInfoSchema)
+	return sp.populateSpannerSchema(conv, &schemaToSpanner, commonInfoSchema)
+}
+
+// populateSpannerSchema reads the schema of the database into conv, using
+// schemaToSpanner to convert it to conv.SpSchema.
+func (sp *InfoSchemaImpl) populateSpannerSchema(conv *internal.Conv, schemaToSpanner common.SchemaToSpannerInterface, commonInfoSchema common.InfoSchemaInterface) error {
+	processSchema := common.ProcessSchemaImpl{}
+	err := processSchema.ProcessSchema(conv, sp, common.DefaultWorkers, internal.AdditionalSchemaAttributes{IsSharded: false}, schemaToSpanner, &common.UtilsOrderImpl{}, commonInfoSchema)
 	if err != nil {
 		return fmt.Errorf("error trying to read and convert spanner schema: %v", err)
 	}
@@ -172,6 +178,9 @@ func (sp *InfoSchemaImpl) PopulateSpannerSchema(ctx context.Context, conv *inter
 		spTable.ParentTable.InterleaveType = parentTable.InterleaveType
 		conv.SpSchema[tableId] = spTable
 	}
+	if err := sp.setIndexOptions(conv.SpSchema); err != nil {
+		conv.Unexpected(fmt.Sprintf("error trying to fetch the options of the indexes from schema: %v", err))
+	}
 	return nil
 }
 
@@ -403,10 +412,13 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		iter = isi.Client.Single().Query(isi.Ctx, stmt)
 	}
 	defer iter.Stop()
-	var name, column, ordering string
+	var name, column string
+	// The ordinal position and ordering of the columns in the STORING clause
+	// of an index are NULL.
+	var ordering spanner.NullString
 	var isUnique bool
 	var isPgUnique string
-	var sequence int64
+	var sequence spanner.NullInt64
 	indexMap := make(map[string]schema.Index)
 	var indexNames []string
 	var indexes []schema.Index
@@ -425,6 +437,7 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 				conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 				continue
 			}
+			isUnique = isPgUnique == "YES"
 		} else {
 			err = row.Columns(&name, &column, &sequence, &ordering, &isUnique)
 			if err != nil {
@@ -434,7 +447,6 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 			}
 		}
 
-		isUnique = isPgUnique == "YES"
 		if _, found := indexMap[name]; !found {
 			indexNames = append(indexNames, name)
 			indexMap[name] = schema.Index{
@@ -444,9 +456,13 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		}
 
 		index := indexMap[name]
-		index.Keys = append(index.Keys, schema.Key{
-			ColId: colNameIdMap[column],
-			Desc:  (ordering == "DESC")})
+		if sequence.Valid {
+			index.Keys = append(index.Keys, schema.Key{
+				ColId: colNameIdMap[column],
+				Desc:  (ordering.StringVal == "DESC")})
+		} else {
+			index.StoredColumnIds = append(index.StoredColumnIds, colNameIdMap[column])
+		}
 		indexMap[name] = index
 	}
 	for _, k := range indexNames {
@@ -493,8 +509,71 @@ func (isi InfoSchemaImpl) GetInterleaveTables(spSchema ddl.Schema) (map[string]d
 	return parentTables, nil
 }
 
+// setIndexOptions sets the options of the indexes of spSchema that the source
+// schema has no place for: NULL_FILTERED and INTERLEAVE IN.
+func (isi InfoSchemaImpl) setIndexOptions(spSchema ddl.Schema) error {
+	q := `SELECT table_name, index_name, parent_table_name, is_null_filtered FROM information_schema.indexes
+	WHERE index_type = 'INDEX' AND table_schema = ''`
+	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+		q = `SELECT table_name, index_name, parent_table_name, is_null_filtered FROM information_schema.indexes
+		WHERE index_type = 'INDEX' AND table_schema = 'public'`
+	}
+	stmt := spanner.Statement{SQL: q}
+
+	var iter spannerclient.RowIterator
+	if isi.SpannerClient != nil {
+		iter = isi.SpannerClient.Single().Query(isi.Ctx, stmt)
+	} else {
+		iter = isi.Client.Single().Query(isi.Ctx, stmt)
+	}
+	defer iter.Stop()
+
+	var tableName, indexName string
+	var parentTableName spanner.NullString
+	var isNullFiltered bool
+	var isPgNullFiltered string
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't read row while fetching index options: %w", err)
+		}
+		if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+			err = row.Columns(&tableName, &indexName, &parentTableName, &isPgNullFiltered)
+			isNullFiltered = isPgNullFiltered == "YES"
+		} else {
+			err = row.Columns(&tableName, &indexName, &parentTableName, &isNullFiltered)
+		}
+		if err != nil {
+			return err
+		}
+		tableId, err := internal.GetTableIdFromSpName(spSchema, tableName)
+		if err != nil {
+			continue
+		}
+		spTable := spSchema[tableId]
+		for i, index := range spTable.Indexes {
+			if !strings.EqualFold(index.Name, indexName) {
+				continue
+			}
+			spTable.Indexes[i].NullFiltered = isNullFiltered
+			if parentTableName.StringVal != "" {
+				spTable.Indexes[i].InterleaveIn, _ = internal.GetTableIdFromSpName(spSchema, parentTableName.StringVal)
+			}
+		}
+	}
+	return nil
+}
+
 func toType(dataType string) schema.Type {
 	switch {
+	case strings.HasSuffix(dataType, "[]"):
+		// Arrays of PostgreSQL dialect databases, e.g. character varying(10)[].
+		schemaType := toType(strings.TrimSuffix(dataType, "[]"))
+		schemaType.ArrayBounds = []int64{-1}
+		return schemaType
 	case strings.Contains(dataType, "ARRAY"):
 		typeLenStr := dataType[(strings.Index(dataType, "<") + 1):(len(dataType) - 1)]
 		schemaType := toType(typeLenStr)
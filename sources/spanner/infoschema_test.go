@@ -15,11 +15,20 @@
 package spanner
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	"cloud.google.com/go/spanner"
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
 )
 
 func TestToType(t *testing.T) {
@@ -47,9 +56,113 @@ func TestToType(t *testing.T) {
 		{"float32_arr", "ARRAY<FLOAT32>", schema.Type{Name: "FLOAT32", ArrayBounds: []int64{-1}}},
 		{"float64_arr", "ARRAY<FLOAT64>", schema.Type{Name: "FLOAT64", ArrayBounds: []int64{-1}}},
 		{"numeric_arr", "ARRAY<NUMERIC>", schema.Type{Name: "NUMERIC", ArrayBounds: []int64{-1}}},
+		// PostgreSQL dialect types.
+		{"pg_bigint", "bigint", schema.Type{Name: "bigint"}},
+		{"pg_varchar", "character varying(20)", schema.Type{Name: "character varying", Mods: []int64{20}}},
+		{"pg_varchar_arr", "character varying(20)[]", schema.Type{Name: "character varying", Mods: []int64{20}, ArrayBounds: []int64{-1}}},
+		{"pg_bigint_arr", "bigint[]", schema.Type{Name: "bigint", ArrayBounds: []int64{-1}}},
 	}
 	for _, tc := range testCases {
 		ty := toType(tc.dataType)
 		assert.Equal(t, tc.expColumnType, ty, tc.name)
 	}
 }
+
+// infoSchemaMock returns an info schema of a GoogleSQL database whose
+// queries return the rows that rows returns for them.
+func infoSchemaMock(rows func(stmt spanner.Statement) [][]interface{}) *InfoSchemaImpl {
+	return &InfoSchemaImpl{
+		Ctx:       context.Background(),
+		SpDialect: constants.DIALECT_GOOGLESQL,
+		SpannerClient: spannerclient.SpannerClientMock{
+			SingleMock: func() spannerclient.ReadOnlyTransaction {
+				return &spannerclient.ReadOnlyTransactionMock{
+					QueryMock: func(ctx context.Context, stmt spanner.Statement) spannerclient.RowIterator {
+						values := rows(stmt)
+						i := 0
+						return &spannerclient.RowIteratorMock{
+							NextMock: func() (*spanner.Row, error) {
+								if i == len(values) {
+									return nil, iterator.Done
+								}
+								var names []string
+								for j := range values[i] {
+									names = append(names, fmt.Sprintf("c%d", j))
+								}
+								i++
+								return spanner.NewRow(names, values[i-1])
+							},
+							StopMock: func() {},
+						}
+					},
+				}
+			},
+		},
+	}
+}
+
+func TestPopulateSpannerSchema_NoDiffWithItsSchema(t *testing.T) {
+	infoSchema := infoSchemaMock(func(stmt spanner.Statement) [][]interface{} {
+		table, _ := stmt.Params["p1"].(string)
+		switch {
+		case strings.Contains(stmt.SQL, "interleave_type"):
+			return [][]interface{}{{"orders", "users", "CASCADE", "IN PARENT"}}
+		case strings.Contains(stmt.SQL, "information_schema.tables"):
+			return [][]interface{}{{"", "users"}, {"", "orders"}}
+		case strings.Contains(stmt.SQL, "information_schema.columns") && table == "users":
+			return [][]interface{}{{"user_id", "INT64", "NO"}, {"email", "STRING(MAX)", "YES"}, {"name", "STRING(MAX)", "YES"}}
+		case strings.Contains(stmt.SQL, "information_schema.columns"):
+			return [][]interface{}{{"user_id", "INT64", "NO"}, {"order_id", "INT64", "NO"}, {"created", "TIMESTAMP", "YES"}}
+		case strings.Contains(stmt.SQL, "constraint_column_usage"):
+			return nil
+		case strings.Contains(stmt.SQL, "information_schema.table_constraints") && table == "users":
+			return [][]interface{}{{"user_id", "PRIMARY KEY"}}
+		case strings.Contains(stmt.SQL, "information_schema.table_constraints"):
+			return [][]interface{}{{"user_id", "PRIMARY KEY"}, {"order_id", "PRIMARY KEY"}}
+		case strings.Contains(stmt.SQL, "information_schema.index_columns") && table == "users":
+			return [][]interface{}{{"users_by_email", "email", int64(1), "ASC", true}, {"users_by_email", "name", spanner.NullInt64{}, spanner.NullString{}, true}}
+		case strings.Contains(stmt.SQL, "information_schema.index_columns"):
+			return [][]interface{}{{"orders_by_created", "user_id", int64(1), "ASC", false}, {"orders_by_created", "created", int64(2), "DESC", false}}
+		case strings.Contains(stmt.SQL, "is_null_filtered"):
+			return [][]interface{}{{"users", "users_by_email", "", true}, {"orders", "orders_by_created", "users", false}}
+		}
+		return nil
+	})
+	conv := internal.MakeConv()
+	err := infoSchema.populateSpannerSchema(conv, &common.SchemaToSpannerImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+
+	// The schema of the database, as the schema file of schema-diff gives it.
+	target := ddl.Schema{
+		"t1": {
+			Name: "users", Id: "t1", ColIds: []string{"c1", "c2", "c5"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "user_id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Name: "email", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c5": {Name: "name", Id: "c5", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			Indexes:     []ddl.CreateIndex{{Name: "users_by_email", TableId: "t1", Unique: true, NullFiltered: true, Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}}, StoredColumnIds: []string{"c5"}}},
+		},
+		"t2": {
+			Name: "orders", Id: "t2", ColIds: []string{"c3", "c4", "c6"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c3": {Name: "user_id", Id: "c3", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c4": {Name: "order_id", Id: "c4", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c6": {Name: "created", Id: "c6", T: ddl.Type{Name: ddl.Timestamp}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c3", Order: 1}, {ColId: "c4", Order: 2}},
+			Indexes:     []ddl.CreateIndex{{Name: "orders_by_created", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c3", Order: 1}, {ColId: "c6", Desc: true, Order: 2}}, InterleaveIn: "t1"}},
+			ParentTable: ddl.InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+		},
+	}
+	diff := ddl.DiffSchemas(ddl.Config{ProtectIds: true}, conv.SpSchema, target)
+	assert.Empty(t, diff.Changes)
+	assert.Empty(t, diff.Unsupported)
+
+	again := internal.MakeConv()
+	assert.Nil(t, infoSchema.populateSpannerSchema(again, &common.SchemaToSpannerImpl{}, &common.InfoSchemaImpl{}))
+	diff = ddl.DiffSchemas(ddl.Config{ProtectIds: true}, conv.SpSchema, again.SpSchema)
+	assert.Empty(t, diff.Changes)
+	assert.Empty(t, diff.Unsupported)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
 
@@ -36,6 +37,12 @@ import (
 // to send to Spanner.  ProcessDataRow is only called in DataMode.
 func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, vals []string) {
 	spTableName, cvtCols, cvtVals, err := ConvertData(conv, tableId, colIds, srcSchema, spSchema, vals)
+	writeDataRow(conv, colIds, srcSchema, vals, spTableName, cvtCols, cvtVals, err)
+}
+
+// writeDataRow writes out a row converted by ConvertData, or records it as a
+// bad row if err, the error of its conversion, is set.
+func writeDataRow(conv *internal.Conv, colIds []string, srcSchema schema.Table, vals []string, spTableName string, cvtCols []string, cvtVals []interface{}, err error) {
 	srcTableName := srcSchema.Name
 	srcCols := []string{}
 	for _, colId := range colIds {
@@ -44,7 +51,7 @@ func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSch
 	if err != nil {
 		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
 		conv.StatsAddBadRow(srcTableName, conv.DataMode())
-		conv.CollectBadRow(srcTableName, srcCols, vals)
+		conv.CollectBadRow(srcTableName, srcCols, vals, err)
 	} else {
 		conv.WriteRow(srcTableName, spTableName, cvtCols, cvtVals)
 	}
@@ -73,7 +80,7 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, srcSchema
 		}
 		var x interface{}
 		var err error
-		x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.TimezoneOffset, vals[i])
+		x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.TimezoneOffset, conv.TimezoneLocation(tableId, colId), vals[i])
 		if err != nil {
 			return "", []string{}, []interface{}{}, err
 		}
@@ -93,7 +100,7 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, srcSchema
 // appropriate Spanner value. It is the caller's responsibility to
 // detect and handle NULL values: convScalar will return error if a
 // NULL value is passed.
-func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, timezoneOffset string, val string) (interface{}, error) {
+func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, timezoneOffset string, location *time.Location, val string) (interface{}, error) {
 	// Whitespace within the val string is considered part of the data value.
 	// Note that many of the underlying conversions functions we use (like
 	// strconv.ParseFloat and strconv.ParseInt) return "invalid syntax"
@@ -116,7 +123,9 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, t
 	case ddl.String:
 		return val, nil
 	case ddl.Timestamp:
-		return convTimestamp(srcTypeName, val)
+		return convTimestamp(srcTypeName, location, val)
+	case ddl.UUID:
+		return common.ConvertUUID(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
@@ -137,7 +146,9 @@ func convBytes(val string) ([]byte, error) {
 }
 
 func convDate(val string) (civil.Date, error) {
-	date := strings.Fields(val)
+	// Datetime values converted to dates, e.g. by a DATE timezone policy,
+	// keep just their date.
+	date := strings.Fields(strings.SplitN(val, "T", 2)[0])
 	d, err := civil.ParseDate(date[0])
 	if err != nil {
 		return d, fmt.Errorf("can't convert to date: %w", err)
@@ -183,8 +194,10 @@ func convNumeric(conv *internal.Conv, val string) (interface{}, error) {
 	}
 }
 
-// convTimestamp maps a source DB datetime types to Spanner timestamp
-func convTimestamp(srcTypeName string, val string) (t time.Time, err error) {
+// convTimestamp maps a source DB datetime types to Spanner timestamp.
+// Values without an offset are interpreted in location, the location of the
+// timezone policy of the column, or as UTC if it is nil.
+func convTimestamp(srcTypeName string, location *time.Location, val string) (t time.Time, err error) {
 	// the query returns the datetime in ISO8601
 	// e.g. 2021-12-15T07:39:52.943 			(datetime)
 	// e.g. 2021-12-15T07:39:52.9433333 		(datetime2)
@@ -194,7 +207,10 @@ func convTimestamp(srcTypeName string, val string) (t time.Time, err error) {
 	if srcTypeName == dateTimeOffsetType {
 		t, err = time.Parse(time.RFC3339, val)
 	} else {
-		t, err = time.Parse("2006-01-02T15:04:05", val)
+		if location == nil {
+			location = time.UTC
+		}
+		t, err = time.ParseInLocation("2006-01-02T15:04:05", val, location)
 	}
 	if err != nil {
 		return t, fmt.Errorf("can't convert to timestamp (mssql type: %s)", srcTypeName)
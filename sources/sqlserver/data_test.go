@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -96,6 +97,7 @@ func TestConvertData(t *testing.T) {
 		{"datetimeoffset", ddl.Type{Name: ddl.Timestamp}, "datetimeoffset", "2021-12-15T07:39:52.9433333+01:20", getTimeWithTimezone(t, "2021-12-15T07:39:52.9433333+01:20")},
 		{"decimal", ddl.Type{Name: ddl.Numeric}, "decimal", "234.90909090909", big.NewRat(23490909090909, 100000000000)},
 		{"numeric", ddl.Type{Name: ddl.Numeric}, "numeric", numStr, numVal},
+		{"uniqueidentifier", ddl.Type{Name: ddl.UUID}, "uniqueidentifier", "6F9619FF-8B86-D011-B42D-00C04FC964FF", uuid.MustParse("6f9619ff-8b86-d011-b42d-00c04fc964ff")},
 	}
 	tableName := "testtable"
 	tableId := "t1"
@@ -122,6 +124,35 @@ func TestConvertData(t *testing.T) {
 	}
 }
 
+func TestConvertData_TimezonePolicy(t *testing.T) {
+	colIds := []string{"c1", "c2", "c3", "c4"}
+	conv := buildConv(
+		ddl.CreateTable{Name: "t", Id: "t1", ColIds: colIds, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Timestamp}},
+			"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+			"c3": {Name: "c", Id: "c3", T: ddl.Type{Name: ddl.Timestamp}},
+			"c4": {Name: "d", Id: "c4", T: ddl.Type{Name: ddl.Date}},
+		}},
+		schema.Table{Name: "t", Id: "t1", ColIds: colIds, ColDefs: map[string]schema.Column{
+			"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "datetime2"}},
+			"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "datetimeoffset"}},
+			"c3": {Name: "c", Id: "c3", Type: schema.Type{Name: "datetime"}},
+			"c4": {Name: "d", Id: "c4", Type: schema.Type{Name: "datetime"}},
+		}})
+	conv.SetTimezonePolicy("t1", "", internal.TimezonePolicy{Mode: internal.TimezoneZone, Location: "Asia/Kolkata"})
+	conv.SetTimezonePolicy("t1", "c3", internal.TimezonePolicy{Mode: internal.TimezoneUTC})
+	conv.SetTimezonePolicy("t1", "c4", internal.TimezonePolicy{Mode: internal.TimezoneDate})
+	vals := []string{"2019-10-29T05:30:00.9433333", "2019-10-29T05:30:00+02:00", "2019-10-29T05:30:00.943", "2019-10-29T05:30:00.943"}
+	_, ac, av, err := ConvertData(conv, "t1", colIds, conv.SrcSchema["t1"], conv.SpSchema["t1"], vals)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ac)
+	assert.True(t, getTimeWithTimezone(t, "2019-10-29T00:00:00.9433333Z").Equal(av[0].(time.Time)))
+	// Values with an offset keep it.
+	assert.True(t, getTimeWithTimezone(t, "2019-10-29T03:30:00Z").Equal(av[1].(time.Time)))
+	assert.True(t, getTimeWithTimezone(t, "2019-10-29T05:30:00.943Z").Equal(av[2].(time.Time)))
+	assert.Equal(t, getDate("2019-10-29"), av[3])
+}
+
 func TestConvertMultiColData(t *testing.T) {
 	multiColTests := []struct {
 		name   string
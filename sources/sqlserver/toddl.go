@@ -114,11 +114,13 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 				return ddl.Type{Name: ddl.Bytes, Len: srcType.Mods[0]}, nil
 			}
 			return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil
-		default:
+		case ddl.String:
 			if len(srcType.Mods) > 0 && srcType.Mods[0] > 0 {
 				return ddl.Type{Name: ddl.String, Len: srcType.Mods[0]}, nil
 			}
 			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		default:
+			return ddl.Type{Name: ddl.UUID}, nil
 		}
 	case "varchar", "char", "nvarchar", "nchar":
 		switch spType {
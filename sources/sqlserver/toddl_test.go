@@ -210,7 +210,7 @@ func TestToSpannerType(t *testing.T) {
 			"c12": {Name: "l", Id: "c12", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
 			"c13": {Name: "m", Id: "c13", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
 			"c14": {Name: "n", Id: "c14", T: ddl.Type{Name: ddl.Bool}},
-			"c15": {Name: "o", Id: "c15", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c15": {Name: "o", Id: "c15", T: ddl.Type{Name: ddl.UUID}},
 			"c22": {Name: "p", Id: "c22", T: ddl.Type{Name: ddl.Float32}},
 		},
 
@@ -324,7 +324,7 @@ func TestToSpannerPostgreSQLDialectType(t *testing.T) {
 			"c12": {Name: "l", Id: "c12", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
 			"c13": {Name: "m", Id: "c13", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
 			"c14": {Name: "n", Id: "c14", T: ddl.Type{Name: ddl.Bool}},
-			"c15": {Name: "o", Id: "c15", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c15": {Name: "o", Id: "c15", T: ddl.Type{Name: ddl.UUID}},
 			"c22": {Name: "p", Id: "c22", T: ddl.Type{Name: ddl.Float32}},
 		},
 
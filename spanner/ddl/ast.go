@@ -55,6 +55,12 @@ const (
 	Numeric string = "NUMERIC"
 	// Json represent JSON type.
 	JSON string = "JSON"
+	// UUID represents the UUID type.
+	UUID string = "UUID"
+	// Enum represents a proto ENUM type, named by Type.ProtoName.
+	Enum string = "ENUM"
+	// Proto represents a PROTO message type, named by Type.ProtoName.
+	Proto string = "PROTO"
 	// MaxLength is a sentinel for Type's Len field, representing the MAX value.
 	MaxLength = math.MaxInt64
 	// StringMaxLength represents maximum allowed STRING length.
@@ -119,7 +125,7 @@ var PGSQL_RESERVED_KEYWORD_LIST = []string{"ALL", "ANALYSE", "ANALYZE", "AND", "
 // Type represents the type of a column.
 //
 //	type:
-//	   { BOOL | INT64 | FLOAT32 | FLOAT64 | STRING( length ) | BYTES( length ) | DATE | TIMESTAMP | NUMERIC }
+//	   { BOOL | INT64 | FLOAT32 | FLOAT64 | STRING( length ) | BYTES( length ) | DATE | TIMESTAMP | NUMERIC | proto_type_name }
 type Type struct {
 	Name string
 	// Len encodes the following Spanner DDL definition:
@@ -129,11 +135,17 @@ type Type struct {
 	// IsArray represents if Type is an array_type or not
 	// When false, column has type T; when true, it is an array of type T.
 	IsArray bool
+	// ProtoName is the fully qualified name of the proto enum or message
+	// of ENUM and PROTO types, e.g. spanner_migration.users_status.
+	ProtoName string `json:",omitempty"`
 }
 
 // PrintColumnDefType unparses the type encoded in a ColumnDef.
 func (ty Type) PrintColumnDefType(isVirtual bool) string {
 	str := ty.Name
+	if ty.Name == Enum || ty.Name == Proto {
+		str = "`" + ty.ProtoName + "`"
+	}
 	if ty.Name == String || ty.Name == Bytes {
 		str += "("
 		if ty.Len == MaxLength || isVirtual {
@@ -242,20 +254,40 @@ func (c Config) quote(s string) string {
 func (cd ColumnDef) PrintColumnDef(c Config) (string, string) {
 	var s string
 	if c.SpDialect == constants.DIALECT_POSTGRESQL {
-		s = fmt.Sprintf("%s %s", c.quote(cd.Name), cd.T.PGPrintColumnDefType(cd.GeneratedColumn.IsVirtual()))
+		// Array columns of a vector index must set their length, and are
+		// printed as arrays of floats rather than as strings.
+		if vectorLength := cd.Opts["vector_length"]; cd.T.IsArray && vectorLength != "" {
+			s = fmt.Sprintf("%s %s[] VECTOR LENGTH %s", c.quote(cd.Name), GetPGType(Type{Name: cd.T.Name}), vectorLength)
+		} else {
+			s = fmt.Sprintf("%s %s", c.quote(cd.Name), cd.T.PGPrintColumnDefType(cd.GeneratedColumn.IsVirtual()))
+		}
 		if cd.NotNull {
 			s += " NOT NULL "
 		}
 		s += cd.DefaultValue.PGPrintDefaultValue(cd.T)
-		s += cd.AutoGen.PGPrintAutoGenCol(c)
+		if cd.T.Name == UUID && cd.AutoGen.isPredefinedUUID() {
+			// spanner.generate_uuid() returns a string.
+			s += " DEFAULT (CAST(spanner.generate_uuid() AS UUID))"
+		} else {
+			s += cd.AutoGen.PGPrintAutoGenCol(c)
+		}
 		s += cd.GeneratedColumn.PGPrintGeneratedColumn(cd.T)
 	} else {
 		s = fmt.Sprintf("%s %s", c.quote(cd.Name), cd.T.PrintColumnDefType(cd.GeneratedColumn.IsVirtual()))
+		// Array columns of a vector index must set their length.
+		if vectorLength := cd.Opts["vector_length"]; cd.T.IsArray && vectorLength != "" {
+			s += fmt.Sprintf("(vector_length=>%s)", vectorLength)
+		}
 		if cd.NotNull {
 			s += " NOT NULL "
 		}
 		s += cd.DefaultValue.PrintDefaultValue(cd.T)
-		s += cd.AutoGen.PrintAutoGenCol(c)
+		if cd.T.Name == UUID && cd.AutoGen.isPredefinedUUID() {
+			// GENERATE_UUID() returns a STRING.
+			s += " DEFAULT (NEW_UUID())"
+		} else {
+			s += cd.AutoGen.PrintAutoGenCol(c)
+		}
 		s += cd.GeneratedColumn.PrintGeneratedColumn(cd.T)
 	}
 	var opts []string
@@ -322,6 +354,46 @@ type InterleavedParent struct {
 	InterleaveType string
 }
 
+// RowDeletionPolicy encodes the following DDL definition:
+//
+//	ROW DELETION POLICY ( OLDER_THAN ( timestamp_column, INTERVAL num_days DAY ) )
+//
+// or, for the PostgreSQL dialect:
+//
+//	TTL INTERVAL 'num_days days' ON timestamp_column
+//
+// Spanner deletes the rows whose timestamp_column is older than num_days.
+type RowDeletionPolicy struct {
+	ColId string // Empty if the table has no row deletion policy.
+	Days  int64
+}
+
+// PrintRowDeletionPolicy unparses the row deletion policy of table ct.
+func (p RowDeletionPolicy) PrintRowDeletionPolicy(ct CreateTable, c Config) string {
+	col := c.quote(ct.ColDefs[p.ColId].Name)
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Sprintf("TTL INTERVAL '%d days' ON %s", p.Days, col)
+	}
+	return fmt.Sprintf("ROW DELETION POLICY (OLDER_THAN(%s, INTERVAL %d DAY))", col, p.Days)
+}
+
+// ValidateRowDeletionPolicy checks that p can be the row deletion policy of
+// table ct: its column must be a TIMESTAMP column of ct, and its number of
+// days can't be negative.
+func (ct CreateTable) ValidateRowDeletionPolicy(p RowDeletionPolicy) error {
+	cd, ok := ct.ColDefs[p.ColId]
+	if !ok {
+		return fmt.Errorf("column %s of the row deletion policy isn't a column of table %s", p.ColId, ct.Name)
+	}
+	if cd.T.Name != Timestamp || cd.T.IsArray {
+		return fmt.Errorf("column %s of the row deletion policy of table %s must be a TIMESTAMP column, not %s", cd.Name, ct.Name, cd.T.PrintColumnDefType(false))
+	}
+	if p.Days < 0 {
+		return fmt.Errorf("row deletion policy of table %s has a negative number of days: %d", ct.Name, p.Days)
+	}
+	return nil
+}
+
 // PrintForeignKey unparses the foreign keys.
 func (k Foreignkey) PrintForeignKey(c Config) string {
 	var cols, referCols []string
@@ -342,21 +414,28 @@ func (k Foreignkey) PrintForeignKey(c Config) string {
 
 // CreateTable encodes the following DDL definition:
 //
-//	create_table: CREATE TABLE table_name ([column_def, ...] ) primary_key [, cluster]
+//	create_table: CREATE TABLE table_name ([column_def, ...] ) primary_key [, cluster] [, row_deletion_policy]
 type CreateTable struct {
-	Name             string
-	ColIds           []string // Provides names and order of columns
-	ShardIdColumn    string
-	ColDefs          map[string]ColumnDef // Provides definition of columns (a map for simpler/faster lookup during type processing)
-	PrimaryKeys      []IndexKey
-	ForeignKeys      []Foreignkey
-	Indexes          []CreateIndex
-	ParentTable      InterleavedParent // if not empty, this table will be interleaved
-	CheckConstraints []CheckConstraint
-	Comment          string
-	Id               string
+	Name              string
+	ColIds            []string // Provides names and order of columns
+	ShardIdColumn     string
+	ColDefs           map[string]ColumnDef // Provides definition of columns (a map for simpler/faster lookup during type processing)
+	PrimaryKeys       []IndexKey
+	ForeignKeys       []Foreignkey
+	Indexes           []CreateIndex
+	SearchIndexes     []CreateSearchIndex
+	VectorIndexes     []CreateVectorIndex
+	ParentTable       InterleavedParent // if not empty, this table will be interleaved
+	CheckConstraints  []CheckConstraint
+	RowDeletionPolicy RowDeletionPolicy // if not empty, old rows of this table are deleted
+	Comment           string
+	Id                string
 }
 
+// newlineReplacer replaces line breaks with spaces, for comments that must
+// fit on a single line.
+var newlineReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
 // PrintCreateTable unparses a CREATE TABLE statement.
 func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 	var col []string
@@ -374,7 +453,9 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 	for i, c := range col {
 		cols += c
 		if config.Comments && len(colComment[i]) > 0 {
-			cols += strings.Repeat(" ", n-len(c)) + " -- " + colComment[i]
+			// Column comments are printed at the end of the line, so
+			// comments from the source spanning several lines are joined.
+			cols += strings.Repeat(" ", n-len(c)) + " -- " + newlineReplacer.Replace(colComment[i])
 		}
 		cols += "\n"
 	}
@@ -390,7 +471,7 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 	}
 	var tableComment string
 	if config.Comments && len(ct.Comment) > 0 {
-		tableComment = "--\n-- " + ct.Comment + "\n--\n"
+		tableComment = "--\n-- " + strings.ReplaceAll(ct.Comment, "\n", "\n-- ") + "\n--\n"
 	}
 
 	var interleave string
@@ -420,6 +501,14 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 		}
 	}
 
+	if ct.RowDeletionPolicy.ColId != "" {
+		if config.SpDialect == constants.DIALECT_POSTGRESQL {
+			interleave += " " + ct.RowDeletionPolicy.PrintRowDeletionPolicy(ct, config)
+		} else {
+			interleave += ",\n" + ct.RowDeletionPolicy.PrintRowDeletionPolicy(ct, config)
+		}
+	}
+
 	var checkString string
 	if len(ct.CheckConstraints) > 0 {
 		checkString = FormatCheckConstraints(ct.CheckConstraints, config.SpDialect)
@@ -439,6 +528,9 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 // CreateIndex encodes the following DDL definition:
 //
 //	create index: CREATE [UNIQUE] [NULL_FILTERED] INDEX index_name ON table_name ( key_part [, ...] ) [ storing_clause ] [ , interleave_clause ]
+//
+// For the PostgreSQL dialect, NULL_FILTERED is printed as a WHERE clause on the
+// key columns and the interleave clause has no leading comma.
 type CreateIndex struct {
 	Name            string
 	TableId         string `json:"TableId"`
@@ -446,8 +538,8 @@ type CreateIndex struct {
 	Keys            []IndexKey
 	Id              string
 	StoredColumnIds []string
-	// We have no requirements for null-filtered option and
-	// interleaving clauses yet, so we omit them for now.
+	NullFiltered    bool   // If true, rows with a NULL in any key column aren't indexed.
+	InterleaveIn    string // Id of the ancestor table the index is interleaved in, if any.
 }
 
 // GeneratedColumn represents a Generated Column.
@@ -492,7 +584,7 @@ func (gc GeneratedColumn) PrintGeneratedColumn(ty Type) string {
 	}
 	var value string
 	switch ty.Name {
-	case "FLOAT32", "NUMERIC", "BOOL", "BYTES":
+	case "FLOAT32", "NUMERIC", "BOOL", "BYTES", "UUID":
 		value = fmt.Sprintf(" AS (CAST(%s AS %s))", gc.Value.Statement, ty.Name)
 	default:
 		value = " AS (" + gc.Value.Statement + ")"
@@ -509,7 +601,7 @@ func (dv DefaultValue) PrintDefaultValue(ty Type) string {
 	}
 	var value string
 	switch ty.Name {
-	case "FLOAT32", "NUMERIC", "BOOL", "BYTES":
+	case "FLOAT32", "NUMERIC", "BOOL", "BYTES", "UUID":
 		value = fmt.Sprintf(" DEFAULT (CAST(%s AS %s))", dv.Value.Statement, ty.Name)
 	default:
 		value = " DEFAULT (" + dv.Value.Statement + ")"
@@ -523,7 +615,7 @@ func (gc GeneratedColumn) PGPrintGeneratedColumn(ty Type) string {
 	}
 	var value string
 	switch GetPGType(ty) {
-	case "FLOAT8", "FLOAT4", "REAL", "NUMERIC", "DECIMAL", "BOOL", "BYTEA":
+	case "FLOAT8", "FLOAT4", "REAL", "NUMERIC", "DECIMAL", "BOOL", "BYTEA", "UUID":
 		value = fmt.Sprintf(" GENERATED ALWAYS AS (CAST(%s AS %s))", gc.Value.Statement, GetPGType(ty))
 	default:
 		value = " GENERATED ALWAYS AS (" + gc.Value.Statement + ")"
@@ -538,7 +630,7 @@ func (dv DefaultValue) PGPrintDefaultValue(ty Type) string {
 	}
 	var value string
 	switch GetPGType(ty) {
-	case "FLOAT8", "FLOAT4", "REAL", "NUMERIC", "DECIMAL", "BOOL", "BYTEA":
+	case "FLOAT8", "FLOAT4", "REAL", "NUMERIC", "DECIMAL", "BOOL", "BYTEA", "UUID":
 		value = fmt.Sprintf(" DEFAULT (CAST(%s AS %s))", dv.Value.Statement, GetPGType(ty))
 	default:
 		value = " DEFAULT (" + dv.Value.Statement + ")"
@@ -546,8 +638,12 @@ func (dv DefaultValue) PGPrintDefaultValue(ty Type) string {
 	return value
 }
 
+func (agc AutoGenCol) isPredefinedUUID() bool {
+	return agc.Name == constants.UUID && agc.GenerationType == "Pre-defined"
+}
+
 func (agc AutoGenCol) PrintAutoGenCol(c Config) string {
-	if agc.Name == constants.UUID && agc.GenerationType == "Pre-defined" {
+	if agc.isPredefinedUUID() {
 		return " DEFAULT (GENERATE_UUID())"
 	}
 	if agc.GenerationType == constants.SEQUENCE {
@@ -560,7 +656,7 @@ func (agc AutoGenCol) PrintAutoGenCol(c Config) string {
 }
 
 func (agc AutoGenCol) PGPrintAutoGenCol(c Config) string {
-	if agc.Name == constants.UUID && agc.GenerationType == "Pre-defined" {
+	if agc.isPredefinedUUID() {
 		return " DEFAULT (spanner.generate_uuid())"
 	}
 	if agc.GenerationType == constants.SEQUENCE {
@@ -590,8 +686,10 @@ func (agc AutoGenCol) PrintIdentityCol(c Config) string {
 	return fmt.Sprintf(" GENERATED BY DEFAULT AS IDENTITY (%s)", strings.Join(options, " "))
 }
 
-// PrintCreateIndex unparses a CREATE INDEX statement.
-func (ci CreateIndex) PrintCreateIndex(ct CreateTable, c Config) string {
+// PrintCreateIndex unparses a CREATE INDEX statement. spSchema is used to
+// print the name of the table the index is interleaved in; the interleave
+// clause is left out if that table isn't an ancestor of ct anymore.
+func (ci CreateIndex) PrintCreateIndex(spSchema Schema, ct CreateTable, c Config) string {
 	var keys []string
 
 	orderedKeys := []IndexKey{}
@@ -603,7 +701,7 @@ func (ci CreateIndex) PrintCreateIndex(ct CreateTable, c Config) string {
 	for _, p := range orderedKeys {
 		keys = append(keys, p.PrintPkOrIndexKey(ct, c))
 	}
-	var unique, stored, storingClause string
+	var unique, nullFiltered, stored, storingClause, interleaveClause, whereClause string
 	if ci.Unique {
 		unique = "UNIQUE "
 	}
@@ -621,7 +719,26 @@ func (ci CreateIndex) PrintCreateIndex(ct CreateTable, c Config) string {
 		}
 		storingClause = fmt.Sprintf(" %s (%s)", stored, strings.Join(storedColumns, ", "))
 	}
-	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)%s", unique, c.quote(ci.Name), c.quote(ct.Name), strings.Join(keys, ", "), storingClause)
+	if spSchema.IsAncestor(ct.Id, ci.InterleaveIn) {
+		parent := spSchema[ci.InterleaveIn]
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			interleaveClause = " INTERLEAVE IN " + c.quote(parent.Name)
+		} else {
+			interleaveClause = ", INTERLEAVE IN " + c.quote(parent.Name)
+		}
+	}
+	if ci.NullFiltered {
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			var conditions []string
+			for _, k := range orderedKeys {
+				conditions = append(conditions, c.quote(ct.ColDefs[k.ColId].Name)+" IS NOT NULL")
+			}
+			whereClause = " WHERE " + strings.Join(conditions, " AND ")
+		} else {
+			nullFiltered = "NULL_FILTERED "
+		}
+	}
+	return fmt.Sprintf("CREATE %s%sINDEX %s ON %s (%s)%s%s%s", unique, nullFiltered, c.quote(ci.Name), c.quote(ct.Name), strings.Join(keys, ", "), storingClause, interleaveClause, whereClause)
 }
 
 // Checks if the colId is part of the primary of a table
@@ -636,6 +753,169 @@ func isStoredColumnKeyPartOfPrimaryKey(ct CreateTable, colId string) bool {
 	return false
 }
 
+// CreateSearchIndex encodes the following DDL definition:
+//
+//	create search index: CREATE SEARCH INDEX index_name ON table_name ( tokenlist_column [, ...] ) [ storing_clause ] [ PARTITION BY column_name [, ...] ] [ ORDER BY key_part [, ...] ] [ , interleave_clause ] [ OPTIONS ( sort_order_sharding = true ) ]
+//
+// The TOKENLIST columns of the index aren't part of the table: each key
+// tokenizes a column of the table into a hidden generated column, which is
+// added to the table before the index is created.
+type CreateSearchIndex struct {
+	Name              string
+	Id                string
+	TableId           string
+	Keys              []SearchIndexKey
+	StoredColumnIds   []string
+	PartitionColIds   []string
+	OrderBy           []IndexKey
+	InterleaveIn      string // Id of the ancestor table the index is interleaved in, if any.
+	SortOrderSharding bool
+}
+
+// SearchIndexKey is a column of a search index, tokenized by one of the
+// TOKENIZE_* functions of Spanner, e.g. TOKENIZE_FULLTEXT.
+type SearchIndexKey struct {
+	ColId       string
+	Tokenizer   string
+	TokenColumn string // Name of the hidden TOKENLIST column. Defaults to <column name>_Tokens.
+}
+
+// TokenColumnName returns the name of the hidden TOKENLIST column of the key.
+func (k SearchIndexKey) TokenColumnName(ct CreateTable) string {
+	if k.TokenColumn != "" {
+		return k.TokenColumn
+	}
+	return ct.ColDefs[k.ColId].Name + "_Tokens"
+}
+
+// PrintCreateSearchIndex unparses the ALTER TABLE statements adding the
+// TOKENLIST columns of the index, followed by the CREATE SEARCH INDEX
+// statement.
+func (si CreateSearchIndex) PrintCreateSearchIndex(spSchema Schema, ct CreateTable, c Config) []string {
+	var ddl, keys []string
+	for _, k := range si.Keys {
+		tokenColumn := k.TokenColumnName(ct)
+		col := c.quote(ct.ColDefs[k.ColId].Name)
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			ddl = append(ddl, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s spanner.tokenlist GENERATED ALWAYS AS (spanner.%s(%s)) VIRTUAL HIDDEN", c.quote(ct.Name), c.quote(tokenColumn), strings.ToLower(k.Tokenizer), col))
+		} else {
+			ddl = append(ddl, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s TOKENLIST AS (%s(%s)) HIDDEN", c.quote(ct.Name), c.quote(tokenColumn), strings.ToUpper(k.Tokenizer), col))
+		}
+		keys = append(keys, c.quote(tokenColumn))
+	}
+	s := fmt.Sprintf("CREATE SEARCH INDEX %s ON %s (%s)", c.quote(si.Name), c.quote(ct.Name), strings.Join(keys, ", "))
+	if len(si.StoredColumnIds) > 0 {
+		var storedColumns []string
+		for _, colId := range si.StoredColumnIds {
+			storedColumns = append(storedColumns, c.quote(ct.ColDefs[colId].Name))
+		}
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			s += fmt.Sprintf(" INCLUDE (%s)", strings.Join(storedColumns, ", "))
+		} else {
+			s += fmt.Sprintf(" STORING (%s)", strings.Join(storedColumns, ", "))
+		}
+	}
+	if len(si.PartitionColIds) > 0 {
+		var partitionColumns []string
+		for _, colId := range si.PartitionColIds {
+			partitionColumns = append(partitionColumns, c.quote(ct.ColDefs[colId].Name))
+		}
+		s += fmt.Sprintf(" PARTITION BY %s", strings.Join(partitionColumns, ", "))
+	}
+	if len(si.OrderBy) > 0 {
+		var orderBy []string
+		for _, k := range si.OrderBy {
+			orderBy = append(orderBy, k.PrintPkOrIndexKey(ct, c))
+		}
+		s += fmt.Sprintf(" ORDER BY %s", strings.Join(orderBy, ", "))
+	}
+	if spSchema.IsAncestor(ct.Id, si.InterleaveIn) {
+		parent := spSchema[si.InterleaveIn]
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			s += " INTERLEAVE IN " + c.quote(parent.Name)
+		} else {
+			s += ", INTERLEAVE IN " + c.quote(parent.Name)
+		}
+	}
+	if si.SortOrderSharding {
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			s += " WITH (sort_order_sharding = true)"
+		} else {
+			s += " OPTIONS (sort_order_sharding = true)"
+		}
+	}
+	return append(ddl, s)
+}
+
+// CreateVectorIndex encodes the following DDL definition:
+//
+//	create vector index: CREATE VECTOR INDEX index_name ON table_name ( column_name ) [ storing_clause ] [ WHERE column_name IS NOT NULL ] OPTIONS ( distance_type = 'distance_type' [, tree_depth = n ] [, num_leaves = n ] [, num_branches = n ] )
+//
+// For the PostgreSQL dialect, it is printed as a CREATE INDEX ... USING ScaNN
+// statement.
+type CreateVectorIndex struct {
+	Name            string
+	Id              string
+	TableId         string
+	ColId           string
+	StoredColumnIds []string
+	DistanceType    string // COSINE, EUCLIDEAN or DOT_PRODUCT.
+	TreeDepth       int64  // 0 leaves the option to Spanner's default, as for NumLeaves and NumBranches.
+	NumLeaves       int64
+	NumBranches     int64
+}
+
+var pgVectorDistanceFunctions = map[string]string{
+	"COSINE":      "spanner.cosine_distance",
+	"EUCLIDEAN":   "spanner.euclidean_distance",
+	"DOT_PRODUCT": "spanner.dot_product",
+}
+
+// PrintCreateVectorIndex unparses a CREATE VECTOR INDEX statement. Spanner
+// requires a nullable column to be filtered with WHERE column IS NOT NULL.
+func (vi CreateVectorIndex) PrintCreateVectorIndex(ct CreateTable, c Config) string {
+	cd := ct.ColDefs[vi.ColId]
+	col := c.quote(cd.Name)
+	var options, storedColumns []string
+	for _, colId := range vi.StoredColumnIds {
+		storedColumns = append(storedColumns, c.quote(ct.ColDefs[colId].Name))
+	}
+	if c.SpDialect != constants.DIALECT_POSTGRESQL {
+		options = append(options, fmt.Sprintf("distance_type = '%s'", strings.ToUpper(vi.DistanceType)))
+	}
+	if vi.TreeDepth > 0 {
+		options = append(options, fmt.Sprintf("tree_depth = %d", vi.TreeDepth))
+	}
+	if vi.NumLeaves > 0 {
+		options = append(options, fmt.Sprintf("num_leaves = %d", vi.NumLeaves))
+	}
+	if vi.NumBranches > 0 {
+		options = append(options, fmt.Sprintf("num_branches = %d", vi.NumBranches))
+	}
+	var s string
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		s = fmt.Sprintf("CREATE INDEX %s ON %s USING ScaNN (%s %s)", c.quote(vi.Name), c.quote(ct.Name), col, pgVectorDistanceFunctions[strings.ToUpper(vi.DistanceType)])
+		if len(storedColumns) > 0 {
+			s += fmt.Sprintf(" INCLUDE (%s)", strings.Join(storedColumns, ", "))
+		}
+		if len(options) > 0 {
+			s += fmt.Sprintf(" WITH (%s)", strings.Join(options, ", "))
+		}
+		if !cd.NotNull {
+			s += fmt.Sprintf(" WHERE %s IS NOT NULL", col)
+		}
+		return s
+	}
+	s = fmt.Sprintf("CREATE VECTOR INDEX %s ON %s (%s)", c.quote(vi.Name), c.quote(ct.Name), col)
+	if len(storedColumns) > 0 {
+		s += fmt.Sprintf(" STORING (%s)", strings.Join(storedColumns, ", "))
+	}
+	if !cd.NotNull {
+		s += fmt.Sprintf(" WHERE %s IS NOT NULL", col)
+	}
+	return s + fmt.Sprintf(" OPTIONS (%s)", strings.Join(options, ", "))
+}
+
 // PrintForeignKeyAlterTable unparses the foreign keys using ALTER TABLE.
 func (k Foreignkey) PrintForeignKeyAlterTable(spannerSchema Schema, c Config, tableId string) string {
 	var cols, referCols []string
@@ -767,7 +1047,13 @@ func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence, db
 		for _, tableId := range tableIds {
 			ddl = append(ddl, tableSchema[tableId].PrintCreateTable(tableSchema, c))
 			for _, index := range tableSchema[tableId].Indexes {
-				ddl = append(ddl, index.PrintCreateIndex(tableSchema[tableId], c))
+				ddl = append(ddl, index.PrintCreateIndex(tableSchema, tableSchema[tableId], c))
+			}
+			for _, index := range tableSchema[tableId].SearchIndexes {
+				ddl = append(ddl, index.PrintCreateSearchIndex(tableSchema, tableSchema[tableId], c)...)
+			}
+			for _, index := range tableSchema[tableId].VectorIndexes {
+				ddl = append(ddl, index.PrintCreateVectorIndex(tableSchema[tableId], c))
 			}
 		}
 	}
@@ -788,6 +1074,19 @@ func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence, db
 	return ddl
 }
 
+// IsAncestor reports whether ancestorId is the id of the parent table of
+// tableId, or of one of its ancestors.
+func (s Schema) IsAncestor(tableId, ancestorId string) bool {
+	seen := make(map[string]bool)
+	for id := s[tableId].ParentTable.Id; id != "" && !seen[id]; id = s[id].ParentTable.Id {
+		if id == ancestorId {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
+
 // CheckInterleaved checks if schema contains interleaved tables.
 func (s Schema) CheckInterleaved() bool {
 	for _, table := range s {
@@ -866,6 +1165,208 @@ func (seq Sequence) PGPrintSequence(c Config) string {
 	return seqDDL
 }
 
+// CreateView encodes the following DDL definition:
+//
+//	create_view: CREATE VIEW view_name SQL SECURITY { INVOKER | DEFINER } AS query
+type CreateView struct {
+	Name        string
+	Id          string
+	SqlSecurity string // INVOKER or DEFINER, defaults to INVOKER.
+	Query       string // Query of the view, in the dialect of the Spanner database.
+	Comment     string
+}
+
+// PrintCreateView unparses a CREATE VIEW statement.
+func (cv CreateView) PrintCreateView(c Config) string {
+	var viewComment string
+	if c.Comments && len(cv.Comment) > 0 {
+		viewComment = "--\n-- " + cv.Comment + "\n--\n"
+	}
+	sqlSecurity := cv.SqlSecurity
+	if sqlSecurity == "" {
+		sqlSecurity = "INVOKER"
+	}
+	return fmt.Sprintf("%sCREATE VIEW %s SQL SECURITY %s AS %s", viewComment, c.quote(cv.Name), sqlSecurity, strings.TrimSpace(cv.Query))
+}
+
+// GetViewsDDL returns the CREATE VIEW statements of views, sorted by name
+// except that a view comes after the views its query refers to. Views are
+// printed after the tables by the callers of GetDDL, since they can refer to
+// any table.
+func GetViewsDDL(c Config, views map[string]CreateView) []string {
+	var ddl []string
+	for _, viewId := range GetSortedViewIds(views) {
+		ddl = append(ddl, views[viewId].PrintCreateView(c))
+	}
+	return ddl
+}
+
+// GetSortedViewIds returns the ids of views sorted by name, with each view
+// after the views whose name appears as a word in its query.
+func GetSortedViewIds(views map[string]CreateView) []string {
+	var viewIds []string
+	for viewId := range views {
+		viewIds = append(viewIds, viewId)
+	}
+	sort.Slice(viewIds, func(i, j int) bool {
+		return views[viewIds[i]].Name < views[viewIds[j]].Name
+	})
+	var sortedViewIds []string
+	added := make(map[string]bool)
+	visiting := make(map[string]bool)
+	var visit func(viewId string)
+	visit = func(viewId string) {
+		if added[viewId] || visiting[viewId] {
+			return
+		}
+		visiting[viewId] = true
+		for _, otherId := range viewIds {
+			if otherId != viewId && views[viewId].DependsOn(views[otherId].Name) {
+				visit(otherId)
+			}
+		}
+		added[viewId] = true
+		sortedViewIds = append(sortedViewIds, viewId)
+	}
+	for _, viewId := range viewIds {
+		visit(viewId)
+	}
+	return sortedViewIds
+}
+
+// ChangeStream encodes the following DDL definition:
+//
+//	create change stream: CREATE CHANGE STREAM change_stream_name [ FOR { table_columns [, ...] | ALL } ] [ OPTIONS ( option [, ...] ) ]
+//	table_columns: table_name [ ( [ column_name, ... ] ) ]
+//
+// For the PostgreSQL dialect, the options are printed in a WITH clause.
+type ChangeStream struct {
+	Name              string
+	Id                string
+	WatchAll          bool // If true, the change stream watches all the tables and Tables is ignored.
+	Tables            []ChangeStreamTable
+	ValueCaptureType  string // OLD_AND_NEW_VALUES, NEW_ROW, NEW_VALUES or NEW_ROW_AND_OLD_VALUES.
+	RetentionPeriod   string // E.g. 7d. Spanner's default is 1d.
+	ExcludeTtlDeletes bool
+	ExcludeInsert     bool
+	ExcludeUpdate     bool
+	ExcludeDelete     bool
+}
+
+// ChangeStreamTable is a table watched by a change stream.
+type ChangeStreamTable struct {
+	TableId   string
+	ColumnIds []string // Watched non-key columns. All the columns are watched if empty, unless KeysOnly is set.
+	KeysOnly  bool     // If true, only the key columns are watched.
+}
+
+// PrintChangeStream unparses a CREATE CHANGE STREAM statement. Tables and
+// columns that aren't in spSchema anymore are skipped.
+func (cs ChangeStream) PrintChangeStream(spSchema Schema, c Config) string {
+	s := fmt.Sprintf("CREATE CHANGE STREAM %s", c.quote(cs.Name))
+	if cs.WatchAll {
+		s += " FOR ALL"
+	} else {
+		var tables []string
+		for _, t := range cs.Tables {
+			ct, ok := spSchema[t.TableId]
+			if !ok {
+				continue
+			}
+			table := c.quote(ct.Name)
+			if t.KeysOnly {
+				table += "()"
+			} else if len(t.ColumnIds) > 0 {
+				var cols []string
+				for _, colId := range t.ColumnIds {
+					if cd, ok := ct.ColDefs[colId]; ok {
+						cols = append(cols, c.quote(cd.Name))
+					}
+				}
+				table += fmt.Sprintf("(%s)", strings.Join(cols, ", "))
+			}
+			tables = append(tables, table)
+		}
+		if len(tables) > 0 {
+			s += " FOR " + strings.Join(tables, ", ")
+		}
+	}
+	var options []string
+	if cs.ValueCaptureType != "" {
+		options = append(options, fmt.Sprintf("value_capture_type = '%s'", cs.ValueCaptureType))
+	}
+	if cs.RetentionPeriod != "" {
+		options = append(options, fmt.Sprintf("retention_period = '%s'", cs.RetentionPeriod))
+	}
+	if cs.ExcludeTtlDeletes {
+		options = append(options, "exclude_ttl_deletes = true")
+	}
+	if cs.ExcludeInsert {
+		options = append(options, "exclude_insert = true")
+	}
+	if cs.ExcludeUpdate {
+		options = append(options, "exclude_update = true")
+	}
+	if cs.ExcludeDelete {
+		options = append(options, "exclude_delete = true")
+	}
+	if len(options) > 0 {
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			s += " WITH (" + strings.Join(options, ", ") + ")"
+		} else {
+			s += " OPTIONS (" + strings.Join(options, ", ") + ")"
+		}
+	}
+	return s
+}
+
+// GetChangeStreamsDDL returns the CREATE CHANGE STREAM statements of change
+// streams, sorted by name. Like views, they are printed after the tables by
+// the callers of GetDDL.
+func GetChangeStreamsDDL(c Config, spSchema Schema, changeStreams map[string]ChangeStream) []string {
+	var changeStreamIds []string
+	for id := range changeStreams {
+		changeStreamIds = append(changeStreamIds, id)
+	}
+	sort.Slice(changeStreamIds, func(i, j int) bool {
+		return changeStreams[changeStreamIds[i]].Name < changeStreams[changeStreamIds[j]].Name
+	})
+	var ddl []string
+	for _, id := range changeStreamIds {
+		ddl = append(ddl, changeStreams[id].PrintChangeStream(spSchema, c))
+	}
+	return ddl
+}
+
+// DependsOn reports whether the query of the view refers to the table or
+// view name.
+func (cv CreateView) DependsOn(name string) bool {
+	return containsWord(cv.Query, name)
+}
+
+// containsWord reports whether s contains word, not preceded or followed by
+// a letter, digit or underscore.
+func containsWord(s, word string) bool {
+	isWordChar := func(b byte) bool {
+		return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+	}
+	if word == "" {
+		return false
+	}
+	for i := 0; i+len(word) <= len(s); {
+		j := strings.Index(s[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		if (start == 0 || !isWordChar(s[start-1])) && (end == len(s) || !isWordChar(s[end])) {
+			return true
+		}
+		i = start + 1
+	}
+	return false
+}
+
 type DatabaseOptions struct {
 	DbName          string
 	DefaultTimezone string
@@ -42,6 +42,9 @@ func TestPrintScalarType(t *testing.T) {
 		{Type{Name: Bytes, Len: int64(42)}, "BYTES(42)"},
 		{Type{Name: Date}, "DATE"},
 		{Type{Name: Timestamp}, "TIMESTAMP"},
+		{Type{Name: Enum, ProtoName: "spanner_migration.users_status"}, "`spanner_migration.users_status`"},
+		{Type{Name: UUID}, "UUID"},
+		{Type{Name: UUID, IsArray: true}, "ARRAY<UUID>"},
 	}
 	for _, tc := range tests {
 		assert.Equal(t, tc.expected, tc.in.PrintColumnDefType(false))
@@ -72,6 +75,7 @@ func TestPrintScalarTypePG(t *testing.T) {
 		{Type{Name: Bytes, Len: MaxLength}, "BYTEA"},
 		{Type{Name: Bytes, Len: int64(42)}, "BYTEA"},
 		{Type{Name: Timestamp}, "TIMESTAMPTZ"},
+		{Type{Name: UUID}, "UUID"},
 	}
 	for _, tc := range tests {
 		assert.Equal(t, tc.expected, tc.in.PGPrintColumnDefType(false))
@@ -99,6 +103,9 @@ func TestPrintColumnDef(t *testing.T) {
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}, NotNull: true}, expected: "col1 INT64 NOT NULL "},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}, NotNull: true}, expected: "col1 ARRAY<INT64> NOT NULL "},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}}, protectIds: true, expected: "`col1` INT64"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: Enum, ProtoName: "spanner_migration.t_col1", IsArray: true}}, expected: "col1 ARRAY<`spanner_migration.t_col1`>"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: UUID}, AutoGen: AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}}, expected: "col1 UUID DEFAULT (NEW_UUID())"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: String, Len: 36}, AutoGen: AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}}, expected: "col1 STRING(36) DEFAULT (GENERATE_UUID())"},
 		{
 			in: ColumnDef{
 				Name: "col1",
@@ -160,6 +167,7 @@ func TestPrintColumnDefPG(t *testing.T) {
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}, NotNull: true}, expected: "col1 INT8 NOT NULL "},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}, NotNull: true}, expected: "col1 VARCHAR(2621440) NOT NULL "},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}}, protectIds: true, expected: "\"col1\" INT8"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: UUID}, AutoGen: AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}}, expected: "col1 UUID DEFAULT (CAST(spanner.generate_uuid() AS UUID))"},
 		{
 			in: ColumnDef{
 				Name: "col1",
@@ -575,7 +583,100 @@ func TestPrintCreateTablePG(t *testing.T) {
 	}
 }
 
+func TestPrintCreateTableRowDeletionPolicy(t *testing.T) {
+	s := Schema{
+		"t1": CreateTable{
+			Name:   "events",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "id", T: Type{Name: Int64}, NotNull: true},
+				"c2": {Name: "created_at", T: Type{Name: Timestamp}},
+			},
+			PrimaryKeys:       []IndexKey{{ColId: "c1", Order: 1}},
+			RowDeletionPolicy: RowDeletionPolicy{ColId: "c2", Days: 30},
+			Id:                "t1",
+		},
+		"t2": CreateTable{
+			Name:   "event_details",
+			ColIds: []string{"c3", "c4", "c5"},
+			ColDefs: map[string]ColumnDef{
+				"c3": {Name: "id", T: Type{Name: Int64}, NotNull: true},
+				"c4": {Name: "detail_id", T: Type{Name: Int64}, NotNull: true},
+				"c5": {Name: "created_at", T: Type{Name: Timestamp}},
+			},
+			PrimaryKeys:       []IndexKey{{ColId: "c3", Order: 1}, {ColId: "c4", Order: 2}},
+			ParentTable:       InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+			RowDeletionPolicy: RowDeletionPolicy{ColId: "c5", Days: 7},
+			Id:                "t2",
+		},
+	}
+	tests := []struct {
+		name     string
+		tableId  string
+		config   Config
+		expected string
+	}{
+		{
+			"row deletion policy",
+			"t1",
+			Config{ProtectIds: true},
+			"CREATE TABLE `events` (\n" +
+				"\t`id` INT64 NOT NULL ,\n" +
+				"\t`created_at` TIMESTAMP,\n" +
+				") PRIMARY KEY (`id`),\n" +
+				"ROW DELETION POLICY (OLDER_THAN(`created_at`, INTERVAL 30 DAY))",
+		},
+		{
+			"row deletion policy of interleaved table",
+			"t2",
+			Config{},
+			"CREATE TABLE event_details (\n" +
+				"\tid INT64 NOT NULL ,\n" +
+				"\tdetail_id INT64 NOT NULL ,\n" +
+				"\tcreated_at TIMESTAMP,\n" +
+				") PRIMARY KEY (id, detail_id),\n" +
+				"INTERLEAVE IN PARENT events ON DELETE CASCADE,\n" +
+				"ROW DELETION POLICY (OLDER_THAN(created_at, INTERVAL 7 DAY))",
+		},
+		{
+			"TTL",
+			"t2",
+			Config{SpDialect: constants.DIALECT_POSTGRESQL},
+			"CREATE TABLE event_details (\n" +
+				"\tid INT8 NOT NULL ,\n" +
+				"\tdetail_id INT8 NOT NULL ,\n" +
+				"\tcreated_at TIMESTAMPTZ,\n" +
+				"\tPRIMARY KEY (id, detail_id)\n" +
+				") INTERLEAVE IN PARENT events ON DELETE CASCADE TTL INTERVAL '7 days' ON created_at",
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, s[tc.tableId].PrintCreateTable(s, tc.config), tc.name)
+	}
+}
+
+func TestValidateRowDeletionPolicy(t *testing.T) {
+	ct := CreateTable{
+		Name:   "events",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "id", T: Type{Name: Int64}},
+			"c2": {Name: "created_at", T: Type{Name: Timestamp}},
+			"c3": {Name: "seen_at", T: Type{Name: Timestamp, IsArray: true}},
+		},
+	}
+	assert.Nil(t, ct.ValidateRowDeletionPolicy(RowDeletionPolicy{ColId: "c2", Days: 30}))
+	assert.Nil(t, ct.ValidateRowDeletionPolicy(RowDeletionPolicy{ColId: "c2", Days: 0}))
+	assert.EqualError(t, ct.ValidateRowDeletionPolicy(RowDeletionPolicy{ColId: "c1", Days: 30}), "column id of the row deletion policy of table events must be a TIMESTAMP column, not INT64")
+	assert.NotNil(t, ct.ValidateRowDeletionPolicy(RowDeletionPolicy{ColId: "c3", Days: 30}))
+	assert.NotNil(t, ct.ValidateRowDeletionPolicy(RowDeletionPolicy{ColId: "c4", Days: 30}))
+	assert.NotNil(t, ct.ValidateRowDeletionPolicy(RowDeletionPolicy{ColId: "c2", Days: -1}))
+}
+
 func TestPrintCreateIndex(t *testing.T) {
+	s := Schema{
+		"t0": {Name: "parent", Id: "t0"},
+	}
 	ct := CreateTable{
 		Name:   "mytable",
 		Id:     "t1",
@@ -584,7 +685,9 @@ func TestPrintCreateIndex(t *testing.T) {
 			"c1": {Name: "col1", Id: "c1"},
 			"c2": {Name: "col2", Id: "c2"},
 		},
+		ParentTable: InterleavedParent{Id: "t0"},
 	}
+	s["t1"] = ct
 	ci := []CreateIndex{
 		{
 			"myindex",
@@ -593,6 +696,8 @@ func TestPrintCreateIndex(t *testing.T) {
 			[]IndexKey{{ColId: "c1", Desc: true}, {ColId: "c2"}},
 			"i1",
 			nil,
+			/*NullFiltered =*/ false,
+			/*InterleaveIn =*/ "",
 		},
 		{
 			"myindex2",
@@ -601,6 +706,28 @@ func TestPrintCreateIndex(t *testing.T) {
 			[]IndexKey{{ColId: "c1", Desc: true}, {ColId: "c2"}},
 			"i2",
 			nil,
+			/*NullFiltered =*/ false,
+			/*InterleaveIn =*/ "",
+		},
+		{
+			"myindex3",
+			"t1",
+			/*Unique =*/ false,
+			[]IndexKey{{ColId: "c1", Desc: true}, {ColId: "c2"}},
+			"i3",
+			[]string{"c2"},
+			/*NullFiltered =*/ true,
+			/*InterleaveIn =*/ "t0",
+		},
+		{
+			"myindex4",
+			"t1",
+			/*Unique =*/ false,
+			[]IndexKey{{ColId: "c2"}},
+			"i4",
+			nil,
+			/*NullFiltered =*/ false,
+			/*InterleaveIn =*/ "t1",
 		},
 	}
 	tests := []struct {
@@ -613,14 +740,182 @@ func TestPrintCreateIndex(t *testing.T) {
 		{"no quote non unique", false, "", ci[0], "CREATE INDEX myindex ON mytable (col1 DESC, col2)"},
 		{"quote non unique", true, "", ci[0], "CREATE INDEX `myindex` ON `mytable` (`col1` DESC, `col2`)"},
 		{"unique key", true, "", ci[1], "CREATE UNIQUE INDEX `myindex2` ON `mytable` (`col1` DESC, `col2`)"},
+		{"null filtered and interleaved", true, "", ci[2], "CREATE NULL_FILTERED INDEX `myindex3` ON `mytable` (`col1` DESC, `col2`) STORING (`col2`), INTERLEAVE IN `parent`"},
+		{"interleaved in a table that isn't an ancestor", false, "", ci[3], "CREATE INDEX myindex4 ON mytable (col2)"},
 		{"quote non unique PG", true, constants.DIALECT_POSTGRESQL, ci[0], "CREATE INDEX \"myindex\" ON \"mytable\" (\"col1\" DESC, \"col2\")"},
 		{"unique key PG", true, constants.DIALECT_POSTGRESQL, ci[1], "CREATE UNIQUE INDEX \"myindex2\" ON \"mytable\" (\"col1\" DESC, \"col2\")"},
+		{"null filtered and interleaved PG", false, constants.DIALECT_POSTGRESQL, ci[2], "CREATE INDEX myindex3 ON mytable (col1 DESC, col2) INCLUDE (col2) INTERLEAVE IN parent WHERE col1 IS NOT NULL AND col2 IS NOT NULL"},
 	}
 	for _, tc := range tests {
-		assert.Equal(t, tc.expected, tc.index.PrintCreateIndex(ct, Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}))
+		assert.Equal(t, tc.expected, tc.index.PrintCreateIndex(s, ct, Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}))
 	}
 }
 
+func TestIsAncestor(t *testing.T) {
+	s := Schema{
+		"t1": {Name: "singers", Id: "t1"},
+		"t2": {Name: "albums", Id: "t2", ParentTable: InterleavedParent{Id: "t1"}},
+		"t3": {Name: "songs", Id: "t3", ParentTable: InterleavedParent{Id: "t2"}},
+	}
+	assert.True(t, s.IsAncestor("t3", "t2"))
+	assert.True(t, s.IsAncestor("t3", "t1"))
+	assert.False(t, s.IsAncestor("t1", "t3"))
+	assert.False(t, s.IsAncestor("t3", "t3"))
+	assert.False(t, s.IsAncestor("t3", ""))
+}
+
+func TestPrintCreateSearchIndex(t *testing.T) {
+	s := Schema{
+		"t0": {Name: "singers", Id: "t0"},
+		"t1": {
+			Name:   "albums",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "singer_id", Id: "c1"},
+				"c2": {Name: "title", Id: "c2"},
+				"c3": {Name: "notes", Id: "c3"},
+				"c4": {Name: "release", Id: "c4"},
+			},
+			ParentTable: InterleavedParent{Id: "t0"},
+		},
+	}
+	si := CreateSearchIndex{
+		Name:    "albums_by_title",
+		Id:      "si1",
+		TableId: "t1",
+		Keys: []SearchIndexKey{
+			{ColId: "c2", Tokenizer: "TOKENIZE_FULLTEXT"},
+			{ColId: "c3", Tokenizer: "TOKENIZE_SUBSTRING", TokenColumn: "notes_substring"},
+		},
+		StoredColumnIds:   []string{"c4"},
+		PartitionColIds:   []string{"c1"},
+		OrderBy:           []IndexKey{{ColId: "c4", Desc: true}},
+		InterleaveIn:      "t0",
+		SortOrderSharding: true,
+	}
+	tests := []struct {
+		name      string
+		spDialect string
+		expected  []string
+	}{
+		{
+			"GoogleSQL",
+			constants.DIALECT_GOOGLESQL,
+			[]string{
+				"ALTER TABLE `albums` ADD COLUMN `title_Tokens` TOKENLIST AS (TOKENIZE_FULLTEXT(`title`)) HIDDEN",
+				"ALTER TABLE `albums` ADD COLUMN `notes_substring` TOKENLIST AS (TOKENIZE_SUBSTRING(`notes`)) HIDDEN",
+				"CREATE SEARCH INDEX `albums_by_title` ON `albums` (`title_Tokens`, `notes_substring`) STORING (`release`) PARTITION BY `singer_id` ORDER BY `release` DESC, INTERLEAVE IN `singers` OPTIONS (sort_order_sharding = true)",
+			},
+		},
+		{
+			"PG",
+			constants.DIALECT_POSTGRESQL,
+			[]string{
+				"ALTER TABLE \"albums\" ADD COLUMN \"title_Tokens\" spanner.tokenlist GENERATED ALWAYS AS (spanner.tokenize_fulltext(\"title\")) VIRTUAL HIDDEN",
+				"ALTER TABLE \"albums\" ADD COLUMN \"notes_substring\" spanner.tokenlist GENERATED ALWAYS AS (spanner.tokenize_substring(\"notes\")) VIRTUAL HIDDEN",
+				"CREATE SEARCH INDEX \"albums_by_title\" ON \"albums\" (\"title_Tokens\", \"notes_substring\") INCLUDE (\"release\") PARTITION BY \"singer_id\" ORDER BY \"release\" DESC INTERLEAVE IN \"singers\" WITH (sort_order_sharding = true)",
+			},
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, si.PrintCreateSearchIndex(s, s["t1"], Config{ProtectIds: true, SpDialect: tc.spDialect}), tc.name)
+	}
+}
+
+func TestPrintCreateVectorIndex(t *testing.T) {
+	ct := CreateTable{
+		Name:   "documents",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+			"c2": {Name: "embedding", Id: "c2", T: Type{Name: Float32, IsArray: true}, Opts: map[string]string{"vector_length": "128"}},
+			"c3": {Name: "title", Id: "c3", T: Type{Name: String, Len: MaxLength}},
+		},
+	}
+	vi := CreateVectorIndex{
+		Name:            "documents_by_embedding",
+		Id:              "vi1",
+		TableId:         "t1",
+		ColId:           "c2",
+		StoredColumnIds: []string{"c3"},
+		DistanceType:    "COSINE",
+		TreeDepth:       2,
+		NumLeaves:       1000,
+	}
+	tests := []struct {
+		name      string
+		spDialect string
+		index     CreateVectorIndex
+		expected  string
+	}{
+		{"GoogleSQL", constants.DIALECT_GOOGLESQL, vi, "CREATE VECTOR INDEX documents_by_embedding ON documents (embedding) STORING (title) WHERE embedding IS NOT NULL OPTIONS (distance_type = 'COSINE', tree_depth = 2, num_leaves = 1000)"},
+		{"GoogleSQL default options", constants.DIALECT_GOOGLESQL, CreateVectorIndex{Name: "documents_by_embedding", TableId: "t1", ColId: "c2", DistanceType: "dot_product"}, "CREATE VECTOR INDEX documents_by_embedding ON documents (embedding) WHERE embedding IS NOT NULL OPTIONS (distance_type = 'DOT_PRODUCT')"},
+		{"PG", constants.DIALECT_POSTGRESQL, vi, "CREATE INDEX documents_by_embedding ON documents USING ScaNN (embedding spanner.cosine_distance) INCLUDE (title) WITH (tree_depth = 2, num_leaves = 1000) WHERE embedding IS NOT NULL"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, tc.index.PrintCreateVectorIndex(ct, Config{SpDialect: tc.spDialect}), tc.name)
+	}
+	col, _ := ct.ColDefs["c2"].PrintColumnDef(Config{})
+	assert.Equal(t, "embedding ARRAY<FLOAT32>(vector_length=>128)", col)
+	col, _ = ct.ColDefs["c2"].PrintColumnDef(Config{SpDialect: constants.DIALECT_POSTGRESQL})
+	assert.Equal(t, "embedding FLOAT4[] VECTOR LENGTH 128", col)
+}
+
+func TestPrintChangeStream(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:    "orders",
+			Id:      "t1",
+			ColIds:  []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ColumnDef{"c1": {Name: "id", Id: "c1"}, "c2": {Name: "status", Id: "c2"}, "c3": {Name: "total", Id: "c3"}},
+		},
+		"t2": {Name: "customers", Id: "t2"},
+	}
+	tests := []struct {
+		name      string
+		spDialect string
+		cs        ChangeStream
+		expected  string
+	}{
+		{
+			"all tables",
+			constants.DIALECT_GOOGLESQL,
+			ChangeStream{Name: "everything", WatchAll: true, ValueCaptureType: "NEW_ROW", RetentionPeriod: "7d"},
+			"CREATE CHANGE STREAM `everything` FOR ALL OPTIONS (value_capture_type = 'NEW_ROW', retention_period = '7d')",
+		},
+		{
+			"tables and columns",
+			constants.DIALECT_GOOGLESQL,
+			ChangeStream{Name: "order_changes", Tables: []ChangeStreamTable{{TableId: "t1", ColumnIds: []string{"c2", "c3"}}, {TableId: "t2", KeysOnly: true}, {TableId: "t3"}}, ExcludeTtlDeletes: true, ExcludeDelete: true},
+			"CREATE CHANGE STREAM `order_changes` FOR `orders`(`status`, `total`), `customers`() OPTIONS (exclude_ttl_deletes = true, exclude_delete = true)",
+		},
+		{
+			"no tables",
+			constants.DIALECT_GOOGLESQL,
+			ChangeStream{Name: "idle"},
+			"CREATE CHANGE STREAM `idle`",
+		},
+		{
+			"PG",
+			constants.DIALECT_POSTGRESQL,
+			ChangeStream{Name: "order_changes", Tables: []ChangeStreamTable{{TableId: "t1"}}, RetentionPeriod: "36h"},
+			"CREATE CHANGE STREAM \"order_changes\" FOR \"orders\" WITH (retention_period = '36h')",
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, tc.cs.PrintChangeStream(s, Config{ProtectIds: true, SpDialect: tc.spDialect}), tc.name)
+	}
+	assert.Equal(t, []string{
+		"CREATE CHANGE STREAM a FOR customers",
+		"CREATE CHANGE STREAM b FOR ALL",
+	}, GetChangeStreamsDDL(Config{}, s, map[string]ChangeStream{
+		"cs1": {Name: "b", Id: "cs1", WatchAll: true},
+		"cs2": {Name: "a", Id: "cs2", Tables: []ChangeStreamTable{{TableId: "t2"}}},
+	}))
+}
+
 func TestPrintForeignKey(t *testing.T) {
 	fk := []Foreignkey{
 		{
@@ -774,6 +1069,17 @@ func TestPrintDefaultValue(t *testing.T) {
 			},
 			expected: " DEFAULT (CAST((`col1` + 1) AS NUMERIC))",
 		},
+		{
+			name: "uuid default value",
+			dv: DefaultValue{
+				IsPresent: true,
+				Value:     Expression{Statement: "'8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3'"},
+			},
+			ty: Type{
+				Name: "UUID",
+			},
+			expected: " DEFAULT (CAST('8f14e45f-ceea-467f-a8a4-39e7c6f6b1e3' AS UUID))",
+		},
 		{
 			name: "empty default value",
 			dv:   DefaultValue{},
@@ -1116,6 +1422,71 @@ func TestPGPrintSequence(t *testing.T) {
 	}
 }
 
+func TestPrintCreateView(t *testing.T) {
+	v := CreateView{
+		Name:    "active_users",
+		Id:      "vw1",
+		Query:   "SELECT id, name FROM users WHERE active",
+		Comment: "Spanner schema for source view active_users",
+	}
+	tests := []struct {
+		name     string
+		view     CreateView
+		config   Config
+		expected string
+	}{
+		{
+			name:     "no quote",
+			view:     v,
+			config:   Config{SpDialect: constants.DIALECT_GOOGLESQL},
+			expected: "CREATE VIEW active_users SQL SECURITY INVOKER AS SELECT id, name FROM users WHERE active",
+		},
+		{
+			name:     "quote",
+			view:     v,
+			config:   Config{ProtectIds: true, SpDialect: constants.DIALECT_GOOGLESQL},
+			expected: "CREATE VIEW `active_users` SQL SECURITY INVOKER AS SELECT id, name FROM users WHERE active",
+		},
+		{
+			name:     "quote pg",
+			view:     v,
+			config:   Config{ProtectIds: true, SpDialect: constants.DIALECT_POSTGRESQL},
+			expected: "CREATE VIEW \"active_users\" SQL SECURITY INVOKER AS SELECT id, name FROM users WHERE active",
+		},
+		{
+			name:     "comments",
+			view:     v,
+			config:   Config{Comments: true, SpDialect: constants.DIALECT_GOOGLESQL},
+			expected: "--\n-- Spanner schema for source view active_users\n--\nCREATE VIEW active_users SQL SECURITY INVOKER AS SELECT id, name FROM users WHERE active",
+		},
+		{
+			name:     "sql security definer",
+			view:     CreateView{Name: "v", SqlSecurity: "DEFINER", Query: " SELECT 1 AS one "},
+			config:   Config{SpDialect: constants.DIALECT_GOOGLESQL},
+			expected: "CREATE VIEW v SQL SECURITY DEFINER AS SELECT 1 AS one",
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, tc.view.PrintCreateView(tc.config), tc.name)
+	}
+}
+
+func TestGetViewsDDL(t *testing.T) {
+	views := map[string]CreateView{
+		"vw1": {Name: "a_summary", Id: "vw1", Query: "SELECT COUNT(*) AS n FROM z_orders_2024"},
+		"vw2": {Name: "z_orders_2024", Id: "vw2", Query: "SELECT id FROM orders WHERE year = 2024"},
+		"vw3": {Name: "m_orders", Id: "vw3", Query: "SELECT id FROM orders_archive"},
+	}
+
+	assert.Equal(t, []string{"vw2", "vw1", "vw3"}, GetSortedViewIds(views))
+	assert.Equal(t, []string{
+		"CREATE VIEW z_orders_2024 SQL SECURITY INVOKER AS SELECT id FROM orders WHERE year = 2024",
+		"CREATE VIEW a_summary SQL SECURITY INVOKER AS SELECT COUNT(*) AS n FROM z_orders_2024",
+		"CREATE VIEW m_orders SQL SECURITY INVOKER AS SELECT id FROM orders_archive",
+	}, GetViewsDDL(Config{SpDialect: constants.DIALECT_GOOGLESQL}, views))
+	assert.Empty(t, GetViewsDDL(Config{}, nil))
+}
+
 func TestGetDDL(t *testing.T) {
 	s := Schema{
 		"t1": CreateTable{
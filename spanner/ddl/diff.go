@@ -0,0 +1,371 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+)
+
+// SchemaChange is a DDL statement of a schema diff.
+type SchemaChange struct {
+	Statement   string
+	Destructive bool // If true, the statement drops a table or a column along with its data.
+}
+
+// SchemaDiff holds the DDL statements that change a live Spanner schema into
+// a target schema, in the order they must be applied.
+type SchemaDiff struct {
+	Changes []SchemaChange
+	// Unsupported lists the differences that can't be applied to an existing
+	// table, such as a changed primary key. The table must be recreated.
+	Unsupported []string
+}
+
+// DiffSchemas compares the live schema of a Spanner database with the target
+// schema and returns the statements that evolve the former into the latter.
+// Tables, columns and indexes are matched by name, case-insensitively, and
+// foreign keys by their columns and referenced columns. Statements are
+// ordered so that each one is valid once the previous ones are applied:
+//
+//	drop foreign keys, drop indexes, drop tables (children first),
+//	create tables (parents first), add and alter columns, drop columns,
+//	create indexes, add foreign keys.
+//
+// Only the parts of a schema read from the information schema of a live
+// database are compared: column types and nullability, primary and
+// interleaving keys, the keys, stored columns, uniqueness, NULL_FILTERED and
+// INTERLEAVE IN of indexes, and foreign key columns. Search and vector
+// indexes are created with new tables only. Views and change streams aren't
+// compared; they are out of the scope of the diff.
+func DiffSchemas(c Config, live, target Schema) SchemaDiff {
+	var d SchemaDiff
+	var dropFks, dropIndexes, dropTables, createTables, alterColumns, dropColumns, createIndexes, addFks []SchemaChange
+	liveIds := tableIdsByName(live)
+	targetIds := tableIdsByName(target)
+	// Columns whose type changes can't be altered while indexes or foreign
+	// keys use them, so these are dropped and recreated around the change.
+	retyped := make(map[string]bool)
+	// Indexes that use dropped columns are dropped before the columns.
+	dropped := make(map[string]bool)
+
+	for _, tableId := range GetSortedTableIdsBySpName(target) {
+		ct := target[tableId]
+		lt, ok := live[liveIds[strings.ToLower(ct.Name)]]
+		if !ok {
+			createTables = append(createTables, SchemaChange{Statement: ct.PrintCreateTable(target, c)})
+			for _, si := range ct.SearchIndexes {
+				for _, s := range si.PrintCreateSearchIndex(target, ct, c) {
+					createIndexes = append(createIndexes, SchemaChange{Statement: s})
+				}
+			}
+			for _, vi := range ct.VectorIndexes {
+				createIndexes = append(createIndexes, SchemaChange{Statement: vi.PrintCreateVectorIndex(ct, c)})
+			}
+			continue
+		}
+		if livePks, targetPks := pkColumnNames(lt), pkColumnNames(ct); !equalFoldSlices(livePks, targetPks) {
+			d.Unsupported = append(d.Unsupported, fmt.Sprintf("table %s: primary key changes from (%s) to (%s)", ct.Name, strings.Join(livePks, ", "), strings.Join(targetPks, ", ")))
+		}
+		if liveParent, targetParent := live[lt.ParentTable.Id].Name, target[ct.ParentTable.Id].Name; !strings.EqualFold(liveParent, targetParent) {
+			d.Unsupported = append(d.Unsupported, fmt.Sprintf("table %s: parent table changes from '%s' to '%s'", ct.Name, liveParent, targetParent))
+		}
+		liveCols := columnIdsByName(lt)
+		for _, colId := range ct.ColIds {
+			cd := ct.ColDefs[colId]
+			lc, ok := lt.ColDefs[liveCols[strings.ToLower(cd.Name)]]
+			if !ok {
+				if cd.NotNull && !cd.DefaultValue.IsPresent && !cd.GeneratedColumn.IsPresent {
+					// Spanner has no value to give the existing rows.
+					d.Unsupported = append(d.Unsupported, fmt.Sprintf("column %s.%s: NOT NULL column without a default value is added", ct.Name, cd.Name))
+					continue
+				}
+				s, _ := cd.PrintColumnDef(c)
+				alterColumns = append(alterColumns, SchemaChange{Statement: fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", c.quote(ct.Name), strings.TrimSpace(s))})
+				continue
+			}
+			sameType, sameNull := equalTypes(lc.T, cd.T), lc.NotNull == cd.NotNull
+			if sameType && sameNull {
+				continue
+			}
+			if cd.GeneratedColumn.IsPresent {
+				d.Unsupported = append(d.Unsupported, fmt.Sprintf("column %s.%s: generated column changes", ct.Name, cd.Name))
+				continue
+			}
+			if !sameType {
+				retyped[strings.ToLower(ct.Name+"."+cd.Name)] = true
+			}
+			alterColumns = append(alterColumns, printAlterColumn(c, ct.Name, lc, cd)...)
+		}
+		tokenColumns := make(map[string]bool)
+		for _, si := range ct.SearchIndexes {
+			for _, k := range si.Keys {
+				tokenColumns[strings.ToLower(k.TokenColumnName(ct))] = true
+			}
+		}
+		targetCols := columnIdsByName(ct)
+		for _, colId := range lt.ColIds {
+			name := lt.ColDefs[colId].Name
+			if _, ok := targetCols[strings.ToLower(name)]; ok || tokenColumns[strings.ToLower(name)] || isPrimaryKey(lt, colId) {
+				continue
+			}
+			dropped[strings.ToLower(lt.Name+"."+name)] = true
+			dropColumns = append(dropColumns, SchemaChange{Statement: fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", c.quote(lt.Name), c.quote(name)), Destructive: true})
+		}
+	}
+
+	// Indexes are matched by name; any difference recreates the index.
+	liveIndexes := make(map[string]bool)
+	for _, tableId := range GetSortedTableIdsBySpName(live) {
+		lt := live[tableId]
+		ct, tableExists := target[targetIds[strings.ToLower(lt.Name)]]
+		for _, idx := range lt.Indexes {
+			var keep bool
+			if tableExists {
+				for _, ti := range ct.Indexes {
+					if strings.EqualFold(ti.Name, idx.Name) {
+						keep = equalIndexes(live, lt, idx, target, ct, ti) && !usesColumn(lt, idx, retyped) && !usesColumn(lt, idx, dropped)
+						break
+					}
+				}
+			}
+			if keep {
+				liveIndexes[strings.ToLower(idx.Name)] = true
+				continue
+			}
+			dropIndexes = append(dropIndexes, SchemaChange{Statement: "DROP INDEX " + c.quote(idx.Name)})
+		}
+	}
+	for _, tableId := range GetSortedTableIdsBySpName(target) {
+		ct := target[tableId]
+		for _, idx := range ct.Indexes {
+			if !liveIndexes[strings.ToLower(idx.Name)] {
+				createIndexes = append(createIndexes, SchemaChange{Statement: idx.PrintCreateIndex(target, ct, c)})
+			}
+		}
+	}
+
+	// Foreign keys are matched by what they constrain, so that unnamed
+	// foreign keys of the target schema match the named live ones.
+	targetFks := make(map[string]bool)
+	for tableId, ct := range target {
+		for _, fk := range ct.ForeignKeys {
+			targetFks[fkSignature(target, tableId, fk)] = true
+		}
+	}
+	liveFks := make(map[string]bool)
+	for _, tableId := range GetSortedTableIdsBySpName(live) {
+		lt := live[tableId]
+		for _, fk := range lt.ForeignKeys {
+			sig := fkSignature(live, tableId, fk)
+			if targetFks[sig] && !fkUsesColumn(live, tableId, fk, retyped) {
+				liveFks[sig] = true
+				continue
+			}
+			if fk.Name != "" {
+				dropFks = append(dropFks, SchemaChange{Statement: fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", c.quote(lt.Name), c.quote(fk.Name))})
+			}
+		}
+	}
+	for _, tableId := range GetSortedTableIdsBySpName(target) {
+		for _, fk := range target[tableId].ForeignKeys {
+			if !liveFks[fkSignature(target, tableId, fk)] {
+				addFks = append(addFks, SchemaChange{Statement: fk.PrintForeignKeyAlterTable(target, c, tableId)})
+			}
+		}
+	}
+
+	// Tables are dropped in the reverse of creation order, so that
+	// interleaved tables are dropped before their parents.
+	sortedLive := GetSortedTableIdsBySpName(live)
+	for i := len(sortedLive) - 1; i >= 0; i-- {
+		lt := live[sortedLive[i]]
+		if _, ok := targetIds[strings.ToLower(lt.Name)]; !ok {
+			dropTables = append(dropTables, SchemaChange{Statement: "DROP TABLE " + c.quote(lt.Name), Destructive: true})
+		}
+	}
+
+	for _, changes := range [][]SchemaChange{dropFks, dropIndexes, dropTables, createTables, alterColumns, dropColumns, createIndexes, addFks} {
+		d.Changes = append(d.Changes, changes...)
+	}
+	return d
+}
+
+// printAlterColumn unparses the statements that change the type or the
+// nullability of a column from those of liveCol to those of targetCol.
+func printAlterColumn(c Config, tableName string, liveCol, targetCol ColumnDef) []SchemaChange {
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN ", c.quote(tableName))
+	if c.SpDialect != constants.DIALECT_POSTGRESQL {
+		s, _ := targetCol.PrintColumnDef(c)
+		return []SchemaChange{{Statement: prefix + strings.TrimSpace(s)}}
+	}
+	var changes []SchemaChange
+	if !equalTypes(liveCol.T, targetCol.T) {
+		changes = append(changes, SchemaChange{Statement: fmt.Sprintf("%s%s TYPE %s", prefix, c.quote(targetCol.Name), targetCol.T.PGPrintColumnDefType(false))})
+	}
+	if liveCol.NotNull != targetCol.NotNull {
+		action := "DROP NOT NULL"
+		if targetCol.NotNull {
+			action = "SET NOT NULL"
+		}
+		changes = append(changes, SchemaChange{Statement: fmt.Sprintf("%s%s %s", prefix, c.quote(targetCol.Name), action)})
+	}
+	return changes
+}
+
+// equalTypes reports whether a and b are the same Spanner type. Lengths are
+// only compared for STRING and BYTES.
+func equalTypes(a, b Type) bool {
+	if a.Name != b.Name || a.IsArray != b.IsArray {
+		return false
+	}
+	if a.Name == String || a.Name == Bytes {
+		return a.Len == b.Len
+	}
+	return true
+}
+
+// equalIndexes reports whether index a of table at of schema as and index b
+// of table bt of schema bs have the same key columns, in the same order and
+// direction, the same stored columns, in any order, and the same uniqueness,
+// NULL_FILTERED and INTERLEAVE IN.
+func equalIndexes(as Schema, at CreateTable, a CreateIndex, bs Schema, bt CreateTable, b CreateIndex) bool {
+	if a.Unique != b.Unique || a.NullFiltered != b.NullFiltered || len(a.Keys) != len(b.Keys) {
+		return false
+	}
+	if !strings.EqualFold(as[a.InterleaveIn].Name, bs[b.InterleaveIn].Name) {
+		return false
+	}
+	for i := range a.Keys {
+		if a.Keys[i].Desc != b.Keys[i].Desc || !strings.EqualFold(at.ColDefs[a.Keys[i].ColId].Name, bt.ColDefs[b.Keys[i].ColId].Name) {
+			return false
+		}
+	}
+	return equalFoldSlices(storedColumnNames(at, a), storedColumnNames(bt, b))
+}
+
+// storedColumnNames returns the lower cased names of the columns of the
+// STORING clause of the index, sorted.
+func storedColumnNames(ct CreateTable, idx CreateIndex) []string {
+	var names []string
+	for _, colId := range idx.StoredColumnIds {
+		names = append(names, strings.ToLower(ct.ColDefs[colId].Name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// usesColumn reports whether a key column or a stored column of the index is
+// in cols, a set of lower cased "table.column" names.
+func usesColumn(ct CreateTable, idx CreateIndex, cols map[string]bool) bool {
+	for _, k := range idx.Keys {
+		if cols[strings.ToLower(ct.Name+"."+ct.ColDefs[k.ColId].Name)] {
+			return true
+		}
+	}
+	for _, colId := range idx.StoredColumnIds {
+		if cols[strings.ToLower(ct.Name+"."+ct.ColDefs[colId].Name)] {
+			return true
+		}
+	}
+	return false
+}
+
+// fkUsesColumn reports whether a column or a referenced column of the
+// foreign key is in cols, a set of lower cased "table.column" names.
+func fkUsesColumn(s Schema, tableId string, fk Foreignkey, cols map[string]bool) bool {
+	for _, colId := range fk.ColIds {
+		if cols[strings.ToLower(s[tableId].Name+"."+s[tableId].ColDefs[colId].Name)] {
+			return true
+		}
+	}
+	for _, colId := range fk.ReferColumnIds {
+		if cols[strings.ToLower(s[fk.ReferTableId].Name+"."+s[fk.ReferTableId].ColDefs[colId].Name)] {
+			return true
+		}
+	}
+	return false
+}
+
+// fkSignature returns the lower cased table, columns, referenced table and
+// referenced columns of a foreign key, e.g. "orders(user_id)->users(id)".
+func fkSignature(s Schema, tableId string, fk Foreignkey) string {
+	var cols, referCols []string
+	for _, colId := range fk.ColIds {
+		cols = append(cols, s[tableId].ColDefs[colId].Name)
+	}
+	for _, colId := range fk.ReferColumnIds {
+		referCols = append(referCols, s[fk.ReferTableId].ColDefs[colId].Name)
+	}
+	return strings.ToLower(fmt.Sprintf("%s(%s)->%s(%s)", s[tableId].Name, strings.Join(cols, ","), s[fk.ReferTableId].Name, strings.Join(referCols, ",")))
+}
+
+// pkColumnNames returns the names of the primary key columns of the table,
+// in key order.
+func pkColumnNames(ct CreateTable) []string {
+	pks := append([]IndexKey{}, ct.PrimaryKeys...)
+	sort.SliceStable(pks, func(i, j int) bool {
+		return pks[i].Order < pks[j].Order
+	})
+	var names []string
+	for _, pk := range pks {
+		names = append(names, ct.ColDefs[pk.ColId].Name)
+	}
+	return names
+}
+
+func isPrimaryKey(ct CreateTable, colId string) bool {
+	for _, pk := range ct.PrimaryKeys {
+		if pk.ColId == colId {
+			return true
+		}
+	}
+	return false
+}
+
+func equalFoldSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// tableIdsByName maps the lower cased names of the tables of the schema to
+// their ids.
+func tableIdsByName(s Schema) map[string]string {
+	ids := make(map[string]string)
+	for id, ct := range s {
+		ids[strings.ToLower(ct.Name)] = id
+	}
+	return ids
+}
+
+// columnIdsByName maps the lower cased names of the columns of the table to
+// their ids.
+func columnIdsByName(ct CreateTable) map[string]string {
+	ids := make(map[string]string)
+	for id, cd := range ct.ColDefs {
+		ids[strings.ToLower(cd.Name)] = id
+	}
+	return ids
+}
@@ -0,0 +1,221 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func liveDiffTestSchema() Schema {
+	return Schema{
+		"lt1": {
+			Name:   "Users",
+			Id:     "lt1",
+			ColIds: []string{"lc1", "lc2", "lc3"},
+			ColDefs: map[string]ColumnDef{
+				"lc1": {Name: "id", Id: "lc1", T: Type{Name: Int64}, NotNull: true},
+				"lc2": {Name: "name", Id: "lc2", T: Type{Name: String, Len: 50}},
+				"lc3": {Name: "legacy", Id: "lc3", T: Type{Name: String, Len: MaxLength}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "lc1", Order: 1}},
+			Indexes:     []CreateIndex{{Name: "users_by_name", TableId: "lt1", Keys: []IndexKey{{ColId: "lc2"}}}},
+		},
+		"lt2": {
+			Name:   "orders",
+			Id:     "lt2",
+			ColIds: []string{"lc4", "lc5"},
+			ColDefs: map[string]ColumnDef{
+				"lc4": {Name: "id", Id: "lc4", T: Type{Name: Int64}, NotNull: true},
+				"lc5": {Name: "user_id", Id: "lc5", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "lc4", Order: 1}},
+			ForeignKeys: []Foreignkey{{Name: "fk_orders_users", ColIds: []string{"lc5"}, ReferTableId: "lt1", ReferColumnIds: []string{"lc1"}}},
+			Indexes:     []CreateIndex{{Name: "orders_by_user", TableId: "lt2", Keys: []IndexKey{{ColId: "lc5"}}}},
+		},
+		"lt3": {
+			Name:        "audit",
+			Id:          "lt3",
+			ColIds:      []string{"lc6"},
+			ColDefs:     map[string]ColumnDef{"lc6": {Name: "id", Id: "lc6", T: Type{Name: Int64}, NotNull: true}},
+			PrimaryKeys: []IndexKey{{ColId: "lc6", Order: 1}},
+			Indexes:     []CreateIndex{{Name: "audit_by_id", TableId: "lt3", Keys: []IndexKey{{ColId: "lc6", Desc: true}}}},
+		},
+	}
+}
+
+func targetDiffTestSchema() Schema {
+	return Schema{
+		"t1": {
+			Name:   "users",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+				"c2": {Name: "name", Id: "c2", T: Type{Name: String, Len: 100}, NotNull: true},
+				"c3": {Name: "email", Id: "c3", T: Type{Name: String, Len: MaxLength}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}},
+			Indexes:     []CreateIndex{{Name: "users_by_name", TableId: "t1", Keys: []IndexKey{{ColId: "c2"}}}},
+		},
+		"t2": {
+			Name:   "orders",
+			Id:     "t2",
+			ColIds: []string{"c4", "c5"},
+			ColDefs: map[string]ColumnDef{
+				"c4": {Name: "id", Id: "c4", T: Type{Name: Int64}, NotNull: true},
+				"c5": {Name: "user_id", Id: "c5", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c4", Order: 1}},
+			ForeignKeys: []Foreignkey{{ColIds: []string{"c5"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}}},
+			Indexes:     []CreateIndex{{Name: "orders_by_user", TableId: "t2", Keys: []IndexKey{{ColId: "c5"}}}},
+		},
+		"t3": {
+			Name:   "addresses",
+			Id:     "t3",
+			ColIds: []string{"c6", "c7"},
+			ColDefs: map[string]ColumnDef{
+				"c6": {Name: "id", Id: "c6", T: Type{Name: Int64}, NotNull: true},
+				"c7": {Name: "user_id", Id: "c7", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c6", Order: 1}},
+			ForeignKeys: []Foreignkey{{Name: "fk_addresses_users", ColIds: []string{"c7"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}}},
+			Indexes:     []CreateIndex{{Name: "addresses_by_user", TableId: "t3", Keys: []IndexKey{{ColId: "c7"}}}},
+		},
+	}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	d := DiffSchemas(Config{ProtectIds: true, SpDialect: constants.DIALECT_GOOGLESQL}, liveDiffTestSchema(), targetDiffTestSchema())
+	expected := []SchemaChange{
+		{Statement: "DROP INDEX `users_by_name`"},
+		{Statement: "DROP INDEX `audit_by_id`"},
+		{Statement: "DROP TABLE `audit`", Destructive: true},
+		{Statement: "CREATE TABLE `addresses` (\n\t`id` INT64 NOT NULL ,\n\t`user_id` INT64,\n) PRIMARY KEY (`id`)"},
+		{Statement: "ALTER TABLE `users` ALTER COLUMN `name` STRING(100) NOT NULL"},
+		{Statement: "ALTER TABLE `users` ADD COLUMN `email` STRING(MAX)"},
+		{Statement: "ALTER TABLE `Users` DROP COLUMN `legacy`", Destructive: true},
+		{Statement: "CREATE INDEX `addresses_by_user` ON `addresses` (`user_id`)"},
+		{Statement: "CREATE INDEX `users_by_name` ON `users` (`name`)"},
+		{Statement: "ALTER TABLE `addresses` ADD CONSTRAINT `fk_addresses_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)"},
+	}
+	assert.Equal(t, expected, d.Changes)
+	assert.Empty(t, d.Unsupported)
+
+	// Diffing a schema with itself is a no-op.
+	d = DiffSchemas(Config{ProtectIds: true}, targetDiffTestSchema(), targetDiffTestSchema())
+	assert.Empty(t, d.Changes)
+	assert.Empty(t, d.Unsupported)
+}
+
+func TestDiffSchemasPostgreSQL(t *testing.T) {
+	target := targetDiffTestSchema()
+	delete(target, "t3")
+	target["t1"].ColDefs["c3"] = ColumnDef{Name: "legacy", Id: "c3", T: Type{Name: String, Len: MaxLength}, NotNull: true}
+	d := DiffSchemas(Config{SpDialect: constants.DIALECT_POSTGRESQL}, liveDiffTestSchema(), target)
+	expected := []SchemaChange{
+		{Statement: "DROP INDEX users_by_name"},
+		{Statement: "DROP INDEX audit_by_id"},
+		{Statement: "DROP TABLE audit", Destructive: true},
+		{Statement: "ALTER TABLE users ALTER COLUMN name TYPE VARCHAR(100)"},
+		{Statement: "ALTER TABLE users ALTER COLUMN name SET NOT NULL"},
+		{Statement: "ALTER TABLE users ALTER COLUMN legacy SET NOT NULL"},
+		{Statement: "CREATE INDEX users_by_name ON users (name)"},
+	}
+	assert.Equal(t, expected, d.Changes)
+}
+
+func TestDiffSchemasUnsupported(t *testing.T) {
+	live := liveDiffTestSchema()
+	target := liveDiffTestSchema()
+	orders := target["lt2"]
+	orders.PrimaryKeys = []IndexKey{{ColId: "lc5", Order: 1}, {ColId: "lc4", Order: 2}}
+	orders.ParentTable = InterleavedParent{Id: "lt1", OnDelete: constants.FK_CASCADE}
+	target["lt2"] = orders
+
+	d := DiffSchemas(Config{}, live, target)
+	assert.Empty(t, d.Changes)
+	assert.Equal(t, []string{
+		"table orders: primary key changes from (id) to (user_id, id)",
+		"table orders: parent table changes from '' to 'Users'",
+	}, d.Unsupported)
+}
+
+func TestDiffSchemasIndexOptions(t *testing.T) {
+	live := liveDiffTestSchema()
+	users := live["lt1"]
+	users.Indexes = []CreateIndex{{Name: "users_by_name", TableId: "lt1", Keys: []IndexKey{{ColId: "lc2"}}, StoredColumnIds: []string{"lc3"}}}
+	live["lt1"] = users
+	target := liveDiffTestSchema()
+	orders := target["lt2"]
+	orders.Indexes = []CreateIndex{{Name: "orders_by_user", TableId: "lt2", Keys: []IndexKey{{ColId: "lc5"}}, NullFiltered: true}}
+	target["lt2"] = orders
+
+	// The stored column of users_by_name is dropped, so the index is dropped
+	// before it.
+	users = target["lt1"]
+	users.ColIds = []string{"lc1", "lc2"}
+	delete(users.ColDefs, "lc3")
+	users.Indexes = []CreateIndex{{Name: "users_by_name", TableId: "lt1", Keys: []IndexKey{{ColId: "lc2"}}}}
+	target["lt1"] = users
+
+	d := DiffSchemas(Config{ProtectIds: true}, live, target)
+	expected := []SchemaChange{
+		{Statement: "DROP INDEX `users_by_name`"},
+		{Statement: "DROP INDEX `orders_by_user`"},
+		{Statement: "ALTER TABLE `Users` DROP COLUMN `legacy`", Destructive: true},
+		{Statement: "CREATE INDEX `users_by_name` ON `Users` (`name`)"},
+		{Statement: "CREATE NULL_FILTERED INDEX `orders_by_user` ON `orders` (`user_id`)"},
+	}
+	assert.Equal(t, expected, d.Changes)
+
+	// Stored columns are compared in any order, and interleaved indexes by
+	// the name of the table they are interleaved in.
+	live, target = liveDiffTestSchema(), liveDiffTestSchema()
+	for _, s := range []Schema{live, target} {
+		users := s["lt1"]
+		users.Indexes = []CreateIndex{{Name: "users_by_name", TableId: "lt1", Keys: []IndexKey{{ColId: "lc2"}}, StoredColumnIds: []string{"lc3", "lc1"}}}
+		s["lt1"] = users
+		orders := s["lt2"]
+		orders.ParentTable = InterleavedParent{Id: "lt1", InterleaveType: "IN"}
+		s["lt2"] = orders
+	}
+	users = target["lt1"]
+	users.Indexes[0].StoredColumnIds = []string{"lc1", "lc3"}
+	target["lt1"] = users
+	orders = target["lt2"]
+	orders.Indexes = []CreateIndex{{Name: "orders_by_user", TableId: "lt2", Keys: []IndexKey{{ColId: "lc5"}}, InterleaveIn: "lt1"}}
+	target["lt2"] = orders
+	d = DiffSchemas(Config{ProtectIds: true}, live, target)
+	assert.Equal(t, []SchemaChange{
+		{Statement: "DROP INDEX `orders_by_user`"},
+		{Statement: "CREATE INDEX `orders_by_user` ON `orders` (`user_id`), INTERLEAVE IN `Users`"},
+	}, d.Changes)
+}
+
+func TestDiffSchemasAddNotNullColumn(t *testing.T) {
+	target := liveDiffTestSchema()
+	audit := target["lt3"]
+	audit.ColIds = append(audit.ColIds, "lc7", "lc8")
+	audit.ColDefs["lc7"] = ColumnDef{Name: "source", Id: "lc7", T: Type{Name: String, Len: 10}, NotNull: true}
+	audit.ColDefs["lc8"] = ColumnDef{Name: "kind", Id: "lc8", T: Type{Name: String, Len: 10}, NotNull: true, DefaultValue: DefaultValue{IsPresent: true, Value: Expression{Statement: "'event'"}}}
+	target["lt3"] = audit
+
+	d := DiffSchemas(Config{ProtectIds: true}, liveDiffTestSchema(), target)
+	assert.Equal(t, []SchemaChange{{Statement: "ALTER TABLE `audit` ADD COLUMN `kind` STRING(10) NOT NULL  DEFAULT ('event')"}}, d.Changes)
+	assert.Equal(t, []string{"column audit.source: NOT NULL column without a default value is added"}, d.Unsupported)
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ProtoPackage is the proto package of the enums generated for source enum
+// types.
+const ProtoPackage = "spanner_migration"
+
+// ProtoEnum is a proto enum generated for a source enum type. Its values
+// are numbered from 1 in the order of Labels, as MySQL numbers enum values,
+// and 0 is the unspecified value required by proto3.
+type ProtoEnum struct {
+	Name   string   // Fully qualified name.
+	Labels []string // Source labels.
+}
+
+// ProtoBundle encodes the following DDL definition:
+//
+//	CREATE PROTO BUNDLE ( proto_type_name [, ...] )
+//
+// The descriptors of its types are sent to Spanner along with the statement.
+type ProtoBundle struct {
+	Enums []ProtoEnum // Sorted by name.
+}
+
+// ProtoEnumName returns the fully qualified name of the proto enum
+// generated for name, with the characters that aren't allowed in proto
+// identifiers replaced by underscores.
+func ProtoEnumName(name string) string {
+	return ProtoPackage + "." + protoIdentifier(name, "Enum")
+}
+
+// EnumNumber returns the number of the value of label in an enum with
+// labels.
+func EnumNumber(labels []string, label string) (int64, error) {
+	for i, l := range labels {
+		if l == label {
+			return int64(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("'%s' isn't a value of the enum", label)
+}
+
+// PrintCreateProtoBundle unparses a CREATE PROTO BUNDLE statement.
+func (pb ProtoBundle) PrintCreateProtoBundle(c Config) string {
+	var names []string
+	for _, e := range pb.Enums {
+		name := e.Name
+		if c.ProtectIds {
+			name = "`" + name + "`"
+		}
+		names = append(names, name)
+	}
+	return fmt.Sprintf("CREATE PROTO BUNDLE (\n\t%s\n)", strings.Join(names, ",\n\t"))
+}
+
+// GetProtoBundleDDL returns the CREATE PROTO BUNDLE statement of pb, which
+// must be run before the tables that use its types. It returns nothing if
+// pb is empty, or for the PostgreSQL dialect, which has no proto types.
+func GetProtoBundleDDL(c Config, pb ProtoBundle) []string {
+	if len(pb.Enums) == 0 || c.SpDialect == constants.DIALECT_POSTGRESQL {
+		return nil
+	}
+	return []string{pb.PrintCreateProtoBundle(c)}
+}
+
+// ProtoFile returns the .proto file that defines the types of pb.
+func (pb ProtoBundle) ProtoFile() string {
+	var b strings.Builder
+	b.WriteString("// Proto enums generated for the enum types of the source database.\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n", ProtoPackage)
+	for _, e := range pb.Enums {
+		fmt.Fprintf(&b, "\nenum %s {\n", e.shortName())
+		for i, name := range e.valueNames() {
+			if i == 0 {
+				fmt.Fprintf(&b, "  %s = 0;\n", name)
+				continue
+			}
+			fmt.Fprintf(&b, "  %s = %d; // %s\n", name, i, strconv.Quote(e.Labels[i-1]))
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// FileDescriptorSet returns the serialized descriptorpb.FileDescriptorSet of
+// the types of pb, as expected by the proto_descriptors field of the Spanner
+// CreateDatabase and UpdateDatabaseDdl requests.
+func (pb ProtoBundle) FileDescriptorSet() ([]byte, error) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String(ProtoPackage + ".proto"),
+		Package: proto.String(ProtoPackage),
+		Syntax:  proto.String("proto3"),
+	}
+	for _, e := range pb.Enums {
+		enum := &descriptorpb.EnumDescriptorProto{Name: proto.String(e.shortName())}
+		for i, name := range e.valueNames() {
+			enum.Value = append(enum.Value, &descriptorpb.EnumValueDescriptorProto{
+				Name:   proto.String(name),
+				Number: proto.Int32(int32(i)),
+			})
+		}
+		file.EnumType = append(file.EnumType, enum)
+	}
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{file}}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(set)
+}
+
+func (e ProtoEnum) shortName() string {
+	return e.Name[strings.LastIndex(e.Name, ".")+1:]
+}
+
+// valueNames returns the names of the values of e, starting with the
+// unspecified value. Enum values are scoped to the package in proto, so they
+// are prefixed with the name of the enum.
+func (e ProtoEnum) valueNames() []string {
+	prefix := strings.ToUpper(e.shortName())
+	names := []string{prefix + "_UNSPECIFIED"}
+	used := map[string]bool{names[0]: true}
+	for _, label := range e.Labels {
+		name := prefix + "_" + strings.ToUpper(protoIdentifier(label, "EMPTY"))
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s_%s_%d", prefix, strings.ToUpper(protoIdentifier(label, "EMPTY")), i)
+		}
+		used[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// protoIdentifier returns s with the characters that aren't letters, digits
+// or underscores replaced by underscores. It returns empty if s is empty,
+// and prefixes s with an underscore if it starts with a digit.
+func protoIdentifier(s, empty string) string {
+	if s == "" {
+		return empty
+	}
+	id := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			return r
+		}
+		return '_'
+	}, s)
+	if unicode.IsDigit(rune(id[0])) {
+		id = "_" + id
+	}
+	return id
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func testProtoBundle() ProtoBundle {
+	return ProtoBundle{Enums: []ProtoEnum{
+		{Name: "spanner_migration.mood", Labels: []string{"sad", "ok", "happy"}},
+		{Name: "spanner_migration.users_size", Labels: []string{"x-small", "X_SMALL", "", "2xl"}},
+	}}
+}
+
+func TestProtoEnumName(t *testing.T) {
+	assert.Equal(t, "spanner_migration.mood", ProtoEnumName("mood"))
+	assert.Equal(t, "spanner_migration.order_items_size", ProtoEnumName("order-items_size"))
+	assert.Equal(t, "spanner_migration._1_t_c", ProtoEnumName("1 t_c"))
+}
+
+func TestEnumNumber(t *testing.T) {
+	labels := []string{"sad", "ok", "happy"}
+	n, err := EnumNumber(labels, "sad")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = EnumNumber(labels, "happy")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+	_, err = EnumNumber(labels, "angry")
+	assert.NotNil(t, err)
+}
+
+func TestGetProtoBundleDDL(t *testing.T) {
+	pb := testProtoBundle()
+	assert.Equal(t, []string{"CREATE PROTO BUNDLE (\n\tspanner_migration.mood,\n\tspanner_migration.users_size\n)"}, GetProtoBundleDDL(Config{}, pb))
+	assert.Equal(t, []string{"CREATE PROTO BUNDLE (\n\t`spanner_migration.mood`,\n\t`spanner_migration.users_size`\n)"}, GetProtoBundleDDL(Config{ProtectIds: true}, pb))
+	assert.Nil(t, GetProtoBundleDDL(Config{SpDialect: constants.DIALECT_POSTGRESQL}, pb))
+	assert.Nil(t, GetProtoBundleDDL(Config{}, ProtoBundle{}))
+}
+
+func TestProtoFile(t *testing.T) {
+	expected := `// Proto enums generated for the enum types of the source database.
+syntax = "proto3";
+
+package spanner_migration;
+
+enum mood {
+  MOOD_UNSPECIFIED = 0;
+  MOOD_SAD = 1; // "sad"
+  MOOD_OK = 2; // "ok"
+  MOOD_HAPPY = 3; // "happy"
+}
+
+enum users_size {
+  USERS_SIZE_UNSPECIFIED = 0;
+  USERS_SIZE_X_SMALL = 1; // "x-small"
+  USERS_SIZE_X_SMALL_2 = 2; // "X_SMALL"
+  USERS_SIZE_EMPTY = 3; // ""
+  USERS_SIZE__2XL = 4; // "2xl"
+}
+`
+	assert.Equal(t, expected, testProtoBundle().ProtoFile())
+}
+
+func TestFileDescriptorSet(t *testing.T) {
+	b, err := testProtoBundle().FileDescriptorSet()
+	assert.Nil(t, err)
+	set := &descriptorpb.FileDescriptorSet{}
+	assert.Nil(t, proto.Unmarshal(b, set))
+	files, err := protodesc.NewFiles(set)
+	assert.Nil(t, err)
+	d, err := files.FindDescriptorByName("spanner_migration.users_size")
+	assert.Nil(t, err)
+	values := d.(protoreflect.EnumDescriptor).Values()
+	assert.Equal(t, 5, values.Len())
+	assert.Equal(t, protoreflect.Name("USERS_SIZE_UNSPECIFIED"), values.ByNumber(0).Name())
+	assert.Equal(t, protoreflect.Name("USERS_SIZE_X_SMALL_2"), values.ByNumber(2).Name())
+	assert.Equal(t, protoreflect.Name("USERS_SIZE__2XL"), values.ByNumber(4).Name())
+}
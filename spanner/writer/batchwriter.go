@@ -41,10 +41,38 @@ const (
 	byteThreshold  = 20 * 1 << 20 // Spanner per-operation limit is 100MB.
 )
 
+// WriteMode specifies the kind of mutation used to write rows to Spanner.
+type WriteMode string
+
+const (
+	// WriteModeInsert fails rows that already exist with error 'AlreadyExists'.
+	WriteModeInsert WriteMode = "insert"
+	// WriteModeInsertOrUpdate overwrites the written columns of rows that
+	// already exist, leaving their other columns unchanged.
+	WriteModeInsertOrUpdate WriteMode = "insert_or_update"
+	// WriteModeReplace deletes rows that already exist before writing them,
+	// so columns that are not written are reset to NULL or their default.
+	WriteModeReplace WriteMode = "replace"
+)
+
+// ParseWriteMode returns the WriteMode named by s. An empty string selects
+// WriteModeInsert.
+func ParseWriteMode(s string) (WriteMode, error) {
+	switch m := WriteMode(s); m {
+	case "":
+		return WriteModeInsert, nil
+	case WriteModeInsert, WriteModeInsertOrUpdate, WriteModeReplace:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid write mode %q, valid values are {%s, %s, %s}", s, WriteModeInsert, WriteModeInsertOrUpdate, WriteModeReplace)
+	}
+}
+
 // BatchWriter accumulates rows of data (via AddRow) and assembles them
-// into batches that it asynchronously writes to Spanner.  Rows are
-// written to Spanner using insert semantics i.e. if a row already exists
-// in the database, the row will fail with error 'AlreadyExists'.  If
+// into batches that it asynchronously writes to Spanner.  By default rows
+// are written to Spanner using insert semantics i.e. if a row already exists
+// in the database, the row will fail with error 'AlreadyExists'; use
+// WriteModeInsertOrUpdate or WriteModeReplace to make loads idempotent.  If
 // Spanner returns an error for a batch, BatchWriter splits the batch
 // into smaller chunks to retry, as it attempts to isolate which row(s)
 // in a batch is bad.  BatchWriter respects Spanner's limits on byte size
@@ -63,6 +91,8 @@ type BatchWriter struct {
 	bytesLimit int64                      // Limit on bytes buffered. AddRow blocks if rBytes exceeded this value.
 	retryLimit int64                      // Limit on retries.
 	verbose    bool                       // If true, print out messages about each write batch.
+	writeMode  WriteMode                  // Kind of mutation used to write rows.
+	deadLetter internal.DeadLetterSink    // Receives every dropped row, if not nil.
 	async      asyncState
 }
 
@@ -96,6 +126,8 @@ type BatchWriterConfig struct {
 	RetryLimit int64                      // Limit on retries.
 	Write      func([]*sp.Mutation) error // Function to call to write to Spanner (typically a closure that calls client.Apply).
 	Verbose    bool                       // If true, print out messages about each write batch.
+	WriteMode  WriteMode                  // Kind of mutation used to write rows. Defaults to WriteModeInsert.
+	DeadLetter internal.DeadLetterSink    // Receives every dropped row with the error that dropped it. Optional.
 }
 
 // NewBatchWriter returns a new BatchWriter with parameters defined by config.
@@ -106,6 +138,8 @@ func NewBatchWriter(config BatchWriterConfig) *BatchWriter {
 		bytesLimit: config.BytesLimit,
 		retryLimit: config.RetryLimit,
 		verbose:    config.Verbose,
+		writeMode:  config.WriteMode,
+		deadLetter: config.DeadLetter,
 		async: asyncState{
 			errors:      make(map[string]int64),
 			droppedRows: make(map[string]int64),
@@ -250,18 +284,35 @@ func (bw *BatchWriter) errorStats(rows []*row, err error, retry bool) {
 	return
 }
 
+// mutation returns the mutation that writes r using bw's write mode.
+func (bw *BatchWriter) mutation(r *row) *sp.Mutation {
+	switch bw.writeMode {
+	case WriteModeInsertOrUpdate:
+		return sp.InsertOrUpdate(r.table, r.cols, r.vals)
+	case WriteModeReplace:
+		return sp.Replace(r.table, r.cols, r.vals)
+	default:
+		return sp.Insert(r.table, r.cols, r.vals)
+	}
+}
+
 // Note: doWriteAndHandleErrors must be thread-safe because it is run
 // inside a go routine.
 func (bw *BatchWriter) doWriteAndHandleErrors(rows []*row) {
 	var m []*sp.Mutation
 	for _, x := range rows {
-		m = append(m, sp.Insert(x.table, x.cols, x.vals))
+		m = append(m, bw.mutation(x))
 	}
 	if err := bw.write(m); err != nil {
 		hitRetryLimit := atomic.LoadInt64(&bw.async.retries) >= bw.retryLimit
 		retry := len(rows) > 1 && !hitRetryLimit
 		bw.errorStats(rows, err, retry)
 		if !retry {
+			if bw.deadLetter != nil {
+				for _, x := range rows {
+					bw.deadLetter.Write(deadLetterRow(x, err))
+				}
+			}
 			if hitRetryLimit && bw.verbose {
 				logger.Log.Info(fmt.Sprintf("Have hit %d retries: will not do any more\n", atomic.LoadInt64(&bw.async.retries)))
 			}
@@ -351,6 +402,8 @@ func GetBatchWriterWithConfig(ctx context.Context, spannerClient spannerclient.S
 		WriteLimit: 2000,
 		RetryLimit: 1000,
 		Verbose:    internal.Verbose(),
+		WriteMode:  WriteMode(conv.DataWriteMode),
+		DeadLetter: conv.DeadLetter,
 	}
 
 	rows := int64(0)
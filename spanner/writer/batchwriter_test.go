@@ -102,6 +102,90 @@ func TestFlush(t *testing.T) {
 	}
 }
 
+func TestWriteMode(t *testing.T) {
+	tests := []struct {
+		mode     WriteMode
+		expected *sp.Mutation
+	}{
+		{mode: "", expected: sp.Insert("t", []string{"a"}, []interface{}{int64(1)})},
+		{mode: WriteModeInsert, expected: sp.Insert("t", []string{"a"}, []interface{}{int64(1)})},
+		{mode: WriteModeInsertOrUpdate, expected: sp.InsertOrUpdate("t", []string{"a"}, []interface{}{int64(1)})},
+		{mode: WriteModeReplace, expected: sp.Replace("t", []string{"a"}, []interface{}{int64(1)})},
+	}
+	for _, tc := range tests {
+		var written []*sp.Mutation
+		bw := NewBatchWriter(BatchWriterConfig{
+			BytesLimit: 100 << 20,
+			WriteLimit: 1,
+			RetryLimit: 1000,
+			WriteMode:  tc.mode,
+			Write: func(m []*sp.Mutation) error {
+				written = append(written, m...)
+				return nil
+			},
+		})
+		bw.AddRow("t", []string{"a"}, []interface{}{int64(1)})
+		bw.Flush()
+		assert.Equal(t, []*sp.Mutation{tc.expected}, written, string(tc.mode))
+	}
+}
+
+// testDeadLetterSink collects the rows sent to it.
+type testDeadLetterSink struct {
+	mutex sync.Mutex
+	rows  []internal.DeadLetterRow
+}
+
+func (s *testDeadLetterSink) Write(row internal.DeadLetterRow) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rows = append(s.rows, row)
+}
+
+func TestDeadLetter(t *testing.T) {
+	sink := &testDeadLetterSink{}
+	bw := NewBatchWriter(BatchWriterConfig{
+		BytesLimit: 100 << 20,
+		WriteLimit: 1,
+		RetryLimit: 1000,
+		DeadLetter: sink,
+		Write: func(m []*sp.Mutation) error {
+			for _, x := range m {
+				if reflect.DeepEqual(x, sp.Insert("t", []string{"a", "b"}, []interface{}{int64(2), nil})) {
+					return errors.New("bad data")
+				}
+			}
+			return nil
+		},
+	})
+	for i := int64(1); i <= 3; i++ {
+		if i == 2 {
+			bw.AddRow("t", []string{"a", "b"}, []interface{}{i, nil})
+		} else {
+			bw.AddRow("t", []string{"a", "b"}, []interface{}{i, "x"})
+		}
+	}
+	bw.Flush()
+	assert.Equal(t, []internal.DeadLetterRow{
+		{Stage: internal.DeadLetterWrite, Table: "t", Cols: []string{"a"}, Vals: []string{"2"}, Error: "bad data"},
+	}, sink.rows)
+}
+
+func TestParseWriteMode(t *testing.T) {
+	for s, expected := range map[string]WriteMode{
+		"":                 WriteModeInsert,
+		"insert":           WriteModeInsert,
+		"insert_or_update": WriteModeInsertOrUpdate,
+		"replace":          WriteModeReplace,
+	} {
+		mode, err := ParseWriteMode(s)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, mode)
+	}
+	_, err := ParseWriteMode("upsert")
+	assert.NotNil(t, err)
+}
+
 func TestDroppedRowsByTable(t *testing.T) {
 	bw := NewBatchWriter(BatchWriterConfig{})
 	bw.async.lock.Lock()
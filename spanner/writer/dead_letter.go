@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+)
+
+// deadLetterRow returns the dead-letter row of a row that was dropped with
+// error err. Values are formatted so that they can be converted back with the
+// rules used for CSV files, e.g. timestamps in RFC 3339 format and arrays as
+// [v1,v2,...], except for bytes, which are base64 encoded.
+func deadLetterRow(r *row, err error) internal.DeadLetterRow {
+	dl := internal.DeadLetterRow{Stage: internal.DeadLetterWrite, Table: r.table, Error: err.Error()}
+	for i, c := range r.cols {
+		v, ok := formatValue(r.vals[i])
+		if !ok {
+			continue
+		}
+		dl.Cols = append(dl.Cols, c)
+		dl.Vals = append(dl.Vals, v)
+	}
+	return dl
+}
+
+// formatValue returns the string form of a value of a mutation, or false if
+// the value is NULL.
+func formatValue(v interface{}) (string, bool) {
+	switch x := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return x, true
+	case []byte:
+		if x == nil {
+			return "", false
+		}
+		return base64.StdEncoding.EncodeToString(x), true
+	case bool:
+		return strconv.FormatBool(x), true
+	case int64:
+		return strconv.FormatInt(x, 10), true
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(x), 'g', -1, 32), true
+	case time.Time:
+		return x.Format(time.RFC3339Nano), true
+	case civil.Date:
+		return x.String(), true
+	case big.Rat:
+		return formatNumeric(&x), true
+	case *big.Rat:
+		if x == nil {
+			return "", false
+		}
+		return formatNumeric(x), true
+	case sp.NullString:
+		return x.StringVal, x.Valid
+	case sp.NullBool:
+		return strconv.FormatBool(x.Bool), x.Valid
+	case sp.NullInt64:
+		return strconv.FormatInt(x.Int64, 10), x.Valid
+	case sp.NullFloat64:
+		return strconv.FormatFloat(x.Float64, 'g', -1, 64), x.Valid
+	case sp.NullFloat32:
+		return strconv.FormatFloat(float64(x.Float32), 'g', -1, 32), x.Valid
+	case sp.NullTime:
+		return x.Time.Format(time.RFC3339Nano), x.Valid
+	case sp.NullDate:
+		return x.Date.String(), x.Valid
+	case sp.NullNumeric:
+		return formatNumeric(&x.Numeric), x.Valid
+	case sp.PGNumeric:
+		return x.Numeric, x.Valid
+	case sp.NullJSON:
+		if !x.Valid {
+			return "", false
+		}
+		b, err := json.Marshal(x.Value)
+		if err != nil {
+			return fmt.Sprint(x.Value), true
+		}
+		return string(b), true
+	case sp.PGJsonB:
+		if !x.Valid {
+			return "", false
+		}
+		b, err := json.Marshal(x.Value)
+		if err != nil {
+			return fmt.Sprint(x.Value), true
+		}
+		return string(b), true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		if rv.IsNil() {
+			return "", false
+		}
+		var elems []string
+		for i := 0; i < rv.Len(); i++ {
+			elem := rv.Index(i).Interface()
+			e, ok := formatValue(elem)
+			if !ok {
+				e = "NULL"
+			} else if isStringValue(elem) {
+				e = strconv.Quote(e)
+			}
+			elems = append(elems, e)
+		}
+		return "[" + strings.Join(elems, ",") + "]", true
+	}
+	return fmt.Sprint(v), true
+}
+
+// isStringValue returns whether v is a string element of an array, which is
+// quoted since it can contain commas or be the string "NULL".
+func isStringValue(v interface{}) bool {
+	switch v.(type) {
+	case string, sp.NullString:
+		return true
+	}
+	return false
+}
+
+// formatNumeric formats a NUMERIC value with the 9 digits of scale of Spanner,
+// without trailing zeros.
+func formatNumeric(r *big.Rat) string {
+	s := r.FloatString(9)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
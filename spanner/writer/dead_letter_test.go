@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatValue(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+		valid bool
+	}{
+		{"nil", nil, "", false},
+		{"string", "abc", "abc", true},
+		{"bytes", []byte{0xff, 0x00, 'a'}, "/wBh", true},
+		{"bool", true, "true", true},
+		{"int64", int64(-42), "-42", true},
+		{"float64", 1.5, "1.5", true},
+		{"float32", float32(0.25), "0.25", true},
+		{"timestamp", ts, "2024-01-02T03:04:05.0000006Z", true},
+		{"date", civil.Date{Year: 2024, Month: 1, Day: 2}, "2024-01-02", true},
+		{"numeric", *big.NewRat(5, 4), "1.25", true},
+		{"integral numeric", *big.NewRat(10, 1), "10", true},
+		{"null string", sp.NullString{}, "", false},
+		{"valid null int64", sp.NullInt64{Int64: 7, Valid: true}, "7", true},
+		{"pg numeric", sp.PGNumeric{Numeric: "1.5", Valid: true}, "1.5", true},
+		{"json", sp.NullJSON{Value: map[string]interface{}{"a": 1}, Valid: true}, `{"a":1}`, true},
+		{"int64 array", []int64{1, 2}, "[1,2]", true},
+		{"string array", []sp.NullString{{StringVal: "a", Valid: true}, {}}, `["a",NULL]`, true},
+		{"nil array", []int64(nil), "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, valid := formatValue(tt.value)
+			assert.Equal(t, tt.valid, valid)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeadLetterRow(t *testing.T) {
+	r := &row{table: "t", cols: []string{"a", "b", "c"}, vals: []interface{}{int64(1), sp.NullString{}, "x"}}
+	assert.Equal(t, internal.DeadLetterRow{
+		Stage: internal.DeadLetterWrite,
+		Table: "t",
+		Cols:  []string{"a", "c"},
+		Vals:  []string{"1", "x"},
+		Error: "AlreadyExists",
+	}, deadLetterRow(r, errors.New("AlreadyExists")))
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteReport writes a per-table summary of results to w, and returns the
+// number of tables that didn't match.
+func WriteReport(w io.Writer, results []TableResult) int {
+	mismatches := 0
+	for _, r := range results {
+		status := "MATCH"
+		if r.Err != nil {
+			status = "ERROR"
+		} else if !r.Match() {
+			status = "MISMATCH"
+		}
+		if status != "MATCH" {
+			mismatches++
+		}
+		fmt.Fprintf(w, "%-8s %s -> %s\n", status, r.SrcTable, r.SpTable)
+		if r.Err != nil {
+			fmt.Fprintf(w, "         %v\n", r.Err)
+			continue
+		}
+		fmt.Fprintf(w, "         source rows: %d, Spanner rows: %d, mismatched key ranges: %d of %d\n", r.SourceRows, r.SpannerRows, r.MismatchedRanges, r.Ranges)
+		if r.SourceErrors > 0 {
+			fmt.Fprintf(w, "         source rows that couldn't be converted: %d\n", r.SourceErrors)
+		}
+		for _, s := range r.Samples {
+			fmt.Fprintf(w, "         %s: %s\n", s.Reason, s.Key)
+		}
+	}
+	fmt.Fprintf(w, "\n%d of %d tables match.\n", len(results)-mismatches, len(results))
+	return mismatches
+}
@@ -0,0 +1,396 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validation compares the data of a migrated Spanner database with
+// the source database it was migrated from.
+//
+// Source rows are read and converted exactly as they are during data
+// migration, and the converted values are compared with the values read back
+// from Spanner. Each table is split into key ranges (when the source supports
+// reading in key ranges), and the row count and checksum of every range are
+// compared. Ranges that don't match are read a second time to find sample
+// primary keys of the mismatched rows.
+package validation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	sp "cloud.google.com/go/spanner"
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	// DefaultRanges is the default number of key ranges a table is split into.
+	DefaultRanges = 16
+	// DefaultSampleSize is the default number of mismatched primary keys
+	// reported per table.
+	DefaultSampleSize = 10
+)
+
+// MismatchReason describes why a row doesn't match.
+type MismatchReason string
+
+const (
+	MissingInSpanner MismatchReason = "missing in Spanner"
+	MissingInSource  MismatchReason = "missing in source"
+	ValuesDiffer     MismatchReason = "values differ"
+)
+
+// MismatchedRow is a row that differs between the source and Spanner.
+type MismatchedRow struct {
+	Key    string // Primary key of the row, e.g. "id=1, name=\"a\"".
+	Reason MismatchReason
+}
+
+// TableResult is the outcome of validating a single table.
+type TableResult struct {
+	SrcTable    string
+	SpTable     string
+	SourceRows  int64
+	SpannerRows int64
+	// SourceErrors is the number of source rows that couldn't be converted,
+	// and so were never written to Spanner.
+	SourceErrors     int64
+	Ranges           int
+	MismatchedRanges int
+	// Samples lists some of the mismatched rows. It is empty for tables
+	// without a primary key, since their rows can't be matched by key.
+	Samples []MismatchedRow
+	Err     error
+}
+
+// Match returns true if the data of the table is the same in the source and
+// in Spanner.
+func (r TableResult) Match() bool {
+	return r.Err == nil && r.MismatchedRanges == 0 && r.SourceErrors == 0
+}
+
+// Validator compares the tables of a source database with the corresponding
+// Spanner tables. Conv holds the source and Spanner schemas, usually read
+// from the session file of the migration.
+type Validator struct {
+	Ctx        context.Context
+	Conv       *internal.Conv
+	InfoSchema common.InfoSchema
+	Client     spannerclient.SpannerClient
+	// Ranges is the number of key ranges each table is split into. Only
+	// tables with an integer leading primary key column are split, and only
+	// for sources that can read key ranges.
+	Ranges int
+	// SampleSize is the maximum number of mismatched rows reported per table.
+	SampleSize int
+}
+
+// rowDigest is a hash of the converted values of a row.
+type rowDigest [16]byte
+
+// rangeDigest is an order independent checksum of the rows of a key range.
+type rangeDigest struct {
+	rows int64
+	sum  [2]uint64
+}
+
+func (d *rangeDigest) add(r rowDigest) {
+	d.rows++
+	d.sum[0] += binary.BigEndian.Uint64(r[:8])
+	d.sum[1] += binary.BigEndian.Uint64(r[8:])
+}
+
+// table holds what is needed to read and compare a single table.
+type table struct {
+	tableId string
+	colIds  []string        // Ids of the columns common to the source and Spanner.
+	cols    []ddl.ColumnDef // Compared columns, sorted by name.
+	keyCols []ddl.ColumnDef // Primary key columns, nil if rows can't be matched by key.
+	chunked bool            // Whether the table is read in key ranges.
+}
+
+// Validate compares every table of the Spanner schema in v.Conv with its
+// source table. Tables are validated one at a time, and an error in one
+// table is recorded in its result without stopping the validation of the
+// remaining tables.
+func (v *Validator) Validate() []TableResult {
+	v.Conv.SetDataMode()
+	var results []TableResult
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(v.Conv.SpSchema) {
+		if _, ok := v.Conv.SrcSchema[tableId]; !ok {
+			continue
+		}
+		r := v.validateTable(tableId)
+		if r.Err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't validate table %s: %v", r.SrcTable, r.Err))
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func (v *Validator) validateTable(tableId string) TableResult {
+	srcSchema := v.Conv.SrcSchema[tableId]
+	spSchema := v.Conv.SpSchema[tableId]
+	res := TableResult{SrcTable: srcSchema.Name, SpTable: spSchema.Name}
+	t := v.newTable(tableId)
+	ranges, err := v.keyRanges(t)
+	if err != nil {
+		res.Err = fmt.Errorf("can't compute key ranges: %v", err)
+		return res
+	}
+	res.Ranges = len(ranges)
+	logger.Log.Info(fmt.Sprintf("validating table %s in %d key ranges", srcSchema.Name, len(ranges)))
+	badRows := v.Conv.Stats.BadRows[srcSchema.Name]
+	var mismatched []common.KeyRange
+	for _, kr := range ranges {
+		src, err := v.readSource(t, kr, nil)
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		dst, err := v.readSpanner(t, kr, nil)
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		res.SourceRows += src.rows
+		res.SpannerRows += dst.rows
+		if src != dst {
+			mismatched = append(mismatched, kr)
+		}
+	}
+	res.SourceErrors = v.Conv.Stats.BadRows[srcSchema.Name] - badRows
+	res.MismatchedRanges = len(mismatched)
+	if t.keyCols == nil {
+		return res
+	}
+	for _, kr := range mismatched {
+		if len(res.Samples) >= v.SampleSize {
+			break
+		}
+		samples, err := v.compareRows(t, kr, v.SampleSize-len(res.Samples))
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		res.Samples = append(res.Samples, samples...)
+	}
+	return res
+}
+
+func (v *Validator) newTable(tableId string) *table {
+	spSchema := v.Conv.SpSchema[tableId]
+	t := &table{tableId: tableId, colIds: common.GetCommonColumnIds(v.Conv, tableId, spSchema.ColIds)}
+	compared := make(map[string]bool)
+	for _, colId := range t.colIds {
+		t.cols = append(t.cols, spSchema.ColDefs[colId])
+		compared[colId] = true
+	}
+	sort.Slice(t.cols, func(i, j int) bool { return t.cols[i].Name < t.cols[j].Name })
+	// Rows of tables with a synthetic primary key, or whose key includes a
+	// column that isn't in the source (e.g. a shard id), can't be matched.
+	if _, ok := v.Conv.SyntheticPKeys[tableId]; ok {
+		return t
+	}
+	pks := make([]ddl.IndexKey, len(spSchema.PrimaryKeys))
+	copy(pks, spSchema.PrimaryKeys)
+	sort.Slice(pks, func(i, j int) bool { return pks[i].Order < pks[j].Order })
+	var keyCols []ddl.ColumnDef
+	for _, pk := range pks {
+		if !compared[pk.ColId] {
+			return t
+		}
+		keyCols = append(keyCols, spSchema.ColDefs[pk.ColId])
+	}
+	if len(keyCols) > 0 {
+		t.keyCols = keyCols
+	}
+	return t
+}
+
+// keyRanges returns the key ranges table t is compared in. Tables are split
+// on their leading primary key column when it is an integer in both the
+// source and Spanner, and the source can read key ranges.
+func (v *Validator) keyRanges(t *table) ([]common.KeyRange, error) {
+	cis, ok := v.InfoSchema.(common.ChunkedInfoSchema)
+	if !ok {
+		return []common.KeyRange{{}}, nil
+	}
+	colId, ok := common.GetChunkColumn(v.Conv.SrcSchema[t.tableId])
+	if !ok {
+		return []common.KeyRange{{}}, nil
+	}
+	spType := v.Conv.SpSchema[t.tableId].ColDefs[colId].T
+	if spType.Name != ddl.Int64 || spType.IsArray {
+		return []common.KeyRange{{}}, nil
+	}
+	t.chunked = true
+	return common.GetKeyRanges(v.Conv, cis, t.tableId, v.Ranges, nil)
+}
+
+// digest returns the primary key and digest of a row, given the values of
+// its columns by column name. Missing columns are treated as NULL, since
+// the source data converters omit NULL values.
+func (t *table) digest(vals map[string]interface{}) (string, rowDigest) {
+	h := sha256.New()
+	for _, col := range t.cols {
+		fmt.Fprintf(h, "%s=%s\x00", col.Name, canonical(col.T, vals[col.Name]))
+	}
+	var d rowDigest
+	copy(d[:], h.Sum(nil))
+	var key []string
+	for _, col := range t.keyCols {
+		key = append(key, fmt.Sprintf("%s=%s", col.Name, canonical(col.T, vals[col.Name])))
+	}
+	return strings.Join(key, ", "), d
+}
+
+// readSource reads the rows of key range kr of the source table, converting
+// them as in data migration. If rows is non-nil, the digest of each row is
+// recorded in it by primary key.
+func (v *Validator) readSource(t *table, kr common.KeyRange, rows map[string]rowDigest) (rangeDigest, error) {
+	var d rangeDigest
+	v.Conv.SetDataSink(func(_ string, cols []string, values []interface{}) {
+		vals := make(map[string]interface{}, len(cols))
+		for i, col := range cols {
+			vals[col] = values[i]
+		}
+		key, r := t.digest(vals)
+		d.add(r)
+		if rows != nil {
+			rows[key] = r
+		}
+	})
+	srcSchema := v.Conv.SrcSchema[t.tableId]
+	spSchema := v.Conv.SpSchema[t.tableId]
+	var err error
+	if t.chunked {
+		cis := v.InfoSchema.(common.ChunkedInfoSchema)
+		err = cis.ProcessDataChunk(v.Conv, t.tableId, srcSchema, t.colIds, spSchema, kr, &sync.Mutex{}, internal.AdditionalDataAttributes{})
+	} else {
+		err = v.InfoSchema.ProcessData(v.Conv, t.tableId, srcSchema, t.colIds, spSchema, internal.AdditionalDataAttributes{})
+	}
+	if err != nil {
+		return d, fmt.Errorf("can't read source table %s: %v", srcSchema.Name, err)
+	}
+	return d, nil
+}
+
+// readSpanner reads the rows of key range kr of the Spanner table. If rows
+// is non-nil, the digest of each row is recorded in it by primary key.
+func (v *Validator) readSpanner(t *table, kr common.KeyRange, rows map[string]rowDigest) (rangeDigest, error) {
+	var d rangeDigest
+	spTable := v.Conv.SpSchema[t.tableId].Name
+	iter := v.Client.Single().Query(v.Ctx, v.query(t, kr))
+	defer iter.Stop()
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return d, fmt.Errorf("can't read Spanner table %s: %v", spTable, err)
+		}
+		vals := make(map[string]interface{}, row.Size())
+		for i, name := range row.ColumnNames() {
+			var gcv sp.GenericColumnValue
+			if err := row.Column(i, &gcv); err != nil {
+				return d, fmt.Errorf("can't read column %s of Spanner table %s: %v", name, spTable, err)
+			}
+			x, err := decodeSpannerValue(gcv)
+			if err != nil {
+				return d, fmt.Errorf("can't read column %s of Spanner table %s: %v", name, spTable, err)
+			}
+			vals[name] = x
+		}
+		key, r := t.digest(vals)
+		d.add(r)
+		if rows != nil {
+			rows[key] = r
+		}
+	}
+	return d, nil
+}
+
+// query returns the statement that reads the compared columns of the rows of
+// key range kr from the Spanner table.
+func (v *Validator) query(t *table, kr common.KeyRange) sp.Statement {
+	quote := func(s string) string { return "`" + s + "`" }
+	placeholder := func(i int) string { return fmt.Sprintf("@p%d", i) }
+	if v.Conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		quote = func(s string) string { return `"` + s + `"` }
+		placeholder = func(i int) string { return fmt.Sprintf("$%d", i) }
+	}
+	spSchema := v.Conv.SpSchema[t.tableId]
+	var cols []string
+	for _, col := range t.cols {
+		cols = append(cols, quote(col.Name))
+	}
+	stmt := sp.Statement{SQL: fmt.Sprintf("SELECT %s FROM %s", strings.Join(cols, ", "), quote(spSchema.Name))}
+	if !t.chunked {
+		return stmt
+	}
+	cond, args := kr.WhereClause(quote(spSchema.ColDefs[kr.ColId].Name), placeholder)
+	if len(args) == 0 {
+		return stmt
+	}
+	stmt.SQL += " WHERE " + cond
+	stmt.Params = make(map[string]interface{})
+	for i, arg := range args {
+		stmt.Params[fmt.Sprintf("p%d", i+1)] = arg
+	}
+	return stmt
+}
+
+// compareRows reads key range kr from the source and from Spanner, and
+// returns up to n rows that differ, ordered by primary key.
+func (v *Validator) compareRows(t *table, kr common.KeyRange, n int) ([]MismatchedRow, error) {
+	srcRows := make(map[string]rowDigest)
+	if _, err := v.readSource(t, kr, srcRows); err != nil {
+		return nil, err
+	}
+	spRows := make(map[string]rowDigest)
+	if _, err := v.readSpanner(t, kr, spRows); err != nil {
+		return nil, err
+	}
+	var mismatches []MismatchedRow
+	for key, r := range srcRows {
+		spR, ok := spRows[key]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, MismatchedRow{Key: key, Reason: MissingInSpanner})
+		case spR != r:
+			mismatches = append(mismatches, MismatchedRow{Key: key, Reason: ValuesDiffer})
+		}
+	}
+	for key := range spRows {
+		if _, ok := srcRows[key]; !ok {
+			mismatches = append(mismatches, MismatchedRow{Key: key, Reason: MissingInSource})
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool { return mismatches[i].Key < mismatches[j].Key })
+	if len(mismatches) > n {
+		mismatches = mismatches[:n]
+	}
+	return mismatches, nil
+}
@@ -0,0 +1,382 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+// fakeSource is a source database holding rows of converted values keyed
+// by table id. The leading column of a row is its key, and rows with an
+// integer key are read in key ranges.
+type fakeSource struct {
+	rows map[string][][]interface{}
+}
+
+func (fs fakeSource) GetToDdl() common.ToDdl { return nil }
+
+func (fs fakeSource) GetTableName(schema string, tableName string) string { return tableName }
+
+func (fs fakeSource) GetTables() ([]common.SchemaAndName, error) { return nil, nil }
+
+func (fs fakeSource) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
+	return nil, nil
+}
+
+func (fs fakeSource) GetRowCount(table common.SchemaAndName) (int64, error) { return 0, nil }
+
+func (fs fakeSource) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, spCols []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	return fs.ProcessDataChunk(conv, tableId, srcSchema, spCols, spSchema, common.KeyRange{}, &sync.Mutex{}, additionalAttributes)
+}
+
+func (fs fakeSource) GetKeyBounds(conv *internal.Conv, tableId string, colId string) (int64, int64, bool, error) {
+	rows := fs.rows[tableId]
+	if len(rows) == 0 {
+		return 0, 0, false, nil
+	}
+	return rows[0][0].(int64), rows[len(rows)-1][0].(int64), true, nil
+}
+
+func (fs fakeSource) ProcessDataChunk(conv *internal.Conv, tableId string, srcSchema schema.Table, spCols []string, spSchema ddl.CreateTable, keyRange common.KeyRange, mutex *sync.Mutex, additionalAttributes internal.AdditionalDataAttributes) error {
+	for _, row := range fs.rows[tableId] {
+		if k, ok := row[0].(int64); ok && !inRange(keyRange.Start, keyRange.End, k) {
+			continue
+		}
+		var cols []string
+		var vals []interface{}
+		for i, colId := range spCols {
+			// Like the source data converters, skip NULL values.
+			if row[i] != nil {
+				cols = append(cols, spSchema.ColDefs[colId].Name)
+				vals = append(vals, row[i])
+			}
+		}
+		conv.WriteRow(srcSchema.Name, spSchema.Name, cols, vals)
+	}
+	return nil
+}
+
+func inRange(start, end *int64, k int64) bool {
+	return (start == nil || k >= *start) && (end == nil || k < *end)
+}
+
+// fakeSpanner returns a Spanner client holding the given rows keyed by table
+// name. Queries are answered by applying the key range in their parameters
+// to the "id" column. The SQL of each query is appended to queries.
+func fakeSpanner(rows map[string][]*sp.Row, queries *[]string) spannerclient.SpannerClient {
+	return spannerclient.SpannerClientMock{
+		SingleMock: func() spannerclient.ReadOnlyTransaction {
+			return spannerclient.ReadOnlyTransactionMock{
+				QueryMock: func(ctx context.Context, stmt sp.Statement) spannerclient.RowIterator {
+					*queries = append(*queries, stmt.SQL)
+					var start, end *int64
+					if strings.Contains(stmt.SQL, ">= @p1") {
+						p := stmt.Params["p1"].(int64)
+						start = &p
+					}
+					for _, name := range []string{"p1", "p2"} {
+						if strings.Contains(stmt.SQL, "< @"+name) {
+							p := stmt.Params[name].(int64)
+							end = &p
+						}
+					}
+					var matching []*sp.Row
+					for table, tableRows := range rows {
+						if !strings.Contains(stmt.SQL, "FROM `"+table+"`") {
+							continue
+						}
+						for _, row := range tableRows {
+							var k sp.NullInt64
+							if err := row.ColumnByName("id", &k); err == nil && !inRange(start, end, k.Int64) {
+								continue
+							}
+							matching = append(matching, row)
+						}
+					}
+					i := 0
+					return spannerclient.RowIteratorMock{
+						NextMock: func() (*sp.Row, error) {
+							if i == len(matching) {
+								return nil, iterator.Done
+							}
+							i++
+							return matching[i-1], nil
+						},
+						StopMock: func() {},
+					}
+				},
+			}
+		},
+	}
+}
+
+func ordersConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name:   "orders",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+				"c2": {Name: "customer", Id: "c2", Type: schema.Type{Name: "varchar"}},
+				"c3": {Name: "amount", Id: "c3", Type: schema.Type{Name: "decimal"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c1"}},
+		},
+		"t2": {
+			Name:   "notes",
+			ColIds: []string{"c4", "c5"},
+			ColDefs: map[string]schema.Column{
+				"c4": {Name: "code", Id: "c4", Type: schema.Type{Name: "varchar"}},
+				"c5": {Name: "body", Id: "c5", Type: schema.Type{Name: "json"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c4"}},
+		},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "customer", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c3": {Name: "amount", Id: "c3", T: ddl.Type{Name: ddl.Numeric}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+		"t2": {
+			Name:   "notes",
+			Id:     "t2",
+			ColIds: []string{"c4", "c5"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c4": {Name: "code", Id: "c4", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c5": {Name: "body", Id: "c5", T: ddl.Type{Name: ddl.JSON}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c4", Order: 1}},
+		},
+	}
+	return conv
+}
+
+func orderRow(t *testing.T, id int64, customer sp.NullString, amount *big.Rat) *sp.Row {
+	row, err := sp.NewRow([]string{"amount", "customer", "id"}, []interface{}{amount, customer, id})
+	assert.Nil(t, err)
+	return row
+}
+
+func TestValidate(t *testing.T) {
+	src := fakeSource{rows: map[string][][]interface{}{
+		"t2": {
+			{"a", `{"x": 1, "y": [true]}`},
+			{"b", nil},
+		},
+	}}
+	var spOrders []*sp.Row
+	for id := int64(1); id <= 10; id++ {
+		customer := fmt.Sprintf("customer%d", id)
+		amount := big.NewRat(id, 4)
+		src.rows["t1"] = append(src.rows["t1"], []interface{}{id, customer, amount})
+		switch id {
+		case 3:
+			// Missing in Spanner.
+		case 5:
+			spOrders = append(spOrders, orderRow(t, id, sp.NullString{StringVal: "someone else", Valid: true}, amount))
+		default:
+			spOrders = append(spOrders, orderRow(t, id, sp.NullString{StringVal: customer, Valid: true}, amount))
+		}
+	}
+	spOrders = append(spOrders, orderRow(t, 42, sp.NullString{}, big.NewRat(1, 1)))
+	noteA, _ := sp.NewRow([]string{"body", "code"}, []interface{}{sp.NullJSON{Value: map[string]interface{}{"y": []bool{true}, "x": 1}, Valid: true}, "a"})
+	noteB, _ := sp.NewRow([]string{"body", "code"}, []interface{}{sp.NullJSON{}, "b"})
+	var queries []string
+	v := Validator{
+		Ctx:        context.Background(),
+		Conv:       ordersConv(),
+		InfoSchema: src,
+		Client:     fakeSpanner(map[string][]*sp.Row{"orders": spOrders, "notes": {noteA, noteB}}, &queries),
+		Ranges:     2,
+		SampleSize: DefaultSampleSize,
+	}
+	results := v.Validate()
+
+	assert.Equal(t, 2, len(results))
+	notes := results[0]
+	assert.Equal(t, "notes", notes.SpTable)
+	assert.True(t, notes.Match())
+	assert.Equal(t, int64(2), notes.SourceRows)
+	assert.Equal(t, int64(2), notes.SpannerRows)
+	assert.Equal(t, 1, notes.Ranges)
+
+	orders := results[1]
+	assert.Equal(t, "orders", orders.SpTable)
+	assert.False(t, orders.Match())
+	assert.Nil(t, orders.Err)
+	assert.Equal(t, int64(10), orders.SourceRows)
+	assert.Equal(t, int64(10), orders.SpannerRows)
+	assert.Equal(t, 2, orders.Ranges)
+	assert.Equal(t, 2, orders.MismatchedRanges)
+	assert.Equal(t, []MismatchedRow{
+		{Key: "id=3", Reason: MissingInSpanner},
+		{Key: "id=5", Reason: ValuesDiffer},
+		{Key: "id=42", Reason: MissingInSource},
+	}, orders.Samples)
+	assert.Contains(t, queries, "SELECT `body`, `code` FROM `notes`")
+	assert.Contains(t, queries, "SELECT `amount`, `customer`, `id` FROM `orders` WHERE `id` < @p1")
+	assert.Contains(t, queries, "SELECT `amount`, `customer`, `id` FROM `orders` WHERE `id` >= @p1")
+
+	var out bytes.Buffer
+	assert.Equal(t, 1, WriteReport(&out, results))
+	assert.Contains(t, out.String(), "MATCH    notes -> notes\n")
+	assert.Contains(t, out.String(), "MISMATCH orders -> orders\n")
+	assert.Contains(t, out.String(), "source rows: 10, Spanner rows: 10, mismatched key ranges: 2 of 2\n")
+	assert.Contains(t, out.String(), "values differ: id=5\n")
+	assert.Contains(t, out.String(), "1 of 2 tables match.\n")
+}
+
+func TestValidate_SampleSize(t *testing.T) {
+	conv := ordersConv()
+	delete(conv.SpSchema, "t2")
+	src := fakeSource{rows: map[string][][]interface{}{}}
+	for id := int64(1); id <= 5; id++ {
+		src.rows["t1"] = append(src.rows["t1"], []interface{}{id, "c", big.NewRat(1, 1)})
+	}
+	var queries []string
+	v := Validator{
+		Ctx:        context.Background(),
+		Conv:       conv,
+		InfoSchema: src,
+		Client:     fakeSpanner(map[string][]*sp.Row{}, &queries),
+		SampleSize: 2,
+	}
+	results := v.Validate()
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, 1, results[0].Ranges)
+	assert.Equal(t, int64(0), results[0].SpannerRows)
+	assert.Equal(t, []MismatchedRow{{Key: "id=1", Reason: MissingInSpanner}, {Key: "id=2", Reason: MissingInSpanner}}, results[0].Samples)
+}
+
+func TestCanonical(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 500, time.FixedZone("x", 3600))
+	tc := []struct {
+		name string
+		t    ddl.Type
+		a, b interface{}
+	}{
+		{"int", ddl.Type{Name: ddl.Int64}, int64(7), sp.NullInt64{Int64: 7, Valid: true}},
+		{"null", ddl.Type{Name: ddl.Int64}, nil, sp.NullInt64{}},
+		{"numeric", ddl.Type{Name: ddl.Numeric}, *big.NewRat(5, 2), big.NewRat(25, 10)},
+		{"pg numeric", ddl.Type{Name: ddl.Numeric}, sp.PGNumeric{Numeric: "2.500", Valid: true}, big.NewRat(5, 2)},
+		{"numeric scale", ddl.Type{Name: ddl.Numeric}, big.NewRat(1, 3), sp.PGNumeric{Numeric: "0.333333333", Valid: true}},
+		{"timestamp", ddl.Type{Name: ddl.Timestamp}, ts, ts.UTC()},
+		{"date", ddl.Type{Name: ddl.Date}, civil.Date{Year: 2024, Month: 3, Day: 1}, sp.NullDate{Date: civil.Date{Year: 2024, Month: 3, Day: 1}, Valid: true}},
+		{"json", ddl.Type{Name: ddl.JSON}, `{"b": 1, "a": [null]}`, `{"a":[null],"b":1}`},
+		{"json value", ddl.Type{Name: ddl.JSON}, `{"a": 1}`, sp.NullJSON{Value: map[string]int{"a": 1}, Valid: true}},
+		{"array", ddl.Type{Name: ddl.String, IsArray: true}, []sp.NullString{{StringVal: "x", Valid: true}, {}}, []interface{}{"x", nil}},
+		{"float32", ddl.Type{Name: ddl.Float32}, float32(0.1), sp.NullFloat32{Float32: 0.1, Valid: true}},
+		{"uuid", ddl.Type{Name: ddl.UUID}, uuid.MustParse("0f8fad5b-d9cb-469f-a165-70867728950e"), "0F8FAD5B-D9CB-469F-A165-70867728950E"},
+		{"null uuid", ddl.Type{Name: ddl.UUID}, sp.NullUUID{UUID: uuid.MustParse("0f8fad5b-d9cb-469f-a165-70867728950e"), Valid: true}, "0f8fad5b-d9cb-469f-a165-70867728950e"},
+	}
+	for _, tt := range tc {
+		assert.Equal(t, canonical(tt.t, tt.a), canonical(tt.t, tt.b), tt.name)
+	}
+	assert.NotEqual(t, canonical(ddl.Type{Name: ddl.String}, "NULL"), canonical(ddl.Type{Name: ddl.String}, nil))
+	assert.NotEqual(t, canonical(ddl.Type{Name: ddl.String}, "1"), canonical(ddl.Type{Name: ddl.Int64}, int64(1)))
+}
+
+func TestDecodeSpannerValue(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 123456000, time.UTC)
+	tc := []struct {
+		t ddl.Type
+		v interface{}
+	}{
+		{ddl.Type{Name: ddl.Int64}, int64(-3)},
+		{ddl.Type{Name: ddl.Float64}, 1.25},
+		{ddl.Type{Name: ddl.Bool}, true},
+		{ddl.Type{Name: ddl.String}, "hello"},
+		{ddl.Type{Name: ddl.Bytes}, []byte{0, 1}},
+		{ddl.Type{Name: ddl.Numeric}, big.NewRat(-7, 8)},
+		{ddl.Type{Name: ddl.Numeric}, sp.PGNumeric{Numeric: "1.5", Valid: true}},
+		{ddl.Type{Name: ddl.Date}, civil.Date{Year: 2020, Month: 2, Day: 29}},
+		{ddl.Type{Name: ddl.Timestamp}, ts},
+		{ddl.Type{Name: ddl.JSON}, sp.NullJSON{Value: map[string]interface{}{"k": "v"}, Valid: true}},
+		{ddl.Type{Name: ddl.Int64, IsArray: true}, []sp.NullInt64{{Int64: 1, Valid: true}, {}}},
+		{ddl.Type{Name: ddl.String}, sp.NullString{}},
+		{ddl.Type{Name: ddl.UUID}, uuid.MustParse("0f8fad5b-d9cb-469f-a165-70867728950e")},
+		{ddl.Type{Name: ddl.UUID, IsArray: true}, []sp.NullUUID{{UUID: uuid.MustParse("0f8fad5b-d9cb-469f-a165-70867728950e"), Valid: true}, {}}},
+	}
+	for _, tt := range tc {
+		row, err := sp.NewRow([]string{"c"}, []interface{}{tt.v})
+		assert.Nil(t, err)
+		var gcv sp.GenericColumnValue
+		assert.Nil(t, row.Column(0, &gcv))
+		got, err := decodeSpannerValue(gcv)
+		assert.Nil(t, err)
+		assert.Equal(t, canonical(tt.t, tt.v), canonical(tt.t, got), fmt.Sprintf("%T", tt.v))
+	}
+}
+
+func TestDecodeSpannerValue_EnumAndProto(t *testing.T) {
+	enumType := &spannerpb.Type{Code: spannerpb.TypeCode_ENUM, ProtoTypeFqn: "db.Status"}
+	got, err := decodeSpannerValue(sp.GenericColumnValue{Type: enumType, Value: structpb.NewStringValue("2")})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), got)
+
+	// Sets are arrays of enums.
+	n, err := ddl.EnumNumber([]string{"a", "b", "c"}, "c")
+	assert.Nil(t, err)
+	list, _ := structpb.NewList([]interface{}{"3", nil})
+	got, err = decodeSpannerValue(sp.GenericColumnValue{
+		Type:  &spannerpb.Type{Code: spannerpb.TypeCode_ARRAY, ArrayElementType: enumType},
+		Value: structpb.NewListValue(list),
+	})
+	assert.Nil(t, err)
+	setType := ddl.Type{Name: ddl.Enum, IsArray: true}
+	assert.Equal(t, canonical(setType, []interface{}{n, nil}), canonical(setType, got))
+
+	_, err = decodeSpannerValue(sp.GenericColumnValue{Type: enumType, Value: structpb.NewStringValue("ACTIVE")})
+	assert.NotNil(t, err)
+
+	protoType := &spannerpb.Type{Code: spannerpb.TypeCode_PROTO, ProtoTypeFqn: "db.Address"}
+	got, err = decodeSpannerValue(sp.GenericColumnValue{Type: protoType, Value: structpb.NewStringValue("CgNmb28=")})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("\n\x03foo"), got)
+}
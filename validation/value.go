@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// canonical returns a string encoding of v, a value of a Spanner column of
+// type t, that doesn't depend on the Go type used to hold the value. Values
+// produced by the source data converters and values read back from Spanner
+// have the same encoding when they are stored as the same Spanner value.
+func canonical(t ddl.Type, v interface{}) string {
+	if v == nil {
+		return "NULL"
+	}
+	if n, ok := v.(sp.NullableValue); ok && n.IsNull() {
+		return "NULL"
+	}
+	switch x := v.(type) {
+	case string:
+		if t.Name == ddl.JSON && !t.IsArray {
+			return canonicalJSON(x)
+		}
+		if t.Name == ddl.UUID {
+			// UUIDs are written from their text, in either case.
+			if u, err := uuid.Parse(x); err == nil {
+				return canonical(t, u)
+			}
+		}
+		return strconv.Quote(x)
+	case uuid.UUID:
+		return strconv.Quote(x.String())
+	case []byte:
+		return base64.StdEncoding.EncodeToString(x)
+	case bool:
+		return strconv.FormatBool(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float32:
+		return strconv.FormatFloat(float64(x), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case big.Rat:
+		return canonicalNumeric(&x)
+	case *big.Rat:
+		return canonicalNumeric(x)
+	case civil.Date:
+		return x.String()
+	case time.Time:
+		return x.UTC().Format(time.RFC3339Nano)
+	case sp.NullString:
+		return canonical(t, x.StringVal)
+	case sp.NullInt64:
+		return canonical(t, x.Int64)
+	case sp.NullFloat32:
+		return canonical(t, x.Float32)
+	case sp.NullFloat64:
+		return canonical(t, x.Float64)
+	case sp.NullBool:
+		return canonical(t, x.Bool)
+	case sp.NullNumeric:
+		return canonical(t, x.Numeric)
+	case sp.NullDate:
+		return canonical(t, x.Date)
+	case sp.NullTime:
+		return canonical(t, x.Time)
+	case sp.NullUUID:
+		return canonical(t, x.UUID)
+	case sp.NullJSON:
+		return canonicalJSONValue(x.Value)
+	case sp.PGNumeric:
+		r, ok := new(big.Rat).SetString(x.Numeric)
+		if !ok {
+			// NaN is the only valid PGNumeric that isn't a rational.
+			return x.Numeric
+		}
+		return canonicalNumeric(r)
+	case sp.PGJsonB:
+		return canonicalJSONValue(x.Value)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		elemType := t
+		elemType.IsArray = false
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			elems[i] = canonical(elemType, rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return strconv.FormatInt(rv.Int(), 10)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// canonicalNumeric encodes r with the scale of the Spanner NUMERIC type, as
+// the Spanner client does when writing r.
+func canonicalNumeric(r *big.Rat) string {
+	s := r.FloatString(sp.NumericScaleDigits)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// canonicalJSON re-encodes the JSON document s so that documents that only
+// differ in whitespace or the order of object keys have the same encoding.
+func canonicalJSON(s string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return strconv.Quote(s)
+	}
+	return canonicalJSONValue(v)
+}
+
+func canonicalJSONValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// decodeSpannerValue converts a column value read from Spanner to the Go
+// value the source data converters produce for that type. NULL values are
+// returned as nil.
+func decodeSpannerValue(v sp.GenericColumnValue) (interface{}, error) {
+	if _, ok := v.Value.GetKind().(*structpb.Value_NullValue); ok {
+		return nil, nil
+	}
+	pg := v.Type.TypeAnnotation == spannerpb.TypeAnnotationCode_PG_NUMERIC || v.Type.TypeAnnotation == spannerpb.TypeAnnotationCode_PG_JSONB
+	switch v.Type.Code {
+	case spannerpb.TypeCode_ARRAY:
+		values := v.Value.GetListValue().GetValues()
+		elems := make([]interface{}, len(values))
+		for i, value := range values {
+			elem, err := decodeSpannerValue(sp.GenericColumnValue{Type: v.Type.ArrayElementType, Value: value})
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return elems, nil
+	case spannerpb.TypeCode_JSON:
+		// JSON is sent as its string encoding, which canonical re-encodes.
+		return v.Value.GetStringValue(), nil
+	case spannerpb.TypeCode_NUMERIC:
+		if pg {
+			var n sp.PGNumeric
+			err := v.Decode(&n)
+			return n, err
+		}
+		var n sp.NullNumeric
+		err := v.Decode(&n)
+		return n.Numeric, err
+	case spannerpb.TypeCode_INT64:
+		var n sp.NullInt64
+		err := v.Decode(&n)
+		return n.Int64, err
+	case spannerpb.TypeCode_FLOAT32:
+		var n sp.NullFloat32
+		err := v.Decode(&n)
+		return n.Float32, err
+	case spannerpb.TypeCode_FLOAT64:
+		var n sp.NullFloat64
+		err := v.Decode(&n)
+		return n.Float64, err
+	case spannerpb.TypeCode_BOOL:
+		var n sp.NullBool
+		err := v.Decode(&n)
+		return n.Bool, err
+	case spannerpb.TypeCode_STRING:
+		var n sp.NullString
+		err := v.Decode(&n)
+		return n.StringVal, err
+	case spannerpb.TypeCode_BYTES:
+		var b []byte
+		err := v.Decode(&b)
+		return b, err
+	case spannerpb.TypeCode_UUID:
+		var n sp.NullUUID
+		err := v.Decode(&n)
+		return n.UUID, err
+	case spannerpb.TypeCode_ENUM:
+		// The source data converters produce the number of the enum value,
+		// which Spanner sends as a string.
+		n, err := strconv.ParseInt(v.Value.GetStringValue(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode enum value %q: %v", v.Value.GetStringValue(), err)
+		}
+		return n, nil
+	case spannerpb.TypeCode_PROTO:
+		// Proto messages are sent as the base64 encoding of their bytes.
+		b, err := base64.StdEncoding.DecodeString(v.Value.GetStringValue())
+		if err != nil {
+			return nil, fmt.Errorf("can't decode proto value: %v", err)
+		}
+		return b, nil
+	case spannerpb.TypeCode_DATE:
+		var n sp.NullDate
+		err := v.Decode(&n)
+		return n.Date, err
+	case spannerpb.TypeCode_TIMESTAMP:
+		var n sp.NullTime
+		err := v.Decode(&n)
+		return n.Time, err
+	}
+	return nil, fmt.Errorf("unsupported Spanner type %s", v.Type.Code)
+}
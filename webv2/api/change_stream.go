@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/utilities"
+)
+
+var (
+	changeStreamValueCaptureTypes = []string{"OLD_AND_NEW_VALUES", "NEW_ROW", "NEW_VALUES", "NEW_ROW_AND_OLD_VALUES"}
+	changeStreamRetentionPeriod   = regexp.MustCompile(`^[0-9]+[dhms]$`)
+)
+
+// AddChangeStream adds a change stream to the Spanner schema.
+func AddChangeStream(w http.ResponseWriter, r *http.Request) {
+	logger.Log.Info(fmt.Sprint("request started", "method", r.Method, "path", r.URL.Path))
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("request's body Read Error"))
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	cs := ddl.ChangeStream{}
+	err = json.Unmarshal(reqBody, &cs)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("request's Body parse error"))
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	if ok, _ := utilities.CheckSpannerNamesValidity([]string{cs.Name}); !ok {
+		http.Error(w, fmt.Sprintf("Change stream Name is not valid: %v", cs.Name), http.StatusBadRequest)
+		return
+	}
+	// Check that the new name is not already used by existing tables, secondary indexes, sequences or foreign key constraints.
+	if ok, err := utilities.CanRename([]string{cs.Name}, ""); !ok {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateChangeStream(sessionState.Conv, cs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if sessionState.Conv.SpChangeStreams == nil {
+		sessionState.Conv.SpChangeStreams = make(map[string]ddl.ChangeStream)
+	}
+	cs.Id = internal.GenerateChangeStreamId()
+	sessionState.Conv.UsedNames[strings.ToLower(cs.Name)] = true
+	sessionState.Conv.SpChangeStreams[cs.Id] = cs
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// UpdateChangeStream replaces a change stream of the Spanner schema.
+func UpdateChangeStream(w http.ResponseWriter, r *http.Request) {
+	logger.Log.Info(fmt.Sprint("request started", "method", r.Method, "path", r.URL.Path))
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("request's body Read Error"))
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	newCs := ddl.ChangeStream{}
+	err = json.Unmarshal(reqBody, &newCs)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("request's Body parse error"))
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	cs, ok := sessionState.Conv.SpChangeStreams[newCs.Id]
+	if !ok {
+		http.Error(w, "Change stream doesn't exist", http.StatusBadRequest)
+		return
+	}
+	if err := validateChangeStream(sessionState.Conv, newCs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !strings.EqualFold(newCs.Name, cs.Name) {
+		if ok, _ := utilities.CheckSpannerNamesValidity([]string{newCs.Name}); !ok {
+			http.Error(w, fmt.Sprintf("Change stream Name is not valid: %v", newCs.Name), http.StatusBadRequest)
+			return
+		}
+		if ok, err := utilities.CanRename([]string{newCs.Name}, ""); !ok {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		delete(sessionState.Conv.UsedNames, strings.ToLower(cs.Name))
+		sessionState.Conv.UsedNames[strings.ToLower(newCs.Name)] = true
+	}
+	sessionState.Conv.SpChangeStreams[newCs.Id] = newCs
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// DropChangeStream removes a change stream from the Spanner schema.
+func DropChangeStream(w http.ResponseWriter, r *http.Request) {
+	changeStreamId := r.FormValue("changeStream")
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, "Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner.", http.StatusNotFound)
+		return
+	}
+	cs, ok := sessionState.Conv.SpChangeStreams[changeStreamId]
+	if !ok {
+		http.Error(w, "Change stream doesn't exist", http.StatusBadRequest)
+		return
+	}
+	delete(sessionState.Conv.UsedNames, strings.ToLower(cs.Name))
+	delete(sessionState.Conv.SpChangeStreams, changeStreamId)
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// GetChangeStreamDDL returns the CREATE CHANGE STREAM statement of each
+// change stream.
+func GetChangeStreamDDL(w http.ResponseWriter, r *http.Request) {
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.RLock()
+	defer sessionState.Conv.ConvLock.RUnlock()
+	conv := sessionState.Conv
+
+	changeStreamDDL := make(map[string]string)
+	for id, cs := range conv.SpChangeStreams {
+		changeStreamDDL[id] = cs.PrintChangeStream(conv.SpSchema, ddl.Config{ProtectIds: false, SpDialect: conv.SpDialect})
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(changeStreamDDL)
+}
+
+// validateChangeStream checks the options of the change stream, and that it
+// only watches non-key columns of tables of the Spanner schema.
+func validateChangeStream(conv *internal.Conv, cs ddl.ChangeStream) error {
+	if cs.ValueCaptureType != "" && !internal.Contains(changeStreamValueCaptureTypes, cs.ValueCaptureType) {
+		return fmt.Errorf("value capture type is not valid: %v", cs.ValueCaptureType)
+	}
+	if cs.RetentionPeriod != "" && !changeStreamRetentionPeriod.MatchString(cs.RetentionPeriod) {
+		return fmt.Errorf("retention period is not valid: %v", cs.RetentionPeriod)
+	}
+	if cs.WatchAll {
+		return nil
+	}
+	for _, t := range cs.Tables {
+		table, ok := conv.SpSchema[t.TableId]
+		if !ok {
+			return fmt.Errorf("table %v doesn't exist", t.TableId)
+		}
+		for _, colId := range t.ColumnIds {
+			if _, ok := table.ColDefs[colId]; !ok {
+				return fmt.Errorf("column %v doesn't exist in table %v", colId, table.Name)
+			}
+			for _, pk := range table.PrimaryKeys {
+				if pk.ColId == colId {
+					return fmt.Errorf("key column %v of table %v is always watched", table.ColDefs[colId].Name, table.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
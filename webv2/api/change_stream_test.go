@@ -0,0 +1,148 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func newChangeStreamTestConv() *internal.Conv {
+	return &internal.Conv{
+		UsedNames: map[string]bool{"orders": true, "order_changes": true},
+		SpSchema: ddl.Schema{
+			"t1": {
+				Name:        "orders",
+				Id:          "t1",
+				ColIds:      []string{"c1", "c2"},
+				ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "id", Id: "c1"}, "c2": {Name: "status", Id: "c2"}},
+				PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			},
+		},
+		SpChangeStreams: map[string]ddl.ChangeStream{
+			"cs1": {Name: "order_changes", Id: "cs1", Tables: []ddl.ChangeStreamTable{{TableId: "t1"}}},
+		},
+	}
+}
+
+func TestAddChangeStream(t *testing.T) {
+	tc := []struct {
+		name       string
+		input      ddl.ChangeStream
+		statusCode int64
+	}{
+		{
+			name:       "Watch columns",
+			input:      ddl.ChangeStream{Name: "status_changes", Tables: []ddl.ChangeStreamTable{{TableId: "t1", ColumnIds: []string{"c2"}}}, ValueCaptureType: "NEW_ROW", RetentionPeriod: "7d"},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Name already used",
+			input:      ddl.ChangeStream{Name: "orders", WatchAll: true},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Key column",
+			input:      ddl.ChangeStream{Name: "key_changes", Tables: []ddl.ChangeStreamTable{{TableId: "t1", ColumnIds: []string{"c1"}}}},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid value capture type",
+			input:      ddl.ChangeStream{Name: "all_changes", WatchAll: true, ValueCaptureType: "OLD_ROW"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid retention period",
+			input:      ddl.ChangeStream{Name: "all_changes", WatchAll: true, RetentionPeriod: "a week"},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		sessionState.Conv = newChangeStreamTestConv()
+
+		inputBytes, err := json.Marshal(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := http.NewRequest("POST", "/AddChangeStream", bytes.NewBuffer(inputBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.AddChangeStream)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.statusCode, int64(rr.Code), tt.name)
+		if rr.Code == http.StatusOK {
+			var res *internal.Conv
+			json.Unmarshal(rr.Body.Bytes(), &res)
+			assert.Equal(t, 2, len(res.SpChangeStreams), tt.name)
+			for id, cs := range res.SpChangeStreams {
+				if id != "cs1" {
+					tt.input.Id = id
+					assert.Equal(t, tt.input, cs, tt.name)
+				}
+			}
+			assert.True(t, sessionState.Conv.UsedNames[tt.input.Name], tt.name)
+		}
+	}
+}
+
+func TestUpdateChangeStream(t *testing.T) {
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = newChangeStreamTestConv()
+
+	input := ddl.ChangeStream{Name: "changes", Id: "cs1", WatchAll: true, ExcludeTtlDeletes: true}
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest("POST", "/UpdateChangeStream", bytes.NewBuffer(inputBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(api.UpdateChangeStream)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res *internal.Conv
+	json.Unmarshal(rr.Body.Bytes(), &res)
+	assert.Equal(t, map[string]ddl.ChangeStream{"cs1": input}, res.SpChangeStreams)
+	assert.Equal(t, map[string]bool{"orders": true, "changes": true}, sessionState.Conv.UsedNames)
+}
+
+func TestDropChangeStream(t *testing.T) {
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = newChangeStreamTestConv()
+
+	req, err := http.NewRequest("POST", "drop/changeStream?changeStream=cs1", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(api.DropChangeStream)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	res := &internal.Conv{}
+	json.Unmarshal(rr.Body.Bytes(), &res)
+	assert.Empty(t, res.SpChangeStreams)
+	assert.Equal(t, map[string]bool{"orders": true}, sessionState.Conv.UsedNames)
+}
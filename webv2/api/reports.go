@@ -86,6 +86,8 @@ func GetDSpannerDDL(w http.ResponseWriter, r *http.Request) {
 	conv := sessionState.Conv
 	now := time.Now()
 	spDDL := ddl.GetDDL(ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: sessionState.Driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	spDDL = append(spDDL, ddl.GetViewsDDL(ddl.Config{Comments: true, ProtectIds: false, SpDialect: conv.SpDialect}, conv.SpViews)...)
+	spDDL = append(spDDL, ddl.GetChangeStreamsDDL(ddl.Config{Comments: true, ProtectIds: false, SpDialect: conv.SpDialect}, conv.SpSchema, conv.SpChangeStreams)...)
 	if len(spDDL) == 0 {
 		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
 	}
@@ -106,6 +108,8 @@ func GetSpannerDDLWoComments(w http.ResponseWriter, r *http.Request) {
 	conv := sessionState.Conv
 	now := time.Now()
 	spDDL := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: sessionState.Driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	spDDL = append(spDDL, ddl.GetViewsDDL(ddl.Config{Comments: false, ProtectIds: true, SpDialect: conv.SpDialect}, conv.SpViews)...)
+	spDDL = append(spDDL, ddl.GetChangeStreamsDDL(ddl.Config{Comments: false, ProtectIds: true, SpDialect: conv.SpDialect}, conv.SpSchema, conv.SpChangeStreams)...)
 	if len(spDDL) == 0 {
 		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
 	}
@@ -273,6 +273,10 @@ func addIndex(newIndex ddl.CreateIndex) (ddl.CreateIndex, error) {
 	sessionState := session.GetSessionState()
 	sp := sessionState.Conv.SpSchema[newIndex.TableId]
 
+	if newIndex.InterleaveIn != "" && !sessionState.Conv.SpSchema.IsAncestor(newIndex.TableId, newIndex.InterleaveIn) {
+		return ddl.CreateIndex{}, fmt.Errorf("index %s can only be interleaved in an ancestor of its table", newIndex.Name)
+	}
+
 	newIndexes := []ddl.CreateIndex{newIndex}
 	index.CheckIndexSuggestion(newIndexes, sp)
 	for i := 0; i < len(newIndexes); i++ {
@@ -253,7 +253,7 @@ func GetDDL(w http.ResponseWriter, r *http.Request) {
 			tableDdl = tableDdl + "\n"
 		}
 		for _, index := range table.Indexes {
-			tableDdl = tableDdl + "\n" + index.PrintCreateIndex(table, c) + ";"
+			tableDdl = tableDdl + "\n" + index.PrintCreateIndex(sessionState.Conv.SpSchema, table, c) + ";"
 		}
 		if len(table.ForeignKeys) > 0 {
 			tableDdl = tableDdl + "\n"
@@ -630,6 +630,107 @@ func UpdateCheckConstraint(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(convm)
 }
 
+// UpdateRowDeletionPolicy sets the row deletion policy of a table, or removes
+// it when the policy has no column.
+func UpdateRowDeletionPolicy(w http.ResponseWriter, r *http.Request) {
+	tableId := r.FormValue("table")
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	sessionState := session.GetSessionState()
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, fmt.Sprintf("Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner."), http.StatusNotFound)
+		return
+	}
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	policy := ddl.RowDeletionPolicy{}
+	if err = json.Unmarshal(reqBody, &policy); err != nil {
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+
+	sp, found := sessionState.Conv.SpSchema[tableId]
+	if !found {
+		http.Error(w, fmt.Sprintf("Table %s not found", tableId), http.StatusBadRequest)
+		return
+	}
+	if policy.ColId != "" {
+		if err := sp.ValidateRowDeletionPolicy(policy); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid row deletion policy : %v", err), http.StatusBadRequest)
+			return
+		}
+	} else {
+		policy = ddl.RowDeletionPolicy{}
+	}
+	sp.RowDeletionPolicy = policy
+	sessionState.Conv.SpSchema[tableId] = sp
+	session.UpdateSessionFile()
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// UpdateTimezonePolicy sets the timezone policy of a column, or the default
+// policy of the table when the policy has no column. A policy without a mode
+// removes it. The STRING and DATE modes retype the columns they apply to.
+func UpdateTimezonePolicy(w http.ResponseWriter, r *http.Request) {
+	tableId := r.FormValue("table")
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	sessionState := session.GetSessionState()
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, fmt.Sprintf("Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner."), http.StatusNotFound)
+		return
+	}
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	policy := types.TimezonePolicy{}
+	if err = json.Unmarshal(reqBody, &policy); err != nil {
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var toddl common.ToDdl
+	switch sessionState.Driver {
+	case constants.MYSQL, constants.MYSQLDUMP:
+		toddl = mysql.InfoSchemaImpl{}.GetToDdl()
+	case constants.POSTGRES, constants.PGDUMP:
+		toddl = postgres.InfoSchemaImpl{}.GetToDdl()
+	case constants.SQLSERVER:
+		toddl = sqlserver.InfoSchemaImpl{}.GetToDdl()
+	case constants.ORACLE:
+		toddl = oracle.InfoSchemaImpl{}.GetToDdl()
+	default:
+		http.Error(w, fmt.Sprintf("Driver : '%s' is not supported", sessionState.Driver), http.StatusBadRequest)
+		return
+	}
+	p := internal.TimezonePolicy{Mode: policy.Mode, Location: policy.Location}
+	if err := common.SetTimezonePolicy(sessionState.Conv, toddl, tableId, policy.ColId, p); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid timezone policy : %v", err), http.StatusBadRequest)
+		return
+	}
+	session.UpdateSessionFile()
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
 // checkAndAddParentheses this method will check parentheses  if found it will return same string
 // or add the parentheses then return the string
 func checkAndAddParentheses(checkClause string) string {
@@ -1026,6 +1127,11 @@ func UpdateIndexes(w http.ResponseWriter, r *http.Request) {
 	defer sessionState.Conv.ConvLock.Unlock()
 	sp := sessionState.Conv.SpSchema[table]
 
+	if len(newIndexes) > 0 && newIndexes[0].InterleaveIn != "" && !sessionState.Conv.SpSchema.IsAncestor(table, newIndexes[0].InterleaveIn) {
+		http.Error(w, "Index can only be interleaved in an ancestor of its table", http.StatusBadRequest)
+		return
+	}
+
 	st := sessionState.Conv.SrcSchema[table]
 
 	for i, ind := range sp.Indexes {
@@ -1038,6 +1144,8 @@ func UpdateIndexes(w http.ResponseWriter, r *http.Request) {
 			sp.Indexes[i].TableId = newIndexes[0].TableId
 			sp.Indexes[i].Unique = newIndexes[0].Unique
 			sp.Indexes[i].Id = newIndexes[0].Id
+			sp.Indexes[i].NullFiltered = newIndexes[0].NullFiltered
+			sp.Indexes[i].InterleaveIn = newIndexes[0].InterleaveIn
 
 			break
 		}
@@ -1217,17 +1325,9 @@ func parentTableHelper(tableId string, parentTableId string, interleaveType stri
 	}
 
 	if !parentEmptyInRequest {
-		pk_condition := checkInterleavePrimaryKeyPrefixCondition(tableId, parentTableId)
-		if pk_condition != "" {
+		if err := sessionState.Conv.CheckInterleaving(tableId, parentTableId); err != nil {
 			tableInterleaveStatus.Possible = false
-			tableInterleaveStatus.Comment = pk_condition
-			return tableInterleaveStatus
-		}
-
-		cycle_condition := checkInterleaveCycleCondition(tableId, parentTableId)
-		if cycle_condition != "" {
-			tableInterleaveStatus.Possible = false
-			tableInterleaveStatus.Comment = cycle_condition
+			tableInterleaveStatus.Comment = err.Error()
 			return tableInterleaveStatus
 		}
 	}
@@ -1248,77 +1348,6 @@ func parentTableHelper(tableId string, parentTableId string, interleaveType stri
 	return tableInterleaveStatus
 }
 
-func hasCycleCheckDfs(tableId string, parentTableId string, undirectedGraph map[string][]string, visited map[string]bool) bool {
-	if visited[tableId] {
-		return true
-	}
-	visited[tableId] = true
-	for _, neighbor := range undirectedGraph[tableId] {
-		if neighbor != parentTableId {
-			if hasCycleCheckDfs(neighbor, tableId, undirectedGraph, visited) {
-				return true
-			}
-		}
-	}
-	return false
-}
-
-func checkInterleaveCycleCondition(tableId string, parentTableId string) string {
-	sessionState := session.GetSessionState()
-	undirectedGraph := map[string][]string{}
-	for _, spTable := range sessionState.Conv.SpSchema {
-		if spTable.ParentTable.Id != "" && spTable.ParentTable.Id != parentTableId && spTable.Id != tableId {
-			undirectedGraph[spTable.Id] = append(undirectedGraph[spTable.Id], spTable.ParentTable.Id)
-			undirectedGraph[spTable.ParentTable.Id] = append(undirectedGraph[spTable.ParentTable.Id], spTable.Id)
-		}
-	}
-	undirectedGraph[tableId] = append(undirectedGraph[tableId], parentTableId)
-	undirectedGraph[parentTableId] = append(undirectedGraph[parentTableId], tableId)
-	visited := map[string]bool{}
-	if hasCycleCheckDfs(tableId, "", undirectedGraph, visited) {
-		message := fmt.Sprintf("Interleaving table '%s' in parent table '%s' will create a cycle.", sessionState.Conv.SpSchema[tableId].Name, sessionState.Conv.SpSchema[parentTableId].Name)
-		return message
-	}
-	return ""
-}
-
-func checkInterleavePrimaryKeyPrefixCondition(tableId string, refTableId string) string {
-	// Check if all parent primary keys are present in child primary keys with same order.
-	// If yes, then returns empty string else returns the comment why prefix condition is not met.
-	sessionState := session.GetSessionState()
-	childPks := sessionState.Conv.SpSchema[tableId].PrimaryKeys
-	parentPks := sessionState.Conv.SpSchema[refTableId].PrimaryKeys
-	parentTable := sessionState.Conv.SpSchema[refTableId]
-	childTable := sessionState.Conv.SpSchema[tableId]
-	parent_table_name := sessionState.Conv.SpSchema[refTableId].Name
-	child_table_name := sessionState.Conv.SpSchema[tableId].Name
-	if len(parentPks) == 0 || len(childPks) == 0 {
-		message := fmt.Sprintf("Both parent table '%s' and child table '%s' must have primary keys.", parent_table_name, child_table_name)
-		return message
-	}
-	if len(childPks) < len(parentPks) {
-		message := fmt.Sprintf("The child table '%s' has '%d' primary keys, which is less than the parent table '%s' primary keys count of '%d'.", child_table_name, len(childPks), parent_table_name, len(parentPks))
-		return message
-	}
-	for i := 0; i < len(parentPks); i++ {
-		j := 0
-		for ; j < len(childPks); j++ {
-			if parentTable.ColDefs[parentPks[i].ColId].Name == childTable.ColDefs[childPks[j].ColId].Name && parentTable.ColDefs[parentPks[i].ColId].T.Name == childTable.ColDefs[childPks[j].ColId].T.Name && parentTable.ColDefs[parentPks[i].ColId].T.Len == childTable.ColDefs[childPks[j].ColId].T.Len && parentTable.ColDefs[parentPks[i].ColId].NotNull == childTable.ColDefs[childPks[j].ColId].NotNull {
-				break
-			}
-		}
-		if j == len(childPks) {
-			message := fmt.Sprintf("The child table '%s' does not have primary key '%s' of parent table '%s'.", child_table_name, parentTable.ColDefs[parentPks[i].ColId].Name, parent_table_name)
-			return message
-		}
-		if parentPks[i].Order != childPks[j].Order {
-			message := fmt.Sprintf("The primary key '%s' of parent table '%s' is at order '%d', but in child table '%s' it is at order '%d'.", parentTable.ColDefs[parentPks[i].ColId].Name, parent_table_name, parentPks[i].Order, child_table_name, childPks[j].Order)
-			return message
-		}
-	}
-	return ""
-}
-
 func hasShardIdPrimaryKeyRule() (bool, bool) {
 	sessionState := session.GetSessionState()
 	for _, rule := range sessionState.Conv.Rules {
@@ -1341,71 +1370,7 @@ func dropTableHelper(w http.ResponseWriter, tableId string) session.ConvWithMeta
 	}
 	sessionState.Conv.ConvLock.Lock()
 	defer sessionState.Conv.ConvLock.Unlock()
-	spSchema := sessionState.Conv.SpSchema
-	issues := sessionState.Conv.SchemaIssues
-	syntheticPkey := sessionState.Conv.SyntheticPKeys
-
-	// remove deleted name from usedName
-	usedNames := sessionState.Conv.UsedNames
-	delete(usedNames, strings.ToLower(sessionState.Conv.SpSchema[tableId].Name))
-	for _, index := range sessionState.Conv.SpSchema[tableId].Indexes {
-		delete(usedNames, index.Name)
-	}
-	for _, fk := range sessionState.Conv.SpSchema[tableId].ForeignKeys {
-		delete(usedNames, fk.Name)
-	}
-
-	delete(spSchema, tableId)
-	issues[tableId] = internal.TableIssues{
-		TableLevelIssues:  []internal.SchemaIssue{},
-		ColumnLevelIssues: map[string][]internal.SchemaIssue{},
-	}
-	delete(syntheticPkey, tableId)
-
-	// drop reference foreign key
-	for tableName, spTable := range spSchema {
-		fks := []ddl.Foreignkey{}
-		for _, fk := range spTable.ForeignKeys {
-			if fk.ReferTableId != tableId {
-				fks = append(fks, fk)
-			} else {
-				delete(usedNames, fk.Name)
-			}
-		}
-		spTable.ForeignKeys = fks
-		spSchema[tableName] = spTable
-	}
-
-	// remove interleave that are interleaved on the drop table as parent
-	for id, spTable := range spSchema {
-		if spTable.ParentTable.Id == tableId {
-			spTable.ParentTable.Id = ""
-			spTable.ParentTable.OnDelete = ""
-			spTable.ParentTable.InterleaveType = ""
-			spSchema[id] = spTable
-		}
-	}
-
-	// remove interleavable suggestion on droping the parent table
-	for tableName, tableIssues := range issues {
-		for colName, colIssues := range tableIssues.ColumnLevelIssues {
-			updatedColIssues := []internal.SchemaIssue{}
-			for _, val := range colIssues {
-				if val != internal.InterleavedOrder {
-					updatedColIssues = append(updatedColIssues, val)
-				}
-			}
-			if len(updatedColIssues) == 0 {
-				delete(issues[tableName].ColumnLevelIssues, colName)
-			} else {
-				issues[tableName].ColumnLevelIssues[colName] = updatedColIssues
-			}
-		}
-	}
-
-	sessionState.Conv.SpSchema = spSchema
-	sessionState.Conv.SchemaIssues = issues
-	sessionState.Conv.UsedNames = usedNames
+	sessionState.Conv.DropSpannerTable(tableId)
 
 	convm := session.ConvWithMetadata{
 		SessionMetadata: sessionState.SessionMetadata,
@@ -1442,24 +1407,34 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
 		if srcTypeName == "tinyint" {
 			l = append(l, types.TypeIssue{T: ddl.Bool, Brief: "Only tinyint(1) can be converted to BOOL, for any other mods it will be converted to INT64"})
 		}
+		if srcTypeName == "char" {
+			l = append(l, types.TypeIssue{T: ddl.UUID, Brief: "Only char(36) can be converted to UUID, for any other mods it will be converted to STRING"})
+		}
+		if srcTypeName == "binary" {
+			l = append(l, types.TypeIssue{T: ddl.UUID, Brief: "Only binary(16) can be converted to UUID, for any other mods it will be converted to BYTES"})
+		}
+		if srcTypeName == "set" || srcTypeName == "enum" {
+			// The values of the proto enum come from the labels of each column.
+			l = append(l, types.TypeIssue{T: ddl.Enum})
+		}
 		ty, _ := toddl.ToSpannerType(sessionState.Conv, "", srcType, false)
 		mysqlDefaultTypeMap[srcTypeName] = ty
 		mysqlTypeMap[srcTypeName] = l
 	}
 	// Initialize postgresTypeMap.
 	toddl = postgres.InfoSchemaImpl{}.GetToDdl()
-	for _, srcTypeName := range []string{"bool", "boolean", "bigserial", "bpchar", "character", "bytea", "date", "float8", "double precision", "float4", "real", "int8", "bigint", "int4", "integer", "int2", "smallint", "numeric", "serial", "smallserial", "text", "timestamptz", "timestamp with time zone", "timestamp", "timestamp without time zone", "varchar", "character varying", "path"} {
+	for _, srcTypeName := range []string{"bool", "boolean", "bigserial", "bpchar", "character", "bytea", "date", "float8", "double precision", "float4", "real", "int8", "bigint", "int4", "integer", "int2", "smallint", "numeric", "serial", "smallserial", "text", "timestamptz", "timestamp with time zone", "timestamp", "timestamp without time zone", "uuid", "varchar", "character varying", "path"} {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -1474,7 +1449,7 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -1504,7 +1479,7 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -1519,7 +1494,7 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = listType
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList("ARRAY<"+ty.Name+">", "ARRAY<"+spType+">", issues, l)
 		}
@@ -1624,7 +1599,7 @@ func initializeAutoGenMap(supportsUuidGeneration bool) {
 }
 
 func makePostgresDialectAutoGenMap(sequences map[string]ddl.Sequence, supportsUuidGeneration bool) {
-	for _, srcTypeName := range []string{ddl.Bool, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.PGBytea, ddl.PGFloat4, ddl.PGFloat8, ddl.PGInt8, ddl.PGJSONB, ddl.PGTimestamptz, ddl.PGVarchar, ddl.Numeric} {
+	for _, srcTypeName := range []string{ddl.Bool, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.PGBytea, ddl.PGFloat4, ddl.PGFloat8, ddl.PGInt8, ddl.PGJSONB, ddl.PGTimestamptz, ddl.PGVarchar, ddl.Numeric, ddl.UUID} {
 		autoGenMap[srcTypeName] = []types.AutoGen{
 			{
 				Name:           "",
@@ -1633,11 +1608,13 @@ func makePostgresDialectAutoGenMap(sequences map[string]ddl.Sequence, supportsUu
 		}
 	}
 	if supportsUuidGeneration {
-		autoGenMap[ddl.PGVarchar] = append(autoGenMap[ddl.PGVarchar],
-			types.AutoGen{
-				Name:           "UUID",
-				GenerationType: "Pre-defined",
-			})
+		for _, spType := range []string{ddl.PGVarchar, ddl.UUID} {
+			autoGenMap[spType] = append(autoGenMap[spType],
+				types.AutoGen{
+					Name:           "UUID",
+					GenerationType: "Pre-defined",
+				})
+		}
 	}
 
 	typesSupportingSequences := []string{ddl.Float64, ddl.Int64, ddl.PGFloat8, ddl.PGInt8}
@@ -1659,7 +1636,7 @@ func makePostgresDialectAutoGenMap(sequences map[string]ddl.Sequence, supportsUu
 }
 
 func makeGoogleSqlDialectAutoGenMap(sequences map[string]ddl.Sequence, supportsUuidGeneration bool) {
-	for _, srcTypeName := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+	for _, srcTypeName := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID} {
 		autoGenMap[srcTypeName] = []types.AutoGen{
 			{
 				Name:           "",
@@ -1668,11 +1645,13 @@ func makeGoogleSqlDialectAutoGenMap(sequences map[string]ddl.Sequence, supportsU
 		}
 	}
 	if supportsUuidGeneration {
-		autoGenMap[ddl.String] = append(autoGenMap[ddl.String],
-			types.AutoGen{
-				Name:           "UUID",
-				GenerationType: "Pre-defined",
-			})
+		for _, spType := range []string{ddl.String, ddl.UUID} {
+			autoGenMap[spType] = append(autoGenMap[spType],
+				types.AutoGen{
+					Name:           "UUID",
+					GenerationType: "Pre-defined",
+				})
+		}
 	}
 
 	typesSupportingSequences := []string{ddl.Float64, ddl.Int64}
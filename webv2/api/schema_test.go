@@ -184,14 +184,16 @@ func TestGetTypeMapMySQL(t *testing.T) {
 			{T: ddl.Bytes, DisplayT: ddl.Bytes},
 			{T: ddl.String, DisplayT: ddl.String}},
 		"enum": {
-			{T: ddl.String, DisplayT: ddl.String}},
+			{T: ddl.String, DisplayT: ddl.String},
+			{T: ddl.Enum, DisplayT: ddl.Enum}},
 		"json": {
 			{T: ddl.Bytes, DisplayT: ddl.Bytes},
 			{T: ddl.String, DisplayT: ddl.String},
 			{T: ddl.JSON, DisplayT: ddl.JSON}},
 		"binary": {
 			{T: ddl.Bytes, DisplayT: ddl.Bytes},
-			{T: ddl.String, DisplayT: ddl.String}},
+			{T: ddl.String, DisplayT: ddl.String},
+			{T: ddl.UUID, Brief: "Only binary(16) can be converted to UUID, for any other mods it will be converted to BYTES", DisplayT: ddl.UUID}},
 		"blob": {
 			{T: ddl.Bytes, DisplayT: ddl.Bytes},
 			{T: ddl.String, DisplayT: ddl.String}},
@@ -1899,6 +1901,51 @@ func TestSetParentTable(t *testing.T) {
 			expectedResponse: &types.TableInterleaveStatus{Possible: false, Comment: "Interleaving table 't2' in parent table 't1' will create a cycle."},
 			update:           true,
 		},
+		{
+			name: "interleave causes cycle through a grandchild",
+			ct: &internal.Conv{
+				SpSchema: map[string]ddl.CreateTable{
+					"t1": {
+						Name:   "t1",
+						Id:     "t1",
+						ColIds: []string{"c1"},
+						ColDefs: map[string]ddl.ColumnDef{
+							"c1": {Name: "col1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+						},
+						PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+					},
+					"t2": {
+						Name:   "t2",
+						Id:     "t2",
+						ColIds: []string{"c2"},
+						ColDefs: map[string]ddl.ColumnDef{
+							"c2": {Name: "col1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+						},
+						PrimaryKeys: []ddl.IndexKey{{ColId: "c2"}},
+						ParentTable: ddl.InterleavedParent{Id: "t1"},
+					},
+					"t3": {
+						Name:   "t3",
+						Id:     "t3",
+						ColIds: []string{"c3"},
+						ColDefs: map[string]ddl.ColumnDef{
+							"c3": {Name: "col1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+						},
+						PrimaryKeys: []ddl.IndexKey{{ColId: "c3"}},
+						ParentTable: ddl.InterleavedParent{Id: "t2"},
+					},
+				},
+				Audit: internal.Audit{
+					MigrationType: migration.MigrationData_SCHEMA_ONLY.Enum(),
+				},
+			},
+			table:            "t1",
+			parent:           "t3",
+			interleaveType:   "IN",
+			statusCode:       http.StatusOK,
+			expectedResponse: &types.TableInterleaveStatus{Possible: false, Comment: "Interleaving table 't1' in parent table 't3' will create a cycle."},
+			update:           true,
+		},
 		{
 			name: "successful interleave IN",
 			ct: &internal.Conv{
@@ -2583,6 +2630,7 @@ func TestGetAutoGenMapMySQL(t *testing.T) {
 		"JSONB":       {types.AutoGen{Name: "", GenerationType: ""}},
 		"NUMERIC":     {types.AutoGen{Name: "", GenerationType: ""}},
 		"TIMESTAMPTZ": {types.AutoGen{Name: "", GenerationType: ""}},
+		"UUID":        {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "UUID", GenerationType: "Pre-defined"}},
 		"VARCHAR":     {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "UUID", GenerationType: "Pre-defined"}}}
 
 	expectedAutoGenMapMySql := map[string][]types.AutoGen{
@@ -2595,7 +2643,8 @@ func TestGetAutoGenMapMySQL(t *testing.T) {
 		"JSON":      {types.AutoGen{Name: "", GenerationType: ""}},
 		"NUMERIC":   {types.AutoGen{Name: "", GenerationType: ""}},
 		"STRING":    {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "UUID", GenerationType: "Pre-defined"}},
-		"TIMESTAMP": {types.AutoGen{Name: "", GenerationType: ""}}}
+		"TIMESTAMP": {types.AutoGen{Name: "", GenerationType: ""}},
+		"UUID":      {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "UUID", GenerationType: "Pre-defined"}}}
 	tests := []struct {
 		dialect            string
 		driver             string
@@ -2664,6 +2713,7 @@ func TestGetAutoGenMapPostgres(t *testing.T) {
 		"JSONB":       {types.AutoGen{Name: "", GenerationType: ""}},
 		"NUMERIC":     {types.AutoGen{Name: "", GenerationType: ""}},
 		"TIMESTAMPTZ": {types.AutoGen{Name: "", GenerationType: ""}},
+		"UUID":        {types.AutoGen{Name: "", GenerationType: ""}},
 		"VARCHAR":     {types.AutoGen{Name: "", GenerationType: ""}}}
 
 	expectedAutoGenMapMySql := map[string][]types.AutoGen{
@@ -2676,7 +2726,8 @@ func TestGetAutoGenMapPostgres(t *testing.T) {
 		"JSON":      {types.AutoGen{Name: "", GenerationType: ""}},
 		"NUMERIC":   {types.AutoGen{Name: "", GenerationType: ""}},
 		"STRING":    {types.AutoGen{Name: "", GenerationType: ""}},
-		"TIMESTAMP": {types.AutoGen{Name: "", GenerationType: ""}}}
+		"TIMESTAMP": {types.AutoGen{Name: "", GenerationType: ""}},
+		"UUID":      {types.AutoGen{Name: "", GenerationType: ""}}}
 	tests := []struct {
 		dialect            string
 		driver             string
@@ -2849,6 +2900,152 @@ func TestUpdateCheckConstraint(t *testing.T) {
 	})
 }
 
+func TestUpdateRowDeletionPolicy(t *testing.T) {
+	tc := []struct {
+		name           string
+		policy         ddl.RowDeletionPolicy
+		statusCode     int
+		expectedPolicy ddl.RowDeletionPolicy
+	}{
+		{
+			name:           "Set policy",
+			policy:         ddl.RowDeletionPolicy{ColId: "c2", Days: 30},
+			statusCode:     http.StatusOK,
+			expectedPolicy: ddl.RowDeletionPolicy{ColId: "c2", Days: 30},
+		},
+		{
+			name:           "Remove policy",
+			policy:         ddl.RowDeletionPolicy{Days: 30},
+			statusCode:     http.StatusOK,
+			expectedPolicy: ddl.RowDeletionPolicy{},
+		},
+		{
+			name:           "Column is not a timestamp",
+			policy:         ddl.RowDeletionPolicy{ColId: "c1", Days: 30},
+			statusCode:     http.StatusBadRequest,
+			expectedPolicy: ddl.RowDeletionPolicy{ColId: "c2", Days: 7},
+		},
+		{
+			name:           "Unknown column",
+			policy:         ddl.RowDeletionPolicy{ColId: "c3", Days: 30},
+			statusCode:     http.StatusBadRequest,
+			expectedPolicy: ddl.RowDeletionPolicy{ColId: "c2", Days: 7},
+		},
+	}
+	for _, tc := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		sessionState.Conv = internal.MakeConv()
+		sessionState.Conv.SpSchema = map[string]ddl.CreateTable{
+			"t1": {
+				Name:   "table1",
+				Id:     "t1",
+				ColIds: []string{"c1", "c2"},
+				ColDefs: map[string]ddl.ColumnDef{
+					"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+					"c2": {Name: "created", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+				},
+				PrimaryKeys:       []ddl.IndexKey{{ColId: "c1", Order: 1}},
+				RowDeletionPolicy: ddl.RowDeletionPolicy{ColId: "c2", Days: 7},
+			},
+		}
+
+		body, err := json.Marshal(tc.policy)
+		assert.NoError(t, err)
+		req, err := http.NewRequest("POST", "/update/rowDeletionPolicy?table=t1", bytes.NewBuffer(body))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.UpdateRowDeletionPolicy)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, tc.statusCode, rr.Code, tc.name)
+		assert.Equal(t, tc.expectedPolicy, sessionState.Conv.SpSchema["t1"].RowDeletionPolicy, tc.name)
+	}
+}
+
+func TestUpdateTimezonePolicy(t *testing.T) {
+	paris := internal.TimezonePolicy{Mode: internal.TimezoneZone, Location: "Europe/Paris"}
+	tc := []struct {
+		name             string
+		policy           types.TimezonePolicy
+		statusCode       int
+		expectedPolicies map[string]internal.TableTimezones
+		expectedType     ddl.Type
+	}{
+		{
+			name:             "Set column policy",
+			policy:           types.TimezonePolicy{ColId: "c2", Mode: internal.TimezoneZone, Location: "Europe/Paris"},
+			statusCode:       http.StatusOK,
+			expectedPolicies: map[string]internal.TableTimezones{"t1": {Columns: map[string]internal.TimezonePolicy{"c2": paris}}},
+			expectedType:     ddl.Type{Name: ddl.Timestamp},
+		},
+		{
+			name:             "Set table policy",
+			policy:           types.TimezonePolicy{Mode: internal.TimezoneString},
+			statusCode:       http.StatusOK,
+			expectedPolicies: map[string]internal.TableTimezones{"t1": {Default: internal.TimezonePolicy{Mode: internal.TimezoneString}}},
+			expectedType:     ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
+		},
+		{
+			name:             "Unknown location",
+			policy:           types.TimezonePolicy{ColId: "c2", Mode: internal.TimezoneZone, Location: "Mars/Olympus_Mons"},
+			statusCode:       http.StatusBadRequest,
+			expectedPolicies: map[string]internal.TableTimezones{},
+			expectedType:     ddl.Type{Name: ddl.Timestamp},
+		},
+		{
+			name:             "Column is not a timestamp",
+			policy:           types.TimezonePolicy{ColId: "c1", Mode: internal.TimezoneUTC},
+			statusCode:       http.StatusBadRequest,
+			expectedPolicies: map[string]internal.TableTimezones{},
+			expectedType:     ddl.Type{Name: ddl.Timestamp},
+		},
+	}
+	for _, tc := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		sessionState.Conv = internal.MakeConv()
+		sessionState.Conv.SrcSchema = map[string]schema.Table{
+			"t1": {
+				Name:   "table1",
+				Id:     "t1",
+				ColIds: []string{"c1", "c2"},
+				ColDefs: map[string]schema.Column{
+					"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+					"c2": {Name: "created", Id: "c2", Type: schema.Type{Name: "datetime"}},
+				},
+				PrimaryKeys: []schema.Key{{ColId: "c1"}},
+			},
+		}
+		sessionState.Conv.SpSchema = map[string]ddl.CreateTable{
+			"t1": {
+				Name:   "table1",
+				Id:     "t1",
+				ColIds: []string{"c1", "c2"},
+				ColDefs: map[string]ddl.ColumnDef{
+					"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+					"c2": {Name: "created", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+				},
+				PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			},
+		}
+
+		body, err := json.Marshal(tc.policy)
+		assert.NoError(t, err)
+		req, err := http.NewRequest("POST", "/update/timezonePolicy?table=t1", bytes.NewBuffer(body))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.UpdateTimezonePolicy)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, tc.statusCode, rr.Code, tc.name)
+		assert.Equal(t, tc.expectedPolicies, sessionState.Conv.TimezonePolicies, tc.name)
+		assert.Equal(t, tc.expectedType, sessionState.Conv.SpSchema["t1"].ColDefs["c2"].T, tc.name)
+	}
+}
+
 type errReader struct{}
 
 func (errReader) Read(p []byte) (n int, err error) {
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/utilities"
+)
+
+var (
+	searchIndexTokenizers    = []string{"TOKENIZE_FULLTEXT", "TOKENIZE_SUBSTRING", "TOKENIZE_NGRAMS", "TOKENIZE_NUMBER", "TOKENIZE_BOOL", "TOKENIZE_JSON", "TOKEN"}
+	vectorIndexDistanceTypes = []string{"COSINE", "EUCLIDEAN", "DOT_PRODUCT"}
+)
+
+// AddSearchIndex adds a search index to a table of the Spanner schema. Each
+// key of the index gets a hidden TOKENLIST column in the table.
+func AddSearchIndex(w http.ResponseWriter, r *http.Request) {
+	logger.Log.Info(fmt.Sprint("request started", "method", r.Method, "path", r.URL.Path))
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("request's body Read Error"))
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	si := ddl.CreateSearchIndex{}
+	if err = json.Unmarshal(reqBody, &si); err != nil {
+		logger.Log.Info(fmt.Sprint("request's Body parse error"))
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	sp, ok := sessionState.Conv.SpSchema[si.TableId]
+	if !ok {
+		http.Error(w, "Table doesn't exist", http.StatusBadRequest)
+		return
+	}
+	if err := checkNewIndexName(si.Name); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(si.Keys) == 0 {
+		http.Error(w, "Search index has no columns", http.StatusBadRequest)
+		return
+	}
+	colIds := append([]string{}, si.StoredColumnIds...)
+	colIds = append(colIds, si.PartitionColIds...)
+	for _, k := range si.OrderBy {
+		colIds = append(colIds, k.ColId)
+	}
+	for i, k := range si.Keys {
+		colIds = append(colIds, k.ColId)
+		si.Keys[i].Tokenizer = strings.ToUpper(k.Tokenizer)
+		if !internal.Contains(searchIndexTokenizers, si.Keys[i].Tokenizer) {
+			http.Error(w, fmt.Sprintf("Tokenizer is not valid: %v", k.Tokenizer), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := checkIndexColumns(sp, colIds); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for i, k := range si.Keys {
+		si.Keys[i].TokenColumn = k.TokenColumnName(sp)
+		if ok, _ := utilities.CheckSpannerNamesValidity([]string{si.Keys[i].TokenColumn}); !ok {
+			http.Error(w, fmt.Sprintf("Token column Name is not valid: %v", si.Keys[i].TokenColumn), http.StatusBadRequest)
+			return
+		}
+		if isTableColumnName(sp, si.Keys[i].TokenColumn) || tokenColumnRepeated(si.Keys[:i], si.Keys[i].TokenColumn) {
+			http.Error(w, fmt.Sprintf("Token column %v is already used in table %v", si.Keys[i].TokenColumn, sp.Name), http.StatusBadRequest)
+			return
+		}
+	}
+	if si.InterleaveIn != "" && !sessionState.Conv.SpSchema.IsAncestor(si.TableId, si.InterleaveIn) {
+		http.Error(w, "Search index can only be interleaved in an ancestor of its table", http.StatusBadRequest)
+		return
+	}
+
+	si.Id = internal.GenerateIndexesId()
+	sessionState.Conv.UsedNames[strings.ToLower(si.Name)] = true
+	sp.SearchIndexes = append(sp.SearchIndexes, si)
+	sessionState.Conv.SpSchema[si.TableId] = sp
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// AddVectorIndex adds a vector index to a table of the Spanner schema. The
+// column of the index must be an ARRAY<FLOAT32> or ARRAY<FLOAT64>; its
+// vector_length is set from VectorLength if the column doesn't have one.
+func AddVectorIndex(w http.ResponseWriter, r *http.Request) {
+	logger.Log.Info(fmt.Sprint("request started", "method", r.Method, "path", r.URL.Path))
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("request's body Read Error"))
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	var details struct {
+		ddl.CreateVectorIndex
+		VectorLength int64
+	}
+	if err = json.Unmarshal(reqBody, &details); err != nil {
+		logger.Log.Info(fmt.Sprint("request's Body parse error"))
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+	vi := details.CreateVectorIndex
+
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	sp, ok := sessionState.Conv.SpSchema[vi.TableId]
+	if !ok {
+		http.Error(w, "Table doesn't exist", http.StatusBadRequest)
+		return
+	}
+	if err := checkNewIndexName(vi.Name); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkIndexColumns(sp, append([]string{vi.ColId}, vi.StoredColumnIds...)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vi.DistanceType = strings.ToUpper(vi.DistanceType)
+	if !internal.Contains(vectorIndexDistanceTypes, vi.DistanceType) {
+		http.Error(w, fmt.Sprintf("Distance type is not valid: %v", vi.DistanceType), http.StatusBadRequest)
+		return
+	}
+	if vi.TreeDepth < 0 || vi.NumLeaves < 0 || vi.NumBranches < 0 {
+		http.Error(w, "Vector index options can't be negative", http.StatusBadRequest)
+		return
+	}
+	colDef := sp.ColDefs[vi.ColId]
+	if !colDef.T.IsArray || (colDef.T.Name != ddl.Float32 && colDef.T.Name != ddl.Float64) {
+		http.Error(w, fmt.Sprintf("Column %v must be an array of FLOAT32 or FLOAT64", colDef.Name), http.StatusBadRequest)
+		return
+	}
+	if colDef.Opts["vector_length"] == "" {
+		if details.VectorLength <= 0 {
+			http.Error(w, fmt.Sprintf("Vector length of column %v is missing", colDef.Name), http.StatusBadRequest)
+			return
+		}
+		if colDef.Opts == nil {
+			colDef.Opts = make(map[string]string)
+		}
+		colDef.Opts["vector_length"] = strconv.FormatInt(details.VectorLength, 10)
+		sp.ColDefs[vi.ColId] = colDef
+	}
+
+	vi.Id = internal.GenerateIndexesId()
+	sessionState.Conv.UsedNames[strings.ToLower(vi.Name)] = true
+	sp.VectorIndexes = append(sp.VectorIndexes, vi)
+	sessionState.Conv.SpSchema[vi.TableId] = sp
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// DropSearchOrVectorIndex removes a search index or a vector index from a
+// table of the Spanner schema.
+func DropSearchOrVectorIndex(w http.ResponseWriter, r *http.Request) {
+	tableId := r.FormValue("table")
+	indexId := r.FormValue("index")
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, "Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner.", http.StatusNotFound)
+		return
+	}
+	sp, ok := sessionState.Conv.SpSchema[tableId]
+	if !ok {
+		http.Error(w, "Table doesn't exist", http.StatusBadRequest)
+		return
+	}
+	found := false
+	for i, si := range sp.SearchIndexes {
+		if si.Id == indexId {
+			delete(sessionState.Conv.UsedNames, strings.ToLower(si.Name))
+			sp.SearchIndexes = append(sp.SearchIndexes[:i], sp.SearchIndexes[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if !found {
+		for i, vi := range sp.VectorIndexes {
+			if vi.Id == indexId {
+				delete(sessionState.Conv.UsedNames, strings.ToLower(vi.Name))
+				sp.VectorIndexes = append(sp.VectorIndexes[:i], sp.VectorIndexes[i+1:]...)
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		http.Error(w, "Index doesn't exist", http.StatusBadRequest)
+		return
+	}
+	sessionState.Conv.SpSchema[tableId] = sp
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// checkNewIndexName checks the name of a new index for Spanner name validity,
+// and that it isn't already used by another entity.
+func checkNewIndexName(name string) error {
+	if ok, invalidNames := utilities.CheckSpannerNamesValidity([]string{name}); !ok {
+		return fmt.Errorf("following names are not valid Spanner identifiers: %s", strings.Join(invalidNames, ","))
+	}
+	_, err := utilities.CanRename([]string{name}, "")
+	return err
+}
+
+// checkIndexColumns checks that the columns are columns of the table.
+func checkIndexColumns(sp ddl.CreateTable, colIds []string) error {
+	for _, colId := range colIds {
+		if _, ok := sp.ColDefs[colId]; !ok {
+			return fmt.Errorf("column %v doesn't exist in table %v", colId, sp.Name)
+		}
+	}
+	return nil
+}
+
+func tokenColumnRepeated(keys []ddl.SearchIndexKey, name string) bool {
+	for _, k := range keys {
+		if strings.EqualFold(k.TokenColumn, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTableColumnName reports whether name is the name of a column of the
+// table, including the TOKENLIST columns of its search indexes.
+func isTableColumnName(sp ddl.CreateTable, name string) bool {
+	for _, colDef := range sp.ColDefs {
+		if strings.EqualFold(colDef.Name, name) {
+			return true
+		}
+	}
+	for _, si := range sp.SearchIndexes {
+		for _, k := range si.Keys {
+			if strings.EqualFold(k.TokenColumnName(sp), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
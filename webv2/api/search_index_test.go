@@ -0,0 +1,185 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSearchIndexTestConv() *internal.Conv {
+	return &internal.Conv{
+		UsedNames: map[string]bool{"singers": true, "albums": true},
+		SpSchema: ddl.Schema{
+			"t1": {Name: "singers", Id: "t1"},
+			"t2": {
+				Name:   "albums",
+				Id:     "t2",
+				ColIds: []string{"c1", "c2", "c3", "c4"},
+				ColDefs: map[string]ddl.ColumnDef{
+					"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+					"c2": {Name: "title", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+					"c3": {Name: "embedding", Id: "c3", T: ddl.Type{Name: ddl.Float32, IsArray: true}},
+					"c4": {Name: "title_Tokens", Id: "c4", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				},
+				ParentTable: ddl.InterleavedParent{Id: "t1"},
+			},
+		},
+	}
+}
+
+func TestAddSearchIndex(t *testing.T) {
+	tc := []struct {
+		name       string
+		input      ddl.CreateSearchIndex
+		statusCode int64
+		expected   []ddl.SearchIndexKey
+	}{
+		{
+			name:       "Interleaved search index",
+			input:      ddl.CreateSearchIndex{Name: "albums_by_title", TableId: "t2", Keys: []ddl.SearchIndexKey{{ColId: "c2", Tokenizer: "tokenize_fulltext", TokenColumn: "title_Fulltext"}}, InterleaveIn: "t1"},
+			statusCode: http.StatusOK,
+			expected:   []ddl.SearchIndexKey{{ColId: "c2", Tokenizer: "TOKENIZE_FULLTEXT", TokenColumn: "title_Fulltext"}},
+		},
+		{
+			name:       "Token column clashes with a column",
+			input:      ddl.CreateSearchIndex{Name: "albums_by_title", TableId: "t2", Keys: []ddl.SearchIndexKey{{ColId: "c2", Tokenizer: "TOKENIZE_FULLTEXT"}}},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid tokenizer",
+			input:      ddl.CreateSearchIndex{Name: "albums_by_title", TableId: "t2", Keys: []ddl.SearchIndexKey{{ColId: "c2", Tokenizer: "SPLIT", TokenColumn: "title_Split"}}},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Not interleaved in an ancestor",
+			input:      ddl.CreateSearchIndex{Name: "albums_by_title", TableId: "t2", Keys: []ddl.SearchIndexKey{{ColId: "c2", Tokenizer: "TOKENIZE_FULLTEXT", TokenColumn: "title_Fulltext"}}, InterleaveIn: "t2"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "No keys",
+			input:      ddl.CreateSearchIndex{Name: "albums_by_title", TableId: "t2"},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		sessionState.Conv = newSearchIndexTestConv()
+
+		inputBytes, err := json.Marshal(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := http.NewRequest("POST", "/AddSearchIndex", bytes.NewBuffer(inputBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.AddSearchIndex)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.statusCode, int64(rr.Code), tt.name)
+		if rr.Code == http.StatusOK {
+			var res *internal.Conv
+			json.Unmarshal(rr.Body.Bytes(), &res)
+			assert.Equal(t, 1, len(res.SpSchema["t2"].SearchIndexes), tt.name)
+			assert.Equal(t, tt.expected, res.SpSchema["t2"].SearchIndexes[0].Keys, tt.name)
+			assert.Equal(t, "t1", res.SpSchema["t2"].SearchIndexes[0].InterleaveIn, tt.name)
+			assert.True(t, sessionState.Conv.UsedNames["albums_by_title"], tt.name)
+		}
+	}
+}
+
+func TestAddVectorIndex(t *testing.T) {
+	tc := []struct {
+		name         string
+		input        string
+		statusCode   int64
+		vectorLength string
+	}{
+		{
+			name:         "Vector index",
+			input:        `{"Name": "albums_by_embedding", "TableId": "t2", "ColId": "c3", "DistanceType": "cosine", "NumLeaves": 100, "VectorLength": 128}`,
+			statusCode:   http.StatusOK,
+			vectorLength: "128",
+		},
+		{
+			name:       "Missing vector length",
+			input:      `{"Name": "albums_by_embedding", "TableId": "t2", "ColId": "c3", "DistanceType": "COSINE"}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Not an array column",
+			input:      `{"Name": "albums_by_embedding", "TableId": "t2", "ColId": "c2", "DistanceType": "COSINE", "VectorLength": 128}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid distance type",
+			input:      `{"Name": "albums_by_embedding", "TableId": "t2", "ColId": "c3", "DistanceType": "MANHATTAN", "VectorLength": 128}`,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		sessionState.Conv = newSearchIndexTestConv()
+
+		req, err := http.NewRequest("POST", "/AddVectorIndex", strings.NewReader(tt.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.AddVectorIndex)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.statusCode, int64(rr.Code), tt.name)
+		if rr.Code == http.StatusOK {
+			var res *internal.Conv
+			json.Unmarshal(rr.Body.Bytes(), &res)
+			assert.Equal(t, 1, len(res.SpSchema["t2"].VectorIndexes), tt.name)
+			vi := res.SpSchema["t2"].VectorIndexes[0]
+			assert.Equal(t, "COSINE", vi.DistanceType, tt.name)
+			assert.Equal(t, int64(100), vi.NumLeaves, tt.name)
+			assert.Equal(t, tt.vectorLength, res.SpSchema["t2"].ColDefs["c3"].Opts["vector_length"], tt.name)
+		}
+	}
+}
+
+func TestDropSearchOrVectorIndex(t *testing.T) {
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = newSearchIndexTestConv()
+	sessionState.Conv.UsedNames["albums_by_title"] = true
+	sessionState.Conv.UsedNames["albums_by_embedding"] = true
+	albums := sessionState.Conv.SpSchema["t2"]
+	albums.SearchIndexes = []ddl.CreateSearchIndex{{Name: "albums_by_title", Id: "i1", TableId: "t2", Keys: []ddl.SearchIndexKey{{ColId: "c2", Tokenizer: "TOKENIZE_FULLTEXT"}}}}
+	albums.VectorIndexes = []ddl.CreateVectorIndex{{Name: "albums_by_embedding", Id: "i2", TableId: "t2", ColId: "c3", DistanceType: "COSINE"}}
+	sessionState.Conv.SpSchema["t2"] = albums
+
+	for _, indexId := range []string{"i1", "i2"} {
+		req, err := http.NewRequest("POST", "drop/searchOrVectorIndex?table=t2&index="+indexId, strings.NewReader(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.DropSearchOrVectorIndex)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+
+	assert.Empty(t, sessionState.Conv.SpSchema["t2"].SearchIndexes)
+	assert.Empty(t, sessionState.Conv.SpSchema["t2"].VectorIndexes)
+	assert.Equal(t, map[string]bool{"singers": true, "albums": true}, sessionState.Conv.UsedNames)
+}
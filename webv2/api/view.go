@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/utilities"
+)
+
+// UpdateView replaces the name, query and SQL security of a Spanner view
+// with the ones reviewed in the UI. The issues of the view are cleared,
+// since its query was reviewed.
+func UpdateView(w http.ResponseWriter, r *http.Request) {
+	logger.Log.Info(fmt.Sprint("request started", "method", r.Method, "path", r.URL.Path))
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("request's body Read Error"))
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	newView := ddl.CreateView{}
+	err = json.Unmarshal(reqBody, &newView)
+	if err != nil {
+		logger.Log.Info(fmt.Sprint("request's Body parse error"))
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	view, ok := sessionState.Conv.SpViews[newView.Id]
+	if !ok {
+		http.Error(w, "View doesn't exist", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(newView.Query) == "" {
+		http.Error(w, "View query is empty", http.StatusBadRequest)
+		return
+	}
+	if newView.SqlSecurity != "" && newView.SqlSecurity != "INVOKER" && newView.SqlSecurity != "DEFINER" {
+		http.Error(w, fmt.Sprintf("SQL security is not valid: %v", newView.SqlSecurity), http.StatusBadRequest)
+		return
+	}
+	if !strings.EqualFold(newView.Name, view.Name) {
+		if ok, _ := utilities.CheckSpannerNamesValidity([]string{newView.Name}); !ok {
+			http.Error(w, fmt.Sprintf("View Name is not valid: %v", newView.Name), http.StatusBadRequest)
+			return
+		}
+		// Check that the new name is not already used by existing tables, secondary indexes, sequences, views or foreign key constraints.
+		if ok, err := utilities.CanRename([]string{newView.Name}, ""); !ok {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		delete(sessionState.Conv.UsedNames, strings.ToLower(view.Name))
+		sessionState.Conv.UsedNames[strings.ToLower(newView.Name)] = true
+	}
+	view.Name = newView.Name
+	view.Query = newView.Query
+	view.SqlSecurity = newView.SqlSecurity
+	sessionState.Conv.SpViews[view.Id] = view
+	delete(sessionState.Conv.ViewIssues, view.Id)
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// DropView removes a view from the Spanner schema.
+func DropView(w http.ResponseWriter, r *http.Request) {
+	viewId := r.FormValue("view")
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, "Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner.", http.StatusNotFound)
+		return
+	}
+	view, ok := sessionState.Conv.SpViews[viewId]
+	if !ok {
+		http.Error(w, "View doesn't exist", http.StatusBadRequest)
+		return
+	}
+
+	delete(sessionState.Conv.UsedNames, strings.ToLower(view.Name))
+	delete(sessionState.Conv.SpViews, viewId)
+	delete(sessionState.Conv.ViewIssues, viewId)
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// GetViewDDL returns the CREATE VIEW statement of each Spanner view.
+func GetViewDDL(w http.ResponseWriter, r *http.Request) {
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.RLock()
+	defer sessionState.Conv.ConvLock.RUnlock()
+	conv := sessionState.Conv
+
+	viewDDL := make(map[string]string)
+	for viewId, view := range conv.SpViews {
+		viewDDL[viewId] = view.PrintCreateView(ddl.Config{ProtectIds: false, SpDialect: conv.SpDialect})
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(viewDDL)
+}
@@ -0,0 +1,140 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func newViewTestConv() *internal.Conv {
+	return &internal.Conv{
+		UsedNames: map[string]bool{"users": true, "user_names": true},
+		SpViews: map[string]ddl.CreateView{
+			"v1": {Name: "user_names", Id: "v1", SqlSecurity: "INVOKER", Query: "SELECT name::text FROM users"},
+		},
+		ViewIssues: map[string][]string{
+			"v1": {"casts with :: aren't supported, use CAST"},
+		},
+	}
+}
+
+func TestUpdateView(t *testing.T) {
+	tc := []struct {
+		name           string
+		input          ddl.CreateView
+		statusCode     int64
+		expectedView   ddl.CreateView
+		expectedIssues map[string][]string
+	}{
+		{
+			name:           "Fix query and rename",
+			input:          ddl.CreateView{Id: "v1", Name: "names", SqlSecurity: "DEFINER", Query: "SELECT CAST(name AS STRING) FROM users"},
+			statusCode:     http.StatusOK,
+			expectedView:   ddl.CreateView{Id: "v1", Name: "names", SqlSecurity: "DEFINER", Query: "SELECT CAST(name AS STRING) FROM users"},
+			expectedIssues: map[string][]string{},
+		},
+		{
+			name:       "Name used by a table",
+			input:      ddl.CreateView{Id: "v1", Name: "users", SqlSecurity: "INVOKER", Query: "SELECT name FROM users"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid SQL security",
+			input:      ddl.CreateView{Id: "v1", Name: "user_names", SqlSecurity: "OWNER", Query: "SELECT name FROM users"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Empty query",
+			input:      ddl.CreateView{Id: "v1", Name: "user_names", SqlSecurity: "INVOKER", Query: " "},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Unknown view",
+			input:      ddl.CreateView{Id: "v2", Name: "user_names", SqlSecurity: "INVOKER", Query: "SELECT name FROM users"},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		sessionState.Conv = newViewTestConv()
+
+		inputBytes, err := json.Marshal(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := http.NewRequest("POST", "/UpdateView", bytes.NewBuffer(inputBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.UpdateView)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.statusCode, int64(rr.Code), tt.name)
+		if rr.Code == http.StatusOK {
+			var res *internal.Conv
+			json.Unmarshal(rr.Body.Bytes(), &res)
+			assert.Equal(t, tt.expectedView, res.SpViews["v1"], tt.name)
+			assert.Equal(t, tt.expectedIssues, res.ViewIssues, tt.name)
+			assert.True(t, sessionState.Conv.UsedNames["names"], tt.name)
+			assert.False(t, sessionState.Conv.UsedNames["user_names"], tt.name)
+		}
+	}
+}
+
+func TestDropView(t *testing.T) {
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = newViewTestConv()
+
+	req, err := http.NewRequest("POST", "drop/view?view=v1", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(api.DropView)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; int64(status) != http.StatusOK {
+		t.Errorf("test drop view : handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	res := &internal.Conv{}
+	json.Unmarshal(rr.Body.Bytes(), &res)
+	assert.Empty(t, res.SpViews)
+	assert.Empty(t, res.ViewIssues)
+	assert.Equal(t, map[string]bool{"users": true}, sessionState.Conv.UsedNames)
+}
+
+func TestGetViewDDL(t *testing.T) {
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = newViewTestConv()
+	sessionState.Conv.SpDialect = constants.DIALECT_GOOGLESQL
+
+	req, err := http.NewRequest("GET", "/viewDdl", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(api.GetViewDDL)
+	handler.ServeHTTP(rr, req)
+
+	var res map[string]string
+	json.Unmarshal(rr.Body.Bytes(), &res)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, map[string]string{"v1": "CREATE VIEW user_names SQL SECURITY INVOKER AS SELECT name::text FROM users"}, res)
+}
@@ -59,6 +59,8 @@ func getRoutes() *mux.Router {
 	router.HandleFunc("/convert/session", loadSession).Methods("POST")
 	router.HandleFunc("/ddl", api.GetDDL).Methods("GET")
 	router.HandleFunc("/seqDdl", api.GetSequenceDDL).Methods("GET")
+	router.HandleFunc("/viewDdl", api.GetViewDDL).Methods("GET")
+	router.HandleFunc("/changeStreamDdl", api.GetChangeStreamDDL).Methods("GET")
 	router.HandleFunc("/conversion", api.GetConversionRate).Methods("GET")
 	router.HandleFunc("/typemap", api.GetTypeMap).Methods("GET")
 	router.HandleFunc("/report", reportAPIHandler.GetReportFile).Methods("GET")
@@ -92,8 +94,17 @@ func getRoutes() *mux.Router {
 	router.HandleFunc("/drop/sequence", api.DropSequence).Methods("POST")
 	router.HandleFunc("/UpdateSequence", api.UpdateSequence).Methods("POST")
 
+	router.HandleFunc("/drop/view", api.DropView).Methods("POST")
+	router.HandleFunc("/UpdateView", api.UpdateView).Methods("POST")
+
+	router.HandleFunc("/drop/changeStream", api.DropChangeStream).Methods("POST")
+	router.HandleFunc("/UpdateChangeStream", api.UpdateChangeStream).Methods("POST")
+	router.HandleFunc("/drop/searchOrVectorIndex", api.DropSearchOrVectorIndex).Methods("POST")
+
 	router.HandleFunc("/update/fks", api.UpdateForeignKeys).Methods("POST")
 	router.HandleFunc("/update/cc", api.UpdateCheckConstraint).Methods("POST")
+	router.HandleFunc("/update/rowDeletionPolicy", api.UpdateRowDeletionPolicy).Methods("POST")
+	router.HandleFunc("/update/timezonePolicy", api.UpdateTimezonePolicy).Methods("POST")
 	router.HandleFunc("/update/indexes", api.UpdateIndexes).Methods("POST")
 
 	// Session Management
@@ -108,6 +119,9 @@ func getRoutes() *mux.Router {
 
 	router.HandleFunc("/AddColumn", table.AddNewColumn).Methods("POST")
 	router.HandleFunc("/AddSequence", api.AddNewSequence).Methods("POST")
+	router.HandleFunc("/AddChangeStream", api.AddChangeStream).Methods("POST")
+	router.HandleFunc("/AddSearchIndex", api.AddSearchIndex).Methods("POST")
+	router.HandleFunc("/AddVectorIndex", api.AddVectorIndex).Methods("POST")
 
 	// Summary
 	router.HandleFunc("/summary", summary.GetSummary).Methods("GET")
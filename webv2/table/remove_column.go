@@ -15,178 +15,10 @@
 package table
 
 import (
-	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
-	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
-	utilities "github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/utilities"
 )
 
 // removeColumn remove given column from schema.
 func RemoveColumn(tableId string, colId string, conv *internal.Conv) {
-	// remove foreign keys from refer tables.
-	for id, sp := range conv.SpSchema {
-		var updatedFks []ddl.Foreignkey
-		for j := 0; j < len(sp.ForeignKeys); j++ {
-			if sp.ForeignKeys[j].ReferTableId == tableId {
-				fkColPosition := getFkColumnPosition(sp.ForeignKeys[j].ReferColumnIds, colId)
-				if fkColPosition == -1 {
-					updatedFks = append(updatedFks, sp.ForeignKeys[j])
-				} else {
-					delete(conv.UsedNames, sp.ForeignKeys[j].Name)
-				}
-			} else {
-				updatedFks = append(updatedFks, sp.ForeignKeys[j])
-			}
-		}
-
-		sp.ForeignKeys = updatedFks
-		conv.SpSchema[id] = sp
-	}
-
-	//remove column from the table.
-	removeColumnFromTableSchema(conv, tableId, colId)
-
-}
-
-// removeColumnFromCurrentTableSchema remove given column from table schema.
-func removeColumnFromTableSchema(conv *internal.Conv, tableId string, colId string) {
-	sp := conv.SpSchema[tableId]
-
-	spSeq := removeColumnFromSequence(conv.SpSequences, colId, tableId, sp.ColDefs[colId].AutoGen)
-
-	sp = removeColumnFromSpannerColDefs(sp, colId)
-
-	sp = removeColumnFromSpannerPK(sp, colId)
-
-	sp = removeColumnFromSpannerSecondaryIndex(sp, colId)
-
-	sp = removeColumnFromSpannerForeignkeyColumns(sp, colId)
-
-	sp = removeColumnFromSpannerForeignkeyReferColumns(sp, colId)
-
-	sp = removeColumnFromSpannerColNames(sp, colId)
-
-	removeSpannerSchemaIssue(tableId, colId, conv)
-
-	conv.SpSchema[tableId] = sp
-	conv.SpSequences = spSeq
-
-	// Note: ToSpanner mapping is not updated for removed columns
-	// since they don't have corresponding source columns
-}
-
-// removeColumnFromSpannerColNames remove given column from ColNames.
-func removeColumnFromSpannerColNames(sp ddl.CreateTable, colId string) ddl.CreateTable {
-
-	for i, col := range sp.ColIds {
-		if col == colId {
-			sp.ColIds = utilities.Remove(sp.ColIds, i)
-			break
-		}
-	}
-	delete(sp.ColDefs, colId)
-	return sp
-}
-
-// removeColumnFromSpannerPK remove given column from Primary Key List.
-func removeColumnFromSpannerPK(sp ddl.CreateTable, colId string) ddl.CreateTable {
-
-	for i, pk := range sp.PrimaryKeys {
-		if pk.ColId == colId {
-			sp.PrimaryKeys = utilities.RemovePk(sp.PrimaryKeys, i)
-			break
-		}
-	}
-	return sp
-}
-
-// removeColumnFromSpannerColDefs remove given column from Spanner ColDefs List.
-func removeColumnFromSpannerColDefs(sp ddl.CreateTable, colId string) ddl.CreateTable {
-	delete(sp.ColDefs, colId)
-	return sp
-}
-
-// removeColumnFromSpannerSecondaryIndex remove given column from Spanner SecondaryIndex List.
-func removeColumnFromSpannerSecondaryIndex(sp ddl.CreateTable, colId string) ddl.CreateTable {
-
-	for i, index := range sp.Indexes {
-		for j, key := range index.Keys {
-			if key.ColId == colId {
-				sp.Indexes[i].Keys = utilities.RemoveColumnFromSecondaryIndexKey(sp.Indexes[i].Keys, j)
-				break
-			}
-		}
-	}
-	return sp
-}
-
-// removeColumnFromSecondaryIndexKey remove given column from Spanner Secondary Schema Issue List.
-func removeSpannerSchemaIssue(tableId string, colId string, conv *internal.Conv) {
-	if conv.SchemaIssues != nil {
-		if conv.SchemaIssues[tableId].ColumnLevelIssues != nil && conv.SchemaIssues[tableId].ColumnLevelIssues[colId] != nil {
-			delete(conv.SchemaIssues[tableId].ColumnLevelIssues, colId)
-		}
-	}
-}
-
-// removeColumnFromSpannerForeignkeyColumns remove given column from Spanner Foreignkey Columns List.
-func removeColumnFromSpannerForeignkeyColumns(sp ddl.CreateTable, colId string) ddl.CreateTable {
-
-	for i, fk := range sp.ForeignKeys {
-		j := 0
-		for _, id := range fk.ColIds {
-			if id == colId {
-				sp.ForeignKeys[i].ColIds = utilities.RemoveFkColumn(fk.ColIds, j)
-			} else {
-				j = j + 1
-			}
-		}
-	}
-
-	// drop foreing key if the foreign key doesn't have any column left after the update.
-	i := 0
-	for _, fk := range sp.ForeignKeys {
-		if len(fk.ColIds) <= 0 {
-			sp.ForeignKeys = append(sp.ForeignKeys[:i], sp.ForeignKeys[i+1:]...)
-		}
-	}
-	return sp
-}
-
-// removeColumnFromSpannerForeignkeyReferColumns remove given column from Spanner Foreignkey Refer Columns List.
-func removeColumnFromSpannerForeignkeyReferColumns(sp ddl.CreateTable, colId string) ddl.CreateTable {
-
-	for i, fk := range sp.ForeignKeys {
-		j := 0
-		for _, id := range fk.ReferColumnIds {
-			if id == colId {
-				sp.ForeignKeys[i].ReferColumnIds = utilities.RemoveFkReferColumns(sp.ForeignKeys[i].ReferColumnIds, j)
-			} else {
-				j = j + 1
-			}
-		}
-	}
-	// drop foreign key if the foreign key doesn't have any refer-column left after the update.
-	i := 0
-	for _, fk := range sp.ForeignKeys {
-		if len(fk.ReferColumnIds) <= 0 {
-			sp.ForeignKeys = append(sp.ForeignKeys[:i], sp.ForeignKeys[i+1:]...)
-		}
-	}
-	return sp
-}
-
-func removeColumnFromSequence(spSeq map[string]ddl.Sequence, colId string, tableId string, autoGen ddl.AutoGenCol) map[string]ddl.Sequence {
-	if autoGen.GenerationType == constants.SEQUENCE {
-		seqName := getSequenceId(autoGen.Name, spSeq)
-		columns := spSeq[seqName].ColumnsUsingSeq[tableId]
-		for i, col := range columns {
-			if col == colId {
-				columns = append(columns[:i], columns[i+1:]...)
-				break
-			}
-		}
-		spSeq[seqName].ColumnsUsingSeq[tableId] = columns
-	}
-	return spSeq
+	conv.DropSpannerColumn(tableId, colId)
 }
@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRemoveColumnTestConv returns a conv with the tables users (t1), which
+// has two indexes, and orders (t2), which has a foreign key on two columns
+// referring to users.
+func newRemoveColumnTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name: "users", Id: "t1", ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c3": {Name: "email", Id: "c3", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			Indexes: []ddl.CreateIndex{
+				{Name: "users_by_name", Id: "i1", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}}},
+				{Name: "users_by_name_email", Id: "i2", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}, {ColId: "c3", Order: 2}}},
+			},
+		},
+		"t2": {
+			Name: "orders", Id: "t2", ColIds: []string{"c4", "c5", "c6"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c4": {Name: "id", Id: "c4", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c5": {Name: "user_id", Id: "c5", T: ddl.Type{Name: ddl.Int64}},
+				"c6": {Name: "user_email", Id: "c6", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c4", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "orders_user", Id: "f1", ColIds: []string{"c5", "c6"}, ReferTableId: "t1", ReferColumnIds: []string{"c1", "c3"}}},
+		},
+	}
+	conv.UsedNames = internal.ComputeUsedNames(conv)
+	return conv
+}
+
+func TestRemoveColumn(t *testing.T) {
+	// Dropping one of the referred columns drops the whole foreign key, and
+	// the column is removed from the keys of the indexes.
+	conv := newRemoveColumnTestConv()
+	RemoveColumn("t1", "c3", conv)
+	assert.Equal(t, []string{"c1", "c2"}, conv.SpSchema["t1"].ColIds)
+	assert.Len(t, conv.SpSchema["t1"].Indexes, 2)
+	assert.Equal(t, []ddl.IndexKey{{ColId: "c2", Order: 1}}, conv.SpSchema["t1"].Indexes[1].Keys)
+	assert.Empty(t, conv.SpSchema["t2"].ForeignKeys)
+	assert.False(t, conv.UsedNames["orders_user"])
+
+	// Indexes left without keys are dropped.
+	RemoveColumn("t1", "c2", conv)
+	assert.Empty(t, conv.SpSchema["t1"].Indexes)
+	assert.False(t, conv.UsedNames["users_by_name"])
+	assert.False(t, conv.UsedNames["users_by_name_email"])
+	assert.True(t, conv.UsedNames["users"])
+
+	// Dropping one of the columns of the foreign key drops it too.
+	conv = newRemoveColumnTestConv()
+	RemoveColumn("t2", "c5", conv)
+	assert.Equal(t, []string{"c4", "c6"}, conv.SpSchema["t2"].ColIds)
+	assert.Empty(t, conv.SpSchema["t2"].ForeignKeys)
+	assert.False(t, conv.UsedNames["orders_user"])
+	assert.Len(t, conv.SpSchema["t1"].Indexes, 2)
+}
@@ -44,6 +44,7 @@ var SpannerToCassandra = map[string]string{
 	ddl.Numeric:   "decimal",
 	ddl.String:    "text",
 	ddl.Timestamp: "timestamp",
+	ddl.UUID:      "uuid",
 }
 
 // GetCassandraType returns default cassandra type for specified Spanner type
@@ -153,4 +153,12 @@ type TableInterleaveStatus struct {
 	OnDelete string
 	Comment  string
 	InterleaveType string
-}
\ No newline at end of file
+}
+
+// TimezonePolicy is the timezone policy of a column, or the default policy
+// of a table when ColId is empty, see internal.TimezonePolicy.
+type TimezonePolicy struct {
+	ColId    string `json:"ColId"`
+	Mode     string `json:"Mode"`
+	Location string `json:"Location"`
+}
@@ -353,10 +353,22 @@ func GetFilePrefix(now time.Time) (string, error) {
 }
 
 func UpdateDataType(conv *internal.Conv, newType, tableId, colId string) error {
+	var issues []internal.SchemaIssue
+	if conv.SchemaIssues != nil {
+		issues = conv.SchemaIssues[tableId].ColumnLevelIssues[colId]
+	}
 	sp, ty, err := GetType(conv, newType, tableId, colId)
 	if err != nil {
 		return err
 	}
+	// Spanner deletes the rows of a table by the TIMESTAMP column of its row
+	// deletion policy, so the column can't be retyped while the policy is set.
+	if sp.RowDeletionPolicy.ColId == colId && (ty.Name != ddl.Timestamp || ty.IsArray) {
+		if issues != nil {
+			conv.SchemaIssues[tableId].ColumnLevelIssues[colId] = issues
+		}
+		return fmt.Errorf("column %s is the column of the row deletion policy of table %s and must be a TIMESTAMP column, remove the policy before changing its type to %s", sp.ColDefs[colId].Name, sp.Name, ty.PrintColumnDefType(false))
+	}
 	colDef := sp.ColDefs[colId]
 	colDef.T = ty
 	if conv.Source == constants.CASSANDRA {
@@ -37,6 +37,7 @@ func TestUpdateDataType(t *testing.T) {
 		srcCol   schema.Column
 		spColDef ddl.ColumnDef
 		newType  string
+		ttl      ddl.RowDeletionPolicy
 		wantType ddl.Type
 		wantOpts map[string]string
 		wantErr  bool
@@ -59,8 +60,8 @@ func TestUpdateDataType(t *testing.T) {
 			source:   constants.CASSANDRA,
 			dialect:  constants.DIALECT_GOOGLESQL,
 			srcCol:   schema.Column{Name: "col1", Type: schema.Type{Name: "uuid"}},
-			spColDef: ddl.ColumnDef{Name: "col1", T: ddl.Type{Name: ddl.String, Len: 36}},
-			newType:  "TEXT",
+			spColDef: ddl.ColumnDef{Name: "col1", T: ddl.Type{Name: ddl.UUID}},
+			newType:  "STRING",
 			wantType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
 			wantOpts: map[string]string{"cassandra_type": "uuid"},
 			wantErr:  false,
@@ -76,6 +77,18 @@ func TestUpdateDataType(t *testing.T) {
 			wantErr:  true,
 			errText:  "driver : 'unsupported' is not supported",
 		},
+		{
+			name:     "Column of the row deletion policy",
+			driver:   constants.MYSQL,
+			source:   constants.MYSQL,
+			dialect:  constants.DIALECT_GOOGLESQL,
+			srcCol:   schema.Column{Name: "col1", Type: schema.Type{Name: "datetime"}},
+			spColDef: ddl.ColumnDef{Name: "col1", T: ddl.Type{Name: ddl.Timestamp}},
+			newType:  "STRING",
+			ttl:      ddl.RowDeletionPolicy{ColId: colId, Days: 30},
+			wantErr:  true,
+			errText:  "row deletion policy",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -84,7 +97,7 @@ func TestUpdateDataType(t *testing.T) {
 			sessionState.Driver = tc.driver
 
 			conv := &internal.Conv{
-				SpSchema:  map[string]ddl.CreateTable{tableId: {Id: tableId, Name: "t1", ColDefs: map[string]ddl.ColumnDef{colId: tc.spColDef}}},
+				SpSchema:  map[string]ddl.CreateTable{tableId: {Id: tableId, Name: "t1", ColDefs: map[string]ddl.ColumnDef{colId: tc.spColDef}, RowDeletionPolicy: tc.ttl}},
 				SrcSchema: map[string]schema.Table{tableId: {Id: tableId, Name: "t1", ColDefs: map[string]schema.Column{colId: tc.srcCol}, ColIds: []string{colId}}},
 				SchemaIssues: map[string]internal.TableIssues{
 					tableId: {ColumnLevelIssues: make(map[string][]internal.SchemaIssue)},
@@ -98,6 +111,7 @@ func TestUpdateDataType(t *testing.T) {
 			if tc.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tc.errText)
+				assert.Equal(t, tc.spColDef, conv.SpSchema[tableId].ColDefs[colId])
 			} else {
 				assert.NoError(t, err)
 				updatedColDef := conv.SpSchema[tableId].ColDefs[colId]